@@ -21,6 +21,18 @@ const (
 	DEFAULT_TENANT  = "5306526529902621696"
 	DEFAULT_ACCOUNT = "100016472"
 
+	// Authentication
+	// JWKS is either a file path or an URL (e.g. cognito .well-known/jwks.json).
+	// The services refuse to start when it is empty, unless AUTH_INSECURE is
+	// set; the insecure mode acts as DEFAULT_ACCOUNT and is meant for development.
+	AUTH_JWKS     = ""
+	AUTH_ISSUER   = ""
+	AUTH_AUDIENCE = ""
+	AUTH_INSECURE = true
+	// Bearer token the glad services (e.g. sfsyncd) send to coursed; coursed
+	// treats its callers as the service account. Disabled when it is empty.
+	AUTH_SERVICE_TOKEN = ""
+
 	// API port
 	API_PORT = 8080
 
@@ -29,6 +41,36 @@ const (
 
 	// SFSYNCD specific consts
 	COURSED_ADDR = "localhost:8080"
+	// Timeout in seconds and retries of the requests to coursed
+	COURSED_TIMEOUT_SEC = 30
+	COURSED_RETRIES     = 2
+
+	// Salesforce REST API used to export GLAD changes.
+	// Export is disabled when the instance url is empty.
+	SF_INSTANCE_URL = ""
+	SF_API_VERSION  = "60.0"
+	SF_ACCESS_TOKEN = ""
+
+	// Workers processing the asynchronous Salesforce import jobs
+	SF_IMPORT_WORKERS = 4
+
+	// Tenants of the Salesforce orgs sending Outbound Messages, as
+	// "<OrganizationId>:<tenant id>:<secret>" entries separated by commas. The
+	// org sends the secret (32 characters or more) as the token query
+	// parameter of the endpoint URL. Messages of other orgs are rejected.
+	SF_ORG_TENANTS = ""
+
+	// Waitlist: hours a promoted registration holds its seat and seconds
+	// between the checks releasing the expired holds
+	WAITLIST_HOLD_HOURS = 24
+	WAITLIST_SWEEP_SEC  = 60
+
+	// Attendance: minutes a session check-in code can be used for
+	ATTENDANCE_CODE_TTL_MIN = 15
+
+	// PUSHD specific consts
+	// Seconds between the deliveries of the pending events as notifications
+	EVENT_POLL_SEC = 30
 
 	// Firebase
 	FCM_JSON     = ""
@@ -27,6 +27,8 @@ const (
 	AccountVolunteer        AccountType = "volunteer"
 	AccountStudent          AccountType = "student"
 	AccountOther            AccountType = "other"
+	// AccountService glad services calling each other (e.g. sfsyncd); never stored
+	AccountService AccountType = "service"
 	// Add new types here
 )
 
@@ -61,6 +63,17 @@ type Account struct {
 	UpdatedAt time.Time
 }
 
+// NewServiceAccount creates the account the glad services act as. It is not
+// stored, hence the audit actor of the changes it makes is unknown.
+func NewServiceAccount(tenantID id.ID) *Account {
+	return &Account{
+		TenantID: tenantID,
+		Username: string(AccountService),
+		Type:     AccountService,
+		Status:   AccountActive,
+	}
+}
+
 // NewAccount creates a new account
 func NewAccount(tenantID id.ID,
 	cognitoID string,
@@ -0,0 +1,221 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// Attendance status of a participant in a session
+type AttendanceStatus string
+
+const (
+	AttendancePresent AttendanceStatus = "present"
+	AttendanceAbsent  AttendanceStatus = "absent"
+	AttendanceLate    AttendanceStatus = "late"
+	// Add new types here
+)
+
+// Check-in code length (digits)
+const AttendanceCodeDigits = 6
+
+// IsValid checks whether the attendance status is known
+func (s AttendanceStatus) IsValid() bool {
+	switch s {
+	case AttendancePresent, AttendanceAbsent, AttendanceLate:
+		return true
+	}
+	return false
+}
+
+// IsAttended checks whether the status counts as attended
+func (s AttendanceStatus) IsAttended() bool {
+	return s == AttendancePresent || s == AttendanceLate
+}
+
+// Attendance of a participant in a session (course timing)
+// Note: Tenant id is not stored with the attendance. It is mapped via the course.
+type Attendance struct {
+	ID            id.ID
+	CourseID      id.ID
+	TimingID      id.ID
+	ParticipantID id.ID
+	Status        AttendanceStatus
+	// CheckedInAt zero when absent
+	CheckedInAt time.Time
+	// RecordedBy account that marked the attendance; the participant's own
+	// account for a self check-in
+	RecordedBy id.ID
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewAttendance creates a new attendance
+func NewAttendance(courseID id.ID,
+	timingID id.ID,
+	participantID id.ID,
+	status AttendanceStatus,
+	checkedInAt time.Time,
+	recordedBy id.ID,
+) (*Attendance, error) {
+	a := &Attendance{
+		ID:            id.New(),
+		CourseID:      courseID,
+		TimingID:      timingID,
+		ParticipantID: participantID,
+		Status:        status,
+		RecordedBy:    recordedBy,
+		CreatedAt:     time.Now(),
+	}
+	a.UpdatedAt = a.CreatedAt
+	if status.IsAttended() {
+		a.CheckedInAt = checkedInAt
+		if a.CheckedInAt.IsZero() {
+			a.CheckedInAt = a.CreatedAt
+		}
+	}
+
+	err := a.Validate()
+	if err != nil {
+		return nil, glad.ErrInvalidEntity
+	}
+	return a, nil
+}
+
+// Validate validates attendance
+func (a *Attendance) Validate() error {
+	if a.CourseID == id.IDInvalid || a.TimingID == id.IDInvalid ||
+		a.ParticipantID == id.IDInvalid || !a.Status.IsValid() {
+		l.Log.Warnf("Invalid attendance timing id=%v, participant id=%v, status=%v",
+			a.TimingID, a.ParticipantID, a.Status)
+		return glad.ErrInvalidEntity
+	}
+	return nil
+}
+
+// AttendanceCode short-lived code participants check in to a session with
+type AttendanceCode struct {
+	TimingID  id.ID
+	CourseID  id.ID
+	Code      string
+	ExpiresAt time.Time
+	CreatedBy id.ID
+
+	// meta data
+	CreatedAt time.Time
+}
+
+// NewAttendanceCode creates a random check-in code for the session, valid for ttl
+func NewAttendanceCode(courseID id.ID,
+	timingID id.ID,
+	createdBy id.ID,
+	ttl time.Duration,
+) (*AttendanceCode, error) {
+	if timingID == id.IDInvalid || ttl <= 0 {
+		return nil, glad.ErrInvalidEntity
+	}
+
+	max := big.NewInt(1)
+	for i := 0; i < AttendanceCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &AttendanceCode{
+		TimingID:  timingID,
+		CourseID:  courseID,
+		Code:      fmt.Sprintf("%0*d", AttendanceCodeDigits, n),
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+	c.ExpiresAt = c.CreatedAt.Add(ttl)
+	return c, nil
+}
+
+// IsExpired checks whether the code can no longer be used
+func (c *AttendanceCode) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// SessionAttendance attendance counts of a session
+type SessionAttendance struct {
+	TimingID id.ID
+	DateTime CourseDateTime
+	Present  int
+	Late     int
+	Absent   int
+	// Unmarked participants without attendance for the session
+	Unmarked int
+}
+
+// ParticipantAttendance attendance of a participant over the sessions of the course
+type ParticipantAttendance struct {
+	ParticipantID id.ID
+	AccountID     id.ID
+	Sessions      int
+	// Attended sessions present or late
+	Attended int
+	Late     int
+	Absent   int
+	// Percent attended sessions out of all the sessions of the course
+	Percent float64
+	// IsEligible attendance meets the minimum required for completion
+	IsEligible bool
+	Records    []*Attendance
+}
+
+// CourseAttendance attendance summary of a course
+type CourseAttendance struct {
+	CourseID id.ID
+	// MinPercent attendance required for completion; 0 when not required
+	MinPercent   int
+	Sessions     []*SessionAttendance
+	Participants []*ParticipantAttendance
+}
+
+// NewParticipantAttendance summarizes the attendance records of the
+// participant over the sessions of the course
+func NewParticipantAttendance(p *Participant,
+	sessions int,
+	records []*Attendance,
+	minPercent int,
+) *ParticipantAttendance {
+	pa := &ParticipantAttendance{
+		ParticipantID: p.ID,
+		AccountID:     p.AccountID,
+		Sessions:      sessions,
+		Records:       records,
+	}
+	for _, a := range records {
+		switch a.Status {
+		case AttendanceAbsent:
+			pa.Absent++
+		case AttendanceLate:
+			pa.Late++
+			pa.Attended++
+		case AttendancePresent:
+			pa.Attended++
+		}
+	}
+	if sessions > 0 {
+		pa.Percent = float64(pa.Attended) * 100 / float64(sessions)
+	}
+	pa.IsEligible = minPercent <= 0 || pa.Percent >= float64(minPercent)
+	return pa
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"ac9/glad/pkg/id"
+)
+
+// Audited entity type
+type AuditEntityType string
+
+const (
+	AuditCourse  AuditEntityType = "course"
+	AuditCenter  AuditEntityType = "center"
+	AuditProduct AuditEntityType = "product"
+	AuditAccount AuditEntityType = "account"
+	AuditTenant  AuditEntityType = "tenant"
+	// AuditTeacherEligibility entity id is the teacher id
+	AuditTeacherEligibility AuditEntityType = "eligibility"
+	// Add new types here
+)
+
+// Audited operation
+type AuditOperation string
+
+const (
+	AuditCreate AuditOperation = "create"
+	AuditUpdate AuditOperation = "update"
+	AuditUpsert AuditOperation = "upsert"
+	AuditDelete AuditOperation = "delete"
+	// Add new types here
+)
+
+// Origin of the change
+type AuditSource string
+
+const (
+	AuditSourceAPI        AuditSource = "api"
+	AuditSourceSalesforce AuditSource = "salesforce"
+	AuditSourceSystem     AuditSource = "system"
+	// Add new types here
+)
+
+// auditRedacted fields that are never written to the audit log
+var auditRedacted = []string{"AuthToken"}
+
+// auditIgnored fields that are kept in the snapshots but not reported as changes
+var auditIgnored = []string{"CreatedAt", "UpdatedAt"}
+
+// AuditActor who made the change and from where
+type AuditActor struct {
+	// AccountID is IDInvalid when the caller is not known
+	AccountID id.ID
+	Source    AuditSource
+}
+
+// AuditChange a field whose value differs between the snapshots
+type AuditChange struct {
+	Field  string
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+// AuditLog change made to an entity
+type AuditLog struct {
+	ID         id.ID
+	TenantID   id.ID
+	EntityType AuditEntityType
+	EntityID   id.ID
+	Operation  AuditOperation
+	Source     AuditSource
+	ActorID    id.ID
+
+	// Before and After are JSON snapshots of the entity; Before is empty for
+	// create and for upsert of a new entity, After is empty for delete.
+	Before  json.RawMessage
+	After   json.RawMessage
+	Changes []AuditChange
+
+	// Seq position in the change feed of the export; zero for audit logs
+	// that are not in the change outbox
+	Seq int64
+
+	// meta data
+	CreatedAt time.Time
+}
+
+// NewAuditLog creates an audit log with the snapshots of the entity before and
+// after the change. Either of them can be nil.
+func NewAuditLog(actor AuditActor,
+	tenantID id.ID,
+	entityType AuditEntityType,
+	entityID id.ID,
+	op AuditOperation,
+	before interface{},
+	after interface{},
+) (*AuditLog, error) {
+	b, err := auditSnapshot(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := auditSnapshot(after)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &AuditLog{
+		ID:         id.New(),
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Operation:  op,
+		Source:     actor.Source,
+		ActorID:    actor.AccountID,
+		Before:     b.raw(),
+		After:      a.raw(),
+		Changes:    auditDiff(b, a),
+		CreatedAt:  time.Now(),
+	}
+	return e, nil
+}
+
+// auditFields top level fields of a marshalled entity
+type auditFields map[string]json.RawMessage
+
+// auditSnapshot marshals the entity and drops the redacted fields
+func auditSnapshot(v interface{}) (auditFields, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	var fields auditFields
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range auditRedacted {
+		delete(fields, f)
+	}
+	return fields, nil
+}
+
+func (f auditFields) raw() json.RawMessage {
+	if f == nil {
+		return nil
+	}
+	data, _ := json.Marshal(f)
+	return data
+}
+
+// auditDiff lists the fields that differ, sorted by name
+func auditDiff(before, after auditFields) []AuditChange {
+	names := map[string]bool{}
+	for f := range before {
+		names[f] = true
+	}
+	for f := range after {
+		names[f] = true
+	}
+	for _, f := range auditIgnored {
+		delete(names, f)
+	}
+
+	var changes []AuditChange
+	for f := range names {
+		if !bytes.Equal(before[f], after[f]) {
+			changes = append(changes, AuditChange{Field: f, Before: before[f], After: after[f]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// calendarTokenBytes random bytes of a calendar subscription token
+const calendarTokenBytes = 32
+
+// NewCalendarToken generates a calendar subscription token. Only the hash of
+// the token is stored; the token itself is handed out once.
+func NewCalendarToken() (token string, hash string, err error) {
+	b := make([]byte, calendarTokenBytes)
+	_, err = rand.Read(b)
+	if err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashCalendarToken(token), nil
+}
+
+// HashCalendarToken hashes the calendar subscription token for the lookup
+func HashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -52,6 +52,8 @@ type Center struct {
 
 	Capacity int32
 	Mode     CenterMode
+	// Timezone IANA zone name; the courses held at the center default to it
+	Timezone string
 
 	WebPage    string
 	IsNational bool
@@ -156,6 +158,10 @@ func (c *Center) Transform() {
 		// TODO: count the centers with empty mode (center mode in SF)
 		c.Mode = CenterNotSet
 	}
+
+	if timezone, err := NormalizeTimezone(c.Timezone); err == nil {
+		c.Timezone = timezone
+	}
 }
 
 func (c *Center) Validate() error {
@@ -175,5 +181,10 @@ func (c *Center) Validate() error {
 		return glad.ErrInvalidEntity
 	}
 
+	if _, err := NormalizeTimezone(c.Timezone); err != nil {
+		l.Log.Warnf("Center extID=%v timezone=%v is not an IANA zone", c.ExtID, c.Timezone)
+		return glad.ErrInvalidEntity
+	}
+
 	return nil
 }
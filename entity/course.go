@@ -97,6 +97,10 @@ type Course struct {
 	URL         string
 	CheckoutURL string
 
+	// Recurrence rule of the sessions, if any; kept apart from the course
+	// record (see CourseRecurrenceWriter)
+	Recurrence *CourseRecurrence
+
 	// meta data
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -197,6 +201,10 @@ func (c *Course) Transform() {
 		// TODO: Add a metric to keep track of this
 		c.Mode = CourseNotSet
 	}
+
+	if timezone, err := NormalizeTimezone(c.Timezone); err == nil {
+		c.Timezone = timezone
+	}
 }
 
 // Validate validate course
@@ -205,5 +213,9 @@ func (c *Course) Validate() error {
 		l.Log.Warnf("Course name is empty; extID=%v", c.ExtID)
 		return glad.ErrInvalidEntity
 	}
+	if _, err := NormalizeTimezone(c.Timezone); err != nil {
+		l.Log.Warnf("Course timezone=%v is not an IANA zone; extID=%v", c.Timezone, c.ExtID)
+		return glad.ErrInvalidEntity
+	}
 	return c.Address.Validate()
 }
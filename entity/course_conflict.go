@@ -0,0 +1,140 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// CourseConflictKind what the overlapping sessions compete for
+type CourseConflictKind string
+
+const (
+	// ConflictTeacher the teacher of the course is booked in both sessions
+	ConflictTeacher CourseConflictKind = "teacher"
+	// ConflictOrganizer the organizer of the course is booked in both sessions
+	ConflictOrganizer CourseConflictKind = "organizer"
+	// ConflictCenter the in-person courses jointly exceed the center capacity
+	ConflictCenter CourseConflictKind = "center"
+)
+
+// CourseSchedule timings of a course with the accounts and the center they
+// book
+type CourseSchedule struct {
+	Course       *Course
+	Timings      []*CourseTiming
+	TeacherIDs   []id.ID
+	OrganizerIDs []id.ID
+	// CenterCapacity capacity of the course center; 0 when not limited
+	CenterCapacity int32
+}
+
+// CourseSession session of a course as instants
+type CourseSession struct {
+	CourseID id.ID
+	TimingID id.ID
+	Start    time.Time
+	End      time.Time
+}
+
+// Overlaps checks whether the sessions share any time
+func (s *CourseSession) Overlaps(o *CourseSession) bool {
+	return s.Start.Before(o.End) && o.Start.Before(s.End)
+}
+
+// CourseConflict session of a course overlapping a session of another course
+type CourseConflict struct {
+	Kind CourseConflictKind
+	// AccountID teacher or organizer booked twice; not set for a center
+	AccountID id.ID
+	CenterID  id.ID
+	Session   CourseSession
+	With      CourseSession
+	// Attendees expected by both courses and the capacity of the center; set
+	// for a center
+	Attendees int32
+	Capacity  int32
+}
+
+// Sessions gets the sessions of the schedule; timings whose date or times
+// cannot be read are left out
+func (cs *CourseSchedule) Sessions() []*CourseSession {
+	loc, err := TimezoneLocation(cs.Course.Timezone)
+	if err != nil {
+		return nil
+	}
+
+	var sessions []*CourseSession
+	for _, ct := range cs.Timings {
+		start, end, err := ct.DateTime.Interval(loc)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, &CourseSession{
+			CourseID: cs.Course.ID,
+			TimingID: ct.ID,
+			Start:    start,
+			End:      end,
+		})
+	}
+	return sessions
+}
+
+// ConflictError the course schedule conflicts with other courses
+type ConflictError struct {
+	Conflicts []*CourseConflict
+}
+
+// Error gets the error message
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v: %v conflicts", glad.ErrScheduleConflict, len(e.Conflicts))
+}
+
+// Unwrap gets glad.ErrScheduleConflict
+func (e *ConflictError) Unwrap() error {
+	return glad.ErrScheduleConflict
+}
+
+// CourseConflictMaxDays longest date range searched for conflicts
+const CourseConflictMaxDays = 366
+
+// CourseConflictQuery conflicts of a course or, without a course, among the
+// courses of an account or a center between the dates (YYYY-MM-DD, inclusive)
+type CourseConflictQuery struct {
+	CourseID  id.ID
+	AccountID id.ID
+	CenterID  id.ID
+	From      string
+	To        string
+}
+
+// Validate validates the conflict query
+func (q *CourseConflictQuery) Validate() error {
+	if q.CourseID != id.IDInvalid {
+		return nil
+	}
+	if q.AccountID == id.IDInvalid && q.CenterID == id.IDInvalid {
+		return glad.ErrMissingParam
+	}
+
+	from, err := time.Parse(CourseDateFormat, q.From)
+	if err != nil {
+		return glad.ErrInvalidValue
+	}
+	to, err := time.Parse(CourseDateFormat, q.To)
+	if err != nil {
+		return glad.ErrInvalidValue
+	}
+	if to.Before(from) || to.Sub(from) > CourseConflictMaxDays*24*time.Hour {
+		return glad.ErrInvalidValue
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// Course sort field
+type CourseSortField string
+
+const (
+	CourseSortUpdatedAt CourseSortField = "updatedAt"
+	CourseSortCreatedAt CourseSortField = "createdAt"
+	CourseSortName      CourseSortField = "name"
+	CourseSortStartDate CourseSortField = "startDate"
+	// Add new types here
+)
+
+// CourseFilterDateLayout is the layout for DateFrom and DateTo
+const CourseFilterDateLayout = "2006-01-02"
+
+// CourseFilter structured course search
+// Zero values are not applied. DateFrom and DateTo match a course when any of
+// its timings fall within the (inclusive) range.
+type CourseFilter struct {
+	Query     string
+	Status    CourseStatus
+	Mode      CourseMode
+	ProductID id.ID
+	CenterID  id.ID
+	TeacherID id.ID
+	DateFrom  string
+	DateTo    string
+	City      string
+	State     string
+
+	SortBy   CourseSortField
+	SortDesc bool
+}
+
+// Validate validates the filter and defaults the sort order
+func (f *CourseFilter) Validate() error {
+	switch f.SortBy {
+	case "":
+		f.SortBy = CourseSortUpdatedAt
+		f.SortDesc = true
+	case CourseSortUpdatedAt, CourseSortCreatedAt, CourseSortName, CourseSortStartDate:
+	default:
+		return glad.ErrInvalidValue
+	}
+
+	var from, to time.Time
+	var err error
+	if f.DateFrom != "" {
+		from, err = time.Parse(CourseFilterDateLayout, f.DateFrom)
+		if err != nil {
+			return glad.ErrInvalidValue
+		}
+	}
+	if f.DateTo != "" {
+		to, err = time.Parse(CourseFilterDateLayout, f.DateTo)
+		if err != nil {
+			return glad.ErrInvalidValue
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return glad.ErrInvalidValue
+	}
+
+	return nil
+}
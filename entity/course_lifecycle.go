@@ -0,0 +1,143 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// Course lifecycle action
+type CourseAction string
+
+const (
+	CourseActionSubmit         CourseAction = "submit"
+	CourseActionApprove        CourseAction = "approve"
+	CourseActionDecline        CourseAction = "decline"
+	CourseActionOpen           CourseAction = "open"
+	CourseActionClose          CourseAction = "close"
+	CourseActionCancel         CourseAction = "cancel"
+	CourseActionSubmitExpense  CourseAction = "submit-expense"
+	CourseActionDeclineExpense CourseAction = "decline-expense"
+	CourseActionDeactivate     CourseAction = "deactivate"
+	CourseActionReactivate     CourseAction = "reactivate"
+	CourseActionArchive        CourseAction = "archive"
+	// Add new actions here
+)
+
+// CourseActionRestore reverts the archive of a course deleted in Salesforce
+// once it is undeleted there. It is not a lifecycle action; the course goes
+// back to the status it had before being archived.
+const CourseActionRestore CourseAction = "restore"
+
+// CourseTransition moves a course from any of the From states to To
+type CourseTransition struct {
+	Action CourseAction
+	From   []CourseStatus
+	To     CourseStatus
+}
+
+// courseTransitions course lifecycle; any other status change is rejected
+var courseTransitions = []CourseTransition{
+	{CourseActionSubmit, []CourseStatus{CourseDraft, CourseDeclined}, CourseSubmitted},
+	{CourseActionApprove, []CourseStatus{CourseSubmitted}, CourseActive},
+	{CourseActionDecline, []CourseStatus{CourseSubmitted}, CourseDeclined},
+	{CourseActionOpen, []CourseStatus{CourseActive}, CourseOpen},
+	{CourseActionClose, []CourseStatus{CourseOpen}, CourseClosed},
+	{CourseActionCancel, []CourseStatus{CourseDraft, CourseSubmitted, CourseActive, CourseOpen}, CourseCanceled},
+	{CourseActionSubmitExpense, []CourseStatus{CourseClosed, CourseExpenseDeclined}, CourseExpenseSubmitted},
+	{CourseActionDeclineExpense, []CourseStatus{CourseExpenseSubmitted}, CourseExpenseDeclined},
+	{CourseActionDeactivate, []CourseStatus{CourseActive, CourseOpen}, CoursedInactive},
+	{CourseActionReactivate, []CourseStatus{CoursedInactive}, CourseActive},
+	{CourseActionArchive, []CourseStatus{CourseExpenseSubmitted, CourseDeclined, CourseCanceled}, CourseArchived},
+}
+
+// CourseActions lists the lifecycle actions
+func CourseActions() []CourseAction {
+	actions := make([]CourseAction, len(courseTransitions))
+	for i, t := range courseTransitions {
+		actions[i] = t.Action
+	}
+	return actions
+}
+
+// Next returns the status reached by performing the action in this status
+// Returns glad.ErrInvalidValue for an unknown action and glad.ErrInvalidTransition
+// when the action is not permitted in this status.
+func (s CourseStatus) Next(action CourseAction) (CourseStatus, error) {
+	for _, t := range courseTransitions {
+		if t.Action != action {
+			continue
+		}
+		for _, from := range t.From {
+			if from == s {
+				return t.To, nil
+			}
+		}
+		return s, glad.ErrInvalidTransition
+	}
+	return s, glad.ErrInvalidValue
+}
+
+// IsRegistrationOpen checks whether participants can register in this status
+// Registrations open once a course is approved (active) and close once it is
+// closed, canceled, declined, inactive or archived; draft and submitted
+// courses are not published yet.
+func (s CourseStatus) IsRegistrationOpen() bool {
+	switch s {
+	case CourseActive, CourseOpen:
+		return true
+	}
+	return false
+}
+
+// IsScheduled checks whether the sessions of a course in this status take
+// place; canceled, declined, inactive and archived courses book nobody
+func (s CourseStatus) IsScheduled() bool {
+	switch s {
+	case CourseCanceled, CourseDeclined, CoursedInactive, CourseArchived:
+		return false
+	}
+	return true
+}
+
+// CourseStatusChange history of a course lifecycle transition
+type CourseStatusChange struct {
+	ID       id.ID
+	TenantID id.ID
+	CourseID id.ID
+	Action   CourseAction
+	From     CourseStatus
+	To       CourseStatus
+	// ActorID account that triggered the transition
+	ActorID id.ID
+
+	// meta data
+	CreatedAt time.Time
+}
+
+// NewCourseStatusChange creates the history record of a transition
+func NewCourseStatusChange(tenantID id.ID,
+	courseID id.ID,
+	action CourseAction,
+	from CourseStatus,
+	to CourseStatus,
+	actorID id.ID,
+) *CourseStatusChange {
+	return &CourseStatusChange{
+		ID:        id.New(),
+		TenantID:  tenantID,
+		CourseID:  courseID,
+		Action:    action,
+		From:      from,
+		To:        to,
+		ActorID:   actorID,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"ac9/glad/pkg/glad"
+)
+
+// Recurrence frequencies (RFC 5545 FREQ) supported
+const (
+	RecurrenceDaily  = "DAILY"
+	RecurrenceWeekly = "WEEKLY"
+)
+
+// Limits of a recurrence rule
+const (
+	MaxRecurrenceSessions = 366
+	MaxRecurrenceYears    = 5
+)
+
+// recurrenceDays weekdays by their RFC 5545 name
+var recurrenceDays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// CourseRecurrence sessions of a course repeated by a recurrence rule; the
+// dates and times are in the course timezone
+type CourseRecurrence struct {
+	// Rule RFC 5545 RRULE, e.g. FREQ=WEEKLY;BYDAY=TU,TH;COUNT=8. Supported:
+	// FREQ (DAILY, WEEKLY), INTERVAL, BYDAY, COUNT and UNTIL.
+	Rule string
+	// StartDate date of the first session (DTSTART) in YYYY-MM-DD format
+	StartDate string
+	StartTime string
+	EndTime   string
+	// ExDates dates without a session (EXDATE) in YYYY-MM-DD format
+	ExDates []string
+}
+
+// RecurrenceRule parsed recurrence rule
+type RecurrenceRule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	Count    int
+	// Until last date of the rule, inclusive; zero when not set
+	Until time.Time
+}
+
+// ParseRecurrenceRule parses the RRULE subset supported. The rule must end,
+// by COUNT or UNTIL.
+func ParseRecurrenceRule(rule string) (*RecurrenceRule, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	rr := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, glad.ErrInvalidValue
+		}
+
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			rr.Freq = strings.ToUpper(value)
+			if rr.Freq != RecurrenceDaily && rr.Freq != RecurrenceWeekly {
+				return nil, glad.ErrInvalidValue
+			}
+		case "INTERVAL":
+			rr.Interval, err = strconv.Atoi(value)
+			if err != nil || rr.Interval < 1 {
+				return nil, glad.ErrInvalidValue
+			}
+		case "COUNT":
+			rr.Count, err = strconv.Atoi(value)
+			if err != nil || rr.Count < 1 {
+				return nil, glad.ErrInvalidValue
+			}
+		case "UNTIL":
+			// Note: Only the date of a date-time is used
+			if len(value) < len("20060102") {
+				return nil, glad.ErrInvalidValue
+			}
+			rr.Until, err = time.Parse("20060102", value[:len("20060102")])
+			if err != nil {
+				return nil, glad.ErrInvalidValue
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := recurrenceDays[strings.ToUpper(day)]
+				if !ok {
+					return nil, glad.ErrInvalidValue
+				}
+				rr.ByDay = append(rr.ByDay, weekday)
+			}
+		default:
+			return nil, glad.ErrInvalidValue
+		}
+	}
+
+	if rr.Freq == "" {
+		return nil, glad.ErrMissingParam
+	}
+	// COUNT and UNTIL must not occur together (RFC 5545)
+	if (rr.Count == 0) == rr.Until.IsZero() {
+		return nil, glad.ErrInvalidValue
+	}
+	return rr, nil
+}
+
+// hasDay checks whether the rule repeats on the weekday; the weekday of the
+// first session when BYDAY is not set
+func (rr *RecurrenceRule) hasDay(day time.Weekday, start time.Time) bool {
+	if len(rr.ByDay) == 0 {
+		return rr.Freq == RecurrenceDaily || day == start.Weekday()
+	}
+	for _, d := range rr.ByDay {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Dates expands the rule from the start date. Weeks start on Monday. A rule
+// going beyond the limits is invalid.
+func (rr *RecurrenceRule) Dates(start time.Time) ([]time.Time, error) {
+	last := start.AddDate(MaxRecurrenceYears, 0, 0)
+	var dates []time.Time
+	// next adds the date when it matches; false when the rule ended
+	next := func(date time.Time) (bool, error) {
+		switch {
+		case !rr.Until.IsZero() && date.After(rr.Until):
+			return false, nil
+		case date.After(last):
+			return false, glad.ErrInvalidValue
+		case !rr.hasDay(date.Weekday(), start):
+			return true, nil
+		case len(dates) == MaxRecurrenceSessions:
+			return false, glad.ErrInvalidValue
+		}
+		dates = append(dates, date)
+		return rr.Count == 0 || len(dates) < rr.Count, nil
+	}
+
+	if rr.Freq == RecurrenceDaily {
+		for date := start; ; date = date.AddDate(0, 0, rr.Interval) {
+			if more, err := next(date); !more {
+				return dates, err
+			}
+		}
+	}
+
+	monday := start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	for week := monday; ; week = week.AddDate(0, 0, 7*rr.Interval) {
+		for i := 0; i < 7; i++ {
+			date := week.AddDate(0, 0, i)
+			if date.Before(start) {
+				continue
+			}
+			if more, err := next(date); !more {
+				return dates, err
+			}
+		}
+	}
+}
+
+// Validate validates the rule, the dates and the session times
+func (r *CourseRecurrence) Validate() error {
+	_, err := r.Sessions("")
+	if err != nil {
+		return glad.ErrInvalidEntity
+	}
+	return nil
+}
+
+// Normalize formats the dates as YYYY-MM-DD and the times as HH:MM:SS
+func (r *CourseRecurrence) Normalize() error {
+	first := CourseDateTime{Date: r.StartDate, StartTime: r.StartTime, EndTime: r.EndTime}
+	err := first.Normalize()
+	if err != nil {
+		return err
+	}
+	r.StartDate, r.StartTime, r.EndTime = first.Date, first.StartTime, first.EndTime
+
+	for i, exDate := range r.ExDates {
+		if len(exDate) < len(CourseDateFormat) {
+			return glad.ErrInvalidValue
+		}
+		date, err := time.Parse(CourseDateFormat, exDate[:len(CourseDateFormat)])
+		if err != nil {
+			return glad.ErrInvalidValue
+		}
+		r.ExDates[i] = date.Format(CourseDateFormat)
+	}
+	return nil
+}
+
+// Sessions expands the rule into the session date/times on or after the date
+// (YYYY-MM-DD, empty for all). As in RFC 5545, the excluded dates count
+// towards COUNT.
+func (r *CourseRecurrence) Sessions(from string) ([]CourseDateTime, error) {
+	rr, err := ParseRecurrenceRule(r.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	first := CourseDateTime{Date: r.StartDate, StartTime: r.StartTime, EndTime: r.EndTime}
+	err = first.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	start, _ := time.Parse(CourseDateFormat, first.Date)
+
+	excluded := map[string]bool{}
+	for _, exDate := range r.ExDates {
+		date, err := time.Parse(CourseDateFormat, exDate)
+		if err != nil {
+			return nil, glad.ErrInvalidValue
+		}
+		excluded[date.Format(CourseDateFormat)] = true
+	}
+
+	dates, err := rr.Dates(start)
+	if err != nil {
+		return nil, err
+	}
+	var sessions []CourseDateTime
+	for _, date := range dates {
+		day := date.Format(CourseDateFormat)
+		if excluded[day] || day < from {
+			continue
+		}
+		sessions = append(sessions, CourseDateTime{
+			Date:      day,
+			StartTime: first.StartTime,
+			EndTime:   first.EndTime,
+		})
+	}
+	return sessions, nil
+}
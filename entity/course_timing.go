@@ -12,6 +12,13 @@ import (
 	"time"
 )
 
+// Course date/time formats
+const (
+	CourseDateFormat      = "2006-01-02"
+	CourseTimeFormat      = "15:04:05"
+	CourseShortTimeFormat = "15:04"
+)
+
 // Course date/time
 type CourseDateTime struct {
 	Date      string // Only date in YYYY-MM-DD format
@@ -50,14 +57,101 @@ func NewCourseDateTime(
 	return dt, nil
 }
 
-// Validate validates course date/time
+// Validate validates course date/time; the date and times must parse and the
+// session must end after it starts
 func (dt *CourseDateTime) Validate() error {
-	if dt.Date == "" || dt.StartTime == "" || dt.EndTime == "" {
+	_, _, _, err := dt.parse()
+	if err != nil {
 		return glad.ErrInvalidEntity
 	}
 	return nil
 }
 
+// Normalize formats the date as YYYY-MM-DD and the times as HH:MM:SS
+// Note: The date may come with a time part (as read from a DATE column); it
+// is dropped.
+func (dt *CourseDateTime) Normalize() error {
+	date, start, end, err := dt.parse()
+	if err != nil {
+		return err
+	}
+	dt.Date = date.Format(CourseDateFormat)
+	dt.StartTime = start.Format(CourseTimeFormat)
+	dt.EndTime = end.Format(CourseTimeFormat)
+	return nil
+}
+
+// parse parses the date and the start and end times of the day
+func (dt *CourseDateTime) parse() (date, start, end time.Time, err error) {
+	if len(dt.Date) < len(CourseDateFormat) {
+		return date, start, end, glad.ErrInvalidValue
+	}
+	date, err = time.Parse(CourseDateFormat, dt.Date[:len(CourseDateFormat)])
+	if err != nil {
+		return date, start, end, glad.ErrInvalidValue
+	}
+
+	clock := func(value string) (time.Time, error) {
+		t, err := time.Parse(CourseTimeFormat, value)
+		if err != nil {
+			t, err = time.Parse(CourseShortTimeFormat, value)
+			if err != nil {
+				return time.Time{}, glad.ErrInvalidValue
+			}
+		}
+		return t, nil
+	}
+	start, err = clock(dt.StartTime)
+	if err != nil {
+		return date, start, end, err
+	}
+	end, err = clock(dt.EndTime)
+	if err != nil {
+		return date, start, end, err
+	}
+	if !end.After(start) {
+		return date, start, end, glad.ErrInvalidValue
+	}
+	return date, start, end, nil
+}
+
+// Interval gets the start and end instants of the session in the location,
+// daylight saving time included. A session starting or ending at a time
+// skipped when the clocks go forward is invalid (see LocalInstant).
+func (dt *CourseDateTime) Interval(loc *time.Location) (time.Time, time.Time, error) {
+	date, startClock, endClock, err := dt.parse()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	start, err := LocalInstant(date, startClock, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := LocalInstant(date, endClock, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, glad.ErrInvalidValue
+	}
+	return start, end, nil
+}
+
+// UTC gets the start and end instants of the session, in UTC, for the course
+// timezone
+func (dt *CourseDateTime) UTC(timezone string) (time.Time, time.Time, error) {
+	loc, err := TimezoneLocation(timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start, end, err := dt.Interval(loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start.UTC(), end.UTC(), nil
+}
+
 // NewCourseTiming creates a new course timings
 // Note: Tenant id is not needed here, because this is linked to the course internally.
 // This object is not exposed externally via API. So, tenant ID can be mapped via the course.
@@ -103,5 +197,20 @@ func (ct *CourseTiming) Validate() error {
 		return glad.ErrInvalidEntity
 	}
 
+	return ct.DateTime.Validate()
+}
+
+// ValidateCourseTimings validates the sessions in the course timezone
+func ValidateCourseTimings(timezone string, timings []*CourseTiming) error {
+	loc, err := TimezoneLocation(timezone)
+	if err != nil {
+		return glad.ErrInvalidEntity
+	}
+	for _, ct := range timings {
+		_, _, err := ct.DateTime.Interval(loc)
+		if err != nil {
+			return glad.ErrInvalidEntity
+		}
+	}
 	return nil
 }
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// Why the imported record could not be saved
+type DeadLetterReason string
+
+const (
+	// DeadLetterMissingDependency the record refers to an entity that is not
+	// imported yet, e.g. a course sent before its center
+	DeadLetterMissingDependency DeadLetterReason = "missing_dependency"
+	// DeadLetterRejected the record could not be saved
+	DeadLetterRejected DeadLetterReason = "rejected"
+	// Add new types here
+)
+
+// DeadLetter imported record that could not be saved. It is kept, with the
+// raw payload, until it is replayed successfully or discarded.
+type DeadLetter struct {
+	ID       id.ID
+	TenantID id.ID
+	// entity types are shared with the audit log
+	EntityType AuditEntityType
+	ExtID      string
+	Payload    json.RawMessage
+	Reason     DeadLetterReason
+	Message    string
+
+	// DependencyType and DependencyExtID identify the missing entity; the
+	// record is replayed when it is imported. Empty unless the reason is
+	// DeadLetterMissingDependency.
+	DependencyType  AuditEntityType
+	DependencyExtID string
+
+	// Attempts number of times the record failed to import
+	Attempts int
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewDeadLetter creates a dead letter for the first failed attempt
+func NewDeadLetter(tenantID id.ID,
+	entityType AuditEntityType,
+	extID string,
+	payload json.RawMessage,
+	reason DeadLetterReason,
+	message string,
+) (*DeadLetter, error) {
+	e := &DeadLetter{
+		ID:         id.New(),
+		TenantID:   tenantID,
+		EntityType: entityType,
+		ExtID:      extID,
+		Payload:    payload,
+		Reason:     reason,
+		Message:    message,
+		Attempts:   1,
+		CreatedAt:  time.Now(),
+	}
+	e.UpdatedAt = e.CreatedAt
+
+	err := e.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// SetDependency records the missing entity the record is waiting for
+func (e *DeadLetter) SetDependency(entityType AuditEntityType, extID string) {
+	e.Reason = DeadLetterMissingDependency
+	e.DependencyType = entityType
+	e.DependencyExtID = extID
+}
+
+// Validate validates the dead letter
+func (e *DeadLetter) Validate() error {
+	if e.ExtID == "" || e.EntityType == "" || len(e.Payload) == 0 {
+		return glad.ErrInvalidEntity
+	}
+	switch e.Reason {
+	case DeadLetterMissingDependency, DeadLetterRejected:
+	default:
+		return glad.ErrInvalidEntity
+	}
+	return nil
+}
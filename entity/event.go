@@ -0,0 +1,76 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// Event type
+type EventType string
+
+const (
+	// EventWaitlistPromoted a seat is held for the account on the waitlist;
+	// the payload is EventWaitlistPromotion
+	EventWaitlistPromoted EventType = "waitlist.promoted"
+	// Add new types here
+)
+
+// Event raised by coursed for the other services (e.g. pushd notifies the
+// account). Events are kept in the outbox until they are delivered.
+type Event struct {
+	ID        id.ID
+	TenantID  id.ID
+	Type      EventType
+	AccountID id.ID
+	CourseID  id.ID
+	// Payload JSON details of the event, by type
+	Payload json.RawMessage
+
+	// meta data
+	CreatedAt time.Time
+	// DeliveredAt zero until delivered
+	DeliveredAt time.Time
+}
+
+// EventWaitlistPromotion payload of EventWaitlistPromoted
+type EventWaitlistPromotion struct {
+	EntryID id.ID `json:"entryID"`
+	// HoldUntil the seat is released unless confirmed by then
+	HoldUntil time.Time `json:"holdUntil"`
+}
+
+// NewEvent creates an event of the account with the payload
+func NewEvent(tenantID id.ID,
+	eventType EventType,
+	accountID id.ID,
+	courseID id.ID,
+	payload interface{},
+) (*Event, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Event{
+		ID:        id.New(),
+		TenantID:  tenantID,
+		Type:      eventType,
+		AccountID: accountID,
+		CourseID:  courseID,
+		Payload:   raw,
+		CreatedAt: time.Now(),
+	}
+	if e.Type == "" || e.AccountID == id.IDInvalid {
+		return nil, glad.ErrInvalidEntity
+	}
+	return e, nil
+}
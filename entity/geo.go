@@ -0,0 +1,104 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"math"
+
+	"ac9/glad/pkg/glad"
+)
+
+const (
+	// EarthRadiusKm mean earth radius used for distance calculations
+	EarthRadiusKm = 6371.0
+
+	// GeoDefaultRadiusKm radius used when none is given
+	GeoDefaultRadiusKm = 25.0
+	// GeoMaxRadiusKm upper bound on the search radius
+	GeoMaxRadiusKm = 500.0
+)
+
+// GeoQuery proximity search around a point
+type GeoQuery struct {
+	Lat      float64
+	Long     float64
+	RadiusKm float64
+}
+
+// GeoBoundingBox lat/long box enclosing a GeoQuery circle
+type GeoBoundingBox struct {
+	MinLat  float64
+	MaxLat  float64
+	MinLong float64
+	MaxLong float64
+}
+
+// CenterNearby center with its distance from the searched point
+type CenterNearby struct {
+	*Center
+	DistanceKm float64
+}
+
+// CourseNearby course with the distance of its center from the searched point
+type CourseNearby struct {
+	*CourseFull
+	DistanceKm float64
+}
+
+// Validate validates the query and defaults the radius
+func (q *GeoQuery) Validate() error {
+	if q.Lat < -90 || q.Lat > 90 || q.Long < -180 || q.Long > 180 {
+		return glad.ErrInvalidValue
+	}
+	if q.RadiusKm == 0 {
+		q.RadiusKm = GeoDefaultRadiusKm
+	}
+	if q.RadiusKm < 0 || q.RadiusKm > GeoMaxRadiusKm {
+		return glad.ErrInvalidValue
+	}
+	return nil
+}
+
+// BoundingBox returns the box enclosing the query circle
+// Used as an index-friendly prefilter before the exact distance check. Near the
+// poles or across the antimeridian the longitude range is widened to the full
+// [-180, 180] instead of being split.
+func (q *GeoQuery) BoundingBox() GeoBoundingBox {
+	dLat := q.RadiusKm / EarthRadiusKm * 180 / math.Pi
+	b := GeoBoundingBox{
+		MinLat:  math.Max(q.Lat-dLat, -90),
+		MaxLat:  math.Min(q.Lat+dLat, 90),
+		MinLong: -180,
+		MaxLong: 180,
+	}
+
+	cosLat := math.Cos(q.Lat * math.Pi / 180)
+	if b.MinLat > -90 && b.MaxLat < 90 && cosLat > 0 {
+		dLong := dLat / cosLat
+		if q.Long-dLong >= -180 && q.Long+dLong <= 180 {
+			b.MinLong = q.Long - dLong
+			b.MaxLong = q.Long + dLong
+		}
+	}
+	return b
+}
+
+// Contains reports whether the location is inside the box
+func (b GeoBoundingBox) Contains(g CenterGeoLocation) bool {
+	return g.Lat >= b.MinLat && g.Lat <= b.MaxLat &&
+		g.Long >= b.MinLong && g.Long <= b.MaxLong
+}
+
+// DistanceKm great-circle (haversine) distance from the query point
+func (q *GeoQuery) DistanceKm(g CenterGeoLocation) float64 {
+	toRad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := toRad(g.Lat - q.Lat)
+	dLong := toRad(g.Long - q.Long)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(q.Lat))*math.Cos(toRad(g.Lat))*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
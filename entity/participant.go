@@ -0,0 +1,61 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"time"
+)
+
+// Participant data
+// Note: Tenant id is not stored with the participant. It is mapped via the course.
+type Participant struct {
+	ID        id.ID
+	CourseID  id.ID
+	AccountID id.ID
+
+	// Note: ext_id is salesforce id. It is nil for registrations made in GLAD.
+	ExtID *string
+	Email string
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewParticipant creates a new participant
+func NewParticipant(courseID id.ID,
+	accountID id.ID,
+	email string,
+) (*Participant, error) {
+	p := &Participant{
+		ID:        id.New(),
+		CourseID:  courseID,
+		AccountID: accountID,
+		Email:     email,
+		CreatedAt: time.Now(),
+	}
+	p.UpdatedAt = p.CreatedAt
+
+	err := p.Validate()
+	if err != nil {
+		return nil, glad.ErrInvalidEntity
+	}
+	return p, nil
+}
+
+// Validate validates participant
+func (p *Participant) Validate() error {
+	if p.CourseID == id.IDInvalid || p.AccountID == id.IDInvalid {
+		l.Log.Warnf("Invalid participant course id=%v, account id=%v", p.CourseID, p.AccountID)
+		return glad.ErrInvalidEntity
+	}
+
+	return nil
+}
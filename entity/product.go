@@ -45,6 +45,8 @@ type Product struct {
 	Format           ProductFormat
 
 	IsAutoApprove bool
+	// IsDeleted product deleted in Salesforce; it is no longer listed
+	IsDeleted bool
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
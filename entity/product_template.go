@@ -0,0 +1,197 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// ProductTemplate defaults of the courses created from a product
+// Note: Kept apart from the product, which is synced from Salesforce. Tenant id
+// is mapped via the product.
+type ProductTemplate struct {
+	ProductID id.ID
+	TenantID  id.ID
+	// StartTime and EndTime of the daily sessions (HH:MM:SS) in the course
+	// timezone
+	StartTime string
+	EndTime   string
+	Notes     string
+
+	// meta data
+	UpdatedAt time.Time
+}
+
+// NewProductTemplate creates a new product template
+func NewProductTemplate(tenantID id.ID,
+	productID id.ID,
+	startTime string,
+	endTime string,
+	notes string,
+) (*ProductTemplate, error) {
+	t := &ProductTemplate{
+		ProductID: productID,
+		TenantID:  tenantID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Notes:     notes,
+		UpdatedAt: time.Now(),
+	}
+
+	err := t.Normalize()
+	if err != nil {
+		l.Log.Warnf("Invalid product template product id=%v, start=%v, end=%v",
+			productID, startTime, endTime)
+		return nil, glad.ErrInvalidEntity
+	}
+	return t, nil
+}
+
+// Normalize formats the session times as HH:MM:SS; the session must end after
+// it starts
+func (t *ProductTemplate) Normalize() error {
+	if t.ProductID == id.IDInvalid {
+		return glad.ErrInvalidEntity
+	}
+	// Note: Any date will do to check the times
+	dt := CourseDateTime{Date: "2000-01-01", StartTime: t.StartTime, EndTime: t.EndTime}
+	err := dt.Normalize()
+	if err != nil {
+		return err
+	}
+	t.StartTime, t.EndTime = dt.StartTime, dt.EndTime
+	return nil
+}
+
+// CourseFromProduct course to be created from a product; the other details
+// are derived from the product, its template and the center
+type CourseFromProduct struct {
+	TenantID  id.ID
+	ProductID id.ID
+	CenterID  id.ID
+	// StartDate date of the first session in YYYY-MM-DD format
+	StartDate string
+
+	// Optional; Name defaults to the product title, Timezone to the center's,
+	// and the session times and notes to the product template's
+	Name      string
+	Timezone  string
+	StartTime string
+	EndTime   string
+	Notes     string
+
+	Organizers []*CourseOrganizer
+	Teachers   []*CourseTeacher
+}
+
+// CourseMode mode of the courses of the product format
+func (f ProductFormat) CourseMode() CourseMode {
+	switch f {
+	case ProductFormatInPerson, ProductFormatDestination:
+		return CourseInPerson
+	case ProductFormatOnline:
+		return CourseOnline
+	}
+	return CourseNotSet
+}
+
+// CourseStatus status the courses of the product are created in; submitted
+// for approval unless the product is approved automatically
+func (p *Product) CourseStatus() CourseStatus {
+	if p.IsAutoApprove {
+		return CourseActive
+	}
+	return CourseSubmitted
+}
+
+// CourseMaxAttendees attendees of the courses of the product held at a center
+// of the capacity; 0 (no limit) when neither is set
+func (p *Product) CourseMaxAttendees(capacity int32) int32 {
+	if capacity > 0 && (p.MaxAttendees <= 0 || p.MaxAttendees > capacity) {
+		return capacity
+	}
+	return p.MaxAttendees
+}
+
+// NewCourseFromProduct creates the course and its daily timings, one per day
+// of the product duration, from the product, its template (nil when there is
+// none) and the center
+func NewCourseFromProduct(req *CourseFromProduct,
+	p *Product,
+	t *ProductTemplate,
+	c *Center,
+) (*Course, []*CourseTiming, error) {
+	if t == nil {
+		t = &ProductTemplate{}
+	}
+
+	name := req.Name
+	if name == "" {
+		name = p.Title
+	}
+	notes := req.Notes
+	if notes == "" {
+		notes = t.Notes
+	}
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = c.Timezone
+	}
+
+	course := &Course{
+		TenantID:     req.TenantID,
+		CenterID:     c.ID,
+		ProductID:    p.ID,
+		Name:         name,
+		Notes:        notes,
+		Timezone:     timezone,
+		Address:      CourseAddress(c.Address),
+		Status:       p.CourseStatus(),
+		Mode:         p.Format.CourseMode(),
+		MaxAttendees: p.CourseMaxAttendees(c.Capacity),
+	}
+
+	first := CourseDateTime{
+		Date:      req.StartDate,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+	if first.StartTime == "" && first.EndTime == "" {
+		first.StartTime, first.EndTime = t.StartTime, t.EndTime
+	}
+	if first.StartTime == "" || first.EndTime == "" {
+		return nil, nil, glad.ErrMissingParam
+	}
+	err := first.Normalize()
+	if err != nil {
+		return nil, nil, glad.ErrInvalidValue
+	}
+
+	days := int(p.DurationDays)
+	if days < 1 {
+		days = 1
+	}
+	if days > MaxRecurrenceSessions {
+		return nil, nil, glad.ErrInvalidValue
+	}
+	start, _ := time.Parse(CourseDateFormat, first.Date)
+	timings := make([]*CourseTiming, days)
+	for i := range timings {
+		timings[i] = &CourseTiming{
+			DateTime: CourseDateTime{
+				Date:      start.AddDate(0, 0, i).Format(CourseDateFormat),
+				StartTime: first.StartTime,
+				EndTime:   first.EndTime,
+			},
+		}
+	}
+	return course, timings, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"time"
+)
+
+// Teaching eligibility type
+type EligibilityType string
+
+const (
+	// EligibilityPrimary may lead the course as its primary teacher
+	EligibilityPrimary EligibilityType = "primary"
+	// EligibilityAssistant may only assist the primary teacher
+	EligibilityAssistant EligibilityType = "assistant"
+	// Add new types here
+)
+
+// IsValid checks whether the eligibility type is known
+func (t EligibilityType) IsValid() bool {
+	switch t {
+	case EligibilityPrimary, EligibilityAssistant:
+		return true
+	}
+	return false
+}
+
+// CanTeach checks whether the eligibility permits teaching as the given teacher
+func (t EligibilityType) CanTeach(ct *CourseTeacher) bool {
+	return t == EligibilityPrimary || (t == EligibilityAssistant && !ct.IsPrimary)
+}
+
+// TeacherEligibility product the teacher is eligible to teach
+// Note: Tenant id is not stored with the eligibility. It is mapped via the product.
+type TeacherEligibility struct {
+	ProductID id.ID
+	TeacherID id.ID
+
+	// Note: ext_id is salesforce id. It is nil for eligibility set in GLAD.
+	ExtID *string
+	Type  EligibilityType
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// EligibleTeacher teacher eligible for a product with the distance of the
+// nearest center they taught at
+type EligibleTeacher struct {
+	*TeacherEligibility
+	DistanceKm float64
+}
+
+// NewTeacherEligibility creates a new teacher eligibility
+func NewTeacherEligibility(productID id.ID,
+	teacherID id.ID,
+	et EligibilityType,
+) (*TeacherEligibility, error) {
+	e := &TeacherEligibility{
+		ProductID: productID,
+		TeacherID: teacherID,
+		Type:      et,
+		CreatedAt: time.Now(),
+	}
+	e.UpdatedAt = e.CreatedAt
+
+	err := e.Validate()
+	if err != nil {
+		return nil, glad.ErrInvalidEntity
+	}
+	return e, nil
+}
+
+// Validate validates teacher eligibility
+func (e *TeacherEligibility) Validate() error {
+	if e.ProductID == id.IDInvalid || e.TeacherID == id.IDInvalid {
+		l.Log.Warnf("Invalid eligibility product id=%v, teacher id=%v", e.ProductID, e.TeacherID)
+		return glad.ErrInvalidEntity
+	}
+	if !e.Type.IsValid() {
+		l.Log.Warnf("Invalid eligibility type=%v", e.Type)
+		return glad.ErrInvalidEntity
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"ac9/glad/pkg/glad"
+)
+
+// legacyTimezones IANA zones of the timezone abbreviations used before zone
+// names (timezone_type); the abbreviation names the zone, the daylight saving
+// time of the zone applies. Salesforce still uses these.
+var legacyTimezones = map[string]string{
+	"EST":  "America/New_York",
+	"CST":  "America/Chicago",
+	"MST":  "America/Denver",
+	"PST":  "America/Los_Angeles",
+	"HST":  "Pacific/Honolulu",
+	"AKST": "America/Anchorage",
+}
+
+// NormalizeTimezone validates the IANA zone name; the legacy abbreviations
+// (EST, CST, ...) are mapped to their zone. An empty name stays empty.
+func NormalizeTimezone(timezone string) (string, error) {
+	if timezone == "" {
+		return "", nil
+	}
+	if name, ok := legacyTimezones[strings.ToUpper(timezone)]; ok {
+		return name, nil
+	}
+	// Local is the zone of the server, not of the course
+	if timezone == "Local" {
+		return "", glad.ErrInvalidValue
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", glad.ErrInvalidValue
+	}
+	return loc.String(), nil
+}
+
+// TimezoneAbbreviation gets the legacy abbreviation of the zone, for the
+// systems that still use them; other zones are returned as is
+func TimezoneAbbreviation(timezone string) string {
+	for abbreviation, name := range legacyTimezones {
+		if name == timezone {
+			return abbreviation
+		}
+	}
+	return timezone
+}
+
+// TimezoneLocation gets the location of the timezone; UTC when not set
+func TimezoneLocation(timezone string) (*time.Location, error) {
+	name, err := NormalizeTimezone(timezone)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// LocalInstant gets the instant at which the wall clock of the location shows
+// the date and time. A time skipped when the clocks go forward does not exist
+// (glad.ErrInvalidValue); a time repeated when the clocks go back is taken
+// the first time, i.e. before the change.
+func LocalInstant(date time.Time, clock time.Time, loc *time.Location) (time.Time, error) {
+	wall := time.Date(date.Year(), date.Month(), date.Day(),
+		clock.Hour(), clock.Minute(), clock.Second(), 0, time.UTC)
+
+	// the offsets in effect around the date cover any change on the day
+	var found time.Time
+	for _, around := range []time.Time{wall.Add(-24 * time.Hour), wall.Add(24 * time.Hour)} {
+		_, offset := around.In(loc).Zone()
+		t := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if !sameWallClock(t, wall) {
+			continue
+		}
+		if found.IsZero() || t.Before(found) {
+			found = t
+		}
+	}
+	if found.IsZero() {
+		return time.Time{}, glad.ErrInvalidValue
+	}
+	return found, nil
+}
+
+// sameWallClock checks whether both times show the same date and time,
+// irrespective of their location
+func sameWallClock(t, wall time.Time) bool {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := wall.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 &&
+		t.Hour() == wall.Hour() && t.Minute() == wall.Minute() && t.Second() == wall.Second()
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// Waitlist entry status
+type WaitlistStatus string
+
+const (
+	// WaitlistWaiting waiting for a seat
+	WaitlistWaiting WaitlistStatus = "waiting"
+	// WaitlistOffered promoted; a seat is held until the entry is confirmed
+	// or the hold expires
+	WaitlistOffered WaitlistStatus = "offered"
+	// Add new types here
+)
+
+// WaitlistEntry registration waiting for a seat in a full course
+// Note: Tenant id is not stored with the entry. It is mapped via the course.
+type WaitlistEntry struct {
+	ID        id.ID
+	TenantID  id.ID
+	CourseID  id.ID
+	AccountID id.ID
+	Email     string
+
+	// Position order of the entry in the waitlist of the course, from 1
+	Position int
+	Status   WaitlistStatus
+	// HoldUntil end of the hold of an offered entry; zero otherwise
+	HoldUntil time.Time
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewWaitlistEntry creates a new waitlist entry; the position is set when the
+// entry is added to the waitlist
+func NewWaitlistEntry(tenantID id.ID,
+	courseID id.ID,
+	accountID id.ID,
+	email string,
+) (*WaitlistEntry, error) {
+	e := &WaitlistEntry{
+		ID:        id.New(),
+		TenantID:  tenantID,
+		CourseID:  courseID,
+		AccountID: accountID,
+		Email:     email,
+		Status:    WaitlistWaiting,
+		CreatedAt: time.Now(),
+	}
+	e.UpdatedAt = e.CreatedAt
+
+	err := e.Validate()
+	if err != nil {
+		return nil, glad.ErrInvalidEntity
+	}
+	return e, nil
+}
+
+// Validate validates waitlist entry
+func (e *WaitlistEntry) Validate() error {
+	if e.CourseID == id.IDInvalid || e.AccountID == id.IDInvalid {
+		l.Log.Warnf("Invalid waitlist entry course id=%v, account id=%v", e.CourseID, e.AccountID)
+		return glad.ErrInvalidEntity
+	}
+	return nil
+}
+
+// Offer holds a seat for the entry until the end of the hold window
+func (e *WaitlistEntry) Offer(now time.Time, hold time.Duration) {
+	e.Status = WaitlistOffered
+	e.HoldUntil = now.Add(hold)
+	e.UpdatedAt = now
+}
+
+// IsHoldExpired checks whether the seat held for the entry is to be released
+func (e *WaitlistEntry) IsHoldExpired(now time.Time) bool {
+	return e.Status == WaitlistOffered && now.After(e.HoldUntil)
+}
@@ -29,21 +29,15 @@ CREATE TYPE course_status AS ENUM ('draft'
     , 'closed'
     , 'active'
     , 'declined'
-    , 'pending-activation'  -- Salesforce: Submitted for activation approval
+    , 'submitted'           -- Salesforce: Submitted for activation approval
     , 'canceled'
     , 'inactive'
+    , 'archived'
     );
 CREATE TYPE course_mode AS ENUM ('in-person'
     , 'online'
     , 'not-set'
     );
-CREATE TYPE timezone_type AS ENUM ('EST'
-    , 'CST'
-    , 'MST'
-    , 'PST'
-    , 'HST'
-    , 'AKST'
-    );
 CREATE TYPE account_type AS ENUM ('assistant-teacher'
     , 'coordinator' -- Note: Unclear what this type is. TBD
     , 'member'
@@ -117,7 +111,9 @@ CREATE TABLE IF NOT EXISTS product (
     format product_format,
 
     is_auto_approve BOOLEAN DEFAULT FALSE,
-    -- is_deleted is an internal field in Salesforce. Hence, need not be synced
+    -- Note: Set when the product is deleted in Salesforce and cleared when it is undeleted.
+    -- Deleted products are not listed, but courses can still refer to them.
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
 
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
@@ -151,6 +147,8 @@ CREATE TABLE IF NOT EXISTS center (
     -- maximum occupancy
     capacity INTEGER,
     mode center_mode DEFAULT 'not-set',
+    -- Note: IANA zone name (e.g. America/New_York); courses at the center default to it
+    timezone VARCHAR(64),
     web_page VARCHAR(255),
     is_national BOOLEAN DEFAULT FALSE,
     is_enabled BOOLEAN,
@@ -162,6 +160,11 @@ CREATE INDEX idx_center_ext_id ON center(ext_id);
 CREATE INDEX idx_center_tenant_id ON center(tenant_id);
 CREATE INDEX idx_center_name ON center(name);
 CREATE INDEX idx_center_ext_name ON center(ext_name);
+-- Note: supports the bounding box prefilter of proximity search
+CREATE INDEX idx_center_geo_location ON center(
+    ((geo_location->>'Lat')::float8),
+    ((geo_location->>'Long')::float8)
+);
 
 CREATE TABLE IF NOT EXISTS center_contact (
     center_id INT NOT NULL REFERENCES center(id) ON DELETE CASCADE,
@@ -186,7 +189,8 @@ CREATE TABLE IF NOT EXISTS course (
 
     name VARCHAR(128) NOT NULL,
     notes TEXT,
-    timezone timezone_type,
+    -- Note: IANA zone name (e.g. America/New_York) of the course timings
+    timezone VARCHAR(64),
 
     -- Note: address format: {"street_1": ..., "street_2": ..., "city": ..., "state": ..., "zip": ..., "country": ...}
     -- When multitenancy is introduced, then country can be removed.
@@ -234,6 +238,9 @@ CREATE TABLE IF NOT EXISTS account (
     -- Note: This photo is stored in Salesforce and accessible via token
     full_photo_url VARCHAR(1024),
 
+    -- Note: Only the SHA-256 hash of the calendar subscription token is kept
+    calendar_token_hash VARCHAR(64) UNIQUE,
+
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
     -- Q: Is it unique? How about kids' account?
@@ -248,11 +255,16 @@ CREATE INDEX idx_account_email ON account(email);
 CREATE TABLE IF NOT EXISTS teacher_eligibility (
     product_id BIGINT NOT NULL REFERENCES product(id) ON DELETE RESTRICT,
     teacher_id BIGINT NOT NULL REFERENCES account(id) ON DELETE RESTRICT,
+    -- Note: ext_id is salesforce id of the eligibility record. It is NULL for
+    -- eligibility set in GLAD.
+    ext_id VARCHAR(32) UNIQUE,
 
-    type teaching_eligibility_type,
+    type teaching_eligibility_type NOT NULL,
 
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (product_id, teacher_id)
 );
 CREATE INDEX idx_teacher_eligibility_product_id ON teacher_eligibility(product_id);
 CREATE INDEX idx_teacher_eligibility_teacher_id ON teacher_eligibility(teacher_id);
@@ -262,6 +274,9 @@ CREATE INDEX idx_teacher_eligibility_teacher_id ON teacher_eligibility(teacher_i
 CREATE TABLE IF NOT EXISTS course_organizer (
     course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
     organizer_id BIGINT NOT NULL REFERENCES account(id) ON DELETE RESTRICT,
+    -- Note: ext_id is salesforce id of the junction record. It is NULL for
+    -- organizers set in GLAD.
+    ext_id VARCHAR(32) UNIQUE,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 CREATE INDEX idx_course_organizer_course_id ON course_organizer(course_id);
@@ -283,6 +298,9 @@ CREATE TABLE IF NOT EXISTS course_teacher (
     course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
     teacher_id BIGINT NOT NULL REFERENCES account(id) ON DELETE RESTRICT,
     is_primary BOOLEAN DEFAULT FALSE,
+    -- Note: ext_id is salesforce id of the junction record. It is NULL for
+    -- teachers set in GLAD.
+    ext_id VARCHAR(32) UNIQUE,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 CREATE INDEX idx_course_teacher_course_id ON course_teacher(course_id);
@@ -299,12 +317,30 @@ CREATE TABLE IF NOT EXISTS course_timing (
     course_date DATE,
     start_time TIME,
     end_time TIME,
+    -- Note: times are wall clock times in the course timezone
+    CONSTRAINT course_timing_end_after_start CHECK (end_time > start_time),
 
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 CREATE INDEX idx_course_timings_course_id ON course_timing(course_id);
 
+-- Note: Tenant is not required for course_recurrence
+-- Recurrence rule (RFC 5545 RRULE) the course timings are generated from;
+-- dates and times are in the course timezone
+CREATE TABLE IF NOT EXISTS course_recurrence (
+    course_id BIGINT PRIMARY KEY REFERENCES course(id) ON DELETE CASCADE,
+    rule VARCHAR(255) NOT NULL,
+    start_date DATE NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    -- Note: ex_dates format: ["YYYY-MM-DD", ...]
+    ex_dates JSONB,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Note: Tenant is not required for course_notify
 -- Notes: Notify is not mandatory for a course
 CREATE TABLE IF NOT EXISTS course_notify (
@@ -314,11 +350,26 @@ CREATE TABLE IF NOT EXISTS course_notify (
 );
 CREATE INDEX idx_course_notify_course_id ON course_notify(course_id);
 
+-- Note: One row per lifecycle transition (POST /v1/courses/{id}/transitions/{action})
+CREATE TABLE IF NOT EXISTS course_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id),
+    course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    action VARCHAR(32) NOT NULL,
+    from_status course_status NOT NULL,
+    to_status course_status NOT NULL,
+    -- Note: History is kept even if the account is removed
+    actor_id BIGINT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_course_status_history_course_id ON course_status_history(course_id);
+
 -- PARTICIPANT entity
 CREATE TABLE IF NOT EXISTS participant (
     id BIGSERIAL PRIMARY KEY,
-    -- Note: ext_id is salesforce id
-    ext_id VARCHAR(32) NOT NULL UNIQUE,
+    -- Note: ext_id is salesforce id. It is NULL for registrations made in GLAD.
+    ext_id VARCHAR(32) UNIQUE,
 
     -- Note: Do not want to delete course if participant exists
     course_id BIGINT NOT NULL REFERENCES course(id),
@@ -328,8 +379,182 @@ CREATE TABLE IF NOT EXISTS participant (
     email VARCHAR(80),
 
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (course_id, account_id)
 );
 CREATE INDEX idx_participant_course_id ON participant(course_id);
 CREATE INDEX idx_participant_account_id ON participant(account_id);
 CREATE INDEX idx_participant_email ON participant(email);
+
+-- WAITLIST entity
+-- Note: Registrations waiting for a seat of a full course. An offered entry
+-- holds a seat (counted in num_attendees) until it is confirmed or hold_until.
+CREATE TABLE IF NOT EXISTS waitlist (
+    id BIGSERIAL PRIMARY KEY,
+    course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+
+    email VARCHAR(80),
+
+    position INTEGER NOT NULL,
+    -- Note: status: waiting, offered
+    status VARCHAR(16) NOT NULL,
+    hold_until TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (course_id, account_id),
+    -- Note: Checked at commit; positions move up when an entry is removed
+    UNIQUE (course_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+CREATE INDEX idx_waitlist_hold_until ON waitlist(status, hold_until);
+
+-- PRODUCT TEMPLATE entity
+-- Note: Defaults of the courses created from a product. Kept apart from
+-- product, which is synced from Salesforce.
+CREATE TABLE IF NOT EXISTS product_template (
+    product_id BIGINT PRIMARY KEY REFERENCES product(id) ON DELETE CASCADE,
+    -- Note: times of the daily sessions, wall clock times in the course timezone
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    CONSTRAINT product_template_end_after_start CHECK (end_time > start_time),
+    notes TEXT,
+
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ATTENDANCE entity
+-- Note: Attendance of a participant in a session (course timing)
+CREATE TABLE IF NOT EXISTS attendance (
+    id BIGSERIAL PRIMARY KEY,
+    course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    timing_id BIGINT NOT NULL REFERENCES course_timing(id) ON DELETE CASCADE,
+    participant_id BIGINT NOT NULL REFERENCES participant(id) ON DELETE CASCADE,
+    -- Note: status: present, absent, late
+    status VARCHAR(16) NOT NULL,
+    checked_in_at TIMESTAMP,
+    -- Note: Account that marked the attendance; the participant's own account for a self check-in
+    recorded_by BIGINT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (timing_id, participant_id)
+);
+CREATE INDEX idx_attendance_course_id ON attendance(course_id);
+
+-- Note: Latest check-in code of a session; a new code replaces it
+CREATE TABLE IF NOT EXISTS attendance_code (
+    timing_id BIGINT PRIMARY KEY REFERENCES course_timing(id) ON DELETE CASCADE,
+    course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    code VARCHAR(16) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_by BIGINT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Note: Check-in attempts of an account with the latest code of a session;
+-- counted from 1 again once a new code is created
+CREATE TABLE IF NOT EXISTS attendance_check_in_attempt (
+    timing_id BIGINT NOT NULL REFERENCES course_timing(id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+    -- created_at of the code the attempts are made with
+    code_created_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (timing_id, account_id)
+);
+
+-- Note: Attendance (percent of the sessions) required for completion of the
+-- courses of a product. Kept apart from product, which is synced from Salesforce.
+CREATE TABLE IF NOT EXISTS attendance_requirement (
+    product_id BIGINT PRIMARY KEY REFERENCES product(id) ON DELETE CASCADE,
+    min_percent INTEGER NOT NULL CHECK (min_percent BETWEEN 0 AND 100),
+
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- AUDIT LOG entity
+-- Note: One row per create, update, upsert or delete made through the usecase services
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    -- Note: Logs are kept even if the tenant or entity is removed
+    tenant_id BIGINT NOT NULL,
+    entity_type VARCHAR(16) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    operation VARCHAR(16) NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    actor_id BIGINT,
+
+    before JSONB,
+    after JSONB,
+    changes JSONB,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_audit_log_entity ON audit_log(tenant_id, entity_type, entity_id, created_at);
+
+-- CHANGE OUTBOX
+-- Note: Changes made in GLAD to the entities exported to Salesforce; written in
+-- the transaction of the change. seq orders the changes of a tenant in commit
+-- order (see queueChange) and is the cursor of the export.
+CREATE TABLE IF NOT EXISTS change_outbox (
+    seq BIGSERIAL PRIMARY KEY,
+    id BIGINT NOT NULL,
+    tenant_id BIGINT NOT NULL,
+    entity_type VARCHAR(16) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    operation VARCHAR(16) NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    actor_id BIGINT,
+
+    after JSONB,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_change_outbox_tenant ON change_outbox(tenant_id, seq);
+
+-- DEAD LETTER entity
+-- Note: Imported records that could not be saved; removed once replayed or discarded
+CREATE TABLE IF NOT EXISTS dead_letter (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id),
+    entity_type VARCHAR(16) NOT NULL,
+    -- Note: ext_id is salesforce id of the failed record
+    ext_id VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    message TEXT,
+
+    -- Note: Missing entity the record is waiting for; replayed once it is imported
+    dependency_type VARCHAR(16),
+    dependency_ext_id VARCHAR(32),
+
+    attempts INTEGER NOT NULL DEFAULT 1,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (tenant_id, entity_type, ext_id)
+);
+CREATE INDEX idx_dead_letter_dependency ON dead_letter(tenant_id, dependency_type, dependency_ext_id);
+
+-- EVENT entity
+-- Note: Outbox of the events raised by coursed (e.g. waitlist promotions);
+-- pushd delivers them as notifications and sets delivered_at
+CREATE TABLE IF NOT EXISTS event (
+    id BIGSERIAL PRIMARY KEY,
+    -- Note: Events are kept even if the tenant, account or course is removed
+    tenant_id BIGINT NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    account_id BIGINT NOT NULL,
+    course_id BIGINT,
+    payload JSONB,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+CREATE INDEX idx_event_pending ON event(created_at) WHERE delivered_at IS NULL;
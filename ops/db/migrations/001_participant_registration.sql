@@ -0,0 +1,21 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Participants registered in GLAD have no Salesforce id; an account is
+-- registered for a course at most once.
+
+BEGIN;
+
+ALTER TABLE participant ALTER COLUMN ext_id DROP NOT NULL;
+
+-- Note: Fails when an account is registered twice for a course; remove the
+-- duplicates reported below first.
+ALTER TABLE participant ADD CONSTRAINT participant_course_id_account_id_key
+    UNIQUE (course_id, account_id);
+
+COMMIT;
+
+-- Duplicate registrations to remove before the migration:
+-- SELECT course_id, account_id, array_agg(id) FROM participant
+--     GROUP BY course_id, account_id HAVING count(*) > 1;
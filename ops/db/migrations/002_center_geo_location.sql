@@ -0,0 +1,14 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Index of the bounding box prefilter of the proximity search of centers
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_center_geo_location ON center(
+    ((geo_location->>'Lat')::float8),
+    ((geo_location->>'Long')::float8)
+);
+
+COMMIT;
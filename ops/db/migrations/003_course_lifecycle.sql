@@ -0,0 +1,29 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Course lifecycle: pending-activation becomes submitted, courses can be
+-- archived and every transition is kept in the status history.
+-- Note: ADD VALUE runs in a transaction block from PostgreSQL 12 on; the new
+-- value is not used before the commit.
+
+BEGIN;
+
+ALTER TYPE course_status RENAME VALUE 'pending-activation' TO 'submitted';
+ALTER TYPE course_status ADD VALUE IF NOT EXISTS 'archived';
+
+CREATE TABLE IF NOT EXISTS course_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id),
+    course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    action VARCHAR(32) NOT NULL,
+    from_status course_status NOT NULL,
+    to_status course_status NOT NULL,
+    -- Note: History is kept even if the account is removed
+    actor_id BIGINT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_course_status_history_course_id ON course_status_history(course_id);
+
+COMMIT;
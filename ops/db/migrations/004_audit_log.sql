@@ -0,0 +1,27 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Audit log of the changes made through the usecase services
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    -- Note: Logs are kept even if the tenant or entity is removed
+    tenant_id BIGINT NOT NULL,
+    entity_type VARCHAR(16) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    operation VARCHAR(16) NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    actor_id BIGINT,
+
+    before JSONB,
+    after JSONB,
+    changes JSONB,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(tenant_id, entity_type, entity_id, created_at);
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Products deleted in Salesforce are flagged instead of removed
+
+BEGIN;
+
+ALTER TABLE product ADD COLUMN IF NOT EXISTS is_deleted BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
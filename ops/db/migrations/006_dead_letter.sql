@@ -0,0 +1,32 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Imported records that could not be saved, kept to be replayed or discarded
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS dead_letter (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL REFERENCES tenant(id),
+    entity_type VARCHAR(16) NOT NULL,
+    -- Note: ext_id is salesforce id of the failed record
+    ext_id VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    message TEXT,
+
+    -- Note: Missing entity the record is waiting for; replayed once it is imported
+    dependency_type VARCHAR(16),
+    dependency_ext_id VARCHAR(32),
+
+    attempts INTEGER NOT NULL DEFAULT 1,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (tenant_id, entity_type, ext_id)
+);
+CREATE INDEX IF NOT EXISTS idx_dead_letter_dependency ON dead_letter(tenant_id, dependency_type, dependency_ext_id);
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Salesforce ids of the course organizer and teacher junction records
+
+BEGIN;
+
+ALTER TABLE course_organizer ADD COLUMN IF NOT EXISTS ext_id VARCHAR(32) UNIQUE;
+ALTER TABLE course_teacher ADD COLUMN IF NOT EXISTS ext_id VARCHAR(32) UNIQUE;
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Teacher eligibility is kept once per product and teacher, with a type and
+-- the Salesforce id of the eligibility record
+
+BEGIN;
+
+ALTER TABLE teacher_eligibility ADD COLUMN IF NOT EXISTS ext_id VARCHAR(32) UNIQUE;
+
+-- Note: Fails when a row has no type or a teacher is eligible twice for a
+-- product; fix the rows reported below first.
+ALTER TABLE teacher_eligibility ALTER COLUMN type SET NOT NULL;
+ALTER TABLE teacher_eligibility ADD PRIMARY KEY (product_id, teacher_id);
+
+COMMIT;
+
+-- Rows to fix before the migration:
+-- SELECT product_id, teacher_id FROM teacher_eligibility WHERE type IS NULL;
+-- SELECT product_id, teacher_id, count(*) FROM teacher_eligibility
+--     GROUP BY product_id, teacher_id HAVING count(*) > 1;
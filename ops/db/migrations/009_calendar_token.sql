@@ -0,0 +1,11 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Hash of the calendar subscription token of the accounts
+
+BEGIN;
+
+ALTER TABLE account ADD COLUMN IF NOT EXISTS calendar_token_hash VARCHAR(64) UNIQUE;
+
+COMMIT;
@@ -0,0 +1,35 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Course timezones become IANA zone names; the timezone_type abbreviations
+-- are mapped to the zone they stand for. Centers get a default timezone and
+-- course timings must end after they start.
+
+BEGIN;
+
+ALTER TABLE course ALTER COLUMN timezone TYPE VARCHAR(64) USING (
+    CASE timezone::TEXT
+        WHEN 'EST' THEN 'America/New_York'
+        WHEN 'CST' THEN 'America/Chicago'
+        WHEN 'MST' THEN 'America/Denver'
+        WHEN 'PST' THEN 'America/Los_Angeles'
+        WHEN 'HST' THEN 'Pacific/Honolulu'
+        WHEN 'AKST' THEN 'America/Anchorage'
+    END
+);
+DROP TYPE IF EXISTS timezone_type;
+
+ALTER TABLE center ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+
+-- Note: NOT VALID leaves existing timings as they are; new and updated timings
+-- are checked. Fix the reported rows, then VALIDATE CONSTRAINT.
+ALTER TABLE course_timing ADD CONSTRAINT course_timing_end_after_start
+    CHECK (end_time > start_time) NOT VALID;
+
+COMMIT;
+
+-- Timings to fix before validating the constraint:
+-- SELECT id, course_id, course_date, start_time, end_time FROM course_timing
+--     WHERE end_time <= start_time;
+-- ALTER TABLE course_timing VALIDATE CONSTRAINT course_timing_end_after_start;
@@ -0,0 +1,18 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Recurrence rules the course timings are generated from
+
+CREATE TABLE IF NOT EXISTS course_recurrence (
+    course_id BIGINT PRIMARY KEY REFERENCES course(id) ON DELETE CASCADE,
+    rule VARCHAR(255) NOT NULL,
+    start_date DATE NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    -- Note: ex_dates format: ["YYYY-MM-DD", ...]
+    ex_dates JSONB,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,42 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Waitlist of full courses and the outbox of the events delivered by pushd
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS waitlist (
+    id BIGSERIAL PRIMARY KEY,
+    course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+
+    email VARCHAR(80),
+
+    position INTEGER NOT NULL,
+    -- Note: status: waiting, offered
+    status VARCHAR(16) NOT NULL,
+    hold_until TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (course_id, account_id),
+    UNIQUE (course_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+CREATE INDEX IF NOT EXISTS idx_waitlist_hold_until ON waitlist(status, hold_until);
+
+CREATE TABLE IF NOT EXISTS event (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id BIGINT NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    account_id BIGINT NOT NULL,
+    course_id BIGINT,
+    payload JSONB,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_event_pending ON event(created_at) WHERE delivered_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,47 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Session attendance, check-in codes and the attendance required for completion
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS attendance (
+    id BIGSERIAL PRIMARY KEY,
+    course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    timing_id BIGINT NOT NULL REFERENCES course_timing(id) ON DELETE CASCADE,
+    participant_id BIGINT NOT NULL REFERENCES participant(id) ON DELETE CASCADE,
+    -- Note: status: present, absent, late
+    status VARCHAR(16) NOT NULL,
+    checked_in_at TIMESTAMP,
+    -- Note: Account that marked the attendance; the participant's own account for a self check-in
+    recorded_by BIGINT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (timing_id, participant_id)
+);
+CREATE INDEX IF NOT EXISTS idx_attendance_course_id ON attendance(course_id);
+
+-- Note: Latest check-in code of a session; a new code replaces it
+CREATE TABLE IF NOT EXISTS attendance_code (
+    timing_id BIGINT PRIMARY KEY REFERENCES course_timing(id) ON DELETE CASCADE,
+    course_id BIGINT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    code VARCHAR(16) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_by BIGINT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Note: Attendance (percent of the sessions) required for completion of the
+-- courses of a product. Kept apart from product, which is synced from Salesforce.
+CREATE TABLE IF NOT EXISTS attendance_requirement (
+    product_id BIGINT PRIMARY KEY REFERENCES product(id) ON DELETE CASCADE,
+    min_percent INTEGER NOT NULL CHECK (min_percent BETWEEN 0 AND 100),
+
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMIT;
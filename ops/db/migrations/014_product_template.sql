@@ -0,0 +1,20 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Defaults of the courses created from a product
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS product_template (
+    product_id BIGINT PRIMARY KEY REFERENCES product(id) ON DELETE CASCADE,
+    -- Note: times of the daily sessions, wall clock times in the course timezone
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    CONSTRAINT product_template_end_after_start CHECK (end_time > start_time),
+    notes TEXT,
+
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMIT;
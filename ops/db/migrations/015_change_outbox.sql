@@ -0,0 +1,26 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Outbox of the changes exported to Salesforce, written in the transaction of
+-- the change; replaces the audit log as the change feed of the export
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS change_outbox (
+    seq BIGSERIAL PRIMARY KEY,
+    id BIGINT NOT NULL,
+    tenant_id BIGINT NOT NULL,
+    entity_type VARCHAR(16) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    operation VARCHAR(16) NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    actor_id BIGINT,
+
+    after JSONB,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_change_outbox_tenant ON change_outbox(tenant_id, seq);
+
+COMMIT;
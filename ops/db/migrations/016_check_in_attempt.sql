@@ -0,0 +1,18 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+-- Check-in attempts of the accounts with the latest code of a session
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS attendance_check_in_attempt (
+    timing_id BIGINT NOT NULL REFERENCES course_timing(id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+    code_created_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (timing_id, account_id)
+);
+
+COMMIT;
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package glad
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Change change made in GLAD as listed by the coursed change feed
+// (GET /v1/audit/changes). After is the snapshot of the entity after the
+// change; it is empty for deletes.
+type Change struct {
+	ID        uint64          `json:"id"`
+	Entity    string          `json:"entity"`
+	EntityID  uint64          `json:"entityID"`
+	Operation string          `json:"operation"`
+	Source    string          `json:"source"`
+	After     json.RawMessage `json:"after,omitempty"`
+	// Seq position in the change feed; the cursor of the next listing
+	Seq       int64     `json:"seq"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ExtID Salesforce id assigned to an entity created in GLAD
+type ExtID struct {
+	ExtID string `json:"extID"`
+}
@@ -41,3 +41,45 @@ type CourseResponse struct {
 	ExtID   string `json:"extID"`
 	IsError bool   `json:"isError"`
 }
+
+// CourseTiming session of a course, referred to by the course's Salesforce id
+// Date is YYYY-MM-DD and the times are HH:MM:SS, in the course timezone.
+type CourseTiming struct {
+	ExtID       string    `json:"extID"`
+	CourseExtID string    `json:"courseExtID"`
+	Date        string    `json:"date"`
+	StartTime   string    `json:"startTime"`
+	EndTime     string    `json:"endTime"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// CourseTimingResponse result of the timing import
+type CourseTimingResponse struct {
+	ID      int64  `json:"id"`
+	ExtID   string `json:"extID"`
+	IsError bool   `json:"isError"`
+}
+
+// Roles of an account in a course roster
+const (
+	CourseRoleTeacher   = "teacher"
+	CourseRoleOrganizer = "organizer"
+)
+
+// CourseRoster teacher or organizer of a course; ExtID is the Salesforce id of
+// the junction record
+type CourseRoster struct {
+	ExtID        string `json:"extID"`
+	CourseExtID  string `json:"courseExtID"`
+	AccountExtID string `json:"accountExtID"`
+	Role         string `json:"role"`
+	IsPrimary    bool   `json:"isPrimary"`
+}
+
+// CourseRosterResponse result of the roster import; ID is the course id
+type CourseRosterResponse struct {
+	ID      int64  `json:"id"`
+	ExtID   string `json:"extID"`
+	IsError bool   `json:"isError"`
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package coursed
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker circuit breaker; opens after threshold consecutive failures and
+// lets requests through again once cooldown has passed. A failure after the
+// cooldown opens it again.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mut      sync.Mutex
+	failures int
+	openedAt time.Time
+}
+
+// allow whether a request can be sent
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	return b.failures < b.threshold || time.Since(b.openedAt) >= b.cooldown
+}
+
+// success records a successful request
+func (b *breaker) success() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	b.failures = 0
+}
+
+// failure records a failed request
+func (b *breaker) failure() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+// Package coursed is the client of the coursed REST API used by the other
+// glad services
+package coursed
+
+import (
+	"encoding/json"
+	"time"
+
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Config client settings; zero values use the defaults
+type Config struct {
+	// Timeout of a single request
+	Timeout time.Duration
+	// Retries how many times a failed request is sent again. Only requests
+	// that are safe to repeat are retried, on transport errors and on
+	// temporary statuses (5xx, 429). Negative disables the retries.
+	Retries int
+	// Backoff wait before the first retry; doubled on every retry up to
+	// MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// BreakerThreshold consecutive failures opening the circuit breaker;
+	// negative disables the breaker
+	BreakerThreshold int
+	// BreakerCooldown how long the breaker stays open
+	BreakerCooldown time.Duration
+	// Token bearer token of the service account (AUTH_SERVICE_TOKEN of coursed)
+	Token string
+}
+
+const (
+	defaultTimeout          = 30 * time.Second
+	defaultRetries          = 2
+	defaultBackoff          = 200 * time.Millisecond
+	defaultMaxBackoff       = 5 * time.Second
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// Client coursed client
+type Client struct {
+	c        *fasthttp.Client
+	basePath string
+	cfg      Config
+	breaker  *breaker
+}
+
+// NewClient creates a new client of the coursed at basePath, e.g.
+// http://localhost:8080
+func NewClient(basePath string, cfg Config) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.Retries == 0 {
+		cfg.Retries = defaultRetries
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultMaxBackoff
+	}
+	if cfg.BreakerThreshold == 0 {
+		cfg.BreakerThreshold = defaultBreakerThreshold
+	}
+	if cfg.BreakerCooldown <= 0 {
+		cfg.BreakerCooldown = defaultBreakerCooldown
+	}
+
+	return &Client{
+		c:        &fasthttp.Client{},
+		basePath: basePath,
+		cfg:      cfg,
+		breaker: &breaker{
+			threshold: cfg.BreakerThreshold,
+			cooldown:  cfg.BreakerCooldown,
+		},
+	}
+}
+
+// request coursed request
+type request struct {
+	method string
+	path   string
+	body   interface{}
+	// status expected on success
+	status int
+	// retry whether the request is safe to send again
+	retry bool
+}
+
+// do sends the request for the tenant and decodes the response into out,
+// when set. Unexpected statuses are returned as *StatusError.
+func (c *Client) do(tenantID id.ID, r request, out interface{}) error {
+	var body []byte
+	if r.body != nil {
+		var err error
+		body, err = json.Marshal(r.body)
+		if err != nil {
+			l.Log.Errorf("err=%v", err)
+			return err
+		}
+	}
+
+	backoff := c.cfg.Backoff
+	for attempt := 0; ; attempt++ {
+		temporary, err := c.send(tenantID, r, body, out)
+		if err == nil || !temporary || !r.retry || attempt >= c.cfg.Retries {
+			return err
+		}
+
+		l.Log.Infof("Retrying method=%v, path=%v in %v, err=%v", r.method, r.path, backoff, err)
+		time.Sleep(backoff)
+		backoff = min(2*backoff, c.cfg.MaxBackoff)
+	}
+}
+
+// send sends the request once; temporary is set for the failures that may
+// not happen when the request is sent again
+func (c *Client) send(tenantID id.ID,
+	r request,
+	body []byte,
+	out interface{},
+) (temporary bool, err error) {
+	if !c.breaker.allow() {
+		return false, ErrCircuitOpen
+	}
+
+	path := c.basePath + r.path
+	l.Log.Debugf("method=%v, path=%v", r.method, path)
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+
+	req.SetRequestURI(path)
+	req.Header.SetMethod(r.method)
+	req.Header.Set(common.HttpHeaderTenantID, tenantID.String())
+	if c.cfg.Token != "" {
+		req.Header.Set(common.HttpHeaderAuthorization, common.HttpHeaderBearer+c.cfg.Token)
+	}
+	if body != nil {
+		req.Header.SetContentType("application/json")
+		req.SetBody(body)
+	}
+
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+
+	err = c.c.DoTimeout(req, resp, c.cfg.Timeout)
+	if err != nil {
+		l.Log.Warnf("Unable to send method=%v, path=%v, err=%v", r.method, path, err)
+		c.breaker.failure()
+		return true, err
+	}
+
+	if resp.StatusCode() != r.status {
+		statusErr := &StatusError{Status: resp.StatusCode(), Message: string(resp.Body())}
+		if statusErr.Temporary() {
+			c.breaker.failure()
+		} else {
+			c.breaker.success()
+		}
+		l.Log.Warnf("Unexpected response method=%v, path=%v, err=%v", r.method, path, statusErr)
+		return statusErr.Temporary(), statusErr
+	}
+	c.breaker.success()
+
+	if out == nil {
+		return false, nil
+	}
+	err = json.Unmarshal(resp.Body(), out)
+	if err != nil {
+		l.Log.Warnf("Unable to decode response path=%v, err=%v", path, err)
+		return false, err
+	}
+	return false, nil
+}
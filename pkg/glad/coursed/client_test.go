@@ -0,0 +1,164 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package coursed
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tenantAlice id.ID = 13790492210917015554
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+// newTestClient creates a client of a coursed failing the first failures
+// requests with status
+func newTestClient(t *testing.T, cfg Config, failures int32, status int) (*Client, *int32) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tenantAlice.String(), r.Header.Get(common.HttpHeaderTenantID))
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte("failed"))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/courses/import":
+			var courses []*glad.Course
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&courses))
+			var response []*glad.CourseResponse
+			for i, c := range courses {
+				response = append(response, &glad.CourseResponse{ID: int64(i + 1), ExtID: c.ExtID})
+			}
+			_ = json.NewEncoder(w).Encode(response)
+		case "/v1/participants/7":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":9}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	cfg.Backoff = time.Millisecond
+	return NewClient(ts.URL, cfg), &calls
+}
+
+func Test_do(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c, calls := newTestClient(t, Config{}, 0, 0)
+		response, err := c.ImportCourses(tenantAlice, []*glad.Course{{ExtID: "a0W1"}, {ExtID: "a0W2"}})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(response))
+		assert.Equal(t, "a0W2", response[1].ExtID)
+		assert.Equal(t, int32(1), *calls)
+	})
+
+	t.Run("service token", func(t *testing.T) {
+		var authorization string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get(common.HttpHeaderAuthorization)
+			_, _ = w.Write([]byte("[]"))
+		}))
+		defer ts.Close()
+
+		c := NewClient(ts.URL, Config{Token: "white-rabbit"})
+		_, err := c.ImportCourses(tenantAlice, []*glad.Course{{ExtID: "a0W1"}})
+		assert.Nil(t, err)
+		assert.Equal(t, common.HttpHeaderBearer+"white-rabbit", authorization)
+	})
+
+	t.Run("retried", func(t *testing.T) {
+		c, calls := newTestClient(t, Config{Retries: 2}, 2, http.StatusServiceUnavailable)
+		response, err := c.ImportCourses(tenantAlice, []*glad.Course{{ExtID: "a0W1"}})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(response))
+		assert.Equal(t, int32(3), *calls)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		c, calls := newTestClient(t, Config{Retries: 1}, 5, http.StatusServiceUnavailable)
+		_, err := c.ImportCourses(tenantAlice, []*glad.Course{{ExtID: "a0W1"}})
+		var statusErr *StatusError
+		assert.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, http.StatusServiceUnavailable, statusErr.Status)
+		assert.Equal(t, "failed", statusErr.Message)
+		assert.Equal(t, int32(2), *calls)
+	})
+
+	t.Run("client error not retried", func(t *testing.T) {
+		c, calls := newTestClient(t, Config{}, 1, http.StatusBadRequest)
+		_, err := c.ImportCourses(tenantAlice, []*glad.Course{{ExtID: "a0W1"}})
+		assert.True(t, errors.Is(err, glad.ErrInvalidValue))
+		assert.Equal(t, int32(1), *calls)
+
+		_, err = c.Archive(tenantAlice, ResourceCourse, "a0W1")
+		assert.True(t, errors.Is(err, glad.ErrNotFound))
+	})
+
+	t.Run("registration not retried", func(t *testing.T) {
+		c, calls := newTestClient(t, Config{}, 1, http.StatusServiceUnavailable)
+		_, err := c.AddParticipant(tenantAlice, 7, 8, "")
+		assert.NotNil(t, err)
+		assert.Equal(t, int32(1), *calls)
+
+		participantID, err := c.AddParticipant(tenantAlice, 7, 8, "")
+		assert.Nil(t, err)
+		assert.Equal(t, id.ID(9), participantID)
+	})
+}
+
+func Test_breaker(t *testing.T) {
+	c, calls := newTestClient(t, Config{
+		Retries:          -1,
+		BreakerThreshold: 2,
+		BreakerCooldown:  50 * time.Millisecond,
+	}, 3, http.StatusInternalServerError)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.ImportCourses(tenantAlice, nil)
+		assert.NotNil(t, err)
+	}
+	_, err := c.ImportCourses(tenantAlice, nil)
+	assert.Equal(t, ErrCircuitOpen, err)
+	assert.Equal(t, int32(2), *calls)
+
+	// half open; a failure opens it again
+	time.Sleep(60 * time.Millisecond)
+	_, err = c.ImportCourses(tenantAlice, nil)
+	assert.NotNil(t, err)
+	_, err = c.ImportCourses(tenantAlice, nil)
+	assert.Equal(t, ErrCircuitOpen, err)
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = c.ImportCourses(tenantAlice, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), *calls)
+}
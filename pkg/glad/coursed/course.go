@@ -0,0 +1,58 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package coursed
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// GetExtID gets the current Salesforce id of the record; empty if the record
+// is yet to be exported
+func (c *Client) GetExtID(tenantID id.ID, r Resource, recordID id.ID) (string, error) {
+	var e glad.ExtID
+	err := c.do(tenantID, request{
+		method: http.MethodGet,
+		path:   "/v1/" + string(r) + "/" + recordID.String(),
+		status: http.StatusOK,
+		retry:  true,
+	}, &e)
+	return e.ExtID, err
+}
+
+// SetCourseExtID sets the Salesforce id of the course created in GLAD
+func (c *Client) SetCourseExtID(tenantID id.ID, courseID id.ID, extID string) error {
+	return c.do(tenantID, request{
+		method: http.MethodPut,
+		path:   "/v1/courses/" + courseID.String() + "/extid",
+		body:   glad.ExtID{ExtID: extID},
+		status: http.StatusOK,
+		retry:  true,
+	}, nil)
+}
+
+// ListChanges lists the changes made in GLAD after the seq, oldest first
+func (c *Client) ListChanges(tenantID id.ID, after int64, limit int) ([]*glad.Change, error) {
+	q := url.Values{}
+	q.Set("after", strconv.FormatInt(after, 10))
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	var changes []*glad.Change
+	err := c.do(tenantID, request{
+		method: http.MethodGet,
+		path:   "/v1/audit/changes?" + q.Encode(),
+		status: http.StatusOK,
+		retry:  true,
+	}, &changes)
+	return changes, err
+}
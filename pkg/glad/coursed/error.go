@@ -0,0 +1,56 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package coursed
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"ac9/glad/pkg/glad"
+)
+
+// ErrCircuitOpen coursed failed repeatedly; requests are not sent until the
+// breaker cools down
+var ErrCircuitOpen = errors.New("coursed circuit open")
+
+// StatusError coursed responded with an unexpected status
+// The common statuses unwrap to the glad errors, so callers can check
+// errors.Is(err, glad.ErrNotFound).
+type StatusError struct {
+	Status  int
+	Message string
+}
+
+// Error gets the error message
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("coursed status=%v", e.Status)
+	}
+	return fmt.Sprintf("coursed status=%v: %v", e.Status, e.Message)
+}
+
+// Unwrap gets the glad error of the status, if any
+func (e *StatusError) Unwrap() error {
+	switch e.Status {
+	case http.StatusBadRequest:
+		return glad.ErrInvalidValue
+	case http.StatusNotFound:
+		return glad.ErrNotFound
+	case http.StatusConflict:
+		return glad.ErrAlreadyExists
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return glad.ErrAuthFailure
+	}
+	return nil
+}
+
+// Temporary whether the request may succeed when sent again
+func (e *StatusError) Temporary() bool {
+	return e.Status >= http.StatusInternalServerError ||
+		e.Status == http.StatusTooManyRequests
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package coursed
+
+import (
+	"net/http"
+	"net/url"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// Resource coursed resource
+type Resource string
+
+const (
+	ResourceProduct Resource = "products"
+	ResourceCenter  Resource = "centers"
+	ResourceAccount Resource = "accounts"
+	ResourceCourse  Resource = "courses"
+
+	// Teacher eligibility, referring to the product and the teacher by ext id
+	ResourceEligibility Resource = "eligibility"
+
+	// Course records, referring to the course by its ext id
+	ResourceCourseTiming      Resource = "courses/timings"
+	ResourceCourseRoster      Resource = "courses/rosters"
+	ResourceCourseParticipant Resource = "courses/participants"
+)
+
+// Note: Imports are upserts by ext id, so they are safe to retry.
+
+// ImportProducts imports the products by ext id
+func (c *Client) ImportProducts(tenantID id.ID, p []*glad.Product) ([]*glad.ProductResponse, error) {
+	var response []*glad.ProductResponse
+	err := c.importRecords(tenantID, ResourceProduct, p, &response)
+	return response, err
+}
+
+// ImportCenters imports the centers by ext id
+func (c *Client) ImportCenters(tenantID id.ID, p []*glad.Center) ([]*glad.CenterResponse, error) {
+	var response []*glad.CenterResponse
+	err := c.importRecords(tenantID, ResourceCenter, p, &response)
+	return response, err
+}
+
+// ImportAccounts imports the accounts by ext id
+func (c *Client) ImportAccounts(tenantID id.ID, p []*glad.Account) ([]*glad.AccountResponse, error) {
+	var response []*glad.AccountResponse
+	err := c.importRecords(tenantID, ResourceAccount, p, &response)
+	return response, err
+}
+
+// ImportCourses imports the courses by ext id
+func (c *Client) ImportCourses(tenantID id.ID, p []*glad.Course) ([]*glad.CourseResponse, error) {
+	var response []*glad.CourseResponse
+	err := c.importRecords(tenantID, ResourceCourse, p, &response)
+	return response, err
+}
+
+// ImportCourseTimings imports the course timings by ext id
+func (c *Client) ImportCourseTimings(tenantID id.ID,
+	p []*glad.CourseTiming,
+) ([]*glad.CourseTimingResponse, error) {
+	var response []*glad.CourseTimingResponse
+	err := c.importRecords(tenantID, ResourceCourseTiming, p, &response)
+	return response, err
+}
+
+// ImportCourseRosters imports the course teachers and organizers by ext id
+func (c *Client) ImportCourseRosters(tenantID id.ID,
+	p []*glad.CourseRoster,
+) ([]*glad.CourseRosterResponse, error) {
+	var response []*glad.CourseRosterResponse
+	err := c.importRecords(tenantID, ResourceCourseRoster, p, &response)
+	return response, err
+}
+
+// ImportCourseParticipants imports the participants of any course by ext id;
+// each participant refers to its course by the course ext id
+func (c *Client) ImportCourseParticipants(tenantID id.ID,
+	p []*glad.Participant,
+) ([]*glad.ParticipantResponse, error) {
+	var response []*glad.ParticipantResponse
+	err := c.importRecords(tenantID, ResourceCourseParticipant, p, &response)
+	return response, err
+}
+
+// ImportParticipants imports the participants of the course by ext id
+func (c *Client) ImportParticipants(tenantID id.ID,
+	courseID id.ID,
+	p []*glad.Participant,
+) ([]*glad.ParticipantResponse, error) {
+	var response []*glad.ParticipantResponse
+	err := c.do(tenantID, request{
+		method: http.MethodPost,
+		path:   "/v1/participants/" + courseID.String() + "/import",
+		body:   p,
+		status: http.StatusOK,
+		retry:  true,
+	}, &response)
+	return response, err
+}
+
+// ImportTeacherEligibility imports the teacher eligibility by ext id
+func (c *Client) ImportTeacherEligibility(tenantID id.ID,
+	p []*glad.TeacherEligibility,
+) ([]*glad.TeacherEligibilityResponse, error) {
+	var response []*glad.TeacherEligibilityResponse
+	err := c.importRecords(tenantID, ResourceEligibility, p, &response)
+	return response, err
+}
+
+// Archive archives the record imported with the ext id and returns its id
+func (c *Client) Archive(tenantID id.ID, r Resource, extID string) (id.ID, error) {
+	return c.syncByExtID(tenantID, http.MethodDelete, importPath(r, extID))
+}
+
+// Restore restores the record imported with the ext id and returns its id
+func (c *Client) Restore(tenantID id.ID, r Resource, extID string) (id.ID, error) {
+	return c.syncByExtID(tenantID, http.MethodPost, importPath(r, extID)+"/restore")
+}
+
+// Remove removes the course record imported with the ext id and returns its
+// id; for the course timings, rosters, participants and the teacher
+// eligibility, which are not archived
+func (c *Client) Remove(tenantID id.ID, r Resource, extID string) (id.ID, error) {
+	return c.syncByExtID(tenantID, http.MethodDelete, importPath(r, extID))
+}
+
+// importRecords imports the records of the resource
+func (c *Client) importRecords(tenantID id.ID, r Resource, records interface{}, out interface{}) error {
+	return c.do(tenantID, request{
+		method: http.MethodPost,
+		path:   "/v1/" + string(r) + "/import",
+		body:   records,
+		status: http.StatusOK,
+		retry:  true,
+	}, out)
+}
+
+// importPath path of the record imported with the ext id
+func importPath(r Resource, extID string) string {
+	return "/v1/" + string(r) + "/import/" + url.PathEscape(extID)
+}
+
+// syncByExtID sends the by ext id request and returns the record id
+func (c *Client) syncByExtID(tenantID id.ID, method string, path string) (id.ID, error) {
+	var record struct {
+		ID id.ID `json:"id"`
+	}
+	err := c.do(tenantID, request{
+		method: method,
+		path:   path,
+		status: http.StatusOK,
+		retry:  true,
+	}, &record)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	return record.ID, nil
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package coursed
+
+import (
+	"net/http"
+
+	"ac9/glad/pkg/id"
+)
+
+// AddParticipant registers the account to the course and returns the
+// participant id. Not retried; a resent registration is a conflict.
+func (c *Client) AddParticipant(tenantID id.ID, courseID id.ID, accountID id.ID, email string) (id.ID, error) {
+	var response struct {
+		ID id.ID `json:"id"`
+	}
+	err := c.do(tenantID, request{
+		method: http.MethodPost,
+		path:   "/v1/participants/" + courseID.String(),
+		body: struct {
+			AccountID id.ID  `json:"accountID"`
+			Email     string `json:"email,omitempty"`
+		}{accountID, email},
+		status: http.StatusCreated,
+	}, &response)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	return response.ID, nil
+}
+
+// RemoveParticipant removes the participant from the course
+func (c *Client) RemoveParticipant(tenantID id.ID, courseID id.ID, participantID id.ID) error {
+	return c.do(tenantID, request{
+		method: http.MethodDelete,
+		path:   "/v1/participants/" + courseID.String() + "/" + participantID.String(),
+		status: http.StatusOK,
+		retry:  true,
+	}, nil)
+}
@@ -37,3 +37,27 @@ var ErrAlreadyExists = errors.New("already exists")
 
 // ErrMissingParam missing parameter
 var ErrMissingParam = errors.New("missing parameter")
+
+// ErrCourseFull course has reached maximum attendees
+var ErrCourseFull = errors.New("course is full")
+
+// ErrInvalidTransition action is not permitted in the current state
+var ErrInvalidTransition = errors.New("invalid state transition")
+
+// ErrRegistrationClosed course does not accept registrations
+var ErrRegistrationClosed = errors.New("registration is closed")
+
+// ErrNotEligible teacher is not eligible to teach the product
+var ErrNotEligible = errors.New("teacher is not eligible")
+
+// ErrScheduleConflict sessions overlap for a teacher, an organizer or a center
+var ErrScheduleConflict = errors.New("schedule conflict")
+
+// ErrWaitlisted course is full; the registration is added to the waitlist
+var ErrWaitlisted = errors.New("added to the waitlist")
+
+// ErrInvalidCode check-in code is wrong or has expired
+var ErrInvalidCode = errors.New("invalid or expired code")
+
+// ErrTooManyAttempts attempts exhausted until a new code is created
+var ErrTooManyAttempts = errors.New("too many attempts")
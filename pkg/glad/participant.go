@@ -0,0 +1,26 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package glad
+
+import "time"
+
+// Note: Ideally these should be proto files and we should use grpc between services
+type Participant struct {
+	ExtID string `json:"extID"`
+	// CourseExtID is set when the course is referred to by its Salesforce id
+	CourseExtID  string    `json:"courseExtID,omitempty"`
+	AccountExtID string    `json:"accountExtID"`
+	Email        string    `json:"email"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type ParticipantResponse struct {
+	ID      int64  `json:"id"`
+	ExtID   string `json:"extID"`
+	IsError bool   `json:"isError"`
+}
@@ -31,3 +31,22 @@ type ProductResponse struct {
 	ExtID   string `json:"extID"`
 	IsError bool   `json:"isError"`
 }
+
+// TeacherEligibility product a teacher is eligible to teach; Type is primary
+// or assistant
+type TeacherEligibility struct {
+	ExtID        string    `json:"extID"`
+	ProductExtID string    `json:"productExtID"`
+	TeacherExtID string    `json:"teacherExtID"`
+	Type         string    `json:"type"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// TeacherEligibilityResponse result of the eligibility import; ID is the
+// teacher id
+type TeacherEligibilityResponse struct {
+	ID      int64  `json:"id"`
+	ExtID   string `json:"extID"`
+	IsError bool   `json:"isError"`
+}
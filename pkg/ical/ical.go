@@ -0,0 +1,304 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+// Package ical encodes iCalendar (RFC 5545) feeds
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event status
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusTentative = "TENTATIVE"
+	StatusCancelled = "CANCELLED"
+)
+
+// ContentType content type of the iCalendar feed
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	dateTimeFormat = "20060102T150405"
+	// maxLineOctets lines longer than this are folded
+	maxLineOctets = 75
+)
+
+// Event calendar event; the location of Start is the timezone of the event
+type Event struct {
+	UID         string
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Status      string
+}
+
+// Calendar calendar feed
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []*Event
+}
+
+// Encode writes the calendar with the timezones of its events
+func (c *Calendar) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	e := &encoder{w: bw}
+
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:" + c.ProdID)
+	e.line("CALSCALE:GREGORIAN")
+	e.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		e.line("X-WR-CALNAME:" + escape(c.Name))
+	}
+
+	for _, tz := range c.timezones() {
+		e.timezone(tz)
+	}
+	for _, event := range c.Events {
+		e.event(event)
+	}
+
+	e.line("END:VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+	return bw.Flush()
+}
+
+// timezone zone used by the events, with the span of the events in it
+type timezone struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+// timezones lists the zones of the events other than UTC, by name
+func (c *Calendar) timezones() []*timezone {
+	byName := map[string]*timezone{}
+	for _, event := range c.Events {
+		loc := event.Start.Location()
+		if isUTC(loc) {
+			continue
+		}
+		tz, ok := byName[loc.String()]
+		if !ok {
+			byName[loc.String()] = &timezone{loc: loc, from: event.Start, to: event.End}
+			continue
+		}
+		if event.Start.Before(tz.from) {
+			tz.from = event.Start
+		}
+		if event.End.After(tz.to) {
+			tz.to = event.End
+		}
+	}
+
+	zones := make([]*timezone, 0, len(byName))
+	for _, tz := range byName {
+		zones = append(zones, tz)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].loc.String() < zones[j].loc.String()
+	})
+	return zones
+}
+
+// transition change of the UTC offset of a zone
+type transition struct {
+	at         time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	isDST      bool
+}
+
+// transitions finds the offset changes of the zone between the times
+func transitions(loc *time.Location, from, to time.Time) []*transition {
+	var found []*transition
+	t := from
+	for t.Before(to) {
+		next := t.Add(24 * time.Hour)
+		_, before := t.In(loc).Zone()
+		_, after := next.In(loc).Zone()
+		if before != after {
+			// the offset changed within the day; narrow it down to the second
+			lo, hi := t, next
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if _, offset := mid.In(loc).Zone(); offset == before {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			at := hi.In(loc)
+			name, _ := at.Zone()
+			found = append(found, &transition{
+				at:         at,
+				offsetFrom: before,
+				offsetTo:   after,
+				name:       name,
+				isDST:      at.IsDST(),
+			})
+		}
+		t = next
+	}
+	return found
+}
+
+// encoder writes folded content lines; the first error is kept
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) line(s string) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = e.w.WriteString(fold(s) + "\r\n")
+}
+
+// timezone writes the VTIMEZONE of the zone. The observance in effect at the
+// start of the year of the first event is followed by every offset change
+// until the end of the year of the last event.
+func (e *encoder) timezone(tz *timezone) {
+	start := time.Date(tz.from.In(tz.loc).Year(), time.January, 1, 0, 0, 0, 0, tz.loc)
+	end := time.Date(tz.to.In(tz.loc).Year()+1, time.January, 1, 0, 0, 0, 0, tz.loc)
+
+	name, offset := start.Zone()
+	e.line("BEGIN:VTIMEZONE")
+	e.line("TZID:" + tz.loc.String())
+	e.observance(&transition{
+		at:         start,
+		offsetFrom: offset,
+		offsetTo:   offset,
+		name:       name,
+		isDST:      start.IsDST(),
+	})
+	for _, tr := range transitions(tz.loc, start, end) {
+		e.observance(tr)
+	}
+	e.line("END:VTIMEZONE")
+}
+
+// observance writes the STANDARD or DAYLIGHT component of the offset change;
+// its start is the local time before the change
+func (e *encoder) observance(tr *transition) {
+	component := "STANDARD"
+	if tr.isDST {
+		component = "DAYLIGHT"
+	}
+	local := tr.at.UTC().Add(time.Duration(tr.offsetFrom) * time.Second)
+
+	e.line("BEGIN:" + component)
+	e.line("DTSTART:" + local.Format(dateTimeFormat))
+	e.line("TZOFFSETFROM:" + formatOffset(tr.offsetFrom))
+	e.line("TZOFFSETTO:" + formatOffset(tr.offsetTo))
+	if tr.name != "" {
+		e.line("TZNAME:" + escape(tr.name))
+	}
+	e.line("END:" + component)
+}
+
+func (e *encoder) event(event *Event) {
+	e.line("BEGIN:VEVENT")
+	e.line("UID:" + escape(event.UID))
+	e.line("DTSTAMP:" + event.Stamp.UTC().Format(dateTimeFormat) + "Z")
+	e.line(dateTimeProperty("DTSTART", event.Start))
+	e.line(dateTimeProperty("DTEND", event.End.In(event.Start.Location())))
+	e.line("SUMMARY:" + escape(event.Summary))
+	if event.Description != "" {
+		e.line("DESCRIPTION:" + escape(event.Description))
+	}
+	if event.Location != "" {
+		e.line("LOCATION:" + escape(event.Location))
+	}
+	if event.URL != "" {
+		e.line("URL:" + event.URL)
+	}
+	if event.Status != "" {
+		e.line("STATUS:" + event.Status)
+	}
+	e.line("END:VEVENT")
+}
+
+// dateTimeProperty formats the time in UTC, or as local time of its zone
+func dateTimeProperty(name string, t time.Time) string {
+	if isUTC(t.Location()) {
+		return name + ":" + t.UTC().Format(dateTimeFormat) + "Z"
+	}
+	return name + ";TZID=" + t.Location().String() + ":" + t.Format(dateTimeFormat)
+}
+
+func isUTC(loc *time.Location) bool {
+	return loc == time.UTC || loc.String() == "UTC"
+}
+
+// formatOffset formats the UTC offset in seconds as +HHMM (+HHMMSS when the
+// offset has seconds)
+func formatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+	if offset%60 != 0 {
+		s += fmt.Sprintf("%02d", offset%60)
+	}
+	return s
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// escape escapes a TEXT value
+func escape(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// fold folds the content line at 75 octets without splitting characters; the
+// continuation lines start with a space
+func fold(s string) string {
+	if len(s) <= maxLineOctets {
+		return s
+	}
+
+	var b strings.Builder
+	limit := maxLineOctets
+	n := 0
+	for _, r := range s {
+		size := utf8.RuneLen(r)
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			// the leading space counts towards the continuation line
+			limit = maxLineOctets - 1
+			n = 0
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
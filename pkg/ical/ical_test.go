@@ -0,0 +1,80 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	c := &Calendar{
+		ProdID: "-//test//EN",
+		Name:   "Courses",
+		Events: []*Event{
+			{
+				UID:      "1@glad",
+				Stamp:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+				Start:    time.Date(2024, 6, 1, 13, 0, 0, 0, ny),
+				End:      time.Date(2024, 6, 1, 15, 0, 0, 0, ny),
+				Summary:  "Happiness Program; Day 1",
+				Location: "1 Main St, Boston, MA 02110, US",
+				URL:      "https://example.org/courses/1",
+				Status:   StatusConfirmed,
+			},
+			{
+				UID:     "2@glad",
+				Stamp:   time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+				Start:   time.Date(2024, 6, 2, 9, 0, 0, 0, time.UTC),
+				End:     time.Date(2024, 6, 2, 10, 0, 0, 0, time.UTC),
+				Summary: "Online",
+				Status:  StatusCancelled,
+			},
+		},
+	}
+
+	var b bytes.Buffer
+	assert.Nil(t, c.Encode(&b))
+	out := b.String()
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "TZID:America/New_York\r\n")
+	// start of the year, then DST starts and ends
+	assert.Contains(t, out, "BEGIN:DAYLIGHT\r\nDTSTART:20240310T020000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0400\r\n")
+	assert.Contains(t, out, "BEGIN:STANDARD\r\nDTSTART:20241103T020000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\n")
+	assert.Contains(t, out, "DTSTART;TZID=America/New_York:20240601T130000\r\n")
+	assert.Contains(t, out, "DTEND;TZID=America/New_York:20240601T150000\r\n")
+	assert.Contains(t, out, "SUMMARY:Happiness Program\\; Day 1\r\n")
+	assert.Contains(t, out, "LOCATION:1 Main St\\, Boston\\, MA 02110\\, US\r\n")
+	assert.Contains(t, out, "DTSTART:20240602T090000Z\r\n")
+	assert.Contains(t, out, "STATUS:CANCELLED\r\n")
+	assert.Equal(t, 1, strings.Count(out, "BEGIN:VTIMEZONE"))
+}
+
+func TestFold(t *testing.T) {
+	s := "DESCRIPTION:" + strings.Repeat("ü", 80)
+	folded := fold(s)
+	for _, line := range strings.Split(folded, "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+	}
+	assert.Equal(t, s, strings.ReplaceAll(folded, "\r\n ", ""))
+	assert.Equal(t, "SUMMARY:short", fold("SUMMARY:short"))
+}
+
+func TestFormatOffset(t *testing.T) {
+	assert.Equal(t, "+0530", formatOffset(5*3600+30*60))
+	assert.Equal(t, "-1000", formatOffset(-10*3600))
+	assert.Equal(t, "+0000", formatOffset(0))
+}
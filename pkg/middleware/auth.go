@@ -0,0 +1,215 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"ac9/glad/config"
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/util"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/urfave/negroni"
+)
+
+// AccountResolver resolves the authenticated caller to an account.
+// account.UseCase satisfies this interface.
+type AccountResolver interface {
+	GetAccountByEmail(tenantID id.ID, email string) (*entity.Account, error)
+	GetAccountByCognitoID(tenantID id.ID, cognitoID string) (*entity.Account, error)
+}
+
+// authClaims claims of the cognito token
+type authClaims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// contextKey key type for the values stored in the request context
+type contextKey string
+
+const accountContextKey contextKey = "account"
+
+// ErrAuthNotConfigured token verification is not configured and the insecure
+// mode is not enabled
+var ErrAuthNotConfigured = errors.New("AUTH_JWKS is not configured")
+
+// Auth verifies the bearer token and resolves the caller
+type Auth struct {
+	keys     *JWKS
+	issuer   string
+	audience string
+	accounts AccountResolver
+	// serviceToken bearer token of the service account; disabled when empty
+	serviceToken string
+}
+
+// NewAuth creates the authentication middleware. Issuer and audience are
+// checked only when they are set. When accounts is nil, the caller is not
+// resolved to an account (e.g. services that do not own the account table).
+// The callers presenting serviceToken, when set, act as the service account.
+func NewAuth(keys *JWKS, issuer, audience string, accounts AccountResolver, serviceToken string) *Auth {
+	return &Auth{
+		keys:         keys,
+		issuer:       issuer,
+		audience:     audience,
+		accounts:     accounts,
+		serviceToken: serviceToken,
+	}
+}
+
+// AccountFromContext retrieves the authenticated account from the context
+func AccountFromContext(ctx context.Context) *entity.Account {
+	account, _ := ctx.Value(accountContextKey).(*entity.Account)
+	return account
+}
+
+// WithAccount stores the authenticated account in the context
+func WithAccount(ctx context.Context, account *entity.Account) context.Context {
+	return context.WithValue(ctx, accountContextKey, account)
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="glad"`)
+	w.WriteHeader(http.StatusUnauthorized)
+	_, _ = w.Write([]byte(message))
+}
+
+func (a *Auth) parse(bearerToken string) (*authClaims, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithExpirationRequired(),
+	}
+	if a.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.issuer))
+	}
+	if a.audience != "" {
+		opts = append(opts, jwt.WithAudience(a.audience))
+	}
+
+	claims := &authClaims{}
+	_, err := jwt.ParseWithClaims(bearerToken, claims, a.keys.Keyfunc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (a *Auth) resolve(tenantID id.ID, claims *authClaims) (*entity.Account, error) {
+	if claims.Email != "" {
+		account, err := a.accounts.GetAccountByEmail(tenantID, claims.Email)
+		if err == nil {
+			return account, nil
+		}
+	}
+	return a.accounts.GetAccountByCognitoID(tenantID, claims.Subject)
+}
+
+func (a *Auth) isServiceToken(bearerToken string) bool {
+	return a.serviceToken != "" &&
+		subtle.ConstantTimeCompare([]byte(bearerToken), []byte(a.serviceToken)) == 1
+}
+
+// authenticateService continues the request of a glad service as the service account
+func (a *Auth) authenticateService(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	tenantID, err := id.FromString(r.Header.Get(common.HttpHeaderTenantID))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Missing tenant ID"))
+		return
+	}
+
+	next(w, r.WithContext(WithAccount(r.Context(), entity.NewServiceAccount(tenantID))))
+}
+
+// Authenticate verifies the bearer token; unauthenticated requests are rejected with 401.
+// Account headers sent by the client are overwritten with the verified values.
+func (a *Auth) Authenticate(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	r.Header.Del(common.HttpHeaderAccountID)
+	r.Header.Del(common.HttpHeaderAccountEmail)
+
+	authHeader := r.Header.Get(common.HttpHeaderAuthorization)
+	if !strings.HasPrefix(authHeader, common.HttpHeaderBearer) {
+		unauthorized(w, "Missing bearer token")
+		return
+	}
+
+	bearerToken := strings.TrimPrefix(authHeader, common.HttpHeaderBearer)
+	if a.isServiceToken(bearerToken) {
+		a.authenticateService(w, r, next)
+		return
+	}
+
+	claims, err := a.parse(bearerToken)
+	if err != nil {
+		l.Log.Warnf("Invalid token err=%v", err)
+		unauthorized(w, "Invalid token")
+		return
+	}
+
+	if claims.Email != "" {
+		r.Header.Set(common.HttpHeaderAccountEmail, claims.Email)
+	}
+
+	if a.accounts == nil {
+		next(w, r)
+		return
+	}
+
+	tenantID, err := id.FromString(r.Header.Get(common.HttpHeaderTenantID))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Missing tenant ID"))
+		return
+	}
+
+	account, err := a.resolve(tenantID, claims)
+	if err != nil || account == nil {
+		l.Log.Warnf("Unable to resolve account email=%v, sub=%v, err=%v",
+			claims.Email, claims.Subject, err)
+		unauthorized(w, "Unknown account")
+		return
+	}
+
+	r.Header.Set(common.HttpHeaderAccountID, account.ID.String())
+	next(w, r.WithContext(WithAccount(r.Context(), account)))
+}
+
+// Authentication returns the authentication middleware as per the configuration.
+// AUTH_JWKS is required; without it, the default account is used only when
+// AUTH_INSECURE is explicitly set (development).
+func Authentication(accounts AccountResolver) (negroni.HandlerFunc, error) {
+	source := util.GetStrEnvOrConfig("AUTH_JWKS", config.AUTH_JWKS)
+	if source == "" {
+		if !util.GetBoolEnvOrConfig("AUTH_INSECURE", config.AUTH_INSECURE) {
+			return nil, ErrAuthNotConfigured
+		}
+		l.Log.Warnf("Token verification is disabled (AUTH_INSECURE), using default account")
+		return AddDefaultAccount, nil
+	}
+
+	keys, err := NewJWKS(source)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := NewAuth(keys,
+		util.GetStrEnvOrConfig("AUTH_ISSUER", config.AUTH_ISSUER),
+		util.GetStrEnvOrConfig("AUTH_AUDIENCE", config.AUTH_AUDIENCE),
+		accounts,
+		util.GetStrEnvOrConfig("AUTH_SERVICE_TOKEN", config.AUTH_SERVICE_TOKEN),
+	)
+	return auth.Authenticate, nil
+}
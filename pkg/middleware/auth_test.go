@@ -0,0 +1,221 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+
+	mock "ac9/glad/usecase/account/mock"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice    id.ID = 7264348473653242881
+	accountIDAlice id.ID = 13790492210917010000
+
+	authKeyID    = "alice-key"
+	authIssuer   = "https://cognito.wonder.land/pool"
+	authAudience = "glad-app"
+	aliceEmail   = "alice@wonder.land"
+	serviceToken = "white-rabbit"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+// newTestJWKS writes the public key to a JWKS file and loads it
+func newTestJWKS(t *testing.T, key *rsa.PrivateKey) *JWKS {
+	set := map[string][]jsonWebKey{
+		"keys": {{
+			Kty: "RSA",
+			Kid: authKeyID,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	data, _ := json.Marshal(set)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.Nil(t, os.WriteFile(path, data, 0600))
+
+	keys, err := NewJWKS(path)
+	assert.Nil(t, err)
+	return keys
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, claims authClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = authKeyID
+	signed, err := token.SignedString(key)
+	assert.Nil(t, err)
+	return signed
+}
+
+func Test_Authenticate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	accounts := mock.NewMockUseCase(controller)
+	auth := NewAuth(newTestJWKS(t, key), authIssuer, authAudience, accounts, serviceToken)
+
+	alice := &entity.Account{ID: accountIDAlice, TenantID: tenantAlice, Email: aliceEmail}
+	validClaims := authClaims{
+		Email: aliceEmail,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "aws:cognito:alice",
+			Issuer:    authIssuer,
+			Audience:  jwt.ClaimStrings{authAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	serve := func(token string) (*httptest.ResponseRecorder, *http.Request) {
+		var seen *http.Request
+		req, _ := http.NewRequest(http.MethodGet, "/v1/courses", nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		// forged header must never reach the handler
+		req.Header.Set(common.HttpHeaderAccountID, "1")
+		if token != "" {
+			req.Header.Set(common.HttpHeaderAuthorization, common.HttpHeaderBearer+token)
+		}
+		rr := httptest.NewRecorder()
+		auth.Authenticate(rr, req, func(w http.ResponseWriter, r *http.Request) {
+			seen = r
+		})
+		return rr, seen
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		accounts.EXPECT().GetAccountByEmail(tenantAlice, aliceEmail).Return(alice, nil)
+		rr, seen := serve(signToken(t, key, validClaims))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotNil(t, seen)
+		assert.Equal(t, accountIDAlice.String(), seen.Header.Get(common.HttpHeaderAccountID))
+		assert.Equal(t, aliceEmail, seen.Header.Get(common.HttpHeaderAccountEmail))
+		assert.Equal(t, alice, AccountFromContext(seen.Context()))
+	})
+
+	t.Run("cognito id", func(t *testing.T) {
+		accounts.EXPECT().GetAccountByEmail(tenantAlice, aliceEmail).Return(nil, glad.ErrNotFound)
+		accounts.EXPECT().GetAccountByCognitoID(tenantAlice, "aws:cognito:alice").Return(alice, nil)
+		rr, seen := serve(signToken(t, key, validClaims))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, accountIDAlice.String(), seen.Header.Get(common.HttpHeaderAccountID))
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		rr, seen := serve("")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Nil(t, seen)
+	})
+
+	t.Run("forged signature", func(t *testing.T) {
+		rr, seen := serve(signToken(t, otherKey, validClaims))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Nil(t, seen)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		claims := validClaims
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		rr, _ := serve(signToken(t, key, claims))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		claims := validClaims
+		claims.Audience = jwt.ClaimStrings{"other-app"}
+		rr, _ := serve(signToken(t, key, claims))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		claims := validClaims
+		claims.Issuer = "https://evil.example"
+		rr, _ := serve(signToken(t, key, claims))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("service token", func(t *testing.T) {
+		rr, seen := serve(serviceToken)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "", seen.Header.Get(common.HttpHeaderAccountID))
+		account := AccountFromContext(seen.Context())
+		assert.Equal(t, entity.AccountService, account.Type)
+		assert.Equal(t, tenantAlice, account.TenantID)
+	})
+
+	t.Run("wrong service token", func(t *testing.T) {
+		rr, seen := serve("black-rabbit")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Nil(t, seen)
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		accounts.EXPECT().GetAccountByEmail(tenantAlice, aliceEmail).Return(nil, glad.ErrNotFound)
+		accounts.EXPECT().GetAccountByCognitoID(tenantAlice, "aws:cognito:alice").Return(nil, glad.ErrNotFound)
+		rr, _ := serve(signToken(t, key, validClaims))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func Test_Authentication(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		t.Setenv("AUTH_JWKS", "")
+		t.Setenv("AUTH_INSECURE", "false")
+		_, err := Authentication(nil)
+		assert.Equal(t, ErrAuthNotConfigured, err)
+	})
+
+	t.Run("insecure overwrites account header", func(t *testing.T) {
+		t.Setenv("AUTH_JWKS", "")
+		t.Setenv("AUTH_INSECURE", "true")
+		t.Setenv("DEFAULT_ACCOUNT", accountIDAlice.String())
+		authenticate, err := Authentication(nil)
+		assert.Nil(t, err)
+
+		r := httptest.NewRequest(http.MethodGet, "/v1/courses", nil)
+		r.Header.Set(common.HttpHeaderAccountID, "42")
+		var accountID string
+		authenticate(httptest.NewRecorder(), r, func(w http.ResponseWriter, r *http.Request) {
+			accountID = r.Header.Get(common.HttpHeaderAccountID)
+		})
+		assert.Equal(t, accountIDAlice.String(), accountID)
+	})
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/policy"
+
+	"github.com/gorilla/mux"
+	"github.com/urfave/negroni"
+)
+
+// AccountGetter retrieves the caller account when it is not in the context
+// (i.e. token verification is disabled). account.UseCase satisfies this interface.
+type AccountGetter interface {
+	GetAccount(tenantID id.ID, accountID id.ID) (*entity.Account, error)
+}
+
+// CourseGetter retrieves the course along with organizers and teachers.
+// course.UseCase satisfies this interface.
+type CourseGetter interface {
+	GetCourse(tenantID id.ID, courseID id.ID) (*entity.CourseFull, error)
+}
+
+// ForbiddenResponse response sent when the caller is not permitted
+type ForbiddenResponse struct {
+	Error     string        `json:"error"`
+	Action    policy.Action `json:"action"`
+	AccountID id.ID         `json:"accountID"`
+}
+
+// Authorizer enforces the policy on the routes
+type Authorizer struct {
+	policy   policy.Policy
+	accounts AccountGetter
+	courses  CourseGetter
+}
+
+// NewAuthorizer creates the authorization middleware
+func NewAuthorizer(p policy.Policy, accounts AccountGetter, courses CourseGetter) *Authorizer {
+	return &Authorizer{
+		policy:   p,
+		accounts: accounts,
+		courses:  courses,
+	}
+}
+
+func forbidden(w http.ResponseWriter, action policy.Action, account *entity.Account) {
+	response := &ForbiddenResponse{
+		Error:     "forbidden",
+		Action:    action,
+		AccountID: account.ID,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// caller gets the account of the caller
+func (a *Authorizer) caller(r *http.Request, tenantID id.ID) *entity.Account {
+	account := AccountFromContext(r.Context())
+	if account != nil || a.accounts == nil {
+		return account
+	}
+
+	accountID, err := id.FromString(r.Header.Get(common.HttpHeaderAccountID))
+	if err != nil {
+		return nil
+	}
+
+	account, err = a.accounts.GetAccount(tenantID, accountID)
+	if err != nil {
+		l.Log.Warnf("Unable to get caller account id=%v, err=%v", accountID, err)
+		return nil
+	}
+	return account
+}
+
+// courseRelation gets the relation of the caller with the course in the path.
+// Note: {courseId} is used for nested resources (e.g. participants), otherwise {id}.
+func (a *Authorizer) courseRelation(r *http.Request,
+	tenantID id.ID,
+	account *entity.Account,
+	rel *policy.Relation,
+) {
+	if a.courses == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	courseVar, ok := vars["courseId"]
+	if !ok {
+		courseVar = vars["id"]
+	}
+	courseID, err := id.FromString(courseVar)
+	if err != nil {
+		return
+	}
+
+	courseFull, err := a.courses.GetCourse(tenantID, courseID)
+	if err != nil || courseFull == nil {
+		return
+	}
+
+	for _, co := range courseFull.Cos {
+		if co.ID == account.ID {
+			rel.CourseOrganizer = true
+		}
+	}
+	for _, ct := range courseFull.Cts {
+		if ct.ID == account.ID {
+			rel.CourseTeacher = true
+		}
+	}
+}
+
+// Require permits the request only when the caller is allowed to perform the action
+func (a *Authorizer) Require(action policy.Action) negroni.HandlerFunc {
+	return a.RequireFunc(func(*http.Request) policy.Action {
+		return action
+	})
+}
+
+// RequireFunc is similar to Require, but the action is derived from the
+// request (e.g. from a path variable)
+func (a *Authorizer) RequireFunc(actionOf func(r *http.Request) policy.Action) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		action := actionOf(r)
+
+		account := a.caller(r, tenantID)
+		if account == nil {
+			unauthorized(w, "Unknown account")
+			return
+		}
+
+		var rel policy.Relation
+		rule, _ := a.policy.Rule(action)
+		if rule.Self {
+			accountID, err := id.FromString(mux.Vars(r)["id"])
+			rel.Self = err == nil && accountID == account.ID
+		}
+		if rule.NeedsCourse() {
+			a.courseRelation(r, tenantID, account, &rel)
+		}
+
+		if !a.policy.IsAllowed(account, action, rel) {
+			l.Log.Warnf("Forbidden account id=%v, type=%v, action=%v", account.ID, account.Type, action)
+			forbidden(w, action, account)
+			return
+		}
+
+		next(w, r.WithContext(WithAccount(r.Context(), account)))
+	}
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package middleware
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	l "ac9/glad/pkg/logger"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksRefreshInterval minimum interval between two JWKS reloads
+const jwksRefreshInterval = time.Minute
+
+var (
+	// ErrUnknownKey signing key is not part of the key set
+	ErrUnknownKey = errors.New("unknown signing key")
+)
+
+// jsonWebKey RSA public key as published in the JWKS
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS JSON web key set used to verify the token signature
+// Note: Only RSA keys are supported as cognito signs the tokens with RS256.
+type JWKS struct {
+	source   string
+	keys     map[string]*rsa.PublicKey
+	loadedAt time.Time
+	mut      *sync.RWMutex
+}
+
+// NewJWKS loads the key set from a file path or an http(s) URL
+func NewJWKS(source string) (*JWKS, error) {
+	k := &JWKS{
+		source: source,
+		keys:   map[string]*rsa.PublicKey{},
+		mut:    &sync.RWMutex{},
+	}
+
+	err := k.load()
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// isURL checks whether the key set is fetched over http
+func (k *JWKS) isURL() bool {
+	return strings.HasPrefix(k.source, "http://") || strings.HasPrefix(k.source, "https://")
+}
+
+func (k *JWKS) read() ([]byte, error) {
+	if !k.isURL() {
+		return os.ReadFile(k.source)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(k.source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch jwks, status=%v", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (k *JWKS) load() error {
+	data, err := k.read()
+	if err != nil {
+		return err
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err = json.Unmarshal(data, &set)
+	if err != nil {
+		return err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" {
+			l.Log.Debugf("Skipping unsupported key kid=%v, kty=%v", jwk.Kid, jwk.Kty)
+			continue
+		}
+
+		pub, err := jwk.publicKey()
+		if err != nil {
+			l.Log.Warnf("Unable to parse key kid=%v, err=%v", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = pub
+	}
+
+	if len(keys) == 0 {
+		return fmt.Errorf("no usable keys in jwks source=%v", k.source)
+	}
+
+	k.mut.Lock()
+	defer k.mut.Unlock()
+
+	k.keys = keys
+	k.loadedAt = time.Now()
+	return nil
+}
+
+func (jwk jsonWebKey) publicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// Keyfunc returns the key used to verify the token. Remote key sets are
+// reloaded when the key id is not known (e.g. after key rotation).
+func (k *JWKS) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	k.mut.RLock()
+	key, ok := k.keys[kid]
+	stale := time.Since(k.loadedAt) > jwksRefreshInterval
+	k.mut.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+
+	if k.isURL() && stale {
+		err := k.load()
+		if err != nil {
+			l.Log.Warnf("Unable to reload jwks err=%v", err)
+			return nil, ErrUnknownKey
+		}
+
+		k.mut.RLock()
+		key, ok = k.keys[kid]
+		k.mut.RUnlock()
+		if ok {
+			return key, nil
+		}
+	}
+
+	return nil, ErrUnknownKey
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package policy
+
+import (
+	"ac9/glad/entity"
+)
+
+// Action operation performed on a resource
+type Action string
+
+const (
+	CourseRead   Action = "course:read"
+	CourseCreate Action = "course:create"
+	CourseUpdate Action = "course:update"
+	CourseDelete Action = "course:delete"
+	CourseImport Action = "course:import"
+	// CourseConflictRead lists the schedule conflicts, when planning courses
+	CourseConflictRead Action = "course:conflicts"
+
+	CenterRead   Action = "center:read"
+	CenterWrite  Action = "center:write"
+	CenterImport Action = "center:import"
+
+	ProductRead   Action = "product:read"
+	ProductWrite  Action = "product:write"
+	ProductImport Action = "product:import"
+
+	TenantRead  Action = "tenant:read"
+	TenantWrite Action = "tenant:write"
+
+	AccountRead   Action = "account:read"
+	AccountWrite  Action = "account:write"
+	AccountImport Action = "account:import"
+
+	ParticipantRead   Action = "participant:read"
+	ParticipantWrite  Action = "participant:write"
+	ParticipantImport Action = "participant:import"
+	// ParticipantSelf registers the caller to a course
+	ParticipantSelf Action = "participant:self"
+	// WaitlistConfirm confirms the seat held for the caller on the waitlist
+	WaitlistConfirm Action = "waitlist:confirm"
+
+	AttendanceRead  Action = "attendance:read"
+	AttendanceWrite Action = "attendance:write"
+	// AttendanceSelf checks the caller in to a session and reads the caller's
+	// own attendance
+	AttendanceSelf Action = "attendance:self"
+
+	AuditRead Action = "audit:read"
+	// AuditChangesRead reads the change feed of the export to Salesforce
+	AuditChangesRead Action = "audit:changes:read"
+
+	DeadLetterRead  Action = "deadletter:read"
+	DeadLetterWrite Action = "deadletter:write"
+
+	EligibilityRead   Action = "eligibility:read"
+	EligibilityWrite  Action = "eligibility:write"
+	EligibilityImport Action = "eligibility:import"
+	// Add new actions here
+)
+
+// CourseTransition action that performs the course lifecycle action
+func CourseTransition(action entity.CourseAction) Action {
+	return Action("course:transition:" + string(action))
+}
+
+// Relation relationship of the caller with the resource being accessed
+type Relation struct {
+	// Self caller is accessing its own account
+	Self bool
+	// CourseOrganizer caller is an organizer of the course (course_organizer)
+	CourseOrganizer bool
+	// CourseTeacher caller is a teacher of the course (course_teacher)
+	CourseTeacher bool
+}
+
+// Rule who is permitted to perform an action
+type Rule struct {
+	// AnyAccount any authenticated account is permitted
+	AnyAccount bool
+	// Types account types that are permitted irrespective of the relation
+	Types []entity.AccountType
+
+	// Relations that permit the action
+	Self            bool
+	CourseOrganizer bool
+	CourseTeacher   bool
+}
+
+// NeedsCourse checks whether the rule depends on the course relationship
+func (r Rule) NeedsCourse() bool {
+	return r.CourseOrganizer || r.CourseTeacher
+}
+
+// Policy maps actions to rules. Actions without a rule are denied.
+type Policy map[Action]Rule
+
+// coordinators manage the tenant data
+var coordinators = []entity.AccountType{
+	entity.AccountCoOrdinator,
+}
+
+// importers sync the tenant data with Salesforce: the coordinators and the glad
+// services (sfsyncd) acting as the service account
+var importers = []entity.AccountType{
+	entity.AccountCoOrdinator,
+	entity.AccountService,
+}
+
+// courseCreators can schedule new courses
+var courseCreators = []entity.AccountType{
+	entity.AccountCoOrdinator,
+	entity.AccountTeacher,
+	entity.AccountAssistantTeacher,
+	entity.AccountOrganizer,
+}
+
+// courseTransitions who may trigger each course lifecycle action. Approvals
+// are left to the coordinators; the course team runs the course itself.
+var courseTransitions = map[entity.CourseAction]Rule{
+	entity.CourseActionSubmit:         {Types: coordinators, CourseOrganizer: true, CourseTeacher: true},
+	entity.CourseActionApprove:        {Types: coordinators},
+	entity.CourseActionDecline:        {Types: coordinators},
+	entity.CourseActionOpen:           {Types: coordinators, CourseOrganizer: true, CourseTeacher: true},
+	entity.CourseActionClose:          {Types: coordinators, CourseOrganizer: true, CourseTeacher: true},
+	entity.CourseActionCancel:         {Types: coordinators, CourseOrganizer: true},
+	entity.CourseActionSubmitExpense:  {Types: coordinators, CourseOrganizer: true, CourseTeacher: true},
+	entity.CourseActionDeclineExpense: {Types: coordinators},
+	entity.CourseActionDeactivate:     {Types: coordinators},
+	entity.CourseActionReactivate:     {Types: coordinators},
+	entity.CourseActionArchive:        {Types: coordinators},
+}
+
+// Default policy used by coursed
+func Default() Policy {
+	p := Policy{
+		CourseRead:   {AnyAccount: true},
+		CourseCreate: {Types: courseCreators},
+		CourseUpdate: {Types: coordinators, CourseOrganizer: true, CourseTeacher: true},
+		CourseDelete: {Types: coordinators, CourseOrganizer: true},
+		CourseImport: {Types: importers},
+
+		CourseConflictRead: {Types: courseCreators},
+
+		CenterRead:   {AnyAccount: true},
+		CenterWrite:  {Types: coordinators},
+		CenterImport: {Types: importers},
+
+		ProductRead:   {AnyAccount: true},
+		ProductWrite:  {Types: coordinators},
+		ProductImport: {Types: importers},
+
+		TenantRead:  {Types: coordinators},
+		TenantWrite: {Types: coordinators},
+
+		AccountRead:   {AnyAccount: true},
+		AccountWrite:  {Types: coordinators, Self: true},
+		AccountImport: {Types: importers},
+
+		ParticipantRead:   {Types: coordinators, CourseOrganizer: true, CourseTeacher: true},
+		ParticipantWrite:  {Types: importers, CourseOrganizer: true, CourseTeacher: true},
+		ParticipantImport: {Types: importers},
+		ParticipantSelf:   {AnyAccount: true},
+		WaitlistConfirm:   {AnyAccount: true},
+
+		AttendanceRead:  {Types: coordinators, CourseOrganizer: true, CourseTeacher: true},
+		AttendanceWrite: {Types: coordinators, CourseOrganizer: true, CourseTeacher: true},
+		AttendanceSelf:  {AnyAccount: true},
+
+		AuditRead:        {Types: coordinators},
+		AuditChangesRead: {Types: importers},
+
+		DeadLetterRead:  {Types: coordinators},
+		DeadLetterWrite: {Types: coordinators},
+
+		EligibilityRead:   {Types: courseCreators},
+		EligibilityWrite:  {Types: coordinators},
+		EligibilityImport: {Types: importers},
+	}
+
+	for action, rule := range courseTransitions {
+		p[CourseTransition(action)] = rule
+	}
+	return p
+}
+
+// Rule gets the rule of the action
+func (p Policy) Rule(action Action) (Rule, bool) {
+	rule, ok := p[action]
+	return rule, ok
+}
+
+// IsAllowed checks whether the account is permitted to perform the action
+func (p Policy) IsAllowed(account *entity.Account, action Action, rel Relation) bool {
+	if account == nil {
+		return false
+	}
+
+	rule, ok := p[action]
+	if !ok {
+		return false
+	}
+
+	if rule.AnyAccount {
+		return true
+	}
+
+	for _, t := range rule.Types {
+		if account.Type == t {
+			return true
+		}
+	}
+
+	return (rule.Self && rel.Self) ||
+		(rule.CourseOrganizer && rel.CourseOrganizer) ||
+		(rule.CourseTeacher && rel.CourseTeacher)
+}
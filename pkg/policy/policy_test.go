@@ -0,0 +1,68 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package policy
+
+import (
+	"testing"
+
+	"ac9/glad/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAllowed(t *testing.T) {
+	p := Default()
+	coordinator := &entity.Account{Type: entity.AccountCoOrdinator}
+	teacher := &entity.Account{Type: entity.AccountTeacher}
+	student := &entity.Account{Type: entity.AccountStudent}
+	service := entity.NewServiceAccount(0)
+
+	assert.True(t, p.IsAllowed(student, CourseRead, Relation{}))
+	assert.False(t, p.IsAllowed(nil, CourseRead, Relation{}))
+
+	assert.True(t, p.IsAllowed(coordinator, TenantWrite, Relation{}))
+	assert.False(t, p.IsAllowed(teacher, TenantWrite, Relation{}))
+	assert.False(t, p.IsAllowed(student, TenantWrite, Relation{}))
+
+	assert.True(t, p.IsAllowed(teacher, CourseCreate, Relation{}))
+	assert.False(t, p.IsAllowed(student, CourseCreate, Relation{}))
+
+	// course relationship
+	assert.False(t, p.IsAllowed(teacher, CourseUpdate, Relation{}))
+	assert.True(t, p.IsAllowed(teacher, CourseUpdate, Relation{CourseTeacher: true}))
+	assert.True(t, p.IsAllowed(student, CourseUpdate, Relation{CourseOrganizer: true}))
+	assert.False(t, p.IsAllowed(teacher, CourseDelete, Relation{CourseTeacher: true}))
+	assert.True(t, p.IsAllowed(coordinator, CourseDelete, Relation{}))
+
+	// self
+	assert.True(t, p.IsAllowed(student, AccountWrite, Relation{Self: true}))
+	assert.False(t, p.IsAllowed(student, AccountWrite, Relation{}))
+	assert.True(t, p.IsAllowed(student, ParticipantSelf, Relation{}))
+	assert.False(t, p.IsAllowed(student, ParticipantWrite, Relation{}))
+
+	// service account
+	assert.True(t, p.IsAllowed(service, CourseImport, Relation{}))
+	assert.False(t, p.IsAllowed(service, AuditRead, Relation{}))
+	assert.True(t, p.IsAllowed(service, AuditChangesRead, Relation{}))
+	assert.False(t, p.IsAllowed(service, TenantWrite, Relation{}))
+	assert.False(t, p.IsAllowed(service, CourseDelete, Relation{}))
+
+	// course lifecycle
+	approve := CourseTransition(entity.CourseActionApprove)
+	cancel := CourseTransition(entity.CourseActionCancel)
+	assert.True(t, p.IsAllowed(coordinator, approve, Relation{}))
+	assert.False(t, p.IsAllowed(teacher, approve, Relation{CourseTeacher: true}))
+	assert.True(t, p.IsAllowed(student, cancel, Relation{CourseOrganizer: true}))
+	assert.False(t, p.IsAllowed(teacher, cancel, Relation{CourseTeacher: true}))
+	for _, action := range entity.CourseActions() {
+		_, ok := p.Rule(CourseTransition(action))
+		assert.True(t, ok, action)
+	}
+
+	// unknown action
+	assert.False(t, p.IsAllowed(coordinator, Action("unknown"), Relation{}))
+}
@@ -60,6 +60,53 @@ func GenBulkInsertPGSQL(
 	return queryBuilder.String(), valueArgs
 }
 
+// MaxParamsPGSQL maximum number of bind parameters in a postgres statement
+const MaxParamsPGSQL = 65535
+
+// BatchSizePGSQL number of rows with numColumns values each that fit in a
+// single postgres statement
+func BatchSizePGSQL(numColumns int) int {
+	if numColumns <= 0 {
+		return MaxParamsPGSQL
+	}
+	return MaxParamsPGSQL / numColumns
+}
+
+// GenBulkUpsertPGSQL generates bulk upsert statement for postgres
+// Rows conflicting on conflictColumn get updateColumns overwritten unless the
+// stored row is newer, i.e. has a later updated_at. Like GenBulkInsertPGSQL,
+// the statement is not terminated so that a RETURNING clause can be appended.
+func GenBulkUpsertPGSQL(
+	tableName string,
+	columns []string,
+	conflictColumn string,
+	updateColumns []string,
+	numRows int,
+	valueExtractor ValueExtractor,
+) (string, []interface{}) {
+	query, valueArgs := GenBulkInsertPGSQL(tableName, columns, numRows, valueExtractor)
+
+	var queryBuilder strings.Builder
+	queryBuilder.WriteString(query)
+	queryBuilder.WriteString(" ON CONFLICT (\"")
+	queryBuilder.WriteString(conflictColumn)
+	queryBuilder.WriteString("\") DO UPDATE SET ")
+	for i, column := range updateColumns {
+		if i > 0 {
+			queryBuilder.WriteString(",")
+		}
+		queryBuilder.WriteString("\"")
+		queryBuilder.WriteString(column)
+		queryBuilder.WriteString("\" = EXCLUDED.\"")
+		queryBuilder.WriteString(column)
+		queryBuilder.WriteString("\"")
+	}
+	queryBuilder.WriteString(" WHERE ")
+	queryBuilder.WriteString(tableName)
+	queryBuilder.WriteString(".updated_at <= EXCLUDED.updated_at")
+	return queryBuilder.String(), valueArgs
+}
+
 // GenBulkDeletePGSQL generates bulk delete statement for postgres
 func GenBulkDeletePGSQL(
 	tableName string,
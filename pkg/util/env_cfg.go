@@ -26,3 +26,12 @@ func GetIntEnvOrConfig(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func GetBoolEnvOrConfig(key string, fallback bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
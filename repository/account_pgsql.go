@@ -12,13 +12,15 @@ import (
 
 	"ac9/glad/entity"
 	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
+	"ac9/glad/usecase/account"
 )
 
 // AccountPGSQL mysql repo
 type AccountPGSQL struct {
-	db *sql.DB
+	db dbtx
 }
 
 // NewAccountPGSQL create new repository
@@ -85,14 +87,115 @@ func (r *AccountPGSQL) GetByName(tenantID id.ID, username string) (*entity.Accou
 // Update updates an account
 func (r *AccountPGSQL) Update(e *entity.Account) error {
 	e.UpdatedAt = time.Now()
-	_, err := r.db.Exec(`UPDATE account SET username = $1, type = $2, cognito_id = $3, updated_at = $4 WHERE id = $5;`,
-		e.Username, e.Type, e.CognitoID, e.UpdatedAt.Format("2006-01-02"), e.ID)
+	res, err := r.db.Exec(`UPDATE account SET username = $1, type = $2, cognito_id = $3, updated_at = $4 WHERE id = $5 AND tenant_id = $6;`,
+		e.Username, e.Type, e.CognitoID, e.UpdatedAt.Format("2006-01-02"), e.ID, e.TenantID)
 	if err != nil {
 		return err
 	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
+
+// BulkUpsert upserts the tenant's accounts keyed by external id and returns
+// their ids by external id
+func (r *AccountPGSQL) BulkUpsert(tenantID id.ID,
+	accounts []*entity.Account,
+) (map[string]id.ID, error) {
+	extIDs := make([]string, len(accounts))
+	for i, e := range accounts {
+		extIDs[i] = e.ExtID
+	}
+
+	values := func(index int) []interface{} {
+		e := accounts[index]
+		return []interface{}{
+			e.ID,
+			e.ExtID,
+			tenantID,
+			e.CognitoID,
+			e.Username,
+			e.FirstName,
+			e.LastName,
+			e.Phone,
+			e.Email,
+			string(e.Type),
+			string(e.Status),
+			e.FullPhotoURL,
+			e.CreatedAt.Format(common.DBFormatDateTimeMS),
+			e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		}
+	}
+
+	return bulkUpsert(r.db, "account", []string{
+		"id", "ext_id", "tenant_id", "cognito_id", "username", "first_name", "last_name",
+		"phone", "email", "type", "status", "full_photo_url",
+		"created_at", "updated_at",
+	}, tenantID, extIDs, values)
+}
+
+// SetStatus changes the account status
+// Note: updated_at is left as is; it tracks the Salesforce changes that the
+// upsert relies on.
+func (r *AccountPGSQL) SetStatus(tenantID id.ID, accountID id.ID, status entity.AccountStatus) error {
+	res, err := r.db.Exec(`UPDATE account SET status = $1 WHERE id = $2 AND tenant_id = $3;`,
+		status, accountID, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
+
+// SetCalendarToken sets the calendar token hash of the account; an empty hash
+// revokes the token
+func (r *AccountPGSQL) SetCalendarToken(tenantID id.ID, accountID id.ID, tokenHash string) error {
+	res, err := r.db.Exec(`UPDATE account SET calendar_token_hash = NULLIF($1, '') WHERE id = $2 AND tenant_id = $3;`,
+		tokenHash, accountID, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
 	return nil
 }
 
+// GetByCalendarToken retrieves an account using the calendar token hash
+func (r *AccountPGSQL) GetByCalendarToken(tokenHash string) (*entity.Account, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT id, tenant_id, ext_id, username, cognito_id, email, type, status, created_at
+		FROM account WHERE calendar_token_hash = $1;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var t entity.Account
+	var ext_id, username, cognito_id, email, acct_type, status sql.NullString
+	err = stmt.QueryRow(tokenHash).Scan(&t.ID, &t.TenantID, &ext_id, &username, &cognito_id, &email,
+		&acct_type, &status, &t.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	t.ExtID = ext_id.String
+	t.Username = username.String
+	t.CognitoID = cognito_id.String
+	t.Email = email.String
+	t.Type = entity.AccountType(acct_type.String)
+	t.Status = entity.AccountStatus(status.String)
+	return &t, nil
+}
+
 // List accounts
 func (r *AccountPGSQL) List(tenantID id.ID, page, limit int, at entity.AccountType) ([]*entity.Account, error) {
 
@@ -138,8 +241,8 @@ func (r *AccountPGSQL) List(tenantID id.ID, page, limit int, at entity.AccountTy
 }
 
 // Delete deletes an account
-func (r *AccountPGSQL) Delete(id id.ID) error {
-	res, err := r.db.Exec(`DELETE FROM account WHERE id = $1;`, id)
+func (r *AccountPGSQL) Delete(tenantID id.ID, accountID id.ID) error {
+	res, err := r.db.Exec(`DELETE FROM account WHERE id = $1 AND tenant_id = $2;`, accountID, tenantID)
 	if err != nil {
 		return err
 	}
@@ -181,11 +284,11 @@ func (r *AccountPGSQL) GetCount(tenantID id.ID) (int, error) {
 }
 
 // Get retrieves an account
-func (r *AccountPGSQL) Get(id id.ID) (*entity.Account, error) {
+func (r *AccountPGSQL) Get(tenantID id.ID, accountID id.ID) (*entity.Account, error) {
 	stmt, err := r.db.Prepare(`
 		SELECT id, tenant_id, ext_id, username, first_name, last_name,
 			phone, email, type, created_at
-		FROM account WHERE id = $1;`)
+		FROM account WHERE id = $1 AND tenant_id = $2;`)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +296,7 @@ func (r *AccountPGSQL) Get(id id.ID) (*entity.Account, error) {
 	var a entity.Account
 	var ext_id, first_name, last_name, phone, email, accountType sql.NullString
 
-	err = stmt.QueryRow(id).Scan(
+	err = stmt.QueryRow(accountID, tenantID).Scan(
 		&a.ID,
 		&a.TenantID,
 		&ext_id,
@@ -333,6 +436,57 @@ func (r *AccountPGSQL) GetByEmail(tenantID id.ID, email string) (*entity.Account
 	return &t, nil
 }
 
+// GetByExtID retrieves an account using external id
+func (r *AccountPGSQL) GetByExtID(tenantID id.ID, extID string) (*entity.Account, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT id, username, cognito_id, email, type, status, created_at FROM account WHERE tenant_id = $1 AND ext_id = $2;`)
+	if err != nil {
+		return nil, err
+	}
+	var t entity.Account
+	var username, cognito_id, email, acct_type, status sql.NullString
+	err = stmt.QueryRow(tenantID, extID).Scan(&t.ID, &username, &cognito_id, &email, &acct_type, &status, &t.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	t.TenantID = tenantID
+	t.ExtID = extID
+	t.Username = username.String
+	t.CognitoID = cognito_id.String
+	t.Email = email.String
+	t.Type = entity.AccountType(acct_type.String)
+	t.Status = entity.AccountStatus(status.String)
+	return &t, nil
+}
+
+// GetByCognitoID retrieves an account using cognito id
+func (r *AccountPGSQL) GetByCognitoID(tenantID id.ID, cognitoID string) (*entity.Account, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT id, ext_id, username, email, type, created_at FROM account WHERE tenant_id = $1 AND cognito_id = $2;`)
+	if err != nil {
+		return nil, err
+	}
+	var t entity.Account
+	var ext_id, username, email, acct_type sql.NullString
+	err = stmt.QueryRow(tenantID, cognitoID).Scan(&t.ID, &ext_id, &username, &email, &acct_type, &t.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	t.TenantID = tenantID
+	t.CognitoID = cognitoID
+	t.ExtID = ext_id.String
+	t.Username = username.String
+	t.Email = email.String
+	t.Type = entity.AccountType(acct_type.String)
+	return &t, nil
+}
+
 // Upsert inserts or updates the account and returns the id
 func (r *AccountPGSQL) Upsert(e *entity.Account) (id.ID, error) {
 	l.Log.Debugf("Upsert: Account=%#v", e)
@@ -347,15 +501,15 @@ func (r *AccountPGSQL) Upsert(e *entity.Account) (id.ID, error) {
 				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 			ON CONFLICT (ext_id)
 			DO UPDATE
-				SET tenant_id = $2, cognito_id = $4, username = $5, first_name = $6, last_name = $7,
+				SET cognito_id = $4, username = $5, first_name = $6, last_name = $7,
 					phone = $8, email = $9, type = $10, status = $11, full_photo_url = $12,
 					created_at = $13, updated_at = $14
-			WHERE account.updated_at <= $14
+			WHERE account.updated_at <= $14 AND account.tenant_id = EXCLUDED.tenant_id
 			RETURNING id
 		)
 		SELECT id FROM upsert
 		UNION ALL
-		SELECT id FROM account WHERE ext_id = $3 AND NOT EXISTS (SELECT 1 FROM upsert);
+		SELECT id FROM account WHERE ext_id = $3 AND tenant_id = $2 AND NOT EXISTS (SELECT 1 FROM upsert);
 	`)
 	if err != nil {
 		l.Log.Warnf("err=%v", err)
@@ -386,3 +540,20 @@ func (r *AccountPGSQL) Upsert(e *entity.Account) (id.ID, error) {
 
 	return accountID, nil
 }
+
+// QueueChange queues the change for the export
+func (r *AccountPGSQL) QueueChange(e *entity.AuditLog) error {
+	return queueChange(r.db, e)
+}
+
+// InTx runs fn with a repository bound to a transaction
+func (r *AccountPGSQL) InTx(fn func(repo account.Repository) error) error {
+	db, ok := r.db.(*sql.DB)
+	if !ok {
+		// already bound to a transaction
+		return fn(r)
+	}
+	return inTx(db, func(tx *sql.Tx) error {
+		return fn(&AccountPGSQL{db: tx})
+	})
+}
@@ -0,0 +1,297 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// AttendancePGSQL attendance repo
+type AttendancePGSQL struct {
+	db *sql.DB
+}
+
+// NewAttendancePGSQL create new repository
+func NewAttendancePGSQL(db *sql.DB) *AttendancePGSQL {
+	return &AttendancePGSQL{
+		db: db,
+	}
+}
+
+// GetCourseTimezone gets the timezone of the tenant's course
+func (r *AttendancePGSQL) GetCourseTimezone(tenantID id.ID, courseID id.ID) (string, error) {
+	var timezone sql.NullString
+	err := r.db.QueryRow(`
+		SELECT timezone FROM course WHERE id = $1 AND tenant_id = $2;`,
+		courseID, tenantID).Scan(&timezone)
+	if err == sql.ErrNoRows {
+		return "", glad.ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return timezone.String, nil
+}
+
+// ListTimings lists the sessions of the course by date
+func (r *AttendancePGSQL) ListTimings(tenantID id.ID, courseID id.ID) ([]*entity.CourseTiming, error) {
+	rows, err := r.db.Query(`
+		SELECT ct.id, ct.course_id, ct.ext_id, ct.course_date, ct.start_time, ct.end_time, ct.created_at
+		FROM course_timing ct
+		JOIN course c ON c.id = ct.course_id
+		WHERE c.tenant_id = $1 AND ct.course_id = $2
+		ORDER BY ct.course_date, ct.start_time;`,
+		tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return (&CourseTimingPGSQL{}).scanRows(rows)
+}
+
+// ListParticipants lists the participants of the course
+func (r *AttendancePGSQL) ListParticipants(tenantID id.ID, courseID id.ID) ([]*entity.Participant, error) {
+	return NewParticipantPGSQL(r.db).ListByCourse(tenantID, courseID, 0, 0)
+}
+
+// GetCourseMinAttendance gets the attendance required by the product of the course
+func (r *AttendancePGSQL) GetCourseMinAttendance(tenantID id.ID, courseID id.ID) (int, error) {
+	var percent int
+	err := r.db.QueryRow(`
+		SELECT COALESCE(ar.min_percent, 0)
+		FROM course c
+		LEFT JOIN attendance_requirement ar ON ar.product_id = c.product_id
+		WHERE c.id = $1 AND c.tenant_id = $2;`,
+		courseID, tenantID).Scan(&percent)
+	if err == sql.ErrNoRows {
+		return 0, glad.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return percent, nil
+}
+
+// ListByCourse lists the attendance of the course
+func (r *AttendancePGSQL) ListByCourse(tenantID id.ID, courseID id.ID) ([]*entity.Attendance, error) {
+	rows, err := r.db.Query(`
+		SELECT a.id, a.course_id, a.timing_id, a.participant_id, a.status, a.checked_in_at,
+			a.recorded_by, a.created_at, a.updated_at
+		FROM attendance a
+		JOIN course c ON c.id = a.course_id
+		WHERE c.tenant_id = $1 AND a.course_id = $2
+		ORDER BY a.checked_in_at, a.id;`,
+		tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// ListByTiming lists the attendance of the session
+func (r *AttendancePGSQL) ListByTiming(tenantID id.ID, timingID id.ID) ([]*entity.Attendance, error) {
+	rows, err := r.db.Query(`
+		SELECT a.id, a.course_id, a.timing_id, a.participant_id, a.status, a.checked_in_at,
+			a.recorded_by, a.created_at, a.updated_at
+		FROM attendance a
+		JOIN course c ON c.id = a.course_id
+		WHERE c.tenant_id = $1 AND a.timing_id = $2
+		ORDER BY a.checked_in_at, a.id;`,
+		tenantID, timingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// GetCode gets the check-in code of the session
+func (r *AttendancePGSQL) GetCode(tenantID id.ID, timingID id.ID) (*entity.AttendanceCode, error) {
+	var c entity.AttendanceCode
+	var createdBy sql.NullInt64
+	err := r.db.QueryRow(`
+		SELECT ac.timing_id, ac.course_id, ac.code, ac.expires_at, ac.created_by, ac.created_at
+		FROM attendance_code ac
+		JOIN course c ON c.id = ac.course_id
+		WHERE c.tenant_id = $1 AND ac.timing_id = $2;`,
+		tenantID, timingID).Scan(&c.TimingID, &c.CourseID, &c.Code, &c.ExpiresAt, &createdBy,
+		&c.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.CreatedBy = id.ID(createdBy.Int64)
+	return &c, nil
+}
+
+// GetMinAttendance gets the attendance required for completion of the product
+func (r *AttendancePGSQL) GetMinAttendance(tenantID id.ID, productID id.ID) (int, error) {
+	var percent int
+	err := r.db.QueryRow(`
+		SELECT COALESCE(ar.min_percent, 0)
+		FROM product p
+		LEFT JOIN attendance_requirement ar ON ar.product_id = p.id
+		WHERE p.id = $1 AND p.tenant_id = $2;`,
+		productID, tenantID).Scan(&percent)
+	if err == sql.ErrNoRows {
+		return 0, glad.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return percent, nil
+}
+
+// Upsert sets the attendance of the participant in the session and returns the id
+func (r *AttendancePGSQL) Upsert(e *entity.Attendance) (id.ID, error) {
+	var checkedInAt interface{}
+	if !e.CheckedInAt.IsZero() {
+		checkedInAt = e.CheckedInAt.Format(common.DBFormatDateTimeMS)
+	}
+
+	var attendanceID id.ID
+	err := r.db.QueryRow(`
+		INSERT INTO attendance (
+			id, course_id, timing_id, participant_id, status, checked_in_at, recorded_by,
+			created_at, updated_at
+		)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (timing_id, participant_id)
+		DO UPDATE
+			SET status = $5, checked_in_at = $6, recorded_by = $7, updated_at = $9
+		RETURNING id;`,
+		e.ID,
+		e.CourseID,
+		e.TimingID,
+		e.ParticipantID,
+		e.Status,
+		checkedInAt,
+		auditNullID(e.RecordedBy),
+		e.CreatedAt.Format(common.DBFormatDateTimeMS),
+		e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+	).Scan(&attendanceID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return id.IDInvalid, err
+	}
+	return attendanceID, nil
+}
+
+// SetCode replaces the check-in code of the session
+func (r *AttendancePGSQL) SetCode(e *entity.AttendanceCode) error {
+	_, err := r.db.Exec(`
+		INSERT INTO attendance_code (timing_id, course_id, code, expires_at, created_by, created_at)
+		VALUES($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (timing_id)
+		DO UPDATE
+			SET code = $3, expires_at = $4, created_by = $5, created_at = $6;`,
+		e.TimingID,
+		e.CourseID,
+		e.Code,
+		e.ExpiresAt.Format(common.DBFormatDateTimeMS),
+		auditNullID(e.CreatedBy),
+		e.CreatedAt.Format(common.DBFormatDateTimeMS),
+	)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+	}
+	return err
+}
+
+// AddCheckInAttempt counts a check-in attempt of the account with the code and
+// returns the attempts made with it; the attempts restart with a new code
+func (r *AttendancePGSQL) AddCheckInAttempt(accountID id.ID, c *entity.AttendanceCode) (int, error) {
+	var attempts int
+	err := r.db.QueryRow(`
+		INSERT INTO attendance_check_in_attempt (timing_id, account_id, code_created_at, attempts)
+		VALUES($1, $2, $3, 1)
+		ON CONFLICT (timing_id, account_id)
+		DO UPDATE
+			SET attempts = CASE
+					WHEN attendance_check_in_attempt.code_created_at = EXCLUDED.code_created_at
+					THEN attendance_check_in_attempt.attempts + 1
+					ELSE 1
+				END,
+				code_created_at = EXCLUDED.code_created_at
+		RETURNING attempts;`,
+		c.TimingID,
+		accountID,
+		c.CreatedAt.Format(common.DBFormatDateTimeMS),
+	).Scan(&attempts)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return 0, err
+	}
+	return attempts, nil
+}
+
+// SetMinAttendance sets the attendance required for completion of the product
+// Note: The requirement is set only when the product belongs to the tenant.
+func (r *AttendancePGSQL) SetMinAttendance(tenantID id.ID, productID id.ID, percent int) error {
+	res, err := r.db.Exec(`
+		INSERT INTO attendance_requirement (product_id, min_percent)
+		SELECT $1, $2
+		WHERE EXISTS (SELECT 1 FROM product WHERE id = $1 AND tenant_id = $3)
+		ON CONFLICT (product_id)
+		DO UPDATE
+			SET min_percent = $2, updated_at = CURRENT_TIMESTAMP;`,
+		productID, percent, tenantID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AttendancePGSQL) scanRows(rows *sql.Rows) ([]*entity.Attendance, error) {
+	var records []*entity.Attendance
+	for rows.Next() {
+		var a entity.Attendance
+		var checkedInAt sql.NullTime
+		var recordedBy sql.NullInt64
+
+		err := rows.Scan(
+			&a.ID,
+			&a.CourseID,
+			&a.TimingID,
+			&a.ParticipantID,
+			&a.Status,
+			&checkedInAt,
+			&recordedBy,
+			&a.CreatedAt,
+			&a.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if checkedInAt.Valid {
+			a.CheckedInAt = checkedInAt.Time
+		}
+		a.RecordedBy = id.ID(recordedBy.Int64)
+
+		records = append(records, &a)
+	}
+
+	return records, nil
+}
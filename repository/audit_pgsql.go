@@ -0,0 +1,213 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// AuditPGSQL audit log repo
+type AuditPGSQL struct {
+	db *sql.DB
+}
+
+// NewAuditPGSQL create new repository
+func NewAuditPGSQL(db *sql.DB) *AuditPGSQL {
+	return &AuditPGSQL{
+		db: db,
+	}
+}
+
+// Create creates an audit log
+func (r *AuditPGSQL) Create(e *entity.AuditLog) (id.ID, error) {
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return e.ID, err
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO audit_log (id, tenant_id, entity_type, entity_id, operation, source,
+			actor_id, before, after, changes, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+		`,
+		e.ID, e.TenantID, e.EntityType, e.EntityID, e.Operation, e.Source,
+		auditNullID(e.ActorID), auditNullJSON(e.Before), auditNullJSON(e.After), changes,
+		e.CreatedAt.Format(common.DBFormatDateTimeMS))
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// List lists the audit logs of an entity type, newest first
+func (r *AuditPGSQL) List(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	entityID id.ID,
+	page, limit int,
+) (int, []*entity.AuditLog, error) {
+	where := ` WHERE tenant_id = $1 AND entity_type = $2`
+	args := []interface{}{tenantID, entityType}
+	if entityID != id.IDInvalid {
+		where += ` AND entity_id = $3`
+		args = append(args, entityID)
+	}
+
+	var count int
+	err := r.db.QueryRow(`SELECT count(*) FROM audit_log`+where+`;`, args...).Scan(&count)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return 0, nil, err
+	}
+	if count == 0 {
+		return 0, nil, nil
+	}
+
+	query := `
+		SELECT id, tenant_id, entity_type, entity_id, operation, source,
+			actor_id, before, after, changes, created_at
+		FROM audit_log` + where + `
+		ORDER BY created_at DESC, id DESC`
+	if page > 0 && limit > 0 {
+		offset := (page - 1) * limit
+		query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+		args = append(args, limit, offset)
+	}
+
+	rows, err := r.db.Query(query+";", args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return count, nil, err
+	}
+	defer rows.Close()
+
+	logs, err := r.scanRows(rows)
+	return count, logs, err
+}
+
+// ListChanges lists the changes in the outbox after the seq, oldest first
+func (r *AuditPGSQL) ListChanges(tenantID id.ID,
+	after int64,
+	limit int,
+) ([]*entity.AuditLog, error) {
+	query := `
+		SELECT seq, id, tenant_id, entity_type, entity_id, operation, source,
+			actor_id, after, created_at
+		FROM change_outbox
+		WHERE tenant_id = $1 AND seq > $2
+		ORDER BY seq`
+	args := []interface{}{tenantID, after}
+	if limit > 0 {
+		query += ` LIMIT $3`
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.Query(query+";", args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []*entity.AuditLog
+	for rows.Next() {
+		var e entity.AuditLog
+		var actorID sql.NullInt64
+		var after []byte
+		err := rows.Scan(&e.Seq, &e.ID, &e.TenantID, &e.EntityType, &e.EntityID, &e.Operation,
+			&e.Source, &actorID, &after, &e.CreatedAt)
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return nil, err
+		}
+		e.ActorID = id.ID(actorID.Int64)
+		if len(after) > 0 {
+			e.After = json.RawMessage(after)
+		}
+		logs = append(logs, &e)
+	}
+	return logs, rows.Err()
+}
+
+// queueChange adds the change to the outbox of the export. db is the
+// transaction of the change.
+// Note: The outbox of the tenant stays locked until the transaction ends, so
+// that seq follows the commit order and the export never skips a change
+// committed after a later seq was listed.
+func queueChange(db dbtx, e *entity.AuditLog) error {
+	_, err := db.Exec(`SELECT pg_advisory_xact_lock(hashtextextended('change_outbox', $1));`,
+		e.TenantID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return err
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO change_outbox (id, tenant_id, entity_type, entity_id, operation, source,
+			actor_id, after, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9);
+		`,
+		e.ID, e.TenantID, e.EntityType, e.EntityID, e.Operation, e.Source,
+		auditNullID(e.ActorID), auditNullJSON(e.After),
+		e.CreatedAt.Format(common.DBFormatDateTimeMS))
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+	}
+	return err
+}
+
+// scanRows scans the audit logs
+func (r *AuditPGSQL) scanRows(rows *sql.Rows) ([]*entity.AuditLog, error) {
+	var logs []*entity.AuditLog
+	for rows.Next() {
+		var e entity.AuditLog
+		var actorID sql.NullInt64
+		var before, after, changes []byte
+		err := rows.Scan(&e.ID, &e.TenantID, &e.EntityType, &e.EntityID, &e.Operation,
+			&e.Source, &actorID, &before, &after, &changes, &e.CreatedAt)
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return nil, err
+		}
+		e.ActorID = id.ID(actorID.Int64)
+		if len(before) > 0 {
+			e.Before = json.RawMessage(before)
+		}
+		if len(after) > 0 {
+			e.After = json.RawMessage(after)
+		}
+		if len(changes) > 0 {
+			err = json.Unmarshal(changes, &e.Changes)
+			if err != nil {
+				l.Log.Warnf("err=%v", err)
+				return nil, err
+			}
+		}
+		logs = append(logs, &e)
+	}
+	return logs, nil
+}
+
+// auditNullID stores unknown actors as NULL
+func auditNullID(v id.ID) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(v), Valid: v != id.IDInvalid}
+}
+
+// auditNullJSON stores missing snapshots as NULL
+func auditNullJSON(v json.RawMessage) interface{} {
+	if len(v) == 0 {
+		return nil
+	}
+	return []byte(v)
+}
@@ -16,11 +16,12 @@ import (
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
+	"ac9/glad/usecase/center"
 )
 
 // CenterPGSQL mysql repo
 type CenterPGSQL struct {
-	db *sql.DB
+	db dbtx
 }
 
 // NewCenterPGSQL create new repository
@@ -34,7 +35,7 @@ func NewCenterPGSQL(db *sql.DB) *CenterPGSQL {
 func (r *CenterPGSQL) Create(e *entity.Center) (id.ID, error) {
 	stmt, err := r.db.Prepare(`
 		INSERT INTO center (id, tenant_id, name, address, geo_location,
-		 capacity, mode, web_page, is_national, is_enabled, created_at)
+		 capacity, mode, timezone, web_page, is_national, is_enabled, created_at)
 		VALUES( $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`)
 	if err != nil {
 		return e.ID, err
@@ -47,6 +48,7 @@ func (r *CenterPGSQL) Create(e *entity.Center) (id.ID, error) {
 		e.GeoLocation, // TODO: to be converted into json
 		e.Capacity,
 		e.Mode,
+		e.Timezone,
 		e.WebPage,
 		e.IsNational,
 		e.IsEnabled,
@@ -64,9 +66,9 @@ func (r *CenterPGSQL) Create(e *entity.Center) (id.ID, error) {
 
 // Get retrieves a center
 // Not all fields are required for v1
-func (r *CenterPGSQL) Get(id id.ID) (*entity.Center, error) {
+func (r *CenterPGSQL) Get(tenantID id.ID, centerID id.ID) (*entity.Center, error) {
 	stmt, err := r.db.Prepare(`
-		SELECT id, tenant_id, name, ext_name, mode, created_at FROM center WHERE id = $1;`)
+		SELECT id, tenant_id, ext_id, name, ext_name, mode, timezone, created_at FROM center WHERE id = $1 AND tenant_id = $2;`)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +76,9 @@ func (r *CenterPGSQL) Get(id id.ID) (*entity.Center, error) {
 	var extName sql.NullString
 	var name sql.NullString
 	var mode sql.NullString
-	err = stmt.QueryRow(id).Scan(&c.ID, &c.TenantID, &name, &extName, &mode, &c.CreatedAt)
+	var timezone sql.NullString
+	err = stmt.QueryRow(centerID, tenantID).Scan(&c.ID, &c.TenantID, &c.ExtID, &name, &extName, &mode, &timezone,
+		&c.CreatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -84,6 +88,7 @@ func (r *CenterPGSQL) Get(id id.ID) (*entity.Center, error) {
 	c.Name = name.String
 	c.ExtName = extName.String
 	c.Mode = entity.CenterMode(mode.String)
+	c.Timezone = timezone.String
 
 	return &c, nil
 }
@@ -91,12 +96,90 @@ func (r *CenterPGSQL) Get(id id.ID) (*entity.Center, error) {
 // Update updates a center
 func (r *CenterPGSQL) Update(e *entity.Center) error {
 	e.UpdatedAt = time.Now()
-	_, err := r.db.Exec(`
-		UPDATE center SET name = $1, mode = $2, updated_at = $3 WHERE id = $4;`,
-		e.Name, e.Mode, e.UpdatedAt.Format("2006-01-02"), e.ID)
+	res, err := r.db.Exec(`
+		UPDATE center SET name = $1, mode = $2, timezone = $3, updated_at = $4 WHERE id = $5 AND tenant_id = $6;`,
+		e.Name, e.Mode, e.Timezone, e.UpdatedAt.Format("2006-01-02"), e.ID, e.TenantID)
 	if err != nil {
 		return err
 	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
+
+// BulkUpsert upserts the tenant's centers keyed by external id and returns
+// their ids by external id
+func (r *CenterPGSQL) BulkUpsert(tenantID id.ID,
+	centers []*entity.Center,
+) (map[string]id.ID, error) {
+	extIDs := make([]string, len(centers))
+	jsonAddresses := make([]string, len(centers))
+	jsonGeoLocations := make([]string, len(centers))
+	for i, e := range centers {
+		extIDs[i] = e.ExtID
+
+		jsonAddress, err := json.Marshal(e.Address)
+		if err != nil {
+			l.Log.Warnf("extID=%v, err=%v", e.ExtID, err)
+			return nil, err
+		}
+		jsonAddresses[i] = string(jsonAddress)
+
+		jsonGeoLocation, err := json.Marshal(e.GeoLocation)
+		if err != nil {
+			l.Log.Warnf("extID=%v, err=%v", e.ExtID, err)
+			return nil, err
+		}
+		jsonGeoLocations[i] = string(jsonGeoLocation)
+	}
+
+	values := func(index int) []interface{} {
+		e := centers[index]
+		return []interface{}{
+			e.ID,
+			e.ExtID,
+			tenantID,
+			e.ExtName,
+			e.Name,
+			jsonAddresses[index],
+			jsonGeoLocations[index],
+			e.Capacity,
+			string(e.Mode),
+			e.WebPage,
+			e.IsNational,
+			e.IsEnabled,
+			e.CreatedAt.Format(common.DBFormatDateTimeMS),
+			e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		}
+	}
+
+	return bulkUpsert(r.db, "center", []string{
+		"id", "ext_id", "tenant_id", "ext_name", "name", "address",
+		"geo_location", "capacity", "mode", "web_page",
+		"is_national", "is_enabled", "created_at", "updated_at",
+	}, tenantID, extIDs, values)
+}
+
+// GetIDsByExtIDs gets center ids by external id; unknown ones are left out
+func (r *CenterPGSQL) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	return getIDsByExtIDs(r.db, "center", tenantID, extIDs)
+}
+
+// SetEnabled enables or disables a center
+// Note: updated_at is left as is; it tracks the Salesforce changes that the
+// upsert relies on.
+func (r *CenterPGSQL) SetEnabled(tenantID id.ID, centerID id.ID, isEnabled bool) error {
+	res, err := r.db.Exec(`UPDATE center SET is_enabled = $1 WHERE id = $2 AND tenant_id = $3;`,
+		isEnabled, centerID, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
 	return nil
 }
 
@@ -105,7 +188,7 @@ func (r *CenterPGSQL) Search(tenantID id.ID,
 	q string, page, limit int,
 ) ([]*entity.Center, error) {
 	query := `
-		SELECT id, tenant_id, name, ext_name, capacity, mode, created_at
+		SELECT id, tenant_id, name, ext_name, capacity, mode, timezone, created_at
 		FROM center
 		WHERE is_enabled = TRUE
 			AND tenant_id = $1
@@ -147,7 +230,7 @@ func (r *CenterPGSQL) Search(tenantID id.ID,
 // List lists centers
 func (r *CenterPGSQL) List(tenantID id.ID, page, limit int) ([]*entity.Center, error) {
 	query := `
-		SELECT id, tenant_id, name, ext_name, capacity, mode, created_at
+		SELECT id, tenant_id, name, ext_name, capacity, mode, timezone, created_at
 		FROM center
 		WHERE is_enabled = TRUE AND tenant_id = $1
 	`
@@ -182,8 +265,8 @@ func (r *CenterPGSQL) List(tenantID id.ID, page, limit int) ([]*entity.Center, e
 }
 
 // Delete deletes a center
-func (r *CenterPGSQL) Delete(id id.ID) error {
-	res, err := r.db.Exec(`DELETE FROM center WHERE id = $1;`, id)
+func (r *CenterPGSQL) Delete(tenantID id.ID, centerID id.ID) error {
+	res, err := r.db.Exec(`DELETE FROM center WHERE id = $1 AND tenant_id = $2;`, centerID, tenantID)
 	if err != nil {
 		return err
 	}
@@ -210,11 +293,98 @@ func (r *CenterPGSQL) GetCount(tenantID id.ID) (int, error) {
 	return count, nil
 }
 
+// Nearby lists enabled centers within the query radius ordered by distance
+func (r *CenterPGSQL) Nearby(tenantID id.ID,
+	q *entity.GeoQuery,
+	page, limit int,
+) ([]*entity.CenterNearby, error) {
+	box := q.BoundingBox()
+	query := `
+		SELECT id, tenant_id, name, ext_name, capacity, mode, timezone, created_at,
+			address, geo_location, distance
+		FROM (
+			SELECT c.*, ` + geoDistanceSQL(2, 3) + ` AS distance
+			FROM center c
+			WHERE c.is_enabled = TRUE AND c.tenant_id = $1
+				AND ` + geoBoundingBoxSQL(4) + `
+		) nearby
+		WHERE distance <= $8
+		ORDER BY distance, id`
+	args := []interface{}{tenantID, q.Lat, q.Long,
+		box.MinLat, box.MaxLat, box.MinLong, box.MaxLong, q.RadiusKm}
+
+	if page > 0 && limit > 0 {
+		query += ` LIMIT $9 OFFSET $10`
+		args = append(args, limit, (page-1)*limit)
+	}
+
+	stmt, err := r.db.Prepare(query + ";")
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var centers []*entity.CenterNearby
+	for rows.Next() {
+		var center entity.Center
+		var ext_name, name, timezone, jsonAddress, jsonGeoLocation sql.NullString
+		var capacity sql.NullInt32
+		var distance float64
+
+		err := rows.Scan(
+			&center.ID,
+			&center.TenantID,
+			&name,
+			&ext_name,
+			&capacity,
+			&center.Mode,
+			&timezone,
+			&center.CreatedAt,
+			&jsonAddress,
+			&jsonGeoLocation,
+			&distance,
+		)
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return nil, err
+		}
+
+		if jsonAddress.Valid && jsonAddress.String != "" {
+			if err := json.Unmarshal([]byte(jsonAddress.String), &center.Address); err != nil {
+				return nil, err
+			}
+		}
+		if jsonGeoLocation.Valid && jsonGeoLocation.String != "" {
+			if err := json.Unmarshal([]byte(jsonGeoLocation.String), &center.GeoLocation); err != nil {
+				return nil, err
+			}
+		}
+
+		center.Name = name.String
+		center.ExtName = ext_name.String
+		center.Capacity = capacity.Int32
+		center.Timezone = timezone.String
+
+		centers = append(centers, &entity.CenterNearby{
+			Center:     &center,
+			DistanceKm: distance,
+		})
+	}
+	return centers, nil
+}
+
 func (r *CenterPGSQL) scanRows(rows *sql.Rows) ([]*entity.Center, error) {
 	var centers []*entity.Center
 	for rows.Next() {
 		var center entity.Center
-		var ext_name, name sql.NullString
+		var ext_name, name, timezone sql.NullString
 		var capacity sql.NullInt32
 
 		err := rows.Scan(
@@ -224,6 +394,7 @@ func (r *CenterPGSQL) scanRows(rows *sql.Rows) ([]*entity.Center, error) {
 			&ext_name,
 			&capacity,
 			&center.Mode,
+			&timezone,
 			&center.CreatedAt,
 		)
 
@@ -234,6 +405,7 @@ func (r *CenterPGSQL) scanRows(rows *sql.Rows) ([]*entity.Center, error) {
 		center.Name = name.String
 		center.ExtName = ext_name.String
 		center.Capacity = capacity.Int32
+		center.Timezone = timezone.String
 
 		centers = append(centers, &center)
 
@@ -254,15 +426,15 @@ func (r *CenterPGSQL) Upsert(e *entity.Center) (id.ID, error) {
 				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 			ON CONFLICT (ext_id)
 			DO UPDATE
-				SET tenant_id = $2, ext_name = $4, name = $5, address = $6, geo_location = $7,
+				SET ext_name = $4, name = $5, address = $6, geo_location = $7,
 					capacity = $8, mode = $9, web_page = $10,
 					is_national = $11,  is_enabled = $12, created_at = $13, updated_at = $14
-			WHERE center.updated_at <= $14
+			WHERE center.updated_at <= $14 AND center.tenant_id = EXCLUDED.tenant_id
 			RETURNING id
 		)
 		SELECT id FROM upsert
 		UNION ALL
-		SELECT id FROM center WHERE ext_id = $3 AND NOT EXISTS (SELECT 1 FROM upsert);
+		SELECT id FROM center WHERE ext_id = $3 AND tenant_id = $2 AND NOT EXISTS (SELECT 1 FROM upsert);
 	`)
 	if err != nil {
 		l.Log.Warnf("err=%v", err)
@@ -315,7 +487,7 @@ func (r *CenterPGSQL) GetByExtID(tenantID id.ID,
 ) (*entity.Center, error) {
 	stmt, err := r.db.Prepare(`
 		SELECT
-			id, tenant_id, name, ext_name, mode, created_at
+			id, tenant_id, name, ext_name, mode, is_enabled, created_at
 		FROM center
 		WHERE tenant_id = $1 AND ext_id = $2;
 	`)
@@ -327,7 +499,8 @@ func (r *CenterPGSQL) GetByExtID(tenantID id.ID,
 	var extName sql.NullString
 	var name sql.NullString
 	var mode sql.NullString
-	err = stmt.QueryRow(tenantID, extID).Scan(&c.ID, &c.TenantID, &name, &extName, &mode, &c.CreatedAt)
+	var isEnabled sql.NullBool
+	err = stmt.QueryRow(tenantID, extID).Scan(&c.ID, &c.TenantID, &name, &extName, &mode, &isEnabled, &c.CreatedAt)
 	if err != nil {
 		l.Log.Warnf("tenantID=%v, extID=%v, err=%v", tenantID, extID, err)
 		if err == sql.ErrNoRows {
@@ -337,7 +510,26 @@ func (r *CenterPGSQL) GetByExtID(tenantID id.ID,
 	}
 	c.Name = name.String
 	c.ExtName = extName.String
+	c.ExtID = extID
 	c.Mode = entity.CenterMode(mode.String)
+	c.IsEnabled = isEnabled.Bool
 
 	return &c, nil
 }
+
+// QueueChange queues the change for the export
+func (r *CenterPGSQL) QueueChange(e *entity.AuditLog) error {
+	return queueChange(r.db, e)
+}
+
+// InTx runs fn with a repository bound to a transaction
+func (r *CenterPGSQL) InTx(fn func(repo center.Repository) error) error {
+	db, ok := r.db.(*sql.DB)
+	if !ok {
+		// already bound to a transaction
+		return fn(r)
+	}
+	return inTx(db, func(tx *sql.Tx) error {
+		return fn(&CenterPGSQL{db: tx})
+	})
+}
@@ -9,10 +9,14 @@ package repository
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"ac9/glad/entity"
 	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
 	"ac9/glad/pkg/util"
@@ -20,7 +24,7 @@ import (
 
 // CoursePGSQL mysql repo
 type CoursePGSQL struct {
-	db *sql.DB
+	db dbtx
 }
 
 // NewCoursePGSQL create new repository
@@ -74,19 +78,19 @@ func (r *CoursePGSQL) Create(e *entity.Course) (id.ID, error) {
 }
 
 // Get retrieves a course
-func (r *CoursePGSQL) Get(id id.ID) (*entity.Course, error) {
+func (r *CoursePGSQL) Get(tenantID id.ID, courseID id.ID) (*entity.Course, error) {
 	stmt, err := r.db.Prepare(`
 		SELECT id, tenant_id, ext_id, center_id, product_id, name, notes, timezone, address,
 		status, mode, max_attendees, num_attendees, created_at
 		FROM course
-		WHERE id = $1;`)
+		WHERE id = $1 AND tenant_id = $2;`)
 	if err != nil {
 		return nil, err
 	}
 	var c entity.Course
 	var ext_id sql.NullString
 	var name, notes, timezone, jsonAddress, status, mode sql.NullString
-	err = stmt.QueryRow(id).Scan(&c.ID, &c.TenantID, &ext_id, &c.CenterID, &c.ProductID, &name, &notes, &timezone,
+	err = stmt.QueryRow(courseID, tenantID).Scan(&c.ID, &c.TenantID, &ext_id, &c.CenterID, &c.ProductID, &name, &notes, &timezone,
 		&jsonAddress, &status, &mode, &c.MaxAttendees, &c.NumAttendees, &c.CreatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -112,6 +116,20 @@ func (r *CoursePGSQL) Get(id id.ID) (*entity.Course, error) {
 	return &c, nil
 }
 
+// GetByExtID gets a course by its Salesforce id
+func (r *CoursePGSQL) GetByExtID(tenantID id.ID, extID string) (*entity.Course, error) {
+	var courseID id.ID
+	err := r.db.QueryRow(`SELECT id FROM course WHERE ext_id = $1 AND tenant_id = $2;`,
+		extID, tenantID).Scan(&courseID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.Get(tenantID, courseID)
+}
+
 // Update updates a course
 func (r *CoursePGSQL) Update(e *entity.Course) error {
 	e.UpdatedAt = time.Now()
@@ -120,59 +138,241 @@ func (r *CoursePGSQL) Update(e *entity.Course) error {
 		return err
 	}
 
-	_, err = r.db.Exec(`
+	// Note: num_attendees is left as is; only the seat reservations change it
+	res, err := r.db.Exec(`
 		UPDATE course SET center_id = $1, name = $2, notes = $3, timezone = $4, address = $5,
-			status = $6, mode = $7, max_attendees = $8, num_attendees = $9,
-			updated_at = $10, product_id = $11
-		WHERE id = $12;
+			mode = $6, max_attendees = $7,
+			updated_at = $8, product_id = $9
+		WHERE id = $10 AND tenant_id = $11;
 		`,
-		e.CenterID, e.Name, e.Notes, e.Timezone, string(jsonAddress), (e.Status), (e.Mode),
-		e.MaxAttendees, e.NumAttendees, e.UpdatedAt.Format("2006-01-02"), e.ProductID,
-		e.ID)
+		e.CenterID, e.Name, e.Notes, e.Timezone, string(jsonAddress), (e.Mode),
+		e.MaxAttendees, e.UpdatedAt.Format("2006-01-02"), e.ProductID,
+		e.ID, e.TenantID)
 	if err != nil {
 		return err
 	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
 	return nil
 }
 
-// Search searches courses
-func (r *CoursePGSQL) Search(tenantID id.ID, q string, page, limit int) ([]*entity.Course, error) {
-	query := `
-		SELECT id, tenant_id, ext_id, center_id, product_id, name, notes, timezone, address,
-		status, mode, max_attendees, num_attendees, created_at
-		FROM course
-		WHERE tenant_id = $1 AND name LIKE $2
-	`
+// UpdateStatus changes the course status if it is still in the from status
+func (r *CoursePGSQL) UpdateStatus(tenantID id.ID,
+	courseID id.ID,
+	from entity.CourseStatus,
+	to entity.CourseStatus,
+) error {
+	res, err := r.db.Exec(`
+		UPDATE course SET status = $1, updated_at = $2
+		WHERE id = $3 AND tenant_id = $4 AND status = $5;
+		`,
+		to, time.Now().Format(common.DBFormatDateTimeMS), courseID, tenantID, from)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return err
+	}
 
-	if page > 0 && limit > 0 {
-		offset := (page - 1) * limit
-		query += ` LIMIT $3 OFFSET $4;`
-		stmt, err := r.db.Prepare(query)
-		if err != nil {
-			return nil, err
-		}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
+
+// SetExtID links the course to its Salesforce record
+// Note: updated_at is left as is, so that the Salesforce import is not skipped
+func (r *CoursePGSQL) SetExtID(tenantID id.ID, courseID id.ID, extID string) error {
+	res, err := r.db.Exec(`
+		UPDATE course SET ext_id = $1 WHERE id = $2 AND tenant_id = $3;
+		`,
+		extID, courseID, tenantID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return err
+	}
 
-		rows, err := stmt.Query(tenantID, "%"+q+"%", limit, offset)
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
+
+// InsertStatusChange records a lifecycle transition in the course history
+func (r *CoursePGSQL) InsertStatusChange(e *entity.CourseStatusChange) error {
+	_, err := r.db.Exec(`
+		INSERT INTO course_status_history
+			(id, tenant_id, course_id, action, from_status, to_status, actor_id, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8);
+		`,
+		e.ID, e.TenantID, e.CourseID, e.Action, e.From, e.To, e.ActorID,
+		e.CreatedAt.Format(common.DBFormatDateTimeMS))
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return err
+	}
+	return nil
+}
+
+// ListStatusChanges lists the lifecycle transitions of a course, oldest first
+func (r *CoursePGSQL) ListStatusChanges(tenantID id.ID,
+	courseID id.ID,
+) ([]*entity.CourseStatusChange, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT id, tenant_id, course_id, action, from_status, to_status, actor_id, created_at
+		FROM course_status_history
+		WHERE course_id = $1 AND tenant_id = $2
+		ORDER BY created_at, id;`)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+
+	rows, err := stmt.Query(courseID, tenantID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []*entity.CourseStatusChange
+	for rows.Next() {
+		var e entity.CourseStatusChange
+		var actorID sql.NullInt64
+		err = rows.Scan(&e.ID, &e.TenantID, &e.CourseID, &e.Action, &e.From, &e.To,
+			&actorID, &e.CreatedAt)
 		if err != nil {
+			l.Log.Warnf("err=%v", err)
 			return nil, err
 		}
+		e.ActorID = id.ID(actorID.Int64)
+		changes = append(changes, &e)
+	}
+	return changes, nil
+}
 
-		defer rows.Close()
-		return r.scanRows(rows)
+// Search searches courses using the filter and returns the total number of
+// matches along with the requested page
+func (r *CoursePGSQL) Search(tenantID id.ID,
+	f *entity.CourseFilter,
+	page, limit int,
+) (int, []*entity.Course, error) {
+	where, args := courseFilterWhere(tenantID, f)
+
+	stmt, err := r.db.Prepare(`SELECT count(*) FROM course c ` + where + `;`)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return 0, nil, err
 	}
 
-	stmt, err := r.db.Prepare(query + ";")
+	var count int
+	err = stmt.QueryRow(args...).Scan(&count)
 	if err != nil {
-		return nil, err
+		l.Log.Warnf("err=%v", err)
+		return 0, nil, err
+	}
+	if count == 0 {
+		return 0, nil, nil
+	}
+
+	query := `
+		SELECT c.id, c.tenant_id, c.ext_id, c.center_id, c.product_id, c.name, c.notes,
+		c.timezone, c.address, c.status, c.mode, c.max_attendees, c.num_attendees, c.created_at
+		FROM course c ` + where + courseFilterOrderBy(f)
+
+	if page > 0 && limit > 0 {
+		offset := (page - 1) * limit
+		query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+		args = append(args, limit, offset)
 	}
 
-	rows, err := stmt.Query(tenantID, "%"+q+"%")
+	stmt, err = r.db.Prepare(query + ";")
 	if err != nil {
-		return nil, err
+		l.Log.Warnf("err=%v", err)
+		return count, nil, err
+	}
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return count, nil, err
 	}
 
 	defer rows.Close()
-	return r.scanRows(rows)
+	courses, err := r.scanRows(rows)
+	return count, courses, err
+}
+
+// courseFilterWhere builds the WHERE clause and its arguments for a course filter
+// Note: address is stored as marshalled entity.CourseAddress, hence the key names
+func courseFilterWhere(tenantID id.ID, f *entity.CourseFilter) (string, []interface{}) {
+	args := []interface{}{tenantID}
+	conds := []string{"c.tenant_id = $1"}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.Query != "" {
+		add("LOWER(c.name) LIKE $%d", "%"+strings.ToLower(f.Query)+"%")
+	}
+	if f.Status != "" {
+		add("c.status = $%d", f.Status)
+	}
+	if f.Mode != "" {
+		add("c.mode = $%d", f.Mode)
+	}
+	if f.ProductID != id.IDInvalid {
+		add("c.product_id = $%d", f.ProductID)
+	}
+	if f.CenterID != id.IDInvalid {
+		add("c.center_id = $%d", f.CenterID)
+	}
+	if f.TeacherID != id.IDInvalid {
+		add("c.id IN (SELECT course_id FROM course_teacher WHERE teacher_id = $%d)", f.TeacherID)
+	}
+	if f.DateFrom != "" || f.DateTo != "" {
+		timing := "SELECT 1 FROM course_timing ct WHERE ct.course_id = c.id"
+		if f.DateFrom != "" {
+			args = append(args, f.DateFrom)
+			timing += fmt.Sprintf(" AND ct.course_date >= $%d", len(args))
+		}
+		if f.DateTo != "" {
+			args = append(args, f.DateTo)
+			timing += fmt.Sprintf(" AND ct.course_date <= $%d", len(args))
+		}
+		conds = append(conds, "EXISTS ("+timing+")")
+	}
+	if f.City != "" {
+		add("LOWER(c.address->>'City') = LOWER($%d)", f.City)
+	}
+	if f.State != "" {
+		add("LOWER(c.address->>'State') = LOWER($%d)", f.State)
+	}
+
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// courseFilterOrderBy builds the ORDER BY clause for a course filter
+// Sort fields are validated by the filter; only known columns are emitted.
+func courseFilterOrderBy(f *entity.CourseFilter) string {
+	var column string
+	switch f.SortBy {
+	case entity.CourseSortCreatedAt:
+		column = "c.created_at"
+	case entity.CourseSortName:
+		column = "c.name"
+	case entity.CourseSortStartDate:
+		column = "(SELECT MIN(ct.course_date) FROM course_timing ct WHERE ct.course_id = c.id)"
+	default:
+		column = "c.updated_at"
+	}
+
+	order := " ASC"
+	if f.SortDesc {
+		order = " DESC"
+	}
+	return " ORDER BY " + column + order + ", c.id" + order
 }
 
 // List lists courses
@@ -238,8 +438,8 @@ func (r *CoursePGSQL) GetByAccount(tenantID id.ID,
 }
 
 // Delete deletes a course
-func (r *CoursePGSQL) Delete(id id.ID) error {
-	res, err := r.db.Exec(`DELETE FROM course WHERE id = $1;`, id)
+func (r *CoursePGSQL) Delete(tenantID id.ID, courseID id.ID) error {
+	res, err := r.db.Exec(`DELETE FROM course WHERE id = $1 AND tenant_id = $2;`, courseID, tenantID)
 	if err != nil {
 		l.Log.Warnf("%v", err)
 		return err
@@ -361,45 +561,121 @@ func (r *CoursePGSQL) getByAccount(tenantID id.ID,
 func (r *CoursePGSQL) scanRows(rows *sql.Rows) ([]*entity.Course, error) {
 	var courses []*entity.Course
 	for rows.Next() {
-		var course entity.Course
-		var ext_id, name, notes, timezone, jsonAddress, status, mode sql.NullString
-		err := rows.Scan(
-			&course.ID,
-			&course.TenantID,
-			&ext_id,
-			&course.CenterID,
-			&course.ProductID,
-			&name,
-			&notes,
-			&timezone,
-			&jsonAddress,
-			&status,
-			&mode,
-			&course.MaxAttendees,
-			&course.NumAttendees,
-			&course.CreatedAt,
-		)
+		course, err := r.scanRow(rows)
 		if err != nil {
-			l.Log.Warnf("err=%v", err)
 			return nil, err
 		}
+		courses = append(courses, course)
+	}
+	return courses, nil
+}
+
+// scanRow scans the course columns followed by any extra columns in the query
+func (r *CoursePGSQL) scanRow(rows *sql.Rows, extra ...interface{}) (*entity.Course, error) {
+	var course entity.Course
+	var ext_id, name, notes, timezone, jsonAddress, status, mode sql.NullString
+	dest := []interface{}{
+		&course.ID,
+		&course.TenantID,
+		&ext_id,
+		&course.CenterID,
+		&course.ProductID,
+		&name,
+		&notes,
+		&timezone,
+		&jsonAddress,
+		&status,
+		&mode,
+		&course.MaxAttendees,
+		&course.NumAttendees,
+		&course.CreatedAt,
+	}
+	err := rows.Scan(append(dest, extra...)...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
 
-		course.ExtID = &ext_id.String
-		course.Name = name.String
-		course.Notes = notes.String
-		course.Timezone = timezone.String
-		course.Status = entity.CourseStatus(status.String)
-		course.Mode = entity.CourseMode(mode.String)
+	course.ExtID = &ext_id.String
+	course.Name = name.String
+	course.Notes = notes.String
+	course.Timezone = timezone.String
+	course.Status = entity.CourseStatus(status.String)
+	course.Mode = entity.CourseMode(mode.String)
 
-		if jsonAddress.Valid && jsonAddress.String != "" {
-			err = json.Unmarshal([]byte(jsonAddress.String), &course.Address)
-			if err != nil {
-				l.Log.Warnf("err=%v", err)
-				return nil, err
-			}
+	if jsonAddress.Valid && jsonAddress.String != "" {
+		err = json.Unmarshal([]byte(jsonAddress.String), &course.Address)
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return nil, err
 		}
+	}
+
+	return &course, nil
+}
+
+// Nearby lists upcoming in-person courses at enabled centers within the query
+// radius, ordered by distance. A course is upcoming when it has a timing on or
+// after the given date (YYYY-MM-DD) and is not a draft, canceled or archived.
+func (r *CoursePGSQL) Nearby(tenantID id.ID,
+	q *entity.GeoQuery,
+	from string,
+	page, limit int,
+) ([]*entity.CourseNearby, error) {
+	box := q.BoundingBox()
+	query := `
+		SELECT id, tenant_id, ext_id, center_id, product_id, name, notes, timezone, address,
+		status, mode, max_attendees, num_attendees, created_at, distance
+		FROM (
+			SELECT co.*, ` + geoDistanceSQL(2, 3) + ` AS distance
+			FROM course co
+			JOIN center c ON c.id = co.center_id
+			WHERE co.tenant_id = $1 AND c.tenant_id = $1 AND c.is_enabled = TRUE
+				AND ` + geoBoundingBoxSQL(4) + `
+				AND co.mode = $9
+				AND co.status NOT IN ($10, $11, $12)
+				AND EXISTS (
+					SELECT 1 FROM course_timing ct
+					WHERE ct.course_id = co.id AND ct.course_date >= $13
+				)
+		) nearby
+		WHERE distance <= $8
+		ORDER BY distance, id`
+	args := []interface{}{tenantID, q.Lat, q.Long,
+		box.MinLat, box.MaxLat, box.MinLong, box.MaxLong, q.RadiusKm,
+		entity.CourseInPerson,
+		entity.CourseDraft, entity.CourseCanceled, entity.CourseArchived,
+		from}
 
-		courses = append(courses, &course)
+	if page > 0 && limit > 0 {
+		query += ` LIMIT $14 OFFSET $15`
+		args = append(args, limit, (page-1)*limit)
+	}
+
+	stmt, err := r.db.Prepare(query + ";")
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var courses []*entity.CourseNearby
+	for rows.Next() {
+		var distance float64
+		course, err := r.scanRow(rows, &distance)
+		if err != nil {
+			return nil, err
+		}
+		courses = append(courses, &entity.CourseNearby{
+			CourseFull: &entity.CourseFull{Course: course},
+			DistanceKm: distance,
+		})
 	}
 	return courses, nil
 }
@@ -420,16 +696,16 @@ func (r *CoursePGSQL) Upsert(e *entity.Course) (id.ID, error) {
 				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) 
 			ON CONFLICT (ext_id)
 			DO UPDATE
-				SET tenant_id = $3, product_id = $4, center_id = $5, name = $6, notes = $7,
+				SET product_id = $4, center_id = $5, name = $6, notes = $7,
 				 	timezone = $8, address = $9, status = $10, mode = $11,
 					max_attendees = $12, num_attendees = $13, url = $14, checkout_url = $15,
 					created_at = $16, updated_at = $17
-			WHERE course.updated_at <= $17
+			WHERE course.updated_at <= $17 AND course.tenant_id = EXCLUDED.tenant_id
 			RETURNING id
 		)
 		SELECT id FROM upsert
 		UNION ALL
-		SELECT id FROM course WHERE ext_id = $2 AND NOT EXISTS (SELECT 1 FROM upsert);
+		SELECT id FROM course WHERE ext_id = $2 AND tenant_id = $3 AND NOT EXISTS (SELECT 1 FROM upsert);
 	`)
 	if err != nil {
 		l.Log.Warnf("err=%v", err)
@@ -474,6 +750,60 @@ func (r *CoursePGSQL) Upsert(e *entity.Course) (id.ID, error) {
 	return courseId, nil
 }
 
+// BulkUpsert upserts the tenant's courses keyed by external id and returns
+// their ids by external id. Every course is expected to have an external id.
+func (r *CoursePGSQL) BulkUpsert(tenantID id.ID,
+	courses []*entity.Course,
+) (map[string]id.ID, error) {
+	extIDs := make([]string, len(courses))
+	jsonAddresses := make([][]byte, len(courses))
+	for i, e := range courses {
+		if e.ExtID == nil {
+			return nil, glad.ErrInvalidValue
+		}
+		extIDs[i] = *e.ExtID
+
+		jsonAddress, err := json.Marshal(e.Address)
+		if err != nil {
+			l.Log.Warnf("extID=%v, err=%v", *e.ExtID, err)
+			return nil, err
+		}
+		jsonAddresses[i] = jsonAddress
+	}
+
+	values := func(index int) []interface{} {
+		e := courses[index]
+		return []interface{}{
+			e.ID,
+			e.ExtID,
+			tenantID,
+			e.ProductID,
+			e.CenterID,
+			e.Name,
+			e.Notes,
+			e.Timezone,
+			jsonAddresses[index],
+			string(e.Status),
+			string(e.Mode),
+			e.MaxAttendees,
+			e.NumAttendees,
+			e.URL,
+			e.CheckoutURL,
+			e.CreatedAt.Format(common.DBFormatDateTimeMS),
+			e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		}
+	}
+
+	return bulkUpsert(r.db, "course", []string{
+		"id", "ext_id", "tenant_id", "product_id", "center_id",
+		"name", "notes", "timezone",
+		"address", "status", "mode",
+		"max_attendees", "num_attendees",
+		"url", "checkout_url",
+		"created_at", "updated_at",
+	}, tenantID, extIDs, values)
+}
+
 // --------------------------------------------------------------------------------
 // Course Organizer
 // --------------------------------------------------------------------------------
@@ -1304,3 +1634,381 @@ func (r *CoursePGSQL) MultiGetCourseNotify(courseIDList []id.ID,
 	defer rows.Close()
 	return cnsList, err
 }
+
+// --------------------------------------------------------------------------------
+// Course Roster imported from Salesforce
+// --------------------------------------------------------------------------------
+// UpsertCourseTeacherByExtID links the teacher to the course by the Salesforce
+// id of the junction record
+func (r *CoursePGSQL) UpsertCourseTeacherByExtID(tenantID id.ID,
+	courseID id.ID,
+	extID string,
+	ct *entity.CourseTeacher,
+) error {
+	return r.withTx(func(r *CoursePGSQL) error {
+		// the junction record may have moved to another course or teacher of
+		// the tenant
+		_, err := r.db.Exec(`
+			DELETE FROM course_teacher t USING course c
+			WHERE t.course_id = c.id AND c.tenant_id = $1
+				AND t.ext_id = $2 AND (t.course_id <> $3 OR t.teacher_id <> $4);`,
+			tenantID, extID, courseID, ct.ID)
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return err
+		}
+
+		res, err := r.db.Exec(`
+			UPDATE course_teacher SET ext_id = $1, is_primary = $4, updated_at = $5
+			WHERE course_id = $2 AND teacher_id = $3;`,
+			extID, courseID, ct.ID, ct.IsPrimary, util.DBTimeNow())
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return err
+		}
+		if cnt, _ := res.RowsAffected(); cnt > 0 {
+			return nil
+		}
+
+		_, err = r.db.Exec(`
+			INSERT INTO course_teacher (course_id, teacher_id, is_primary, ext_id, updated_at)
+			VALUES($1, $2, $3, $4, $5);`,
+			courseID, ct.ID, ct.IsPrimary, extID, util.DBTimeNow())
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+		}
+		return err
+	})
+}
+
+// UpsertCourseOrganizerByExtID links the organizer to the course by the
+// Salesforce id of the junction record
+func (r *CoursePGSQL) UpsertCourseOrganizerByExtID(tenantID id.ID,
+	courseID id.ID,
+	extID string,
+	co *entity.CourseOrganizer,
+) error {
+	return r.withTx(func(r *CoursePGSQL) error {
+		// the junction record may have moved to another course or organizer
+		// of the tenant
+		_, err := r.db.Exec(`
+			DELETE FROM course_organizer o USING course c
+			WHERE o.course_id = c.id AND c.tenant_id = $1
+				AND o.ext_id = $2 AND (o.course_id <> $3 OR o.organizer_id <> $4);`,
+			tenantID, extID, courseID, co.ID)
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return err
+		}
+
+		res, err := r.db.Exec(`
+			UPDATE course_organizer SET ext_id = $1, updated_at = $4
+			WHERE course_id = $2 AND organizer_id = $3;`,
+			extID, courseID, co.ID, util.DBTimeNow())
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return err
+		}
+		if cnt, _ := res.RowsAffected(); cnt > 0 {
+			return nil
+		}
+
+		_, err = r.db.Exec(`
+			INSERT INTO course_organizer (course_id, organizer_id, ext_id, updated_at)
+			VALUES($1, $2, $3, $4);`,
+			courseID, co.ID, extID, util.DBTimeNow())
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+		}
+		return err
+	})
+}
+
+// withTx runs fn with the repository bound to a transaction. The repository
+// of a unit of work is already bound to one.
+func (r *CoursePGSQL) withTx(fn func(r *CoursePGSQL) error) error {
+	db, ok := r.db.(*sql.DB)
+	if !ok {
+		return fn(r)
+	}
+	return inTx(db, func(tx *sql.Tx) error {
+		return fn(&CoursePGSQL{db: tx})
+	})
+}
+
+// DeleteCourseRosterByExtID removes the teacher or organizer entry linked to
+// the Salesforce id and returns its course id; id.IDInvalid when there is none
+func (r *CoursePGSQL) DeleteCourseRosterByExtID(tenantID id.ID, extID string) (id.ID, error) {
+	for _, table := range []string{"course_teacher", "course_organizer"} {
+		var courseID id.ID
+		err := r.db.QueryRow(`
+			DELETE FROM `+table+` t USING course c
+			WHERE t.course_id = c.id AND c.tenant_id = $1 AND t.ext_id = $2
+			RETURNING t.course_id;`,
+			tenantID, extID).Scan(&courseID)
+		switch err {
+		case nil:
+			return courseID, nil
+		case sql.ErrNoRows:
+		default:
+			l.Log.Warnf("err=%v", err)
+			return id.IDInvalid, err
+		}
+	}
+	return id.IDInvalid, nil
+}
+
+// --------------------------------------------------------------------------------
+// Teacher eligibility of the course roster
+// --------------------------------------------------------------------------------
+// GetTeacherEligibility gets the eligibility of the teachers for the product by
+// teacher id; teachers who are not eligible are left out
+func (r *CoursePGSQL) GetTeacherEligibility(tenantID id.ID,
+	productID id.ID,
+	teacherIDs []id.ID,
+) (map[id.ID]entity.EligibilityType, error) {
+	eligibility := make(map[id.ID]entity.EligibilityType, len(teacherIDs))
+	if len(teacherIDs) == 0 {
+		return eligibility, nil
+	}
+
+	params := make([]string, len(teacherIDs))
+	args := []interface{}{tenantID, productID}
+	for i, teacherID := range teacherIDs {
+		params[i] = fmt.Sprintf("$%d", i+3)
+		args = append(args, teacherID)
+	}
+	rows, err := r.db.Query(`
+		SELECT te.teacher_id, te.type
+		FROM teacher_eligibility te
+		JOIN product p ON p.id = te.product_id
+		WHERE p.tenant_id = $1 AND te.product_id = $2
+			AND te.teacher_id IN (`+strings.Join(params, ",")+`);`,
+		args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teacherID id.ID
+		var et entity.EligibilityType
+		err := rows.Scan(&teacherID, &et)
+		if err != nil {
+			return nil, err
+		}
+		eligibility[teacherID] = et
+	}
+	return eligibility, nil
+}
+
+// GetCenterTimezone gets the timezone of the center of the tenant; empty when
+// not set
+func (r *CoursePGSQL) GetCenterTimezone(tenantID id.ID, centerID id.ID) (string, error) {
+	var timezone sql.NullString
+	err := r.db.QueryRow(`
+		SELECT timezone FROM center WHERE id = $1 AND tenant_id = $2;`,
+		centerID, tenantID).Scan(&timezone)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return "", err
+	}
+	return timezone.String, nil
+}
+
+// --------------------------------------------------------------------------------
+// Course schedules for the conflicts
+// --------------------------------------------------------------------------------
+// LockSchedules locks the schedules of the accounts and the center until the
+// end of the transaction
+// Note: The locks are taken in the same order by every transaction (accounts
+// by id, then the center) so that they cannot deadlock.
+func (r *CoursePGSQL) LockSchedules(accountIDs []id.ID, centerID id.ID) error {
+	sorted := append([]id.ID{}, accountIDs...)
+	slices.Sort(sorted)
+	for _, accountID := range slices.Compact(sorted) {
+		_, err := r.db.Exec(`SELECT pg_advisory_xact_lock(hashtextextended('course_schedule_account', $1));`,
+			accountID)
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return err
+		}
+	}
+
+	if centerID != id.IDInvalid {
+		_, err := r.db.Exec(`SELECT pg_advisory_xact_lock(hashtextextended('course_schedule_center', $1));`,
+			centerID)
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// ListCourseSchedules lists the scheduled courses of the tenant, other than the
+// course, taught or organized by any of the accounts or held at the center,
+// with their timings between the dates (YYYY-MM-DD, inclusive)
+func (r *CoursePGSQL) ListCourseSchedules(tenantID id.ID,
+	courseID id.ID,
+	accountIDs []id.ID,
+	centerID id.ID,
+	from, to string,
+) ([]*entity.CourseSchedule, error) {
+	args := []interface{}{tenantID, courseID, from, to,
+		entity.CourseCanceled, entity.CourseDeclined, entity.CoursedInactive, entity.CourseArchived,
+		centerID}
+	books := `co.center_id = $9`
+	if len(accountIDs) > 0 {
+		params := make([]string, len(accountIDs))
+		for i, accountID := range accountIDs {
+			params[i] = fmt.Sprintf("$%d", len(args)+1)
+			args = append(args, accountID)
+		}
+		in := strings.Join(params, ",")
+		books += `
+			OR co.id IN (SELECT course_id FROM course_teacher WHERE teacher_id IN (` + in + `))
+			OR co.id IN (SELECT course_id FROM course_organizer WHERE organizer_id IN (` + in + `))`
+	}
+
+	rows, err := r.db.Query(`
+		SELECT co.id, co.tenant_id, co.ext_id, co.center_id, co.product_id, co.name, co.notes,
+			co.timezone, co.address, co.status, co.mode, co.max_attendees, co.num_attendees,
+			co.created_at, COALESCE(c.capacity, 0),
+			ct.id, ct.course_date, ct.start_time, ct.end_time
+		FROM course co
+		JOIN center c ON c.id = co.center_id
+		JOIN course_timing ct ON ct.course_id = co.id
+		WHERE co.tenant_id = $1 AND co.id <> $2
+			AND ct.course_date BETWEEN $3 AND $4
+			AND co.status NOT IN ($5, $6, $7, $8)
+			AND (`+books+`)
+		ORDER BY co.id, ct.course_date, ct.start_time;`,
+		args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*entity.CourseSchedule
+	var courseIDs []id.ID
+	for rows.Next() {
+		var capacity int32
+		var ct entity.CourseTiming
+		var courseDate, startTime, endTime sql.NullString
+		course, err := r.scanRow(rows, &capacity, &ct.ID, &courseDate, &startTime, &endTime)
+		if err != nil {
+			return nil, err
+		}
+		ct.CourseID = course.ID
+		ct.DateTime = entity.CourseDateTime{
+			Date:      courseDate.String,
+			StartTime: startTime.String,
+			EndTime:   endTime.String,
+		}
+		// Note: rows predating the validation are kept as read
+		_ = ct.DateTime.Normalize()
+
+		// rows are ordered by course
+		if len(schedules) == 0 || schedules[len(schedules)-1].Course.ID != course.ID {
+			schedules = append(schedules, &entity.CourseSchedule{
+				Course:         course,
+				CenterCapacity: capacity,
+			})
+			courseIDs = append(courseIDs, course.ID)
+		}
+		cs := schedules[len(schedules)-1]
+		cs.Timings = append(cs.Timings, &ct)
+	}
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+
+	ctsList, err := r.MultiGetCourseTeacher(courseIDs)
+	if err != nil {
+		return nil, err
+	}
+	cosList, err := r.MultiGetCourseOrganizer(courseIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i, cs := range schedules {
+		for _, ct := range ctsList[i] {
+			cs.TeacherIDs = append(cs.TeacherIDs, ct.ID)
+		}
+		for _, co := range cosList[i] {
+			cs.OrganizerIDs = append(cs.OrganizerIDs, co.ID)
+		}
+	}
+	return schedules, nil
+}
+
+// --------------------------------------------------------------------------------
+// Course recurrence
+// --------------------------------------------------------------------------------
+// GetCourseRecurrence gets the recurrence rule of the course; nil when there is
+// none
+func (r *CoursePGSQL) GetCourseRecurrence(courseID id.ID) (*entity.CourseRecurrence, error) {
+	var rec entity.CourseRecurrence
+	var startDate, startTime, endTime, jsonExDates sql.NullString
+	err := r.db.QueryRow(`
+		SELECT rule, start_date, start_time, end_time, ex_dates
+		FROM course_recurrence WHERE course_id = $1;`,
+		courseID).Scan(&rec.Rule, &startDate, &startTime, &endTime, &jsonExDates)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+
+	rec.StartDate = startDate.String
+	rec.StartTime = startTime.String
+	rec.EndTime = endTime.String
+	if jsonExDates.Valid {
+		err = json.Unmarshal([]byte(jsonExDates.String), &rec.ExDates)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Note: the start date is read with a time part
+	_ = rec.Normalize()
+	return &rec, nil
+}
+
+// SetCourseRecurrence sets or replaces the recurrence rule of the course
+func (r *CoursePGSQL) SetCourseRecurrence(courseID id.ID, rec *entity.CourseRecurrence) error {
+	jsonExDates, err := json.Marshal(rec.ExDates)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO course_recurrence
+			(course_id, rule, start_date, start_time, end_time, ex_dates, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (course_id) DO UPDATE SET
+			rule = EXCLUDED.rule,
+			start_date = EXCLUDED.start_date,
+			start_time = EXCLUDED.start_time,
+			end_time = EXCLUDED.end_time,
+			ex_dates = EXCLUDED.ex_dates,
+			updated_at = EXCLUDED.updated_at;`,
+		courseID, rec.Rule, rec.StartDate, rec.StartTime, rec.EndTime, string(jsonExDates),
+		time.Now().Format(common.DBFormatDateTimeMS))
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+	}
+	return err
+}
+
+// QueueChange queues the change for the export
+func (r *CoursePGSQL) QueueChange(e *entity.AuditLog) error {
+	return queueChange(r.db, e)
+}
@@ -19,7 +19,7 @@ import (
 
 // CourseTimingPGSQL pgsql repo
 type CourseTimingPGSQL struct {
-	db *sql.DB
+	db dbtx
 }
 
 // NewCourseTimingPGSQL create new repository
@@ -90,12 +90,37 @@ func (r *CourseTimingPGSQL) Get(id id.ID) (*entity.CourseTiming, error) {
 		StartTime: start_time.String,
 		EndTime:   end_time.String,
 	}
+	// Note: rows predating the validation are kept as read
+	_ = ct.DateTime.Normalize()
 
 	log.Printf("Course timing: %#v", ct)
 
 	return &ct, nil
 }
 
+// GetByExtID retrieves a course timing by its Salesforce id; the course must
+// belong to the tenant
+func (r *CourseTimingPGSQL) GetByExtID(tenantID id.ID, extID string) (*entity.CourseTiming, error) {
+	rows, err := r.db.Query(`
+		SELECT
+			ct.id, ct.course_id, ct.ext_id, ct.course_date, ct.start_time, ct.end_time, ct.created_at
+		FROM course_timing ct
+		INNER JOIN course c ON c.id = ct.course_id
+		WHERE ct.ext_id = $1 AND c.tenant_id = $2;`,
+		extID, tenantID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	cts, err := r.scanRows(rows)
+	if err != nil || len(cts) == 0 {
+		return nil, err
+	}
+	return cts[0], nil
+}
+
 // Update updates a course timing
 func (r *CourseTimingPGSQL) Update(ct *entity.CourseTiming) error {
 	ct.UpdatedAt = time.Now()
@@ -108,7 +133,7 @@ func (r *CourseTimingPGSQL) Update(ct *entity.CourseTiming) error {
 			start_time = $4,
 			end_time = $5,
 			updated_at = $6
-		WHERE id = $7;
+		WHERE id = $7 AND course_id = $1;
 		`,
 		ct.CourseID,
 		ct.ExtID,
@@ -211,6 +236,7 @@ func (r *CourseTimingPGSQL) scanRow(rows *sql.Rows) (*entity.CourseTiming, error
 	ct.DateTime.Date = course_date.String
 	ct.DateTime.StartTime = start_time.String
 	ct.DateTime.EndTime = end_time.String
+	_ = ct.DateTime.Normalize()
 
 	return &ct, err
 }
@@ -0,0 +1,35 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"ac9/glad/usecase/course"
+)
+
+// CourseUnitOfWorkPGSQL runs course and course timing writes in one transaction
+type CourseUnitOfWorkPGSQL struct {
+	db *sql.DB
+}
+
+// NewCourseUnitOfWorkPGSQL create new unit of work
+func NewCourseUnitOfWorkPGSQL(db *sql.DB) *CourseUnitOfWorkPGSQL {
+	return &CourseUnitOfWorkPGSQL{
+		db: db,
+	}
+}
+
+// Do begins a transaction, hands fn repositories bound to it and commits
+// when fn succeeds. Any error (or panic) from fn rolls the transaction back.
+func (u *CourseUnitOfWorkPGSQL) Do(
+	fn func(cRepo course.CourseRepository, ctRepo course.CourseTimingRepository) error,
+) error {
+	return inTx(u.db, func(tx *sql.Tx) error {
+		return fn(&CoursePGSQL{db: tx}, &CourseTimingPGSQL{db: tx})
+	})
+}
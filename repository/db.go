@@ -0,0 +1,169 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/util"
+)
+
+// dbtx is satisfied by both *sql.DB and *sql.Tx so that a repository can
+// run either standalone or as part of a unit of work
+type dbtx interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Prepare(query string) (*sql.Stmt, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// inTx runs fn in a transaction and commits when fn succeeds. Any error (or
+// panic) from fn rolls the transaction back.
+func inTx(db *sql.DB, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && rbErr != sql.ErrTxDone {
+				l.Log.Warnf("Unable to rollback transaction. err=%v", rbErr)
+			}
+		}
+	}()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// bulkUpsert upserts rows keyed by ext_id in as few statements as possible
+// and returns the ids by ext_id. columns start with id and ext_id; the rest
+// but tenant_id are overwritten on conflict. Rows left untouched because the
+// stored copy is newer are resolved with a lookup, so every ext_id of the input
+// gets its id. A row of another tenant is never updated, nor is its id
+// returned; its ext_id is absent from the result.
+func bulkUpsert(db dbtx,
+	tableName string,
+	columns []string,
+	tenantID id.ID,
+	extIDs []string,
+	valueExtractor util.ValueExtractor,
+) (map[string]id.ID, error) {
+	var updateColumns []string
+	for _, column := range columns[2:] {
+		if column != "tenant_id" {
+			updateColumns = append(updateColumns, column)
+		}
+	}
+
+	ids := make(map[string]id.ID, len(extIDs))
+	batchSize := util.BatchSizePGSQL(len(columns))
+	for start := 0; start < len(extIDs); start += batchSize {
+		end := min(start+batchSize, len(extIDs))
+		query, valueArgs := util.GenBulkUpsertPGSQL(
+			tableName,
+			columns,
+			"ext_id",
+			updateColumns,
+			end-start,
+			func(index int) []interface{} { return valueExtractor(start + index) },
+		)
+		query += " AND " + tableName + ".tenant_id = EXCLUDED.tenant_id"
+
+		rows, err := db.Query(query+" RETURNING id, ext_id, tenant_id;", valueArgs...)
+		if err != nil {
+			l.Log.Warnf("table=%v, err=%v", tableName, err)
+			return nil, err
+		}
+		err = scanIDsByExtID(rows, tenantID, ids)
+		if err != nil {
+			l.Log.Warnf("table=%v, err=%v", tableName, err)
+			return nil, err
+		}
+	}
+
+	var skipped []string
+	for _, extID := range extIDs {
+		if _, ok := ids[extID]; !ok {
+			skipped = append(skipped, extID)
+		}
+	}
+	if len(skipped) == 0 {
+		return ids, nil
+	}
+
+	stored, err := getIDsByExtIDs(db, tableName, tenantID, skipped)
+	if err != nil {
+		return nil, err
+	}
+	for extID, storedID := range stored {
+		ids[extID] = storedID
+	}
+	return ids, nil
+}
+
+// getIDsByExtIDs gets the ids of the tenant's rows using one IN query per
+// batch of external ids. Unknown external ids are absent from the result.
+func getIDsByExtIDs(db dbtx,
+	tableName string,
+	tenantID id.ID,
+	extIDs []string,
+) (map[string]id.ID, error) {
+	ids := make(map[string]id.ID, len(extIDs))
+	batchSize := util.BatchSizePGSQL(1)
+	for start := 0; start < len(extIDs); start += batchSize {
+		end := min(start+batchSize, len(extIDs))
+		whereIn, valueArgs := util.BuildQueryWhereClauseIn(
+			"ext_id",
+			end-start,
+			func(index int) []interface{} { return []interface{}{extIDs[start+index]} },
+		)
+
+		query := "SELECT id, ext_id, tenant_id FROM " + tableName + whereIn
+		rows, err := db.Query(query, valueArgs...)
+		if err != nil {
+			l.Log.Warnf("table=%v, err=%v", tableName, err)
+			return nil, err
+		}
+		err = scanIDsByExtID(rows, tenantID, ids)
+		if err != nil {
+			l.Log.Warnf("table=%v, err=%v", tableName, err)
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// scanIDsByExtID reads (id, ext_id, tenant_id) rows of the tenant into ids
+// and closes rows
+func scanIDsByExtID(rows *sql.Rows, tenantID id.ID, ids map[string]id.ID) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		var rowID, rowTenantID id.ID
+		var extID sql.NullString
+		err := rows.Scan(&rowID, &extID, &rowTenantID)
+		if err != nil {
+			return err
+		}
+		if rowTenantID != tenantID {
+			continue
+		}
+		ids[extID.String] = rowID
+	}
+	return rows.Err()
+}
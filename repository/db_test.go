@@ -0,0 +1,232 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice id.ID = 7264348473653242881
+	tenantBob   id.ID = 7264348473653242882
+
+	accountIDAlice id.ID  = 1379049221091701000
+	accountExtID   string = "0035g00000AbCdE"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+// containsAll matches the queries containing every expected fragment, separated by '|'
+var containsAll = sqlmock.QueryMatcherFunc(func(expected, actual string) error {
+	for _, fragment := range strings.Split(expected, "|") {
+		if !strings.Contains(actual, fragment) {
+			return sqlmock.ErrCancelled
+		}
+	}
+	return nil
+})
+
+func Test_bulkUpsert_tenants(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(containsAll))
+	assert.Nil(t, err)
+	defer db.Close()
+	repo := NewAccountPGSQL(db)
+
+	account := &entity.Account{ID: id.New(), ExtID: accountExtID, Type: entity.AccountStudent}
+	upsert := `ON CONFLICT ("ext_id") DO UPDATE SET "cognito_id" = EXCLUDED."cognito_id"|` +
+		`AND account.tenant_id = EXCLUDED.tenant_id RETURNING id, ext_id, tenant_id`
+
+	t.Run("own row", func(t *testing.T) {
+		mock.ExpectQuery(upsert).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "ext_id", "tenant_id"}).
+				AddRow(int64(accountIDAlice), accountExtID, int64(tenantAlice)))
+
+		ids, err := repo.BulkUpsert(tenantAlice, []*entity.Account{account})
+		assert.Nil(t, err)
+		assert.Equal(t, accountIDAlice, ids[accountExtID])
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("other tenant's row", func(t *testing.T) {
+		// the row of alice is left as is, hence not returned by the upsert
+		mock.ExpectQuery(upsert).WillReturnRows(
+			sqlmock.NewRows([]string{"id", "ext_id", "tenant_id"}))
+		mock.ExpectQuery("SELECT id, ext_id, tenant_id FROM account").WillReturnRows(
+			sqlmock.NewRows([]string{"id", "ext_id", "tenant_id"}).
+				AddRow(int64(accountIDAlice), accountExtID, int64(tenantAlice)))
+
+		ids, err := repo.BulkUpsert(tenantBob, []*entity.Account{account})
+		assert.Nil(t, err)
+		_, ok := ids[accountExtID]
+		assert.False(t, ok)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func Test_bulkUpsert_keepsTenant(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(
+		sqlmock.QueryMatcherFunc(func(_, actual string) error {
+			if strings.Contains(actual, `"tenant_id" = EXCLUDED."tenant_id"`) {
+				return sqlmock.ErrCancelled
+			}
+			return nil
+		})))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "ext_id", "tenant_id"}).
+			AddRow(int64(accountIDAlice), accountExtID, int64(tenantAlice)))
+
+	ids, err := NewAccountPGSQL(db).BulkUpsert(tenantAlice,
+		[]*entity.Account{{ID: id.New(), ExtID: accountExtID}})
+	assert.Nil(t, err)
+	assert.Equal(t, accountIDAlice, ids[accountExtID])
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_lockSchedules_order(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(containsAll))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// accounts by id once each, then the center
+	for _, arg := range []id.ID{1, 2, 3} {
+		mock.ExpectExec("course_schedule_account").WithArgs(int64(arg)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec("course_schedule_center").WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = NewCoursePGSQL(db).LockSchedules([]id.ID{3, 1, 2, 1}, 7)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_releaseSeatToWaitlist_oneTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(containsAll))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	const courseID id.ID = 1379049349508707123
+	const entryID id.ID = 1379049349508707555
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	columns := []string{"id", "tenant_id", "course_id", "account_id", "email", "position",
+		"status", "hold_until", "created_at", "updated_at"}
+
+	// the seat is released and reserved again for the entry before the lock
+	// of the course is released
+	mock.ExpectBegin()
+	mock.ExpectQuery("FROM course|FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(courseID)))
+	mock.ExpectExec("num_attendees = GREATEST(").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("FROM waitlist|LIMIT 1").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(int64(entryID), int64(tenantAlice), int64(courseID), int64(accountIDAlice), nil, 1,
+			entity.WaitlistWaiting, nil, now, now))
+	mock.ExpectQuery("num_attendees = COALESCE(num_attendees, 0) + 1").
+		WillReturnRows(sqlmock.NewRows([]string{"num_attendees"}).AddRow(10))
+	mock.ExpectExec("UPDATE waitlist SET status").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	e, err := NewParticipantPGSQL(db).ReleaseSeatToWaitlist(tenantAlice, courseID, now, time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, entryID, e.ID)
+	assert.Equal(t, entity.WaitlistOffered, e.Status)
+	assert.Equal(t, now.Add(time.Hour), e.HoldUntil)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_courseUpdate_keepsSeats(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(
+		sqlmock.QueryMatcherFunc(func(_, actual string) error {
+			if strings.Contains(actual, "num_attendees") {
+				return sqlmock.ErrCancelled
+			}
+			return nil
+		})))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewCoursePGSQL(db).Update(&entity.Course{ID: id.New(), TenantID: tenantAlice})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_upsert_keepsTenant(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(
+		sqlmock.QueryMatcherFunc(func(expected, actual string) error {
+			if strings.Contains(actual, "SET tenant_id") {
+				return sqlmock.ErrCancelled
+			}
+			return containsAll.Match(expected, actual)
+		})))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	// another tenant's row is neither taken over nor returned
+	mock.ExpectPrepare("AND course.tenant_id = EXCLUDED.tenant_id|" +
+		"FROM course WHERE ext_id = $2 AND tenant_id = $3").
+		ExpectQuery().
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectPrepare("AND center.tenant_id = EXCLUDED.tenant_id|" +
+		"FROM center WHERE ext_id = $3 AND tenant_id = $2").
+		ExpectQuery().
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	courseExtID := "a0B5g00000CoUrSe"
+	_, err = NewCoursePGSQL(db).Upsert(
+		&entity.Course{ID: id.New(), TenantID: tenantBob, ExtID: &courseExtID})
+	assert.NotNil(t, err)
+	_, err = NewCenterPGSQL(db).Upsert(
+		&entity.Center{ID: id.New(), TenantID: tenantBob, ExtID: "a0C5g00000CeNtEr"})
+	assert.NotNil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_upsertCourseTeacherByExtID_oneTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(containsAll))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	const courseID id.ID = 1379049349508707123
+	const teacherExtID = "a0T5g00000TeAcH"
+
+	// only the entries on the tenant's courses are replaced
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM course_teacher t USING course c|c.tenant_id = $1").
+		WithArgs(int64(tenantAlice), teacherExtID, int64(courseID), int64(accountIDAlice)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE course_teacher").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO course_teacher").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewCoursePGSQL(db).UpsertCourseTeacherByExtID(tenantAlice, courseID, teacherExtID,
+		&entity.CourseTeacher{ID: accountIDAlice})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
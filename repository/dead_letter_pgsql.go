@@ -0,0 +1,243 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+const deadLetterColumns = `id, tenant_id, entity_type, ext_id, payload, reason, message,
+	dependency_type, dependency_ext_id, attempts, created_at, updated_at`
+
+// DeadLetterPGSQL dead letter repo
+type DeadLetterPGSQL struct {
+	db *sql.DB
+}
+
+// NewDeadLetterPGSQL create new repository
+func NewDeadLetterPGSQL(db *sql.DB) *DeadLetterPGSQL {
+	return &DeadLetterPGSQL{
+		db: db,
+	}
+}
+
+// Create creates a dead letter
+func (r *DeadLetterPGSQL) Create(e *entity.DeadLetter) (id.ID, error) {
+	_, err := r.db.Exec(`
+		INSERT INTO dead_letter (`+deadLetterColumns+`)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+		`,
+		e.ID, e.TenantID, e.EntityType, e.ExtID, []byte(e.Payload), e.Reason, e.Message,
+		deadLetterNullString(string(e.DependencyType)), deadLetterNullString(e.DependencyExtID),
+		e.Attempts,
+		e.CreatedAt.Format(common.DBFormatDateTimeMS),
+		e.UpdatedAt.Format(common.DBFormatDateTimeMS))
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return id.IDInvalid, err
+	}
+	return e.ID, nil
+}
+
+// Get gets a dead letter; nil when not found
+func (r *DeadLetterPGSQL) Get(tenantID id.ID, deadLetterID id.ID) (*entity.DeadLetter, error) {
+	rows, err := r.db.Query(`
+		SELECT `+deadLetterColumns+`
+		FROM dead_letter
+		WHERE tenant_id = $1 AND id = $2;`, tenantID, deadLetterID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRow(rows)
+}
+
+// GetByExtID gets the dead letter of the record; nil when not found
+func (r *DeadLetterPGSQL) GetByExtID(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	extID string,
+) (*entity.DeadLetter, error) {
+	rows, err := r.db.Query(`
+		SELECT `+deadLetterColumns+`
+		FROM dead_letter
+		WHERE tenant_id = $1 AND entity_type = $2 AND ext_id = $3;`,
+		tenantID, entityType, extID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRow(rows)
+}
+
+// List lists the dead letters of an entity type, oldest first
+func (r *DeadLetterPGSQL) List(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	page, limit int,
+) (int, []*entity.DeadLetter, error) {
+	where := ` WHERE tenant_id = $1`
+	args := []interface{}{tenantID}
+	if entityType != "" {
+		where += ` AND entity_type = $2`
+		args = append(args, entityType)
+	}
+
+	var count int
+	err := r.db.QueryRow(`SELECT count(*) FROM dead_letter`+where+`;`, args...).Scan(&count)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return 0, nil, err
+	}
+	if count == 0 {
+		return 0, nil, nil
+	}
+
+	query := `SELECT ` + deadLetterColumns + ` FROM dead_letter` + where + `
+		ORDER BY created_at, id`
+	if page > 0 && limit > 0 {
+		offset := (page - 1) * limit
+		query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+		args = append(args, limit, offset)
+	}
+
+	rows, err := r.db.Query(query+";", args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return count, nil, err
+	}
+	defer rows.Close()
+
+	letters, err := r.scanRows(rows)
+	return count, letters, err
+}
+
+// ListByDependency lists the dead letters waiting for any of the entities,
+// oldest first
+func (r *DeadLetterPGSQL) ListByDependency(tenantID id.ID,
+	dependencyType entity.AuditEntityType,
+	dependencyExtIDs []string,
+) ([]*entity.DeadLetter, error) {
+	in, args := deadLetterWhereIn(3, dependencyExtIDs)
+	rows, err := r.db.Query(`
+		SELECT `+deadLetterColumns+`
+		FROM dead_letter
+		WHERE tenant_id = $1 AND dependency_type = $2 AND dependency_ext_id IN (`+in+`)
+		ORDER BY created_at, id;`,
+		append([]interface{}{tenantID, dependencyType}, args...)...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// Update updates the dead letter with the latest failed attempt
+func (r *DeadLetterPGSQL) Update(e *entity.DeadLetter) error {
+	_, err := r.db.Exec(`
+		UPDATE dead_letter
+		SET payload = $1, reason = $2, message = $3, dependency_type = $4,
+			dependency_ext_id = $5, attempts = $6, updated_at = $7
+		WHERE tenant_id = $8 AND id = $9;`,
+		[]byte(e.Payload), e.Reason, e.Message,
+		deadLetterNullString(string(e.DependencyType)), deadLetterNullString(e.DependencyExtID),
+		e.Attempts, e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		e.TenantID, e.ID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return err
+	}
+	return nil
+}
+
+// Delete deletes a dead letter
+func (r *DeadLetterPGSQL) Delete(tenantID id.ID, deadLetterID id.ID) error {
+	_, err := r.db.Exec(`DELETE FROM dead_letter WHERE tenant_id = $1 AND id = $2;`,
+		tenantID, deadLetterID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteByExtIDs deletes the dead letters of the records
+func (r *DeadLetterPGSQL) DeleteByExtIDs(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	extIDs []string,
+) error {
+	in, args := deadLetterWhereIn(3, extIDs)
+	_, err := r.db.Exec(`
+		DELETE FROM dead_letter
+		WHERE tenant_id = $1 AND entity_type = $2 AND ext_id IN (`+in+`);`,
+		append([]interface{}{tenantID, entityType}, args...)...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return err
+	}
+	return nil
+}
+
+// scanRow scans the first dead letter; nil when there is none
+func (r *DeadLetterPGSQL) scanRow(rows *sql.Rows) (*entity.DeadLetter, error) {
+	letters, err := r.scanRows(rows)
+	if err != nil || len(letters) == 0 {
+		return nil, err
+	}
+	return letters[0], nil
+}
+
+// scanRows scans the dead letters
+func (r *DeadLetterPGSQL) scanRows(rows *sql.Rows) ([]*entity.DeadLetter, error) {
+	var letters []*entity.DeadLetter
+	for rows.Next() {
+		var e entity.DeadLetter
+		var payload []byte
+		var message, dependencyType, dependencyExtID sql.NullString
+		err := rows.Scan(&e.ID, &e.TenantID, &e.EntityType, &e.ExtID, &payload, &e.Reason,
+			&message, &dependencyType, &dependencyExtID, &e.Attempts,
+			&e.CreatedAt, &e.UpdatedAt)
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return nil, err
+		}
+		e.Payload = json.RawMessage(payload)
+		e.Message = message.String
+		e.DependencyType = entity.AuditEntityType(dependencyType.String)
+		e.DependencyExtID = dependencyExtID.String
+		letters = append(letters, &e)
+	}
+	return letters, rows.Err()
+}
+
+// deadLetterWhereIn builds the placeholders of the IN list, numbered from first
+func deadLetterWhereIn(first int, values []string) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		placeholders[i] = fmt.Sprintf("$%d", first+i)
+		args[i] = v
+	}
+	return strings.Join(placeholders, ","), args
+}
+
+// deadLetterNullString stores empty values as NULL
+func deadLetterNullString(v string) sql.NullString {
+	return sql.NullString{String: v, Valid: v != ""}
+}
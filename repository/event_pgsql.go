@@ -0,0 +1,109 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// EventPGSQL event outbox repo
+type EventPGSQL struct {
+	db *sql.DB
+}
+
+// NewEventPGSQL create new repository
+func NewEventPGSQL(db *sql.DB) *EventPGSQL {
+	return &EventPGSQL{
+		db: db,
+	}
+}
+
+// Create creates an event
+func (r *EventPGSQL) Create(e *entity.Event) (id.ID, error) {
+	_, err := r.db.Exec(`
+		INSERT INTO event (id, tenant_id, type, account_id, course_id, payload, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7);
+		`,
+		e.ID, e.TenantID, e.Type, e.AccountID, auditNullID(e.CourseID), auditNullJSON(e.Payload),
+		e.CreatedAt.Format(common.DBFormatDateTimeMS))
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// ListPending lists the events not delivered yet, oldest first
+func (r *EventPGSQL) ListPending(limit int) ([]*entity.Event, error) {
+	query := `
+		SELECT id, tenant_id, type, account_id, course_id, payload, created_at
+		FROM event
+		WHERE delivered_at IS NULL
+		ORDER BY created_at, id`
+	var args []interface{}
+	if limit > 0 {
+		query += ` LIMIT $1`
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.Query(query+";", args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*entity.Event
+	for rows.Next() {
+		var e entity.Event
+		var courseID sql.NullInt64
+		var payload []byte
+		err := rows.Scan(&e.ID, &e.TenantID, &e.Type, &e.AccountID, &courseID, &payload,
+			&e.CreatedAt)
+		if err != nil {
+			l.Log.Warnf("err=%v", err)
+			return nil, err
+		}
+		e.CourseID = id.ID(courseID.Int64)
+		if len(payload) > 0 {
+			e.Payload = json.RawMessage(payload)
+		}
+		events = append(events, &e)
+	}
+	return events, nil
+}
+
+// MarkDelivered marks the events delivered
+func (r *EventPGSQL) MarkDelivered(eventIDs []id.ID) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+
+	params := make([]string, len(eventIDs))
+	args := []interface{}{time.Now().Format(common.DBFormatDateTimeMS)}
+	for i, eventID := range eventIDs {
+		params[i] = fmt.Sprintf("$%d", i+2)
+		args = append(args, eventID)
+	}
+	_, err := r.db.Exec(`
+		UPDATE event SET delivered_at = $1
+		WHERE id IN (`+strings.Join(params, ",")+`);`,
+		args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"fmt"
+
+	"ac9/glad/entity"
+)
+
+// Expressions over center.geo_location; these match the expression index
+// idx_center_geo_location so the bounding box prefilter can use it.
+// Note: geo_location is the marshalled entity.CenterGeoLocation, hence the key names
+const (
+	centerLatSQL  = `((c.geo_location->>'Lat')::float8)`
+	centerLongSQL = `((c.geo_location->>'Long')::float8)`
+)
+
+// geoDistanceSQL haversine distance in km between the center and the point
+// held in the given query parameters
+func geoDistanceSQL(latParam, longParam int) string {
+	return fmt.Sprintf(`(2 * %[5]v * ASIN(LEAST(1, SQRT(
+		POWER(SIN(RADIANS(%[1]v - $%[3]d) / 2), 2) +
+		COS(RADIANS($%[3]d)) * COS(RADIANS(%[1]v)) *
+		POWER(SIN(RADIANS(%[2]v - $%[4]d) / 2), 2)))))`,
+		centerLatSQL, centerLongSQL, latParam, longParam, entity.EarthRadiusKm)
+}
+
+// geoBoundingBoxSQL bounding box prefilter on the center location using
+// four consecutive query parameters starting at first
+func geoBoundingBoxSQL(first int) string {
+	return fmt.Sprintf(`%v BETWEEN $%d AND $%d AND %v BETWEEN $%d AND $%d`,
+		centerLatSQL, first, first+1, centerLongSQL, first+2, first+3)
+}
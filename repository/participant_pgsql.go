@@ -0,0 +1,692 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// ParticipantPGSQL mysql repo
+type ParticipantPGSQL struct {
+	db *sql.DB
+}
+
+// NewParticipantPGSQL create new repository
+func NewParticipantPGSQL(db *sql.DB) *ParticipantPGSQL {
+	return &ParticipantPGSQL{
+		db: db,
+	}
+}
+
+// Create creates a participant
+func (r *ParticipantPGSQL) Create(e *entity.Participant) (id.ID, error) {
+	stmt, err := r.db.Prepare(`
+		INSERT INTO participant (id, ext_id, course_id, account_id, email, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7)`)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	_, err = stmt.Exec(
+		e.ID,
+		e.ExtID,
+		e.CourseID,
+		e.AccountID,
+		e.Email,
+		e.CreatedAt.Format(common.DBFormatDateTimeMS),
+		e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+	)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	err = stmt.Close()
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// Get retrieves a participant
+func (r *ParticipantPGSQL) Get(tenantID id.ID, participantID id.ID) (*entity.Participant, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT p.id, p.ext_id, p.course_id, p.account_id, p.email, p.created_at, p.updated_at
+		FROM participant p
+		JOIN course c ON c.id = p.course_id
+		WHERE c.tenant_id = $1 AND p.id = $2;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(tenantID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	participants, err := r.scanRows(rows)
+	if err != nil || len(participants) == 0 {
+		return nil, err
+	}
+	return participants[0], nil
+}
+
+// GetByAccount retrieves a participant of a course using account id
+func (r *ParticipantPGSQL) GetByAccount(tenantID id.ID,
+	courseID id.ID,
+	accountID id.ID,
+) (*entity.Participant, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT p.id, p.ext_id, p.course_id, p.account_id, p.email, p.created_at, p.updated_at
+		FROM participant p
+		JOIN course c ON c.id = p.course_id
+		WHERE c.tenant_id = $1 AND p.course_id = $2 AND p.account_id = $3;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(tenantID, courseID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	participants, err := r.scanRows(rows)
+	if err != nil || len(participants) == 0 {
+		return nil, err
+	}
+	return participants[0], nil
+}
+
+// GetByExtID gets a participant of a course of the tenant by its Salesforce id
+func (r *ParticipantPGSQL) GetByExtID(tenantID id.ID, extID string) (*entity.Participant, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT p.id, p.ext_id, p.course_id, p.account_id, p.email, p.created_at, p.updated_at
+		FROM participant p
+		JOIN course c ON c.id = p.course_id
+		WHERE c.tenant_id = $1 AND p.ext_id = $2;`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(tenantID, extID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	participants, err := r.scanRows(rows)
+	if err != nil || len(participants) == 0 {
+		return nil, err
+	}
+	return participants[0], nil
+}
+
+// ListByCourse lists participants of a course
+func (r *ParticipantPGSQL) ListByCourse(tenantID id.ID,
+	courseID id.ID,
+	page, limit int,
+) ([]*entity.Participant, error) {
+	query := `
+		SELECT p.id, p.ext_id, p.course_id, p.account_id, p.email, p.created_at, p.updated_at
+		FROM participant p
+		JOIN course c ON c.id = p.course_id
+		WHERE c.tenant_id = $1 AND p.course_id = $2
+		ORDER BY p.created_at
+	`
+
+	if page > 0 && limit > 0 {
+		offset := (page - 1) * limit
+		query += ` LIMIT $3 OFFSET $4;`
+		stmt, err := r.db.Prepare(query)
+		if err != nil {
+			return nil, err
+		}
+		defer stmt.Close()
+		rows, err := stmt.Query(tenantID, courseID, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		return r.scanRows(rows)
+	}
+
+	stmt, err := r.db.Prepare(query + ";")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	rows, err := stmt.Query(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// GetCountByCourse gets total participants of a course
+func (r *ParticipantPGSQL) GetCountByCourse(tenantID id.ID, courseID id.ID) (int, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT count(*)
+		FROM participant p
+		JOIN course c ON c.id = p.course_id
+		WHERE c.tenant_id = $1 AND p.course_id = $2;`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var count int
+	err = stmt.QueryRow(tenantID, courseID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Delete deletes a participant
+func (r *ParticipantPGSQL) Delete(participantID id.ID) error {
+	res, err := r.db.Exec(`DELETE FROM participant WHERE id = $1;`, participantID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetExtID links the participant to its Salesforce record
+func (r *ParticipantPGSQL) SetExtID(participantID id.ID, extID string) error {
+	res, err := r.db.Exec(`UPDATE participant SET ext_id = $1 WHERE id = $2;`,
+		extID, participantID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
+
+// Upsert inserts or updates the participant and returns the id
+// Note: The participant is inserted only when the course belongs to the tenant.
+func (r *ParticipantPGSQL) Upsert(tenantID id.ID, e *entity.Participant) (id.ID, error) {
+	l.Log.Debugf("Upsert: Participant=%#v", e)
+	stmt, err := r.db.Prepare(`
+		WITH upsert AS (
+			INSERT INTO participant (
+				id, ext_id, course_id, account_id, email,
+				created_at, updated_at
+			)
+			SELECT $1, $2, $3, $4, $5, $6, $7
+			WHERE EXISTS (SELECT 1 FROM course WHERE id = $3 AND tenant_id = $8)
+			ON CONFLICT (ext_id)
+			DO UPDATE
+				SET course_id = $3, account_id = $4, email = $5,
+					created_at = $6, updated_at = $7
+			WHERE participant.updated_at <= $7
+				AND participant.course_id IN (SELECT id FROM course WHERE tenant_id = $8)
+			RETURNING id
+		)
+		SELECT id FROM upsert
+		UNION ALL
+		SELECT id FROM participant
+		WHERE ext_id = $2
+			AND course_id IN (SELECT id FROM course WHERE tenant_id = $8)
+			AND NOT EXISTS (SELECT 1 FROM upsert);
+	`)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return id.IDInvalid, err
+	}
+	defer stmt.Close()
+
+	var participantID id.ID
+	err = stmt.QueryRow(
+		e.ID,
+		e.ExtID,
+		e.CourseID,
+		e.AccountID,
+		e.Email,
+		e.CreatedAt.Format(common.DBFormatDateTimeMS),
+		e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		tenantID,
+	).Scan(&participantID)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		if err == sql.ErrNoRows {
+			return id.IDInvalid, glad.ErrNotFound
+		}
+		return id.IDInvalid, err
+	}
+
+	return participantID, nil
+}
+
+// ReserveSeat increments the attendee count of the course
+// Note: Check and increment are done in a single statement so that concurrent
+// registrations cannot go beyond the maximum attendees.
+func (r *ParticipantPGSQL) ReserveSeat(tenantID id.ID, courseID id.ID) error {
+	reserved, err := reserveSeat(r.db, tenantID, courseID)
+	if err != nil {
+		return err
+	}
+	if reserved {
+		return nil
+	}
+
+	// either the course does not exist, is not accepting registrations or is full
+	var status string
+	err = r.db.QueryRow(`SELECT status FROM course WHERE id = $1 AND tenant_id = $2;`,
+		courseID, tenantID,
+	).Scan(&status)
+	if err == sql.ErrNoRows {
+		return glad.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !entity.CourseStatus(status).IsRegistrationOpen() {
+		return glad.ErrRegistrationClosed
+	}
+	return glad.ErrCourseFull
+}
+
+// ReleaseSeat decrements the attendee count of the course
+func (r *ParticipantPGSQL) ReleaseSeat(tenantID id.ID, courseID id.ID) error {
+	return releaseSeat(r.db, tenantID, courseID)
+}
+
+// ReleaseSeatToWaitlist releases a seat of the course and offers it to the
+// first waiting entry, held for hold from now
+// Note: Both are done holding the lock of the course, which registrations
+// reserving a seat wait for; hence the seat cannot be taken meanwhile.
+func (r *ParticipantPGSQL) ReleaseSeatToWaitlist(tenantID id.ID,
+	courseID id.ID,
+	now time.Time,
+	hold time.Duration,
+) (*entity.WaitlistEntry, error) {
+	var offered *entity.WaitlistEntry
+	err := r.withWaitlistLock(tenantID, courseID, func(tx *sql.Tx) error {
+		err := releaseSeat(tx, tenantID, courseID)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(`
+			SELECT`+waitlistColumns+`
+			WHERE c.tenant_id = $1 AND w.course_id = $2 AND w.status = $3
+			ORDER BY w.position
+			LIMIT 1;`,
+			tenantID, courseID, entity.WaitlistWaiting)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		entries, err := r.scanWaitlistRows(rows)
+		if err != nil || len(entries) == 0 {
+			return err
+		}
+
+		// the maximum attendees went down or registrations closed meanwhile
+		reserved, err := reserveSeat(tx, tenantID, courseID)
+		if err != nil || !reserved {
+			return err
+		}
+
+		e := entries[0]
+		e.Offer(now, hold)
+		err = offerWaitlistEntry(tx, e)
+		if err != nil {
+			return err
+		}
+		offered = e
+		return nil
+	})
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	return offered, nil
+}
+
+// reserveSeat increments the attendee count of the course unless it is full or
+// does not accept registrations; false when not reserved
+func reserveSeat(db dbtx, tenantID id.ID, courseID id.ID) (bool, error) {
+	var numAttendees int32
+	err := db.QueryRow(`
+		UPDATE course SET num_attendees = COALESCE(num_attendees, 0) + 1
+		WHERE id = $1 AND tenant_id = $2
+			AND status IN ($3, $4)
+			AND (COALESCE(max_attendees, 0) = 0 OR COALESCE(num_attendees, 0) < max_attendees)
+		RETURNING num_attendees;`,
+		courseID, tenantID,
+		// as entity.CourseStatus.IsRegistrationOpen
+		entity.CourseActive, entity.CourseOpen,
+	).Scan(&numAttendees)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseSeat decrements the attendee count of the course
+func releaseSeat(db dbtx, tenantID id.ID, courseID id.ID) error {
+	res, err := db.Exec(`
+		UPDATE course SET num_attendees = GREATEST(COALESCE(num_attendees, 0) - 1, 0)
+		WHERE id = $1 AND tenant_id = $2;`,
+		courseID, tenantID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *ParticipantPGSQL) scanRows(rows *sql.Rows) ([]*entity.Participant, error) {
+	var participants []*entity.Participant
+	for rows.Next() {
+		var p entity.Participant
+		var extID, email sql.NullString
+
+		err := rows.Scan(
+			&p.ID,
+			&extID,
+			&p.CourseID,
+			&p.AccountID,
+			&email,
+			&p.CreatedAt,
+			&p.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if extID.Valid {
+			p.ExtID = &extID.String
+		}
+		p.Email = email.String
+
+		participants = append(participants, &p)
+	}
+
+	return participants, nil
+}
+
+// waitlistColumns columns of the waitlist entries; the tenant is the course's
+const waitlistColumns = `
+		w.id, c.tenant_id, w.course_id, w.account_id, w.email, w.position, w.status,
+		w.hold_until, w.created_at, w.updated_at
+		FROM waitlist w
+		JOIN course c ON c.id = w.course_id`
+
+// GetWaitlistEntry retrieves a waitlist entry
+func (r *ParticipantPGSQL) GetWaitlistEntry(tenantID id.ID, entryID id.ID) (*entity.WaitlistEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT`+waitlistColumns+`
+		WHERE c.tenant_id = $1 AND w.id = $2;`,
+		tenantID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries, err := r.scanWaitlistRows(rows)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+// GetWaitlistEntryByAccount retrieves a waitlist entry of a course using account id
+func (r *ParticipantPGSQL) GetWaitlistEntryByAccount(tenantID id.ID,
+	courseID id.ID,
+	accountID id.ID,
+) (*entity.WaitlistEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT`+waitlistColumns+`
+		WHERE c.tenant_id = $1 AND w.course_id = $2 AND w.account_id = $3;`,
+		tenantID, courseID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries, err := r.scanWaitlistRows(rows)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+// ListWaitlist lists the entries of the course by position
+func (r *ParticipantPGSQL) ListWaitlist(tenantID id.ID, courseID id.ID) ([]*entity.WaitlistEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT`+waitlistColumns+`
+		WHERE c.tenant_id = $1 AND w.course_id = $2
+		ORDER BY w.position;`,
+		tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanWaitlistRows(rows)
+}
+
+// ListExpiredHolds lists the offered entries of all the tenants whose hold
+// ended before now
+func (r *ParticipantPGSQL) ListExpiredHolds(now time.Time) ([]*entity.WaitlistEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT`+waitlistColumns+`
+		WHERE w.status = $1 AND w.hold_until < $2
+		ORDER BY w.hold_until;`,
+		entity.WaitlistOffered, now.Format(common.DBFormatDateTimeMS))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanWaitlistRows(rows)
+}
+
+// CreateWaitlistEntry adds the entry at the end of the waitlist of the course
+// Note: The course is locked so that concurrent entries get distinct positions.
+func (r *ParticipantPGSQL) CreateWaitlistEntry(e *entity.WaitlistEntry) (id.ID, error) {
+	err := r.withWaitlistLock(e.TenantID, e.CourseID, func(tx *sql.Tx) error {
+		return tx.QueryRow(`
+			INSERT INTO waitlist (id, course_id, account_id, email, position, status,
+				created_at, updated_at)
+			SELECT $1, $2, $3, $4, COALESCE(MAX(position), 0) + 1, $5, $6, $7
+			FROM waitlist WHERE course_id = $2
+			RETURNING position;`,
+			e.ID,
+			e.CourseID,
+			e.AccountID,
+			e.Email,
+			e.Status,
+			e.CreatedAt.Format(common.DBFormatDateTimeMS),
+			e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		).Scan(&e.Position)
+	})
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return id.IDInvalid, err
+	}
+	return e.ID, nil
+}
+
+// offerWaitlistEntry saves the hold of the waiting entry
+func offerWaitlistEntry(db dbtx, e *entity.WaitlistEntry) error {
+	res, err := db.Exec(`
+		UPDATE waitlist SET status = $1, hold_until = $2, updated_at = $3
+		WHERE id = $4 AND status = $5;`,
+		e.Status,
+		e.HoldUntil.Format(common.DBFormatDateTimeMS),
+		e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		e.ID,
+		entity.WaitlistWaiting,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteWaitlistEntry removes the entry; the entries after it move up
+func (r *ParticipantPGSQL) DeleteWaitlistEntry(entryID id.ID) error {
+	var tenantID, courseID id.ID
+	err := r.db.QueryRow(`
+		SELECT c.tenant_id, w.course_id
+		FROM waitlist w
+		JOIN course c ON c.id = w.course_id
+		WHERE w.id = $1;`,
+		entryID,
+	).Scan(&tenantID, &courseID)
+	if err == sql.ErrNoRows {
+		return glad.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return r.withWaitlistLock(tenantID, courseID, func(tx *sql.Tx) error {
+		var position int
+		err := tx.QueryRow(`DELETE FROM waitlist WHERE id = $1 RETURNING position;`,
+			entryID,
+		).Scan(&position)
+		if err == sql.ErrNoRows {
+			return glad.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			UPDATE waitlist SET position = position - 1
+			WHERE course_id = $1 AND position > $2;`,
+			courseID, position)
+		return err
+	})
+}
+
+// ReorderWaitlist sets the positions of the entries of the course, in the
+// order of entryIDs
+func (r *ParticipantPGSQL) ReorderWaitlist(tenantID id.ID, courseID id.ID, entryIDs []id.ID) error {
+	return r.withWaitlistLock(tenantID, courseID, func(tx *sql.Tx) error {
+		now := time.Now().Format(common.DBFormatDateTimeMS)
+		for i, entryID := range entryIDs {
+			res, err := tx.Exec(`
+				UPDATE waitlist SET position = $1, updated_at = $2
+				WHERE id = $3 AND course_id = $4;`,
+				i+1, now, entryID, courseID)
+			if err != nil {
+				return err
+			}
+			if cnt, _ := res.RowsAffected(); cnt == 0 {
+				return glad.ErrNotFound
+			}
+		}
+		return nil
+	})
+}
+
+// withWaitlistLock runs fn in a transaction holding the lock of the course
+// Note: Positions are unique per course (checked at commit); the lock keeps
+// concurrent changes of the waitlist from conflicting.
+func (r *ParticipantPGSQL) withWaitlistLock(tenantID id.ID,
+	courseID id.ID,
+	fn func(tx *sql.Tx) error,
+) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && rbErr != sql.ErrTxDone {
+				l.Log.Warnf("Unable to rollback waitlist transaction. err=%v", rbErr)
+			}
+		}
+	}()
+
+	var locked id.ID
+	err = tx.QueryRow(`SELECT id FROM course WHERE id = $1 AND tenant_id = $2 FOR UPDATE;`,
+		courseID, tenantID,
+	).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return glad.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *ParticipantPGSQL) scanWaitlistRows(rows *sql.Rows) ([]*entity.WaitlistEntry, error) {
+	var entries []*entity.WaitlistEntry
+	for rows.Next() {
+		var e entity.WaitlistEntry
+		var email sql.NullString
+		var holdUntil sql.NullTime
+
+		err := rows.Scan(
+			&e.ID,
+			&e.TenantID,
+			&e.CourseID,
+			&e.AccountID,
+			&email,
+			&e.Position,
+			&e.Status,
+			&holdUntil,
+			&e.CreatedAt,
+			&e.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		e.Email = email.String
+		if holdUntil.Valid {
+			e.HoldUntil = holdUntil.Time
+		}
+
+		entries = append(entries, &e)
+	}
+
+	return entries, nil
+}
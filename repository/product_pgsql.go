@@ -6,6 +6,7 @@ import (
 
 	"ac9/glad/entity"
 	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
 )
@@ -57,11 +58,11 @@ func (r *ProductPGSQL) Create(e *entity.Product) (id.ID, error) {
 }
 
 // Get retrieves a product
-func (r *ProductPGSQL) Get(id id.ID) (*entity.Product, error) {
+func (r *ProductPGSQL) Get(tenantID id.ID, productID id.ID) (*entity.Product, error) {
 	stmt, err := r.db.Prepare(`
 		SELECT id, tenant_id, ext_id, ext_name, title, ctype, base_product_ext_id, 
-			duration_days, visibility, max_attendees, format, is_auto_approve, created_at 
-		FROM product WHERE id = $1;`)
+			duration_days, visibility, max_attendees, format, is_auto_approve, is_deleted, created_at
+		FROM product WHERE id = $1 AND tenant_id = $2;`)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +71,7 @@ func (r *ProductPGSQL) Get(id id.ID) (*entity.Product, error) {
 	var ext_id, base_product_ext_id, visibility, format sql.NullString
 	var duration_days, max_attendees sql.NullInt32
 
-	err = stmt.QueryRow(id).Scan(
+	err = stmt.QueryRow(productID, tenantID).Scan(
 		&p.ID,
 		&p.TenantID,
 		&ext_id,
@@ -83,6 +84,7 @@ func (r *ProductPGSQL) Get(id id.ID) (*entity.Product, error) {
 		&max_attendees,
 		&format,
 		&p.IsAutoApprove,
+		&p.IsDeleted,
 		&p.CreatedAt,
 	)
 	if err != nil {
@@ -105,12 +107,12 @@ func (r *ProductPGSQL) Get(id id.ID) (*entity.Product, error) {
 // Update updates a product
 func (r *ProductPGSQL) Update(e *entity.Product) error {
 	e.UpdatedAt = time.Now()
-	_, err := r.db.Exec(`
+	res, err := r.db.Exec(`
 		UPDATE product 
 		SET ext_name = $1, title = $2, ctype = $3, base_product_ext_id = $4,
 			duration_days = $5, visibility = $6, max_attendees = $7,
 			format = $8,  is_auto_approve = $9, updated_at = $10
-		WHERE id = $11;`,
+		WHERE id = $11 AND tenant_id = $12;`,
 		e.ExtName,
 		e.Title,
 		e.CType,
@@ -122,10 +124,15 @@ func (r *ProductPGSQL) Update(e *entity.Product) error {
 		e.IsAutoApprove,
 		e.UpdatedAt.Format("2006-01-02"),
 		e.ID,
+		e.TenantID,
 	)
 	if err != nil {
 		return err
 	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
 	return nil
 }
 
@@ -133,9 +140,10 @@ func (r *ProductPGSQL) Update(e *entity.Product) error {
 func (r *ProductPGSQL) Search(tenantID id.ID, q string, page, limit int) ([]*entity.Product, error) {
 	query := `
 		SELECT id, tenant_id, ext_id, ext_name, title, ctype, base_product_ext_id,
-			duration_days, visibility, max_attendees, format, is_auto_approve, created_at
+			duration_days, visibility, max_attendees, format, is_auto_approve, is_deleted, created_at
 		FROM product 
-		WHERE tenant_id = $1 AND (LOWER(ext_name) LIKE LOWER($2) OR LOWER(title) LIKE LOWER($2))
+		WHERE tenant_id = $1 AND NOT is_deleted
+			AND (LOWER(ext_name) LIKE LOWER($2) OR LOWER(title) LIKE LOWER($2))
 	`
 
 	// Add pagination if specified
@@ -172,9 +180,9 @@ func (r *ProductPGSQL) Search(tenantID id.ID, q string, page, limit int) ([]*ent
 func (r *ProductPGSQL) List(tenantID id.ID, page, limit int) ([]*entity.Product, error) {
 	query := `
 		SELECT id, tenant_id, ext_id, ext_name, title, ctype, base_product_ext_id,
-			duration_days, visibility, max_attendees, format, is_auto_approve, created_at
+			duration_days, visibility, max_attendees, format, is_auto_approve, is_deleted, created_at
 		FROM product 
-		WHERE tenant_id = $1
+		WHERE tenant_id = $1 AND NOT is_deleted
 	`
 
 	// Add pagination if specified
@@ -208,8 +216,8 @@ func (r *ProductPGSQL) List(tenantID id.ID, page, limit int) ([]*entity.Product,
 }
 
 // Delete deletes a product
-func (r *ProductPGSQL) Delete(id id.ID) error {
-	res, err := r.db.Exec(`DELETE FROM product WHERE id = $1;`, id)
+func (r *ProductPGSQL) Delete(tenantID id.ID, productID id.ID) error {
+	res, err := r.db.Exec(`DELETE FROM product WHERE id = $1 AND tenant_id = $2;`, productID, tenantID)
 	if err != nil {
 		return err
 	}
@@ -221,12 +229,28 @@ func (r *ProductPGSQL) Delete(id id.ID) error {
 	return nil
 }
 
+// SetDeleted marks the product as deleted or restores it
+// Note: updated_at is left as is; it tracks the Salesforce changes that the
+// upsert relies on.
+func (r *ProductPGSQL) SetDeleted(tenantID id.ID, productID id.ID, isDeleted bool) error {
+	res, err := r.db.Exec(`UPDATE product SET is_deleted = $1 WHERE id = $2 AND tenant_id = $3;`,
+		isDeleted, productID, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
+
 // GetCount gets total products count for a tenant
 func (r *ProductPGSQL) GetCount(tenantID id.ID) (int, error) {
 	stmt, err := r.db.Prepare(`
 		SELECT COUNT(*) 
 		FROM product 
-		WHERE tenant_id = $1;
+		WHERE tenant_id = $1 AND NOT is_deleted;
 	`)
 	if err != nil {
 		return 0, err
@@ -263,6 +287,7 @@ func (r *ProductPGSQL) scanRows(rows *sql.Rows) ([]*entity.Product, error) {
 			&max_attendees,
 			&format,
 			&p.IsAutoApprove,
+			&p.IsDeleted,
 			&p.CreatedAt,
 		)
 		if err != nil {
@@ -294,15 +319,15 @@ func (r *ProductPGSQL) Upsert(e *entity.Product) (id.ID, error) {
 				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 			ON CONFLICT (ext_id)
 			DO UPDATE
-				SET tenant_id = $2, ext_name = $4, title = $5, ctype = $6, base_product_ext_id = $7,
+				SET ext_name = $4, title = $5, ctype = $6, base_product_ext_id = $7,
 					duration_days = $8, visibility = $9, max_attendees = $10,
 					format = $11,  is_auto_approve = $12, created_at = $13, updated_at = $14
-			WHERE product.updated_at <= $14
+			WHERE product.updated_at <= $14 AND product.tenant_id = EXCLUDED.tenant_id
 			RETURNING id
 		)
 		SELECT id FROM upsert
 		UNION ALL
-		SELECT id FROM product WHERE ext_id = $3 AND NOT EXISTS (SELECT 1 FROM upsert);
+		SELECT id FROM product WHERE ext_id = $3 AND tenant_id = $2 AND NOT EXISTS (SELECT 1 FROM upsert);
 	`)
 	if err != nil {
 		l.Log.Warnf("err=%v", err)
@@ -334,6 +359,48 @@ func (r *ProductPGSQL) Upsert(e *entity.Product) (id.ID, error) {
 	return productID, nil
 }
 
+// BulkUpsert upserts the tenant's products keyed by external id and returns
+// their ids by external id
+func (r *ProductPGSQL) BulkUpsert(tenantID id.ID,
+	products []*entity.Product,
+) (map[string]id.ID, error) {
+	extIDs := make([]string, len(products))
+	for i, e := range products {
+		extIDs[i] = e.ExtID
+	}
+
+	values := func(index int) []interface{} {
+		e := products[index]
+		return []interface{}{
+			e.ID,
+			e.ExtID,
+			tenantID,
+			e.ExtName,
+			e.Title,
+			e.CType,
+			e.BaseProductExtID,
+			e.DurationDays,
+			string(e.Visibility),
+			e.MaxAttendees,
+			string(e.Format),
+			e.IsAutoApprove,
+			e.CreatedAt.Format(common.DBFormatDateTimeMS),
+			e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		}
+	}
+
+	return bulkUpsert(r.db, "product", []string{
+		"id", "ext_id", "tenant_id", "ext_name", "title", "ctype", "base_product_ext_id",
+		"duration_days", "visibility", "max_attendees", "format", "is_auto_approve",
+		"created_at", "updated_at",
+	}, tenantID, extIDs, values)
+}
+
+// GetIDsByExtIDs gets product ids by external id; unknown ones are left out
+func (r *ProductPGSQL) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	return getIDsByExtIDs(r.db, "product", tenantID, extIDs)
+}
+
 // GetByExtID retrieves a product using external id
 func (r *ProductPGSQL) GetByExtID(tenantID id.ID,
 	extID string,
@@ -342,7 +409,7 @@ func (r *ProductPGSQL) GetByExtID(tenantID id.ID,
 		SELECT
 		 	id, tenant_id, ext_id, ext_name, title, ctype, base_product_ext_id, 
 			duration_days, visibility, max_attendees, format, is_auto_approve,
-			created_at 
+			is_deleted, created_at
 		FROM product
 		WHERE
 			tenant_id = $1 AND ext_id = $2;
@@ -369,6 +436,7 @@ func (r *ProductPGSQL) GetByExtID(tenantID id.ID,
 		&max_attendees,
 		&format,
 		&p.IsAutoApprove,
+		&p.IsDeleted,
 		&p.CreatedAt,
 	)
 	if err != nil {
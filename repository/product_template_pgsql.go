@@ -0,0 +1,83 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// ProductTemplatePGSQL product template repo
+type ProductTemplatePGSQL struct {
+	db *sql.DB
+}
+
+// NewProductTemplatePGSQL create new repository
+func NewProductTemplatePGSQL(db *sql.DB) *ProductTemplatePGSQL {
+	return &ProductTemplatePGSQL{
+		db: db,
+	}
+}
+
+// Get gets the template of the tenant's product
+func (r *ProductTemplatePGSQL) Get(tenantID id.ID, productID id.ID) (*entity.ProductTemplate, error) {
+	var t entity.ProductTemplate
+	var startTime, endTime, notes sql.NullString
+	err := r.db.QueryRow(`
+		SELECT pt.product_id, p.tenant_id, pt.start_time, pt.end_time, pt.notes, pt.updated_at
+		FROM product_template pt
+		JOIN product p ON p.id = pt.product_id
+		WHERE p.tenant_id = $1 AND pt.product_id = $2;`,
+		tenantID, productID).Scan(&t.ProductID, &t.TenantID, &startTime, &endTime, &notes,
+		&t.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+
+	t.StartTime = startTime.String
+	t.EndTime = endTime.String
+	t.Notes = notes.String
+	_ = t.Normalize()
+	return &t, nil
+}
+
+// Set sets or replaces the template of the product
+// Note: The template is set only when the product belongs to the tenant.
+func (r *ProductTemplatePGSQL) Set(e *entity.ProductTemplate) error {
+	res, err := r.db.Exec(`
+		INSERT INTO product_template (product_id, start_time, end_time, notes, updated_at)
+		SELECT $1, $2, $3, $4, $5
+		WHERE EXISTS (SELECT 1 FROM product WHERE id = $1 AND tenant_id = $6)
+		ON CONFLICT (product_id)
+		DO UPDATE
+			SET start_time = $2, end_time = $3, notes = $4, updated_at = $5;`,
+		e.ProductID,
+		e.StartTime,
+		e.EndTime,
+		e.Notes,
+		e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		e.TenantID,
+	)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
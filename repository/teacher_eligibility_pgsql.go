@@ -0,0 +1,276 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+const teacherEligibilityColumns = `te.product_id, te.teacher_id, te.ext_id, te.type,
+	te.created_at, te.updated_at`
+
+// TeacherEligibilityPGSQL teacher eligibility repo
+type TeacherEligibilityPGSQL struct {
+	db *sql.DB
+}
+
+// NewTeacherEligibilityPGSQL create new repository
+func NewTeacherEligibilityPGSQL(db *sql.DB) *TeacherEligibilityPGSQL {
+	return &TeacherEligibilityPGSQL{
+		db: db,
+	}
+}
+
+// Get gets the eligibility of the teacher for the product; nil when not found
+func (r *TeacherEligibilityPGSQL) Get(tenantID id.ID,
+	productID id.ID,
+	teacherID id.ID,
+) (*entity.TeacherEligibility, error) {
+	rows, err := r.db.Query(`
+		SELECT `+teacherEligibilityColumns+`
+		FROM teacher_eligibility te
+		JOIN product p ON p.id = te.product_id
+		WHERE p.tenant_id = $1 AND te.product_id = $2 AND te.teacher_id = $3;`,
+		tenantID, productID, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list, err := r.scanRows(rows)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
+
+// GetByExtID gets the eligibility by its Salesforce id; nil when not found
+func (r *TeacherEligibilityPGSQL) GetByExtID(tenantID id.ID, extID string) (*entity.TeacherEligibility, error) {
+	rows, err := r.db.Query(`
+		SELECT `+teacherEligibilityColumns+`
+		FROM teacher_eligibility te
+		JOIN product p ON p.id = te.product_id
+		WHERE p.tenant_id = $1 AND te.ext_id = $2;`,
+		tenantID, extID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list, err := r.scanRows(rows)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
+
+// ListByProduct lists the eligibility of the teachers for the product
+func (r *TeacherEligibilityPGSQL) ListByProduct(tenantID id.ID,
+	productID id.ID,
+	page, limit int,
+) ([]*entity.TeacherEligibility, error) {
+	query := `
+		SELECT ` + teacherEligibilityColumns + `
+		FROM teacher_eligibility te
+		JOIN product p ON p.id = te.product_id
+		WHERE p.tenant_id = $1 AND te.product_id = $2
+		ORDER BY te.teacher_id`
+	args := []interface{}{tenantID, productID}
+	if page > 0 && limit > 0 {
+		query += ` LIMIT $3 OFFSET $4`
+		args = append(args, limit, (page-1)*limit)
+	}
+
+	rows, err := r.db.Query(query+";", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// ListByTeacher lists the eligibility of the teacher for the products
+func (r *TeacherEligibilityPGSQL) ListByTeacher(tenantID id.ID, teacherID id.ID) ([]*entity.TeacherEligibility, error) {
+	rows, err := r.db.Query(`
+		SELECT `+teacherEligibilityColumns+`
+		FROM teacher_eligibility te
+		JOIN product p ON p.id = te.product_id
+		WHERE p.tenant_id = $1 AND te.teacher_id = $2
+		ORDER BY te.product_id;`,
+		tenantID, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanRows(rows)
+}
+
+// Nearby lists the teachers eligible for the product who taught at enabled
+// centers within the query radius, ordered by the distance of the nearest one
+func (r *TeacherEligibilityPGSQL) Nearby(tenantID id.ID,
+	productID id.ID,
+	q *entity.GeoQuery,
+	page, limit int,
+) ([]*entity.EligibleTeacher, error) {
+	box := q.BoundingBox()
+	query := `
+		SELECT product_id, teacher_id, ext_id, type, created_at, updated_at,
+			MIN(distance) AS nearest
+		FROM (
+			SELECT ` + teacherEligibilityColumns + `, ` + geoDistanceSQL(3, 4) + ` AS distance
+			FROM teacher_eligibility te
+			JOIN product p ON p.id = te.product_id
+			JOIN course_teacher cot ON cot.teacher_id = te.teacher_id
+			JOIN course co ON co.id = cot.course_id
+			JOIN center c ON c.id = co.center_id
+			WHERE p.tenant_id = $1 AND te.product_id = $2
+				AND co.tenant_id = $1 AND c.tenant_id = $1 AND c.is_enabled = TRUE
+				AND ` + geoBoundingBoxSQL(5) + `
+		) taught
+		WHERE distance <= $9
+		GROUP BY product_id, teacher_id, ext_id, type, created_at, updated_at
+		ORDER BY nearest, teacher_id`
+	args := []interface{}{tenantID, productID, q.Lat, q.Long,
+		box.MinLat, box.MaxLat, box.MinLong, box.MaxLong, q.RadiusKm}
+	if page > 0 && limit > 0 {
+		query += ` LIMIT $10 OFFSET $11`
+		args = append(args, limit, (page-1)*limit)
+	}
+
+	rows, err := r.db.Query(query+";", args...)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teachers []*entity.EligibleTeacher
+	for rows.Next() {
+		var distance float64
+		e, err := r.scanRow(rows, &distance)
+		if err != nil {
+			return nil, err
+		}
+		teachers = append(teachers, &entity.EligibleTeacher{TeacherEligibility: e, DistanceKm: distance})
+	}
+	return teachers, nil
+}
+
+// Upsert sets the eligibility of the teacher for the product
+// Note: The eligibility is written only when the product and the teacher
+// belong to the tenant.
+func (r *TeacherEligibilityPGSQL) Upsert(tenantID id.ID, e *entity.TeacherEligibility) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if e.ExtID != nil {
+		// the Salesforce record now refers to another product or teacher
+		_, err = tx.Exec(`
+			DELETE FROM teacher_eligibility
+			WHERE ext_id = $1 AND (product_id <> $2 OR teacher_id <> $3);`,
+			*e.ExtID, e.ProductID, e.TeacherID)
+		if err != nil {
+			return err
+		}
+	}
+
+	res, err := tx.Exec(`
+		INSERT INTO teacher_eligibility (product_id, teacher_id, ext_id, type, created_at, updated_at)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE EXISTS (SELECT 1 FROM product WHERE id = $1 AND tenant_id = $7)
+			AND EXISTS (SELECT 1 FROM account WHERE id = $2 AND tenant_id = $7)
+		ON CONFLICT (product_id, teacher_id)
+		DO UPDATE
+			SET ext_id = COALESCE(EXCLUDED.ext_id, teacher_eligibility.ext_id),
+				type = EXCLUDED.type, updated_at = EXCLUDED.updated_at;`,
+		e.ProductID,
+		e.TeacherID,
+		e.ExtID,
+		e.Type,
+		e.CreatedAt.Format(common.DBFormatDateTimeMS),
+		e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		tenantID,
+	)
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return err
+	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		err = glad.ErrNotFound
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes the eligibility of the teacher for the product
+func (r *TeacherEligibilityPGSQL) Delete(tenantID id.ID, productID id.ID, teacherID id.ID) error {
+	res, err := r.db.Exec(`
+		DELETE FROM teacher_eligibility te
+		USING product p
+		WHERE p.id = te.product_id AND p.tenant_id = $1
+			AND te.product_id = $2 AND te.teacher_id = $3;`,
+		tenantID, productID, teacherID)
+	if err != nil {
+		return err
+	}
+
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return glad.ErrNotFound
+	}
+	return nil
+}
+
+// scanRow scans the eligibility followed by the extra columns, if any
+func (r *TeacherEligibilityPGSQL) scanRow(rows *sql.Rows, extra ...interface{}) (*entity.TeacherEligibility, error) {
+	var e entity.TeacherEligibility
+	var extID sql.NullString
+
+	err := rows.Scan(append([]interface{}{
+		&e.ProductID,
+		&e.TeacherID,
+		&extID,
+		&e.Type,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	}, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if extID.Valid {
+		e.ExtID = &extID.String
+	}
+	return &e, nil
+}
+
+func (r *TeacherEligibilityPGSQL) scanRows(rows *sql.Rows) ([]*entity.TeacherEligibility, error) {
+	var list []*entity.TeacherEligibility
+	for rows.Next() {
+		e, err := r.scanRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, nil
+}
@@ -16,6 +16,8 @@ import (
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
 	"ac9/glad/usecase/account"
 
 	"ac9/glad/services/coursed/presenter"
@@ -213,7 +215,7 @@ func deleteAccount(service account.UseCase) http.Handler {
 			return
 		}
 
-		err = service.DeleteAccount(tenantID, accountID)
+		err = service.DeleteAccount(auditActor(r, entity.AuditSourceAPI), tenantID, accountID)
 		switch err {
 		case nil:
 			w.WriteHeader(http.StatusOK)
@@ -282,7 +284,7 @@ func deleteAccountByUsername(service account.UseCase) http.Handler {
 		errorMessage := "Error removing account"
 		vars := mux.Vars(r)
 		username := vars["username"]
-		err = service.DeleteAccountByName(tenantID, username)
+		err = service.DeleteAccountByName(auditActor(r, entity.AuditSourceAPI), tenantID, username)
 		switch err {
 		case nil:
 			w.WriteHeader(http.StatusOK)
@@ -302,12 +304,22 @@ func deleteAccountByUsername(service account.UseCase) http.Handler {
 func updateAccount(service account.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error updating account"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
 
 		vars := mux.Vars(r)
-		username := vars["username"]
+		accountID, err := id.FromString(vars["id"])
+		if err != nil {
+			l.Log.Warnf("Unable to convert id=%v to internal format", vars["id"])
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
 
 		var account entity.Account
-		err := json.NewDecoder(r.Body).Decode(&account)
+		err = json.NewDecoder(r.Body).Decode(&account)
 		if err != nil {
 			log.Println(err.Error())
 			w.WriteHeader(http.StatusBadRequest)
@@ -315,8 +327,46 @@ func updateAccount(service account.UseCase) http.Handler {
 			return
 		}
 
-		account.Username = username
-		err = service.UpdateAccount(&account)
+		// Note: the account being updated is the one authorized by the path,
+		// never the one in the body
+		account.ID = accountID
+		account.TenantID = tenantID
+
+		existing, err := service.GetAccount(tenantID, accountID)
+		if err == glad.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Account doesn't exist"))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		// Type and cognito id are left unchanged when they are not set; only
+		// the coordinators may change them
+		if account.Type == "" {
+			account.Type = existing.Type
+		}
+		if account.CognitoID == "" {
+			account.CognitoID = existing.CognitoID
+		}
+		caller := middleware.AccountFromContext(r.Context())
+		isCoordinator := caller != nil && caller.Type == entity.AccountCoOrdinator
+		if !isCoordinator &&
+			(account.Type != existing.Type || account.CognitoID != existing.CognitoID) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("Only coordinators may change the account type or cognito id"))
+			return
+		}
+
+		err = service.UpdateAccount(auditActor(r, entity.AuditSourceAPI), &account)
+		if err == glad.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Account doesn't exist"))
+			return
+		}
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -349,23 +399,27 @@ func importAccount(service account.UseCase) http.Handler {
 			return
 		}
 
-		var response []*presenter.AccountImportResponse
-		for _, input := range iAccounts {
-			account := &entity.Account{}
-			presenter.GladAccountToEntity(input, account)
-			account.TenantID = tenantID
+		accounts := make([]*entity.Account, len(iAccounts))
+		for i, input := range iAccounts {
+			accounts[i] = &entity.Account{}
+			presenter.GladAccountToEntity(input, accounts[i])
+		}
 
-			// TODO: optimize DB operations by doing multiple inserts simultaneously
-			accountID, err := service.UpsertAccount(account)
-			if err != nil {
-				l.Log.Warnf("Unable to upsert account extID=%v, err=%v", account.ExtID, err)
-			}
+		accountIDs, err := service.UpsertAccounts(auditActor(r, entity.AuditSourceSalesforce), tenantID, accounts)
+		if err != nil {
+			l.Log.Warnf("Unable to upsert accounts, err=%v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
 
-			response = append(response, &presenter.AccountImportResponse{
-				ID:      accountID,
+		response := make([]*presenter.AccountImportResponse, len(accounts))
+		for i, account := range accounts {
+			response[i] = &presenter.AccountImportResponse{
+				ID:      accountIDs[i],
 				ExtID:   account.ExtID,
-				IsError: err != nil,
-			})
+				IsError: accountIDs[i] == id.IDInvalid,
+			}
 		}
 
 		w.Header().Set(common.HttpHeaderTenantID, tenant)
@@ -380,8 +434,13 @@ func importAccount(service account.UseCase) http.Handler {
 }
 
 // MakeAccountHandlers make url handlers
-func MakeAccountHandlers(r *mux.Router, n negroni.Negroni, service account.UseCase) {
+func MakeAccountHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service account.UseCase,
+) {
 	r.Handle("/v1/accounts", n.With(
+		authz.Require(policy.AccountRead),
 		negroni.Wrap(listAccounts(service)),
 	)).Methods(http.MethodGet, http.MethodOptions).Name("listAccounts")
 
@@ -390,26 +449,42 @@ func MakeAccountHandlers(r *mux.Router, n negroni.Negroni, service account.UseCa
 	// )).Methods(http.MethodPost, http.MethodOptions).Name("createAccount")
 
 	r.Handle("/v1/accounts/import", n.With(
+		authz.Require(policy.AccountImport),
 		negroni.Wrap(importAccount(service)),
 	)).Methods(http.MethodPost, http.MethodOptions).Name("importAccount")
 
+	r.Handle("/v1/accounts/import/{extID}", n.With(
+		authz.Require(policy.AccountImport),
+		negroni.Wrap(syncByExtID(service.ArchiveAccountByExtID, "Error archiving account")),
+	)).Methods(http.MethodDelete, http.MethodOptions).Name("archiveAccountByExtID")
+
+	r.Handle("/v1/accounts/import/{extID}/restore", n.With(
+		authz.Require(policy.AccountImport),
+		negroni.Wrap(syncByExtID(service.RestoreAccountByExtID, "Error restoring account")),
+	)).Methods(http.MethodPost, http.MethodOptions).Name("restoreAccountByExtID")
+
 	r.Handle("/v1/accounts/{id}", n.With(
+		authz.Require(policy.AccountRead),
 		negroni.Wrap(getAccount(service)),
 	)).Methods(http.MethodGet, http.MethodOptions).Name("getAccount")
 
 	r.Handle("/v1/accounts/{id}", n.With(
+		authz.Require(policy.AccountWrite),
 		negroni.Wrap(deleteAccount(service)),
 	)).Methods(http.MethodDelete, http.MethodOptions).Name("deleteAccount")
 
 	r.Handle("/v1/accounts/username/{username}", n.With(
+		authz.Require(policy.AccountRead),
 		negroni.Wrap(getAccountByUsername(service)),
 	)).Methods(http.MethodGet, http.MethodOptions).Name("getAccountByUsername")
 
 	r.Handle("/v1/accounts/username/{username}", n.With(
+		authz.Require(policy.AccountWrite),
 		negroni.Wrap(deleteAccountByUsername(service)),
 	)).Methods(http.MethodDelete, http.MethodOptions).Name("deleteAccountByUsername")
 
 	r.Handle("/v1/accounts/{id}", n.With(
+		authz.Require(policy.AccountWrite),
 		negroni.Wrap(updateAccount(service)),
 	)).Methods(http.MethodPut, http.MethodOptions).Name("updateAccount")
 }
@@ -8,10 +8,12 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"ac9/glad/entity"
@@ -19,6 +21,8 @@ import (
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	"ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
 	"ac9/glad/services/coursed/presenter"
 
 	mock "ac9/glad/usecase/account/mock"
@@ -51,13 +55,33 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// coordinatorCaller is permitted every action in the default policy
+var coordinatorCaller = &entity.Account{
+	ID:       accountIDSecondary,
+	TenantID: tenantAlice,
+	Username: accountUsernameSecondary,
+	Type:     entity.AccountCoOrdinator,
+}
+
+// newTestNegroni adds the caller to the request context as done by the authentication middleware
+func newTestNegroni(caller *entity.Account) *negroni.Negroni {
+	return negroni.New(negroni.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+			next(w, r.WithContext(middleware.WithAccount(r.Context(), caller)))
+		}))
+}
+
+func testAuthorizer() *middleware.Authorizer {
+	return middleware.NewAuthorizer(policy.Default(), nil, nil)
+}
+
 func Test_listAccounts(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeAccountHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeAccountHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("listAccounts").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/accounts", path)
@@ -142,8 +166,8 @@ func Test_getAccount(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeAccountHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeAccountHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("getAccount").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/accounts/{id}", path)
@@ -186,14 +210,14 @@ func Test_deleteAccount(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeAccountHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeAccountHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("deleteAccount").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/accounts/{id}", path)
 
 	accountID := accountIDPrimary
-	service.EXPECT().DeleteAccount(tenantAlice, accountID).Return(nil)
+	service.EXPECT().DeleteAccount(gomock.Any(), tenantAlice, accountID).Return(nil)
 	handler := deleteAccount(service)
 	req, _ := http.NewRequest("DELETE", "/v1/accounts/"+accountID.String(), nil)
 	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
@@ -208,8 +232,8 @@ func Test_getAccountByUsername(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeAccountHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeAccountHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("getAccountByUsername").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/accounts/username/{username}", path)
@@ -252,14 +276,14 @@ func Test_deleteAccountByUsername(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeAccountHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeAccountHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("deleteAccountByUsername").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/accounts/username/{username}", path)
 
 	username := accountUsernamePrimary
-	service.EXPECT().DeleteAccountByName(tenantAlice, username).Return(nil)
+	service.EXPECT().DeleteAccountByName(gomock.Any(), tenantAlice, username).Return(nil)
 	handler := deleteAccount(service)
 	req, _ := http.NewRequest("DELETE", "/v1/accounts/username/"+username, nil)
 	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
@@ -274,14 +298,14 @@ func Test_deleteAccountNonExistent(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeAccountHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeAccountHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("deleteAccountByUsername").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/accounts/username/{username}", path)
 
 	username := accountUsernamePrimary
-	service.EXPECT().DeleteAccountByName(tenantAlice, username).Return(glad.ErrNotFound)
+	service.EXPECT().DeleteAccountByName(gomock.Any(), tenantAlice, username).Return(glad.ErrNotFound)
 	handler := deleteAccount(service)
 	req, _ := http.NewRequest("DELETE", "/v1/accounts/username/"+username, nil)
 	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
@@ -292,3 +316,65 @@ func Test_deleteAccountNonExistent(t *testing.T) {
 }
 
 // TODO: Test case for updating account
+
+func Test_updateAccount(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+
+	student := &entity.Account{
+		ID:        accountIDPrimary,
+		TenantID:  tenantAlice,
+		Username:  accountUsernamePrimary,
+		CognitoID: "aws:cognito:alice",
+		Type:      entity.AccountStudent,
+	}
+
+	serve := func(caller *entity.Account, path string, body string) *httptest.ResponseRecorder {
+		r := mux.NewRouter()
+		n := newTestNegroni(caller)
+		MakeAccountHandlers(r, *n, testAuthorizer(), service)
+		req, _ := http.NewRequest(http.MethodPut, path, strings.NewReader(body))
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+	selfPath := "/v1/accounts/" + accountIDPrimary.String()
+
+	t.Run("self", func(t *testing.T) {
+		service.EXPECT().GetAccount(tenantAlice, accountIDPrimary).Return(student, nil)
+		service.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ entity.AuditActor, a *entity.Account) error {
+				assert.Equal(t, accountIDPrimary, a.ID)
+				assert.Equal(t, entity.AccountStudent, a.Type)
+				assert.Equal(t, "aws:cognito:alice", a.CognitoID)
+				return nil
+			})
+		body := fmt.Sprintf(`{"ID":%d,"Username":"alice"}`, accountIDSecondary)
+		rr := serve(student, selfPath, body)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("self promotion", func(t *testing.T) {
+		service.EXPECT().GetAccount(tenantAlice, accountIDPrimary).Return(student, nil)
+		rr := serve(student, selfPath, `{"Username":"alice","Type":"coordinator"}`)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("other account", func(t *testing.T) {
+		rr := serve(student, "/v1/accounts/"+accountIDSecondary.String(), `{"Username":"alice"}`)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("coordinator", func(t *testing.T) {
+		service.EXPECT().GetAccount(tenantAlice, accountIDPrimary).Return(student, nil)
+		service.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ entity.AuditActor, a *entity.Account) error {
+				assert.Equal(t, entity.AccountTeacher, a.Type)
+				return nil
+			})
+		rr := serve(coordinatorCaller, selfPath, `{"Username":"alice","Type":"teacher"}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -0,0 +1,379 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
+	"ac9/glad/services/coursed/presenter"
+	"ac9/glad/usecase/attendance"
+
+	"github.com/gorilla/mux"
+	"github.com/urfave/negroni"
+)
+
+// sessionFromPath parses the course and timing ids of the session in the path;
+// responds with bad request when they cannot be parsed
+func sessionFromPath(w http.ResponseWriter, r *http.Request) (id.ID, id.ID, error) {
+	vars := mux.Vars(r)
+	courseID, err := id.FromString(vars["courseId"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Unable to parse course id"))
+		return id.IDInvalid, id.IDInvalid, err
+	}
+	timingID, err := id.FromString(vars["timingId"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Unable to parse timing id"))
+		return id.IDInvalid, id.IDInvalid, err
+	}
+	return courseID, timingID, nil
+}
+
+// writeAttendanceError responds with the status of the attendance error;
+// false when there is no error
+func writeAttendanceError(w http.ResponseWriter, errorMessage string, err error) bool {
+	switch err {
+	case nil:
+		return false
+	case glad.ErrInvalidEntity, glad.ErrInvalidValue:
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+	case glad.ErrInvalidCode:
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+	case glad.ErrTooManyAttempts:
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+	case glad.ErrNotFound:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(errorMessage))
+	default:
+		l.Log.Errorf(err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(errorMessage))
+	}
+	return true
+}
+
+// writeJSON encodes the response
+func writeJSON(w http.ResponseWriter, tenantID id.ID, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		l.Log.Errorf(err.Error())
+	}
+}
+
+func listSessionAttendance(service attendance.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading attendance"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+		courseID, timingID, err := sessionFromPath(w, r)
+		if err != nil {
+			return
+		}
+
+		data, err := service.ListSessionAttendance(tenantID, courseID, timingID)
+		if writeAttendanceError(w, errorMessage, err) {
+			return
+		}
+
+		var toJ []*presenter.Attendance
+		for _, d := range data {
+			a := &presenter.Attendance{}
+			a.FromAttendanceEntity(d)
+			toJ = append(toJ, a)
+		}
+
+		w.Header().Set(common.HttpHeaderTotalCount, strconv.Itoa(len(toJ)))
+		writeJSON(w, tenantID, http.StatusOK, toJ)
+	})
+}
+
+func markAttendance(service attendance.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error marking attendance"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+		courseID, timingID, err := sessionFromPath(w, r)
+		if err != nil {
+			return
+		}
+
+		var input presenter.AttendanceMarkReq
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		var recordedBy id.ID
+		if account := middleware.AccountFromContext(r.Context()); account != nil {
+			recordedBy = account.ID
+		}
+
+		var records []*entity.Attendance
+		for i := range input.Records {
+			records = append(records, input.Records[i].ToAttendanceEntity())
+		}
+
+		err = service.MarkAttendance(recordedBy, tenantID, courseID, timingID, records)
+		if writeAttendanceError(w, errorMessage, err) {
+			return
+		}
+
+		listSessionAttendance(service).ServeHTTP(w, r)
+	})
+}
+
+func createCheckInCode(service attendance.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error creating check-in code"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+		courseID, timingID, err := sessionFromPath(w, r)
+		if err != nil {
+			return
+		}
+
+		var createdBy id.ID
+		if account := middleware.AccountFromContext(r.Context()); account != nil {
+			createdBy = account.ID
+		}
+
+		c, err := service.CreateCheckInCode(createdBy, tenantID, courseID, timingID)
+		if writeAttendanceError(w, errorMessage, err) {
+			return
+		}
+
+		response := &presenter.AttendanceCode{}
+		response.FromAttendanceCodeEntity(c)
+		writeJSON(w, tenantID, http.StatusCreated, response)
+	})
+}
+
+func checkIn(service attendance.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error checking in"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+		courseID, timingID, err := sessionFromPath(w, r)
+		if err != nil {
+			return
+		}
+
+		account := middleware.AccountFromContext(r.Context())
+		if account == nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("Unknown account"))
+			return
+		}
+
+		var input presenter.CheckInReq
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		a, err := service.CheckIn(tenantID, courseID, timingID, account.ID, input.Code, time.Now())
+		if writeAttendanceError(w, errorMessage, err) {
+			return
+		}
+
+		response := &presenter.Attendance{}
+		response.FromAttendanceEntity(a)
+		writeJSON(w, tenantID, http.StatusOK, response)
+	})
+}
+
+func getCourseAttendance(service attendance.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading course attendance"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		courseID, err := id.FromString(mux.Vars(r)["courseId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse course id"))
+			return
+		}
+
+		data, err := service.GetCourseAttendance(tenantID, courseID)
+		if writeAttendanceError(w, errorMessage, err) {
+			return
+		}
+
+		response := &presenter.CourseAttendance{}
+		response.FromCourseAttendanceEntity(data)
+		writeJSON(w, tenantID, http.StatusOK, response)
+	})
+}
+
+// getMyAttendance gets the attendance of the caller in the course
+func getMyAttendance(service attendance.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading attendance"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		courseID, err := id.FromString(mux.Vars(r)["courseId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse course id"))
+			return
+		}
+
+		account := middleware.AccountFromContext(r.Context())
+		if account == nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("Unknown account"))
+			return
+		}
+
+		data, err := service.GetParticipantAttendance(tenantID, courseID, account.ID)
+		if writeAttendanceError(w, errorMessage, err) {
+			return
+		}
+
+		response := &presenter.ParticipantAttendance{}
+		response.FromParticipantAttendanceEntity(data)
+		writeJSON(w, tenantID, http.StatusOK, response)
+	})
+}
+
+func getAttendanceRequirement(service attendance.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading attendance requirement"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		productID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse product id"))
+			return
+		}
+
+		percent, err := service.GetMinAttendance(tenantID, productID)
+		if writeAttendanceError(w, errorMessage, err) {
+			return
+		}
+
+		writeJSON(w, tenantID, http.StatusOK, &presenter.AttendanceRequirement{MinPercent: percent})
+	})
+}
+
+func setAttendanceRequirement(service attendance.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error setting attendance requirement"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		productID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse product id"))
+			return
+		}
+
+		var input presenter.AttendanceRequirement
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		err = service.SetMinAttendance(tenantID, productID, input.MinPercent)
+		if writeAttendanceError(w, errorMessage, err) {
+			return
+		}
+
+		writeJSON(w, tenantID, http.StatusOK, &input)
+	})
+}
+
+// MakeAttendanceHandlers make url handlers
+func MakeAttendanceHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service attendance.UseCase,
+) {
+	r.Handle("/v1/courses/{courseId}/timings/{timingId}/attendance", n.With(
+		authz.Require(policy.AttendanceRead),
+		negroni.Wrap(listSessionAttendance(service)),
+	)).Methods("GET", "OPTIONS").Name("listSessionAttendance")
+
+	r.Handle("/v1/courses/{courseId}/timings/{timingId}/attendance", n.With(
+		authz.Require(policy.AttendanceWrite),
+		negroni.Wrap(markAttendance(service)),
+	)).Methods("PUT", "OPTIONS").Name("markAttendance")
+
+	r.Handle("/v1/courses/{courseId}/timings/{timingId}/checkin-code", n.With(
+		authz.Require(policy.AttendanceWrite),
+		negroni.Wrap(createCheckInCode(service)),
+	)).Methods("POST", "OPTIONS").Name("createCheckInCode")
+
+	r.Handle("/v1/courses/{courseId}/timings/{timingId}/checkin", n.With(
+		authz.Require(policy.AttendanceSelf),
+		negroni.Wrap(checkIn(service)),
+	)).Methods("POST", "OPTIONS").Name("checkIn")
+
+	r.Handle("/v1/courses/{courseId}/attendance", n.With(
+		authz.Require(policy.AttendanceRead),
+		negroni.Wrap(getCourseAttendance(service)),
+	)).Methods("GET", "OPTIONS").Name("getCourseAttendance")
+
+	r.Handle("/v1/courses/{courseId}/attendance/me", n.With(
+		authz.Require(policy.AttendanceSelf),
+		negroni.Wrap(getMyAttendance(service)),
+	)).Methods("GET", "OPTIONS").Name("getMyAttendance")
+
+	r.Handle("/v1/products/{id}/attendance", n.With(
+		authz.Require(policy.ProductRead),
+		negroni.Wrap(getAttendanceRequirement(service)),
+	)).Methods("GET", "OPTIONS").Name("getAttendanceRequirement")
+
+	r.Handle("/v1/products/{id}/attendance", n.With(
+		authz.Require(policy.ProductWrite),
+		negroni.Wrap(setAttendanceRequirement(service)),
+	)).Methods("PUT", "OPTIONS").Name("setAttendanceRequirement")
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/coursed/presenter"
+
+	mock "ac9/glad/usecase/attendance/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	attendanceTimingID  id.ID = 13790493495087076001
+	attendanceProductID id.ID = 13790493495087076002
+)
+
+func Test_attendance(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	for name, want := range map[string]string{
+		"listSessionAttendance":    "/v1/courses/{courseId}/timings/{timingId}/attendance",
+		"markAttendance":           "/v1/courses/{courseId}/timings/{timingId}/attendance",
+		"createCheckInCode":        "/v1/courses/{courseId}/timings/{timingId}/checkin-code",
+		"checkIn":                  "/v1/courses/{courseId}/timings/{timingId}/checkin",
+		"getCourseAttendance":      "/v1/courses/{courseId}/attendance",
+		"getMyAttendance":          "/v1/courses/{courseId}/attendance/me",
+		"getAttendanceRequirement": "/v1/products/{id}/attendance",
+		"setAttendanceRequirement": "/v1/products/{id}/attendance",
+	} {
+		r := mux.NewRouter()
+		MakeAttendanceHandlers(r, *newTestNegroni(coordinatorCaller), testAuthorizer(), service)
+		path, err := r.GetRoute(name).GetPathTemplate()
+		assert.Nil(t, err)
+		assert.Equal(t, want, path)
+	}
+
+	member := &entity.Account{
+		ID:       accountIDPrimary,
+		TenantID: tenantAlice,
+		Username: accountUsernamePrimary,
+		Type:     entity.AccountMember,
+	}
+	do := func(caller *entity.Account, method, path string, body interface{}) *httptest.ResponseRecorder {
+		r := mux.NewRouter()
+		MakeAttendanceHandlers(r, *newTestNegroni(caller), testAuthorizer(), service)
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+	coursePath := "/v1/courses/" + participantCourseID.String()
+	sessionPath := coursePath + "/timings/" + attendanceTimingID.String()
+	checkedInAt := time.Date(2024, 5, 14, 17, 5, 0, 0, time.UTC)
+	present := &entity.Attendance{
+		ID:            id.New(),
+		CourseID:      participantCourseID,
+		TimingID:      attendanceTimingID,
+		ParticipantID: participantIDAlice,
+		Status:        entity.AttendancePresent,
+		CheckedInAt:   checkedInAt,
+		RecordedBy:    coordinatorCaller.ID,
+	}
+
+	t.Run("mark", func(t *testing.T) {
+		service.EXPECT().MarkAttendance(coordinatorCaller.ID, tenantAlice, participantCourseID,
+			attendanceTimingID, []*entity.Attendance{
+				{ParticipantID: participantIDAlice, Status: entity.AttendancePresent, CheckedInAt: checkedInAt},
+			}).Return(nil)
+		service.EXPECT().ListSessionAttendance(tenantAlice, participantCourseID, attendanceTimingID).
+			Return([]*entity.Attendance{present}, nil)
+
+		rr := do(coordinatorCaller, http.MethodPut, sessionPath+"/attendance", presenter.AttendanceMarkReq{
+			Records: []presenter.AttendanceReq{
+				{ParticipantID: participantIDAlice, Status: entity.AttendancePresent, CheckedInAt: &checkedInAt},
+			},
+		})
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d []*presenter.Attendance
+		_ = json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, 1, len(d))
+		assert.Equal(t, entity.AttendancePresent, d[0].Status)
+		assert.True(t, checkedInAt.Equal(*d[0].CheckedInAt))
+
+		// participants cannot mark attendance
+		rr = do(member, http.MethodPut, sessionPath+"/attendance", presenter.AttendanceMarkReq{})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("check-in code", func(t *testing.T) {
+		code := &entity.AttendanceCode{
+			TimingID:  attendanceTimingID,
+			CourseID:  participantCourseID,
+			Code:      "012345",
+			ExpiresAt: checkedInAt.Add(15 * time.Minute),
+		}
+		service.EXPECT().CreateCheckInCode(coordinatorCaller.ID, tenantAlice, participantCourseID,
+			attendanceTimingID).Return(code, nil)
+
+		rr := do(coordinatorCaller, http.MethodPost, sessionPath+"/checkin-code", nil)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var d presenter.AttendanceCode
+		_ = json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, "012345", d.Code)
+	})
+
+	t.Run("check in", func(t *testing.T) {
+		self := *present
+		self.RecordedBy = member.ID
+		service.EXPECT().CheckIn(tenantAlice, participantCourseID, attendanceTimingID, member.ID,
+			"012345", gomock.Any()).Return(&self, nil)
+		rr := do(member, http.MethodPost, sessionPath+"/checkin", presenter.CheckInReq{Code: "012345"})
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d presenter.Attendance
+		_ = json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, member.ID, d.RecordedBy)
+
+		service.EXPECT().CheckIn(tenantAlice, participantCourseID, attendanceTimingID, member.ID,
+			"999999", gomock.Any()).Return(nil, glad.ErrInvalidCode)
+		rr = do(member, http.MethodPost, sessionPath+"/checkin", presenter.CheckInReq{Code: "999999"})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		service.EXPECT().CheckIn(tenantAlice, participantCourseID, attendanceTimingID, member.ID,
+			"999998", gomock.Any()).Return(nil, glad.ErrTooManyAttempts)
+		rr = do(member, http.MethodPost, sessionPath+"/checkin", presenter.CheckInReq{Code: "999998"})
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		pa := &entity.ParticipantAttendance{
+			ParticipantID: participantIDAlice,
+			AccountID:     member.ID,
+			Sessions:      2,
+			Attended:      1,
+			Percent:       50,
+			Records:       []*entity.Attendance{present},
+		}
+		service.EXPECT().GetCourseAttendance(tenantAlice, participantCourseID).
+			Return(&entity.CourseAttendance{
+				CourseID:   participantCourseID,
+				MinPercent: 75,
+				Sessions: []*entity.SessionAttendance{{
+					TimingID: attendanceTimingID,
+					DateTime: entity.CourseDateTime{Date: "2024-05-14", StartTime: "10:00:00", EndTime: "12:00:00"},
+					Present:  1,
+				}},
+				Participants: []*entity.ParticipantAttendance{pa},
+			}, nil)
+
+		rr := do(coordinatorCaller, http.MethodGet, coursePath+"/attendance", nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d presenter.CourseAttendance
+		_ = json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, 75, d.MinPercent)
+		assert.Equal(t, "2024-05-14", d.Sessions[0].DateTime.Date)
+		assert.Equal(t, float64(50), d.Participants[0].Percent)
+		assert.False(t, d.Participants[0].IsEligible)
+
+		// participants only read their own attendance
+		assert.Equal(t, http.StatusForbidden, do(member, http.MethodGet, coursePath+"/attendance", nil).Code)
+
+		service.EXPECT().GetParticipantAttendance(tenantAlice, participantCourseID, member.ID).
+			Return(pa, nil)
+		rr = do(member, http.MethodGet, coursePath+"/attendance/me", nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var me presenter.ParticipantAttendance
+		_ = json.NewDecoder(rr.Body).Decode(&me)
+		assert.Equal(t, 1, me.Attended)
+		assert.Equal(t, 1, len(me.Records))
+	})
+
+	t.Run("requirement", func(t *testing.T) {
+		productPath := "/v1/products/" + attendanceProductID.String() + "/attendance"
+		service.EXPECT().SetMinAttendance(tenantAlice, attendanceProductID, 80).Return(nil)
+		rr := do(coordinatorCaller, http.MethodPut, productPath, presenter.AttendanceRequirement{MinPercent: 80})
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		service.EXPECT().SetMinAttendance(tenantAlice, attendanceProductID, 101).Return(glad.ErrInvalidValue)
+		rr = do(coordinatorCaller, http.MethodPut, productPath, presenter.AttendanceRequirement{MinPercent: 101})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		service.EXPECT().GetMinAttendance(tenantAlice, attendanceProductID).Return(80, nil)
+		rr = do(member, http.MethodGet, productPath, nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d presenter.AttendanceRequirement
+		_ = json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, 80, d.MinPercent)
+	})
+}
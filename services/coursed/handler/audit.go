@@ -0,0 +1,187 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
+	"ac9/glad/services/coursed/presenter"
+	"ac9/glad/usecase/audit"
+
+	"github.com/gorilla/mux"
+	"github.com/urfave/negroni"
+)
+
+// Audit log query parameters
+const (
+	auditParamEntity = "entity"
+	auditParamID     = "id"
+	auditParamAfter  = "after"
+	auditParamLimit  = "limit"
+)
+
+// auditActor gets the caller making the change. The authenticated account is
+// preferred over the account header; the account is unknown when neither is set.
+func auditActor(r *http.Request, source entity.AuditSource) entity.AuditActor {
+	actor := entity.AuditActor{Source: source}
+	if account := middleware.AccountFromContext(r.Context()); account != nil {
+		actor.AccountID = account.ID
+		return actor
+	}
+
+	accountID, err := id.FromString(r.Header.Get(common.HttpHeaderAccountID))
+	if err == nil {
+		actor.AccountID = accountID
+	}
+	return actor
+}
+
+func listAuditLogs(service audit.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading audit logs"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		page, limit, err := common.HttpGetPageParams(w, r)
+		if err != nil {
+			return
+		}
+
+		q := r.URL.Query()
+		entityType := entity.AuditEntityType(q.Get(auditParamEntity))
+		var entityID id.ID
+		if v := q.Get(auditParamID); v != "" {
+			entityID, err = id.FromString(v)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+		}
+
+		total, logs, err := service.ListAuditLogs(tenantID, entityType, entityID, page, limit)
+		switch err {
+		case nil:
+		case glad.ErrInvalidValue:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		default:
+			l.Log.Warnf("%v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		var responses []*presenter.AuditLog
+		for _, e := range logs {
+			resp := &presenter.AuditLog{}
+			resp.FromEntityAuditLog(e)
+			responses = append(responses, resp)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTotalCount, strconv.Itoa(total))
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		if err := json.NewEncoder(w).Encode(responses); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode audit logs"))
+		}
+	})
+}
+
+// listAuditChanges lists the changes made in GLAD after the seq of the change
+// outbox, oldest first. Salesforce export uses it as the change feed.
+func listAuditChanges(service audit.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading changes"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		q := r.URL.Query()
+		var after int64
+		if v := q.Get(auditParamAfter); v != "" {
+			after, err = strconv.ParseInt(v, 10, 64)
+			if err != nil || after < 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Invalid after"))
+				return
+			}
+		}
+		var limit int
+		if v := q.Get(auditParamLimit); v != "" {
+			limit, err = strconv.Atoi(v)
+			if err != nil || limit < 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Invalid limit"))
+				return
+			}
+		}
+
+		logs, err := service.ListChanges(tenantID, after, limit)
+		switch err {
+		case nil:
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		default:
+			l.Log.Warnf("%v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		var responses []*presenter.AuditLog
+		for _, e := range logs {
+			resp := &presenter.AuditLog{}
+			resp.FromEntityAuditLog(e)
+			responses = append(responses, resp)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		if err := json.NewEncoder(w).Encode(responses); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode changes"))
+		}
+	})
+}
+
+// MakeAuditHandlers make url handlers
+func MakeAuditHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service audit.UseCase,
+) {
+	r.Handle("/v1/audit", n.With(
+		authz.Require(policy.AuditRead),
+		negroni.Wrap(listAuditLogs(service)),
+	)).Methods("GET", "OPTIONS").Name("listAuditLogs")
+
+	r.Handle("/v1/audit/changes", n.With(
+		authz.Require(policy.AuditChangesRead),
+		negroni.Wrap(listAuditChanges(service)),
+	)).Methods("GET", "OPTIONS").Name("listAuditChanges")
+}
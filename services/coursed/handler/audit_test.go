@@ -0,0 +1,179 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/services/coursed/presenter"
+
+	mock "ac9/glad/usecase/audit/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_listAuditLogs(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeAuditHandlers(r, *n, testAuthorizer(), service)
+	path, err := r.GetRoute("listAuditLogs").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/audit", path)
+
+	courseID := id.New()
+	log := &entity.AuditLog{
+		ID:         id.New(),
+		TenantID:   tenantAlice,
+		EntityType: entity.AuditCourse,
+		EntityID:   courseID,
+		Operation:  entity.AuditUpdate,
+		Source:     entity.AuditSourceSalesforce,
+		ActorID:    coordinatorCaller.ID,
+		Changes: []entity.AuditChange{
+			{Field: "Name", Before: json.RawMessage(`"old"`), After: json.RawMessage(`"new"`)},
+		},
+	}
+	service.EXPECT().
+		ListAuditLogs(tenantAlice, entity.AuditCourse, courseID, gomock.Any(), gomock.Any()).
+		Return(1, []*entity.AuditLog{log}, nil)
+	service.EXPECT().
+		ListAuditLogs(tenantAlice, entity.AuditEntityType("participant"), id.ID(id.IDInvalid),
+			gomock.Any(), gomock.Any()).
+		Return(0, nil, glad.ErrInvalidValue)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodGet,
+		ts.URL+"/v1/audit?entity=course&id="+courseID.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "1", res.Header.Get(common.HttpHeaderTotalCount))
+
+	var d []*presenter.AuditLog
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, courseID, d[0].EntityID)
+	assert.Equal(t, entity.AuditSourceSalesforce, d[0].Source)
+	assert.Equal(t, "Name", d[0].Changes[0].Field)
+
+	// unknown entity type
+	req, _ = http.NewRequest(http.MethodGet, ts.URL+"/v1/audit?entity=participant", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// malformed id
+	req, _ = http.NewRequest(http.MethodGet, ts.URL+"/v1/audit?entity=course&id=abc", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func Test_listAuditLogs_Forbidden(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	teacher := &entity.Account{ID: id.New(), TenantID: tenantAlice, Type: entity.AccountTeacher}
+	n := newTestNegroni(teacher)
+	MakeAuditHandlers(r, *n, testAuthorizer(), service)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/audit?entity=course", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+}
+
+func Test_auditActor(t *testing.T) {
+	accountID := id.New()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(common.HttpHeaderAccountID, accountID.String())
+
+	actor := auditActor(r, entity.AuditSourceSalesforce)
+	assert.Equal(t, accountID, actor.AccountID)
+	assert.Equal(t, entity.AuditSourceSalesforce, actor.Source)
+
+	// authenticated account takes precedence over the header
+	r = r.WithContext(middleware.WithAccount(r.Context(), coordinatorCaller))
+	actor = auditActor(r, entity.AuditSourceAPI)
+	assert.Equal(t, coordinatorCaller.ID, actor.AccountID)
+	assert.Equal(t, entity.AuditSourceAPI, actor.Source)
+}
+
+func Test_listAuditChanges(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeAuditHandlers(r, *n, testAuthorizer(), service)
+	path, err := r.GetRoute("listAuditChanges").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/audit/changes", path)
+
+	createdAt := time.Date(2024, 10, 1, 10, 30, 0, 123000000, time.UTC)
+	courseID := id.New()
+	log := &entity.AuditLog{
+		ID:         id.New(),
+		TenantID:   tenantAlice,
+		EntityType: entity.AuditCourse,
+		EntityID:   courseID,
+		Operation:  entity.AuditCreate,
+		Source:     entity.AuditSourceAPI,
+		After:      json.RawMessage(`{"Name":"Happiness Program"}`),
+		CreatedAt:  createdAt,
+		Seq:        43,
+	}
+	service.EXPECT().ListChanges(tenantAlice, int64(42), 10).Return([]*entity.AuditLog{log}, nil)
+	service.EXPECT().ListChanges(tenantAlice, int64(0), 0).Return(nil, glad.ErrNotFound)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	get := func(query string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/audit/changes"+query, nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	res := get("?after=42&limit=10")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var d []*glad.Change
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, uint64(courseID), d[0].EntityID)
+	assert.Equal(t, string(entity.AuditCourse), d[0].Entity)
+	assert.JSONEq(t, string(log.After), string(d[0].After))
+	assert.True(t, log.CreatedAt.Equal(d[0].CreatedAt))
+	assert.Equal(t, int64(43), d[0].Seq)
+
+	assert.Equal(t, http.StatusNotFound, get("").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, get("?after=yesterday").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, get("?limit=-1").StatusCode)
+}
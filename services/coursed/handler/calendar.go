@@ -0,0 +1,242 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/ical"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
+	"ac9/glad/services/coursed/presenter"
+	"ac9/glad/usecase/account"
+	"ac9/glad/usecase/course"
+
+	"github.com/gorilla/mux"
+	"github.com/urfave/negroni"
+)
+
+// centerCalendarDays days of past courses in the center calendar
+const centerCalendarDays = 90
+
+// calendarPath path of the account calendar feed
+func calendarPath(token string) string {
+	return "/v1/calendars/" + token + ".ics"
+}
+
+// writeCalendar writes the calendar feed
+func writeCalendar(w http.ResponseWriter, c *ical.Calendar, filename string) {
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s.ics"`, filename))
+	if err := c.Encode(w); err != nil {
+		l.Log.Warnf("Unable to encode calendar err=%v", err)
+	}
+}
+
+func getCourseCalendar(service course.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading course"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		courseID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		courseFull, err := service.GetCourse(tenantID, courseID)
+		if err != nil && err != glad.ErrNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+		if courseFull == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Course doesn't exist"))
+			return
+		}
+
+		writeCalendar(w, presenter.CourseCalendar(courseFull.Course.Name, []*entity.CourseFull{courseFull}),
+			courseID.String())
+	})
+}
+
+func getCenterCalendar(service course.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading center courses"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		centerID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		f := &entity.CourseFilter{
+			CenterID: centerID,
+			DateFrom: time.Now().AddDate(0, 0, -centerCalendarDays).Format(entity.CourseFilterDateLayout),
+			SortBy:   entity.CourseSortStartDate,
+		}
+		_, courses, err := service.SearchCourses(tenantID, f, 0, 0)
+		if err != nil && err != glad.ErrNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		writeCalendar(w, presenter.CourseCalendar("Center courses", courses), centerID.String())
+	})
+}
+
+// getAccountCalendar serves the courses of the account subscribed with the
+// token in the path; the same courses as getCourseByAccount
+func getAccountCalendar(service course.UseCase, accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading courses"
+		a, err := accountService.GetAccountByCalendarToken(mux.Vars(r)["token"])
+		if err == glad.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Calendar doesn't exist"))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		_, courses, err := service.GetCourseByAccount(a.TenantID, a.ID, 0, 0)
+		if err != nil && err != glad.ErrNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		writeCalendar(w, presenter.CourseCalendar("My courses", courses), "courses")
+	})
+}
+
+func resetCalendarToken(accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error creating calendar subscription"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		accountID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		token, err := accountService.ResetCalendarToken(auditActor(r, entity.AuditSourceAPI), tenantID, accountID)
+		switch err {
+		case nil:
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Account doesn't exist"))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(&presenter.CalendarToken{
+			Token: token,
+			Path:  calendarPath(token),
+		}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode calendar subscription"))
+		}
+	})
+}
+
+func revokeCalendarToken(accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error removing calendar subscription"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		accountID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		err = accountService.RevokeCalendarToken(auditActor(r, entity.AuditSourceAPI), tenantID, accountID)
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusNoContent)
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Account doesn't exist"))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// MakeCalendarHandlers make url handlers of the calendar feeds. These must be
+// registered before the course and center handlers, {id}.ics would otherwise
+// be taken for an id.
+func MakeCalendarHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service course.UseCase,
+	accountService account.UseCase,
+) {
+	r.Handle("/v1/courses/{id:[0-9]+}.ics", n.With(
+		authz.Require(policy.CourseRead),
+		negroni.Wrap(getCourseCalendar(service)),
+	)).Methods(http.MethodGet, http.MethodOptions).Name("getCourseCalendar")
+
+	r.Handle("/v1/centers/{id:[0-9]+}.ics", n.With(
+		authz.Require(policy.CourseRead),
+		negroni.Wrap(getCenterCalendar(service)),
+	)).Methods(http.MethodGet, http.MethodOptions).Name("getCenterCalendar")
+
+	r.Handle("/v1/accounts/{id}/calendar-token", n.With(
+		authz.Require(policy.AccountWrite),
+		negroni.Wrap(resetCalendarToken(accountService)),
+	)).Methods(http.MethodPut, http.MethodOptions).Name("resetCalendarToken")
+
+	r.Handle("/v1/accounts/{id}/calendar-token", n.With(
+		authz.Require(policy.AccountWrite),
+		negroni.Wrap(revokeCalendarToken(accountService)),
+	)).Methods(http.MethodDelete, http.MethodOptions).Name("revokeCalendarToken")
+
+	// Calendar apps poll the feed without a bearer token; the token in the
+	// path authenticates the account. Served outside the middleware chain so
+	// the token is not logged.
+	r.Handle("/v1/calendars/{token}.ics",
+		getAccountCalendar(service, accountService),
+	).Methods(http.MethodGet).Name("getAccountCalendar")
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/ical"
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/coursed/presenter"
+
+	amock "ac9/glad/usecase/account/mock"
+	mock "ac9/glad/usecase/course/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCalendarServer serves the calendar handlers for the coordinator
+func newCalendarServer(controller *gomock.Controller,
+) (*httptest.Server, *mux.Router, *mock.MockUseCase, *amock.MockUseCase) {
+	svc := mock.NewMockUseCase(controller)
+	asvc := amock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCalendarHandlers(r, *n, testAuthorizer(), svc, asvc)
+	return httptest.NewServer(r), r, svc, asvc
+}
+
+// newCalendarCourse course with a timing in New York
+func newCalendarCourse() *entity.CourseFull {
+	return &entity.CourseFull{
+		Course: &entity.Course{
+			ID:       id.New(),
+			TenantID: tenantAlice,
+			Name:     "Happiness Program",
+			Timezone: "EST",
+			Mode:     entity.CourseInPerson,
+			Status:   entity.CourseOpen,
+			Address: entity.CourseAddress{
+				Street1: "1 Main St",
+				City:    "Boston",
+				State:   "MA",
+				Zip:     "02110",
+				Country: "US",
+			},
+			URL: "https://example.org/courses/1",
+		},
+		CourseTiming: []*entity.CourseTiming{{
+			ID: 1001,
+			DateTime: entity.CourseDateTime{
+				Date:      "2024-06-01",
+				StartTime: "13:00",
+				EndTime:   "15:00",
+			},
+		}},
+	}
+}
+
+func Test_getCourseCalendar(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	ts, r, svc, _ := newCalendarServer(controller)
+	defer ts.Close()
+	path, err := r.GetRoute("getCourseCalendar").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/{id:[0-9]+}.ics", path)
+
+	cf := newCalendarCourse()
+	svc.EXPECT().GetCourse(tenantAlice, cf.Course.ID).Return(cf, nil)
+	svc.EXPECT().GetCourse(tenantAlice, id.ID(1)).Return(nil, glad.ErrNotFound)
+
+	get := func(courseID id.ID) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/courses/"+courseID.String()+".ics", nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	res := get(cf.Course.ID)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, ical.ContentType, res.Header.Get("Content-Type"))
+	body, _ := io.ReadAll(res.Body)
+	assert.Contains(t, string(body), "UID:1001@glad\r\n")
+	assert.Contains(t, string(body), "TZID:America/New_York\r\n")
+	assert.Contains(t, string(body), "DTSTART;TZID=America/New_York:20240601T130000\r\n")
+	assert.Contains(t, string(body), "LOCATION:1 Main St\\, Boston\\, MA 02110\\, US\r\n")
+	assert.Contains(t, string(body), "URL:https://example.org/courses/1\r\n")
+
+	assert.Equal(t, http.StatusNotFound, get(1).StatusCode)
+}
+
+func Test_getAccountCalendar(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	ts, r, svc, asvc := newCalendarServer(controller)
+	defer ts.Close()
+	path, err := r.GetRoute("getAccountCalendar").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/calendars/{token}.ics", path)
+
+	accountID := id.New()
+	asvc.EXPECT().GetAccountByCalendarToken("secret").
+		Return(&entity.Account{ID: accountID, TenantID: tenantAlice}, nil)
+	asvc.EXPECT().GetAccountByCalendarToken("stale").Return(nil, glad.ErrNotFound)
+	svc.EXPECT().GetCourseByAccount(tenantAlice, accountID, 0, 0).
+		Return(1, []*entity.CourseFull{newCalendarCourse()}, nil)
+
+	// no tenant or bearer token; the token identifies the account
+	res, err := http.Get(ts.URL + "/v1/calendars/secret.ics")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	body, _ := io.ReadAll(res.Body)
+	assert.Contains(t, string(body), "SUMMARY:Happiness Program\r\n")
+
+	res, err = http.Get(ts.URL + "/v1/calendars/stale.ics")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func Test_resetCalendarToken(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	ts, r, _, asvc := newCalendarServer(controller)
+	defer ts.Close()
+	path, err := r.GetRoute("resetCalendarToken").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/accounts/{id}/calendar-token", path)
+
+	accountID := id.New()
+	asvc.EXPECT().ResetCalendarToken(gomock.Any(), tenantAlice, accountID).Return("secret", nil)
+	asvc.EXPECT().RevokeCalendarToken(gomock.Any(), tenantAlice, accountID).Return(nil)
+
+	send := func(method string) *http.Response {
+		req, _ := http.NewRequest(method, ts.URL+"/v1/accounts/"+accountID.String()+"/calendar-token", nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	res := send(http.MethodPut)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var d presenter.CalendarToken
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, "secret", d.Token)
+	assert.Equal(t, "/v1/calendars/secret.ics", d.Path)
+
+	assert.Equal(t, http.StatusNoContent, send(http.MethodDelete).StatusCode)
+}
@@ -16,6 +16,8 @@ import (
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
 	"ac9/glad/usecase/center"
 
 	"ac9/glad/services/coursed/presenter"
@@ -28,7 +30,6 @@ import (
 
 // TODO:
 // 	- JSON based search and formatting requires some work
-// 	- Support for location and geolocation
 
 func listCenters(service center.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -116,6 +117,7 @@ func createCenter(service center.UseCase) http.Handler {
 		}
 
 		centerID, err := service.CreateCenter(
+			auditActor(r, entity.AuditSourceAPI),
 			tenantID,
 			input.Name,
 			input.Mode,
@@ -140,9 +142,65 @@ func createCenter(service center.UseCase) http.Handler {
 	})
 }
 
+func getNearbyCenters(service center.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading nearby centers"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		q, err := geoQueryFromQuery(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		page, limit, err := common.HttpGetPageParams(w, r)
+		if err != nil {
+			return
+		}
+
+		centers, err := service.GetNearbyCenters(tenantID, q, page, limit)
+		w.Header().Set("Content-Type", "application/json")
+		if err == glad.ErrInvalidValue {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid location or radius"))
+			return
+		}
+		if err != nil && err != glad.ErrNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		if centers == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+		var responses []*presenter.CenterNearby
+		for _, center := range centers {
+			resp := &presenter.CenterNearby{}
+			resp.FromEntityCenterNearby(center)
+			responses = append(responses, resp)
+		}
+		if err := json.NewEncoder(w).Encode(responses); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode center"))
+		}
+	})
+}
+
 func getCenter(service center.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error reading center"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
 		vars := mux.Vars(r)
 		centerID, err := id.FromString(vars["id"])
 		if err != nil {
@@ -150,7 +208,7 @@ func getCenter(service center.UseCase) http.Handler {
 			_, _ = w.Write([]byte(err.Error()))
 			return
 		}
-		center, err := service.GetCenter(centerID)
+		center, err := service.GetCenter(tenantID, centerID)
 		if err != nil && err != glad.ErrNotFound {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -177,6 +235,11 @@ func getCenter(service center.UseCase) http.Handler {
 func deleteCenter(service center.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error removing center"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
 		vars := mux.Vars(r)
 		id, err := id.FromString(vars["id"])
 		if err != nil {
@@ -184,7 +247,7 @@ func deleteCenter(service center.UseCase) http.Handler {
 			_, _ = w.Write([]byte(errorMessage))
 			return
 		}
-		err = service.DeleteCenter(id)
+		err = service.DeleteCenter(auditActor(r, entity.AuditSourceAPI), tenantID, id)
 		switch err {
 		case nil:
 			w.WriteHeader(http.StatusOK)
@@ -232,7 +295,17 @@ func updateCenter(service center.UseCase) http.Handler {
 
 		input.ID = centerID
 		input.TenantID = tenantID
-		err = service.UpdateCenter(&input)
+		err = service.UpdateCenter(auditActor(r, entity.AuditSourceAPI), &input)
+		if err == glad.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Center doesn't exist"))
+			return
+		}
+		if err == glad.ErrInvalidEntity {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -244,7 +317,8 @@ func updateCenter(service center.UseCase) http.Handler {
 	})
 }
 
-func importCenter(service center.UseCase) http.Handler {
+// importCenter upserts the centers and replays the courses that were waiting for them
+func importCenter(service center.UseCase, importer *CourseImporter) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error importing centers"
 
@@ -265,24 +339,34 @@ func importCenter(service center.UseCase) http.Handler {
 			return
 		}
 
-		var response []*presenter.CenterImportResponse
-		for _, gCenter := range gCenters {
-			center := &entity.Center{}
-			presenter.GladCenterToEntity(gCenter, center)
-			center.TenantID = tenantID
+		centers := make([]*entity.Center, len(gCenters))
+		for i, gCenter := range gCenters {
+			centers[i] = &entity.Center{}
+			presenter.GladCenterToEntity(gCenter, centers[i])
+		}
 
-			// TODO: optimize DB operations by doing multiple inserts simultaneously
-			centerID, err := service.UpsertCenter(center)
-			if err != nil {
-				l.Log.Warnf("Unable to upsert center extID=%v, err=%v", center.ExtID, err)
-			}
+		actor := auditActor(r, entity.AuditSourceSalesforce)
+		centerIDs, err := service.UpsertCenters(actor, tenantID, centers)
+		if err != nil {
+			l.Log.Warnf("Unable to upsert centers, err=%v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
 
-			response = append(response, &presenter.CenterImportResponse{
-				ID:      centerID,
+		var imported []string
+		response := make([]*presenter.CenterImportResponse, len(centers))
+		for i, center := range centers {
+			response[i] = &presenter.CenterImportResponse{
+				ID:      centerIDs[i],
 				ExtID:   center.ExtID,
-				IsError: err != nil,
-			})
+				IsError: centerIDs[i] == id.IDInvalid,
+			}
+			if !response[i].IsError {
+				imported = append(imported, center.ExtID)
+			}
 		}
+		importer.ReplayDependents(actor, tenantID, entity.AuditCenter, imported)
 
 		w.Header().Set(common.HttpHeaderTenantID, tenant)
 		w.WriteHeader(http.StatusOK)
@@ -296,28 +380,54 @@ func importCenter(service center.UseCase) http.Handler {
 }
 
 // MakeCenterHandlers make url handlers
-func MakeCenterHandlers(r *mux.Router, n negroni.Negroni, service center.UseCase) {
+func MakeCenterHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service center.UseCase,
+	importer *CourseImporter,
+) {
 	r.Handle("/v1/centers", n.With(
+		authz.Require(policy.CenterRead),
 		negroni.Wrap(listCenters(service)),
 	)).Methods("GET", "OPTIONS").Name("listCenters")
 
 	r.Handle("/v1/centers", n.With(
+		authz.Require(policy.CenterWrite),
 		negroni.Wrap(createCenter(service)),
 	)).Methods("POST", "OPTIONS").Name("createCenter")
 
 	r.Handle("/v1/centers/import", n.With(
-		negroni.Wrap(importCenter(service)),
+		authz.Require(policy.CenterImport),
+		negroni.Wrap(importCenter(service, importer)),
 	)).Methods("POST", "OPTIONS").Name("importCenter")
 
+	r.Handle("/v1/centers/import/{extID}", n.With(
+		authz.Require(policy.CenterImport),
+		negroni.Wrap(syncByExtID(service.ArchiveCenterByExtID, "Error archiving center")),
+	)).Methods("DELETE", "OPTIONS").Name("archiveCenterByExtID")
+
+	r.Handle("/v1/centers/import/{extID}/restore", n.With(
+		authz.Require(policy.CenterImport),
+		negroni.Wrap(syncByExtID(service.RestoreCenterByExtID, "Error restoring center")),
+	)).Methods("POST", "OPTIONS").Name("restoreCenterByExtID")
+
+	r.Handle("/v1/centers/nearby", n.With(
+		authz.Require(policy.CenterRead),
+		negroni.Wrap(getNearbyCenters(service)),
+	)).Methods("GET", "OPTIONS").Name("getNearbyCenters")
+
 	r.Handle("/v1/centers/{id}", n.With(
+		authz.Require(policy.CenterRead),
 		negroni.Wrap(getCenter(service)),
 	)).Methods("GET", "OPTIONS").Name("getCenter")
 
 	r.Handle("/v1/centers/{id}", n.With(
+		authz.Require(policy.CenterWrite),
 		negroni.Wrap(deleteCenter(service)),
 	)).Methods("DELETE", "OPTIONS").Name("deleteCenter")
 
 	r.Handle("/v1/centers/{id}", n.With(
+		authz.Require(policy.CenterWrite),
 		negroni.Wrap(updateCenter(service)),
 	)).Methods("PUT", "OPTIONS").Name("updateCenter")
 }
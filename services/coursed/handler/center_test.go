@@ -24,7 +24,6 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
-	"github.com/urfave/negroni"
 )
 
 const (
@@ -38,8 +37,8 @@ func Test_listCenters(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeCenterHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeCenterHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("listCenters").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/centers", path)
@@ -117,8 +116,8 @@ func Test_createCenter(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeCenterHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeCenterHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("createCenter").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/centers", path)
@@ -126,6 +125,7 @@ func Test_createCenter(t *testing.T) {
 	centerID := id.New()
 	service.EXPECT().
 		CreateCenter(gomock.Any(),
+			gomock.Any(),
 			gomock.Any(),
 			gomock.Any(),
 			gomock.Any()).
@@ -172,8 +172,8 @@ func Test_getCenter(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeCenterHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeCenterHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("getCenter").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/centers/{id}", path)
@@ -185,13 +185,15 @@ func Test_getCenter(t *testing.T) {
 		Mode:     entity.CenterInPerson,
 	}
 	service.EXPECT().
-		GetCenter(center.ID).
+		GetCenter(tenantAlice, center.ID).
 		Return(center, nil)
 	handler := getCenter(service)
 	r.Handle("/v1/centers/{id}", handler)
 	ts := httptest.NewServer(r)
 	defer ts.Close()
-	res, err := http.Get(ts.URL + "/v1/centers/" + center.ID.String())
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/centers/"+center.ID.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 
@@ -206,21 +208,67 @@ func Test_getCenter(t *testing.T) {
 	assert.Equal(t, tenantAlice.String(), res.Header.Get(common.HttpHeaderTenantID))
 }
 
+func Test_getNearbyCenters(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCenterHandlers(r, *n, testAuthorizer(), service, nil)
+	path, err := r.GetRoute("getNearbyCenters").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/centers/nearby", path)
+	center := &entity.Center{
+		ID:       id.New(),
+		TenantID: tenantAlice,
+		Name:     "default-0",
+		Mode:     entity.CenterInPerson,
+	}
+	service.EXPECT().
+		GetNearbyCenters(tenantAlice,
+			&entity.GeoQuery{Lat: 37.33, Long: -121.88, RadiusKm: 10},
+			gomock.Any(), gomock.Any()).
+		Return([]*entity.CenterNearby{{Center: center, DistanceKm: 1.5}}, nil)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodGet,
+		ts.URL+"/v1/centers/nearby?lat=37.33&long=-121.88&radius=10", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d []*presenter.CenterNearby
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, center.ID, d[0].ID)
+	assert.Equal(t, 1.5, d[0].DistanceKm)
+
+	// lat is required
+	req, _ = http.NewRequest(http.MethodGet, ts.URL+"/v1/centers/nearby?long=-121.88", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
 func Test_deleteCenter(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeCenterHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeCenterHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("deleteCenter").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/centers/{id}", path)
 
 	id := id.New()
-	service.EXPECT().DeleteCenter(id).Return(nil)
+	service.EXPECT().DeleteCenter(gomock.Any(), tenantAlice, id).Return(nil)
 	handler := deleteCenter(service)
 	req, _ := http.NewRequest("DELETE", "/v1/centers/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/centers/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -232,16 +280,17 @@ func Test_deleteCenterNonExistent(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeCenterHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeCenterHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("deleteCenter").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/centers/{id}", path)
 
 	id := id.New()
-	service.EXPECT().DeleteCenter(id).Return(glad.ErrNotFound)
+	service.EXPECT().DeleteCenter(gomock.Any(), tenantAlice, id).Return(glad.ErrNotFound)
 	handler := deleteCenter(service)
 	req, _ := http.NewRequest("DELETE", "/v1/centers/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/centers/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -8,18 +8,22 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"ac9/glad/pkg/common"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
 	"ac9/glad/usecase/account"
-	"ac9/glad/usecase/center"
 	"ac9/glad/usecase/course"
-	"ac9/glad/usecase/product"
 
 	"ac9/glad/services/coursed/presenter"
 
@@ -29,11 +33,82 @@ import (
 	"github.com/urfave/negroni"
 )
 
+// Course search query parameters
+const (
+	courseParamStatus    = "status"
+	courseParamMode      = "mode"
+	courseParamProductID = "productId"
+	courseParamCenterID  = "centerId"
+	courseParamTeacherID = "teacherId"
+	courseParamDateFrom  = "from"
+	courseParamDateTo    = "to"
+	courseParamCity      = "city"
+	courseParamState     = "state"
+	courseParamSort      = "sort"
+	courseParamOrder     = "order"
+	courseParamFull      = "full"
+	courseParamCourseID  = "courseId"
+	courseParamTimezone  = "tz"
+)
+
+// timezoneFromQuery gets the zone to render the session instants in; nil to
+// keep the course timezone
+func timezoneFromQuery(r *http.Request) (*time.Location, error) {
+	timezone := r.URL.Query().Get(courseParamTimezone)
+	if timezone == "" {
+		return nil, nil
+	}
+	name, err := entity.NormalizeTimezone(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v", courseParamTimezone)
+	}
+	return time.LoadLocation(name)
+}
+
+// courseFilterFromQuery builds the course filter from the url query
+func courseFilterFromQuery(r *http.Request) (*entity.CourseFilter, error) {
+	q := r.URL.Query()
+	f := &entity.CourseFilter{
+		Query:    q.Get(common.HttpParamQuery),
+		Status:   entity.CourseStatus(q.Get(courseParamStatus)),
+		Mode:     entity.CourseMode(q.Get(courseParamMode)),
+		DateFrom: q.Get(courseParamDateFrom),
+		DateTo:   q.Get(courseParamDateTo),
+		City:     q.Get(courseParamCity),
+		State:    q.Get(courseParamState),
+		SortBy:   entity.CourseSortField(q.Get(courseParamSort)),
+	}
+
+	for param, dst := range map[string]*id.ID{
+		courseParamProductID: &f.ProductID,
+		courseParamCenterID:  &f.CenterID,
+		courseParamTeacherID: &f.TeacherID,
+	} {
+		if v := q.Get(param); v != "" {
+			parsed, err := id.FromString(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %v: %w", param, err)
+			}
+			*dst = parsed
+		}
+	}
+
+	switch q.Get(courseParamOrder) {
+	case "":
+	case "asc":
+		f.SortDesc = false
+	case "desc":
+		f.SortDesc = true
+	default:
+		return nil, fmt.Errorf("invalid %v", courseParamOrder)
+	}
+
+	return f, nil
+}
+
 func listCourses(service course.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error reading courses"
-		var data []*entity.Course
-		var err error
 		tenant := r.Header.Get(common.HttpHeaderTenantID)
 		tenantID, err := id.FromString(tenant)
 		if err != nil {
@@ -42,29 +117,38 @@ func listCourses(service course.UseCase) http.Handler {
 			return
 		}
 
-		search := r.URL.Query().Get(common.HttpParamQuery)
-		page, limit, err := common.HttpGetPageParams(w, r)
+		filter, err := courseFilterFromQuery(r)
 		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		full, _ := strconv.ParseBool(r.URL.Query().Get(courseParamFull))
+		loc, err := timezoneFromQuery(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
 			return
 		}
 
-		switch {
-		case search == "":
-			data, err = service.ListCourses(tenantID, page, limit)
-		default:
-			// TODO: search need to be reworked; need to add a count
-			// for search; also need to see how the caller generates
-			// the search query request
-			data, err = service.SearchCourses(tenantID, search, page, limit)
+		page, limit, err := common.HttpGetPageParams(w, r)
+		if err != nil {
+			return
 		}
+
+		total, data, err := service.SearchCourses(tenantID, filter, page, limit)
 		w.Header().Set("Content-Type", "application/json")
+		if err == glad.ErrInvalidValue {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid search filter"))
+			return
+		}
 		if err != nil && err != glad.ErrNotFound {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
 			return
 		}
 
-		total := service.GetCount(tenantID)
 		w.Header().Set(common.HttpHeaderTotalCount, strconv.Itoa(total))
 
 		if data == nil {
@@ -75,21 +159,161 @@ func listCourses(service course.UseCase) http.Handler {
 		var courses []*presenter.Course
 		for _, d := range data {
 			pc := &presenter.Course{}
-			pc.FromEntityCourse(d)
+			if full {
+				pc.FromEntityCourseFull(d)
+				pc.RenderTimings(loc)
+			} else {
+				pc.FromEntityCourse(d.Course)
+			}
+			courses = append(courses, pc)
+		}
+		if err := json.NewEncoder(w).Encode(courses); err != nil {
+			w.Header().Set(common.HttpHeaderTenantID, tenant)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode course"))
+		}
+	})
+}
 
-			pc.Address = &presenter.Address{}
-			pc.Address.CopyFrom(d.Address)
+func getNearbyCourses(service course.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading nearby courses"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		q, err := geoQueryFromQuery(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		loc, err := timezoneFromQuery(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		page, limit, err := common.HttpGetPageParams(w, r)
+		if err != nil {
+			return
+		}
+
+		data, err := service.GetNearbyCourses(tenantID, q, page, limit)
+		w.Header().Set("Content-Type", "application/json")
+		if err == glad.ErrInvalidValue {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid location or radius"))
+			return
+		}
+		if err != nil && err != glad.ErrNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
 
+		if data == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+		var courses []*presenter.CourseNearby
+		for _, d := range data {
+			pc := &presenter.CourseNearby{}
+			pc.FromEntityCourseNearby(d)
+			pc.RenderTimings(loc)
 			courses = append(courses, pc)
 		}
 		if err := json.NewEncoder(w).Encode(courses); err != nil {
-			w.Header().Set(common.HttpHeaderTenantID, tenant)
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte("Unable to encode course"))
 		}
 	})
 }
 
+// courseConflictQueryFromQuery builds the conflict query from the query
+// parameters; teacherId is matched against teachers and organizers alike
+func courseConflictQueryFromQuery(r *http.Request) (*entity.CourseConflictQuery, error) {
+	q := r.URL.Query()
+	cq := &entity.CourseConflictQuery{
+		From: q.Get(courseParamDateFrom),
+		To:   q.Get(courseParamDateTo),
+	}
+
+	for param, target := range map[string]*id.ID{
+		courseParamCourseID:  &cq.CourseID,
+		courseParamTeacherID: &cq.AccountID,
+		courseParamCenterID:  &cq.CenterID,
+	} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+		parsed, err := id.FromString(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v", param)
+		}
+		*target = parsed
+	}
+	return cq, nil
+}
+
+// writeCourseConflicts writes the conflicts of a rejected course; returns
+// false when err is not a conflict error
+func writeCourseConflicts(w http.ResponseWriter, err error) bool {
+	var ce *entity.ConflictError
+	if !errors.As(err, &ce) {
+		return false
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	_ = json.NewEncoder(w).Encode(presenter.FromEntityCourseConflicts(ce.Conflicts))
+	return true
+}
+
+func listCourseConflicts(service course.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading course conflicts"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		q, err := courseConflictQueryFromQuery(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		data, err := service.ListConflicts(tenantID, q)
+		switch err {
+		case nil:
+		case glad.ErrMissingParam, glad.ErrInvalidValue:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Course doesn't exist"))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(presenter.FromEntityCourseConflicts(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode course conflicts"))
+		}
+	})
+}
+
 func createCourse(service course.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error adding course"
@@ -133,6 +357,7 @@ func createCourse(service course.UseCase) http.Handler {
 		}
 
 		courseID, courseTimingsID, err := service.CreateCourse(
+			auditActor(r, entity.AuditSourceAPI),
 			course,
 			cos,
 			cts,
@@ -140,6 +365,14 @@ func createCourse(service course.UseCase) http.Handler {
 			cns,
 			courseTimings,
 		)
+		if writeCourseConflicts(w, err) {
+			return
+		}
+		if err == glad.ErrNotEligible || err == glad.ErrInvalidEntity {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -164,6 +397,11 @@ func createCourse(service course.UseCase) http.Handler {
 func getCourse(service course.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error reading course"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
 		vars := mux.Vars(r)
 		id, err := id.FromString(vars["id"])
 		if err != nil {
@@ -171,7 +409,14 @@ func getCourse(service course.UseCase) http.Handler {
 			_, _ = w.Write([]byte(err.Error()))
 			return
 		}
-		courseFull, err := service.GetCourse(id)
+		loc, err := timezoneFromQuery(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		courseFull, err := service.GetCourse(tenantID, id)
 		if err != nil && err != glad.ErrNotFound {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -186,6 +431,7 @@ func getCourse(service course.UseCase) http.Handler {
 
 		response := &presenter.Course{}
 		response.FromEntityCourseFull(courseFull)
+		response.RenderTimings(loc)
 
 		w.Header().Set(common.HttpHeaderTenantID, courseFull.Course.TenantID.String())
 		if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -195,6 +441,112 @@ func getCourse(service course.UseCase) http.Handler {
 	})
 }
 
+// courseTransitionAction policy action of the lifecycle action in the path
+func courseTransitionAction(r *http.Request) policy.Action {
+	return policy.CourseTransition(entity.CourseAction(mux.Vars(r)["action"]))
+}
+
+// courseActionsPattern matches the known lifecycle actions in the path
+func courseActionsPattern() string {
+	var actions []string
+	for _, action := range entity.CourseActions() {
+		actions = append(actions, string(action))
+	}
+	return strings.Join(actions, "|")
+}
+
+func transitionCourse(service course.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error changing course status"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		vars := mux.Vars(r)
+		courseID, err := id.FromString(vars["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		change, err := service.TransitionCourse(auditActor(r, entity.AuditSourceAPI),
+			tenantID, courseID, entity.CourseAction(vars["action"]))
+		switch err {
+		case nil:
+		case glad.ErrInvalidValue:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Course doesn't exist"))
+			return
+		case glad.ErrInvalidTransition:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		default:
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		response := &presenter.CourseTransition{}
+		response.FromEntityCourseStatusChange(change)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode course transition"))
+		}
+	})
+}
+
+func listCourseTransitions(service course.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading course transitions"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		courseID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		changes, err := service.ListCourseTransitions(tenantID, courseID)
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil && err != glad.ErrNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		if changes == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+		var responses []*presenter.CourseTransition
+		for _, change := range changes {
+			resp := &presenter.CourseTransition{}
+			resp.FromEntityCourseStatusChange(change)
+			responses = append(responses, resp)
+		}
+		if err := json.NewEncoder(w).Encode(responses); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode course transition"))
+		}
+	})
+}
+
 func getCourseByAccount(service course.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -265,6 +617,13 @@ func getCourseByAccountImpl(w http.ResponseWriter,
 		return
 	}
 
+	loc, err := timezoneFromQuery(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
 	count, cfList, err := service.GetCourseByAccount(tenantID, accountID, page, limit)
 	if err != nil && err != glad.ErrNotFound {
 		l.Log.Warnf("%v", err)
@@ -284,6 +643,7 @@ func getCourseByAccountImpl(w http.ResponseWriter,
 	for _, courseFull := range cfList {
 		c := &presenter.Course{}
 		c.FromEntityCourseFull(courseFull)
+		c.RenderTimings(loc)
 		courseList = append(courseList, c)
 	}
 
@@ -296,9 +656,67 @@ func getCourseByAccountImpl(w http.ResponseWriter,
 	}
 }
 
+// setCourseExtID links a course created in GLAD to the Salesforce record it
+// was exported to
+func setCourseExtID(service course.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error setting course external id"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		courseID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		var input glad.ExtID
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		err = service.SetCourseExtID(auditActor(r, entity.AuditSourceSalesforce),
+			tenantID, courseID, input.ExtID)
+		switch err {
+		case nil:
+		case glad.ErrInvalidValue:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Course doesn't exist"))
+			return
+		case glad.ErrAlreadyExists:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		default:
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
 func deleteCourse(service course.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error removing course"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
 		vars := mux.Vars(r)
 		id, err := id.FromString(vars["id"])
 		if err != nil {
@@ -306,7 +724,7 @@ func deleteCourse(service course.UseCase) http.Handler {
 			_, _ = w.Write([]byte(errorMessage))
 			return
 		}
-		err = service.DeleteCourse(id)
+		err = service.DeleteCourse(auditActor(r, entity.AuditSourceAPI), tenantID, id)
 		switch err {
 		case nil:
 			w.WriteHeader(http.StatusOK)
@@ -381,6 +799,7 @@ func updateCourse(service course.UseCase) http.Handler {
 		}
 
 		err = service.UpdateCourse(
+			auditActor(r, entity.AuditSourceAPI),
 			course,
 			cos,
 			cts,
@@ -388,6 +807,19 @@ func updateCourse(service course.UseCase) http.Handler {
 			cns,
 			courseTimings,
 		)
+		if err == glad.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Course doesn't exist"))
+			return
+		}
+		if writeCourseConflicts(w, err) {
+			return
+		}
+		if err == glad.ErrNotEligible || err == glad.ErrInvalidEntity {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -411,11 +843,7 @@ func updateCourse(service course.UseCase) http.Handler {
 	})
 }
 
-func importCourse(service course.UseCase,
-	accountService account.UseCase,
-	productService product.UseCase,
-	centerService center.UseCase,
-) http.Handler {
+func importCourse(importer *CourseImporter) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error importing courses"
 
@@ -436,48 +864,11 @@ func importCourse(service course.UseCase,
 			return
 		}
 
-		var response []*presenter.ImportCourseResponse
-		for _, gCourse := range gCourses {
-			course := &entity.Course{}
-			presenter.GladCourseToEntity(gCourse, course)
-			course.TenantID = tenantID
-
-			productID, err :=
-				productService.GetIDByExtID(tenantID, gCourse.ProductExtID)
-			if err != nil {
-				l.Log.Warnf("Unable to get product id extID=%v, err=%v", gCourse.ProductExtID, err)
-				response = append(response, &presenter.ImportCourseResponse{
-					ExtID:   gCourse.ExtID,
-					IsError: err != nil,
-				})
-				continue
-			}
-
-			centerID, err :=
-				centerService.GetIDByExtID(tenantID, gCourse.CenterExtID)
-			if err != nil {
-				l.Log.Warnf("Unable to get center id extID=%v, err=%v", gCourse.CenterExtID, err)
-				response = append(response, &presenter.ImportCourseResponse{
-					ExtID:   gCourse.ExtID,
-					IsError: err != nil,
-				})
-				continue
-			}
-
-			course.ProductID = productID
-			course.CenterID = centerID
-
-			// TODO: optimize DB operations by doing multiple inserts simultaneously
-			courseID, err := service.UpsertCourse(course)
-			if err != nil {
-				l.Log.Warnf("Unable to upsert course extID=%v, err=%v", course.ExtID, err)
-			}
-
-			response = append(response, &presenter.ImportCourseResponse{
-				ID:      courseID,
-				ExtID:   *course.ExtID,
-				IsError: err != nil,
-			})
+		response, err := importer.Import(auditActor(r, entity.AuditSourceSalesforce), tenantID, gCourses)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
 		}
 
 		w.Header().Set(common.HttpHeaderTenantID, tenant)
@@ -494,41 +885,106 @@ func importCourse(service course.UseCase,
 // MakeCourseHandlers make url handlers
 func MakeCourseHandlers(r *mux.Router,
 	n negroni.Negroni,
+	authz *middleware.Authorizer,
 	service course.UseCase,
 	accountService account.UseCase,
-	productService product.UseCase,
-	centerService center.UseCase,
+	importer *CourseImporter,
 ) {
 	r.Handle("/v1/courses", n.With(
+		authz.Require(policy.CourseRead),
 		negroni.Wrap(listCourses(service)),
 	)).Methods("GET", "OPTIONS").Name("listCourses")
 
 	r.Handle("/v1/courses", n.With(
+		authz.Require(policy.CourseCreate),
 		negroni.Wrap(createCourse(service)),
 	)).Methods("POST", "OPTIONS").Name("createCourse")
 
 	r.Handle("/v1/courses/import", n.With(
-		negroni.Wrap(importCourse(service, accountService, productService, centerService)),
+		authz.Require(policy.CourseImport),
+		negroni.Wrap(importCourse(importer)),
 	)).Methods("POST", "OPTIONS").Name("importCourse")
 
 	// get courses by account-id
+	r.Handle("/v1/courses/import/{extID}", n.With(
+		authz.Require(policy.CourseImport),
+		negroni.Wrap(syncByExtID(service.ArchiveCourseByExtID, "Error archiving course")),
+	)).Methods("DELETE", "OPTIONS").Name("archiveCourseByExtID")
+
+	r.Handle("/v1/courses/import/{extID}/restore", n.With(
+		authz.Require(policy.CourseImport),
+		negroni.Wrap(syncByExtID(service.RestoreCourseByExtID, "Error restoring course")),
+	)).Methods("POST", "OPTIONS").Name("restoreCourseByExtID")
+
+	r.Handle("/v1/courses/timings/import", n.With(
+		authz.Require(policy.CourseImport),
+		negroni.Wrap(importCourseTimings(service)),
+	)).Methods("POST", "OPTIONS").Name("importCourseTimings")
+
+	r.Handle("/v1/courses/timings/import/{extID}", n.With(
+		authz.Require(policy.CourseImport),
+		negroni.Wrap(syncByExtID(removeByExtID(service.RemoveCourseTimingByExtID),
+			"Error removing course timing")),
+	)).Methods("DELETE", "OPTIONS").Name("removeCourseTimingByExtID")
+
+	r.Handle("/v1/courses/rosters/import", n.With(
+		authz.Require(policy.CourseImport),
+		negroni.Wrap(importCourseRosters(service, accountService)),
+	)).Methods("POST", "OPTIONS").Name("importCourseRosters")
+
+	r.Handle("/v1/courses/rosters/import/{extID}", n.With(
+		authz.Require(policy.CourseImport),
+		negroni.Wrap(syncByExtID(removeByExtID(service.RemoveCourseRosterByExtID),
+			"Error removing course roster")),
+	)).Methods("DELETE", "OPTIONS").Name("removeCourseRosterByExtID")
+
 	r.Handle("/v1/courses/account/{accountID}", n.With(
+		authz.Require(policy.CourseRead),
 		negroni.Wrap(getCourseByAccount(service)),
 	)).Methods("GET", "OPTIONS").Name("getCourseByAccount")
 
 	r.Handle("/v1/courses/me", n.With(
+		authz.Require(policy.CourseRead),
 		negroni.Wrap(getCourseMe(service, accountService)),
 	)).Methods("GET", "OPTIONS").Name("getCourseMe")
 
+	r.Handle("/v1/courses/nearby", n.With(
+		authz.Require(policy.CourseRead),
+		negroni.Wrap(getNearbyCourses(service)),
+	)).Methods("GET", "OPTIONS").Name("getNearbyCourses")
+
+	r.Handle("/v1/courses/conflicts", n.With(
+		authz.Require(policy.CourseConflictRead),
+		negroni.Wrap(listCourseConflicts(service)),
+	)).Methods("GET", "OPTIONS").Name("listCourseConflicts")
+
+	r.Handle("/v1/courses/{id}/transitions", n.With(
+		authz.Require(policy.CourseRead),
+		negroni.Wrap(listCourseTransitions(service)),
+	)).Methods("GET", "OPTIONS").Name("listCourseTransitions")
+
+	r.Handle("/v1/courses/{id}/transitions/{action:"+courseActionsPattern()+"}", n.With(
+		authz.RequireFunc(courseTransitionAction),
+		negroni.Wrap(transitionCourse(service)),
+	)).Methods("POST", "OPTIONS").Name("transitionCourse")
+
+	r.Handle("/v1/courses/{id}/extid", n.With(
+		authz.Require(policy.CourseImport),
+		negroni.Wrap(setCourseExtID(service)),
+	)).Methods("PUT", "OPTIONS").Name("setCourseExtID")
+
 	r.Handle("/v1/courses/{id}", n.With(
+		authz.Require(policy.CourseRead),
 		negroni.Wrap(getCourse(service)),
 	)).Methods("GET", "OPTIONS").Name("getCourse")
 
 	r.Handle("/v1/courses/{id}", n.With(
+		authz.Require(policy.CourseDelete),
 		negroni.Wrap(deleteCourse(service)),
 	)).Methods("DELETE", "OPTIONS").Name("deleteCourse")
 
 	r.Handle("/v1/courses/{id}", n.With(
+		authz.Require(policy.CourseUpdate),
 		negroni.Wrap(updateCourse(service)),
 	)).Methods("PUT", "OPTIONS").Name("updateCourse")
 }
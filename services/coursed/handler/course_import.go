@@ -0,0 +1,201 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/coursed/presenter"
+	"ac9/glad/usecase/center"
+	"ac9/glad/usecase/course"
+	"ac9/glad/usecase/dead_letter"
+	"ac9/glad/usecase/product"
+)
+
+// CourseImporter imports the Salesforce courses. Courses that cannot be saved
+// are kept as dead letters; the ones waiting for a center or product are
+// replayed when it is imported.
+type CourseImporter struct {
+	service           course.UseCase
+	productService    product.UseCase
+	centerService     center.UseCase
+	deadLetterService dead_letter.UseCase
+}
+
+// NewCourseImporter creates a new course importer
+func NewCourseImporter(service course.UseCase,
+	productService product.UseCase,
+	centerService center.UseCase,
+	deadLetterService dead_letter.UseCase,
+) *CourseImporter {
+	return &CourseImporter{
+		service:           service,
+		productService:    productService,
+		centerService:     centerService,
+		deadLetterService: deadLetterService,
+	}
+}
+
+// Import upserts the courses; the response is in the order of gCourses
+func (c *CourseImporter) Import(actor entity.AuditActor,
+	tenantID id.ID,
+	gCourses []glad.Course,
+) ([]*presenter.ImportCourseResponse, error) {
+	productExtIDs := make([]string, 0, len(gCourses))
+	centerExtIDs := make([]string, 0, len(gCourses))
+	for _, gCourse := range gCourses {
+		productExtIDs = append(productExtIDs, gCourse.ProductExtID)
+		centerExtIDs = append(centerExtIDs, gCourse.CenterExtID)
+	}
+
+	productIDs, err := c.productService.GetIDsByExtIDs(tenantID, productExtIDs)
+	if err != nil {
+		l.Log.Warnf("Unable to get product ids, err=%v", err)
+		return nil, err
+	}
+
+	centerIDs, err := c.centerService.GetIDsByExtIDs(tenantID, centerExtIDs)
+	if err != nil {
+		l.Log.Warnf("Unable to get center ids, err=%v", err)
+		return nil, err
+	}
+
+	// courses with an unknown product or center are reported without
+	// being upserted
+	response := make([]*presenter.ImportCourseResponse, len(gCourses))
+	courses := make([]*entity.Course, 0, len(gCourses))
+	var indexes []int
+	for i, gCourse := range gCourses {
+		response[i] = &presenter.ImportCourseResponse{
+			ExtID:   gCourse.ExtID,
+			IsError: true,
+		}
+
+		productID, ok := productIDs[gCourse.ProductExtID]
+		if !ok {
+			l.Log.Warnf("Unable to get product id extID=%v", gCourse.ProductExtID)
+			c.addDeadLetter(tenantID, gCourse, entity.AuditProduct, gCourse.ProductExtID,
+				"Unknown product extID="+gCourse.ProductExtID)
+			continue
+		}
+		centerID, ok := centerIDs[gCourse.CenterExtID]
+		if !ok {
+			l.Log.Warnf("Unable to get center id extID=%v", gCourse.CenterExtID)
+			c.addDeadLetter(tenantID, gCourse, entity.AuditCenter, gCourse.CenterExtID,
+				"Unknown center extID="+gCourse.CenterExtID)
+			continue
+		}
+
+		course := &entity.Course{}
+		presenter.GladCourseToEntity(gCourse, course)
+		course.ProductID = productID
+		course.CenterID = centerID
+		courses = append(courses, course)
+		indexes = append(indexes, i)
+	}
+
+	courseIDs, err := c.service.UpsertCourses(actor, tenantID, courses)
+	if err != nil {
+		l.Log.Warnf("Unable to upsert courses, err=%v", err)
+		return nil, err
+	}
+
+	var imported []string
+	for k, i := range indexes {
+		response[i].ID = courseIDs[k]
+		response[i].IsError = courseIDs[k] == id.IDInvalid
+		if response[i].IsError {
+			c.addDeadLetter(tenantID, gCourses[i], "", "", "Unable to upsert course")
+			continue
+		}
+		imported = append(imported, gCourses[i].ExtID)
+	}
+
+	err = c.deadLetterService.ResolveDeadLetters(tenantID, entity.AuditCourse, imported)
+	if err != nil {
+		l.Log.Warnf("Unable to resolve dead letters, err=%v", err)
+	}
+	return response, nil
+}
+
+// Replay imports the courses of the dead letters again. Dead letters of
+// other entity types cannot be replayed.
+func (c *CourseImporter) Replay(actor entity.AuditActor,
+	tenantID id.ID,
+	letters []*entity.DeadLetter,
+) ([]*presenter.ImportCourseResponse, error) {
+	gCourses := make([]glad.Course, len(letters))
+	for i, e := range letters {
+		if e.EntityType != entity.AuditCourse {
+			return nil, glad.ErrInvalidValue
+		}
+		err := json.Unmarshal(e.Payload, &gCourses[i])
+		if err != nil {
+			l.Log.Warnf("Unable to decode dead letter id=%v, err=%v", e.ID, err)
+			return nil, glad.ErrInvalidValue
+		}
+	}
+	return c.Import(actor, tenantID, gCourses)
+}
+
+// ReplayDependents replays the courses waiting for the imported centers or
+// products. Replay is best effort; failures are logged and the courses stay
+// in the dead letters.
+func (c *CourseImporter) ReplayDependents(actor entity.AuditActor,
+	tenantID id.ID,
+	dependencyType entity.AuditEntityType,
+	extIDs []string,
+) {
+	letters, err := c.deadLetterService.ListDependentDeadLetters(tenantID, dependencyType, extIDs)
+	if err != nil {
+		l.Log.Warnf("Unable to list dead letters waiting for %v, err=%v", dependencyType, err)
+		return
+	}
+	if len(letters) == 0 {
+		return
+	}
+
+	l.Log.Infof("Replaying %v courses waiting for %v", len(letters), dependencyType)
+	_, err = c.Replay(actor, tenantID, letters)
+	if err != nil {
+		l.Log.Warnf("Unable to replay dead letters waiting for %v, err=%v", dependencyType, err)
+	}
+}
+
+// addDeadLetter keeps the course that could not be imported; a course waiting
+// for a missing entity has the dependency set
+func (c *CourseImporter) addDeadLetter(tenantID id.ID,
+	gCourse glad.Course,
+	dependencyType entity.AuditEntityType,
+	dependencyExtID string,
+	message string,
+) {
+	payload, err := json.Marshal(gCourse)
+	if err != nil {
+		l.Log.Warnf("Unable to encode course extID=%v, err=%v", gCourse.ExtID, err)
+		return
+	}
+
+	e, err := entity.NewDeadLetter(tenantID, entity.AuditCourse, gCourse.ExtID, payload,
+		entity.DeadLetterRejected, message)
+	if err != nil {
+		l.Log.Warnf("Unable to create dead letter extID=%v, err=%v", gCourse.ExtID, err)
+		return
+	}
+	if dependencyType != "" {
+		e.SetDependency(dependencyType, dependencyExtID)
+	}
+
+	err = c.deadLetterService.AddDeadLetter(e)
+	if err != nil {
+		l.Log.Warnf("Unable to add dead letter extID=%v, err=%v", gCourse.ExtID, err)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/coursed/presenter"
+	"ac9/glad/usecase/account"
+	"ac9/glad/usecase/course"
+)
+
+// importCourseTimings upserts the sessions of courses imported from Salesforce
+func importCourseTimings(service course.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error importing course timings"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		var gTimings []glad.CourseTiming
+		err = json.NewDecoder(r.Body).Decode(&gTimings)
+		if err != nil {
+			l.Log.Warnf("Unable to decode object. err = %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		var response []*presenter.ImportResponse
+		for _, gTiming := range gTimings {
+			c, err := service.GetCourseByExtID(tenantID, gTiming.CourseExtID)
+			if err != nil {
+				l.Log.Warnf("Unable to get course extID=%v, err=%v", gTiming.CourseExtID, err)
+				response = append(response, &presenter.ImportResponse{
+					ExtID:   gTiming.ExtID,
+					IsError: true,
+				})
+				continue
+			}
+
+			extID := gTiming.ExtID
+			timingID, err := service.UpsertCourseTiming(tenantID, &entity.CourseTiming{
+				CourseID: c.ID,
+				ExtID:    &extID,
+				DateTime: entity.CourseDateTime{
+					Date:      gTiming.Date,
+					StartTime: gTiming.StartTime,
+					EndTime:   gTiming.EndTime,
+				},
+				CreatedAt: gTiming.CreatedAt,
+				UpdatedAt: gTiming.UpdatedAt,
+			})
+			if err != nil {
+				l.Log.Warnf("Unable to upsert course timing extID=%v, err=%v", extID, err)
+			}
+
+			response = append(response, &presenter.ImportResponse{
+				ID:      timingID,
+				ExtID:   extID,
+				IsError: err != nil,
+			})
+		}
+
+		writeImportResponse(w, tenantID, response, errorMessage)
+	})
+}
+
+// importCourseRosters links the teachers and organizers imported from
+// Salesforce to their courses
+func importCourseRosters(service course.UseCase, accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error importing course rosters"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		var gRosters []glad.CourseRoster
+		err = json.NewDecoder(r.Body).Decode(&gRosters)
+		if err != nil {
+			l.Log.Warnf("Unable to decode object. err = %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		var response []*presenter.ImportResponse
+		for _, gRoster := range gRosters {
+			courseID, err := upsertCourseRoster(service, accountService, tenantID, &gRoster)
+			if err != nil {
+				l.Log.Warnf("Unable to upsert course roster extID=%v, err=%v", gRoster.ExtID, err)
+			}
+
+			response = append(response, &presenter.ImportResponse{
+				ID:      courseID,
+				ExtID:   gRoster.ExtID,
+				IsError: err != nil,
+			})
+		}
+
+		writeImportResponse(w, tenantID, response, errorMessage)
+	})
+}
+
+// upsertCourseRoster resolves the course and the account of the roster entry
+// and links them by role; returns the course id
+func upsertCourseRoster(service course.UseCase,
+	accountService account.UseCase,
+	tenantID id.ID,
+	gRoster *glad.CourseRoster,
+) (id.ID, error) {
+	c, err := service.GetCourseByExtID(tenantID, gRoster.CourseExtID)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	a, err := accountService.GetAccountByExtID(tenantID, gRoster.AccountExtID)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	switch gRoster.Role {
+	case glad.CourseRoleTeacher:
+		err = service.UpsertCourseTeacherByExtID(tenantID, c.ID, gRoster.ExtID,
+			&entity.CourseTeacher{ID: a.ID, IsPrimary: gRoster.IsPrimary})
+	case glad.CourseRoleOrganizer:
+		err = service.UpsertCourseOrganizerByExtID(tenantID, c.ID, gRoster.ExtID,
+			&entity.CourseOrganizer{ID: a.ID})
+	default:
+		err = glad.ErrInvalidValue
+	}
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	return c.ID, nil
+}
+
+// writeImportResponse writes the per record result of an import
+func writeImportResponse(w http.ResponseWriter,
+	tenantID id.ID,
+	response []*presenter.ImportResponse,
+	errorMessage string,
+) {
+	w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		l.Log.Errorf(err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(errorMessage))
+	}
+}
+
+// removeByExtID adapts a Salesforce removal, which is not audited, to syncByExtID
+func removeByExtID(remove func(tenantID id.ID, extID string) (id.ID, error)) byExtIDFunc {
+	return func(_ entity.AuditActor, tenantID id.ID, extID string) (id.ID, error) {
+		return remove(tenantID, extID)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/coursed/presenter"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_importCourseTimings(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+	path, err := r.GetRoute("importCourseTimings").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/timings/import", path)
+
+	courseID := id.New()
+	timingID := id.New()
+	svc.EXPECT().GetCourseByExtID(tenantAlice, "a0W000000000001").
+		Return(&entity.Course{ID: courseID, TenantID: tenantAlice}, nil)
+	svc.EXPECT().GetCourseByExtID(tenantAlice, "a0W000000000009").
+		Return(nil, glad.ErrNotFound)
+	svc.EXPECT().UpsertCourseTiming(tenantAlice, gomock.Any()).
+		DoAndReturn(func(_ id.ID, ct *entity.CourseTiming) (id.ID, error) {
+			assert.Equal(t, courseID, ct.CourseID)
+			assert.Equal(t, "a0S000000000001", *ct.ExtID)
+			assert.Equal(t, entity.CourseDateTime{
+				Date: "2024-11-02", StartTime: "09:00:00", EndTime: "11:30:00",
+			}, ct.DateTime)
+			return timingID, nil
+		})
+
+	payload, _ := json.Marshal([]glad.CourseTiming{
+		{
+			ExtID:       "a0S000000000001",
+			CourseExtID: "a0W000000000001",
+			Date:        "2024-11-02",
+			StartTime:   "09:00:00",
+			EndTime:     "11:30:00",
+		},
+		{ExtID: "a0S000000000002", CourseExtID: "a0W000000000009"},
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/courses/timings/import", bytes.NewReader(payload))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var d []*presenter.ImportResponse
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, 2, len(d))
+	assert.Equal(t, timingID, d[0].ID)
+	assert.False(t, d[0].IsError)
+	assert.True(t, d[1].IsError)
+}
+
+func Test_importCourseRosters(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+	path, err := r.GetRoute("importCourseRosters").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/rosters/import", path)
+
+	courseID := id.New()
+	svc.EXPECT().GetCourseByExtID(tenantAlice, "a0W000000000001").
+		Return(&entity.Course{ID: courseID, TenantID: tenantAlice}, nil).Times(3)
+	asvc.EXPECT().GetAccountByExtID(tenantAlice, "003000000000001").
+		Return(&entity.Account{ID: accountIDPrimary, TenantID: tenantAlice}, nil).Times(3)
+	svc.EXPECT().UpsertCourseTeacherByExtID(tenantAlice, courseID, "a0T000000000001",
+		&entity.CourseTeacher{ID: accountIDPrimary, IsPrimary: true}).Return(nil)
+	svc.EXPECT().UpsertCourseOrganizerByExtID(tenantAlice, courseID, "a0O000000000001",
+		&entity.CourseOrganizer{ID: accountIDPrimary}).Return(nil)
+
+	payload, _ := json.Marshal([]glad.CourseRoster{
+		{
+			ExtID:        "a0T000000000001",
+			CourseExtID:  "a0W000000000001",
+			AccountExtID: "003000000000001",
+			Role:         glad.CourseRoleTeacher,
+			IsPrimary:    true,
+		},
+		{
+			ExtID:        "a0O000000000001",
+			CourseExtID:  "a0W000000000001",
+			AccountExtID: "003000000000001",
+			Role:         glad.CourseRoleOrganizer,
+		},
+		{
+			ExtID:        "a0X000000000001",
+			CourseExtID:  "a0W000000000001",
+			AccountExtID: "003000000000001",
+			Role:         "assistant",
+		},
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/courses/rosters/import", bytes.NewReader(payload))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var d []*presenter.ImportResponse
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, 3, len(d))
+	assert.Equal(t, courseID, d[0].ID)
+	assert.False(t, d[0].IsError)
+	assert.False(t, d[1].IsError)
+	assert.True(t, d[2].IsError)
+}
+
+func Test_removeCourseRosterByExtID(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+	path, err := r.GetRoute("removeCourseRosterByExtID").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/rosters/import/{extID}", path)
+	path, err = r.GetRoute("removeCourseTimingByExtID").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/timings/import/{extID}", path)
+
+	courseID := id.New()
+	svc.EXPECT().RemoveCourseRosterByExtID(tenantAlice, "a0T000000000001").Return(courseID, nil)
+	svc.EXPECT().RemoveCourseTimingByExtID(tenantAlice, "a0S000000000001").
+		Return(id.ID(id.IDInvalid), glad.ErrNotFound)
+
+	remove := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodDelete, path, nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := remove("/v1/courses/rosters/import/a0T000000000001")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var d presenter.ImportResponse
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, courseID, d.ID)
+	assert.Equal(t, http.StatusNotFound, remove("/v1/courses/timings/import/a0S000000000001").Code)
+}
@@ -17,17 +17,19 @@ import (
 	"ac9/glad/pkg/common"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
 	"ac9/glad/services/coursed/presenter"
 
 	amock "ac9/glad/usecase/account/mock"
 	cmock "ac9/glad/usecase/center/mock"
 	mock "ac9/glad/usecase/course/mock"
+	dmock "ac9/glad/usecase/dead_letter/mock"
 	pmock "ac9/glad/usecase/product/mock"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
-	"github.com/urfave/negroni"
 )
 
 func getMocks(controller *gomock.Controller,
@@ -42,13 +44,22 @@ func getMocks(controller *gomock.Controller,
 		cmock.NewMockUseCase(controller)
 }
 
+// newTestImporter course importer whose dead letters are not expected to be used
+func newTestImporter(controller *gomock.Controller,
+	svc *mock.MockUseCase,
+	psvc *pmock.MockUseCase,
+	csvc *cmock.MockUseCase,
+) *CourseImporter {
+	return NewCourseImporter(svc, psvc, csvc, dmock.NewMockUseCase(controller))
+}
+
 func Test_listCourses(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 	svc, asvc, psvc, csvc := getMocks(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeCourseHandlers(r, *n, svc, asvc, psvc, csvc)
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
 	path, err := r.GetRoute("listCourses").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/courses", path)
@@ -57,10 +68,9 @@ func Test_listCourses(t *testing.T) {
 		TenantID: tenantAlice,
 		Name:     "default-0",
 	}
-	svc.EXPECT().GetCount(course.TenantID).Return(1)
 	svc.EXPECT().
-		ListCourses(course.TenantID, gomock.Any(), gomock.Any()).
-		Return([]*entity.Course{course}, nil)
+		SearchCourses(course.TenantID, &entity.CourseFilter{}, gomock.Any(), gomock.Any()).
+		Return(1, []*entity.CourseFull{{Course: course}}, nil)
 	ts := httptest.NewServer(listCourses(svc))
 	defer ts.Close()
 
@@ -79,10 +89,9 @@ func Test_listCourses_NotFound(t *testing.T) {
 	ts := httptest.NewServer(listCourses(service))
 	defer ts.Close()
 	tenantID := tenantAlice
-	service.EXPECT().GetCount(tenantID).Return(0)
 	service.EXPECT().
-		SearchCourses(tenantID, "non-existent", gomock.Any(), gomock.Any()).
-		Return(nil, glad.ErrNotFound)
+		SearchCourses(tenantID, &entity.CourseFilter{Query: "non-existent"}, gomock.Any(), gomock.Any()).
+		Return(0, nil, glad.ErrNotFound)
 
 	client := &http.Client{}
 	req, _ := http.NewRequest(http.MethodGet,
@@ -103,22 +112,171 @@ func Test_listCourses_Search(t *testing.T) {
 		TenantID: tenantAlice,
 		Name:     "default-0",
 	}
-	service.EXPECT().GetCount(course.TenantID).Return(1)
+	teacherID := id.New()
+	filter := &entity.CourseFilter{
+		Query:     "default",
+		Status:    entity.CourseOpen,
+		TeacherID: teacherID,
+		DateFrom:  "2024-06-01",
+		City:      "Austin",
+		SortBy:    entity.CourseSortStartDate,
+		SortDesc:  true,
+	}
+	courseFull := &entity.CourseFull{
+		Course: course,
+		Cts:    []*entity.CourseTeacher{{ID: teacherID}},
+	}
 	service.EXPECT().
-		SearchCourses(course.TenantID, "default", gomock.Any(), gomock.Any()).
-		Return([]*entity.Course{course}, nil)
+		SearchCourses(course.TenantID, filter, gomock.Any(), gomock.Any()).
+		Return(7, []*entity.CourseFull{courseFull}, nil)
 	ts := httptest.NewServer(listCourses(service))
 	defer ts.Close()
 
 	client := &http.Client{}
 	req, _ := http.NewRequest(http.MethodGet,
-		ts.URL+"?q=default",
+		ts.URL+"?q=default&status=open&teacherId="+teacherID.String()+
+			"&from=2024-06-01&city=Austin&sort=startDate&order=desc&full=true",
 		nil)
 	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	res, err := client.Do(req)
 
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "7", res.Header.Get(common.HttpHeaderTotalCount))
+
+	var d []*presenter.Course
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, 1, len(d[0].Teacher))
+	assert.Equal(t, teacherID, d[0].Teacher[0].ID)
+}
+
+func Test_listCourses_BadFilter(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	ts := httptest.NewServer(listCourses(service))
+	defer ts.Close()
+
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"?centerId=abc", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func Test_getNearbyCourses(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+	path, err := r.GetRoute("getNearbyCourses").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/nearby", path)
+	course := &entity.Course{
+		ID:       id.New(),
+		TenantID: tenantAlice,
+		Name:     "default-0",
+		Mode:     entity.CourseInPerson,
+	}
+	svc.EXPECT().
+		GetNearbyCourses(tenantAlice, &entity.GeoQuery{Lat: 12.97, Long: 77.59},
+			gomock.Any(), gomock.Any()).
+		Return([]*entity.CourseNearby{
+			{CourseFull: &entity.CourseFull{Course: course}, DistanceKm: 3.2},
+		}, nil)
+	svc.EXPECT().
+		GetNearbyCourses(tenantAlice, &entity.GeoQuery{Lat: 12.97, Long: 77.59, RadiusKm: 900},
+			gomock.Any(), gomock.Any()).
+		Return(nil, glad.ErrInvalidValue)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodGet,
+		ts.URL+"/v1/courses/nearby?lat=12.97&long=77.59", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d []*presenter.CourseNearby
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, course.ID, d[0].ID)
+	assert.Equal(t, 3.2, d[0].DistanceKm)
+
+	req, _ = http.NewRequest(http.MethodGet,
+		ts.URL+"/v1/courses/nearby?lat=12.97&long=77.59&radius=900", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func Test_transitionCourse(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+	path, err := r.GetRoute("transitionCourse").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Contains(t, path, "/v1/courses/{id}/transitions/{action:")
+	courseID := id.New()
+	change := entity.NewCourseStatusChange(tenantAlice, courseID, entity.CourseActionApprove,
+		entity.CourseSubmitted, entity.CourseActive, coordinatorCaller.ID)
+	actor := entity.AuditActor{Source: entity.AuditSourceAPI, AccountID: coordinatorCaller.ID}
+	svc.EXPECT().
+		TransitionCourse(actor, tenantAlice, courseID, entity.CourseActionApprove).
+		Return(change, nil)
+	svc.EXPECT().
+		TransitionCourse(actor, tenantAlice, courseID, entity.CourseActionClose).
+		Return(nil, glad.ErrInvalidTransition)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	post := func(action string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost,
+			ts.URL+"/v1/courses/"+courseID.String()+"/transitions/"+action, nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	res := post("approve")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var d *presenter.CourseTransition
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, entity.CourseSubmitted, d.From)
+	assert.Equal(t, entity.CourseActive, d.To)
+	assert.Equal(t, coordinatorCaller.ID, d.ActorID)
+
+	assert.Equal(t, http.StatusConflict, post("close").StatusCode)
+	assert.Equal(t, http.StatusNotFound, post("publish").StatusCode)
+}
+
+func Test_transitionCourse_Forbidden(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	r := mux.NewRouter()
+	student := &entity.Account{ID: id.New(), TenantID: tenantAlice, Type: entity.AccountStudent}
+	n := newTestNegroni(student)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodPost,
+		ts.URL+"/v1/courses/"+id.New().String()+"/transitions/approve", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
 }
 
 func Test_createCourse(t *testing.T) {
@@ -126,8 +284,8 @@ func Test_createCourse(t *testing.T) {
 	defer controller.Finish()
 	svc, asvc, psvc, csvc := getMocks(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeCourseHandlers(r, *n, svc, asvc, psvc, csvc)
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
 	path, err := r.GetRoute("createCourse").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/courses", path)
@@ -141,6 +299,7 @@ func Test_createCourse(t *testing.T) {
 			gomock.Any(),
 			gomock.Any(),
 			gomock.Any(),
+			gomock.Any(),
 			gomock.Any()).
 		Return(courseID, nil, nil)
 	h := createCourse(svc)
@@ -179,6 +338,103 @@ func Test_createCourse(t *testing.T) {
 	json.NewDecoder(res.Body).Decode(&course)
 	assert.Equal(t, courseID, course.ID)
 	assert.Equal(t, tenantAlice.String(), res.Header.Get(common.HttpHeaderTenantID))
+
+	// teacher not eligible for the product
+	svc.EXPECT().
+		CreateCourse(gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any()).
+		Return(id.ID(id.IDInvalid), nil, glad.ErrNotEligible)
+	req, _ = http.NewRequest(http.MethodPost,
+		ts.URL+"/v1/courses",
+		bytes.NewReader(payloadBytes))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	req.Header.Set("Content-Type", "application/json")
+	res, err = client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// teacher booked by another course at the same time
+	teacherID := id.New()
+	svc.EXPECT().
+		CreateCourse(gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any(),
+			gomock.Any()).
+		Return(id.ID(id.IDInvalid), nil, &entity.ConflictError{Conflicts: []*entity.CourseConflict{{
+			Kind:      entity.ConflictTeacher,
+			AccountID: teacherID,
+		}}})
+	req, _ = http.NewRequest(http.MethodPost,
+		ts.URL+"/v1/courses",
+		bytes.NewReader(payloadBytes))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	req.Header.Set("Content-Type", "application/json")
+	res, err = client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var conflicts []*presenter.CourseConflict
+	json.NewDecoder(res.Body).Decode(&conflicts)
+	assert.Equal(t, 1, len(conflicts))
+	assert.Equal(t, entity.ConflictTeacher, conflicts[0].Kind)
+	assert.Equal(t, teacherID, conflicts[0].AccountID)
+}
+
+func Test_listCourseConflicts(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+	path, err := r.GetRoute("listCourseConflicts").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/conflicts", path)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	teacherID := id.New()
+	otherID := id.New()
+	svc.EXPECT().
+		ListConflicts(tenantAlice, &entity.CourseConflictQuery{
+			AccountID: teacherID,
+			From:      "2024-06-01",
+			To:        "2024-06-30",
+		}).
+		Return([]*entity.CourseConflict{{
+			Kind:      entity.ConflictTeacher,
+			AccountID: teacherID,
+			With:      entity.CourseSession{CourseID: otherID},
+		}}, nil)
+	svc.EXPECT().
+		ListConflicts(tenantAlice, &entity.CourseConflictQuery{CenterID: otherID}).
+		Return(nil, glad.ErrMissingParam)
+
+	get := func(query string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/courses/conflicts"+query, nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	res := get("?teacherId=" + teacherID.String() + "&from=2024-06-01&to=2024-06-30")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var d []*presenter.CourseConflict
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, otherID, d[0].With.CourseID)
+
+	assert.Equal(t, http.StatusBadRequest, get("?centerId="+otherID.String()).StatusCode)
+	assert.Equal(t, http.StatusBadRequest, get("?courseId=abc").StatusCode)
 }
 
 func Test_getCourse(t *testing.T) {
@@ -186,8 +442,8 @@ func Test_getCourse(t *testing.T) {
 	defer controller.Finish()
 	svc, asvc, psvc, csvc := getMocks(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeCourseHandlers(r, *n, svc, asvc, psvc, csvc)
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
 	path, err := r.GetRoute("getCourse").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/courses/{id}", path)
@@ -203,13 +459,15 @@ func Test_getCourse(t *testing.T) {
 		Course: course,
 	}
 	svc.EXPECT().
-		GetCourse(course.ID).
+		GetCourse(tenantAlice, course.ID).
 		Return(courseFull, nil)
 	handler := getCourse(svc)
 	r.Handle("/v1/courses/{id}", handler)
 	ts := httptest.NewServer(r)
 	defer ts.Close()
-	res, err := http.Get(ts.URL + "/v1/courses/" + course.ID.String())
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/courses/"+course.ID.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 
@@ -224,21 +482,78 @@ func Test_getCourse(t *testing.T) {
 	assert.Equal(t, tenantAlice.String(), res.Header.Get(common.HttpHeaderTenantID))
 }
 
+func Test_getCourse_Timezone(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	course := &entity.Course{
+		ID:       id.New(),
+		TenantID: tenantAlice,
+		Name:     "default-0",
+		Timezone: "America/New_York",
+		Mode:     entity.CourseInPerson,
+	}
+	courseFull := &entity.CourseFull{
+		Course: course,
+		CourseTiming: []*entity.CourseTiming{{
+			ID:       id.New(),
+			DateTime: entity.CourseDateTime{Date: "2024-06-01", StartTime: "13:00:00", EndTime: "15:00:00"},
+		}},
+	}
+	svc.EXPECT().GetCourse(tenantAlice, course.ID).Return(courseFull, nil).Times(2)
+
+	get := func(tz string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/courses/"+course.ID.String()+"?tz="+tz, nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+	startsAt := func(res *http.Response) string {
+		var d struct {
+			DateTime []struct {
+				StartsAt string `json:"startsAt"`
+			} `json:"date"`
+		}
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Len(t, d.DateTime, 1)
+		return d.DateTime[0].StartsAt
+	}
+
+	// course timezone, daylight saving time included
+	res := get("")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "2024-06-01T13:00:00-04:00", startsAt(res))
+
+	res = get("Asia/Kolkata")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "2024-06-01T22:30:00+05:30", startsAt(res))
+
+	assert.Equal(t, http.StatusBadRequest, get("Nowhere").StatusCode)
+}
+
 func Test_deleteCourse(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 	svc, asvc, psvc, csvc := getMocks(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeCourseHandlers(r, *n, svc, asvc, psvc, csvc)
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
 	path, err := r.GetRoute("deleteCourse").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/courses/{id}", path)
 
 	id := id.New()
-	svc.EXPECT().DeleteCourse(id).Return(nil)
+	svc.EXPECT().DeleteCourse(gomock.Any(), tenantAlice, id).Return(nil)
 	handler := deleteCourse(svc)
 	req, _ := http.NewRequest("DELETE", "/v1/courses/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/courses/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -250,20 +565,214 @@ func Test_deleteCourseNonExistent(t *testing.T) {
 	defer controller.Finish()
 	svc, asvc, psvc, csvc := getMocks(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeCourseHandlers(r, *n, svc, asvc, psvc, csvc)
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
 	path, err := r.GetRoute("deleteCourse").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/courses/{id}", path)
 
 	id := id.New()
-	svc.EXPECT().DeleteCourse(id).Return(glad.ErrNotFound)
+	svc.EXPECT().DeleteCourse(gomock.Any(), tenantAlice, id).Return(glad.ErrNotFound)
 	handler := deleteCourse(svc)
 	req, _ := http.NewRequest("DELETE", "/v1/courses/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/courses/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 }
 
+func Test_deleteCourse_Relation(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+
+	courseID := id.New()
+	courseFull := &entity.CourseFull{
+		Course: &entity.Course{ID: courseID, TenantID: tenantAlice},
+		Cos:    []*entity.CourseOrganizer{{ID: accountIDPrimary}},
+		Cts:    []*entity.CourseTeacher{{ID: accountIDSecondary}},
+	}
+	svc.EXPECT().GetCourse(tenantAlice, courseID).Return(courseFull, nil).AnyTimes()
+	authz := middleware.NewAuthorizer(policy.Default(), asvc, svc)
+
+	serve := func(caller *entity.Account) int {
+		r := mux.NewRouter()
+		n := newTestNegroni(caller)
+		MakeCourseHandlers(r, *n, authz, svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+		req, _ := http.NewRequest(http.MethodDelete, "/v1/courses/"+courseID.String(), nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	// organizer of the course
+	svc.EXPECT().DeleteCourse(gomock.Any(), tenantAlice, courseID).Return(nil)
+	assert.Equal(t, http.StatusOK, serve(&entity.Account{
+		ID:       accountIDPrimary,
+		TenantID: tenantAlice,
+		Type:     entity.AccountMember,
+	}))
+
+	// teacher of the course is not permitted to delete
+	assert.Equal(t, http.StatusForbidden, serve(&entity.Account{
+		ID:       accountIDSecondary,
+		TenantID: tenantAlice,
+		Type:     entity.AccountTeacher,
+	}))
+}
+
 // TODO: Test case for updating course
+
+func Test_setCourseExtID(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+	path, err := r.GetRoute("setCourseExtID").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/{id}/extid", path)
+
+	courseID := id.New()
+	actor := entity.AuditActor{AccountID: coordinatorCaller.ID, Source: entity.AuditSourceSalesforce}
+	svc.EXPECT().SetCourseExtID(actor, tenantAlice, courseID, "a0W000000000001").Return(nil)
+	svc.EXPECT().SetCourseExtID(actor, tenantAlice, courseID, "a0W000000000002").
+		Return(glad.ErrAlreadyExists)
+	svc.EXPECT().SetCourseExtID(actor, tenantAlice, courseID, "").Return(glad.ErrInvalidValue)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	put := func(body string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPut,
+			ts.URL+"/v1/courses/"+courseID.String()+"/extid", bytes.NewBufferString(body))
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	assert.Equal(t, http.StatusOK, put(`{"extID":"a0W000000000001"}`).StatusCode)
+	assert.Equal(t, http.StatusConflict, put(`{"extID":"a0W000000000002"}`).StatusCode)
+	assert.Equal(t, http.StatusBadRequest, put(`{"extID":""}`).StatusCode)
+	assert.Equal(t, http.StatusBadRequest, put(`{"extID":`).StatusCode)
+}
+
+func Test_archiveCourseByExtID(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, newTestImporter(controller, svc, psvc, csvc))
+	path, err := r.GetRoute("archiveCourseByExtID").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/import/{extID}", path)
+	path, err = r.GetRoute("restoreCourseByExtID").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/import/{extID}/restore", path)
+
+	courseID := id.New()
+	actor := entity.AuditActor{AccountID: coordinatorCaller.ID, Source: entity.AuditSourceSalesforce}
+	svc.EXPECT().ArchiveCourseByExtID(actor, tenantAlice, "a0W000000000001").Return(courseID, nil)
+	svc.EXPECT().ArchiveCourseByExtID(actor, tenantAlice, "a0W000000000002").
+		Return(id.ID(id.IDInvalid), glad.ErrNotFound)
+	svc.EXPECT().RestoreCourseByExtID(actor, tenantAlice, "a0W000000000001").Return(courseID, nil)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	send := func(method, path string) *http.Response {
+		req, _ := http.NewRequest(method, ts.URL+path, nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	res := send(http.MethodDelete, "/v1/courses/import/a0W000000000001")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var d *presenter.ImportResponse
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, courseID, d.ID)
+	assert.Equal(t, "a0W000000000001", d.ExtID)
+
+	res = send(http.MethodDelete, "/v1/courses/import/a0W000000000002")
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	res = send(http.MethodPost, "/v1/courses/import/a0W000000000001/restore")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func Test_importCourse(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, asvc, psvc, csvc := getMocks(controller)
+	dsvc := dmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCourseHandlers(r, *n, testAuthorizer(), svc, asvc, NewCourseImporter(svc, psvc, csvc, dsvc))
+	path, err := r.GetRoute("importCourse").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/import", path)
+
+	productID, centerID, courseID := id.New(), id.New(), id.New()
+	gCourses := []glad.Course{
+		{ExtID: "a0W000000000001", ProductExtID: "p1", CenterExtID: "c1", Name: "one"},
+		{ExtID: "a0W000000000002", ProductExtID: "p2", CenterExtID: "c1", Name: "two"},
+		{ExtID: "a0W000000000003", ProductExtID: "p1", CenterExtID: "c1", Name: ""},
+	}
+
+	// product and center ids are resolved once for the whole batch
+	psvc.EXPECT().GetIDsByExtIDs(tenantAlice, []string{"p1", "p2", "p1"}).
+		Return(map[string]id.ID{"p1": productID}, nil)
+	csvc.EXPECT().GetIDsByExtIDs(tenantAlice, []string{"c1", "c1", "c1"}).
+		Return(map[string]id.ID{"c1": centerID}, nil)
+	actor := entity.AuditActor{AccountID: coordinatorCaller.ID, Source: entity.AuditSourceSalesforce}
+	svc.EXPECT().UpsertCourses(actor, tenantAlice, gomock.Any()).
+		DoAndReturn(func(_ entity.AuditActor, _ id.ID, courses []*entity.Course) ([]id.ID, error) {
+			assert.Equal(t, 2, len(courses))
+			assert.Equal(t, productID, courses[0].ProductID)
+			assert.Equal(t, centerID, courses[0].CenterID)
+			return []id.ID{courseID, id.IDInvalid}, nil
+		})
+	// failed courses are kept; the one with the unknown product waits for it
+	dsvc.EXPECT().AddDeadLetter(gomock.Any()).
+		DoAndReturn(func(e *entity.DeadLetter) error {
+			assert.Equal(t, "a0W000000000002", e.ExtID)
+			assert.Equal(t, entity.DeadLetterMissingDependency, e.Reason)
+			assert.Equal(t, entity.AuditProduct, e.DependencyType)
+			assert.Equal(t, "p2", e.DependencyExtID)
+			return nil
+		})
+	dsvc.EXPECT().AddDeadLetter(gomock.Any()).
+		DoAndReturn(func(e *entity.DeadLetter) error {
+			assert.Equal(t, "a0W000000000003", e.ExtID)
+			assert.Equal(t, entity.DeadLetterRejected, e.Reason)
+			return nil
+		})
+	dsvc.EXPECT().ResolveDeadLetters(tenantAlice, entity.AuditCourse, []string{"a0W000000000001"}).
+		Return(nil)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	payload, _ := json.Marshal(gCourses)
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v1/courses/import", bytes.NewReader(payload))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d []*presenter.ImportCourseResponse
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 3, len(d))
+	assert.Equal(t, courseID, d[0].ID)
+	assert.False(t, d[0].IsError)
+	// unknown product
+	assert.Equal(t, "a0W000000000002", d[1].ExtID)
+	assert.True(t, d[1].IsError)
+	// rejected by the upsert
+	assert.Equal(t, "a0W000000000003", d[2].ExtID)
+	assert.True(t, d[2].IsError)
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
+	"ac9/glad/services/coursed/presenter"
+	"ac9/glad/usecase/dead_letter"
+
+	"github.com/gorilla/mux"
+	"github.com/urfave/negroni"
+)
+
+// Dead letter query parameters
+const (
+	deadLetterParamEntity = "entity"
+)
+
+func listDeadLetters(service dead_letter.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading dead letters"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		page, limit, err := common.HttpGetPageParams(w, r)
+		if err != nil {
+			return
+		}
+
+		entityType := entity.AuditEntityType(r.URL.Query().Get(deadLetterParamEntity))
+		total, letters, err := service.ListDeadLetters(tenantID, entityType, page, limit)
+		switch err {
+		case nil:
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		default:
+			l.Log.Warnf("%v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		var responses []*presenter.DeadLetter
+		for _, e := range letters {
+			resp := &presenter.DeadLetter{}
+			resp.FromEntityDeadLetter(e)
+			responses = append(responses, resp)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTotalCount, strconv.Itoa(total))
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		if err := json.NewEncoder(w).Encode(responses); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode dead letters"))
+		}
+	})
+}
+
+// getDeadLetterImpl gets the dead letter of the path; the response is written
+// when it cannot be read
+func getDeadLetterImpl(w http.ResponseWriter,
+	r *http.Request,
+	service dead_letter.UseCase,
+	errorMessage string,
+) (*entity.DeadLetter, error) {
+	tenantID, err := common.HttpGetTenantID(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetterID, err := id.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Invalid dead letter ID"))
+		return nil, err
+	}
+
+	e, err := service.GetDeadLetter(tenantID, deadLetterID)
+	switch err {
+	case nil:
+		return e, nil
+	case glad.ErrNotFound:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("Dead letter doesn't exist"))
+	default:
+		l.Log.Warnf("Unable to get dead letter id=%v, err=%v", deadLetterID, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(errorMessage))
+	}
+	return nil, err
+}
+
+func getDeadLetter(service dead_letter.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading dead letter"
+		e, err := getDeadLetterImpl(w, r, service, errorMessage)
+		if err != nil {
+			return
+		}
+
+		response := &presenter.DeadLetter{}
+		response.FromEntityDeadLetter(e)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, e.TenantID.String())
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// replayDeadLetter imports the record again. The dead letter is removed when
+// the import succeeds and its attempts are counted otherwise. The record came
+// from Salesforce; it is audited as such so that it is not exported back.
+func replayDeadLetter(service dead_letter.UseCase, importer *CourseImporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error replaying dead letter"
+		e, err := getDeadLetterImpl(w, r, service, errorMessage)
+		if err != nil {
+			return
+		}
+
+		response, err := importer.Replay(auditActor(r, entity.AuditSourceSalesforce),
+			e.TenantID, []*entity.DeadLetter{e})
+		switch err {
+		case nil:
+		case glad.ErrInvalidValue:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to replay " + string(e.EntityType)))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, e.TenantID.String())
+		if err := json.NewEncoder(w).Encode(response[0]); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+func discardDeadLetter(service dead_letter.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error discarding dead letter"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		deadLetterID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid dead letter ID"))
+			return
+		}
+
+		err = service.DiscardDeadLetter(tenantID, deadLetterID)
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusOK)
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Dead letter doesn't exist"))
+		default:
+			l.Log.Warnf("Unable to discard dead letter id=%v, err=%v", deadLetterID, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// MakeDeadLetterHandlers make url handlers
+func MakeDeadLetterHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service dead_letter.UseCase,
+	importer *CourseImporter,
+) {
+	r.Handle("/v1/deadletters", n.With(
+		authz.Require(policy.DeadLetterRead),
+		negroni.Wrap(listDeadLetters(service)),
+	)).Methods("GET", "OPTIONS").Name("listDeadLetters")
+
+	r.Handle("/v1/deadletters/{id}", n.With(
+		authz.Require(policy.DeadLetterRead),
+		negroni.Wrap(getDeadLetter(service)),
+	)).Methods("GET", "OPTIONS").Name("getDeadLetter")
+
+	r.Handle("/v1/deadletters/{id}/replay", n.With(
+		authz.Require(policy.DeadLetterWrite),
+		negroni.Wrap(replayDeadLetter(service, importer)),
+	)).Methods("POST", "OPTIONS").Name("replayDeadLetter")
+
+	r.Handle("/v1/deadletters/{id}", n.With(
+		authz.Require(policy.DeadLetterWrite),
+		negroni.Wrap(discardDeadLetter(service)),
+	)).Methods("DELETE", "OPTIONS").Name("discardDeadLetter")
+}
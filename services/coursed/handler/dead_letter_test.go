@@ -0,0 +1,198 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/coursed/presenter"
+
+	dmock "ac9/glad/usecase/dead_letter/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFixtureDeadLetter(gCourse glad.Course) *entity.DeadLetter {
+	payload, _ := json.Marshal(gCourse)
+	e, _ := entity.NewDeadLetter(tenantAlice, entity.AuditCourse, gCourse.ExtID, payload,
+		entity.DeadLetterRejected, "Unknown center extID="+gCourse.CenterExtID)
+	e.SetDependency(entity.AuditCenter, gCourse.CenterExtID)
+	return e
+}
+
+func Test_listDeadLetters(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, _, psvc, csvc := getMocks(controller)
+	dsvc := dmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeDeadLetterHandlers(r, *n, testAuthorizer(), dsvc, NewCourseImporter(svc, psvc, csvc, dsvc))
+	path, err := r.GetRoute("listDeadLetters").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/deadletters", path)
+
+	e := newFixtureDeadLetter(glad.Course{ExtID: "a0W000000000001", CenterExtID: "c1"})
+	dsvc.EXPECT().ListDeadLetters(tenantAlice, entity.AuditCourse, 1, 10).
+		Return(1, []*entity.DeadLetter{e}, nil)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/deadletters?entity=course&page=1&limit=10", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "1", res.Header.Get(common.HttpHeaderTotalCount))
+
+	var d []*presenter.DeadLetter
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, e.ID, d[0].ID)
+	assert.Equal(t, entity.AuditCenter, d[0].DependencyType)
+	assert.Equal(t, "c1", d[0].DependencyExtID)
+}
+
+func Test_replayDeadLetter(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, _, psvc, csvc := getMocks(controller)
+	dsvc := dmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeDeadLetterHandlers(r, *n, testAuthorizer(), dsvc, NewCourseImporter(svc, psvc, csvc, dsvc))
+	path, err := r.GetRoute("replayDeadLetter").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/deadletters/{id}/replay", path)
+
+	gCourse := glad.Course{ExtID: "a0W000000000001", ProductExtID: "p1", CenterExtID: "c1"}
+	e := newFixtureDeadLetter(gCourse)
+	courseID := id.New()
+	dsvc.EXPECT().GetDeadLetter(tenantAlice, e.ID).Return(e, nil)
+	psvc.EXPECT().GetIDsByExtIDs(tenantAlice, []string{"p1"}).
+		Return(map[string]id.ID{"p1": id.New()}, nil)
+	csvc.EXPECT().GetIDsByExtIDs(tenantAlice, []string{"c1"}).
+		Return(map[string]id.ID{"c1": id.New()}, nil)
+	svc.EXPECT().UpsertCourses(gomock.Any(), tenantAlice, gomock.Any()).
+		Return([]id.ID{courseID}, nil)
+	dsvc.EXPECT().ResolveDeadLetters(tenantAlice, entity.AuditCourse, []string{gCourse.ExtID}).
+		Return(nil)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v1/deadletters/"+e.ID.String()+"/replay", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d presenter.ImportCourseResponse
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, courseID, d.ID)
+	assert.False(t, d.IsError)
+
+	t.Run("not found", func(t *testing.T) {
+		missingID := id.New()
+		dsvc.EXPECT().GetDeadLetter(tenantAlice, missingID).Return(nil, glad.ErrNotFound)
+		req, _ := http.NewRequest(http.MethodPost,
+			ts.URL+"/v1/deadletters/"+missingID.String()+"/replay", nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
+
+func Test_discardDeadLetter(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	dsvc := dmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeDeadLetterHandlers(r, *n, testAuthorizer(), dsvc, nil)
+	path, err := r.GetRoute("discardDeadLetter").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/deadletters/{id}", path)
+
+	deadLetterID := id.New()
+	dsvc.EXPECT().DiscardDeadLetter(tenantAlice, deadLetterID).Return(nil)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/v1/deadletters/"+deadLetterID.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func Test_discardDeadLetter_Forbidden(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	dsvc := dmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	teacher := &entity.Account{ID: id.New(), TenantID: tenantAlice, Type: entity.AccountTeacher}
+	n := newTestNegroni(teacher)
+	MakeDeadLetterHandlers(r, *n, testAuthorizer(), dsvc, nil)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/v1/deadletters/"+id.New().String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+}
+
+// Test_importCenter_ReplayDependents courses waiting for the imported center
+// are imported again
+func Test_importCenter_ReplayDependents(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	svc, _, psvc, csvc := getMocks(controller)
+	dsvc := dmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeCenterHandlers(r, *n, testAuthorizer(), csvc, NewCourseImporter(svc, psvc, csvc, dsvc))
+
+	centerID, courseID := id.New(), id.New()
+	gCourse := glad.Course{ExtID: "a0W000000000001", ProductExtID: "p1", CenterExtID: "c1"}
+	actor := entity.AuditActor{AccountID: coordinatorCaller.ID, Source: entity.AuditSourceSalesforce}
+	csvc.EXPECT().UpsertCenters(actor, tenantAlice, gomock.Any()).Return([]id.ID{centerID}, nil)
+	dsvc.EXPECT().ListDependentDeadLetters(tenantAlice, entity.AuditCenter, []string{"c1"}).
+		Return([]*entity.DeadLetter{newFixtureDeadLetter(gCourse)}, nil)
+	psvc.EXPECT().GetIDsByExtIDs(tenantAlice, []string{"p1"}).
+		Return(map[string]id.ID{"p1": id.New()}, nil)
+	csvc.EXPECT().GetIDsByExtIDs(tenantAlice, []string{"c1"}).
+		Return(map[string]id.ID{"c1": centerID}, nil)
+	svc.EXPECT().UpsertCourses(actor, tenantAlice, gomock.Any()).
+		DoAndReturn(func(_ entity.AuditActor, _ id.ID, courses []*entity.Course) ([]id.ID, error) {
+			assert.Equal(t, centerID, courses[0].CenterID)
+			return []id.ID{courseID}, nil
+		})
+	dsvc.EXPECT().ResolveDeadLetters(tenantAlice, entity.AuditCourse, []string{gCourse.ExtID}).
+		Return(nil)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	payload, _ := json.Marshal([]glad.Center{{ExtID: "c1", Name: "one"}})
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v1/centers/import", bytes.NewReader(payload))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
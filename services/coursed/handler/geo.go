@@ -0,0 +1,48 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"ac9/glad/entity"
+)
+
+// Proximity search query parameters
+const (
+	geoParamLat    = "lat"
+	geoParamLong   = "long"
+	geoParamRadius = "radius"
+)
+
+// geoQueryFromQuery builds the proximity query from the url query
+// lat and long are required; radius (km) is optional.
+func geoQueryFromQuery(r *http.Request) (*entity.GeoQuery, error) {
+	q := r.URL.Query()
+	gq := &entity.GeoQuery{}
+	for param, dst := range map[string]*float64{
+		geoParamLat:    &gq.Lat,
+		geoParamLong:   &gq.Long,
+		geoParamRadius: &gq.RadiusKm,
+	} {
+		v := q.Get(param)
+		if v == "" {
+			if param == geoParamRadius {
+				continue
+			}
+			return nil, fmt.Errorf("missing %v", param)
+		}
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v: %w", param, err)
+		}
+		*dst = parsed
+	}
+	return gq, nil
+}
@@ -9,68 +9,612 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"ac9/glad/entity"
 	"ac9/glad/pkg/common"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
 	"ac9/glad/services/coursed/presenter"
 	"ac9/glad/usecase/account"
+	"ac9/glad/usecase/course"
+	"ac9/glad/usecase/participant"
 
 	"github.com/gorilla/mux"
-	"github.com/ulule/deepcopier"
 	"github.com/urfave/negroni"
 )
 
-// TODO: This is temporary until we implement the correct search
-func getParticipantByCourse(service account.UseCase) http.Handler {
+func listParticipants(service participant.UseCase, accountService account.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error reading participants"
-		tenant := r.Header.Get(common.HttpHeaderTenantID)
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
 
-		tenantID, err := id.FromString(tenant)
+		vars := mux.Vars(r)
+		courseID, err := id.FromString(vars["courseId"])
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte("Unable to parse tenant id"))
+			_, _ = w.Write([]byte("Unable to parse course id"))
+			return
+		}
+
+		page, limit, err := common.HttpGetPageParams(w, r)
+		if err != nil {
 			return
 		}
 
-		// hard-coded value
-		data, err := service.GetAccountByName(tenantID, "Sri Sri Ravi Shankar")
+		data, err := service.ListParticipants(tenantID, courseID, page, limit)
+		w.Header().Set("Content-Type", "application/json")
 		if err != nil && err != glad.ErrNotFound {
+			l.Log.Errorf(err.Error())
 			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			_, _ = w.Write([]byte(errorMessage))
 			return
 		}
 
 		if data == nil {
 			w.WriteHeader(http.StatusNotFound)
-			_, _ = w.Write([]byte("Empty data returned"))
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		var toJ []*presenter.Participant
+		for _, d := range data {
+			p := &presenter.Participant{}
+			p.FromParticipantEntity(d)
+
+			a, err := accountService.GetAccount(tenantID, d.AccountID)
+			if err != nil {
+				l.Log.Warnf("Unable to get account id=%v, err=%v", d.AccountID, err)
+			} else {
+				p.Account = &presenter.Account{}
+				_ = p.Account.FromAccountEntity(a)
+			}
+			toJ = append(toJ, p)
+		}
+
+		total := service.GetCount(tenantID, courseID)
+		w.Header().Set(common.HttpHeaderTotalCount, strconv.Itoa(total))
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode participant"))
+		}
+	})
+}
+
+func addParticipant(service participant.UseCase, accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		vars := mux.Vars(r)
+		courseID, err := id.FromString(vars["courseId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse course id"))
+			return
+		}
+
+		var input presenter.ParticipantReq
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		// Note: participants carry no tenant; the account must be of the tenant
+		_, err = accountService.GetAccount(tenantID, input.AccountID)
+		switch err {
+		case nil:
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Account doesn't exist"))
+			return
+		default:
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Error adding participant"))
+			return
+		}
+
+		participantID, err := service.AddParticipant(tenantID, courseID, input.AccountID, input.Email)
+		writeParticipantAdded(w, service, tenantID, courseID, participantID, err)
+	})
+}
+
+// registerSelf registers the caller to the course
+func registerSelf(service participant.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		vars := mux.Vars(r)
+		courseID, err := id.FromString(vars["courseId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse course id"))
+			return
+		}
+
+		account := middleware.AccountFromContext(r.Context())
+		if account == nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("Unknown account"))
+			return
+		}
+
+		participantID, err := service.AddParticipant(tenantID, courseID, account.ID, account.Email)
+		writeParticipantAdded(w, service, tenantID, courseID, participantID, err)
+	})
+}
+
+// writeParticipantAdded responds to a registration of an account to the course
+func writeParticipantAdded(w http.ResponseWriter,
+	service participant.UseCase,
+	tenantID id.ID,
+	courseID id.ID,
+	participantID id.ID,
+	err error,
+) {
+	errorMessage := "Error adding participant"
+	switch err {
+	case nil:
+	case glad.ErrWaitlisted:
+		writeWaitlistEntry(w, service, tenantID, courseID, participantID)
+		return
+	case glad.ErrInvalidEntity:
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+		return
+	case glad.ErrNotFound:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("Course doesn't exist"))
+		return
+	case glad.ErrAlreadyExists, glad.ErrCourseFull, glad.ErrRegistrationClosed:
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+		return
+	default:
+		l.Log.Errorf(err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(errorMessage))
+		return
+	}
+
+	response := &presenter.ParticipantResponse{
+		ID: participantID,
+	}
+
+	w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		l.Log.Errorf(err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(errorMessage))
+	}
+}
+
+func removeParticipant(service participant.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error removing participant"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		vars := mux.Vars(r)
+		courseID, err := id.FromString(vars["courseId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse course id"))
+			return
+		}
+		participantID, err := id.FromString(vars["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse participant id"))
+			return
+		}
+
+		err = service.RemoveParticipant(tenantID, courseID, participantID)
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusOK)
+			return
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Participant doesn't exist"))
+			return
+		default:
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
 			return
 		}
+	})
+}
+
+// writeWaitlistEntry responds to a registration added to the waitlist
+func writeWaitlistEntry(w http.ResponseWriter,
+	service participant.UseCase,
+	tenantID id.ID,
+	courseID id.ID,
+	entryID id.ID,
+) {
+	response := &presenter.WaitlistEntry{
+		ID:       entryID,
+		CourseID: courseID,
+	}
+	e, err := service.GetWaitlistEntry(tenantID, entryID)
+	if err != nil {
+		l.Log.Warnf("Unable to get waitlist entry id=%v, err=%v", entryID, err)
+	} else {
+		response.FromWaitlistEntryEntity(e)
+	}
 
-		account := &presenter.Account{}
-		deepcopier.Copy(data).To(account)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		l.Log.Errorf(err.Error())
+	}
+}
 
-		p := &presenter.Participant{
-			ID:      account.ID + 3,
-			Email:   account.Email,
-			Account: account,
+func listWaitlist(service participant.UseCase, accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading waitlist"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
 		}
 
-		toJ := []*presenter.Participant{p}
+		vars := mux.Vars(r)
+		courseID, err := id.FromString(vars["courseId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse course id"))
+			return
+		}
 
-		w.Header().Set(common.HttpHeaderTenantID, data.TenantID.String())
+		data, err := service.ListWaitlist(tenantID, courseID)
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil && err != glad.ErrNotFound {
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		if data == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		var toJ []*presenter.WaitlistEntry
+		for _, d := range data {
+			e := &presenter.WaitlistEntry{}
+			e.FromWaitlistEntryEntity(d)
+
+			a, err := accountService.GetAccount(tenantID, d.AccountID)
+			if err != nil {
+				l.Log.Warnf("Unable to get account id=%v, err=%v", d.AccountID, err)
+			} else {
+				e.Account = &presenter.Account{}
+				_ = e.Account.FromAccountEntity(a)
+			}
+			toJ = append(toJ, e)
+		}
+
+		w.Header().Set(common.HttpHeaderTotalCount, strconv.Itoa(len(toJ)))
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
 		if err := json.NewEncoder(w).Encode(toJ); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte("Unable to encode course"))
+			_, _ = w.Write([]byte("Unable to encode waitlist"))
 		}
 	})
 }
 
-// MakeCourseHandlers make url handlers
-func MakeParticipantHandlers(r *mux.Router, n negroni.Negroni, service account.UseCase) {
-	// TODO: implement get participants by course-id
+func reorderWaitlist(service participant.UseCase, accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reordering waitlist"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		vars := mux.Vars(r)
+		courseID, err := id.FromString(vars["courseId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse course id"))
+			return
+		}
+
+		var input presenter.WaitlistReorderReq
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		err = service.ReorderWaitlist(tenantID, courseID, input.EntryIDs)
+		switch err {
+		case nil:
+		case glad.ErrInvalidValue:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Waitlist doesn't exist"))
+			return
+		default:
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		listWaitlist(service, accountService).ServeHTTP(w, r)
+	})
+}
+
+func confirmWaitlistEntry(service participant.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error confirming waitlist seat"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		vars := mux.Vars(r)
+		courseID, err := id.FromString(vars["courseId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse course id"))
+			return
+		}
+
+		account := middleware.AccountFromContext(r.Context())
+		if account == nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("Unknown account"))
+			return
+		}
+
+		participantID, err := service.ConfirmWaitlistEntry(tenantID, courseID, account.ID)
+		switch err {
+		case nil:
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Waitlist entry doesn't exist"))
+			return
+		case glad.ErrInvalidTransition:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(errorMessage + ": no seat is held"))
+			return
+		default:
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		response := &presenter.ParticipantResponse{
+			ID: participantID,
+		}
+
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+	})
+}
+
+func importParticipant(service participant.UseCase, accountService account.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error importing participants"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		vars := mux.Vars(r)
+		courseID, err := id.FromString(vars["courseId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse course id"))
+			return
+		}
+
+		var gParticipants []glad.Participant
+		err = json.NewDecoder(r.Body).Decode(&gParticipants)
+		if err != nil {
+			l.Log.Warnf("Unable to decode object. err = %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		var response []*presenter.ImportParticipantResponse
+		for _, gParticipant := range gParticipants {
+			response = append(response,
+				upsertImportedParticipant(service, accountService, tenantID, courseID, &gParticipant))
+		}
+
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+	})
+}
+
+// importCourseParticipants upserts the registrations imported from Salesforce
+// Unlike importParticipant, each participant refers to its course by the
+// course's Salesforce id.
+func importCourseParticipants(service participant.UseCase,
+	accountService account.UseCase,
+	courseService course.UseCase,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error importing participants"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		var gParticipants []glad.Participant
+		err = json.NewDecoder(r.Body).Decode(&gParticipants)
+		if err != nil {
+			l.Log.Warnf("Unable to decode object. err = %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		var response []*presenter.ImportParticipantResponse
+		for _, gParticipant := range gParticipants {
+			c, err := courseService.GetCourseByExtID(tenantID, gParticipant.CourseExtID)
+			if err != nil {
+				l.Log.Warnf("Unable to get course extID=%v, err=%v", gParticipant.CourseExtID, err)
+				response = append(response, &presenter.ImportParticipantResponse{
+					ExtID:   gParticipant.ExtID,
+					IsError: true,
+				})
+				continue
+			}
+
+			response = append(response,
+				upsertImportedParticipant(service, accountService, tenantID, c.ID, &gParticipant))
+		}
+
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+	})
+}
+
+// upsertImportedParticipant upserts a participant of the course imported from
+// Salesforce and returns its import result
+func upsertImportedParticipant(service participant.UseCase,
+	accountService account.UseCase,
+	tenantID id.ID,
+	courseID id.ID,
+	gParticipant *glad.Participant,
+) *presenter.ImportParticipantResponse {
+	a, err := accountService.GetAccountByExtID(tenantID, gParticipant.AccountExtID)
+	if err != nil {
+		l.Log.Warnf("Unable to get account extID=%v, err=%v", gParticipant.AccountExtID, err)
+		return &presenter.ImportParticipantResponse{
+			ExtID:   gParticipant.ExtID,
+			IsError: true,
+		}
+	}
+
+	extID := gParticipant.ExtID
+	p := &entity.Participant{
+		CourseID:  courseID,
+		AccountID: a.ID,
+		ExtID:     &extID,
+		Email:     gParticipant.Email,
+		CreatedAt: gParticipant.CreatedAt,
+		UpdatedAt: gParticipant.UpdatedAt,
+	}
+
+	participantID, err := service.UpsertParticipant(tenantID, p)
+	if err != nil {
+		l.Log.Warnf("Unable to upsert participant extID=%v, err=%v", extID, err)
+	}
+
+	return &presenter.ImportParticipantResponse{
+		ID:      participantID,
+		ExtID:   extID,
+		IsError: err != nil,
+	}
+}
+
+// MakeParticipantHandlers make url handlers
+func MakeParticipantHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service participant.UseCase,
+	accountService account.UseCase,
+	courseService course.UseCase,
+) {
+	r.Handle("/v1/courses/participants/import", n.With(
+		authz.Require(policy.ParticipantImport),
+		negroni.Wrap(importCourseParticipants(service, accountService, courseService)),
+	)).Methods("POST", "OPTIONS").Name("importCourseParticipants")
+
+	r.Handle("/v1/courses/participants/import/{extID}", n.With(
+		authz.Require(policy.ParticipantImport),
+		negroni.Wrap(syncByExtID(removeByExtID(service.RemoveParticipantByExtID),
+			"Error removing participant")),
+	)).Methods("DELETE", "OPTIONS").Name("removeParticipantByExtID")
+
 	r.Handle("/v1/participants/{courseId}", n.With(
-		negroni.Wrap(getParticipantByCourse(service)),
+		authz.Require(policy.ParticipantRead),
+		negroni.Wrap(listParticipants(service, accountService)),
 	)).Methods("GET", "OPTIONS").Name("getParticipantByCourse")
+
+	r.Handle("/v1/participants/{courseId}", n.With(
+		authz.Require(policy.ParticipantWrite),
+		negroni.Wrap(addParticipant(service, accountService)),
+	)).Methods("POST", "OPTIONS").Name("addParticipant")
+
+	r.Handle("/v1/courses/{courseId}/register", n.With(
+		authz.Require(policy.ParticipantSelf),
+		negroni.Wrap(registerSelf(service)),
+	)).Methods("POST", "OPTIONS").Name("registerSelf")
+
+	r.Handle("/v1/participants/{courseId}/import", n.With(
+		authz.Require(policy.ParticipantImport),
+		negroni.Wrap(importParticipant(service, accountService)),
+	)).Methods("POST", "OPTIONS").Name("importParticipant")
+
+	r.Handle("/v1/courses/{courseId}/waitlist", n.With(
+		authz.Require(policy.ParticipantRead),
+		negroni.Wrap(listWaitlist(service, accountService)),
+	)).Methods("GET", "OPTIONS").Name("listWaitlist")
+
+	r.Handle("/v1/courses/{courseId}/waitlist", n.With(
+		authz.Require(policy.ParticipantWrite),
+		negroni.Wrap(reorderWaitlist(service, accountService)),
+	)).Methods("PUT", "OPTIONS").Name("reorderWaitlist")
+
+	r.Handle("/v1/courses/{courseId}/waitlist/confirm", n.With(
+		authz.Require(policy.WaitlistConfirm),
+		negroni.Wrap(confirmWaitlistEntry(service)),
+	)).Methods("POST", "OPTIONS").Name("confirmWaitlistEntry")
+
+	r.Handle("/v1/participants/{courseId}/{id}", n.With(
+		authz.Require(policy.ParticipantWrite),
+		negroni.Wrap(removeParticipant(service)),
+	)).Methods("DELETE", "OPTIONS").Name("removeParticipant")
 }
@@ -0,0 +1,412 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/coursed/presenter"
+
+	amock "ac9/glad/usecase/account/mock"
+	coursemock "ac9/glad/usecase/course/mock"
+	mock "ac9/glad/usecase/participant/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	participantCourseID id.ID = 13790493495087071234
+	participantIDAlice  id.ID = 13790493495087075555
+	waitlistEntryBob    id.ID = 13790493495087075556
+	waitlistEntryCarol  id.ID = 13790493495087075557
+)
+
+func Test_listParticipants(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	accountService := amock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeParticipantHandlers(r, *n, testAuthorizer(), service, accountService,
+		coursemock.NewMockUseCase(controller))
+	path, err := r.GetRoute("getParticipantByCourse").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/participants/{courseId}", path)
+
+	p := &entity.Participant{
+		ID:        participantIDAlice,
+		CourseID:  participantCourseID,
+		AccountID: accountIDPrimary,
+		Email:     nameAlice,
+	}
+	account := &entity.Account{
+		ID:       accountIDPrimary,
+		TenantID: tenantAlice,
+		Username: accountUsernamePrimary,
+		Type:     entity.AccountMember,
+	}
+	service.EXPECT().
+		ListParticipants(tenantAlice, participantCourseID, gomock.Any(), gomock.Any()).
+		Return([]*entity.Participant{p}, nil)
+	service.EXPECT().GetCount(tenantAlice, participantCourseID).Return(1)
+	accountService.EXPECT().GetAccount(tenantAlice, accountIDPrimary).Return(account, nil)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/participants/"+participantCourseID.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "1", res.Header.Get(common.HttpHeaderTotalCount))
+
+	var d []*presenter.Participant
+	_ = json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, participantIDAlice, d[0].ID)
+	assert.Equal(t, participantCourseID, d[0].CourseID)
+	assert.Equal(t, accountUsernamePrimary, d[0].Account.Username)
+}
+
+func Test_addParticipant(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	accountService := amock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeParticipantHandlers(r, *n, testAuthorizer(), service, accountService,
+		coursemock.NewMockUseCase(controller))
+	path, err := r.GetRoute("addParticipant").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/participants/{courseId}", path)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	post := func() *http.Response {
+		payload, _ := json.Marshal(presenter.ParticipantReq{AccountID: accountIDPrimary})
+		req, _ := http.NewRequest(http.MethodPost,
+			ts.URL+"/v1/participants/"+participantCourseID.String(),
+			bytes.NewReader(payload))
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	// an account of another tenant is not found in the caller's tenant
+	accountService.EXPECT().GetAccount(tenantAlice, accountIDPrimary).
+		Return(nil, glad.ErrNotFound)
+	res := post()
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	accountService.EXPECT().GetAccount(tenantAlice, accountIDPrimary).
+		Return(&entity.Account{ID: accountIDPrimary, TenantID: tenantAlice}, nil).Times(3)
+	service.EXPECT().
+		AddParticipant(tenantAlice, participantCourseID, accountIDPrimary, "").
+		Return(participantIDAlice, nil)
+	res = post()
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	var d presenter.ParticipantResponse
+	_ = json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, participantIDAlice, d.ID)
+
+	service.EXPECT().
+		AddParticipant(tenantAlice, participantCourseID, accountIDPrimary, "").
+		Return(id.ID(id.IDInvalid), glad.ErrCourseFull)
+	res = post()
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	service.EXPECT().
+		AddParticipant(tenantAlice, participantCourseID, accountIDPrimary, "").
+		Return(waitlistEntryBob, glad.ErrWaitlisted)
+	service.EXPECT().GetWaitlistEntry(tenantAlice, waitlistEntryBob).
+		Return(&entity.WaitlistEntry{
+			ID:        waitlistEntryBob,
+			CourseID:  participantCourseID,
+			AccountID: accountIDPrimary,
+			Position:  3,
+			Status:    entity.WaitlistWaiting,
+		}, nil)
+	res = post()
+	assert.Equal(t, http.StatusAccepted, res.StatusCode)
+	var e presenter.WaitlistEntry
+	_ = json.NewDecoder(res.Body).Decode(&e)
+	assert.Equal(t, waitlistEntryBob, e.ID)
+	assert.Equal(t, 3, e.Position)
+	assert.Nil(t, e.HoldUntil)
+}
+
+func Test_registerSelf(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	student := &entity.Account{
+		ID:       accountIDPrimary,
+		TenantID: tenantAlice,
+		Email:    nameAlice,
+		Type:     entity.AccountStudent,
+	}
+	r := mux.NewRouter()
+	n := newTestNegroni(student)
+	MakeParticipantHandlers(r, *n, testAuthorizer(), service, amock.NewMockUseCase(controller),
+		coursemock.NewMockUseCase(controller))
+	path, err := r.GetRoute("registerSelf").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/{courseId}/register", path)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	// the student registers their own account, whatever the payload
+	service.EXPECT().
+		AddParticipant(tenantAlice, participantCourseID, accountIDPrimary, nameAlice).
+		Return(participantIDAlice, nil)
+	req, _ := http.NewRequest(http.MethodPost,
+		ts.URL+"/v1/courses/"+participantCourseID.String()+"/register", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	var d presenter.ParticipantResponse
+	_ = json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, participantIDAlice, d.ID)
+
+	// the student is not allowed to register someone else
+	payload, _ := json.Marshal(presenter.ParticipantReq{AccountID: accountIDSecondary})
+	req, _ = http.NewRequest(http.MethodPost,
+		ts.URL+"/v1/participants/"+participantCourseID.String(), bytes.NewReader(payload))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+}
+
+func Test_waitlist(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	accountService := amock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeParticipantHandlers(r, *n, testAuthorizer(), service, accountService,
+		coursemock.NewMockUseCase(controller))
+	for name, want := range map[string]string{
+		"listWaitlist":         "/v1/courses/{courseId}/waitlist",
+		"reorderWaitlist":      "/v1/courses/{courseId}/waitlist",
+		"confirmWaitlistEntry": "/v1/courses/{courseId}/waitlist/confirm",
+	} {
+		path, err := r.GetRoute(name).GetPathTemplate()
+		assert.Nil(t, err)
+		assert.Equal(t, want, path)
+	}
+
+	holdUntil := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	entries := []*entity.WaitlistEntry{
+		{
+			ID:        waitlistEntryCarol,
+			CourseID:  participantCourseID,
+			AccountID: accountIDPrimary,
+			Position:  1,
+			Status:    entity.WaitlistOffered,
+			HoldUntil: holdUntil,
+		},
+		{
+			ID:        waitlistEntryBob,
+			CourseID:  participantCourseID,
+			AccountID: accountIDPrimary,
+			Position:  2,
+			Status:    entity.WaitlistWaiting,
+		},
+	}
+	account := &entity.Account{
+		ID:       accountIDPrimary,
+		TenantID: tenantAlice,
+		Username: accountUsernamePrimary,
+		Type:     entity.AccountMember,
+	}
+	path := "/v1/courses/" + participantCourseID.String() + "/waitlist"
+	do := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("list", func(t *testing.T) {
+		service.EXPECT().ListWaitlist(tenantAlice, participantCourseID).Return(entries, nil)
+		accountService.EXPECT().GetAccount(tenantAlice, accountIDPrimary).Return(account, nil).Times(2)
+
+		rr := do(http.MethodGet, path, nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "2", rr.Header().Get(common.HttpHeaderTotalCount))
+
+		var d []*presenter.WaitlistEntry
+		_ = json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, 2, len(d))
+		assert.Equal(t, waitlistEntryCarol, d[0].ID)
+		assert.Equal(t, entity.WaitlistOffered, d[0].Status)
+		assert.True(t, holdUntil.Equal(*d[0].HoldUntil))
+		assert.Equal(t, accountUsernamePrimary, d[1].Account.Username)
+	})
+
+	t.Run("reorder", func(t *testing.T) {
+		order := []id.ID{waitlistEntryBob, waitlistEntryCarol}
+		service.EXPECT().ReorderWaitlist(tenantAlice, participantCourseID, order).Return(nil)
+		service.EXPECT().ListWaitlist(tenantAlice, participantCourseID).
+			Return([]*entity.WaitlistEntry{entries[1], entries[0]}, nil)
+		accountService.EXPECT().GetAccount(tenantAlice, accountIDPrimary).Return(account, nil).Times(2)
+
+		rr := do(http.MethodPut, path, presenter.WaitlistReorderReq{EntryIDs: order})
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d []*presenter.WaitlistEntry
+		_ = json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, waitlistEntryBob, d[0].ID)
+
+		service.EXPECT().ReorderWaitlist(tenantAlice, participantCourseID, order[:1]).
+			Return(glad.ErrInvalidValue)
+		rr = do(http.MethodPut, path, presenter.WaitlistReorderReq{EntryIDs: order[:1]})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("confirm", func(t *testing.T) {
+		service.EXPECT().ConfirmWaitlistEntry(tenantAlice, participantCourseID, coordinatorCaller.ID).
+			Return(participantIDAlice, nil)
+		rr := do(http.MethodPost, path+"/confirm", nil)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var d presenter.ParticipantResponse
+		_ = json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, participantIDAlice, d.ID)
+
+		service.EXPECT().ConfirmWaitlistEntry(tenantAlice, participantCourseID, coordinatorCaller.ID).
+			Return(id.ID(id.IDInvalid), glad.ErrInvalidTransition)
+		assert.Equal(t, http.StatusConflict, do(http.MethodPost, path+"/confirm", nil).Code)
+	})
+}
+
+func Test_removeParticipant(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	accountService := amock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeParticipantHandlers(r, *n, testAuthorizer(), service, accountService,
+		coursemock.NewMockUseCase(controller))
+	path, err := r.GetRoute("removeParticipant").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/participants/{courseId}/{id}", path)
+
+	service.EXPECT().
+		RemoveParticipant(tenantAlice, participantCourseID, participantIDAlice).
+		Return(nil)
+	req, _ := http.NewRequest(http.MethodDelete,
+		"/v1/participants/"+participantCourseID.String()+"/"+participantIDAlice.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func Test_importCourseParticipants(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	accountService := amock.NewMockUseCase(controller)
+	courseService := coursemock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeParticipantHandlers(r, *n, testAuthorizer(), service, accountService, courseService)
+	path, err := r.GetRoute("importCourseParticipants").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/participants/import", path)
+
+	courseService.EXPECT().GetCourseByExtID(tenantAlice, "a0W000000000001").
+		Return(&entity.Course{ID: participantCourseID, TenantID: tenantAlice}, nil)
+	courseService.EXPECT().GetCourseByExtID(tenantAlice, "a0W000000000009").
+		Return(nil, glad.ErrNotFound)
+	accountService.EXPECT().GetAccountByExtID(tenantAlice, "003000000000001").
+		Return(&entity.Account{ID: accountIDPrimary, TenantID: tenantAlice}, nil)
+	service.EXPECT().UpsertParticipant(tenantAlice, gomock.Any()).
+		DoAndReturn(func(_ id.ID, p *entity.Participant) (id.ID, error) {
+			assert.Equal(t, participantCourseID, p.CourseID)
+			assert.Equal(t, accountIDPrimary, p.AccountID)
+			assert.Equal(t, "a0R000000000001", *p.ExtID)
+			return participantIDAlice, nil
+		})
+
+	payload, _ := json.Marshal([]glad.Participant{
+		{ExtID: "a0R000000000001", CourseExtID: "a0W000000000001", AccountExtID: "003000000000001"},
+		{ExtID: "a0R000000000002", CourseExtID: "a0W000000000009", AccountExtID: "003000000000001"},
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/v1/courses/participants/import", bytes.NewReader(payload))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var d []*presenter.ImportParticipantResponse
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, 2, len(d))
+	assert.Equal(t, participantIDAlice, d[0].ID)
+	assert.False(t, d[0].IsError)
+	assert.Equal(t, "a0R000000000002", d[1].ExtID)
+	assert.True(t, d[1].IsError)
+}
+
+func Test_removeParticipantByExtID(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeParticipantHandlers(r, *n, testAuthorizer(), service, amock.NewMockUseCase(controller),
+		coursemock.NewMockUseCase(controller))
+	path, err := r.GetRoute("removeParticipantByExtID").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/courses/participants/import/{extID}", path)
+
+	service.EXPECT().RemoveParticipantByExtID(tenantAlice, "a0R000000000001").
+		Return(participantIDAlice, nil)
+	service.EXPECT().RemoveParticipantByExtID(tenantAlice, "a0R000000000002").
+		Return(id.ID(id.IDInvalid), glad.ErrNotFound)
+
+	remove := func(extID string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodDelete, "/v1/courses/participants/import/"+extID, nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := remove("a0R000000000001")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var d presenter.ImportResponse
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, participantIDAlice, d.ID)
+	assert.Equal(t, http.StatusNotFound, remove("a0R000000000002").Code)
+}
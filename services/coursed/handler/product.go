@@ -17,6 +17,8 @@ import (
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
 	"ac9/glad/services/coursed/presenter"
 	"ac9/glad/usecase/product"
 
@@ -102,6 +104,7 @@ func createProduct(service product.UseCase) http.Handler {
 		}
 
 		productID, err := service.CreateProduct(
+			auditActor(r, entity.AuditSourceAPI),
 			tenantID,
 			req.ExtName,
 			req.Title,
@@ -136,6 +139,11 @@ func createProduct(service product.UseCase) http.Handler {
 func getProduct(service product.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error reading product"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
 		vars := mux.Vars(r)
 		id, err := id.FromString(vars["id"])
 		if err != nil {
@@ -143,7 +151,7 @@ func getProduct(service product.UseCase) http.Handler {
 			_, _ = w.Write([]byte(err.Error()))
 			return
 		}
-		data, err := service.GetProduct(id)
+		data, err := service.GetProduct(tenantID, id)
 		if err != nil && err != glad.ErrNotFound {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -170,6 +178,11 @@ func getProduct(service product.UseCase) http.Handler {
 func deleteProduct(service product.UseCase) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error removing product"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
 		vars := mux.Vars(r)
 		id, err := id.FromString(vars["id"])
 		if err != nil {
@@ -177,7 +190,7 @@ func deleteProduct(service product.UseCase) http.Handler {
 			_, _ = w.Write([]byte(errorMessage))
 			return
 		}
-		err = service.DeleteProduct(id)
+		err = service.DeleteProduct(auditActor(r, entity.AuditSourceAPI), tenantID, id)
 		switch err {
 		case nil:
 			w.WriteHeader(http.StatusOK)
@@ -225,7 +238,12 @@ func updateProduct(service product.UseCase) http.Handler {
 
 		input.ID = productID
 		input.TenantID = tenantID
-		err = service.UpdateProduct(&input)
+		err = service.UpdateProduct(auditActor(r, entity.AuditSourceAPI), &input)
+		if err == glad.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Product doesn't exist"))
+			return
+		}
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -237,7 +255,8 @@ func updateProduct(service product.UseCase) http.Handler {
 	})
 }
 
-func importProduct(service product.UseCase) http.Handler {
+// importProduct upserts the products and replays the courses that were waiting for them
+func importProduct(service product.UseCase, importer *CourseImporter) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error importing products"
 
@@ -258,24 +277,34 @@ func importProduct(service product.UseCase) http.Handler {
 			return
 		}
 
-		var response []*presenter.ProductImportResponse
-		for _, input := range iProducts {
-			product := &entity.Product{}
-			presenter.GladProductToEntity(input, product)
-			product.TenantID = tenantID
+		products := make([]*entity.Product, len(iProducts))
+		for i, input := range iProducts {
+			products[i] = &entity.Product{}
+			presenter.GladProductToEntity(input, products[i])
+		}
 
-			// TODO: optimize DB operations by doing multiple inserts simultaneously
-			productID, err := service.UpsertProduct(product)
-			if err != nil {
-				l.Log.Warnf("Unable to upsert product extID=%v, err=%v", product.ExtID, err)
-			}
+		actor := auditActor(r, entity.AuditSourceSalesforce)
+		productIDs, err := service.UpsertProducts(actor, tenantID, products)
+		if err != nil {
+			l.Log.Warnf("Unable to upsert products, err=%v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
 
-			response = append(response, &presenter.ProductImportResponse{
-				ID:      productID,
+		var imported []string
+		response := make([]*presenter.ProductImportResponse, len(products))
+		for i, product := range products {
+			response[i] = &presenter.ProductImportResponse{
+				ID:      productIDs[i],
 				ExtID:   product.ExtID,
-				IsError: err != nil,
-			})
+				IsError: productIDs[i] == id.IDInvalid,
+			}
+			if !response[i].IsError {
+				imported = append(imported, product.ExtID)
+			}
 		}
+		importer.ReplayDependents(actor, tenantID, entity.AuditProduct, imported)
 
 		w.Header().Set(common.HttpHeaderTenantID, tenant)
 		w.WriteHeader(http.StatusOK)
@@ -289,28 +318,49 @@ func importProduct(service product.UseCase) http.Handler {
 }
 
 // MakeProductHandlers make url handlers
-func MakeProductHandlers(r *mux.Router, n negroni.Negroni, service product.UseCase) {
+func MakeProductHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service product.UseCase,
+	importer *CourseImporter,
+) {
 	r.Handle("/v1/products", n.With(
+		authz.Require(policy.ProductRead),
 		negroni.Wrap(listProducts(service)),
 	)).Methods("GET", "OPTIONS").Name("listProducts")
 
 	r.Handle("/v1/products", n.With(
+		authz.Require(policy.ProductWrite),
 		negroni.Wrap(createProduct(service)),
 	)).Methods("POST", "OPTIONS").Name("createProduct")
 
 	r.Handle("/v1/products/import", n.With(
-		negroni.Wrap(importProduct(service)),
+		authz.Require(policy.ProductImport),
+		negroni.Wrap(importProduct(service, importer)),
 	)).Methods("POST", "OPTIONS").Name("importProduct")
 
+	r.Handle("/v1/products/import/{extID}", n.With(
+		authz.Require(policy.ProductImport),
+		negroni.Wrap(syncByExtID(service.ArchiveProductByExtID, "Error archiving product")),
+	)).Methods("DELETE", "OPTIONS").Name("archiveProductByExtID")
+
+	r.Handle("/v1/products/import/{extID}/restore", n.With(
+		authz.Require(policy.ProductImport),
+		negroni.Wrap(syncByExtID(service.RestoreProductByExtID, "Error restoring product")),
+	)).Methods("POST", "OPTIONS").Name("restoreProductByExtID")
+
 	r.Handle("/v1/products/{id}", n.With(
+		authz.Require(policy.ProductRead),
 		negroni.Wrap(getProduct(service)),
 	)).Methods("GET", "OPTIONS").Name("getProduct")
 
 	r.Handle("/v1/products/{id}", n.With(
+		authz.Require(policy.ProductWrite),
 		negroni.Wrap(deleteProduct(service)),
 	)).Methods("DELETE", "OPTIONS").Name("deleteProduct")
 
 	r.Handle("/v1/products/{id}", n.With(
+		authz.Require(policy.ProductWrite),
 		negroni.Wrap(updateProduct(service)),
 	)).Methods("PUT", "OPTIONS").Name("updateProduct")
 }
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
+	"ac9/glad/services/coursed/presenter"
+	"ac9/glad/usecase/product_template"
+
+	"github.com/gorilla/mux"
+	"github.com/urfave/negroni"
+)
+
+func getProductTemplate(service product_template.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading product template"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		productID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse product id"))
+			return
+		}
+
+		t, err := service.GetProductTemplate(tenantID, productID)
+		switch err {
+		case nil:
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		default:
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		response := &presenter.ProductTemplate{}
+		response.FromProductTemplateEntity(t)
+		writeJSON(w, tenantID, http.StatusOK, response)
+	})
+}
+
+func setProductTemplate(service product_template.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error setting product template"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		productID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse product id"))
+			return
+		}
+
+		var input presenter.ProductTemplate
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		t, err := entity.NewProductTemplate(tenantID, productID, input.StartTime, input.EndTime, input.Notes)
+		if err == nil {
+			err = service.SetProductTemplate(t)
+		}
+		switch err {
+		case nil:
+		case glad.ErrInvalidEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Product doesn't exist"))
+			return
+		default:
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		response := &presenter.ProductTemplate{}
+		response.FromProductTemplateEntity(t)
+		writeJSON(w, tenantID, http.StatusOK, response)
+	})
+}
+
+// createCourseFromProduct creates a course with the defaults of the product
+func createCourseFromProduct(service product_template.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error adding course"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		productID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to parse product id"))
+			return
+		}
+
+		var input presenter.CourseFromProductReq
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		courseID, courseTimingsID, err := service.CreateCourse(
+			auditActor(r, entity.AuditSourceAPI),
+			input.ToEntity(tenantID, productID),
+		)
+		if writeCourseConflicts(w, err) {
+			return
+		}
+		switch err {
+		case nil:
+		case glad.ErrNotEligible, glad.ErrInvalidEntity, glad.ErrInvalidValue, glad.ErrMissingParam:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Product doesn't exist"))
+			return
+		default:
+			l.Log.Errorf(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		writeJSON(w, tenantID, http.StatusCreated, &presenter.CourseResponse{
+			ID:         courseID,
+			DateTimeID: courseTimingsID,
+		})
+	})
+}
+
+// MakeProductTemplateHandlers make url handlers
+func MakeProductTemplateHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service product_template.UseCase,
+) {
+	r.Handle("/v1/products/{id}/template", n.With(
+		authz.Require(policy.ProductRead),
+		negroni.Wrap(getProductTemplate(service)),
+	)).Methods("GET", "OPTIONS").Name("getProductTemplate")
+
+	r.Handle("/v1/products/{id}/template", n.With(
+		authz.Require(policy.ProductWrite),
+		negroni.Wrap(setProductTemplate(service)),
+	)).Methods("PUT", "OPTIONS").Name("setProductTemplate")
+
+	r.Handle("/v1/products/{id}/courses", n.With(
+		authz.Require(policy.CourseCreate),
+		negroni.Wrap(createCourseFromProduct(service)),
+	)).Methods("POST", "OPTIONS").Name("createCourseFromProduct")
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/coursed/presenter"
+
+	mock "ac9/glad/usecase/product_template/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	templateProductID id.ID = 13790493495087077001
+	templateCenterID  id.ID = 13790493495087077002
+	templateCourseID  id.ID = 13790493495087077003
+)
+
+func Test_productTemplate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeProductTemplateHandlers(r, *n, testAuthorizer(), service)
+	for name, want := range map[string]string{
+		"getProductTemplate":      "/v1/products/{id}/template",
+		"setProductTemplate":      "/v1/products/{id}/template",
+		"createCourseFromProduct": "/v1/products/{id}/courses",
+	} {
+		path, err := r.GetRoute(name).GetPathTemplate()
+		assert.Nil(t, err)
+		assert.Equal(t, want, path)
+	}
+
+	productPath := "/v1/products/" + templateProductID.String()
+	do := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("template", func(t *testing.T) {
+		service.EXPECT().SetProductTemplate(gomock.Any()).
+			DoAndReturn(func(e *entity.ProductTemplate) error {
+				assert.Equal(t, tenantAlice, e.TenantID)
+				assert.Equal(t, templateProductID, e.ProductID)
+				assert.Equal(t, "09:30:00", e.StartTime)
+				return nil
+			})
+		rr := do(http.MethodPut, productPath+"/template", presenter.ProductTemplate{
+			StartTime: "09:30",
+			EndTime:   "12:00",
+			Notes:     "Bring a mat",
+		})
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		// session ends before it starts
+		rr = do(http.MethodPut, productPath+"/template", presenter.ProductTemplate{
+			StartTime: "12:00",
+			EndTime:   "09:30",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		service.EXPECT().GetProductTemplate(tenantAlice, templateProductID).
+			Return(&entity.ProductTemplate{StartTime: "09:30:00", EndTime: "12:00:00"}, nil)
+		rr = do(http.MethodGet, productPath+"/template", nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d presenter.ProductTemplate
+		_ = json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, "12:00:00", d.EndTime)
+
+		service.EXPECT().GetProductTemplate(tenantAlice, templateProductID).Return(nil, glad.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, do(http.MethodGet, productPath+"/template", nil).Code)
+	})
+
+	t.Run("create course", func(t *testing.T) {
+		actor := entity.AuditActor{AccountID: coordinatorCaller.ID, Source: entity.AuditSourceAPI}
+		service.EXPECT().CreateCourse(actor, &entity.CourseFromProduct{
+			TenantID:  tenantAlice,
+			ProductID: templateProductID,
+			CenterID:  templateCenterID,
+			StartDate: "2024-05-14",
+			Teachers:  []*entity.CourseTeacher{{ID: accountIDPrimary, IsPrimary: true}},
+		}).Return(templateCourseID, []id.ID{id.New(), id.New()}, nil)
+
+		req := presenter.CourseFromProductReq{
+			CenterID:  templateCenterID,
+			StartDate: "2024-05-14",
+			Teacher:   []presenter.CourseTeacher{{ID: accountIDPrimary, IsPrimary: true}},
+		}
+		rr := do(http.MethodPost, productPath+"/courses", req)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var d presenter.CourseResponse
+		_ = json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, templateCourseID, d.ID)
+		assert.Equal(t, 2, len(d.DateTimeID))
+
+		service.EXPECT().CreateCourse(actor, gomock.Any()).
+			Return(id.ID(id.IDInvalid), nil, glad.ErrMissingParam)
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, productPath+"/courses", req).Code)
+
+		service.EXPECT().CreateCourse(actor, gomock.Any()).
+			Return(id.ID(id.IDInvalid), nil, glad.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, do(http.MethodPost, productPath+"/courses", req).Code)
+	})
+}
@@ -24,7 +24,6 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
-	"github.com/urfave/negroni"
 )
 
 // TODO: Add test cases to test page and limit functionality
@@ -33,8 +32,8 @@ func Test_listProducts(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeProductHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeProductHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("listProducts").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/products", path)
@@ -127,8 +126,8 @@ func Test_createProduct(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeProductHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeProductHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("createProduct").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/products", path)
@@ -146,6 +145,7 @@ func Test_createProduct(t *testing.T) {
 			gomock.Any(),
 			gomock.Any(),
 			gomock.Any(),
+			gomock.Any(),
 		).
 		Return(productID, nil)
 	h := createProduct(service)
@@ -199,8 +199,8 @@ func Test_getProduct(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeProductHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeProductHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("getProduct").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/products/{id}", path)
@@ -218,13 +218,15 @@ func Test_getProduct(t *testing.T) {
 		Format:       entity.ProductFormatInPerson,
 	}
 	service.EXPECT().
-		GetProduct(product.ID).
+		GetProduct(tenantAlice, product.ID).
 		Return(product, nil)
 	handler := getProduct(service)
 	r.Handle("/v1/products/{id}", handler)
 	ts := httptest.NewServer(r)
 	defer ts.Close()
-	res, err := http.Get(ts.URL + "/v1/products/" + product.ID.String())
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/products/"+product.ID.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 
@@ -247,16 +249,17 @@ func Test_deleteProduct(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeProductHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeProductHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("deleteProduct").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/products/{id}", path)
 
 	id := id.New()
-	service.EXPECT().DeleteProduct(id).Return(nil)
+	service.EXPECT().DeleteProduct(gomock.Any(), tenantAlice, id).Return(nil)
 	handler := deleteProduct(service)
 	req, _ := http.NewRequest("DELETE", "/v1/products/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/products/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -268,16 +271,17 @@ func Test_deleteProductNonExistent(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeProductHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeProductHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("deleteProduct").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/products/{id}", path)
 
 	id := id.New()
-	service.EXPECT().DeleteProduct(id).Return(glad.ErrNotFound)
+	service.EXPECT().DeleteProduct(gomock.Any(), tenantAlice, id).Return(glad.ErrNotFound)
 	handler := deleteProduct(service)
 	req, _ := http.NewRequest("DELETE", "/v1/products/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/products/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -289,8 +293,8 @@ func Test_updateProduct(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeProductHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeProductHandlers(r, *n, testAuthorizer(), service, nil)
 	path, err := r.GetRoute("updateProduct").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/products/{id}", path)
@@ -310,7 +314,7 @@ func Test_updateProduct(t *testing.T) {
 	}
 
 	service.EXPECT().
-		UpdateProduct(gomock.Any()).
+		UpdateProduct(gomock.Any(), gomock.Any()).
 		Return(nil)
 
 	handler := updateProduct(service)
@@ -336,8 +340,8 @@ func Test_updateProduct_BadRequest(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeProductHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeProductHandlers(r, *n, testAuthorizer(), service, nil)
 
 	id := id.New()
 	handler := updateProduct(service)
@@ -361,8 +365,8 @@ func Test_updateProduct_MissingTenant(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeProductHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeProductHandlers(r, *n, testAuthorizer(), service, nil)
 
 	id := id.New()
 	updatePayload := &entity.Product{
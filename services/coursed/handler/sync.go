@@ -0,0 +1,64 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/coursed/presenter"
+
+	"github.com/gorilla/mux"
+)
+
+// byExtIDFunc archives or restores the record linked to the Salesforce id
+type byExtIDFunc func(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error)
+
+// syncByExtID applies the Salesforce delete or undelete of a record
+// The record is referred to by its Salesforce id, the extID path variable.
+func syncByExtID(op byExtIDFunc, errorMessage string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		extID := mux.Vars(r)["extID"]
+		recordID, err := op(auditActor(r, entity.AuditSourceSalesforce), tenantID, extID)
+		switch err {
+		case nil:
+		case glad.ErrInvalidValue:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		default:
+			l.Log.Errorf("extID=%v, err=%v", extID, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		if err := json.NewEncoder(w).Encode(&presenter.ImportResponse{
+			ID:    recordID,
+			ExtID: extID,
+		}); err != nil {
+			l.Log.Warnf("%v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+		}
+	})
+}
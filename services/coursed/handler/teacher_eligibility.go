@@ -0,0 +1,393 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
+	"ac9/glad/services/coursed/presenter"
+	"ac9/glad/usecase/account"
+	"ac9/glad/usecase/center"
+	"ac9/glad/usecase/product"
+	"ac9/glad/usecase/teacher_eligibility"
+
+	"github.com/gorilla/mux"
+	"github.com/urfave/negroni"
+)
+
+// Eligibility query parameters
+const (
+	eligibilityParamProductID = "productId"
+	eligibilityParamTeacherID = "teacherId"
+	eligibilityParamCenterID  = "centerId"
+)
+
+// idFromQuery parses the optional id query parameter; id.IDInvalid when absent
+func idFromQuery(r *http.Request, param string) (id.ID, error) {
+	v := r.URL.Query().Get(param)
+	if v == "" {
+		return id.IDInvalid, nil
+	}
+	return id.FromString(v)
+}
+
+// listTeacherEligibility lists the teachers eligible for the product or, without
+// a product, the products the teacher is eligible for
+func listTeacherEligibility(service teacher_eligibility.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading teacher eligibility"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		productID, err := idFromQuery(r, eligibilityParamProductID)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		teacherID, err := idFromQuery(r, eligibilityParamTeacherID)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		page, limit, err := common.HttpGetPageParams(w, r)
+		if err != nil {
+			return
+		}
+
+		var list []*entity.TeacherEligibility
+		switch {
+		case productID != id.IDInvalid:
+			list, err = service.ListEligibleTeachers(tenantID, productID, page, limit)
+		case teacherID != id.IDInvalid:
+			list, err = service.ListTeacherEligibility(tenantID, teacherID)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Missing " + eligibilityParamProductID + " or " + eligibilityParamTeacherID))
+			return
+		}
+		if err != nil {
+			l.Log.Warnf("%v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		responses := []*presenter.TeacherEligibility{}
+		for _, e := range list {
+			resp := &presenter.TeacherEligibility{}
+			resp.FromEntityTeacherEligibility(e)
+			responses = append(responses, resp)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		if err := json.NewEncoder(w).Encode(responses); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode teacher eligibility"))
+		}
+	})
+}
+
+// getNearbyTeachers lists the teachers eligible for the product who taught at
+// centers near the given center, nearest first
+func getNearbyTeachers(service teacher_eligibility.UseCase, centerService center.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading nearby teachers"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		productID, err := idFromQuery(r, eligibilityParamProductID)
+		if err == nil && productID == id.IDInvalid {
+			err = glad.ErrInvalidValue
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid " + eligibilityParamProductID))
+			return
+		}
+		centerID, err := idFromQuery(r, eligibilityParamCenterID)
+		if err == nil && centerID == id.IDInvalid {
+			err = glad.ErrInvalidValue
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid " + eligibilityParamCenterID))
+			return
+		}
+
+		q := &entity.GeoQuery{}
+		if v := r.URL.Query().Get(geoParamRadius); v != "" {
+			q.RadiusKm, err = strconv.ParseFloat(v, 64)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Invalid " + geoParamRadius))
+				return
+			}
+		}
+
+		page, limit, err := common.HttpGetPageParams(w, r)
+		if err != nil {
+			return
+		}
+
+		c, err := centerService.GetCenter(tenantID, centerID)
+		if err == glad.ErrNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Center doesn't exist"))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+		q.Lat = c.GeoLocation.Lat
+		q.Long = c.GeoLocation.Long
+
+		teachers, err := service.GetNearbyTeachers(tenantID, productID, q, page, limit)
+		if err == glad.ErrInvalidValue {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid center location or radius"))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		responses := []*presenter.EligibleTeacher{}
+		for _, teacher := range teachers {
+			resp := &presenter.EligibleTeacher{}
+			resp.FromEntityEligibleTeacher(teacher)
+			responses = append(responses, resp)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		if err := json.NewEncoder(w).Encode(responses); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("Unable to encode teachers"))
+		}
+	})
+}
+
+// setTeacherEligibility sets the eligibility of the teacher for the product
+func setTeacherEligibility(service teacher_eligibility.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error setting teacher eligibility"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		var input presenter.TeacherEligibilityReq
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			l.Log.Warnf("Unable to decode object. err=%v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		err = service.SetEligibility(auditActor(r, entity.AuditSourceAPI),
+			tenantID, input.ProductID, input.TeacherID, input.Type)
+		switch err {
+		case nil:
+		case glad.ErrInvalidEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Product or teacher doesn't exist"))
+			return
+		default:
+			l.Log.Warnf("%v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// removeTeacherEligibility removes the eligibility of the teacher for the product
+func removeTeacherEligibility(service teacher_eligibility.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error removing teacher eligibility"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		vars := mux.Vars(r)
+		productID, err := id.FromString(vars["productId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		teacherID, err := id.FromString(vars["teacherId"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+
+		err = service.RemoveEligibility(auditActor(r, entity.AuditSourceAPI), tenantID, productID, teacherID)
+		switch err {
+		case nil:
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Teacher eligibility doesn't exist"))
+			return
+		default:
+			l.Log.Warnf("%v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
+			return
+		}
+
+		w.Header().Set(common.HttpHeaderTenantID, tenantID.String())
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// importTeacherEligibility upserts the teacher eligibility imported from
+// Salesforce; the product and the teacher are referred to by ext id
+func importTeacherEligibility(service teacher_eligibility.UseCase,
+	productService product.UseCase,
+	accountService account.UseCase,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error importing teacher eligibility"
+		tenantID, err := common.HttpGetTenantID(w, r)
+		if err != nil {
+			return
+		}
+
+		var gEligibility []glad.TeacherEligibility
+		err = json.NewDecoder(r.Body).Decode(&gEligibility)
+		if err != nil {
+			l.Log.Warnf("Unable to decode object. err = %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		actor := auditActor(r, entity.AuditSourceSalesforce)
+		var response []*presenter.ImportResponse
+		for _, ge := range gEligibility {
+			teacherID, err := upsertTeacherEligibility(service, productService, accountService,
+				actor, tenantID, &ge)
+			if err != nil {
+				l.Log.Warnf("Unable to upsert teacher eligibility extID=%v, err=%v", ge.ExtID, err)
+			}
+
+			response = append(response, &presenter.ImportResponse{
+				ID:      teacherID,
+				ExtID:   ge.ExtID,
+				IsError: err != nil,
+			})
+		}
+
+		writeImportResponse(w, tenantID, response, errorMessage)
+	})
+}
+
+// upsertTeacherEligibility resolves the product and the teacher of the
+// imported eligibility and upserts it; returns the teacher id
+func upsertTeacherEligibility(service teacher_eligibility.UseCase,
+	productService product.UseCase,
+	accountService account.UseCase,
+	actor entity.AuditActor,
+	tenantID id.ID,
+	ge *glad.TeacherEligibility,
+) (id.ID, error) {
+	productID, err := productService.GetIDByExtID(tenantID, ge.ProductExtID)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	a, err := accountService.GetAccountByExtID(tenantID, ge.TeacherExtID)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	extID := ge.ExtID
+	err = service.UpsertEligibility(actor, tenantID, &entity.TeacherEligibility{
+		ProductID: productID,
+		TeacherID: a.ID,
+		ExtID:     &extID,
+		Type:      entity.EligibilityType(ge.Type),
+		CreatedAt: ge.CreatedAt,
+		UpdatedAt: ge.UpdatedAt,
+	})
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	return a.ID, nil
+}
+
+// MakeTeacherEligibilityHandlers make url handlers
+func MakeTeacherEligibilityHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service teacher_eligibility.UseCase,
+	productService product.UseCase,
+	accountService account.UseCase,
+	centerService center.UseCase,
+) {
+	r.Handle("/v1/eligibility", n.With(
+		authz.Require(policy.EligibilityRead),
+		negroni.Wrap(listTeacherEligibility(service)),
+	)).Methods("GET", "OPTIONS").Name("listTeacherEligibility")
+
+	r.Handle("/v1/eligibility", n.With(
+		authz.Require(policy.EligibilityWrite),
+		negroni.Wrap(setTeacherEligibility(service)),
+	)).Methods("PUT", "OPTIONS").Name("setTeacherEligibility")
+
+	r.Handle("/v1/eligibility/nearby", n.With(
+		authz.Require(policy.EligibilityRead),
+		negroni.Wrap(getNearbyTeachers(service, centerService)),
+	)).Methods("GET", "OPTIONS").Name("getNearbyTeachers")
+
+	r.Handle("/v1/eligibility/import", n.With(
+		authz.Require(policy.EligibilityImport),
+		negroni.Wrap(importTeacherEligibility(service, productService, accountService)),
+	)).Methods("POST", "OPTIONS").Name("importTeacherEligibility")
+
+	r.Handle("/v1/eligibility/import/{extID}", n.With(
+		authz.Require(policy.EligibilityImport),
+		negroni.Wrap(syncByExtID(service.RemoveEligibilityByExtID, "Error removing teacher eligibility")),
+	)).Methods("DELETE", "OPTIONS").Name("removeTeacherEligibilityByExtID")
+
+	r.Handle("/v1/eligibility/{productId}/{teacherId}", n.With(
+		authz.Require(policy.EligibilityWrite),
+		negroni.Wrap(removeTeacherEligibility(service)),
+	)).Methods("DELETE", "OPTIONS").Name("removeTeacherEligibility")
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/coursed/presenter"
+
+	amock "ac9/glad/usecase/account/mock"
+	cmock "ac9/glad/usecase/center/mock"
+	pmock "ac9/glad/usecase/product/mock"
+	emock "ac9/glad/usecase/teacher_eligibility/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// newEligibilityServer serves the eligibility handlers for the coordinator
+func newEligibilityServer(controller *gomock.Controller,
+) (*httptest.Server, *mux.Router, *emock.MockUseCase, *pmock.MockUseCase, *amock.MockUseCase, *cmock.MockUseCase) {
+	svc := emock.NewMockUseCase(controller)
+	psvc := pmock.NewMockUseCase(controller)
+	asvc := amock.NewMockUseCase(controller)
+	csvc := cmock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	n := newTestNegroni(coordinatorCaller)
+	MakeTeacherEligibilityHandlers(r, *n, testAuthorizer(), svc, psvc, asvc, csvc)
+	return httptest.NewServer(r), r, svc, psvc, asvc, csvc
+}
+
+func Test_listTeacherEligibility(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	ts, r, svc, _, _, _ := newEligibilityServer(controller)
+	defer ts.Close()
+	path, err := r.GetRoute("listTeacherEligibility").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/eligibility", path)
+
+	productID := id.New()
+	teacherID := id.New()
+	e := &entity.TeacherEligibility{ProductID: productID, TeacherID: teacherID, Type: entity.EligibilityPrimary}
+	svc.EXPECT().ListEligibleTeachers(tenantAlice, productID, gomock.Any(), gomock.Any()).
+		Return([]*entity.TeacherEligibility{e}, nil)
+	svc.EXPECT().ListTeacherEligibility(tenantAlice, teacherID).
+		Return([]*entity.TeacherEligibility{e}, nil)
+
+	get := func(query string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/eligibility"+query, nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	res := get("?productId=" + productID.String())
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var d []*presenter.TeacherEligibility
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, teacherID, d[0].TeacherID)
+	assert.Equal(t, entity.EligibilityPrimary, d[0].Type)
+
+	res = get("?teacherId=" + teacherID.String())
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	assert.Equal(t, http.StatusBadRequest, get("").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, get("?productId=abc").StatusCode)
+}
+
+func Test_getNearbyTeachers(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	ts, r, svc, _, _, csvc := newEligibilityServer(controller)
+	defer ts.Close()
+	path, err := r.GetRoute("getNearbyTeachers").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/eligibility/nearby", path)
+
+	productID := id.New()
+	centerID := id.New()
+	teacherID := id.New()
+	csvc.EXPECT().GetCenter(tenantAlice, centerID).Return(&entity.Center{
+		ID:          centerID,
+		GeoLocation: entity.CenterGeoLocation{Lat: 12.97, Long: 77.59},
+	}, nil)
+	svc.EXPECT().
+		GetNearbyTeachers(tenantAlice, productID,
+			&entity.GeoQuery{Lat: 12.97, Long: 77.59, RadiusKm: 25}, gomock.Any(), gomock.Any()).
+		Return([]*entity.EligibleTeacher{{
+			TeacherEligibility: &entity.TeacherEligibility{
+				ProductID: productID,
+				TeacherID: teacherID,
+				Type:      entity.EligibilityAssistant,
+			},
+			DistanceKm: 3.5,
+		}}, nil)
+
+	get := func(query string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/eligibility/nearby"+query, nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	res := get("?productId=" + productID.String() + "&centerId=" + centerID.String() + "&radius=25")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var d []*presenter.EligibleTeacher
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, teacherID, d[0].TeacherID)
+	assert.Equal(t, 3.5, d[0].DistanceKm)
+
+	assert.Equal(t, http.StatusBadRequest, get("?productId="+productID.String()).StatusCode)
+	assert.Equal(t, http.StatusBadRequest,
+		get("?productId="+productID.String()+"&centerId="+centerID.String()+"&radius=x").StatusCode)
+}
+
+func Test_setAndRemoveTeacherEligibility(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	ts, r, svc, _, _, _ := newEligibilityServer(controller)
+	defer ts.Close()
+	path, err := r.GetRoute("removeTeacherEligibility").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/eligibility/{productId}/{teacherId}", path)
+
+	productID := id.New()
+	teacherID := id.New()
+	svc.EXPECT().
+		SetEligibility(gomock.Any(), tenantAlice, productID, teacherID, entity.EligibilityPrimary).
+		Return(nil)
+	svc.EXPECT().
+		SetEligibility(gomock.Any(), tenantAlice, productID, teacherID, entity.EligibilityType("lead")).
+		Return(glad.ErrInvalidEntity)
+	svc.EXPECT().RemoveEligibility(gomock.Any(), tenantAlice, productID, teacherID).Return(nil)
+	svc.EXPECT().RemoveEligibility(gomock.Any(), tenantAlice, productID, teacherID).Return(glad.ErrNotFound)
+
+	put := func(et entity.EligibilityType) *http.Response {
+		payload, _ := json.Marshal(&presenter.TeacherEligibilityReq{
+			ProductID: productID,
+			TeacherID: teacherID,
+			Type:      et,
+		})
+		req, _ := http.NewRequest(http.MethodPut, ts.URL+"/v1/eligibility", bytes.NewReader(payload))
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+	assert.Equal(t, http.StatusNoContent, put(entity.EligibilityPrimary).StatusCode)
+	assert.Equal(t, http.StatusBadRequest, put("lead").StatusCode)
+
+	remove := func() *http.Response {
+		req, _ := http.NewRequest(http.MethodDelete,
+			ts.URL+"/v1/eligibility/"+productID.String()+"/"+teacherID.String(), nil)
+		req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+	assert.Equal(t, http.StatusNoContent, remove().StatusCode)
+	assert.Equal(t, http.StatusNotFound, remove().StatusCode)
+}
+
+func Test_importTeacherEligibility(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	ts, r, svc, psvc, asvc, _ := newEligibilityServer(controller)
+	defer ts.Close()
+	path, err := r.GetRoute("importTeacherEligibility").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/eligibility/import", path)
+
+	productID := id.New()
+	teacherID := id.New()
+	extID := "a0E000000000001"
+	psvc.EXPECT().GetIDByExtID(tenantAlice, "a0T000000000001").Return(productID, nil)
+	psvc.EXPECT().GetIDByExtID(tenantAlice, "a0T000000000002").Return(id.ID(id.IDInvalid), glad.ErrNotFound)
+	asvc.EXPECT().GetAccountByExtID(tenantAlice, "003000000000001").
+		Return(&entity.Account{ID: teacherID}, nil)
+	svc.EXPECT().UpsertEligibility(gomock.Any(), tenantAlice, &entity.TeacherEligibility{
+		ProductID: productID,
+		TeacherID: teacherID,
+		ExtID:     &extID,
+		Type:      entity.EligibilityAssistant,
+	}).Return(nil)
+
+	payload, _ := json.Marshal([]glad.TeacherEligibility{
+		{
+			ExtID:        extID,
+			ProductExtID: "a0T000000000001",
+			TeacherExtID: "003000000000001",
+			Type:         "assistant",
+		},
+		{
+			ExtID:        "a0E000000000002",
+			ProductExtID: "a0T000000000002",
+			TeacherExtID: "003000000000001",
+			Type:         "primary",
+		},
+	})
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v1/eligibility/import", bytes.NewReader(payload))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d []*presenter.ImportResponse
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 2, len(d))
+	assert.Equal(t, teacherID, d[0].ID)
+	assert.False(t, d[0].IsError)
+	assert.True(t, d[1].IsError)
+}
+
+func Test_removeTeacherEligibilityByExtID(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	ts, r, svc, _, _, _ := newEligibilityServer(controller)
+	defer ts.Close()
+	path, err := r.GetRoute("removeTeacherEligibilityByExtID").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/eligibility/import/{extID}", path)
+
+	teacherID := id.New()
+	svc.EXPECT().RemoveEligibilityByExtID(gomock.Any(), tenantAlice, "a0E000000000001").Return(teacherID, nil)
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL+"/v1/eligibility/import/a0E000000000001", nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d presenter.ImportResponse
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, teacherID, d.ID)
+}
@@ -15,6 +15,8 @@ import (
 	"ac9/glad/pkg/common"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
 	"ac9/glad/usecase/tenant"
 
 	"ac9/glad/services/coursed/presenter"
@@ -86,7 +88,7 @@ func createTenant(service tenant.UseCase) http.Handler {
 			return
 		}
 
-		tenantID, err := service.CreateTenant(input.Name, input.Country)
+		tenantID, err := service.CreateTenant(auditActor(r, entity.AuditSourceAPI), input.Name, input.Country)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -155,7 +157,7 @@ func deleteTenant(service tenant.UseCase) http.Handler {
 			_, _ = w.Write([]byte(errorMessage))
 			return
 		}
-		err = service.DeleteTenant(tenantID)
+		err = service.DeleteTenant(auditActor(r, entity.AuditSourceAPI), tenantID)
 		switch err {
 		case nil:
 			w.WriteHeader(http.StatusOK)
@@ -237,7 +239,7 @@ func updateTenant(service tenant.UseCase) http.Handler {
 			return
 		}
 
-		err = service.UpdateTenant(&input)
+		err = service.UpdateTenant(auditActor(r, entity.AuditSourceAPI), &input)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte(errorMessage + ":" + err.Error()))
@@ -263,28 +265,44 @@ func updateTenant(service tenant.UseCase) http.Handler {
 }
 
 // MakeTenantHandlers make tenant handlers
-func MakeTenantHandlers(r *mux.Router, n negroni.Negroni, service tenant.UseCase) {
+func MakeTenantHandlers(r *mux.Router,
+	n negroni.Negroni,
+	authz *middleware.Authorizer,
+	service tenant.UseCase,
+) {
 	r.Handle("/v1/tenants", n.With(
+		authz.Require(policy.TenantRead),
 		negroni.Wrap(listTenants(service)),
 	)).Methods("GET", "OPTIONS").Name("listTenants")
 
 	r.Handle("/v1/tenants", n.With(
+		authz.Require(policy.TenantWrite),
 		negroni.Wrap(createTenant(service)),
 	)).Methods("POST", "OPTIONS").Name("createTenant")
 
 	r.Handle("/v1/tenants/{id}", n.With(
+		authz.Require(policy.TenantRead),
 		negroni.Wrap(getTenant(service)),
 	)).Methods("GET", "OPTIONS").Name("getTenant")
 
 	r.Handle("/v1/tenants/{id}", n.With(
+		authz.Require(policy.TenantWrite),
 		negroni.Wrap(deleteTenant(service)),
 	)).Methods("DELETE", "OPTIONS").Name("deleteTenant")
 
-	r.Handle("/v1/login", n.With(
-		negroni.Wrap(login(service)),
-	)).Methods("POST", "OPTIONS").Name("login")
-
 	r.Handle("/v1/tenants/{id}", n.With(
+		authz.Require(policy.TenantWrite),
 		negroni.Wrap(updateTenant(service)),
 	)).Methods("PUT", "OPTIONS").Name("updateTenant")
 }
+
+// MakeLoginHandlers make the login handler; the caller has no token yet, n is
+// not expected to authenticate the requests
+func MakeLoginHandlers(r *mux.Router,
+	n negroni.Negroni,
+	service tenant.UseCase,
+) {
+	r.Handle("/v1/login", n.With(
+		negroni.Wrap(login(service)),
+	)).Methods("POST", "OPTIONS").Name("login")
+}
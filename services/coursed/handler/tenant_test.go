@@ -17,6 +17,8 @@ import (
 	"ac9/glad/pkg/common"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
 	"ac9/glad/services/coursed/presenter"
 
 	mock "ac9/glad/usecase/tenant/mock"
@@ -37,8 +39,8 @@ func Test_listTenants(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeTenantHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeTenantHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("listTenants").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/tenants", path)
@@ -65,8 +67,8 @@ func Test_createTenant(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeTenantHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeTenantHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("createTenant").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/tenants", path)
@@ -74,6 +76,7 @@ func Test_createTenant(t *testing.T) {
 	id := id.New()
 	service.EXPECT().
 		CreateTenant(gomock.Any(),
+			gomock.Any(),
 			gomock.Any()).
 		Return(id, nil)
 	h := createTenant(service)
@@ -110,8 +113,8 @@ func Test_getTenant(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeTenantHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeTenantHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("getTenant").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/tenants/{id}", path)
@@ -128,7 +131,9 @@ func Test_getTenant(t *testing.T) {
 	r.Handle("/v1/tenants/{id}", handler)
 	ts := httptest.NewServer(r)
 	defer ts.Close()
-	res, err := http.Get(ts.URL + "/v1/tenants/" + tenant.ID.String())
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/tenants/"+tenant.ID.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 
@@ -148,37 +153,64 @@ func Test_deleteTenant(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeTenantHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeTenantHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("deleteTenant").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/tenants/{id}", path)
 
 	id := id.New()
-	service.EXPECT().DeleteTenant(id).Return(nil)
+	service.EXPECT().DeleteTenant(gomock.Any(), id).Return(nil)
 	handler := deleteTenant(service)
 	req, _ := http.NewRequest("DELETE", "/v1/tenants/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/tenants/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
 
+func Test_deleteTenant_Forbidden(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	service := mock.NewMockUseCase(controller)
+	r := mux.NewRouter()
+	student := &entity.Account{
+		ID:       accountIDPrimary,
+		TenantID: tenantAlice,
+		Type:     entity.AccountStudent,
+	}
+	n := newTestNegroni(student)
+	MakeTenantHandlers(r, *n, testAuthorizer(), service)
+
+	req, _ := http.NewRequest("DELETE", "/v1/tenants/"+tenantAlice.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	var d middleware.ForbiddenResponse
+	_ = json.NewDecoder(rr.Body).Decode(&d)
+	assert.Equal(t, policy.TenantWrite, d.Action)
+	assert.Equal(t, student.ID, d.AccountID)
+}
+
 func Test_deleteTenantNonExistent(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeTenantHandlers(r, *n, service)
+	n := newTestNegroni(coordinatorCaller)
+	MakeTenantHandlers(r, *n, testAuthorizer(), service)
 	path, err := r.GetRoute("deleteTenant").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/tenants/{id}", path)
 
 	id := id.New()
-	service.EXPECT().DeleteTenant(id).Return(glad.ErrNotFound)
+	service.EXPECT().DeleteTenant(gomock.Any(), id).Return(glad.ErrNotFound)
 	handler := deleteTenant(service)
 	req, _ := http.NewRequest("DELETE", "/v1/tenants/"+id.String(), nil)
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
 	r.Handle("/v1/tenants/{id}", handler).Methods("DELETE", "OPTIONS")
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -190,8 +222,8 @@ func Test_login(t *testing.T) {
 	defer controller.Finish()
 	service := mock.NewMockUseCase(controller)
 	r := mux.NewRouter()
-	n := negroni.New()
-	MakeTenantHandlers(r, *n, service)
+	// no caller, login is not authenticated
+	MakeLoginHandlers(r, *negroni.New(), service)
 	path, err := r.GetRoute("login").GetPathTemplate()
 	assert.Nil(t, err)
 	assert.Equal(t, "/v1/login", path)
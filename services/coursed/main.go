@@ -7,6 +7,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -15,15 +16,25 @@ import (
 	"strconv"
 	"time"
 
+	// course timezones are resolved in images without a zoneinfo database
+	_ "time/tzdata"
+
 	"ac9/glad/repository"
 
 	// Uber zap logging
 	"ac9/glad/pkg/logger"
 
 	"ac9/glad/usecase/account"
+	"ac9/glad/usecase/attendance"
+	"ac9/glad/usecase/audit"
 	"ac9/glad/usecase/center"
 	"ac9/glad/usecase/course"
+	"ac9/glad/usecase/dead_letter"
+	"ac9/glad/usecase/event"
+	"ac9/glad/usecase/participant"
 	"ac9/glad/usecase/product"
+	"ac9/glad/usecase/product_template"
+	"ac9/glad/usecase/teacher_eligibility"
 	"ac9/glad/usecase/tenant"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -31,11 +42,12 @@ import (
 	"ac9/glad/config"
 	"ac9/glad/pkg/metric"
 	"ac9/glad/pkg/middleware"
+	"ac9/glad/pkg/policy"
 	"ac9/glad/pkg/util"
 	"ac9/glad/services/coursed/handler"
 
 	_ "github.com/go-sql-driver/mysql"
-	"github.com/gorilla/context"
+	gorillaContext "github.com/gorilla/context"
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
 	"github.com/urfave/negroni"
@@ -67,21 +79,65 @@ func main() {
 	}
 	defer db.Close()
 
+	auditRepo := repository.NewAuditPGSQL(db)
+	auditService := audit.NewService(auditRepo)
+
 	accountRepo := repository.NewAccountPGSQL(db)
-	accountService := account.NewService(accountRepo)
+	accountService := account.NewService(accountRepo, auditService)
 
 	centerRepo := repository.NewCenterPGSQL(db)
-	centerService := center.NewService(centerRepo)
+	centerService := center.NewService(centerRepo, auditService)
 
 	courseRepo := repository.NewCoursePGSQL(db)
 	courseTimingRepo := repository.NewCourseTimingPGSQL(db)
-	courseService := course.NewService(courseRepo, courseTimingRepo)
+	courseUnitOfWork := repository.NewCourseUnitOfWorkPGSQL(db)
+	courseService := course.NewService(courseRepo, courseTimingRepo, courseUnitOfWork, auditService)
+
+	eventRepo := repository.NewEventPGSQL(db)
+	eventService := event.NewService(eventRepo)
+
+	participantRepo := repository.NewParticipantPGSQL(db)
+	participantService := participant.NewService(participantRepo, eventService,
+		time.Duration(util.GetIntEnvOrConfig("WAITLIST_HOLD_HOURS", config.WAITLIST_HOLD_HOURS))*time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = participantService.Start(ctx,
+		time.Duration(util.GetIntEnvOrConfig("WAITLIST_SWEEP_SEC", config.WAITLIST_SWEEP_SEC))*time.Second)
+	if err != nil {
+		Log.Fatalf("Unable to start waitlist: %v", err.Error())
+	}
+
+	attendanceRepo := repository.NewAttendancePGSQL(db)
+	attendanceService := attendance.NewService(attendanceRepo,
+		time.Duration(util.GetIntEnvOrConfig("ATTENDANCE_CODE_TTL_MIN", config.ATTENDANCE_CODE_TTL_MIN))*time.Minute)
 
 	productRepo := repository.NewProductPGSQL(db)
-	productService := product.NewService(productRepo)
+	productService := product.NewService(productRepo, auditService)
+
+	productTemplateRepo := repository.NewProductTemplatePGSQL(db)
+	productTemplateService := product_template.NewService(productTemplateRepo, courseService,
+		productService,
+		centerService,
+	)
+
+	eligibilityRepo := repository.NewTeacherEligibilityPGSQL(db)
+	eligibilityService := teacher_eligibility.NewService(eligibilityRepo, auditService)
+
+	deadLetterRepo := repository.NewDeadLetterPGSQL(db)
+	deadLetterService := dead_letter.NewService(deadLetterRepo)
+
+	courseImporter := handler.NewCourseImporter(courseService, productService, centerService,
+		deadLetterService)
 
 	tenantRepo := repository.NewTenantPGSQL(db)
-	tenantService := tenant.NewService(tenantRepo)
+	tenantService := tenant.NewService(tenantRepo, auditService)
+
+	authenticate, err := middleware.Authentication(accountService)
+	if err != nil {
+		Log.Fatalf("Unable to initialize authentication: %v", err.Error())
+	}
+
+	authz := middleware.NewAuthorizer(policy.Default(), accountService, courseService)
 
 	metricService, err := metric.NewPrometheusService()
 	if err != nil {
@@ -93,38 +149,60 @@ func main() {
 		negroni.HandlerFunc(middleware.Metrics(metricService)),
 		negroni.HandlerFunc(middleware.Cors),
 		negroni.HandlerFunc(middleware.AddDefaultTenant),
-		negroni.HandlerFunc(middleware.AddDefaultAccount),
 		negroni.NewLogger(),
 	)
 	n.Use(&middleware.APILogging{Log: Log})
+	// Note: the routes are authenticated but for the login, health checks and
+	// the token-authenticated calendar feeds
+	authn := n.With(authenticate)
 
 	// log handler
-	logger.MakeLogHandlers(r, *n, "coursed", Log)
+	logger.MakeLogHandlers(r, *authn, "coursed", Log)
 
 	// info handler
-	util.MakeInfoHandlers(r, *n, "coursed")
+	util.MakeInfoHandlers(r, *authn, "coursed")
 
 	// account
-	handler.MakeAccountHandlers(r, *n, accountService)
+	handler.MakeAccountHandlers(r, *authn, authz, accountService)
+
+	// calendar feeds; ahead of the center and course handlers
+	handler.MakeCalendarHandlers(r, *authn, authz, courseService, accountService)
 
 	// center
-	handler.MakeCenterHandlers(r, *n, centerService)
+	handler.MakeCenterHandlers(r, *authn, authz, centerService, courseImporter)
 
 	// course
-	handler.MakeCourseHandlers(r, *n, courseService,
+	handler.MakeCourseHandlers(r, *authn, authz, courseService,
 		accountService,
-		productService,
-		centerService,
+		courseImporter,
 	)
 
 	// participant
-	handler.MakeParticipantHandlers(r, *n, accountService)
+	handler.MakeParticipantHandlers(r, *authn, authz, participantService, accountService, courseService)
+
+	// attendance
+	handler.MakeAttendanceHandlers(r, *authn, authz, attendanceService)
 
 	// product
-	handler.MakeProductHandlers(r, *n, productService)
+	handler.MakeProductHandlers(r, *authn, authz, productService, courseImporter)
+	handler.MakeProductTemplateHandlers(r, *authn, authz, productTemplateService)
+
+	// teacher eligibility
+	handler.MakeTeacherEligibilityHandlers(r, *authn, authz, eligibilityService,
+		productService,
+		accountService,
+		centerService,
+	)
 
 	// tenant
-	handler.MakeTenantHandlers(r, *n, tenantService)
+	handler.MakeTenantHandlers(r, *authn, authz, tenantService)
+	handler.MakeLoginHandlers(r, *n, tenantService)
+
+	// audit
+	handler.MakeAuditHandlers(r, *authn, authz, auditService)
+
+	// dead letter
+	handler.MakeDeadLetterHandlers(r, *authn, authz, deadLetterService, courseImporter)
 
 	http.Handle("/", r)
 	http.Handle("/metrics", promhttp.Handler())
@@ -141,7 +219,7 @@ func main() {
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		Addr:         ":" + strconv.Itoa(util.GetIntEnvOrConfig("API_PORT", config.API_PORT)),
-		Handler:      context.ClearHandler(http.DefaultServeMux),
+		Handler:      gorillaContext.ClearHandler(http.DefaultServeMux),
 		ErrorLog:     logger,
 	}
 	Log.Infof("Starting server at %v ...", srv.Addr)
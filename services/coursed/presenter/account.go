@@ -19,6 +19,7 @@ import (
 // X-GLAD-TenantID
 type Account struct {
 	ID        id.ID              `json:"id"`
+	ExtID     string             `json:"extID,omitempty"`
 	Username  string             `json:"username"`
 	FirstName string             `json:"firstName,omitempty"`
 	LastName  string             `json:"lastName,omitempty"`
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// Attendance of a participant in a session
+type Attendance struct {
+	ID            id.ID                   `json:"id"`
+	TimingID      id.ID                   `json:"timingID"`
+	ParticipantID id.ID                   `json:"participantID"`
+	Status        entity.AttendanceStatus `json:"status"`
+	CheckedInAt   *time.Time              `json:"checkedInAt,omitempty"`
+	RecordedBy    id.ID                   `json:"recordedBy,omitempty"`
+}
+
+// AttendanceReq attendance of a participant marked by the teacher (REST API)
+type AttendanceReq struct {
+	ParticipantID id.ID                   `json:"participantID"`
+	Status        entity.AttendanceStatus `json:"status"`
+	// CheckedInAt defaults to now when present or late
+	CheckedInAt *time.Time `json:"checkedInAt,omitempty"`
+}
+
+// AttendanceMarkReq attendance of the participants in a session (REST API)
+type AttendanceMarkReq struct {
+	Records []AttendanceReq `json:"records"`
+}
+
+// CheckInReq self check-in to a session (REST API)
+type CheckInReq struct {
+	Code string `json:"code"`
+}
+
+// AttendanceCode check-in code of a session
+type AttendanceCode struct {
+	TimingID  id.ID     `json:"timingID"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// SessionAttendance attendance counts of a session
+type SessionAttendance struct {
+	TimingID id.ID    `json:"timingID"`
+	DateTime DateTime `json:"date"`
+	Present  int      `json:"present"`
+	Late     int      `json:"late"`
+	Absent   int      `json:"absent"`
+	Unmarked int      `json:"unmarked"`
+}
+
+// ParticipantAttendance attendance of a participant over the sessions of the course
+type ParticipantAttendance struct {
+	ParticipantID id.ID         `json:"participantID"`
+	AccountID     id.ID         `json:"accountID"`
+	Sessions      int           `json:"sessions"`
+	Attended      int           `json:"attended"`
+	Late          int           `json:"late"`
+	Absent        int           `json:"absent"`
+	Percent       float64       `json:"percent"`
+	IsEligible    bool          `json:"isEligible"`
+	Records       []*Attendance `json:"records,omitempty"`
+}
+
+// CourseAttendance attendance summary of a course
+type CourseAttendance struct {
+	CourseID     id.ID                    `json:"courseID"`
+	MinPercent   int                      `json:"minPercent"`
+	Sessions     []*SessionAttendance     `json:"sessions"`
+	Participants []*ParticipantAttendance `json:"participants"`
+}
+
+// AttendanceRequirement attendance required for completion of a product
+type AttendanceRequirement struct {
+	// MinPercent attendance (percent of the sessions); 0 when not required
+	MinPercent int `json:"minPercent"`
+}
+
+// FromAttendanceEntity creates attendance response from attendance entity
+func (a *Attendance) FromAttendanceEntity(e *entity.Attendance) {
+	a.ID = e.ID
+	a.TimingID = e.TimingID
+	a.ParticipantID = e.ParticipantID
+	a.Status = e.Status
+	a.RecordedBy = e.RecordedBy
+	if !e.CheckedInAt.IsZero() {
+		checkedInAt := e.CheckedInAt
+		a.CheckedInAt = &checkedInAt
+	}
+}
+
+// ToAttendanceEntity creates the attendance to be marked
+func (a *AttendanceReq) ToAttendanceEntity() *entity.Attendance {
+	e := &entity.Attendance{
+		ParticipantID: a.ParticipantID,
+		Status:        a.Status,
+	}
+	if a.CheckedInAt != nil {
+		e.CheckedInAt = *a.CheckedInAt
+	}
+	return e
+}
+
+// FromAttendanceCodeEntity creates check-in code response from its entity
+func (c *AttendanceCode) FromAttendanceCodeEntity(e *entity.AttendanceCode) {
+	c.TimingID = e.TimingID
+	c.Code = e.Code
+	c.ExpiresAt = e.ExpiresAt
+}
+
+// FromParticipantAttendanceEntity creates participant attendance response from its entity
+func (p *ParticipantAttendance) FromParticipantAttendanceEntity(e *entity.ParticipantAttendance) {
+	p.ParticipantID = e.ParticipantID
+	p.AccountID = e.AccountID
+	p.Sessions = e.Sessions
+	p.Attended = e.Attended
+	p.Late = e.Late
+	p.Absent = e.Absent
+	p.Percent = e.Percent
+	p.IsEligible = e.IsEligible
+	for _, r := range e.Records {
+		a := &Attendance{}
+		a.FromAttendanceEntity(r)
+		p.Records = append(p.Records, a)
+	}
+}
+
+// FromCourseAttendanceEntity creates course attendance response from its entity
+func (c *CourseAttendance) FromCourseAttendanceEntity(e *entity.CourseAttendance) {
+	c.CourseID = e.CourseID
+	c.MinPercent = e.MinPercent
+	c.Sessions = []*SessionAttendance{}
+	for _, s := range e.Sessions {
+		sa := &SessionAttendance{
+			TimingID: s.TimingID,
+			Present:  s.Present,
+			Late:     s.Late,
+			Absent:   s.Absent,
+			Unmarked: s.Unmarked,
+		}
+		sa.DateTime.CopyFrom(s.DateTime)
+		c.Sessions = append(c.Sessions, sa)
+	}
+	c.Participants = []*ParticipantAttendance{}
+	for _, pa := range e.Participants {
+		p := &ParticipantAttendance{}
+		p.FromParticipantAttendanceEntity(pa)
+		c.Participants = append(c.Participants, p)
+	}
+}
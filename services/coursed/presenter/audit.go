@@ -0,0 +1,60 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// AuditChange field changed by the operation
+type AuditChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// AuditLog change made to an entity and who made it
+type AuditLog struct {
+	ID         id.ID                  `json:"id"`
+	EntityType entity.AuditEntityType `json:"entity"`
+	EntityID   id.ID                  `json:"entityID"`
+	Operation  entity.AuditOperation  `json:"operation"`
+	Source     entity.AuditSource     `json:"source"`
+	ActorID    id.ID                  `json:"actorID"`
+	Before     json.RawMessage        `json:"before,omitempty"`
+	After      json.RawMessage        `json:"after,omitempty"`
+	Changes    []AuditChange          `json:"changes"`
+	Seq        int64                  `json:"seq,omitempty"`
+	CreatedAt  time.Time              `json:"createdAt"`
+}
+
+// FromEntityAuditLog creates audit log response from the entity
+func (a *AuditLog) FromEntityAuditLog(e *entity.AuditLog) error {
+	a.ID = e.ID
+	a.EntityType = e.EntityType
+	a.EntityID = e.EntityID
+	a.Operation = e.Operation
+	a.Source = e.Source
+	a.ActorID = e.ActorID
+	a.Before = e.Before
+	a.After = e.After
+	a.Changes = []AuditChange{}
+	for _, c := range e.Changes {
+		a.Changes = append(a.Changes, AuditChange{
+			Field:  c.Field,
+			Before: c.Before,
+			After:  c.After,
+		})
+	}
+	a.Seq = e.Seq
+	a.CreatedAt = e.CreatedAt
+	return nil
+}
@@ -36,6 +36,7 @@ type CenterReq struct {
 	// GeoLocation CenterGeoLocation `json:"geoLocation,omitempty"`
 	Capacity   int32             `json:"capacity"`
 	Mode       entity.CenterMode `json:"mode"`
+	Timezone   string            `json:"timezone,omitempty"`
 	IsNational bool              `json:"isNational"`
 	IsEnabled  bool              `json:"isEnabled"`
 	WebPage    string            `json:"webPage,omitempty"`
@@ -43,6 +44,8 @@ type CenterReq struct {
 
 type CenterResponse struct {
 	ID id.ID `json:"id"`
+	// ExtID Salesforce id of the center
+	ExtID string `json:"extID,omitempty"`
 }
 
 type Center struct {
@@ -56,9 +59,23 @@ type CenterImportResponse struct {
 	IsError bool   `json:"isError"`
 }
 
+// CenterNearby center with its distance from the searched point
+type CenterNearby struct {
+	Center
+	DistanceKm float64 `json:"distanceKm"`
+}
+
+// FromEntityCenterNearby populates nearby center struct from the entity
+func (c *CenterNearby) FromEntityCenterNearby(e *entity.CenterNearby) error {
+	c.FromEntityCenter(e.Center)
+	c.DistanceKm = e.DistanceKm
+	return nil
+}
+
 // FromEntityCenter populates center struct from center entity
 func (c *Center) FromEntityCenter(e *entity.Center) error {
 	deepcopier.Copy(e).To(c)
+	c.ExtID = e.ExtID
 	return nil
 }
 
@@ -7,7 +7,10 @@
 package presenter
 
 import (
+	"time"
+
 	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
 )
 
 // Address
@@ -20,11 +23,23 @@ type Address struct {
 	Country string `json:"country,omitempty"`
 }
 
+// ImportResponse result of the Salesforce sync of a record
+type ImportResponse struct {
+	ID      id.ID  `json:"id"`
+	ExtID   string `json:"extID"`
+	IsError bool   `json:"isError"`
+}
+
 // Date/time
 type DateTime struct {
 	Date      string `json:"date,omitempty"`      // Only date in YYYY-MM-DD format
 	StartTime string `json:"startTime,omitempty"` // Only time in HH:MM:SS format (SS is optional, default 00)
 	EndTime   string `json:"endTime,omitempty"`
+
+	// Note: StartsAt and EndsAt are output only; the instants of the session
+	// in the course timezone, or the zone asked for
+	StartsAt *time.Time `json:"startsAt,omitempty"`
+	EndsAt   *time.Time `json:"endsAt,omitempty"`
 }
 
 func (a *Address) CopyFrom(sa entity.CourseAddress) {
@@ -41,3 +56,26 @@ func (dt *DateTime) CopyFrom(sdt entity.CourseDateTime) {
 	dt.StartTime = sdt.StartTime
 	dt.EndTime = sdt.EndTime
 }
+
+// CopyInstants sets the instants of the session in the location; left out
+// when the session cannot be placed in it
+func (dt *DateTime) CopyInstants(sdt entity.CourseDateTime, loc *time.Location) {
+	start, end, err := sdt.Interval(loc)
+	if err != nil {
+		return
+	}
+	dt.StartsAt = &start
+	dt.EndsAt = &end
+}
+
+// In renders the instants of the session in the location
+func (dt *DateTime) In(loc *time.Location) {
+	if dt.StartsAt != nil {
+		start := dt.StartsAt.In(loc)
+		dt.StartsAt = &start
+	}
+	if dt.EndsAt != nil {
+		end := dt.EndsAt.In(loc)
+		dt.EndsAt = &end
+	}
+}
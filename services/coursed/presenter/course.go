@@ -7,6 +7,8 @@
 package presenter
 
 import (
+	"time"
+
 	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
@@ -55,11 +57,26 @@ type CourseReq struct {
 	Teacher      []CourseTeacher      `json:"teacher,omitempty"`
 	Notify       []id.ID              `json:"notify,omitempty"`
 	DateTime     []DateTime           `json:"date,omitempty"`
+	// Recurrence generates the sessions in addition to date; on update, the
+	// sessions from today on are replaced by the ones of the rule
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+}
+
+// Recurrence sessions repeated by an RFC 5545 recurrence rule
+type Recurrence struct {
+	// Rule RRULE, e.g. FREQ=WEEKLY;BYDAY=TU,TH;COUNT=8
+	Rule      string   `json:"rule"`
+	StartDate string   `json:"startDate"` // date of the first session in YYYY-MM-DD format
+	StartTime string   `json:"startTime"`
+	EndTime   string   `json:"endTime"`
+	ExDates   []string `json:"exDates,omitempty"` // dates without a session
 }
 
 // CourseResponse struct used as response to the create course request (REST API)
 type CourseResponse struct {
-	ID         id.ID   `json:"id"`
+	ID id.ID `json:"id"`
+	// ExtID Salesforce id; not set for courses yet to be exported
+	ExtID      *string `json:"extID,omitempty"`
 	DateTimeID []id.ID `json:"dateID,omitempty"`
 	ShortURL   *string `json:"shortURL,omitempty"`
 }
@@ -79,6 +96,15 @@ func (cr CourseReq) ToCourse(tenantID id.ID) (entity.Course, error) {
 	if cr.Address != nil {
 		deepcopier.Copy(cr.Address).To(&course.Address)
 	}
+	if cr.Recurrence != nil {
+		course.Recurrence = &entity.CourseRecurrence{
+			Rule:      cr.Recurrence.Rule,
+			StartDate: cr.Recurrence.StartDate,
+			StartTime: cr.Recurrence.StartTime,
+			EndTime:   cr.Recurrence.EndTime,
+			ExDates:   cr.Recurrence.ExDates,
+		}
+	}
 
 	course.TenantID = tenantID
 
@@ -170,6 +196,7 @@ type Course struct {
 // FromEntityCourse creates course response from course entity
 func (c *Course) FromEntityCourse(e *entity.Course) error {
 	c.ID = e.ID
+	c.ExtID = e.ExtID
 	c.Name = e.Name
 	c.CenterID = e.CenterID
 	c.ProductID = e.ProductID
@@ -182,6 +209,15 @@ func (c *Course) FromEntityCourse(e *entity.Course) error {
 	c.NumAttendees = &e.NumAttendees
 
 	c.Address.CopyFrom(e.Address)
+	if e.Recurrence != nil {
+		c.Recurrence = &Recurrence{
+			Rule:      e.Recurrence.Rule,
+			StartDate: e.Recurrence.StartDate,
+			StartTime: e.Recurrence.StartTime,
+			EndTime:   e.Recurrence.EndTime,
+			ExDates:   e.Recurrence.ExDates,
+		}
+	}
 
 	return nil
 }
@@ -198,6 +234,19 @@ func (c *Course) FromEntityCourseFull(cf *entity.CourseFull) error {
 	return nil
 }
 
+// CourseNearby course with the distance of its center from the searched point
+type CourseNearby struct {
+	Course
+	DistanceKm float64 `json:"distanceKm"`
+}
+
+// FromEntityCourseNearby creates nearby course response from the entity
+func (c *CourseNearby) FromEntityCourseNearby(e *entity.CourseNearby) error {
+	c.FromEntityCourseFull(e.CourseFull)
+	c.DistanceKm = e.DistanceKm
+	return nil
+}
+
 // FromCourseOrganizer creates course from course organizer
 func (c *Course) FromCourseOrganizer(cos []*entity.CourseOrganizer) error {
 	for _, co := range cos {
@@ -237,21 +286,34 @@ func (c *Course) FromCourseNotify(cns []*entity.CourseNotify) error {
 	return nil
 }
 
-// FromCourseTiming creates course from course timing
+// FromCourseTiming creates course from course timing; the instants are set
+// in the course timezone (FromEntityCourse sets it)
 func (c *Course) FromCourseTiming(cts []*entity.CourseTiming) error {
+	loc, locErr := entity.TimezoneLocation(c.Timezone)
 	for _, ct := range cts {
 		c.DateTimeID = append(c.DateTimeID, ct.ID)
-		c.DateTime = append(c.DateTime,
-			DateTime{
-				Date:      ct.DateTime.Date,
-				StartTime: ct.DateTime.StartTime,
-				EndTime:   ct.DateTime.EndTime,
-			})
+		dt := DateTime{}
+		dt.CopyFrom(ct.DateTime)
+		if locErr == nil {
+			dt.CopyInstants(ct.DateTime, loc)
+		}
+		c.DateTime = append(c.DateTime, dt)
 	}
 
 	return nil
 }
 
+// RenderTimings renders the instants of the sessions in the location; kept in
+// the course timezone when loc is nil
+func (c *Course) RenderTimings(loc *time.Location) {
+	if loc == nil {
+		return
+	}
+	for i := range c.DateTime {
+		c.DateTime[i].In(loc)
+	}
+}
+
 // ToEntity populates entity course from presenter course
 func (c Course) ToEntity(e *entity.Course) error {
 	deepcopier.Copy(c).To(e)
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/ical"
+)
+
+// calendarProdID product identifier of the course calendars
+const calendarProdID = "-//AboveCloud9.AI//GLAD Courses//EN"
+
+// CourseCalendar creates the calendar with an event per course timing. Timings
+// that cannot be placed in the course timezone are left out.
+func CourseCalendar(name string, courses []*entity.CourseFull) *ical.Calendar {
+	c := &ical.Calendar{
+		ProdID: calendarProdID,
+		Name:   name,
+	}
+
+	for _, cf := range courses {
+		course := cf.Course
+		if course == nil {
+			continue
+		}
+		loc, err := entity.TimezoneLocation(course.Timezone)
+		if err != nil {
+			continue
+		}
+
+		for _, ct := range cf.CourseTiming {
+			start, end, err := ct.DateTime.Interval(loc)
+			if err != nil {
+				continue
+			}
+
+			stamp := course.UpdatedAt
+			if ct.UpdatedAt.After(stamp) {
+				stamp = ct.UpdatedAt
+			}
+			if stamp.IsZero() {
+				stamp = time.Now()
+			}
+
+			event := &ical.Event{
+				UID:         fmt.Sprintf("%v@glad", ct.ID),
+				Stamp:       stamp,
+				Start:       start,
+				End:         end,
+				Summary:     course.Name,
+				Description: course.Notes,
+				URL:         course.URL,
+				Status:      calendarStatus(course.Status),
+			}
+			if course.Mode != entity.CourseOnline {
+				event.Location = addressLine(&course.Address)
+			}
+			c.Events = append(c.Events, event)
+		}
+	}
+	return c
+}
+
+// calendarStatus status of the events of the course
+func calendarStatus(status entity.CourseStatus) string {
+	switch {
+	case !status.IsScheduled():
+		return ical.StatusCancelled
+	case status == entity.CourseDraft || status == entity.CourseSubmitted:
+		return ical.StatusTentative
+	default:
+		return ical.StatusConfirmed
+	}
+}
+
+// addressLine formats the address on a single line
+func addressLine(a *entity.CourseAddress) string {
+	var parts []string
+	for _, part := range []string{
+		a.Street1,
+		a.Street2,
+		a.City,
+		strings.TrimSpace(a.State + " " + a.Zip),
+		a.Country,
+	} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CalendarToken calendar subscription of an account
+type CalendarToken struct {
+	Token string `json:"token"`
+	// Path of the calendar feed to subscribe to
+	Path string `json:"path"`
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// CourseSession session of a course; the times are in UTC
+type CourseSession struct {
+	CourseID   id.ID     `json:"courseID"`
+	DateTimeID id.ID     `json:"dateTimeID,omitempty"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+}
+
+// CourseConflict session overlapping the session of another course
+type CourseConflict struct {
+	Kind      entity.CourseConflictKind `json:"kind"`
+	AccountID id.ID                     `json:"accountID,omitempty"`
+	CenterID  id.ID                     `json:"centerID,omitempty"`
+	Session   CourseSession             `json:"session"`
+	With      CourseSession             `json:"with"`
+	Attendees int32                     `json:"attendees,omitempty"`
+	Capacity  int32                     `json:"capacity,omitempty"`
+}
+
+// FromEntityCourseSession populates session struct from the entity
+func (s *CourseSession) FromEntityCourseSession(e *entity.CourseSession) {
+	s.CourseID = e.CourseID
+	s.DateTimeID = e.TimingID
+	s.Start = e.Start.UTC()
+	s.End = e.End.UTC()
+}
+
+// FromEntityCourseConflict populates conflict struct from the entity
+func (c *CourseConflict) FromEntityCourseConflict(e *entity.CourseConflict) {
+	c.Kind = e.Kind
+	c.AccountID = e.AccountID
+	c.CenterID = e.CenterID
+	c.Session.FromEntityCourseSession(&e.Session)
+	c.With.FromEntityCourseSession(&e.With)
+	c.Attendees = e.Attendees
+	c.Capacity = e.Capacity
+}
+
+// FromEntityCourseConflicts creates the conflicts response from the entities
+func FromEntityCourseConflicts(conflicts []*entity.CourseConflict) []*CourseConflict {
+	responses := []*CourseConflict{}
+	for _, conflict := range conflicts {
+		resp := &CourseConflict{}
+		resp.FromEntityCourseConflict(conflict)
+		responses = append(responses, resp)
+	}
+	return responses
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// CourseTransition course lifecycle transition and the account that triggered it
+type CourseTransition struct {
+	ID        id.ID               `json:"id"`
+	CourseID  id.ID               `json:"courseID"`
+	Action    entity.CourseAction `json:"action"`
+	From      entity.CourseStatus `json:"from"`
+	To        entity.CourseStatus `json:"to"`
+	ActorID   id.ID               `json:"actorID"`
+	CreatedAt time.Time           `json:"createdAt"`
+}
+
+// FromEntityCourseStatusChange creates transition response from the entity
+func (ct *CourseTransition) FromEntityCourseStatusChange(e *entity.CourseStatusChange) error {
+	ct.ID = e.ID
+	ct.CourseID = e.CourseID
+	ct.Action = e.Action
+	ct.From = e.From
+	ct.To = e.To
+	ct.ActorID = e.ActorID
+	ct.CreatedAt = e.CreatedAt
+	return nil
+}
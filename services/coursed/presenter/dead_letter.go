@@ -0,0 +1,46 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"encoding/json"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// DeadLetter imported record that could not be saved
+type DeadLetter struct {
+	ID              id.ID                   `json:"id"`
+	EntityType      entity.AuditEntityType  `json:"entity"`
+	ExtID           string                  `json:"extID"`
+	Payload         json.RawMessage         `json:"payload"`
+	Reason          entity.DeadLetterReason `json:"reason"`
+	Message         string                  `json:"message,omitempty"`
+	DependencyType  entity.AuditEntityType  `json:"dependency,omitempty"`
+	DependencyExtID string                  `json:"dependencyExtID,omitempty"`
+	Attempts        int                     `json:"attempts"`
+	CreatedAt       time.Time               `json:"createdAt"`
+	UpdatedAt       time.Time               `json:"updatedAt"`
+}
+
+// FromEntityDeadLetter creates dead letter response from the entity
+func (d *DeadLetter) FromEntityDeadLetter(e *entity.DeadLetter) error {
+	d.ID = e.ID
+	d.EntityType = e.EntityType
+	d.ExtID = e.ExtID
+	d.Payload = e.Payload
+	d.Reason = e.Reason
+	d.Message = e.Message
+	d.DependencyType = e.DependencyType
+	d.DependencyExtID = e.DependencyExtID
+	d.Attempts = e.Attempts
+	d.CreatedAt = e.CreatedAt
+	d.UpdatedAt = e.UpdatedAt
+	return nil
+}
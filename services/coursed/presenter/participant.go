@@ -6,12 +6,75 @@
 
 package presenter
 
-import "ac9/glad/pkg/id"
+import (
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
 
 // Participant data - TenantID is returned in the HTTP header (may be not, as account is global?)
 // X-GLAD-TenantID
 type Participant struct {
-	ID      id.ID    `json:"id"`
-	Email   string   `json:"email,omitempty"`
-	Account *Account `json:"account,omitempty"`
+	ID       id.ID    `json:"id"`
+	CourseID id.ID    `json:"courseID"`
+	Email    string   `json:"email,omitempty"`
+	Account  *Account `json:"account,omitempty"`
+}
+
+// ParticipantReq registers an account to a course (REST API)
+type ParticipantReq struct {
+	AccountID id.ID  `json:"accountID"`
+	Email     string `json:"email,omitempty"`
+}
+
+// ParticipantResponse struct used as response to the add participant request (REST API)
+type ParticipantResponse struct {
+	ID id.ID `json:"id"`
+}
+
+type ImportParticipantResponse struct {
+	ID      id.ID  `json:"id"`
+	ExtID   string `json:"extID"`
+	IsError bool   `json:"isError"`
+}
+
+// FromParticipantEntity creates participant response from participant entity
+func (p *Participant) FromParticipantEntity(e *entity.Participant) {
+	p.ID = e.ID
+	p.CourseID = e.CourseID
+	p.Email = e.Email
+}
+
+// WaitlistEntry registration waiting for a seat in a full course
+type WaitlistEntry struct {
+	ID        id.ID                 `json:"id"`
+	CourseID  id.ID                 `json:"courseID"`
+	AccountID id.ID                 `json:"accountID"`
+	Email     string                `json:"email,omitempty"`
+	Position  int                   `json:"position"`
+	Status    entity.WaitlistStatus `json:"status"`
+	// HoldUntil the seat held for an offered entry is released after it
+	HoldUntil *time.Time `json:"holdUntil,omitempty"`
+	Account   *Account   `json:"account,omitempty"`
+}
+
+// WaitlistReorderReq new order of the waitlist of a course (REST API)
+type WaitlistReorderReq struct {
+	// EntryIDs all the entries of the waitlist, first to last
+	EntryIDs []id.ID `json:"entryIDs"`
+}
+
+// FromWaitlistEntryEntity creates waitlist entry response from waitlist entry entity
+func (w *WaitlistEntry) FromWaitlistEntryEntity(e *entity.WaitlistEntry) {
+	w.ID = e.ID
+	w.CourseID = e.CourseID
+	w.AccountID = e.AccountID
+	w.Email = e.Email
+	w.Position = e.Position
+	w.Status = e.Status
+	if !e.HoldUntil.IsZero() {
+		holdUntil := e.HoldUntil
+		w.HoldUntil = &holdUntil
+	}
 }
@@ -32,6 +32,10 @@ type ProductReq struct {
 
 type ProductResponse struct {
 	ID id.ID `json:"id"`
+	// ExtID Salesforce id of the product
+	ExtID string `json:"extID,omitempty"`
+	// IsDeleted product deleted in Salesforce
+	IsDeleted bool `json:"isDeleted,omitempty"`
 }
 
 type Product struct {
@@ -56,6 +60,7 @@ type ProductImportResponse struct {
 // FromEntityProduct creates presenter product from entity
 func (p *Product) FromEntityProduct(e *entity.Product) error {
 	p.ID = e.ID
+	p.ExtID = e.ExtID
 	p.ExtName = e.ExtName
 	p.Title = e.Title
 	p.CType = e.CType
@@ -65,6 +70,7 @@ func (p *Product) FromEntityProduct(e *entity.Product) error {
 	p.MaxAttendees = e.MaxAttendees
 	p.Format = e.Format
 	p.IsAutoApprove = e.IsAutoApprove
+	p.IsDeleted = e.IsDeleted
 
 	return nil
 }
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// ProductTemplate defaults of the courses created from a product
+type ProductTemplate struct {
+	StartTime string `json:"startTime"` // time in HH:MM:SS format (SS is optional)
+	EndTime   string `json:"endTime"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+// CourseFromProductReq creates a course from a product (REST API); the fields
+// left out are derived from the product, its template and the center
+type CourseFromProductReq struct {
+	CenterID  id.ID  `json:"centerID"`
+	StartDate string `json:"startDate"` // date of the first session in YYYY-MM-DD format
+
+	Name      string          `json:"name,omitempty"`
+	Timezone  string          `json:"timezone,omitempty"`
+	StartTime string          `json:"startTime,omitempty"`
+	EndTime   string          `json:"endTime,omitempty"`
+	Notes     string          `json:"notes,omitempty"`
+	Organizer []id.ID         `json:"organizer,omitempty"`
+	Teacher   []CourseTeacher `json:"teacher,omitempty"`
+}
+
+// FromProductTemplateEntity creates product template response from its entity
+func (t *ProductTemplate) FromProductTemplateEntity(e *entity.ProductTemplate) {
+	t.StartTime = e.StartTime
+	t.EndTime = e.EndTime
+	t.Notes = e.Notes
+}
+
+// ToEntity creates the course request of the tenant's product
+func (cr CourseFromProductReq) ToEntity(tenantID id.ID, productID id.ID) *entity.CourseFromProduct {
+	req := &entity.CourseFromProduct{
+		TenantID:  tenantID,
+		ProductID: productID,
+		CenterID:  cr.CenterID,
+		StartDate: cr.StartDate,
+		Name:      cr.Name,
+		Timezone:  cr.Timezone,
+		StartTime: cr.StartTime,
+		EndTime:   cr.EndTime,
+		Notes:     cr.Notes,
+	}
+	for _, organizerID := range cr.Organizer {
+		req.Organizers = append(req.Organizers, &entity.CourseOrganizer{ID: organizerID})
+	}
+	for _, t := range cr.Teacher {
+		req.Teachers = append(req.Teachers, &entity.CourseTeacher{
+			ID:        t.ID,
+			IsPrimary: t.IsPrimary,
+		})
+	}
+	return req
+}
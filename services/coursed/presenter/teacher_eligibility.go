@@ -0,0 +1,54 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// TeacherEligibility product a teacher is eligible to teach
+type TeacherEligibility struct {
+	ProductID id.ID                  `json:"productID"`
+	TeacherID id.ID                  `json:"teacherID"`
+	ExtID     string                 `json:"extID,omitempty"`
+	Type      entity.EligibilityType `json:"type"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
+
+// TeacherEligibilityReq sets the eligibility of a teacher (REST API)
+type TeacherEligibilityReq struct {
+	ProductID id.ID                  `json:"productID"`
+	TeacherID id.ID                  `json:"teacherID"`
+	Type      entity.EligibilityType `json:"type"`
+}
+
+// EligibleTeacher eligible teacher with the distance of the nearest center
+// the teacher taught at
+type EligibleTeacher struct {
+	TeacherEligibility
+	DistanceKm float64 `json:"distanceKm"`
+}
+
+// FromEntityTeacherEligibility populates eligibility struct from the entity
+func (te *TeacherEligibility) FromEntityTeacherEligibility(e *entity.TeacherEligibility) {
+	te.ProductID = e.ProductID
+	te.TeacherID = e.TeacherID
+	if e.ExtID != nil {
+		te.ExtID = *e.ExtID
+	}
+	te.Type = e.Type
+	te.UpdatedAt = e.UpdatedAt
+}
+
+// FromEntityEligibleTeacher populates eligible teacher struct from the entity
+func (et *EligibleTeacher) FromEntityEligibleTeacher(e *entity.EligibleTeacher) {
+	et.FromEntityTeacherEligibility(e.TeacherEligibility)
+	et.DistanceKm = e.DistanceKm
+}
@@ -30,9 +30,17 @@ func getConfig() http.Handler {
 		}
 
 		config := presenter.Config{
-			Version:  1,
-			Timezone: []string{"EST", "CST", "MST", "PST", "HST", "AKST"},
-			Auth:     auth,
+			Version: 1,
+			// Note: Any IANA zone name is accepted; these are the common ones
+			Timezone: []string{
+				"America/New_York",
+				"America/Chicago",
+				"America/Denver",
+				"America/Los_Angeles",
+				"Pacific/Honolulu",
+				"America/Anchorage",
+			},
+			Auth: auth,
 		}
 
 		if err := json.NewEncoder(w).Encode(config); err != nil {
@@ -27,6 +27,9 @@ import (
 	"ac9/glad/pkg/metric"
 	"ac9/glad/pkg/middleware"
 	"ac9/glad/pkg/util"
+	gladRepository "ac9/glad/repository"
+	"ac9/glad/usecase/account"
+	"ac9/glad/usecase/audit"
 
 	"github.com/gorilla/context"
 	"github.com/gorilla/mux"
@@ -64,6 +67,13 @@ func main() {
 	liveDarshanRepo := repository.NewLiveDarshanPGSQL(db)
 	liveDarshanService := live_darshan.NewService(liveDarshanRepo)
 
+	accountService := account.NewService(gladRepository.NewAccountPGSQL(db),
+		audit.NewService(gladRepository.NewAuditPGSQL(db)))
+	authenticate, err := middleware.Authentication(accountService)
+	if err != nil {
+		Log.Fatalf("Unable to initialize authentication: %v", err.Error())
+	}
+
 	metricService, err := metric.NewPrometheusService()
 	if err != nil {
 		Log.Fatalf("%v", err.Error())
@@ -74,16 +84,17 @@ func main() {
 		negroni.HandlerFunc(middleware.Metrics(metricService)),
 		negroni.HandlerFunc(middleware.Cors),
 		negroni.HandlerFunc(middleware.AddDefaultTenant),
-		negroni.HandlerFunc(middleware.AddDefaultAccount),
 		negroni.NewLogger(),
 	)
 	n.Use(&middleware.APILogging{Log: Log})
+	// Note: the routes are authenticated but for the health checks
+	authn := n.With(authenticate)
 
 	// log handler
-	logger.MakeLogHandlers(r, *n, "ldsd", Log)
+	logger.MakeLogHandlers(r, *authn, "ldsd", Log)
 
 	// info handler
-	util.MakeInfoHandlers(r, *n, "ldsd")
+	util.MakeInfoHandlers(r, *authn, "ldsd")
 
 	http.Handle("/", r)
 	http.Handle("/metrics", promhttp.Handler())
@@ -96,10 +107,10 @@ func main() {
 	})
 
 	// log handler
-	logger.MakeLogHandlers(r, *n, "ldsd", Log)
+	logger.MakeLogHandlers(r, *authn, "ldsd", Log)
 
 	// live darshan
-	handler.MakeLiveDarshanHandlers(r, *n, liveDarshanService)
+	handler.MakeLiveDarshanHandlers(r, *authn, liveDarshanService)
 
 	logger := log.New(os.Stderr, "logger: ", log.Lshortfile)
 	srv := &http.Server{
@@ -22,6 +22,7 @@ import (
 	"ac9/glad/services/pushd/handler"
 	"ac9/glad/services/pushd/repository"
 	"ac9/glad/services/pushd/usecase/device"
+	"ac9/glad/services/pushd/usecase/notification"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
@@ -29,6 +30,10 @@ import (
 	"ac9/glad/pkg/metric"
 	"ac9/glad/pkg/middleware"
 	"ac9/glad/pkg/util"
+	gladRepository "ac9/glad/repository"
+	"ac9/glad/usecase/account"
+	"ac9/glad/usecase/audit"
+	"ac9/glad/usecase/event"
 
 	gorillaContext "github.com/gorilla/context"
 	"github.com/gorilla/mux"
@@ -68,6 +73,24 @@ func main() {
 	deviceRepo := repository.NewDevicePGSQL(db)
 	deviceService := device.NewService(deviceRepo, pushService)
 
+	// events raised by coursed, e.g. waitlist promotions
+	notificationService := notification.NewService(event.NewService(gladRepository.NewEventPGSQL(db)),
+		deviceService)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = notificationService.Start(ctx,
+		time.Duration(util.GetIntEnvOrConfig("EVENT_POLL_SEC", config.EVENT_POLL_SEC))*time.Second)
+	if err != nil {
+		Log.Fatalf("Unable to start notifications: %v", err.Error())
+	}
+
+	accountService := account.NewService(gladRepository.NewAccountPGSQL(db),
+		audit.NewService(gladRepository.NewAuditPGSQL(db)))
+	authenticate, err := middleware.Authentication(accountService)
+	if err != nil {
+		Log.Fatalf("Unable to initialize authentication: %v", err.Error())
+	}
+
 	metricService, err := metric.NewPrometheusService()
 	if err != nil {
 		Log.Fatalf("%v", err.Error())
@@ -78,13 +101,14 @@ func main() {
 		negroni.HandlerFunc(middleware.Metrics(metricService)),
 		negroni.HandlerFunc(middleware.Cors),
 		negroni.HandlerFunc(middleware.AddDefaultTenant),
-		negroni.HandlerFunc(middleware.AddDefaultAccount),
 		negroni.NewLogger(),
 	)
 	n.Use(&middleware.APILogging{Log: Log})
+	// Note: the routes are authenticated but for the health checks
+	authn := n.With(authenticate)
 
 	// log handler
-	logger.MakeLogHandlers(r, *n, "pushd", Log)
+	logger.MakeLogHandlers(r, *authn, "pushd", Log)
 
 	http.Handle("/", r)
 	http.Handle("/metrics", promhttp.Handler())
@@ -97,13 +121,13 @@ func main() {
 	})
 
 	// log handler
-	logger.MakeLogHandlers(r, *n, "pushd", Log)
+	logger.MakeLogHandlers(r, *authn, "pushd", Log)
 
 	// info handler
-	util.MakeInfoHandlers(r, *n, "pushd")
+	util.MakeInfoHandlers(r, *authn, "pushd")
 
 	// device
-	handler.MakeDeviceHandlers(r, *n, deviceService)
+	handler.MakeDeviceHandlers(r, *authn, deviceService)
 
 	logger := log.New(os.Stderr, "logger: ", log.Lshortfile)
 	srv := &http.Server{
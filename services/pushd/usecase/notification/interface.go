@@ -0,0 +1,36 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package notification
+
+import (
+	"context"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// EventSource events raised by coursed. event.UseCase satisfies this interface.
+type EventSource interface {
+	ListPending(limit int) ([]*entity.Event, error)
+	MarkDelivered(eventIDs []id.ID) error
+}
+
+// Notifier sends the notifications to the devices of the accounts.
+// device.UseCase satisfies this interface.
+type Notifier interface {
+	Notify(tenantID id.ID, accountID []id.ID, header, content string) ([]int, error)
+}
+
+// UseCase interface
+type UseCase interface {
+	// Dispatch notifies the accounts of the pending events and returns the
+	// number of events delivered
+	Dispatch() int
+	// Start runs Dispatch at the interval until ctx is done
+	Start(ctx context.Context, interval time.Duration) error
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+const (
+	// batchSize events delivered at once
+	batchSize = 100
+	// maxEventAge events failing to deliver are retried until they are this old
+	maxEventAge = 24 * time.Hour
+)
+
+// Service notification usecase
+type Service struct {
+	events   EventSource
+	notifier Notifier
+}
+
+// NewService creates new service
+func NewService(events EventSource, notifier Notifier) *Service {
+	return &Service{
+		events:   events,
+		notifier: notifier,
+	}
+}
+
+// Dispatch notifies the accounts of the pending events, oldest first
+// Note: Events of accounts without devices are delivered as well; there is
+// nobody to notify. Events failing to deliver are retried by the next dispatch.
+func (s *Service) Dispatch() int {
+	events, err := s.events.ListPending(batchSize)
+	if err != nil {
+		if err != glad.ErrNotFound {
+			l.Log.Warnf("Unable to list pending events. err=%v", err)
+		}
+		return 0
+	}
+
+	var delivered []id.ID
+	for _, e := range events {
+		if s.deliver(e) || time.Since(e.CreatedAt) > maxEventAge {
+			delivered = append(delivered, e.ID)
+		}
+	}
+
+	err = s.events.MarkDelivered(delivered)
+	if err != nil {
+		l.Log.Warnf("Unable to mark events delivered. err=%v", err)
+		return 0
+	}
+	return len(delivered)
+}
+
+// deliver notifies the account of the event; false when it is to be retried
+func (s *Service) deliver(e *entity.Event) bool {
+	header, content, err := message(e)
+	if err != nil {
+		l.Log.Warnf("Dropping event id=%v, type=%v, err=%v", e.ID, e.Type, err)
+		return true
+	}
+
+	statuses, err := s.notifier.Notify(e.TenantID, []id.ID{e.AccountID}, header, content)
+	if err != nil {
+		l.Log.Warnf("Unable to notify event id=%v, err=%v", e.ID, err)
+		return false
+	}
+	for _, status := range statuses {
+		if status == http.StatusInternalServerError {
+			return false
+		}
+	}
+	return true
+}
+
+// message header and content of the notification of the event
+func message(e *entity.Event) (string, string, error) {
+	switch e.Type {
+	case entity.EventWaitlistPromoted:
+		var p entity.EventWaitlistPromotion
+		err := json.Unmarshal(e.Payload, &p)
+		if err != nil {
+			return "", "", err
+		}
+		return "A seat is available",
+			fmt.Sprintf("A seat is held for you until %v. Confirm your registration to keep it.",
+				p.HoldUntil.UTC().Format(time.RFC1123)),
+			nil
+	default:
+		return "", "", glad.ErrInvalidValue
+	}
+}
+
+// Start runs Dispatch at the interval until ctx is done
+func (s *Service) Start(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return glad.ErrInvalidValue
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if delivered := s.Dispatch(); delivered > 0 {
+					l.Log.Infof("Delivered %v events", delivered)
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package notification
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+	dmock "ac9/glad/services/pushd/usecase/device/mock"
+	emock "ac9/glad/usecase/event/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice  id.ID = 13790492210917015554
+	accountAlice id.ID = 13790493495087075501
+	accountBob   id.ID = 13790493495087075502
+	courseAlice  id.ID = 13790493495087071234
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+func newFixtureEvent(eventID id.ID, accountID id.ID, createdAt time.Time) *entity.Event {
+	payload, _ := json.Marshal(entity.EventWaitlistPromotion{
+		EntryID:   eventID,
+		HoldUntil: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC),
+	})
+	return &entity.Event{
+		ID:        eventID,
+		TenantID:  tenantAlice,
+		Type:      entity.EventWaitlistPromoted,
+		AccountID: accountID,
+		CourseID:  courseAlice,
+		Payload:   payload,
+		CreatedAt: createdAt,
+	}
+}
+
+func Test_Dispatch(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	events := emock.NewMockUseCase(controller)
+	notifier := dmock.NewMockUseCase(controller)
+	s := NewService(events, notifier)
+
+	promoted := newFixtureEvent(1, accountAlice, time.Now())
+	failing := newFixtureEvent(2, accountBob, time.Now())
+	stale := newFixtureEvent(3, accountBob, time.Now().Add(-2*maxEventAge))
+	unknown := newFixtureEvent(4, accountAlice, time.Now())
+	unknown.Type = "course.unknown"
+
+	events.EXPECT().ListPending(batchSize).
+		Return([]*entity.Event{promoted, failing, stale, unknown}, nil)
+	notifier.EXPECT().Notify(tenantAlice, []id.ID{accountAlice}, "A seat is available", gomock.Any()).
+		DoAndReturn(func(_ id.ID, _ []id.ID, _, content string) ([]int, error) {
+			assert.Contains(t, content, "Sat, 01 Jun 2024 09:00:00 UTC")
+			return []int{http.StatusCreated}, nil
+		})
+	notifier.EXPECT().Notify(tenantAlice, []id.ID{accountBob}, gomock.Any(), gomock.Any()).
+		Return([]int{http.StatusInternalServerError}, nil).Times(2)
+	// the failing event is retried; the stale and the unknown ones are dropped
+	events.EXPECT().MarkDelivered([]id.ID{promoted.ID, stale.ID, unknown.ID}).Return(nil)
+
+	assert.Equal(t, 3, s.Dispatch())
+
+	t.Run("nothing pending", func(t *testing.T) {
+		events.EXPECT().ListPending(batchSize).Return(nil, glad.ErrNotFound)
+		assert.Equal(t, 0, s.Dispatch())
+	})
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// ImportJobStatus status of an import job
+type ImportJobStatus string
+
+const (
+	// ImportJobQueued job waits for a worker
+	ImportJobQueued ImportJobStatus = "queued"
+	// ImportJobRunning job is being processed; progress is saved per chunk
+	ImportJobRunning ImportJobStatus = "running"
+	// ImportJobCompleted all the records were processed; some may have failed
+	ImportJobCompleted ImportJobStatus = "completed"
+	// ImportJobFailed job could not be processed at all, e.g. bad payload
+	ImportJobFailed ImportJobStatus = "failed"
+)
+
+// ImportRecordError failure of a single record of an import job
+type ImportRecordError struct {
+	// Index position of the record in the payload
+	Index   int
+	ExtID   string
+	Action  string
+	Message string
+}
+
+// ImportJob asynchronous import of Salesforce records of a resource
+type ImportJob struct {
+	ID       id.ID
+	TenantID id.ID
+	Resource string
+	Status   ImportJobStatus
+
+	// Payload Salesforce records as received
+	Payload json.RawMessage
+
+	// progress
+	Total     int
+	Processed int
+	Succeeded int
+	Failed    int
+	Errors    []ImportRecordError
+	// Message reason the job failed
+	Message string
+	// LeaseUntil the running job is owned by a worker until then; renewed
+	// with the progress
+	LeaseUntil time.Time
+
+	// meta data
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewImportJob creates a queued import job of the records
+func NewImportJob(tenantID id.ID, resource string, records []json.RawMessage) (*ImportJob, error) {
+	payload, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	j := &ImportJob{
+		ID:        id.New(),
+		TenantID:  tenantID,
+		Resource:  resource,
+		Status:    ImportJobQueued,
+		Payload:   payload,
+		Total:     len(records),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = j.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// Validate validates the import job
+func (j *ImportJob) Validate() error {
+	if j.TenantID == id.IDInvalid || j.Resource == "" {
+		l.Log.Warnf("Invalid import job tenantID=%v, resource=%v", j.TenantID, j.Resource)
+		return glad.ErrInvalidEntity
+	}
+	return nil
+}
+
+// IsDone whether the job reached a final status
+func (j *ImportJob) IsDone() bool {
+	return j.Status == ImportJobCompleted || j.Status == ImportJobFailed
+}
+
+// IsClaimable whether a worker may claim the job, i.e. it is queued or its
+// worker stopped renewing the lease, e.g. on a crash
+func (j *ImportJob) IsClaimable(now time.Time) bool {
+	return j.Status == ImportJobQueued ||
+		(j.Status == ImportJobRunning && j.LeaseUntil.Before(now))
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"ac9/glad/pkg/id"
+)
+
+// OutboundNotification Salesforce Outbound Messaging notification. The
+// notification id is kept before the record is applied, and dropped if it
+// fails, so that a resent notification is not applied again.
+type OutboundNotification struct {
+	// ID is the Salesforce notification id
+	ID       string
+	TenantID id.ID
+	Resource string
+	// Record is the record imported for the notification; it is not kept
+	Record json.RawMessage
+
+	// meta data
+	CreatedAt time.Time
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/presenter"
+	"ac9/glad/services/sfsyncd/usecase/sf_export"
+
+	"github.com/gorilla/mux"
+	"github.com/urfave/negroni"
+)
+
+// exportChanges exports the changes made in GLAD after the seq of the change
+// feed to Salesforce. The response carries the after to be used in the next
+// request.
+func exportChanges(service sf_export.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error exporting changes"
+
+		tenant := r.Header.Get(common.HttpHeaderTenantID)
+		tenantID, err := id.FromString(tenant)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Missing tenant ID"))
+			return
+		}
+
+		q := r.URL.Query()
+		var after int64
+		if v := q.Get("after"); v != "" {
+			after, err = strconv.ParseInt(v, 10, 64)
+			if err != nil || after < 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Invalid after"))
+				return
+			}
+		}
+		var limit int
+		if v := q.Get("limit"); v != "" {
+			limit, err = strconv.Atoi(v)
+			if err != nil || limit < 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("Invalid limit"))
+				return
+			}
+		}
+
+		next, results, err := service.Export(tenantID, after, limit)
+		if err != nil {
+			l.Log.Warnf("Unable to export changes tenantID=%v, err=%v", tenantID, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage + ". " + err.Error()))
+			return
+		}
+
+		response := &presenter.Export{Next: next, Records: []*presenter.ExportResponse{}}
+		for _, result := range results {
+			response.Records = append(response.Records, &presenter.ExportResponse{
+				Entity:    string(result.Entity),
+				ID:        result.ID,
+				ExtID:     result.ExtID,
+				IsCreated: result.Created,
+				IsError:   result.Err != nil,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(common.HttpHeaderTenantID, tenant)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+	})
+}
+
+// MakeExportHandlers make url handlers
+func MakeExportHandlers(r *mux.Router, n negroni.Negroni, service sf_export.UseCase) {
+	r.Handle("/v1/export/salesforce", n.With(
+		negroni.Wrap(exportChanges(service)),
+	)).Methods(http.MethodPost, http.MethodOptions).Name("exportChanges")
+}
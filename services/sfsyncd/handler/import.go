@@ -16,179 +16,54 @@ import (
 	"log"
 	"net/http"
 
-	l "ac9/glad/pkg/logger"
-
 	"github.com/gorilla/mux"
 	"github.com/ulule/deepcopier"
 	"github.com/urfave/negroni"
 )
 
 func importProducts(service sf_import.UseCase) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		errorMessage := "Error importing products"
-
-		var sfProducts []presenter.Product
-		tenant := r.Header.Get(common.HttpHeaderTenantID)
-		tenantID, err := id.FromString(tenant)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte("Missing tenant ID"))
-			return
-		}
-
-		err = json.NewDecoder(r.Body).Decode(&sfProducts)
-		if err != nil {
-			log.Println(err.Error())
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
-			return
-		}
-
-		var gProducts []*glad.Product
-		for _, sfProduct := range sfProducts {
-			product := &glad.Product{}
-			deepcopier.Copy(sfProduct).To(product)
-			gProducts = append(gProducts, product)
-		}
-
-		gResponses, err := service.ImportProduct(tenantID, gProducts)
-		if err != nil {
-			l.Log.Warnf("Unable to import products tenantID=%v, err=%v", tenantID, err)
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte("Unable to import products. " + err.Error()))
-		}
-
-		var sfResponses []*presenter.ProductResponse
-		for _, gResponse := range gResponses {
-			resp := &presenter.ProductResponse{}
-			deepcopier.Copy(gResponse).To(resp)
-			sfResponses = append(sfResponses, resp)
-		}
-
-		w.Header().Set(common.HttpHeaderTenantID, tenant)
-		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(sfResponses); err != nil {
-			log.Println(err.Error())
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(errorMessage))
-			return
-		}
-	})
+	return importRecords[presenter.ProductResponse](service,
+		sf_import.ResourceProduct, "Error importing products")
 }
 
 func importCenters(service sf_import.UseCase) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		errorMessage := "Error importing centers"
-
-		var sfCenters []presenter.Center
-		tenant := r.Header.Get(common.HttpHeaderTenantID)
-		tenantID, err := id.FromString(tenant)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte("Missing tenant ID"))
-			return
-		}
-
-		err = json.NewDecoder(r.Body).Decode(&sfCenters)
-		if err != nil {
-			log.Println(err.Error())
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
-			return
-		}
-
-		var gCenters []*glad.Center
-		for _, sfCenter := range sfCenters {
-			center := &glad.Center{}
-			sfCenter.ToGladCenter(center)
-			l.Log.Debugf("sfCenter=%#v, center=%#v", sfCenter, center)
-			gCenters = append(gCenters, center)
-		}
-
-		gResponses, err := service.ImportCenter(tenantID, gCenters)
-		if err != nil {
-			l.Log.Warnf("Unable to import centers tenantID=%v, err=%v", tenantID, err)
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte("Unable to import centers. " + err.Error()))
-		}
-
-		var sfResponses []*presenter.CenterResponse
-		for _, gResponse := range gResponses {
-			resp := &presenter.CenterResponse{}
-			deepcopier.Copy(gResponse).To(resp)
-			sfResponses = append(sfResponses, resp)
-		}
-
-		w.Header().Set(common.HttpHeaderTenantID, tenant)
-		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(sfResponses); err != nil {
-			log.Println(err.Error())
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(errorMessage))
-			return
-		}
-	})
+	return importRecords[presenter.CenterResponse](service,
+		sf_import.ResourceCenter, "Error importing centers")
 }
 
 func importAccounts(service sf_import.UseCase) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		errorMessage := "Error importing accounts"
-
-		var sfAccounts []presenter.Account
-		tenant := r.Header.Get(common.HttpHeaderTenantID)
-		tenantID, err := id.FromString(tenant)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte("Missing tenant ID"))
-			return
-		}
-
-		err = json.NewDecoder(r.Body).Decode(&sfAccounts)
-		if err != nil {
-			log.Println(err.Error())
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
-			return
-		}
+	return importRecords[presenter.AccountResponse](service,
+		sf_import.ResourceAccount, "Error importing accounts")
+}
 
-		var gAccounts []*glad.Account
-		for _, sfAccount := range sfAccounts {
-			account := &glad.Account{}
-			sfAccount.ToGladAccount(account)
-			l.Log.Debugf("sfAccount=%#v, account=%#v", sfAccount, account)
-			gAccounts = append(gAccounts, account)
-		}
+func importCourses(service sf_import.UseCase) http.Handler {
+	return importRecords[presenter.CourseResponse](service,
+		sf_import.ResourceCourse, "Error importing courses")
+}
 
-		gResponses, err := service.ImportAccount(tenantID, gAccounts)
-		if err != nil {
-			l.Log.Warnf("Unable to import accounts tenantID=%v, err=%v", tenantID, err)
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte("Unable to import accounts. " + err.Error()))
-		}
+func importCourseTimings(service sf_import.UseCase) http.Handler {
+	return importRecords[presenter.CourseTimingResponse](service,
+		sf_import.ResourceCourseTiming, "Error importing course timings")
+}
 
-		var sfResponses []*presenter.AccountResponse
-		for _, gResponse := range gResponses {
-			resp := &presenter.AccountResponse{}
-			deepcopier.Copy(gResponse).To(resp)
-			sfResponses = append(sfResponses, resp)
-		}
+func importCourseRosters(service sf_import.UseCase) http.Handler {
+	return importRecords[presenter.CourseRosterResponse](service,
+		sf_import.ResourceCourseRoster, "Error importing course rosters")
+}
 
-		w.Header().Set(common.HttpHeaderTenantID, tenant)
-		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(sfResponses); err != nil {
-			log.Println(err.Error())
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(errorMessage))
-			return
-		}
-	})
+func importParticipants(service sf_import.UseCase) http.Handler {
+	return importRecords[presenter.ParticipantResponse](service,
+		sf_import.ResourceCourseParticipant, "Error importing participants")
 }
 
-func importCourses(service sf_import.UseCase) http.Handler {
+// importRecords syncs the Salesforce records of the resource in the request
+// and responds with the result of each record as T
+func importRecords[T any](service sf_import.UseCase,
+	resource sf_import.Resource,
+	errorMessage string,
+) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		errorMessage := "Error importing courses"
-
-		var sfCourses []presenter.CourseWrapper
+		var records []json.RawMessage
 		tenant := r.Header.Get(common.HttpHeaderTenantID)
 		tenantID, err := id.FromString(tenant)
 		if err != nil {
@@ -197,7 +72,7 @@ func importCourses(service sf_import.UseCase) http.Handler {
 			return
 		}
 
-		err = json.NewDecoder(r.Body).Decode(&sfCourses)
+		err = json.NewDecoder(r.Body).Decode(&records)
 		if err != nil {
 			log.Println(err.Error())
 			w.WriteHeader(http.StatusBadRequest)
@@ -205,25 +80,18 @@ func importCourses(service sf_import.UseCase) http.Handler {
 			return
 		}
 
-		var gCourses []*glad.Course
-		for _, sfCourse := range sfCourses {
-			course := &glad.Course{}
-			sfCourse.Value.ToGladCourse(course)
-			l.Log.Debugf("sfCourse=%#v, course=%#v", sfCourse, course)
-			gCourses = append(gCourses, course)
-		}
-
-		gResponses, err := service.ImportCourse(tenantID, gCourses)
-		if err != nil {
-			l.Log.Warnf("Unable to import courses tenantID=%v, err=%v", tenantID, err)
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte("Unable to import courses. " + err.Error()))
+		// Note: failures of the upsert batch are reported per record
+		results, err := service.Sync(tenantID, resource, records)
+		if err == glad.ErrInvalidValue {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data"))
+			return
 		}
 
-		var sfResponses []*presenter.CourseResponse
-		for _, gResponse := range gResponses {
-			resp := &presenter.CourseResponse{}
-			deepcopier.Copy(gResponse).To(resp)
+		var sfResponses []*T
+		for _, result := range results {
+			resp := new(T)
+			deepcopier.Copy(result).To(resp)
 			sfResponses = append(sfResponses, resp)
 		}
 
@@ -238,7 +106,6 @@ func importCourses(service sf_import.UseCase) http.Handler {
 	})
 }
 
-
 // MakeImportHandlers make import handlers
 func MakeImportHandlers(r *mux.Router, n negroni.Negroni, service sf_import.UseCase) {
 	r.Handle("/v1/import/salesforce/products", n.With(
@@ -256,4 +123,16 @@ func MakeImportHandlers(r *mux.Router, n negroni.Negroni, service sf_import.UseC
 	r.Handle("/v1/import/salesforce/courses", n.With(
 		negroni.Wrap(importCourses(service)),
 	)).Methods(http.MethodPost, http.MethodOptions).Name("importCourses")
+
+	r.Handle("/v1/import/salesforce/timings", n.With(
+		negroni.Wrap(importCourseTimings(service)),
+	)).Methods(http.MethodPost, http.MethodOptions).Name("importCourseTimings")
+
+	r.Handle("/v1/import/salesforce/rosters", n.With(
+		negroni.Wrap(importCourseRosters(service)),
+	)).Methods(http.MethodPost, http.MethodOptions).Name("importCourseRosters")
+
+	r.Handle("/v1/import/salesforce/participants", n.With(
+		negroni.Wrap(importParticipants(service)),
+	)).Methods(http.MethodPost, http.MethodOptions).Name("importParticipants")
 }
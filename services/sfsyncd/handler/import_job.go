@@ -0,0 +1,137 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/presenter"
+	"ac9/glad/services/sfsyncd/usecase/import_job"
+	"ac9/glad/services/sfsyncd/usecase/sf_import"
+
+	"github.com/gorilla/mux"
+	"github.com/urfave/negroni"
+)
+
+// createImportJob queues the import of the Salesforce records of the resource
+// and responds with the job right away; progress is polled using its id
+func createImportJob(service import_job.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error creating import job"
+
+		var records []json.RawMessage
+		tenant := r.Header.Get(common.HttpHeaderTenantID)
+		tenantID, err := id.FromString(tenant)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Missing tenant ID"))
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&records)
+		if err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unable to decode the data. " + err.Error()))
+			return
+		}
+
+		resource := sf_import.Resource(mux.Vars(r)["resource"])
+		job, err := service.CreateJob(tenantID, resource, records)
+		switch err {
+		case nil:
+		case glad.ErrInvalidValue:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Unknown resource " + string(resource)))
+			return
+		default:
+			l.Log.Warnf("Unable to create import job tenantID=%v, err=%v", tenantID, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		response := &presenter.ImportJob{}
+		response.FromEntityImportJob(job)
+
+		w.Header().Set(common.HttpHeaderTenantID, tenant)
+		w.Header().Set("Location", "/v1/import/jobs/"+job.ID.String())
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+	})
+}
+
+// getImportJob reports the progress, per record errors and final counts of
+// the import job
+func getImportJob(service import_job.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading import job"
+
+		tenant := r.Header.Get(common.HttpHeaderTenantID)
+		tenantID, err := id.FromString(tenant)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Missing tenant ID"))
+			return
+		}
+
+		jobID, err := id.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("Invalid job ID"))
+			return
+		}
+
+		job, err := service.GetJob(tenantID, jobID)
+		switch err {
+		case nil:
+		case glad.ErrNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Import job not found"))
+			return
+		default:
+			l.Log.Warnf("Unable to get import job id=%v, err=%v", jobID, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+
+		response := &presenter.ImportJob{}
+		response.FromEntityImportJob(job)
+
+		w.Header().Set(common.HttpHeaderTenantID, tenant)
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(errorMessage))
+			return
+		}
+	})
+}
+
+// MakeImportJobHandlers make import job handlers
+func MakeImportJobHandlers(r *mux.Router, n negroni.Negroni, service import_job.UseCase) {
+	r.Handle("/v1/import/salesforce/{resource}/jobs", n.With(
+		negroni.Wrap(createImportJob(service)),
+	)).Methods(http.MethodPost, http.MethodOptions).Name("createImportJob")
+
+	r.Handle("/v1/import/jobs/{id}", n.With(
+		negroni.Wrap(getImportJob(service)),
+	)).Methods(http.MethodGet, http.MethodOptions).Name("getImportJob")
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/glad/coursed"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/presenter"
+	"ac9/glad/services/sfsyncd/usecase/sf_import"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/negroni"
+)
+
+const tenantAlice id.ID = 13790492210917015554
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+// fakeCoursed fake coursed keeping the course ids by Salesforce id
+type fakeCoursed struct {
+	courses  map[string]int64
+	archived map[string]bool
+	imported []glad.Course
+	rosters  []glad.CourseRoster
+	removed  []string
+}
+
+func (f *fakeCoursed) router() http.Handler {
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/courses/import", func(w http.ResponseWriter, r *http.Request) {
+		var courses []glad.Course
+		_ = json.NewDecoder(r.Body).Decode(&courses)
+		f.imported = append(f.imported, courses...)
+
+		var resp []glad.CourseResponse
+		for _, c := range courses {
+			resp = append(resp, glad.CourseResponse{ID: f.courses[c.ExtID], ExtID: c.ExtID})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}).Methods(http.MethodPost)
+
+	sync := func(archive bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			extID := mux.Vars(r)["extID"]
+			courseID, ok := f.courses[extID]
+			if !ok || r.Header.Get(common.HttpHeaderTenantID) != tenantAlice.String() {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			f.archived[extID] = archive
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": courseID, "extID": extID})
+		}
+	}
+	r.HandleFunc("/v1/courses/import/{extID}", sync(true)).Methods(http.MethodDelete)
+	r.HandleFunc("/v1/courses/import/{extID}/restore", sync(false)).Methods(http.MethodPost)
+
+	r.HandleFunc("/v1/courses/rosters/import", func(w http.ResponseWriter, r *http.Request) {
+		var rosters []glad.CourseRoster
+		_ = json.NewDecoder(r.Body).Decode(&rosters)
+		f.rosters = append(f.rosters, rosters...)
+
+		var resp []glad.CourseRosterResponse
+		for _, roster := range rosters {
+			resp = append(resp, glad.CourseRosterResponse{ID: f.courses[roster.CourseExtID], ExtID: roster.ExtID})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}).Methods(http.MethodPost)
+	r.HandleFunc("/v1/courses/rosters/import/{extID}", func(w http.ResponseWriter, r *http.Request) {
+		extID := mux.Vars(r)["extID"]
+		f.removed = append(f.removed, extID)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": f.courses["a0W000000000001"], "extID": extID})
+	}).Methods(http.MethodDelete)
+	return r
+}
+
+func Test_importCourses(t *testing.T) {
+	fake := &fakeCoursed{
+		courses: map[string]int64{
+			"a0W000000000001": 1001,
+			"a0W000000000002": 1002,
+			"a0W000000000003": 1003,
+			"a0W000000000004": 1004,
+		},
+		archived: map[string]bool{},
+	}
+	coursedServer := httptest.NewServer(fake.router())
+	defer coursedServer.Close()
+
+	r := mux.NewRouter()
+	MakeImportHandlers(r, *negroni.New(), sf_import.NewService(coursed.NewClient(coursedServer.URL, coursed.Config{})))
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	wrappers := []presenter.CourseWrapper{
+		{Object: presenter.SObjectCourse, Operation: "insert", Value: presenter.Course{ExtID: "a0W000000000001"}},
+		{Object: presenter.SObjectCourse, Operation: "DELETE", Value: presenter.Course{ExtID: "a0W000000000002"}},
+		{Object: presenter.SObjectCourse, Operation: "undelete", Value: presenter.Course{ExtID: "a0W000000000003"}},
+		{Object: presenter.SObjectCourse, Operation: "update", Value: presenter.Course{ExtID: "a0W000000000004"}},
+		{Object: presenter.SObjectCourse, Operation: "merge", Value: presenter.Course{ExtID: "a0W000000000005"}},
+		{Object: presenter.SObjectCourse, Operation: "delete", Value: presenter.Course{ExtID: "a0W000000000006"}},
+	}
+	body, _ := json.Marshal(wrappers)
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v1/import/salesforce/courses", bytes.NewReader(body))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d []*presenter.CourseResponse
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&d))
+	assert.Equal(t, len(wrappers), len(d))
+
+	for i, want := range []presenter.CourseResponse{
+		{ID: 1001, ExtID: "a0W000000000001", Action: presenter.ActionUpsert},
+		{ID: 1002, ExtID: "a0W000000000002", Action: presenter.ActionArchive},
+		{ID: 1003, ExtID: "a0W000000000003", Action: presenter.ActionRestore},
+		{ID: 1004, ExtID: "a0W000000000004", Action: presenter.ActionUpsert},
+		{ExtID: "a0W000000000005", IsError: true},
+		{ExtID: "a0W000000000006", IsError: true, Action: presenter.ActionArchive},
+	} {
+		assert.Equal(t, want, *d[i], i)
+	}
+
+	// inserts and updates are imported in a single batch
+	assert.Equal(t, 2, len(fake.imported))
+	assert.True(t, fake.archived["a0W000000000002"])
+	assert.False(t, fake.archived["a0W000000000003"])
+}
+
+func Test_importCourseRosters(t *testing.T) {
+	fake := &fakeCoursed{
+		courses:  map[string]int64{"a0W000000000001": 1001},
+		archived: map[string]bool{},
+	}
+	coursedServer := httptest.NewServer(fake.router())
+	defer coursedServer.Close()
+
+	r := mux.NewRouter()
+	MakeImportHandlers(r, *negroni.New(), sf_import.NewService(coursed.NewClient(coursedServer.URL, coursed.Config{})))
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	wrappers := []presenter.CourseRosterWrapper{
+		{Object: presenter.SObjectCourseTeacher, Operation: "insert", Value: presenter.CourseRoster{
+			ExtID: "a0T000000000001", CourseExtID: "a0W000000000001", TeacherExtID: "003000000000001", IsPrimary: true,
+		}},
+		{Object: presenter.SObjectCourseOrganizer, Operation: "undelete", Value: presenter.CourseRoster{
+			ExtID: "a0O000000000001", CourseExtID: "a0W000000000001", OrganizerExtID: "003000000000002",
+		}},
+		{Object: presenter.SObjectCourseTeacher, Operation: "delete", Value: presenter.CourseRoster{
+			ExtID: "a0T000000000002",
+		}},
+	}
+	body, _ := json.Marshal(wrappers)
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v1/import/salesforce/rosters", bytes.NewReader(body))
+	req.Header.Set(common.HttpHeaderTenantID, tenantAlice.String())
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var d []*presenter.CourseRosterResponse
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&d))
+	assert.Equal(t, len(wrappers), len(d))
+	for i, want := range []presenter.CourseRosterResponse{
+		{ID: 1001, ExtID: "a0T000000000001", Action: presenter.ActionUpsert},
+		{ID: 1001, ExtID: "a0O000000000001", Action: presenter.ActionUpsert},
+		{ID: 1001, ExtID: "a0T000000000002", Action: presenter.ActionRemove},
+	} {
+		assert.Equal(t, want, *d[i], i)
+	}
+
+	// undeleted records are imported again; deleted records are removed
+	assert.Equal(t, []glad.CourseRoster{
+		{
+			ExtID:        "a0T000000000001",
+			CourseExtID:  "a0W000000000001",
+			AccountExtID: "003000000000001",
+			Role:         glad.CourseRoleTeacher,
+			IsPrimary:    true,
+		},
+		{
+			ExtID:        "a0O000000000001",
+			CourseExtID:  "a0W000000000001",
+			AccountExtID: "003000000000002",
+			Role:         glad.CourseRoleOrganizer,
+		},
+	}, fake.rosters)
+	assert.Equal(t, []string{"a0T000000000002"}, fake.removed)
+}
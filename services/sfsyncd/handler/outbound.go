@@ -0,0 +1,168 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/xml"
+	"net/http"
+	"slices"
+
+	"ac9/glad/pkg/glad"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/entity"
+	"ac9/glad/services/sfsyncd/presenter"
+	"ac9/glad/services/sfsyncd/usecase/sf_import"
+	"ac9/glad/services/sfsyncd/usecase/sf_outbound"
+
+	"github.com/gorilla/mux"
+	"github.com/urfave/negroni"
+)
+
+// outboundValue creates the presenter the sObject of the resource is mapped to
+func outboundValue(r sf_import.Resource) interface{} {
+	switch r {
+	case sf_import.ResourceCourse:
+		return &presenter.Course{}
+	case sf_import.ResourceCenter:
+		return &presenter.Center{}
+	case sf_import.ResourceProduct:
+		return &presenter.Product{}
+	case sf_import.ResourceAccount:
+		return &presenter.Account{}
+	case sf_import.ResourceCourseTiming:
+		return &presenter.CourseTiming{}
+	case sf_import.ResourceCourseRoster:
+		return &presenter.CourseRoster{}
+	case sf_import.ResourceCourseParticipant:
+		return &presenter.Participant{}
+	}
+	return nil
+}
+
+// outboundObjects Salesforce objects the resource is notified for
+func outboundObjects(r sf_import.Resource) []string {
+	switch r {
+	case sf_import.ResourceCourse:
+		return []string{presenter.SObjectCourse}
+	case sf_import.ResourceCenter:
+		return []string{presenter.SObjectCenter}
+	case sf_import.ResourceProduct:
+		return []string{presenter.SObjectProduct}
+	case sf_import.ResourceAccount:
+		return []string{presenter.SObjectAccount}
+	case sf_import.ResourceCourseTiming:
+		return []string{presenter.SObjectCourseTiming}
+	case sf_import.ResourceCourseRoster:
+		return []string{presenter.SObjectCourseTeacher, presenter.SObjectCourseOrganizer}
+	case sf_import.ResourceCourseParticipant:
+		return []string{presenter.SObjectParticipant}
+	}
+	return nil
+}
+
+// writeOutboundResponse writes the SOAP response; Salesforce resends the
+// message unless it is acknowledged
+func writeOutboundResponse(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// importOutbound imports the records of a Salesforce Outbound Messaging
+// envelope into the tenant of the org sending it. Salesforce cannot send
+// custom headers; the org authenticates with the secret set as the token query
+// parameter of the endpoint URL. Notifications received before are acknowledged without
+// being applied again. Records rejected by coursed are acknowledged as well;
+// resending them would not change the outcome.
+func importOutbound(service sf_outbound.UseCase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := sf_import.Resource(mux.Vars(r)["resource"])
+		if !resource.IsValid() {
+			writeOutboundResponse(w, http.StatusNotFound,
+				presenter.OutboundFault(true, "Unknown resource "+string(resource)))
+			return
+		}
+
+		var envelope presenter.OutboundEnvelope
+		err := xml.NewDecoder(r.Body).Decode(&envelope)
+		if err != nil {
+			l.Log.Warnf("Unable to decode outbound message. err=%v", err)
+			writeOutboundResponse(w, http.StatusBadRequest,
+				presenter.OutboundFault(true, "Unable to decode the message. "+err.Error()))
+			return
+		}
+
+		orgID := envelope.Notifications.OrganizationID
+		tenantID, err := service.GetTenantID(orgID, r.URL.Query().Get("token"))
+		switch err {
+		case nil:
+		case glad.ErrAuthFailure:
+			l.Log.Warnf("Outbound message of org id=%v with an invalid token", orgID)
+			writeOutboundResponse(w, http.StatusUnauthorized,
+				presenter.OutboundFault(true, "Invalid token"))
+			return
+		default:
+			l.Log.Warnf("Outbound message of unknown org id=%v", orgID)
+			writeOutboundResponse(w, http.StatusForbidden,
+				presenter.OutboundFault(true, "Unknown organization "+orgID))
+			return
+		}
+
+		var notifications []*entity.OutboundNotification
+		for _, n := range envelope.Notifications.Notification {
+			// Note: an outbound message sent to the endpoint of another
+			// resource would import the record as that resource
+			if !slices.Contains(outboundObjects(resource), n.SObject.Object()) {
+				l.Log.Warnf("Unexpected sObject %v for %v, notification id=%v",
+					n.SObject.Object(), resource, n.ID)
+				writeOutboundResponse(w, http.StatusBadRequest,
+					presenter.OutboundFault(true, "Unexpected sObject "+n.SObject.Object()+
+						" for "+string(resource)))
+				return
+			}
+			record, err := n.ToRecord(outboundValue(resource))
+			if err != nil {
+				l.Log.Warnf("Unable to map notification id=%v, err=%v", n.ID, err)
+				writeOutboundResponse(w, http.StatusBadRequest,
+					presenter.OutboundFault(true, "Unable to map notification "+n.ID+". "+err.Error()))
+				return
+			}
+			notifications = append(notifications, &entity.OutboundNotification{
+				ID:     n.ID,
+				Record: record,
+			})
+		}
+
+		results, err := service.Receive(tenantID, resource, notifications)
+		switch err {
+		case nil:
+		case glad.ErrInvalidValue:
+			writeOutboundResponse(w, http.StatusBadRequest,
+				presenter.OutboundFault(true, "Unable to decode the records"))
+			return
+		default:
+			writeOutboundResponse(w, http.StatusInternalServerError,
+				presenter.OutboundFault(false, "Error importing "+string(resource)))
+			return
+		}
+
+		for _, result := range results {
+			if result.IsError {
+				l.Log.Warnf("Outbound %v extID=%v not imported: %v",
+					resource, result.ExtID, result.Message)
+			}
+		}
+		writeOutboundResponse(w, http.StatusOK, presenter.OutboundAck(true))
+	})
+}
+
+// MakeOutboundHandlers make Salesforce Outbound Messaging handlers
+func MakeOutboundHandlers(r *mux.Router, n negroni.Negroni, service sf_outbound.UseCase) {
+	r.Handle("/v1/import/salesforce/{resource}/outbound", n.With(
+		negroni.Wrap(importOutbound(service)),
+	)).Methods(http.MethodPost, http.MethodOptions).Name("importOutbound")
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package handler
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ac9/glad/pkg/glad/coursed"
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/sfsyncd/entity"
+	"ac9/glad/services/sfsyncd/usecase/sf_import"
+	"ac9/glad/services/sfsyncd/usecase/sf_outbound"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/negroni"
+)
+
+// fakeNotifications keeps the received notification ids
+type fakeNotifications map[string]bool
+
+func (f fakeNotifications) Claim(notifications []*entity.OutboundNotification) ([]string, error) {
+	var claimed []string
+	for _, n := range notifications {
+		if !f[n.ID] {
+			f[n.ID] = true
+			claimed = append(claimed, n.ID)
+		}
+	}
+	return claimed, nil
+}
+
+func (f fakeNotifications) Release(tenantID id.ID, notificationIDs []string) error {
+	for _, notificationID := range notificationIDs {
+		delete(f, notificationID)
+	}
+	return nil
+}
+
+func (f fakeNotifications) DeleteBefore(t time.Time) error {
+	return nil
+}
+
+// outboundSecret secret the test org sends its messages with
+const outboundSecret = "6f1c2b0e9a8d4c7f5e3b1a0d9c8e7f6a"
+
+const outboundCourses = `<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"
+    xmlns:xsd="http://www.w3.org/2001/XMLSchema"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+ <soapenv:Body>
+  <notifications xmlns="http://soap.sforce.com/2005/09/outbound">
+   <OrganizationId>00D000000000001</OrganizationId>
+   <ActionId>04k000000000001</ActionId>
+   <SessionId xsi:nil="true"/>
+   <Notification>
+    <Id>04l000000000001</Id>
+    <sObject xsi:type="sf:Workshop__c" xmlns:sf="urn:sobject.enterprise.soap.sforce.com">
+     <sf:Id>a0W000000000001</sf:Id>
+     <sf:Location__c>a0C000000000001</sf:Location__c>
+     <sf:Workshop_Type__c>a0T000000000001</sf:Workshop_Type__c>
+     <sf:Name>Happiness Program</sf:Name>
+     <sf:Notes__c xsi:nil="true"/>
+     <sf:Max_attendees__c>20.0</sf:Max_attendees__c>
+     <sf:LastModifiedDate>2024-05-01T10:20:30.000Z</sf:LastModifiedDate>
+    </sObject>
+   </Notification>
+   <Notification>
+    <Id>04l000000000002</Id>
+    <sObject xsi:type="sf:Workshop__c" xmlns:sf="urn:sobject.enterprise.soap.sforce.com">
+     <sf:Id>a0W000000000002</sf:Id>
+     <sf:Name>Sahaj Samadhi</sf:Name>
+    </sObject>
+   </Notification>
+  </notifications>
+ </soapenv:Body>
+</soapenv:Envelope>`
+
+func Test_importOutbound(t *testing.T) {
+	fake := &fakeCoursed{
+		courses: map[string]int64{
+			"a0W000000000001": 1001,
+			"a0W000000000002": 1002,
+		},
+		archived: map[string]bool{},
+	}
+	coursedServer := httptest.NewServer(fake.router())
+	defer coursedServer.Close()
+
+	r := mux.NewRouter()
+	MakeOutboundHandlers(r, *negroni.New(),
+		sf_outbound.NewService(fakeNotifications{},
+			sf_import.NewService(coursed.NewClient(coursedServer.URL, coursed.Config{})),
+			map[string]sf_outbound.OrgTenant{
+				"00D000000000001": {TenantID: tenantAlice, Secret: outboundSecret},
+			}))
+	path, err := r.GetRoute("importOutbound").GetPathTemplate()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/import/salesforce/{resource}/outbound", path)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	sendWithToken := func(resource string, token string, body string) (*http.Response, string) {
+		req, _ := http.NewRequest(http.MethodPost,
+			ts.URL+"/v1/import/salesforce/"+resource+"/outbound?token="+token,
+			strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		data, _ := io.ReadAll(res.Body)
+		return res, string(data)
+	}
+	send := func(resource string, body string) (*http.Response, string) {
+		return sendWithToken(resource, outboundSecret, body)
+	}
+
+	res, body := send("courses", outboundCourses)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var ack struct {
+		Ack bool `xml:"Body>notificationsResponse>Ack"`
+	}
+	assert.Nil(t, xml.Unmarshal([]byte(body), &ack))
+	assert.True(t, ack.Ack)
+
+	// sObject fields are mapped through the presenter
+	assert.Equal(t, 2, len(fake.imported))
+	course := fake.imported[0]
+	assert.Equal(t, "a0W000000000001", course.ExtID)
+	assert.Equal(t, "a0C000000000001", course.CenterExtID)
+	assert.Equal(t, "a0T000000000001", course.ProductExtID)
+	assert.Equal(t, "Happiness Program", course.Name)
+	assert.Equal(t, 20, course.MaxAttendees)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC), course.UpdatedAt.UTC())
+
+	// resent notifications are acknowledged without being applied again
+	res, body = send("courses", outboundCourses)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, body, "<Ack>true</Ack>")
+	assert.Equal(t, 2, len(fake.imported))
+
+	res, body = send("contacts", outboundCourses)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Contains(t, body, "soapenv:Client")
+
+	res, _ = send("courses", "<soapenv:Envelope")
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// the sObject must be the one of the resource
+	res, body = send("centers", strings.Replace(outboundCourses,
+		"04l000000000001", "04l000000000003", 1))
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Contains(t, body, "Unexpected sObject Workshop__c")
+	assert.Equal(t, 2, len(fake.imported))
+
+	// the tenant is the one of the org sending the message
+	res, body = send("courses", strings.Replace(outboundCourses,
+		"00D000000000001", "00D000000000002", 1))
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	assert.Contains(t, body, "Unknown organization")
+	assert.Equal(t, 2, len(fake.imported))
+
+	// the org id alone does not authenticate the message
+	for _, token := range []string{"", "not-the-secret-of-the-org-000000000"} {
+		res, body = sendWithToken("courses", token, strings.Replace(outboundCourses,
+			"04l000000000001", "04l000000000004", 1))
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		assert.Contains(t, body, "Invalid token")
+	}
+	assert.Equal(t, 2, len(fake.imported))
+}
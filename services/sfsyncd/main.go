@@ -7,6 +7,9 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -16,16 +19,22 @@ import (
 	// Uber zap logging
 	"ac9/glad/pkg/logger"
 	"ac9/glad/services/sfsyncd/handler"
+	"ac9/glad/services/sfsyncd/repository"
+	"ac9/glad/services/sfsyncd/salesforce"
+	"ac9/glad/services/sfsyncd/usecase/import_job"
+	"ac9/glad/services/sfsyncd/usecase/sf_export"
 	"ac9/glad/services/sfsyncd/usecase/sf_import"
+	"ac9/glad/services/sfsyncd/usecase/sf_outbound"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"ac9/glad/config"
+	"ac9/glad/pkg/glad/coursed"
 	"ac9/glad/pkg/metric"
 	"ac9/glad/pkg/middleware"
 	"ac9/glad/pkg/util"
 
-	"github.com/gorilla/context"
+	gorillaContext "github.com/gorilla/context"
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
 	"github.com/urfave/negroni"
@@ -45,8 +54,60 @@ func main() {
 		}
 	}()
 
-	importService := sf_import.NewService(
-		"http://" + util.GetStrEnvOrConfig("COURSED_ADDR", config.COURSED_ADDR))
+	dataSourceName := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		util.GetStrEnvOrConfig("DB_USER", config.DB_USER),
+		util.GetStrEnvOrConfig("DB_PASSWORD", config.DB_PASSWORD),
+		util.GetStrEnvOrConfig("DB_HOST", config.DB_HOST),
+		util.GetIntEnvOrConfig("DB_PORT", config.DB_PORT),
+		util.GetStrEnvOrConfig("DB_DATABASE", config.DB_DATABASE),
+		util.GetStrEnvOrConfig("DB_SSLMODE", config.DB_SSLMODE))
+	db, err := sql.Open("postgres", dataSourceName)
+	if err != nil {
+		Log.Fatalf("Unable to initialize database: %v", err.Error())
+	}
+	defer db.Close()
+
+	coursedClient := coursed.NewClient(
+		"http://"+util.GetStrEnvOrConfig("COURSED_ADDR", config.COURSED_ADDR),
+		coursed.Config{
+			Timeout: time.Duration(util.GetIntEnvOrConfig("COURSED_TIMEOUT_SEC",
+				config.COURSED_TIMEOUT_SEC)) * time.Second,
+			Retries: util.GetIntEnvOrConfig("COURSED_RETRIES", config.COURSED_RETRIES),
+			Token:   util.GetStrEnvOrConfig("AUTH_SERVICE_TOKEN", config.AUTH_SERVICE_TOKEN),
+		})
+	importService := sf_import.NewService(coursedClient)
+
+	// Note: import jobs run in the background, independent of the request
+	// timeouts; unfinished jobs resume on start
+	importJobService := import_job.NewService(repository.NewImportJobPGSQL(db), importService)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = importJobService.Start(ctx,
+		util.GetIntEnvOrConfig("SF_IMPORT_WORKERS", config.SF_IMPORT_WORKERS))
+	if err != nil {
+		Log.Fatalf("Unable to start import jobs: %v", err.Error())
+	}
+
+	orgTenants, err := sf_outbound.ParseOrgTenants(
+		util.GetStrEnvOrConfig("SF_ORG_TENANTS", config.SF_ORG_TENANTS))
+	if err != nil {
+		Log.Fatalf("Unable to parse Salesforce org tenants: %v", err.Error())
+	}
+	outboundService := sf_outbound.NewService(repository.NewOutboundNotificationPGSQL(db),
+		importService, orgTenants)
+
+	sfInstanceURL := util.GetStrEnvOrConfig("SF_INSTANCE_URL", config.SF_INSTANCE_URL)
+	exportService := sf_export.NewService(
+		sf_export.NewCoursedClient(coursedClient),
+		salesforce.NewClient(sfInstanceURL,
+			util.GetStrEnvOrConfig("SF_API_VERSION", config.SF_API_VERSION),
+			util.GetStrEnvOrConfig("SF_ACCESS_TOKEN", config.SF_ACCESS_TOKEN)))
+
+	// Note: sfsyncd does not own the accounts; the callers are not resolved
+	authenticate, err := middleware.Authentication(nil)
+	if err != nil {
+		Log.Fatalf("Unable to initialize authentication: %v", err.Error())
+	}
 
 	metricService, err := metric.NewPrometheusService()
 	if err != nil {
@@ -61,9 +122,12 @@ func main() {
 		negroni.NewLogger(),
 	)
 	n.Use(&middleware.APILogging{Log: Log})
+	// Note: the routes are authenticated but for the health checks and the
+	// outbound messages, which Salesforce sends with the secret of the org
+	authn := n.With(authenticate)
 
 	// log handler
-	logger.MakeLogHandlers(r, *n, "sfsyncd", Log)
+	logger.MakeLogHandlers(r, *authn, "sfsyncd", Log)
 
 	http.Handle("/", r)
 	http.Handle("/metrics", promhttp.Handler())
@@ -76,20 +140,29 @@ func main() {
 	})
 
 	// log handler
-	logger.MakeLogHandlers(r, *n, "sfsyncd", Log)
+	logger.MakeLogHandlers(r, *authn, "sfsyncd", Log)
 
 	// info handler
-	util.MakeInfoHandlers(r, *n, "sfsyncd")
+	util.MakeInfoHandlers(r, *authn, "sfsyncd")
 
 	// import handler
-	handler.MakeImportHandlers(r, *n, importService)
+	handler.MakeImportHandlers(r, *authn, importService)
+	handler.MakeImportJobHandlers(r, *authn, importJobService)
+	handler.MakeOutboundHandlers(r, *n, outboundService)
+
+	// export handler
+	if sfInstanceURL != "" {
+		handler.MakeExportHandlers(r, *authn, exportService)
+	} else {
+		Log.Warnf("Salesforce instance url is not set; export is disabled")
+	}
 
 	logger := log.New(os.Stderr, "logger: ", log.Lshortfile)
 	srv := &http.Server{
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		Addr:         ":" + strconv.Itoa(util.GetIntEnvOrConfig("API_PORT", config.API_PORT)),
-		Handler:      context.ClearHandler(http.DefaultServeMux),
+		Handler:      gorillaContext.ClearHandler(http.DefaultServeMux),
 		ErrorLog:     logger,
 	}
 	Log.Infof("Starting server at %v ...", srv.Addr)
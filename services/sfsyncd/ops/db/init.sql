@@ -0,0 +1,60 @@
+-- Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+-- All rights reserved.
+-- This code may not be used, copied, modified, or distributed without explicit permission.
+
+CREATE DATABASE glad_sfsyncd
+    WITH
+    OWNER = glad_user
+    ENCODING = 'UTF8'
+    LC_COLLATE = 'en_US.UTF-8'
+    LC_CTYPE = 'en_US.UTF-8'
+    TEMPLATE = template0
+    CONNECTION LIMIT = -1;
+
+-- Connect to the new database (only works in psql)
+\c glad_sfsyncd;
+
+-- Create custom ENUM types (add these before the table creation)
+CREATE TYPE import_job_status AS ENUM ('queued', 'running', 'completed', 'failed');
+
+-- Asynchronous import of the Salesforce records of a resource. The payload is
+-- kept so that unfinished jobs resume after a restart from the processed offset.
+CREATE TABLE IF NOT EXISTS import_job (
+    id BIGINT PRIMARY KEY,
+    tenant_id BIGINT NOT NULL,
+
+    -- coursed resource, e.g. courses
+    resource VARCHAR(32) NOT NULL,
+    status import_job_status NOT NULL DEFAULT 'queued',
+    payload JSONB NOT NULL,
+
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    succeeded INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    -- per record errors
+    errors JSONB,
+    message TEXT,
+    -- a running job is owned by a worker until then; expired leases are
+    -- claimed again
+    lease_until TIMESTAMP,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_import_job_tenant_id ON import_job(tenant_id);
+CREATE INDEX idx_import_job_status ON import_job(status);
+
+-- Salesforce Outbound Messaging notifications claimed to be applied. Salesforce
+-- resends a notification until it is acknowledged; resent ones are skipped.
+CREATE TABLE IF NOT EXISTS outbound_notification (
+    -- Salesforce notification id
+    id VARCHAR(32) NOT NULL,
+    tenant_id BIGINT NOT NULL,
+    resource VARCHAR(32) NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (tenant_id, id)
+);
+CREATE INDEX idx_outbound_notification_created_at ON outbound_notification(created_at);
@@ -7,12 +7,20 @@
 package presenter
 
 import (
+	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"time"
 
 	"github.com/ulule/deepcopier"
 )
 
+// AccountWrapper Salesforce operation on a account
+type AccountWrapper struct {
+	Object    string  `json:"object"`
+	Operation string  `json:"operation"`
+	Value     Account `json:"value"`
+}
+
 type Account struct {
 	ExtID        string `json:"Id"`
 	CognitoID    string `json:"Cognito_User_Id__c"`
@@ -35,9 +43,27 @@ type AccountResponse struct {
 	ID      int64  `json:"id"`
 	ExtID   string `json:"extID"`
 	IsError bool   `json:"isError"`
+	// Action action taken for the Salesforce operation
+	Action Action `json:"action,omitempty"`
 }
 
 // ToGladAccount populates glad account using presenter account
 func (c Account) ToGladAccount(gc *glad.Account) {
 	deepcopier.Copy(c).To(gc)
 }
+
+// FromEntityAccount populates Salesforce account using glad account
+func (c *Account) FromEntityAccount(e *entity.Account) {
+	c.ExtID = e.ExtID
+	c.CognitoID = e.CognitoID
+	c.Username = e.Username
+	c.FirstName = e.FirstName
+	c.LastName = e.LastName
+	c.Phone = e.Phone
+	c.Email = e.Email
+	c.Type = string(e.Type)
+	c.Status = string(e.Status)
+	c.FullPhotoURL = e.FullPhotoURL
+	c.CreatedAt = e.CreatedAt
+	c.UpdatedAt = e.UpdatedAt
+}
@@ -7,12 +7,20 @@
 package presenter
 
 import (
+	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"time"
 
 	"github.com/ulule/deepcopier"
 )
 
+// CenterWrapper Salesforce operation on a center
+type CenterWrapper struct {
+	Object    string `json:"object"`
+	Operation string `json:"operation"`
+	Value     Center `json:"value"`
+}
+
 type Center struct {
 	ExtID       string    `json:"Id"`
 	ExtName     string    `json:"Name"`
@@ -38,6 +46,8 @@ type CenterResponse struct {
 	ID      int64  `json:"id"`
 	ExtID   string `json:"extID"`
 	IsError bool   `json:"isError"`
+	// Action action taken for the Salesforce operation
+	Action Action `json:"action,omitempty"`
 }
 
 // ToGladCenter populates glad center using presenter center
@@ -54,3 +64,30 @@ func (c Center) ToGladCenter(gc *glad.Center) {
 	gc.GeoLocation.Latitude = c.Latitude
 	gc.GeoLocation.Longitude = c.Longitude
 }
+
+// FromEntityCenter populates Salesforce center using glad center
+func (c *Center) FromEntityCenter(e *entity.Center) {
+	c.ExtID = e.ExtID
+	c.ExtName = e.ExtName
+	c.Name = e.Name
+
+	c.Address1 = e.Address.Street1
+	c.Address2 = e.Address.Street2
+	c.City = e.Address.City
+	c.State = e.Address.State
+	c.PostalCode = e.Address.Zip
+	c.Country = e.Address.Country
+
+	c.Latitude = e.GeoLocation.Lat
+	c.Longitude = e.GeoLocation.Long
+
+	c.MaxCapacity = e.Capacity
+	if e.Mode != entity.CenterNotSet {
+		c.Mode = string(e.Mode)
+	}
+	c.IsNational = e.IsNational
+	c.IsEnabled = e.IsEnabled
+	c.CenterURL = e.WebPage
+	c.CreatedAt = e.CreatedAt
+	c.UpdatedAt = e.UpdatedAt
+}
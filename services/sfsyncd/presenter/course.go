@@ -1,6 +1,7 @@
 package presenter
 
 import (
+	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"time"
 
@@ -41,6 +42,8 @@ type CourseResponse struct {
 	ID      int64  `json:"id"`
 	ExtID   string `json:"extID"`
 	IsError bool   `json:"isError"`
+	// Action action taken for the Salesforce operation
+	Action Action `json:"action,omitempty"`
 }
 
 // ToGladCourse populates glad center using presenter center
@@ -54,3 +57,35 @@ func (c Course) ToGladCourse(gc *glad.Course) {
 	gc.Address.Zip = c.PostalCode
 	gc.Address.Country = c.Country
 }
+
+// FromEntityCourse populates Salesforce course using glad course
+// Center and product are referred to by their Salesforce ids.
+func (c *Course) FromEntityCourse(e *entity.Course, centerExtID, productExtID string) {
+	if e.ExtID != nil {
+		c.ExtID = *e.ExtID
+	}
+	c.CenterExtID = centerExtID
+	c.ProductExtID = productExtID
+	c.Name = e.Name
+	c.Notes = e.Notes
+	// Salesforce keeps the abbreviations of the US zones
+	c.Timezone = entity.TimezoneAbbreviation(e.Timezone)
+
+	c.Address1 = e.Address.Street1
+	c.Address2 = e.Address.Street2
+	c.City = e.Address.City
+	c.State = e.Address.State
+	c.PostalCode = e.Address.Zip
+	c.Country = e.Address.Country
+
+	c.Status = string(e.Status)
+	if e.Mode != entity.CourseNotSet {
+		c.Mode = string(e.Mode)
+	}
+	c.MaxAttendees = int(e.MaxAttendees)
+	c.NumAttendees = int(e.NumAttendees)
+	c.URL = e.URL
+	c.CheckoutURL = e.CheckoutURL
+	c.CreatedAt = e.CreatedAt
+	c.UpdatedAt = e.UpdatedAt
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"ac9/glad/pkg/glad"
+)
+
+// Salesforce junction objects of the course roster
+const (
+	SObjectCourseTeacher   = "Workshop_Teacher__c"
+	SObjectCourseOrganizer = "Workshop_Organizer__c"
+)
+
+type CourseRosterWrapper struct {
+	Object    string       `json:"object"`
+	Operation string       `json:"operation"`
+	Value     CourseRoster `json:"value"`
+}
+
+// CourseRoster teacher or organizer of a course
+type CourseRoster struct {
+	ExtID          string `json:"Id"`
+	CourseExtID    string `json:"Workshop__c"`
+	TeacherExtID   string `json:"Teacher__c"`
+	OrganizerExtID string `json:"Organizer__c"`
+	IsPrimary      bool   `json:"Is_Primary__c"`
+}
+
+type CourseRosterResponse struct {
+	ID      int64  `json:"id"`
+	ExtID   string `json:"extID"`
+	IsError bool   `json:"isError"`
+	// Action action taken for the Salesforce operation
+	Action Action `json:"action,omitempty"`
+}
+
+// ToGladCourseRoster populates glad course roster using the presenter
+// The role follows the junction object and, when the object is not known,
+// the account field that is set.
+func (w CourseRosterWrapper) ToGladCourseRoster(gr *glad.CourseRoster) {
+	gr.ExtID = w.Value.ExtID
+	gr.CourseExtID = w.Value.CourseExtID
+	gr.IsPrimary = w.Value.IsPrimary
+
+	isOrganizer := w.Object == SObjectCourseOrganizer ||
+		(w.Object != SObjectCourseTeacher && w.Value.OrganizerExtID != "")
+	if isOrganizer {
+		gr.Role = glad.CourseRoleOrganizer
+		gr.AccountExtID = w.Value.OrganizerExtID
+		return
+	}
+	gr.Role = glad.CourseRoleTeacher
+	gr.AccountExtID = w.Value.TeacherExtID
+}
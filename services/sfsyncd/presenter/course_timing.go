@@ -0,0 +1,60 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"strings"
+	"time"
+
+	"ac9/glad/pkg/glad"
+)
+
+// SObjectCourseTiming Salesforce object of the course timings
+const SObjectCourseTiming = "Workshop_Timing__c"
+
+type CourseTimingWrapper struct {
+	Object    string       `json:"object"`
+	Operation string       `json:"operation"`
+	Value     CourseTiming `json:"value"`
+}
+
+// CourseTiming session of a course
+type CourseTiming struct {
+	ExtID       string    `json:"Id"`
+	CourseExtID string    `json:"Workshop__c"`
+	Date        string    `json:"Date__c"`
+	StartTime   string    `json:"Start_Time__c"`
+	EndTime     string    `json:"End_Time__c"`
+	CreatedAt   time.Time `json:"CreatedDate"`
+	UpdatedAt   time.Time `json:"LastModifiedDate"`
+}
+
+type CourseTimingResponse struct {
+	ID      int64  `json:"id"`
+	ExtID   string `json:"extID"`
+	IsError bool   `json:"isError"`
+	// Action action taken for the Salesforce operation
+	Action Action `json:"action,omitempty"`
+}
+
+// ToGladCourseTiming populates glad course timing using presenter course timing
+func (ct CourseTiming) ToGladCourseTiming(gct *glad.CourseTiming) {
+	gct.ExtID = ct.ExtID
+	gct.CourseExtID = ct.CourseExtID
+	gct.Date = ct.Date
+	gct.StartTime = fromSalesforceTime(ct.StartTime)
+	gct.EndTime = fromSalesforceTime(ct.EndTime)
+	gct.CreatedAt = ct.CreatedAt
+	gct.UpdatedAt = ct.UpdatedAt
+}
+
+// fromSalesforceTime converts the Salesforce time, e.g. 09:30:00.000Z, to
+// HH:MM:SS
+func fromSalesforceTime(t string) string {
+	t, _, _ = strings.Cut(strings.TrimSuffix(t, "Z"), ".")
+	return t
+}
@@ -0,0 +1,27 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"ac9/glad/pkg/id"
+)
+
+// ExportResponse outcome of exporting an entity to Salesforce
+type ExportResponse struct {
+	Entity    string `json:"entity"`
+	ID        id.ID  `json:"id"`
+	ExtID     string `json:"extID"`
+	IsCreated bool   `json:"isCreated"`
+	IsError   bool   `json:"isError"`
+}
+
+// Export response of the export request. Next is to be passed as after in
+// the next request.
+type Export struct {
+	Next    int64             `json:"next"`
+	Records []*ExportResponse `json:"records"`
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"time"
+
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/sfsyncd/entity"
+)
+
+// ImportRecordError failure of a single record of an import job
+type ImportRecordError struct {
+	Index   int    `json:"index"`
+	ExtID   string `json:"extID"`
+	Action  string `json:"action,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportJob status and progress of an import job
+type ImportJob struct {
+	ID        id.ID               `json:"id"`
+	Resource  string              `json:"resource"`
+	Status    string              `json:"status"`
+	Total     int                 `json:"total"`
+	Processed int                 `json:"processed"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Errors    []ImportRecordError `json:"errors"`
+	Message   string              `json:"message,omitempty"`
+	CreatedAt time.Time           `json:"createdAt"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+}
+
+// FromEntityImportJob populates the import job using the entity
+func (j *ImportJob) FromEntityImportJob(e *entity.ImportJob) {
+	j.ID = e.ID
+	j.Resource = e.Resource
+	j.Status = string(e.Status)
+	j.Total = e.Total
+	j.Processed = e.Processed
+	j.Succeeded = e.Succeeded
+	j.Failed = e.Failed
+	j.Errors = make([]ImportRecordError, len(e.Errors))
+	for i, recordError := range e.Errors {
+		j.Errors[i] = ImportRecordError(recordError)
+	}
+	j.Message = e.Message
+	j.CreatedAt = e.CreatedAt
+	j.UpdatedAt = e.UpdatedAt
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"strings"
+
+	"ac9/glad/pkg/glad"
+)
+
+// Salesforce operations on a record
+// Note: Apex triggers send insert, while change data capture sends create.
+const (
+	OperationInsert   = "insert"
+	OperationCreate   = "create"
+	OperationUpdate   = "update"
+	OperationDelete   = "delete"
+	OperationUndelete = "undelete"
+)
+
+// Action action taken in GLAD for a Salesforce record
+type Action string
+
+const (
+	ActionUpsert  Action = "upsert"
+	ActionArchive Action = "archive"
+	ActionRestore Action = "restore"
+	// ActionRemove records that are not archived, e.g. course timings, are
+	// removed when deleted in Salesforce
+	ActionRemove Action = "remove"
+)
+
+// ToAction maps the Salesforce operation to the action taken in GLAD
+// Operations are matched case insensitively. Returns glad.ErrInvalidValue for
+// an unknown operation.
+func ToAction(operation string) (Action, error) {
+	switch strings.ToLower(operation) {
+	case OperationInsert, OperationCreate, OperationUpdate:
+		return ActionUpsert, nil
+	case OperationDelete:
+		return ActionArchive, nil
+	case OperationUndelete:
+		return ActionRestore, nil
+	}
+	return "", glad.ErrInvalidValue
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Salesforce Outbound Messaging
+// Salesforce posts a SOAP envelope with up to 100 notifications and resends it
+// until the notifications are acknowledged. Only creates and updates are
+// notified, so every notification is imported as an update.
+const (
+	xmlnsSoapEnv  = "http://schemas.xmlsoap.org/soap/envelope/"
+	xmlnsOutbound = "http://soap.sforce.com/2005/09/outbound"
+)
+
+// OutboundEnvelope SOAP envelope sent by Salesforce Outbound Messaging
+type OutboundEnvelope struct {
+	XMLName       xml.Name              `xml:"http://schemas.xmlsoap.org/soap/envelope/ Envelope"`
+	Notifications OutboundNotifications `xml:"Body>notifications"`
+}
+
+// OutboundNotifications notifications of an outbound message
+type OutboundNotifications struct {
+	OrganizationID string                 `xml:"OrganizationId"`
+	ActionID       string                 `xml:"ActionId"`
+	Notification   []OutboundNotification `xml:"Notification"`
+}
+
+// OutboundNotification change to a Salesforce record; ID is the notification
+// id, which stays the same when the notification is resent
+type OutboundNotification struct {
+	ID      string          `xml:"Id"`
+	SObject OutboundSObject `xml:"sObject"`
+}
+
+// OutboundSObject fields of the Salesforce record selected for the message
+type OutboundSObject struct {
+	// Type is the object type prefixed by the namespace, e.g. sf:Workshop__c
+	Type   string          `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+	Fields []OutboundField `xml:",any"`
+}
+
+// OutboundField field of the Salesforce record
+type OutboundField struct {
+	XMLName xml.Name
+	Nil     bool   `xml:"http://www.w3.org/2001/XMLSchema-instance nil,attr"`
+	Value   string `xml:",chardata"`
+}
+
+// Object gets the Salesforce object type without the namespace prefix
+func (s OutboundSObject) Object() string {
+	if i := strings.LastIndex(s.Type, ":"); i >= 0 {
+		return s.Type[i+1:]
+	}
+	return s.Type
+}
+
+// Values gets the field values by field name; nil fields are left out
+func (s OutboundSObject) Values() map[string]string {
+	values := make(map[string]string, len(s.Fields))
+	for _, f := range s.Fields {
+		if !f.Nil {
+			values[f.XMLName.Local] = f.Value
+		}
+	}
+	return values
+}
+
+// ToRecord converts the notification into the record imported by sf_import,
+// the same as the JSON sent by the Apex triggers. value is the presenter of
+// the object, e.g. *Course; its fields are mapped through the json tags.
+func (n OutboundNotification) ToRecord(value interface{}) (json.RawMessage, error) {
+	err := FromSObjectFields(n.SObject.Values(), value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Object    string      `json:"object"`
+		Operation string      `json:"operation"`
+		Value     interface{} `json:"value"`
+	}{
+		Object:    n.SObject.Object(),
+		Operation: OperationUpdate,
+		Value:     value,
+	})
+}
+
+// FromSObjectFields sets the fields of the presenter pointed to by v from the
+// Salesforce field values, matching the field names with the json tags.
+// Fields that are not in the presenter are ignored.
+func FromSObjectFields(values map[string]string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected pointer to struct, got %T", v)
+	}
+	rv = rv.Elem()
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+		value, ok := values[name]
+		if name == "" || name == "-" || !ok {
+			continue
+		}
+		err := setSObjectField(rv.Field(i), value)
+		if err != nil {
+			return fmt.Errorf("field %v: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setSObjectField parses the Salesforce value into the field
+func setSObjectField(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			// date fields have no time
+			t, err = time.Parse(time.DateOnly, value)
+		}
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Salesforce numbers are decimals, e.g. 20.0
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %v", field.Type())
+	}
+	return nil
+}
+
+// OutboundAck SOAP response acknowledging the notifications. Salesforce
+// resends the message unless ack is true.
+func OutboundAck(ack bool) []byte {
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>`+
+		`<soapenv:Envelope xmlns:soapenv="%s"><soapenv:Body>`+
+		`<notificationsResponse xmlns="%s"><Ack>%v</Ack></notificationsResponse>`+
+		`</soapenv:Body></soapenv:Envelope>`, xmlnsSoapEnv, xmlnsOutbound, ack))
+}
+
+// OutboundFault SOAP fault reporting why the notifications were not processed
+// Client faults are for messages that cannot be processed as sent.
+func OutboundFault(client bool, message string) []byte {
+	code := "soapenv:Server"
+	if client {
+		code = "soapenv:Client"
+	}
+	var escaped bytes.Buffer
+	_ = xml.EscapeText(&escaped, []byte(message))
+
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>`+
+		`<soapenv:Envelope xmlns:soapenv="%s"><soapenv:Body><soapenv:Fault>`+
+		`<faultcode>%s</faultcode><faultstring>%s</faultstring>`+
+		`</soapenv:Fault></soapenv:Body></soapenv:Envelope>`, xmlnsSoapEnv, code, escaped.String()))
+}
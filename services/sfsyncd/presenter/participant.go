@@ -0,0 +1,50 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"time"
+
+	"ac9/glad/pkg/glad"
+)
+
+// SObjectParticipant Salesforce object of the course participants
+const SObjectParticipant = "Workshop_Participant__c"
+
+type ParticipantWrapper struct {
+	Object    string      `json:"object"`
+	Operation string      `json:"operation"`
+	Value     Participant `json:"value"`
+}
+
+// Participant registration of an account to a course
+type Participant struct {
+	ExtID        string    `json:"Id"`
+	CourseExtID  string    `json:"Workshop__c"`
+	AccountExtID string    `json:"Account__c"`
+	Email        string    `json:"Email__c"`
+	CreatedAt    time.Time `json:"CreatedDate"`
+	UpdatedAt    time.Time `json:"LastModifiedDate"`
+}
+
+type ParticipantResponse struct {
+	ID      int64  `json:"id"`
+	ExtID   string `json:"extID"`
+	IsError bool   `json:"isError"`
+	// Action action taken for the Salesforce operation
+	Action Action `json:"action,omitempty"`
+}
+
+// ToGladParticipant populates glad participant using presenter participant
+func (p Participant) ToGladParticipant(gp *glad.Participant) {
+	gp.ExtID = p.ExtID
+	gp.CourseExtID = p.CourseExtID
+	gp.AccountExtID = p.AccountExtID
+	gp.Email = p.Email
+	gp.CreatedAt = p.CreatedAt
+	gp.UpdatedAt = p.UpdatedAt
+}
@@ -11,6 +11,9 @@ import (
 	"time"
 )
 
+// SObjectProduct Salesforce object of the products
+const SObjectProduct = "Master__c"
+
 // Note: There is no need to have this wrapper if salesforce can use HTTP verbs
 // and paths. Salesforce already uses different paths, so verb can be easily added
 // and wrapper can be get rid of.
@@ -41,4 +44,6 @@ type ProductResponse struct {
 	ID      int64  `json:"id"`
 	ExtID   string `json:"extID"`
 	IsError bool   `json:"isError"`
+	// Action action taken for the Salesforce operation
+	Action Action `json:"action,omitempty"`
 }
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package presenter
+
+import (
+	"encoding/json"
+)
+
+// Salesforce objects the glad entities are exported to
+const (
+	SObjectCourse  = "Workshop__c"
+	SObjectCenter  = "Center__c"
+	SObjectAccount = "Account"
+)
+
+// sfSystemFields are set by Salesforce and cannot be written
+var sfSystemFields = []string{"Id", "CreatedDate", "LastModifiedDate"}
+
+// SalesforceFields converts the presenter into the fields of a Salesforce
+// record. System fields and the given read only fields are left out.
+func SalesforceFields(v interface{}, readOnly ...string) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range append(sfSystemFields, readOnly...) {
+		delete(fields, f)
+	}
+	return fields, nil
+}
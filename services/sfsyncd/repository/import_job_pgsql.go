@@ -0,0 +1,204 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/entity"
+)
+
+// ImportJobPGSQL pgsql repo
+type ImportJobPGSQL struct {
+	db *sql.DB
+}
+
+// NewImportJobPGSQL create new repository
+func NewImportJobPGSQL(db *sql.DB) *ImportJobPGSQL {
+	return &ImportJobPGSQL{
+		db: db,
+	}
+}
+
+// Create creates an import job
+func (r *ImportJobPGSQL) Create(e *entity.ImportJob) (id.ID, error) {
+	stmt, err := r.db.Prepare(`
+		INSERT INTO import_job
+			(
+				id, tenant_id, resource, status, payload,
+				total, processed, succeeded, failed,
+				created_at, updated_at
+			)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);`)
+	if err != nil {
+		l.Log.Errorf("err=%#v", err)
+		return id.IDInvalid, err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(
+		e.ID,
+		e.TenantID,
+		e.Resource,
+		string(e.Status),
+		string(e.Payload),
+		e.Total,
+		e.Processed,
+		e.Succeeded,
+		e.Failed,
+		e.CreatedAt.Format(common.DBFormatDateTimeMS),
+		e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+	)
+	if err != nil {
+		l.Log.Errorf("err=%#v", err)
+		return id.IDInvalid, err
+	}
+	return e.ID, nil
+}
+
+// Get gets an import job; nil when not found
+func (r *ImportJobPGSQL) Get(jobID id.ID) (*entity.ImportJob, error) {
+	stmt, err := r.db.Prepare(`
+		SELECT
+			id, tenant_id, resource, status, payload,
+			total, processed, succeeded, failed, errors, message,
+			lease_until, created_at, updated_at
+		FROM import_job
+		WHERE id = $1;`)
+	if err != nil {
+		l.Log.Errorf("err=%#v", err)
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var e entity.ImportJob
+	var status, payload string
+	var errors, message sql.NullString
+	var leaseUntil sql.NullTime
+	err = stmt.QueryRow(jobID).Scan(
+		&e.ID,
+		&e.TenantID,
+		&e.Resource,
+		&status,
+		&payload,
+		&e.Total,
+		&e.Processed,
+		&e.Succeeded,
+		&e.Failed,
+		&errors,
+		&message,
+		&leaseUntil,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		l.Log.Errorf("err=%#v", err)
+		return nil, err
+	}
+
+	e.Status = entity.ImportJobStatus(status)
+	e.Payload = json.RawMessage(payload)
+	e.Message = message.String
+	e.LeaseUntil = leaseUntil.Time
+	if errors.Valid {
+		err = json.Unmarshal([]byte(errors.String), &e.Errors)
+		if err != nil {
+			l.Log.Errorf("Unable to decode errors of import job id=%v, err=%v", jobID, err)
+			return nil, err
+		}
+	}
+	return &e, nil
+}
+
+// Claim marks the job running and owned until leaseUntil when it is queued or
+// its lease expired; the update is atomic, hence only one worker claims it
+func (r *ImportJobPGSQL) Claim(jobID id.ID, now time.Time, leaseUntil time.Time) (bool, error) {
+	var claimed id.ID
+	err := r.db.QueryRow(`
+		UPDATE import_job
+		SET status = 'running', lease_until = $2
+		WHERE id = $1
+			AND (status = 'queued'
+				OR (status = 'running' AND (lease_until IS NULL OR lease_until < $3)))
+		RETURNING id;`,
+		jobID,
+		leaseUntil.Format(common.DBFormatDateTimeMS),
+		now.Format(common.DBFormatDateTimeMS),
+	).Scan(&claimed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		l.Log.Errorf("err=%#v", err)
+		return false, err
+	}
+	return true, nil
+}
+
+// ListUnfinished lists the ids of the queued and running jobs, oldest first
+func (r *ImportJobPGSQL) ListUnfinished() ([]id.ID, error) {
+	rows, err := r.db.Query(`
+		SELECT id FROM import_job
+		WHERE status IN ('queued', 'running')
+		ORDER BY created_at;`)
+	if err != nil {
+		l.Log.Errorf("err=%#v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []id.ID
+	for rows.Next() {
+		var jobID id.ID
+		err = rows.Scan(&jobID)
+		if err != nil {
+			l.Log.Errorf("err=%#v", err)
+			return nil, err
+		}
+		ids = append(ids, jobID)
+	}
+	return ids, rows.Err()
+}
+
+// Update saves the status, progress and lease of the job; payload is not changed
+func (r *ImportJobPGSQL) Update(e *entity.ImportJob) error {
+	errors, err := json.Marshal(e.Errors)
+	if err != nil {
+		l.Log.Errorf("err=%v", err)
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		UPDATE import_job
+		SET status = $1, total = $2, processed = $3, succeeded = $4, failed = $5,
+			errors = $6, message = $7, lease_until = $8, updated_at = $9
+		WHERE id = $10;`,
+		string(e.Status),
+		e.Total,
+		e.Processed,
+		e.Succeeded,
+		e.Failed,
+		string(errors),
+		e.Message,
+		e.LeaseUntil.Format(common.DBFormatDateTimeMS),
+		e.UpdatedAt.Format(common.DBFormatDateTimeMS),
+		e.ID,
+	)
+	if err != nil {
+		l.Log.Errorf("err=%#v", err)
+		return err
+	}
+	return nil
+}
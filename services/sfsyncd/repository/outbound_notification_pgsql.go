@@ -0,0 +1,108 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/pkg/util"
+	"ac9/glad/services/sfsyncd/entity"
+)
+
+// OutboundNotificationPGSQL pgsql repo
+type OutboundNotificationPGSQL struct {
+	db *sql.DB
+}
+
+// NewOutboundNotificationPGSQL create new repository
+func NewOutboundNotificationPGSQL(db *sql.DB) *OutboundNotificationPGSQL {
+	return &OutboundNotificationPGSQL{
+		db: db,
+	}
+}
+
+// Claim keeps the ids of the notifications and returns the ids not kept before
+// Note: a notification repeated within the batch is inserted once
+func (r *OutboundNotificationPGSQL) Claim(notifications []*entity.OutboundNotification) ([]string, error) {
+	seen := map[string]bool{}
+	var unique []*entity.OutboundNotification
+	for _, n := range notifications {
+		if !seen[n.ID] {
+			seen[n.ID] = true
+			unique = append(unique, n)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, nil
+	}
+
+	query, args := util.GenBulkInsertPGSQL("outbound_notification",
+		[]string{"id", "tenant_id", "resource", "created_at"},
+		len(unique),
+		func(index int) []interface{} {
+			n := unique[index]
+			return []interface{}{n.ID, n.TenantID, n.Resource,
+				n.CreatedAt.Format(common.DBFormatDateTimeMS)}
+		})
+	rows, err := r.db.Query(query+" ON CONFLICT DO NOTHING RETURNING id;", args...)
+	if err != nil {
+		l.Log.Errorf("err=%#v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var claimed []string
+	for rows.Next() {
+		var notificationID string
+		err = rows.Scan(&notificationID)
+		if err != nil {
+			l.Log.Errorf("err=%#v", err)
+			return nil, err
+		}
+		claimed = append(claimed, notificationID)
+	}
+	return claimed, rows.Err()
+}
+
+// Release drops the ids of the claimed notifications
+func (r *OutboundNotificationPGSQL) Release(tenantID id.ID, notificationIDs []string) error {
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(notificationIDs))
+	args := []interface{}{tenantID}
+	for i, notificationID := range notificationIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args = append(args, notificationID)
+	}
+	_, err := r.db.Exec(`
+		DELETE FROM outbound_notification
+		WHERE tenant_id = $1 AND id IN (`+strings.Join(placeholders, ",")+`);`, args...)
+	if err != nil {
+		l.Log.Errorf("err=%#v", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteBefore drops the notifications received before t
+func (r *OutboundNotificationPGSQL) DeleteBefore(t time.Time) error {
+	_, err := r.db.Exec(`DELETE FROM outbound_notification WHERE created_at < $1;`,
+		t.Format(common.DBFormatDateTimeMS))
+	if err != nil {
+		l.Log.Errorf("err=%#v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package salesforce
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	l "ac9/glad/pkg/logger"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Client Salesforce REST API client
+// Note: The access token is obtained outside of sfsyncd (e.g. connected app
+// with the client credentials flow) and passed in the configuration.
+type Client struct {
+	c           *fasthttp.Client
+	instanceURL string
+	version     string
+	token       string
+}
+
+// NewClient creates a new client
+// instanceURL is the org url (e.g. https://ac9.my.salesforce.com) and version
+// the REST API version (e.g. 60.0).
+func NewClient(instanceURL, version, token string) *Client {
+	return &Client{
+		c:           &fasthttp.Client{},
+		instanceURL: strings.TrimSuffix(instanceURL, "/"),
+		version:     version,
+		token:       token,
+	}
+}
+
+// createResponse response of the sobject create request
+type createResponse struct {
+	ID      string  `json:"id"`
+	Success bool    `json:"success"`
+	Errors  []Error `json:"errors"`
+}
+
+// Error error returned by the REST API
+type Error struct {
+	StatusCode int      `json:"-"`
+	ErrorCode  string   `json:"errorCode"`
+	Message    string   `json:"message"`
+	Fields     []string `json:"fields,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("salesforce: status=%v, errorCode=%v, message=%v",
+		e.StatusCode, e.ErrorCode, e.Message)
+}
+
+// Create creates a record of the sobject and returns its id
+func (c *Client) Create(object string, fields map[string]interface{}) (string, error) {
+	path := c.sobjectPath(object)
+	l.Log.Debugf("path=%v", path)
+
+	resp, err := c.do(http.MethodPost, path, fields)
+	if err != nil {
+		return "", err
+	}
+	defer fasthttp.ReleaseResponse(resp)
+
+	if resp.StatusCode() != fasthttp.StatusCreated {
+		return "", apiError(resp)
+	}
+
+	var created createResponse
+	err = json.Unmarshal(resp.Body(), &created)
+	if err != nil {
+		l.Log.Warnf("Unable to decode create response. err=%v", err)
+		return "", err
+	}
+	if !created.Success || created.ID == "" {
+		e := &Error{StatusCode: resp.StatusCode(), Message: "record not created"}
+		if len(created.Errors) > 0 {
+			*e = created.Errors[0]
+			e.StatusCode = resp.StatusCode()
+		}
+		return "", e
+	}
+	return created.ID, nil
+}
+
+// Update updates the fields of an existing record
+func (c *Client) Update(object string, extID string, fields map[string]interface{}) error {
+	path := c.sobjectPath(object) + extID
+	l.Log.Debugf("path=%v", path)
+
+	resp, err := c.do(http.MethodPatch, path, fields)
+	if err != nil {
+		return err
+	}
+	defer fasthttp.ReleaseResponse(resp)
+
+	if resp.StatusCode() != fasthttp.StatusNoContent && resp.StatusCode() != fasthttp.StatusOK {
+		return apiError(resp)
+	}
+	return nil
+}
+
+// sobjectPath path of the sobject resource
+func (c *Client) sobjectPath(object string) string {
+	return c.instanceURL + "/services/data/v" + c.version + "/sobjects/" + object + "/"
+}
+
+// do sends the request; the response is to be released by the caller
+func (c *Client) do(method string, path string, body interface{}) (*fasthttp.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		l.Log.Errorf("err=%v", err)
+		return nil, err
+	}
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+
+	req.SetRequestURI(path)
+	req.Header.SetMethod(method)
+	req.Header.SetContentType("application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.SetBody(data)
+
+	resp := fasthttp.AcquireResponse()
+	err = c.c.Do(req, resp)
+	if err != nil {
+		fasthttp.ReleaseResponse(resp)
+		l.Log.Errorf("err=%v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// apiError converts the error response into Error
+// Salesforce returns a list of errors; the first one is used.
+func apiError(resp *fasthttp.Response) error {
+	e := &Error{StatusCode: resp.StatusCode()}
+	var errs []Error
+	if json.Unmarshal(resp.Body(), &errs) == nil && len(errs) > 0 {
+		*e = errs[0]
+		e.StatusCode = resp.StatusCode()
+	} else {
+		e.Message = string(resp.Body())
+	}
+	l.Log.Warnf("%v", e)
+	return e
+}
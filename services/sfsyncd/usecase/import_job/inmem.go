@@ -0,0 +1,114 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package import_job
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/sfsyncd/entity"
+)
+
+// inmem in memory repo
+// Jobs are copied in and out as workers update them concurrently.
+type inmem struct {
+	m   map[id.ID]*entity.ImportJob
+	mut sync.Mutex
+}
+
+// newInmem create new repository
+func newInmem() *inmem {
+	return &inmem{
+		m: map[id.ID]*entity.ImportJob{},
+	}
+}
+
+// Create creates an import job
+func (r *inmem) Create(e *entity.ImportJob) (id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if _, ok := r.m[e.ID]; ok {
+		return id.IDInvalid, glad.ErrAlreadyExists
+	}
+	r.m[e.ID] = copyJob(e)
+	return e.ID, nil
+}
+
+// Get gets an import job
+func (r *inmem) Get(jobID id.ID) (*entity.ImportJob, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	e, ok := r.m[jobID]
+	if !ok {
+		return nil, glad.ErrNotFound
+	}
+	return copyJob(e), nil
+}
+
+// Claim marks the job running and owned until leaseUntil when it is claimable
+func (r *inmem) Claim(jobID id.ID, now time.Time, leaseUntil time.Time) (bool, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	e, ok := r.m[jobID]
+	if !ok {
+		return false, glad.ErrNotFound
+	}
+	if !e.IsClaimable(now) {
+		return false, nil
+	}
+	e.Status = entity.ImportJobRunning
+	e.LeaseUntil = leaseUntil
+	return true, nil
+}
+
+// ListUnfinished lists the ids of the queued and running jobs, oldest first
+func (r *inmem) ListUnfinished() ([]id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	var jobs []*entity.ImportJob
+	for _, e := range r.m {
+		if !e.IsDone() {
+			jobs = append(jobs, e)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+
+	ids := make([]id.ID, len(jobs))
+	for i, e := range jobs {
+		ids[i] = e.ID
+	}
+	return ids, nil
+}
+
+// Update saves the status and progress of the job
+func (r *inmem) Update(e *entity.ImportJob) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	saved, ok := r.m[e.ID]
+	if !ok {
+		return glad.ErrNotFound
+	}
+	updated := copyJob(e)
+	updated.Payload = saved.Payload
+	r.m[e.ID] = updated
+	return nil
+}
+
+// copyJob copies the job including its errors
+func copyJob(e *entity.ImportJob) *entity.ImportJob {
+	c := *e
+	c.Errors = append([]entity.ImportRecordError(nil), e.Errors...)
+	return &c
+}
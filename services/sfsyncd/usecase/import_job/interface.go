@@ -0,0 +1,54 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package import_job
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/sfsyncd/entity"
+	"ac9/glad/services/sfsyncd/usecase/sf_import"
+)
+
+// Reader import job reader
+type Reader interface {
+	Get(jobID id.ID) (*entity.ImportJob, error)
+	// ListUnfinished lists the ids of the queued and running jobs, oldest first
+	ListUnfinished() ([]id.ID, error)
+}
+
+// Writer import job writer
+type Writer interface {
+	Create(e *entity.ImportJob) (id.ID, error)
+	// Claim marks the job running and owned until leaseUntil when it is
+	// claimable at now; false when another worker owns or finished it
+	Claim(jobID id.ID, now time.Time, leaseUntil time.Time) (bool, error)
+	// Update saves the status, progress and lease of the job; payload is not changed
+	Update(e *entity.ImportJob) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// Importer imports a chunk of Salesforce records of a resource
+type Importer interface {
+	Sync(tenantID id.ID, r sf_import.Resource, records []json.RawMessage) ([]*sf_import.SyncResult, error)
+}
+
+// UseCase interface
+type UseCase interface {
+	// CreateJob persists the import of the records and queues it
+	CreateJob(tenantID id.ID, r sf_import.Resource, records []json.RawMessage) (*entity.ImportJob, error)
+	GetJob(tenantID id.ID, jobID id.ID) (*entity.ImportJob, error)
+	// Start starts the workers, which run until ctx is done
+	Start(ctx context.Context, workers int) error
+}
@@ -0,0 +1,309 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package import_job
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/entity"
+	"ac9/glad/services/sfsyncd/usecase/sf_import"
+)
+
+const (
+	// defaultChunkSize number of records sent to coursed at once
+	defaultChunkSize = 200
+	// maxAttempts attempts of a chunk before its records are reported failed
+	maxAttempts = 3
+	// defaultRetryDelay delay before the second attempt; grows linearly
+	defaultRetryDelay = time.Second
+	// queueSize jobs queued for the workers; further jobs are left to the
+	// next scan
+	queueSize = 1024
+	// defaultLease time a worker owns a running job without saving progress
+	defaultLease = 5 * time.Minute
+	// defaultScanInterval interval of the scans for unfinished jobs, e.g.
+	// created while the queue was full or left by a crashed instance
+	defaultScanInterval = time.Minute
+)
+
+// Service import job usecase
+type Service struct {
+	repo       Repository
+	importer   Importer
+	queue      chan id.ID
+	chunkSize  int
+	retryDelay time.Duration
+	lease      time.Duration
+	scan       time.Duration
+}
+
+// NewService creates a new service
+func NewService(r Repository, i Importer) *Service {
+	return &Service{
+		repo:       r,
+		importer:   i,
+		queue:      make(chan id.ID, queueSize),
+		chunkSize:  defaultChunkSize,
+		retryDelay: defaultRetryDelay,
+		lease:      defaultLease,
+		scan:       defaultScanInterval,
+	}
+}
+
+// CreateJob persists the import of the records and queues it
+// The records are processed once the workers are started.
+func (s *Service) CreateJob(tenantID id.ID,
+	r sf_import.Resource,
+	records []json.RawMessage,
+) (*entity.ImportJob, error) {
+	if !r.IsValid() {
+		return nil, glad.ErrInvalidValue
+	}
+
+	job, err := entity.NewImportJob(tenantID, string(r), records)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.repo.Create(job)
+	if err != nil {
+		l.Log.Warnf("Unable to create import job. err=%v", err)
+		return nil, err
+	}
+
+	s.enqueue(job.ID)
+	return job, nil
+}
+
+// GetJob gets the import job of the tenant
+func (s *Service) GetJob(tenantID id.ID, jobID id.ID) (*entity.ImportJob, error) {
+	job, err := s.repo.Get(jobID)
+	if job == nil || (err == nil && job.TenantID != tenantID) {
+		return nil, glad.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Start starts the workers, which run until ctx is done
+// Jobs left unfinished, e.g. by a restart, are queued again and resume from
+// the last processed chunk. Unfinished jobs are scanned for periodically, so
+// that jobs of a crashed instance are resumed once their lease expires.
+func (s *Service) Start(ctx context.Context, workers int) error {
+	if workers <= 0 {
+		return glad.ErrInvalidValue
+	}
+
+	pending, err := s.repo.ListUnfinished()
+	if err != nil {
+		l.Log.Warnf("Unable to list unfinished import jobs. err=%v", err)
+		return err
+	}
+
+	for i := 0; i < workers; i++ {
+		go s.work(ctx)
+	}
+	for _, jobID := range pending {
+		s.enqueue(jobID)
+	}
+	go s.scanUnfinished(ctx)
+	return nil
+}
+
+// enqueue queues the job without blocking the caller
+// When the queue is full the job stays queued in the repository and is
+// picked up by the next scan.
+func (s *Service) enqueue(jobID id.ID) {
+	select {
+	case s.queue <- jobID:
+	default:
+		l.Log.Infof("Import queue is full; job id=%v is left to the next scan", jobID)
+	}
+}
+
+// scanUnfinished queues the unfinished jobs periodically until ctx is done
+// Jobs owned by a worker are skipped when claimed.
+func (s *Service) scanUnfinished(ctx context.Context) {
+	ticker := time.NewTicker(s.scan)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pending, err := s.repo.ListUnfinished()
+			if err != nil {
+				l.Log.Warnf("Unable to list unfinished import jobs. err=%v", err)
+				continue
+			}
+			for _, jobID := range pending {
+				s.enqueue(jobID)
+			}
+		}
+	}
+}
+
+// work processes the queued jobs one at a time
+func (s *Service) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case jobID := <-s.queue:
+			s.process(ctx, jobID)
+		}
+	}
+}
+
+// process imports the records of the job chunk by chunk, saving the progress
+// after each chunk
+// The job is claimed first, hence processed by a single worker across the
+// instances; saving the progress renews the lease.
+func (s *Service) process(ctx context.Context, jobID id.ID) {
+	now := time.Now()
+	claimed, err := s.repo.Claim(jobID, now, now.Add(s.lease))
+	if err != nil {
+		l.Log.Warnf("Unable to claim import job id=%v, err=%v", jobID, err)
+		return
+	}
+	if !claimed {
+		// finished or owned by another worker
+		return
+	}
+
+	job, err := s.repo.Get(jobID)
+	if job == nil || err != nil {
+		l.Log.Warnf("Unable to get import job id=%v, err=%v", jobID, err)
+		return
+	}
+
+	var records []json.RawMessage
+	err = json.Unmarshal(job.Payload, &records)
+	if err != nil {
+		l.Log.Warnf("Unable to decode import job id=%v, err=%v", jobID, err)
+		job.Status = entity.ImportJobFailed
+		job.Message = "Unable to decode the payload"
+		s.save(job)
+		return
+	}
+
+	job.Status = entity.ImportJobRunning
+	job.Total = len(records)
+	s.save(job)
+
+	for job.Processed < len(records) {
+		if ctx.Err() != nil {
+			s.release(job)
+			return
+		}
+
+		end := min(job.Processed+s.chunkSize, len(records))
+		results, err := s.syncChunk(ctx, job, records[job.Processed:end])
+		if err != nil && ctx.Err() != nil {
+			s.release(job)
+			return
+		}
+		s.addResults(job, end-job.Processed, results, err)
+		job.Processed = end
+		s.save(job)
+	}
+
+	job.Status = entity.ImportJobCompleted
+	s.save(job)
+	l.Log.Infof("Import job id=%v done. total=%v, succeeded=%v, failed=%v",
+		job.ID, job.Total, job.Succeeded, job.Failed)
+}
+
+// syncChunk syncs the records, retrying while the chunk fails as a whole
+// Records that cannot be decoded are not retried.
+func (s *Service) syncChunk(ctx context.Context,
+	job *entity.ImportJob,
+	records []json.RawMessage,
+) ([]*sf_import.SyncResult, error) {
+	var results []*sf_import.SyncResult
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		results, err = s.importer.Sync(job.TenantID, sf_import.Resource(job.Resource), records)
+		if err == nil || err == glad.ErrInvalidValue {
+			return results, err
+		}
+
+		l.Log.Warnf("Import job id=%v, offset=%v, attempt=%v, err=%v",
+			job.ID, job.Processed, attempt, err)
+		if attempt < maxAttempts {
+			select {
+			case <-ctx.Done():
+				return results, err
+			case <-time.After(s.retryDelay * time.Duration(attempt)):
+			}
+		}
+	}
+	return results, err
+}
+
+// addResults counts the results of the n records of the chunk starting at
+// the processed offset and keeps the errors
+func (s *Service) addResults(job *entity.ImportJob,
+	n int,
+	results []*sf_import.SyncResult,
+	err error,
+) {
+	for i := 0; i < n; i++ {
+		var result *sf_import.SyncResult
+		if i < len(results) {
+			result = results[i]
+		}
+		if result != nil && !result.IsError {
+			job.Succeeded++
+			continue
+		}
+
+		job.Failed++
+		recordError := entity.ImportRecordError{Index: job.Processed + i}
+		if result != nil {
+			recordError.ExtID = result.ExtID
+			recordError.Action = string(result.Action)
+			recordError.Message = result.Message
+		}
+		if recordError.Message == "" && err != nil {
+			recordError.Message = err.Error()
+		}
+		if recordError.Message == "" {
+			recordError.Message = "Rejected by coursed"
+		}
+		job.Errors = append(job.Errors, recordError)
+	}
+}
+
+// release gives up the lease of the stopped job, which is resumed from the
+// saved progress by the next claim
+func (s *Service) release(job *entity.ImportJob) {
+	job.LeaseUntil = time.Now()
+	s.update(job)
+}
+
+// save saves the status and progress of the job and renews its lease
+func (s *Service) save(job *entity.ImportJob) {
+	job.LeaseUntil = time.Now().Add(s.lease)
+	s.update(job)
+}
+
+// update updates the job
+func (s *Service) update(job *entity.ImportJob) {
+	job.UpdatedAt = time.Now()
+	err := s.repo.Update(job)
+	if err != nil {
+		l.Log.Warnf("Unable to save import job id=%v, err=%v", job.ID, err)
+	}
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package import_job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/entity"
+	"ac9/glad/services/sfsyncd/presenter"
+	"ac9/glad/services/sfsyncd/usecase/sf_import"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice id.ID = 13790492210917015554
+	tenantBob   id.ID = 13790492210917015555
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+// fakeImporter upserts the records, failing the records whose ext id starts
+// with "bad" and failing the first calls as a whole, as set by failures
+type fakeImporter struct {
+	mut      sync.Mutex
+	failures int
+	calls    int
+}
+
+func (f *fakeImporter) Sync(tenantID id.ID,
+	r sf_import.Resource,
+	records []json.RawMessage,
+) ([]*sf_import.SyncResult, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	f.calls++
+	var results []*sf_import.SyncResult
+	for _, rec := range records {
+		var wrapper presenter.CourseWrapper
+		if json.Unmarshal(rec, &wrapper) != nil {
+			return nil, glad.ErrInvalidValue
+		}
+		extID := wrapper.Value.ExtID
+		results = append(results, &sf_import.SyncResult{
+			ID:      int64(len(extID)),
+			ExtID:   extID,
+			IsError: f.failures > 0 || len(extID) > 3 && extID[:3] == "bad",
+			Action:  presenter.ActionUpsert,
+		})
+	}
+	if f.failures > 0 {
+		f.failures--
+		return results, errors.New("coursed status=503")
+	}
+	return results, nil
+}
+
+func newRecords(extIDs ...string) []json.RawMessage {
+	var records []json.RawMessage
+	for _, extID := range extIDs {
+		rec, _ := json.Marshal(presenter.CourseWrapper{
+			Operation: presenter.OperationInsert,
+			Value:     presenter.Course{ExtID: extID},
+		})
+		records = append(records, rec)
+	}
+	return records
+}
+
+func newTestService(importer Importer) *Service {
+	s := NewService(newInmem(), importer)
+	s.chunkSize = 2
+	s.retryDelay = time.Millisecond
+	return s
+}
+
+func Test_CreateJob(t *testing.T) {
+	s := newTestService(&fakeImporter{})
+
+	job, err := s.CreateJob(tenantAlice, sf_import.ResourceCourse, newRecords("c1", "c2"))
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ImportJobQueued, job.Status)
+	assert.Equal(t, 2, job.Total)
+
+	saved, err := s.GetJob(tenantAlice, job.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, job.ID, saved.ID)
+
+	t.Run("other tenant", func(t *testing.T) {
+		_, err := s.GetJob(tenantBob, job.ID)
+		assert.Equal(t, glad.ErrNotFound, err)
+		_, err = s.GetJob(tenantAlice, id.New())
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+	t.Run("unknown resource", func(t *testing.T) {
+		_, err := s.CreateJob(tenantAlice, "contacts", newRecords("p1"))
+		assert.Equal(t, glad.ErrInvalidValue, err)
+	})
+}
+
+func Test_process(t *testing.T) {
+	// bad2 is rejected by coursed, the other records are imported
+	importer := &fakeImporter{}
+	s := newTestService(importer)
+	job, err := s.CreateJob(tenantAlice, sf_import.ResourceCourse,
+		newRecords("c1", "bad2", "c3", "c4", "c5"))
+	assert.Nil(t, err)
+
+	s.process(context.Background(), job.ID)
+	// c1, bad2 | c3, c4 | c5
+	assert.Equal(t, 3, importer.calls)
+
+	done, _ := s.GetJob(tenantAlice, job.ID)
+	assert.Equal(t, entity.ImportJobCompleted, done.Status)
+	assert.Equal(t, 5, done.Processed)
+	assert.Equal(t, 4, done.Succeeded)
+	assert.Equal(t, 1, done.Failed)
+	assert.Equal(t, 1, len(done.Errors))
+	assert.Equal(t, 1, done.Errors[0].Index)
+	assert.Equal(t, "bad2", done.Errors[0].ExtID)
+	assert.NotEmpty(t, done.Errors[0].Message)
+
+	t.Run("retries", func(t *testing.T) {
+		importer := &fakeImporter{failures: 1}
+		s := newTestService(importer)
+		job, _ := s.CreateJob(tenantAlice, sf_import.ResourceCourse, newRecords("c1", "c2"))
+
+		s.process(context.Background(), job.ID)
+		assert.Equal(t, 2, importer.calls)
+		done, _ := s.GetJob(tenantAlice, job.ID)
+		assert.Equal(t, 2, done.Succeeded)
+		assert.Equal(t, 0, done.Failed)
+	})
+	t.Run("retries exhausted", func(t *testing.T) {
+		importer := &fakeImporter{failures: maxAttempts}
+		s := newTestService(importer)
+		job, _ := s.CreateJob(tenantAlice, sf_import.ResourceCourse, newRecords("c1", "c2", "c3"))
+
+		s.process(context.Background(), job.ID)
+		assert.Equal(t, maxAttempts+1, importer.calls)
+		done, _ := s.GetJob(tenantAlice, job.ID)
+		assert.Equal(t, entity.ImportJobCompleted, done.Status)
+		assert.Equal(t, 1, done.Succeeded)
+		assert.Equal(t, 2, done.Failed)
+		assert.Equal(t, "coursed status=503", done.Errors[0].Message)
+	})
+	t.Run("bad records", func(t *testing.T) {
+		s := newTestService(&fakeImporter{})
+		records := append(newRecords("c1"), json.RawMessage(`"not a record"`))
+		job, _ := s.CreateJob(tenantAlice, sf_import.ResourceCourse, records)
+
+		s.process(context.Background(), job.ID)
+		done, _ := s.GetJob(tenantAlice, job.ID)
+		assert.Equal(t, entity.ImportJobCompleted, done.Status)
+		assert.Equal(t, 2, done.Failed)
+	})
+}
+
+func Test_Start(t *testing.T) {
+	repo := newInmem()
+	s := NewService(repo, &fakeImporter{})
+	s.chunkSize = 2
+
+	// left running by a restart after the first chunk
+	job, _ := entity.NewImportJob(tenantAlice, string(sf_import.ResourceCourse),
+		newRecords("c1", "c2", "c3"))
+	job.Status = entity.ImportJobRunning
+	job.Processed = 2
+	job.Succeeded = 2
+	_, _ = repo.Create(job)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Equal(t, glad.ErrInvalidValue, s.Start(ctx, 0))
+	assert.Nil(t, s.Start(ctx, 2))
+
+	queued, err := s.CreateJob(tenantAlice, sf_import.ResourceCourse, newRecords("c4"))
+	assert.Nil(t, err)
+
+	for _, jobID := range []id.ID{job.ID, queued.ID} {
+		var done *entity.ImportJob
+		assert.Eventually(t, func() bool {
+			done, _ = s.GetJob(tenantAlice, jobID)
+			return done.IsDone()
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, entity.ImportJobCompleted, done.Status)
+		assert.Equal(t, done.Total, done.Succeeded)
+	}
+}
+
+func Test_process_claimed(t *testing.T) {
+	repo := newInmem()
+	importer := &fakeImporter{}
+	s := NewService(repo, importer)
+
+	// owned by the worker of another instance
+	job, _ := entity.NewImportJob(tenantAlice, string(sf_import.ResourceCourse), newRecords("c1"))
+	job.Status = entity.ImportJobRunning
+	job.LeaseUntil = time.Now().Add(time.Minute)
+	_, _ = repo.Create(job)
+
+	s.process(context.Background(), job.ID)
+	assert.Equal(t, 0, importer.calls)
+	owned, _ := s.GetJob(tenantAlice, job.ID)
+	assert.Equal(t, entity.ImportJobRunning, owned.Status)
+	assert.Equal(t, 0, owned.Processed)
+
+	t.Run("lease expired", func(t *testing.T) {
+		job.LeaseUntil = time.Now().Add(-time.Second)
+		_ = repo.Update(job)
+
+		s.process(context.Background(), job.ID)
+		assert.Equal(t, 1, importer.calls)
+		done, _ := s.GetJob(tenantAlice, job.ID)
+		assert.Equal(t, entity.ImportJobCompleted, done.Status)
+
+		// finished jobs are not claimed again
+		s.process(context.Background(), job.ID)
+		assert.Equal(t, 1, importer.calls)
+	})
+}
+
+func Test_Start_queueFull(t *testing.T) {
+	s := NewService(newInmem(), &fakeImporter{})
+	s.scan = time.Millisecond
+	// no room in the queue until a worker receives
+	s.queue = make(chan id.ID)
+
+	job, err := s.CreateJob(tenantAlice, sf_import.ResourceCourse, newRecords("c1"))
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Nil(t, s.Start(ctx, 1))
+
+	assert.Eventually(t, func() bool {
+		done, _ := s.GetJob(tenantAlice, job.ID)
+		return done.Status == entity.ImportJobCompleted
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package sf_export
+
+import (
+	"errors"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/glad/coursed"
+	"ac9/glad/pkg/id"
+)
+
+// entityResources coursed resources of the entities
+var entityResources = map[entity.AuditEntityType]coursed.Resource{
+	entity.AuditCourse:  coursed.ResourceCourse,
+	entity.AuditCenter:  coursed.ResourceCenter,
+	entity.AuditAccount: coursed.ResourceAccount,
+	entity.AuditProduct: coursed.ResourceProduct,
+}
+
+// CoursedClient coursed client used by the export
+type CoursedClient struct {
+	c *coursed.Client
+}
+
+// NewCoursedClient creates a new client
+func NewCoursedClient(c *coursed.Client) *CoursedClient {
+	return &CoursedClient{
+		c: c,
+	}
+}
+
+// ListChanges lists the changes made in GLAD after the seq, oldest first
+func (c *CoursedClient) ListChanges(tenantID id.ID,
+	after int64,
+	limit int,
+) ([]*glad.Change, error) {
+	changes, err := c.c.ListChanges(tenantID, after, limit)
+	if errors.Is(err, glad.ErrNotFound) {
+		return nil, nil
+	}
+	return changes, err
+}
+
+// GetExtID gets the current Salesforce id of the entity
+func (c *CoursedClient) GetExtID(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	entityID id.ID,
+) (string, error) {
+	resource, ok := entityResources[entityType]
+	if !ok {
+		return "", glad.ErrInvalidValue
+	}
+	return c.c.GetExtID(tenantID, resource, entityID)
+}
+
+// SetCourseExtID writes the Salesforce id of the course back to GLAD
+func (c *CoursedClient) SetCourseExtID(tenantID id.ID, courseID id.ID, extID string) error {
+	return c.c.SetCourseExtID(tenantID, courseID, extID)
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package sf_export
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// Salesforce REST client
+type Salesforce interface {
+	// Create creates a record of the sobject and returns its id
+	Create(object string, fields map[string]interface{}) (string, error)
+	// Update updates the fields of an existing record
+	Update(object string, extID string, fields map[string]interface{}) error
+}
+
+// Client interface to coursed
+type Client interface {
+	// ListChanges lists the changes made in GLAD after the seq, oldest first
+	ListChanges(tenantID id.ID, after int64, limit int) ([]*glad.Change, error)
+	// GetExtID gets the current Salesforce id of the entity; empty if the
+	// entity is yet to be exported
+	GetExtID(tenantID id.ID, entityType entity.AuditEntityType, entityID id.ID) (string, error)
+	// SetCourseExtID writes the Salesforce id of the course back to GLAD
+	SetCourseExtID(tenantID id.ID, courseID id.ID, extID string) error
+}
+
+// Result outcome of exporting an entity
+type Result struct {
+	Entity  entity.AuditEntityType
+	ID      id.ID
+	ExtID   string
+	Created bool
+	Err     error
+}
+
+// UseCase export GLAD changes to Salesforce
+type UseCase interface {
+	// Export exports the changes made after the seq and returns the seq to
+	// export from in the next run
+	Export(tenantID id.ID, after int64, limit int) (int64, []*Result, error)
+}
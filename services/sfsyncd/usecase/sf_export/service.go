@@ -0,0 +1,203 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package sf_export
+
+import (
+	"encoding/json"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/presenter"
+)
+
+// Service export usecase
+type Service struct {
+	coursed Client
+	sf      Salesforce
+}
+
+// NewService creates a new service
+func NewService(c Client, sf Salesforce) *Service {
+	return &Service{
+		coursed: c,
+		sf:      sf,
+	}
+}
+
+// changeKey entity that was changed
+type changeKey struct {
+	entityType entity.AuditEntityType
+	entityID   id.ID
+}
+
+// Export exports the changes made in GLAD after the seq to Salesforce. Only
+// the latest state of each changed course, center and account is exported; the
+// Salesforce id is looked up in GLAD so that exporting again only updates the
+// record. Records created in Salesforce are linked back to the GLAD course.
+//
+// Note: Deletes are not propagated. The returned seq stops before the first
+// change of a record that failed, so that the next run retries it; the records
+// exported after it are exported again, which only updates them.
+func (s *Service) Export(tenantID id.ID,
+	after int64,
+	limit int,
+) (int64, []*Result, error) {
+	changes, err := s.coursed.ListChanges(tenantID, after, limit)
+	if err != nil {
+		l.Log.Warnf("Unable to list changes tenantID=%v, err=%v", tenantID, err)
+		return after, nil, err
+	}
+
+	next := after
+	latest := map[changeKey]*glad.Change{}
+	// first seq of each record in this run
+	first := map[changeKey]int64{}
+	var order []changeKey
+	for _, c := range changes {
+		next = c.Seq
+		k := changeKey{entity.AuditEntityType(c.Entity), id.ID(c.EntityID)}
+		if _, ok := sfObjects[k.entityType]; !ok {
+			continue
+		}
+		if _, ok := latest[k]; !ok {
+			order = append(order, k)
+			first[k] = c.Seq
+		}
+		latest[k] = c
+	}
+
+	var results []*Result
+	for _, k := range order {
+		c := latest[k]
+		if entity.AuditOperation(c.Operation) == entity.AuditDelete || len(c.After) == 0 {
+			continue
+		}
+
+		r := s.export(tenantID, k, c.After)
+		if r.Err != nil {
+			l.Log.Warnf("Unable to export %v id=%v, err=%v", k.entityType, k.entityID, r.Err)
+			next = min(next, first[k]-1)
+		}
+		results = append(results, r)
+	}
+	return next, results, nil
+}
+
+// sfObjects Salesforce objects of the exported entities
+var sfObjects = map[entity.AuditEntityType]string{
+	entity.AuditCourse:  presenter.SObjectCourse,
+	entity.AuditCenter:  presenter.SObjectCenter,
+	entity.AuditAccount: presenter.SObjectAccount,
+}
+
+// export creates or updates the Salesforce record of the entity
+func (s *Service) export(tenantID id.ID, k changeKey, snapshot json.RawMessage) *Result {
+	r := &Result{Entity: k.entityType, ID: k.entityID}
+
+	r.ExtID, r.Err = s.coursed.GetExtID(tenantID, k.entityType, k.entityID)
+	if r.Err != nil {
+		return r
+	}
+
+	fields, err := s.fields(tenantID, k.entityType, snapshot)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+
+	object := sfObjects[k.entityType]
+	if r.ExtID != "" {
+		r.Err = s.sf.Update(object, r.ExtID, fields)
+		return r
+	}
+
+	// Note: Only courses are created in GLAD; centers and accounts always
+	// come from Salesforce.
+	if k.entityType != entity.AuditCourse {
+		r.Err = glad.ErrNotFound
+		return r
+	}
+
+	r.ExtID, r.Err = s.sf.Create(object, fields)
+	if r.Err != nil {
+		return r
+	}
+	r.Created = true
+	r.Err = s.coursed.SetCourseExtID(tenantID, k.entityID, r.ExtID)
+	return r
+}
+
+// fields maps the entity snapshot to the Salesforce record fields
+func (s *Service) fields(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	snapshot json.RawMessage,
+) (map[string]interface{}, error) {
+	switch entityType {
+	case entity.AuditCourse:
+		var e entity.Course
+		if err := json.Unmarshal(snapshot, &e); err != nil {
+			return nil, err
+		}
+		centerExtID, err := s.refExtID(tenantID, entity.AuditCenter, e.CenterID)
+		if err != nil {
+			return nil, err
+		}
+		productExtID, err := s.refExtID(tenantID, entity.AuditProduct, e.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		c := presenter.Course{}
+		c.FromEntityCourse(&e, centerExtID, productExtID)
+		// Note: Number of students is maintained by Salesforce
+		fields, err := presenter.SalesforceFields(c, "Number_Of_Students__c")
+		if err != nil {
+			return nil, err
+		}
+		// lookups are left as is when the course is not linked
+		if centerExtID == "" {
+			delete(fields, "Location__c")
+		}
+		if productExtID == "" {
+			delete(fields, "Workshop_Type__c")
+		}
+		return fields, nil
+
+	case entity.AuditCenter:
+		var e entity.Center
+		if err := json.Unmarshal(snapshot, &e); err != nil {
+			return nil, err
+		}
+		c := presenter.Center{}
+		c.FromEntityCenter(&e)
+		return presenter.SalesforceFields(c)
+
+	case entity.AuditAccount:
+		var e entity.Account
+		if err := json.Unmarshal(snapshot, &e); err != nil {
+			return nil, err
+		}
+		a := presenter.Account{}
+		a.FromEntityAccount(&e)
+		// Note: Name of a person account is derived from the first and last name
+		return presenter.SalesforceFields(a, "Name")
+	}
+	return nil, glad.ErrInvalidValue
+}
+
+// refExtID gets the Salesforce id of an entity referred to by the course
+func (s *Service) refExtID(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	entityID id.ID,
+) (string, error) {
+	if entityID == id.IDInvalid {
+		return "", nil
+	}
+	return s.coursed.GetExtID(tenantID, entityType, entityID)
+}
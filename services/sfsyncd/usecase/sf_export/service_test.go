@@ -0,0 +1,346 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package sf_export
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/common"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/glad/coursed"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/salesforce"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice    id.ID = 13790492210917015554
+	sfToken              = "token123"
+	centerExtID          = "a0C000000000001"
+	productExtID         = "a0P000000000001"
+	accountExtID         = "001000000000001"
+	createdExtID         = "a0W000000000001"
+	rejectedCourse       = "rejected"
+	sfAPIVersion         = "60.0"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+// sfRequest request received by the fake Salesforce
+type sfRequest struct {
+	Method string
+	Path   string
+	Fields map[string]interface{}
+}
+
+// fakeSalesforce fake Salesforce REST API
+type fakeSalesforce struct {
+	mut      sync.Mutex
+	requests []sfRequest
+}
+
+func (f *fakeSalesforce) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+sfToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`[{"errorCode":"INVALID_SESSION_ID","message":"Session expired or invalid"}]`))
+			return
+		}
+
+		var fields map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&fields)
+		f.mut.Lock()
+		f.requests = append(f.requests, sfRequest{r.Method, r.URL.Path, fields})
+		f.mut.Unlock()
+
+		if fields["Name"] == rejectedCourse {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`[{"errorCode":"REQUIRED_FIELD_MISSING","message":"Required fields are missing: [Timezone__c]","fields":["Timezone__c"]}]`))
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"` + createdExtID + `","success":true,"errors":[]}`))
+		case http.MethodPatch:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// fakeCoursed fake coursed serving the change feed and the entities
+type fakeCoursed struct {
+	mut     sync.Mutex
+	changes []*glad.Change
+	// extIDs Salesforce ids of the entities, by GLAD id
+	extIDs map[string]string
+	after  string
+}
+
+func (f *fakeCoursed) router() http.Handler {
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/audit/changes", func(w http.ResponseWriter, r *http.Request) {
+		f.after = r.URL.Query().Get("after")
+		if r.Header.Get(common.HttpHeaderTenantID) != tenantAlice.String() || len(f.changes) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(f.changes)
+	}).Methods(http.MethodGet)
+
+	r.HandleFunc("/v1/{entity}/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mut.Lock()
+		defer f.mut.Unlock()
+		extID, ok := f.extIDs[mux.Vars(r)["id"]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(glad.ExtID{ExtID: extID})
+	}).Methods(http.MethodGet)
+
+	r.HandleFunc("/v1/courses/{id}/extid", func(w http.ResponseWriter, r *http.Request) {
+		var e glad.ExtID
+		_ = json.NewDecoder(r.Body).Decode(&e)
+		f.mut.Lock()
+		defer f.mut.Unlock()
+		f.extIDs[mux.Vars(r)["id"]] = e.ExtID
+	}).Methods(http.MethodPut)
+	return r
+}
+
+func newChange(entityType entity.AuditEntityType,
+	entityID id.ID,
+	op entity.AuditOperation,
+	after interface{},
+	seq int64,
+) *glad.Change {
+	c := &glad.Change{
+		ID:        uint64(id.New()),
+		Seq:       seq,
+		Entity:    string(entityType),
+		EntityID:  uint64(entityID),
+		Operation: string(op),
+		Source:    string(entity.AuditSourceAPI),
+		CreatedAt: time.Now(),
+	}
+	if after != nil {
+		c.After, _ = json.Marshal(after)
+	}
+	return c
+}
+
+func newFixtureCourse(centerID, productID id.ID) *entity.Course {
+	return &entity.Course{
+		ID:        id.New(),
+		TenantID:  tenantAlice,
+		CenterID:  centerID,
+		ProductID: productID,
+		Name:      "Happiness Program",
+		Timezone:  "PST",
+		Address: entity.CourseAddress{
+			Street1: "1 Main St",
+			City:    "San Jose",
+			State:   "CA",
+			Zip:     "95112",
+			Country: "US",
+		},
+		Status:       entity.CourseDraft,
+		Mode:         entity.CourseInPerson,
+		MaxAttendees: 20,
+		NumAttendees: 3,
+		CreatedAt:    time.Now(),
+	}
+}
+
+func Test_Export(t *testing.T) {
+	sf := &fakeSalesforce{}
+	sfServer := httptest.NewServer(sf.handler())
+	defer sfServer.Close()
+
+	centerID := id.New()
+	productID := id.New()
+	accountID := id.New()
+	course := newFixtureCourse(centerID, productID)
+	rejected := newFixtureCourse(centerID, id.IDInvalid)
+	rejected.Name = rejectedCourse
+
+	center := &entity.Center{ID: centerID, TenantID: tenantAlice, ExtID: centerExtID,
+		Name: "San Jose", Mode: entity.CenterInPerson, IsEnabled: true}
+	updatedCourse := *course
+	updatedCourse.Name = "Happiness Program - Weekend"
+
+	var start int64 = 100
+	fake := &fakeCoursed{
+		extIDs: map[string]string{
+			course.ID.String():   "",
+			rejected.ID.String(): "",
+			centerID.String():    centerExtID,
+			productID.String():   productExtID,
+			accountID.String():   accountExtID,
+		},
+		changes: []*glad.Change{
+			newChange(entity.AuditCourse, course.ID, entity.AuditCreate, course, start+1),
+			newChange(entity.AuditCenter, centerID, entity.AuditUpdate, center, start+2),
+			newChange(entity.AuditCourse, course.ID, entity.AuditUpdate, updatedCourse, start+3),
+			newChange(entity.AuditProduct, productID, entity.AuditUpdate, &entity.Product{}, start+4),
+			newChange(entity.AuditAccount, accountID, entity.AuditDelete, nil, start+5),
+			newChange(entity.AuditCourse, rejected.ID, entity.AuditCreate, rejected, start+6),
+		},
+	}
+	coursedServer := httptest.NewServer(fake.router())
+	defer coursedServer.Close()
+
+	s := NewService(NewCoursedClient(coursed.NewClient(coursedServer.URL, coursed.Config{})),
+		salesforce.NewClient(sfServer.URL+"/", sfAPIVersion, sfToken))
+
+	next, results, err := s.Export(tenantAlice, start, 0)
+	assert.Nil(t, err)
+	// the cursor stops before the rejected course, to retry it in the next run
+	assert.Equal(t, start+5, next)
+	assert.Equal(t, "100", fake.after)
+
+	// course created once with its latest state, center updated, the rest skipped
+	assert.Equal(t, 3, len(results))
+	assert.Equal(t, 3, len(sf.requests))
+
+	t.Run("course created and linked", func(t *testing.T) {
+		r := results[0]
+		assert.Nil(t, r.Err)
+		assert.Equal(t, entity.AuditCourse, r.Entity)
+		assert.Equal(t, course.ID, r.ID)
+		assert.True(t, r.Created)
+		assert.Equal(t, createdExtID, r.ExtID)
+		assert.Equal(t, createdExtID, fake.extIDs[course.ID.String()])
+
+		req := sf.requests[0]
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/services/data/v60.0/sobjects/Workshop__c/", req.Path)
+		assert.Equal(t, updatedCourse.Name, req.Fields["Name"])
+		assert.Equal(t, centerExtID, req.Fields["Location__c"])
+		assert.Equal(t, productExtID, req.Fields["Workshop_Type__c"])
+		assert.Equal(t, "San Jose", req.Fields["City__c"])
+		assert.Equal(t, "95112", req.Fields["Zip_Postal_Code__c"])
+		assert.Equal(t, "in-person", req.Fields["Online_Or_In_Person__c"])
+		assert.Equal(t, float64(20), req.Fields["Max_attendees__c"])
+		for _, f := range []string{"Id", "CreatedDate", "LastModifiedDate", "Number_Of_Students__c"} {
+			assert.NotContains(t, req.Fields, f)
+		}
+	})
+
+	t.Run("center updated", func(t *testing.T) {
+		r := results[1]
+		assert.Nil(t, r.Err)
+		assert.False(t, r.Created)
+		assert.Equal(t, centerExtID, r.ExtID)
+
+		req := sf.requests[1]
+		assert.Equal(t, http.MethodPatch, req.Method)
+		assert.Equal(t, "/services/data/v60.0/sobjects/Center__c/"+centerExtID, req.Path)
+		assert.Equal(t, "San Jose", req.Fields["Center_Name__c"])
+		assert.Equal(t, true, req.Fields["Is_Enable__c"])
+	})
+
+	t.Run("rejected by salesforce", func(t *testing.T) {
+		r := results[2]
+		assert.Equal(t, rejected.ID, r.ID)
+		assert.False(t, r.Created)
+		assert.NotNil(t, r.Err)
+		assert.True(t, strings.Contains(r.Err.Error(), "REQUIRED_FIELD_MISSING"))
+		assert.Equal(t, "", fake.extIDs[rejected.ID.String()])
+
+		// product is not set, so the lookup is left out
+		assert.NotContains(t, sf.requests[2].Fields, "Workshop_Type__c")
+	})
+
+	t.Run("exported again", func(t *testing.T) {
+		sf.requests = nil
+		fake.changes = fake.changes[2:3]
+
+		_, results, err := s.Export(tenantAlice, start, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Nil(t, results[0].Err)
+		assert.False(t, results[0].Created)
+		assert.Equal(t, http.MethodPatch, sf.requests[0].Method)
+		assert.Equal(t, "/services/data/v60.0/sobjects/Workshop__c/"+createdExtID, sf.requests[0].Path)
+	})
+
+	t.Run("failed record holds the cursor", func(t *testing.T) {
+		sf.requests = nil
+		fake.changes = []*glad.Change{
+			newChange(entity.AuditCourse, rejected.ID, entity.AuditUpdate, rejected, start+7),
+			newChange(entity.AuditCourse, course.ID, entity.AuditUpdate, updatedCourse, start+8),
+			newChange(entity.AuditCourse, rejected.ID, entity.AuditUpdate, rejected, start+9),
+		}
+
+		next, results, err := s.Export(tenantAlice, start+6, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(results))
+		assert.NotNil(t, results[0].Err)
+		assert.Nil(t, results[1].Err)
+		assert.Equal(t, start+6, next)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		fake.changes = nil
+		next, results, err := s.Export(tenantAlice, start, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(results))
+		assert.Equal(t, start, next)
+	})
+}
+
+func Test_Export_Unauthorized(t *testing.T) {
+	sfServer := httptest.NewServer((&fakeSalesforce{}).handler())
+	defer sfServer.Close()
+
+	course := newFixtureCourse(id.IDInvalid, id.IDInvalid)
+	fake := &fakeCoursed{
+		extIDs: map[string]string{course.ID.String(): ""},
+		changes: []*glad.Change{
+			newChange(entity.AuditCourse, course.ID, entity.AuditCreate, course, 1),
+		},
+	}
+	coursedServer := httptest.NewServer(fake.router())
+	defer coursedServer.Close()
+
+	s := NewService(NewCoursedClient(coursed.NewClient(coursedServer.URL, coursed.Config{})),
+		salesforce.NewClient(sfServer.URL, sfAPIVersion, "expired"))
+	_, results, err := s.Export(tenantAlice, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(results))
+
+	sfErr, ok := results[0].Err.(*salesforce.Error)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnauthorized, sfErr.StatusCode)
+	assert.Equal(t, "INVALID_SESSION_ID", sfErr.ErrorCode)
+	assert.Equal(t, "", fake.extIDs[course.ID.String()])
+}
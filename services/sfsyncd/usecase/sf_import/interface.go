@@ -7,7 +7,10 @@
 package sf_import
 
 import (
+	"encoding/json"
+
 	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/glad/coursed"
 	"ac9/glad/pkg/id"
 )
 
@@ -15,8 +18,68 @@ import (
 type Repository interface {
 }
 
-// Client interface to other glad services
+// Client interface to coursed
 type Client interface {
+	ImportProducts(tenantID id.ID, p []*glad.Product) ([]*glad.ProductResponse, error)
+	ImportCenters(tenantID id.ID, p []*glad.Center) ([]*glad.CenterResponse, error)
+	ImportAccounts(tenantID id.ID, p []*glad.Account) ([]*glad.AccountResponse, error)
+	ImportCourses(tenantID id.ID, p []*glad.Course) ([]*glad.CourseResponse, error)
+	ImportCourseTimings(tenantID id.ID, p []*glad.CourseTiming) ([]*glad.CourseTimingResponse, error)
+	ImportCourseRosters(tenantID id.ID, p []*glad.CourseRoster) ([]*glad.CourseRosterResponse, error)
+	ImportCourseParticipants(tenantID id.ID, p []*glad.Participant) ([]*glad.ParticipantResponse, error)
+	// Archive archives the record imported with the ext id and returns its id
+	Archive(tenantID id.ID, r coursed.Resource, extID string) (id.ID, error)
+	// Restore restores the record imported with the ext id and returns its id
+	Restore(tenantID id.ID, r coursed.Resource, extID string) (id.ID, error)
+	// Remove removes the course record imported with the ext id and returns its id
+	Remove(tenantID id.ID, r coursed.Resource, extID string) (id.ID, error)
+}
+
+// Resource coursed resource imported from Salesforce
+type Resource string
+
+const (
+	ResourceProduct = Resource(coursed.ResourceProduct)
+	ResourceCenter  = Resource(coursed.ResourceCenter)
+	ResourceAccount = Resource(coursed.ResourceAccount)
+	ResourceCourse  = Resource(coursed.ResourceCourse)
+
+	// Course records; removed rather than archived when deleted in Salesforce
+	ResourceCourseTiming      Resource = "timings"
+	ResourceCourseRoster      Resource = "rosters"
+	ResourceCourseParticipant Resource = "participants"
+)
+
+// IsValid whether the resource is imported from Salesforce
+func (r Resource) IsValid() bool {
+	switch r {
+	case ResourceProduct, ResourceCenter, ResourceAccount, ResourceCourse,
+		ResourceCourseTiming, ResourceCourseRoster, ResourceCourseParticipant:
+		return true
+	}
+	return false
+}
+
+// IsArchived whether the records deleted in Salesforce are archived in GLAD
+func (r Resource) IsArchived() bool {
+	switch r {
+	case ResourceCourseTiming, ResourceCourseRoster, ResourceCourseParticipant:
+		return false
+	}
+	return true
+}
+
+// toCoursed coursed resource the records are imported to
+func (r Resource) toCoursed() coursed.Resource {
+	switch r {
+	case ResourceCourseTiming:
+		return coursed.ResourceCourseTiming
+	case ResourceCourseRoster:
+		return coursed.ResourceCourseRoster
+	case ResourceCourseParticipant:
+		return coursed.ResourceCourseParticipant
+	}
+	return coursed.Resource(r)
 }
 
 // UseCase defines the interface for product business logic
@@ -25,4 +88,15 @@ type UseCase interface {
 	ImportCenter(tenantID id.ID, p []*glad.Center) ([]*glad.CenterResponse, error)
 	ImportAccount(tenantID id.ID, p []*glad.Account) ([]*glad.AccountResponse, error)
 	ImportCourse(tenantID id.ID, p []*glad.Course) ([]*glad.CourseResponse, error)
+	ImportCourseTiming(tenantID id.ID, p []*glad.CourseTiming) ([]*glad.CourseTimingResponse, error)
+	ImportCourseRoster(tenantID id.ID, p []*glad.CourseRoster) ([]*glad.CourseRosterResponse, error)
+	ImportParticipant(tenantID id.ID, p []*glad.Participant) ([]*glad.ParticipantResponse, error)
+	// Archive archives the record deleted in Salesforce and returns its id
+	Archive(tenantID id.ID, r Resource, extID string) (id.ID, error)
+	// Restore restores the record undeleted in Salesforce and returns its id
+	Restore(tenantID id.ID, r Resource, extID string) (id.ID, error)
+	// Remove removes the course record deleted in Salesforce and returns its id
+	Remove(tenantID id.ID, r Resource, extID string) (id.ID, error)
+	// Sync applies the Salesforce operations of the resource's records
+	Sync(tenantID id.ID, r Resource, records []json.RawMessage) ([]*SyncResult, error)
 }
@@ -1,28 +1,19 @@
 package sf_import
 
 import (
-	"ac9/glad/pkg/common"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
-	"encoding/json"
-	"net/http"
-
-	l "ac9/glad/pkg/logger"
-
-	"github.com/valyala/fasthttp"
 )
 
 // Service import usecase
 type Service struct {
-	c        *fasthttp.Client
-	basePath string
+	c Client
 }
 
 // NewService creates a new service
-func NewService(basePath string) *Service {
+func NewService(c Client) *Service {
 	return &Service{
-		c:        &fasthttp.Client{},
-		basePath: basePath,
+		c: c,
 	}
 }
 
@@ -30,186 +21,62 @@ func NewService(basePath string) *Service {
 func (s *Service) ImportProduct(tenantID id.ID,
 	p []*glad.Product,
 ) ([]*glad.ProductResponse, error) {
-	path := s.basePath + "/v1/products/import"
-	l.Log.Debugf("path=%v", path)
-
-	jsonProducts, err := json.Marshal(p)
-	if err != nil {
-		l.Log.Errorf("err=%v", err)
-		return nil, err
-	}
-
-	req := fasthttp.AcquireRequest()
-	defer fasthttp.ReleaseRequest(req)
-
-	req.SetRequestURI(path)
-	req.Header.SetMethod(http.MethodPost)
-	req.Header.SetContentType("application/json")
-	req.Header.Set(common.HttpHeaderTenantID, tenantID.String())
-	req.SetBody([]byte(jsonProducts))
-
-	resp := fasthttp.AcquireResponse()
-	defer fasthttp.ReleaseResponse(resp)
-
-	err = s.c.Do(req, resp)
-	if err != nil {
-		l.Log.Errorf("err=%v", err)
-		return nil, err
-	}
-
-	if resp.StatusCode() != fasthttp.StatusOK {
-		l.Log.Warnf("Unable to import product. resp=%v, status=%v",
-			string(resp.Body()), resp.StatusCode())
-		return nil, err
-	}
-
-	var gResponse []*glad.ProductResponse
-	err = json.Unmarshal(resp.Body(), &gResponse)
-	if err != nil {
-		l.Log.Warnf("Unable to decode import product response. err=%v", err)
-		return nil, err
-	}
-
-	return gResponse, err
+	return s.c.ImportProducts(tenantID, p)
 }
 
 // ImportCenter imports centers
 func (s *Service) ImportCenter(tenantID id.ID,
 	p []*glad.Center,
 ) ([]*glad.CenterResponse, error) {
-	path := s.basePath + "/v1/centers/import"
-	l.Log.Debugf("path=%v", path)
-
-	jsonCenters, err := json.Marshal(p)
-	if err != nil {
-		l.Log.Errorf("err=%v", err)
-		return nil, err
-	}
-
-	req := fasthttp.AcquireRequest()
-	defer fasthttp.ReleaseRequest(req)
-
-	req.SetRequestURI(path)
-	req.Header.SetMethod(http.MethodPost)
-	req.Header.SetContentType("application/json")
-	req.Header.Set(common.HttpHeaderTenantID, tenantID.String())
-	req.SetBody([]byte(jsonCenters))
-
-	resp := fasthttp.AcquireResponse()
-	defer fasthttp.ReleaseResponse(resp)
-
-	err = s.c.Do(req, resp)
-	if err != nil {
-		l.Log.Errorf("err=%v", err)
-		return nil, err
-	}
-
-	if resp.StatusCode() != fasthttp.StatusOK {
-		l.Log.Warnf("Unable to import center. resp=%v, status=%v",
-			string(resp.Body()), resp.StatusCode())
-		return nil, err
-	}
-
-	var gResponse []*glad.CenterResponse
-	err = json.Unmarshal(resp.Body(), &gResponse)
-	if err != nil {
-		l.Log.Warnf("Unable to decode import center response. err=%v", err)
-		return nil, err
-	}
-
-	return gResponse, err
+	return s.c.ImportCenters(tenantID, p)
 }
 
 // ImportAccount imports accounts
 func (s *Service) ImportAccount(tenantID id.ID,
 	p []*glad.Account,
 ) ([]*glad.AccountResponse, error) {
-	path := s.basePath + "/v1/accounts/import"
-	l.Log.Debugf("path=%v", path)
-
-	jsonAccounts, err := json.Marshal(p)
-	if err != nil {
-		l.Log.Errorf("err=%v", err)
-		return nil, err
-	}
-
-	req := fasthttp.AcquireRequest()
-	defer fasthttp.ReleaseRequest(req)
-
-	req.SetRequestURI(path)
-	req.Header.SetMethod(http.MethodPost)
-	req.Header.SetContentType("application/json")
-	req.Header.Set(common.HttpHeaderTenantID, tenantID.String())
-	req.SetBody([]byte(jsonAccounts))
-
-	resp := fasthttp.AcquireResponse()
-	defer fasthttp.ReleaseResponse(resp)
-
-	err = s.c.Do(req, resp)
-	if err != nil {
-		l.Log.Errorf("err=%v", err)
-		return nil, err
-	}
-
-	if resp.StatusCode() != fasthttp.StatusOK {
-		l.Log.Warnf("Unable to import account. resp=%v, status=%v",
-			string(resp.Body()), resp.StatusCode())
-		return nil, err
-	}
-
-	var gResponse []*glad.AccountResponse
-	err = json.Unmarshal(resp.Body(), &gResponse)
-	if err != nil {
-		l.Log.Warnf("Unable to decode import account response. err=%v", err)
-		return nil, err
-	}
-
-	return gResponse, err
+	return s.c.ImportAccounts(tenantID, p)
 }
 
 // ImportCourse imports courses
 func (s *Service) ImportCourse(tenantID id.ID,
 	p []*glad.Course,
 ) ([]*glad.CourseResponse, error) {
-	path := s.basePath + "/v1/courses/import"
-	l.Log.Debugf("path=%v", path)
-
-	jsonCourses, err := json.Marshal(p)
-	if err != nil {
-		l.Log.Errorf("err=%v", err)
-		return nil, err
-	}
-
-	req := fasthttp.AcquireRequest()
-	defer fasthttp.ReleaseRequest(req)
+	return s.c.ImportCourses(tenantID, p)
+}
 
-	req.SetRequestURI(path)
-	req.Header.SetMethod(http.MethodPost)
-	req.Header.SetContentType("application/json")
-	req.Header.Set(common.HttpHeaderTenantID, tenantID.String())
-	req.SetBody([]byte(jsonCourses))
+// ImportCourseTiming imports course timings
+func (s *Service) ImportCourseTiming(tenantID id.ID,
+	p []*glad.CourseTiming,
+) ([]*glad.CourseTimingResponse, error) {
+	return s.c.ImportCourseTimings(tenantID, p)
+}
 
-	resp := fasthttp.AcquireResponse()
-	defer fasthttp.ReleaseResponse(resp)
+// ImportCourseRoster imports course teachers and organizers
+func (s *Service) ImportCourseRoster(tenantID id.ID,
+	p []*glad.CourseRoster,
+) ([]*glad.CourseRosterResponse, error) {
+	return s.c.ImportCourseRosters(tenantID, p)
+}
 
-	err = s.c.Do(req, resp)
-	if err != nil {
-		l.Log.Errorf("err=%v", err)
-		return nil, err
-	}
+// ImportParticipant imports participants of courses
+func (s *Service) ImportParticipant(tenantID id.ID,
+	p []*glad.Participant,
+) ([]*glad.ParticipantResponse, error) {
+	return s.c.ImportCourseParticipants(tenantID, p)
+}
 
-	if resp.StatusCode() != fasthttp.StatusOK {
-		l.Log.Warnf("Unable to import course. resp=%v, status=%v",
-			string(resp.Body()), resp.StatusCode())
-		return nil, err
-	}
+// Archive archives the record deleted in Salesforce
+func (s *Service) Archive(tenantID id.ID, r Resource, extID string) (id.ID, error) {
+	return s.c.Archive(tenantID, r.toCoursed(), extID)
+}
 
-	var gResponse []*glad.CourseResponse
-	err = json.Unmarshal(resp.Body(), &gResponse)
-	if err != nil {
-		l.Log.Warnf("Unable to decode import course response. err=%v", err)
-		return nil, err
-	}
+// Restore restores the record undeleted in Salesforce
+func (s *Service) Restore(tenantID id.ID, r Resource, extID string) (id.ID, error) {
+	return s.c.Restore(tenantID, r.toCoursed(), extID)
+}
 
-	return gResponse, err
+// Remove removes the course record deleted in Salesforce
+func (s *Service) Remove(tenantID id.ID, r Resource, extID string) (id.ID, error) {
+	return s.c.Remove(tenantID, r.toCoursed(), extID)
 }
@@ -0,0 +1,292 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package sf_import
+
+import (
+	"encoding/json"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/presenter"
+
+	"github.com/ulule/deepcopier"
+)
+
+// SyncResult result of the sync of a Salesforce record
+type SyncResult struct {
+	ID      int64
+	ExtID   string
+	IsError bool
+	Action  presenter.Action
+	// Message reason of the failure, when known
+	Message string
+}
+
+// record Salesforce operation on a record
+type record struct {
+	Operation string
+	ExtID     string
+}
+
+// upsertFunc imports the records at the given positions as a single batch
+// Results are expected in the order of the positions.
+type upsertFunc func(batch []int) ([]*SyncResult, error)
+
+// Sync applies the Salesforce operation of each record of the resource and
+// returns the results in the order of the records. records are the
+// Salesforce wrappers (object, operation, value) of the resource.
+// Returns glad.ErrInvalidValue when the records cannot be decoded. Any other
+// error means the upsert batch failed as a whole; the results are returned
+// nevertheless and the call is safe to repeat.
+func (s *Service) Sync(tenantID id.ID,
+	r Resource,
+	records []json.RawMessage,
+) ([]*SyncResult, error) {
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r {
+	case ResourceProduct:
+		var sfProducts []presenter.ProductWrapper
+		if err := decodeRecords(data, &sfProducts); err != nil {
+			return nil, err
+		}
+		recs := make([]record, len(sfProducts))
+		for i, sfProduct := range sfProducts {
+			recs[i] = record{sfProduct.Operation, sfProduct.Value.ExtID}
+		}
+		return s.syncRecords(tenantID, r, recs, func(batch []int) ([]*SyncResult, error) {
+			var gProducts []*glad.Product
+			for _, i := range batch {
+				product := &glad.Product{}
+				deepcopier.Copy(sfProducts[i].Value).To(product)
+				gProducts = append(gProducts, product)
+			}
+			gResponses, err := s.ImportProduct(tenantID, gProducts)
+			return toSyncResults(gResponses), err
+		})
+
+	case ResourceCenter:
+		var sfCenters []presenter.CenterWrapper
+		if err := decodeRecords(data, &sfCenters); err != nil {
+			return nil, err
+		}
+		recs := make([]record, len(sfCenters))
+		for i, sfCenter := range sfCenters {
+			recs[i] = record{sfCenter.Operation, sfCenter.Value.ExtID}
+		}
+		return s.syncRecords(tenantID, r, recs, func(batch []int) ([]*SyncResult, error) {
+			var gCenters []*glad.Center
+			for _, i := range batch {
+				center := &glad.Center{}
+				sfCenters[i].Value.ToGladCenter(center)
+				l.Log.Debugf("sfCenter=%#v, center=%#v", sfCenters[i].Value, center)
+				gCenters = append(gCenters, center)
+			}
+			gResponses, err := s.ImportCenter(tenantID, gCenters)
+			return toSyncResults(gResponses), err
+		})
+
+	case ResourceAccount:
+		var sfAccounts []presenter.AccountWrapper
+		if err := decodeRecords(data, &sfAccounts); err != nil {
+			return nil, err
+		}
+		recs := make([]record, len(sfAccounts))
+		for i, sfAccount := range sfAccounts {
+			recs[i] = record{sfAccount.Operation, sfAccount.Value.ExtID}
+		}
+		return s.syncRecords(tenantID, r, recs, func(batch []int) ([]*SyncResult, error) {
+			var gAccounts []*glad.Account
+			for _, i := range batch {
+				account := &glad.Account{}
+				sfAccounts[i].Value.ToGladAccount(account)
+				l.Log.Debugf("sfAccount=%#v, account=%#v", sfAccounts[i].Value, account)
+				gAccounts = append(gAccounts, account)
+			}
+			gResponses, err := s.ImportAccount(tenantID, gAccounts)
+			return toSyncResults(gResponses), err
+		})
+
+	case ResourceCourse:
+		var sfCourses []presenter.CourseWrapper
+		if err := decodeRecords(data, &sfCourses); err != nil {
+			return nil, err
+		}
+		recs := make([]record, len(sfCourses))
+		for i, sfCourse := range sfCourses {
+			recs[i] = record{sfCourse.Operation, sfCourse.Value.ExtID}
+		}
+		return s.syncRecords(tenantID, r, recs, func(batch []int) ([]*SyncResult, error) {
+			var gCourses []*glad.Course
+			for _, i := range batch {
+				course := &glad.Course{}
+				sfCourses[i].Value.ToGladCourse(course)
+				l.Log.Debugf("sfCourse=%#v, course=%#v", sfCourses[i], course)
+				gCourses = append(gCourses, course)
+			}
+			gResponses, err := s.ImportCourse(tenantID, gCourses)
+			return toSyncResults(gResponses), err
+		})
+
+	case ResourceCourseTiming:
+		var sfTimings []presenter.CourseTimingWrapper
+		if err := decodeRecords(data, &sfTimings); err != nil {
+			return nil, err
+		}
+		recs := make([]record, len(sfTimings))
+		for i, sfTiming := range sfTimings {
+			recs[i] = record{sfTiming.Operation, sfTiming.Value.ExtID}
+		}
+		return s.syncRecords(tenantID, r, recs, func(batch []int) ([]*SyncResult, error) {
+			var gTimings []*glad.CourseTiming
+			for _, i := range batch {
+				timing := &glad.CourseTiming{}
+				sfTimings[i].Value.ToGladCourseTiming(timing)
+				gTimings = append(gTimings, timing)
+			}
+			gResponses, err := s.ImportCourseTiming(tenantID, gTimings)
+			return toSyncResults(gResponses), err
+		})
+
+	case ResourceCourseRoster:
+		var sfRosters []presenter.CourseRosterWrapper
+		if err := decodeRecords(data, &sfRosters); err != nil {
+			return nil, err
+		}
+		recs := make([]record, len(sfRosters))
+		for i, sfRoster := range sfRosters {
+			recs[i] = record{sfRoster.Operation, sfRoster.Value.ExtID}
+		}
+		return s.syncRecords(tenantID, r, recs, func(batch []int) ([]*SyncResult, error) {
+			var gRosters []*glad.CourseRoster
+			for _, i := range batch {
+				roster := &glad.CourseRoster{}
+				sfRosters[i].ToGladCourseRoster(roster)
+				gRosters = append(gRosters, roster)
+			}
+			gResponses, err := s.ImportCourseRoster(tenantID, gRosters)
+			return toSyncResults(gResponses), err
+		})
+
+	case ResourceCourseParticipant:
+		var sfParticipants []presenter.ParticipantWrapper
+		if err := decodeRecords(data, &sfParticipants); err != nil {
+			return nil, err
+		}
+		recs := make([]record, len(sfParticipants))
+		for i, sfParticipant := range sfParticipants {
+			recs[i] = record{sfParticipant.Operation, sfParticipant.Value.ExtID}
+		}
+		return s.syncRecords(tenantID, r, recs, func(batch []int) ([]*SyncResult, error) {
+			var gParticipants []*glad.Participant
+			for _, i := range batch {
+				participant := &glad.Participant{}
+				sfParticipants[i].Value.ToGladParticipant(participant)
+				gParticipants = append(gParticipants, participant)
+			}
+			gResponses, err := s.ImportParticipant(tenantID, gParticipants)
+			return toSyncResults(gResponses), err
+		})
+	}
+
+	l.Log.Warnf("Unknown resource=%v", r)
+	return nil, glad.ErrInvalidValue
+}
+
+// syncRecords applies the Salesforce operation of each record and returns the
+// results in the order of the records. Deletes and undeletes are applied one
+// record at a time; inserts and updates are upserted as a single batch.
+// Failures are reported per record.
+func (s *Service) syncRecords(tenantID id.ID,
+	resource Resource,
+	records []record,
+	upsert upsertFunc,
+) ([]*SyncResult, error) {
+	results := make([]*SyncResult, len(records))
+	var batch []int
+	for i, rec := range records {
+		action, err := presenter.ToAction(rec.Operation)
+		if !resource.IsArchived() {
+			// removed on delete; the undeleted record is imported again
+			switch action {
+			case presenter.ActionArchive:
+				action = presenter.ActionRemove
+			case presenter.ActionRestore:
+				action = presenter.ActionUpsert
+			}
+		}
+		if action == presenter.ActionUpsert {
+			batch = append(batch, i)
+			continue
+		}
+
+		result := &SyncResult{ExtID: rec.ExtID, Action: action}
+		var recordID id.ID
+		switch action {
+		case presenter.ActionArchive:
+			recordID, err = s.Archive(tenantID, resource, rec.ExtID)
+		case presenter.ActionRestore:
+			recordID, err = s.Restore(tenantID, resource, rec.ExtID)
+		case presenter.ActionRemove:
+			recordID, err = s.Remove(tenantID, resource, rec.ExtID)
+		}
+		if err != nil {
+			l.Log.Warnf("Unable to sync %v extID=%v, operation=%v, err=%v",
+				resource, rec.ExtID, rec.Operation, err)
+			result.Message = err.Error()
+		}
+		result.ID = int64(recordID)
+		result.IsError = err != nil
+		results[i] = result
+	}
+
+	if len(batch) == 0 {
+		return results, nil
+	}
+
+	upserted, err := upsert(batch)
+	if err != nil {
+		l.Log.Warnf("Unable to import %v tenantID=%v, err=%v", resource, tenantID, err)
+	}
+	for j, i := range batch {
+		if j < len(upserted) && upserted[j] != nil {
+			results[i] = upserted[j]
+		} else {
+			// not imported; the whole batch failed
+			results[i] = &SyncResult{ExtID: records[i].ExtID, IsError: true}
+			if err != nil {
+				results[i].Message = err.Error()
+			}
+		}
+		results[i].Action = presenter.ActionUpsert
+	}
+	return results, err
+}
+
+// decodeRecords decodes the Salesforce wrappers of a resource
+func decodeRecords(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		l.Log.Warnf("Unable to decode records. err=%v", err)
+		return glad.ErrInvalidValue
+	}
+	return nil
+}
+
+// toSyncResults converts the import responses of coursed
+func toSyncResults[T any](responses []*T) []*SyncResult {
+	results := make([]*SyncResult, len(responses))
+	for i, resp := range responses {
+		results[i] = &SyncResult{}
+		deepcopier.Copy(resp).To(results[i])
+	}
+	return results
+}
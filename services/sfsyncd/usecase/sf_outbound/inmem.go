@@ -0,0 +1,71 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package sf_outbound
+
+import (
+	"sync"
+	"time"
+
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/sfsyncd/entity"
+)
+
+// inmem in memory repo
+type inmem struct {
+	m   map[id.ID]map[string]time.Time
+	mut sync.Mutex
+}
+
+// newInmem create new repository
+func newInmem() *inmem {
+	return &inmem{
+		m: map[id.ID]map[string]time.Time{},
+	}
+}
+
+// Claim keeps the ids of the notifications and returns the ids not kept before
+func (r *inmem) Claim(notifications []*entity.OutboundNotification) ([]string, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	var claimed []string
+	for _, n := range notifications {
+		if r.m[n.TenantID] == nil {
+			r.m[n.TenantID] = map[string]time.Time{}
+		}
+		if _, ok := r.m[n.TenantID][n.ID]; ok {
+			continue
+		}
+		r.m[n.TenantID][n.ID] = n.CreatedAt
+		claimed = append(claimed, n.ID)
+	}
+	return claimed, nil
+}
+
+// Release drops the ids of the claimed notifications
+func (r *inmem) Release(tenantID id.ID, notificationIDs []string) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	for _, notificationID := range notificationIDs {
+		delete(r.m[tenantID], notificationID)
+	}
+	return nil
+}
+
+// DeleteBefore drops the notifications received before t
+func (r *inmem) DeleteBefore(t time.Time) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	for _, ids := range r.m {
+		for notificationID, createdAt := range ids {
+			if createdAt.Before(t) {
+				delete(ids, notificationID)
+			}
+		}
+	}
+	return nil
+}
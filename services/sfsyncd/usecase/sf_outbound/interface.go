@@ -0,0 +1,52 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package sf_outbound
+
+import (
+	"encoding/json"
+	"time"
+
+	"ac9/glad/pkg/id"
+	"ac9/glad/services/sfsyncd/entity"
+	"ac9/glad/services/sfsyncd/usecase/sf_import"
+)
+
+// Writer outbound notification writer
+type Writer interface {
+	// Claim keeps the ids of the notifications and returns the ids that were
+	// not kept before; only those are applied
+	Claim(notifications []*entity.OutboundNotification) ([]string, error)
+	// Release drops the ids of the claimed notifications that were not
+	// applied, so that the resent notifications are
+	Release(tenantID id.ID, notificationIDs []string) error
+	// DeleteBefore drops the notifications that Salesforce no longer resends
+	DeleteBefore(t time.Time) error
+}
+
+// Repository interface
+type Repository interface {
+	Writer
+}
+
+// Importer imports the Salesforce records of a resource
+type Importer interface {
+	Sync(tenantID id.ID, r sf_import.Resource, records []json.RawMessage) ([]*sf_import.SyncResult, error)
+}
+
+// UseCase interface
+type UseCase interface {
+	// GetTenantID gets the tenant of the Salesforce org sending the messages
+	// with the secret of the org; glad.ErrAuthFailure when the secret does not
+	// match
+	GetTenantID(organizationID string, secret string) (id.ID, error)
+	// Receive applies the notifications that were not applied before. The
+	// notifications can be acknowledged when there is no error.
+	Receive(tenantID id.ID,
+		r sf_import.Resource,
+		notifications []*entity.OutboundNotification,
+	) ([]*sf_import.SyncResult, error)
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package sf_outbound
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/entity"
+	"ac9/glad/services/sfsyncd/usecase/sf_import"
+)
+
+const (
+	// retention how long the notification ids are kept; Salesforce stops
+	// resending a notification after 24 hours
+	retention = 7 * 24 * time.Hour
+	// purgeInterval how often the expired notification ids are dropped
+	purgeInterval = time.Hour
+	// orgIDLength length of the case-sensitive Salesforce org id
+	orgIDLength = 15
+	// minSecretLength minimum length of the secret of an org
+	minSecretLength = 32
+)
+
+// OrgTenant tenant of a Salesforce org and the secret the org sends its
+// messages with
+type OrgTenant struct {
+	TenantID id.ID
+	Secret   string
+}
+
+// Service outbound messaging usecase
+type Service struct {
+	repo     Repository
+	importer Importer
+	// tenants tenants by Salesforce org id
+	tenants   map[string]OrgTenant
+	mut       sync.Mutex
+	lastPurge time.Time
+}
+
+// NewService creates a new service; tenants are the tenants by the Salesforce
+// org id, see ParseOrgTenants
+func NewService(r Repository, i Importer, tenants map[string]OrgTenant) *Service {
+	return &Service{
+		repo:     r,
+		importer: i,
+		tenants:  tenants,
+	}
+}
+
+// ParseOrgTenants parses the tenants of the Salesforce orgs, given as
+// "<OrganizationId>:<tenant id>:<secret>" entries separated by commas
+func ParseOrgTenants(s string) (map[string]OrgTenant, error) {
+	tenants := map[string]OrgTenant{}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		// Note: the secret is not part of the errors, they are logged
+		fields := strings.SplitN(entry, ":", 3)
+		if len(fields) != 3 || len(fields[0]) < orgIDLength {
+			return nil, fmt.Errorf("invalid org tenant of org %q", fields[0])
+		}
+		tenantID, err := id.FromString(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid tenant of org %q: %w", fields[0], err)
+		}
+		if len(fields[2]) < minSecretLength {
+			return nil, fmt.Errorf("secret of org %q is shorter than %v characters",
+				fields[0], minSecretLength)
+		}
+		tenants[fields[0][:orgIDLength]] = OrgTenant{TenantID: tenantID, Secret: fields[2]}
+	}
+	return tenants, nil
+}
+
+// GetTenantID gets the tenant of the Salesforce org sending the messages with
+// the secret of the org
+// Note: Salesforce sends the 18 character org id; the 15 character id is
+// accepted as well.
+func (s *Service) GetTenantID(organizationID string, secret string) (id.ID, error) {
+	if len(organizationID) < orgIDLength {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+	tenant, ok := s.tenants[organizationID[:orgIDLength]]
+	if !ok {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(tenant.Secret)) != 1 {
+		return id.IDInvalid, glad.ErrAuthFailure
+	}
+	return tenant.TenantID, nil
+}
+
+// Receive applies the notifications that were not applied before
+// The notification ids are claimed before the records are applied, so that a
+// notification resent while the first delivery is being applied is skipped.
+// Results are for the applied notifications only. A notification resent
+// within the same message is applied once.
+func (s *Service) Receive(tenantID id.ID,
+	r sf_import.Resource,
+	notifications []*entity.OutboundNotification,
+) ([]*sf_import.SyncResult, error) {
+	if !r.IsValid() {
+		return nil, glad.ErrInvalidValue
+	}
+
+	for _, n := range notifications {
+		n.TenantID = tenantID
+		n.Resource = string(r)
+		n.CreatedAt = time.Now()
+	}
+	ids, err := s.repo.Claim(notifications)
+	if err != nil {
+		l.Log.Warnf("Unable to claim notifications, err=%v", err)
+		return nil, err
+	}
+	claimed := make(map[string]bool, len(ids))
+	for _, notificationID := range ids {
+		claimed[notificationID] = true
+	}
+
+	var records []json.RawMessage
+	for _, n := range notifications {
+		if !claimed[n.ID] {
+			l.Log.Infof("Skipping notification id=%v received before", n.ID)
+			continue
+		}
+		// applied once when resent within the message
+		claimed[n.ID] = false
+		records = append(records, n.Record)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	results, err := s.importer.Sync(tenantID, r, records)
+	if err != nil {
+		l.Log.Warnf("Unable to import notifications, err=%v", err)
+		// Note: the import is safe to repeat; the resent notifications are
+		// applied again unless the release fails
+		if err := s.repo.Release(tenantID, ids); err != nil {
+			l.Log.Errorf("Unable to release notifications ids=%v, err=%v", ids, err)
+		}
+		return nil, err
+	}
+
+	s.purge()
+	return results, nil
+}
+
+// purge drops the expired notification ids, at most once per purgeInterval
+func (s *Service) purge() {
+	s.mut.Lock()
+	if time.Since(s.lastPurge) < purgeInterval {
+		s.mut.Unlock()
+		return
+	}
+	s.lastPurge = time.Now()
+	s.mut.Unlock()
+
+	err := s.repo.DeleteBefore(time.Now().Add(-retention))
+	if err != nil {
+		l.Log.Warnf("Unable to purge received notifications, err=%v", err)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package sf_outbound
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+	"ac9/glad/services/sfsyncd/entity"
+	"ac9/glad/services/sfsyncd/usecase/sf_import"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice id.ID = 13790492210917015554
+	tenantBob   id.ID = 13790492210917015555
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+	os.Exit(m.Run())
+}
+
+// fakeImporter records the imported records, failing the calls when err is set
+type fakeImporter struct {
+	records []json.RawMessage
+	err     error
+	// during runs while the records are imported
+	during func()
+}
+
+func (f *fakeImporter) Sync(tenantID id.ID,
+	r sf_import.Resource,
+	records []json.RawMessage,
+) ([]*sf_import.SyncResult, error) {
+	if f.during != nil {
+		f.during()
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	var results []*sf_import.SyncResult
+	for _, rec := range records {
+		f.records = append(f.records, rec)
+		results = append(results, &sf_import.SyncResult{ExtID: string(rec)})
+	}
+	return results, nil
+}
+
+func newNotifications(ids ...string) []*entity.OutboundNotification {
+	var notifications []*entity.OutboundNotification
+	for _, notificationID := range ids {
+		notifications = append(notifications, &entity.OutboundNotification{
+			ID:     notificationID,
+			Record: json.RawMessage(`"` + notificationID + `"`),
+		})
+	}
+	return notifications
+}
+
+func Test_Receive(t *testing.T) {
+	repo := newInmem()
+	importer := &fakeImporter{}
+	s := NewService(repo, importer, nil)
+
+	t.Run("duplicates within the message", func(t *testing.T) {
+		results, err := s.Receive(tenantAlice, sf_import.ResourceCourse,
+			newNotifications("n1", "n2", "n1"))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(results))
+		assert.Equal(t, 2, len(importer.records))
+	})
+
+	t.Run("resent notifications", func(t *testing.T) {
+		results, err := s.Receive(tenantAlice, sf_import.ResourceCourse,
+			newNotifications("n2", "n3"))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, json.RawMessage(`"n3"`), importer.records[2])
+
+		results, err = s.Receive(tenantAlice, sf_import.ResourceCourse,
+			newNotifications("n1", "n3"))
+		assert.Nil(t, err)
+		assert.Nil(t, results)
+		assert.Equal(t, 3, len(importer.records))
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		results, err := s.Receive(tenantBob, sf_import.ResourceCourse,
+			newNotifications("n1"))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(results))
+	})
+
+	t.Run("import error", func(t *testing.T) {
+		importer.err = errors.New("coursed status=503")
+		_, err := s.Receive(tenantAlice, sf_import.ResourceCourse,
+			newNotifications("n4"))
+		assert.NotNil(t, err)
+
+		// not kept, so the resent notification is applied
+		importer.err = nil
+		results, err := s.Receive(tenantAlice, sf_import.ResourceCourse,
+			newNotifications("n4"))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(results))
+	})
+
+	t.Run("resent while applied", func(t *testing.T) {
+		importer.during = func() {
+			importer.during = nil
+			results, err := s.Receive(tenantAlice, sf_import.ResourceCourse,
+				newNotifications("n6"))
+			assert.Nil(t, err)
+			assert.Nil(t, results)
+		}
+		results, err := s.Receive(tenantAlice, sf_import.ResourceCourse,
+			newNotifications("n6"))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(results))
+	})
+
+	t.Run("invalid resource", func(t *testing.T) {
+		_, err := s.Receive(tenantAlice, sf_import.Resource("contacts"),
+			newNotifications("n5"))
+		assert.Equal(t, glad.ErrInvalidValue, err)
+	})
+}
+
+func Test_purge(t *testing.T) {
+	repo := newInmem()
+	s := NewService(repo, &fakeImporter{}, nil)
+
+	_, err := s.Receive(tenantAlice, sf_import.ResourceCourse, newNotifications("n1"))
+	assert.Nil(t, err)
+	repo.m[tenantAlice]["n1"] = time.Now().Add(-2 * retention)
+
+	// purged at most once per interval
+	_, err = s.Receive(tenantAlice, sf_import.ResourceCourse, newNotifications("n2"))
+	assert.Nil(t, err)
+	assert.Contains(t, repo.m[tenantAlice], "n1")
+
+	s.lastPurge = time.Now().Add(-2 * purgeInterval)
+	_, err = s.Receive(tenantAlice, sf_import.ResourceCourse, newNotifications("n3"))
+	assert.Nil(t, err)
+	assert.NotContains(t, repo.m[tenantAlice], "n1")
+	assert.Contains(t, repo.m[tenantAlice], "n2")
+	assert.Contains(t, repo.m[tenantAlice], "n3")
+}
+
+func Test_GetTenantID(t *testing.T) {
+	const secretAlice = "0123456789abcdef0123456789abcdef"
+	const secretBob = "fedcba9876543210fedcba9876543210"
+	tenants, err := ParseOrgTenants(" 00D000000000001AAA:" + tenantAlice.String() + ":" + secretAlice +
+		", 00D000000000002:" + tenantBob.String() + ":" + secretBob)
+	assert.Nil(t, err)
+	s := NewService(newInmem(), &fakeImporter{}, tenants)
+
+	tenantID, err := s.GetTenantID("00D000000000001", secretAlice)
+	assert.Nil(t, err)
+	assert.Equal(t, tenantAlice, tenantID)
+
+	tenantID, err = s.GetTenantID("00D000000000002BBB", secretBob)
+	assert.Nil(t, err)
+	assert.Equal(t, tenantBob, tenantID)
+
+	// the secret is the one of the org
+	_, err = s.GetTenantID("00D000000000002BBB", secretAlice)
+	assert.Equal(t, glad.ErrAuthFailure, err)
+	_, err = s.GetTenantID("00D000000000001", "")
+	assert.Equal(t, glad.ErrAuthFailure, err)
+
+	_, err = s.GetTenantID("00D000000000003AAA", secretAlice)
+	assert.Equal(t, glad.ErrNotFound, err)
+	_, err = s.GetTenantID("", "")
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	_, err = ParseOrgTenants("00D000000000001")
+	assert.NotNil(t, err)
+	_, err = ParseOrgTenants("00D000000000001:alice:" + secretAlice)
+	assert.NotNil(t, err)
+	_, err = ParseOrgTenants("00D000000000001:" + tenantAlice.String())
+	assert.NotNil(t, err)
+	_, err = ParseOrgTenants("00D000000000001:" + tenantAlice.String() + ":short")
+	assert.NotNil(t, err)
+}
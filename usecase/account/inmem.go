@@ -16,15 +16,20 @@ import (
 
 // inmem in memory repo
 type inmem struct {
-	m   map[id.ID]*entity.Account
-	mut *sync.RWMutex
+	m map[id.ID]*entity.Account
+	// calendar token hashes by account
+	tokens map[id.ID]string
+	// changes outbox of the export
+	changes []*entity.AuditLog
+	mut     *sync.RWMutex
 }
 
 // newInmem create new repository
 func newInmem() *inmem {
 	return &inmem{
-		m:   map[id.ID]*entity.Account{},
-		mut: &sync.RWMutex{},
+		m:      map[id.ID]*entity.Account{},
+		tokens: map[id.ID]string{},
+		mut:    &sync.RWMutex{},
 	}
 }
 
@@ -38,12 +43,12 @@ func (r *inmem) Create(e *entity.Account) error {
 }
 
 // Get retrieves an account
-func (r *inmem) Get(id id.ID) (*entity.Account, error) {
+func (r *inmem) Get(tenantID id.ID, accountID id.ID) (*entity.Account, error) {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
 	for _, j := range r.m {
-		if j.ID == id {
+		if j.ID == accountID && j.TenantID == tenantID {
 			return r.m[j.ID], nil
 		}
 	}
@@ -70,8 +75,8 @@ func (r *inmem) Update(e *entity.Account) error {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
-	_, ok := r.m[e.ID]
-	if !ok {
+	a, ok := r.m[e.ID]
+	if !ok || a.TenantID != e.TenantID {
 		return glad.ErrNotFound
 	}
 
@@ -104,12 +109,12 @@ func (r *inmem) List(tenantID id.ID, page, limit int, at entity.AccountType) ([]
 }
 
 // Delete deletes an account
-func (r *inmem) Delete(id id.ID) error {
+func (r *inmem) Delete(tenantID id.ID, accountID id.ID) error {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
-	account := r.m[id]
-	if account == nil {
+	account := r.m[accountID]
+	if account == nil || account.TenantID != tenantID {
 		return glad.ErrNotFound
 	}
 
@@ -188,6 +193,49 @@ func (r *inmem) GetByEmail(tenantID id.ID, email string) (*entity.Account, error
 	return nil, glad.ErrNotFound
 }
 
+// GetByExtID retrieves an account using external id
+func (r *inmem) GetByExtID(tenantID id.ID, extID string) (*entity.Account, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	for _, j := range r.m {
+		if j.ExtID == extID && j.TenantID == tenantID {
+			return r.m[j.ID], nil
+		}
+	}
+
+	return nil, glad.ErrNotFound
+}
+
+// SetStatus changes the account status
+func (r *inmem) SetStatus(tenantID id.ID, accountID id.ID, status entity.AccountStatus) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	a, ok := r.m[accountID]
+	if !ok || a.TenantID != tenantID {
+		return glad.ErrNotFound
+	}
+	updated := *a
+	updated.Status = status
+	r.m[accountID] = &updated
+	return nil
+}
+
+// GetByCognitoID retrieves an account using cognito id
+func (r *inmem) GetByCognitoID(tenantID id.ID, cognitoID string) (*entity.Account, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	for _, j := range r.m {
+		if j.CognitoID == cognitoID && j.TenantID == tenantID {
+			return r.m[j.ID], nil
+		}
+	}
+
+	return nil, glad.ErrNotFound
+}
+
 // Upsert upserts an account in memory
 func (r *inmem) Upsert(e *entity.Account) (id.ID, error) {
 	r.mut.Lock()
@@ -201,3 +249,71 @@ func (r *inmem) Upsert(e *entity.Account) (id.ID, error) {
 	r.m[e.ID] = e
 	return e.ID, nil
 }
+
+// BulkUpsert upserts accounts in memory
+func (r *inmem) BulkUpsert(tenantID id.ID, accounts []*entity.Account) (map[string]id.ID, error) {
+	ids := make(map[string]id.ID, len(accounts))
+	for _, a := range accounts {
+		a.TenantID = tenantID
+		accountID, err := r.Upsert(a)
+		if err != nil {
+			return nil, err
+		}
+		ids[a.ExtID] = accountID
+	}
+	return ids, nil
+}
+
+// SetCalendarToken sets the calendar token hash of the account
+func (r *inmem) SetCalendarToken(tenantID id.ID, accountID id.ID, tokenHash string) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	a, ok := r.m[accountID]
+	if !ok || a.TenantID != tenantID {
+		return glad.ErrNotFound
+	}
+	if tokenHash == "" {
+		delete(r.tokens, accountID)
+		return nil
+	}
+	r.tokens[accountID] = tokenHash
+	return nil
+}
+
+// GetByCalendarToken retrieves an account using the calendar token hash
+func (r *inmem) GetByCalendarToken(tokenHash string) (*entity.Account, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	for accountID, hash := range r.tokens {
+		if hash == tokenHash {
+			return r.m[accountID], nil
+		}
+	}
+
+	return nil, glad.ErrNotFound
+}
+
+// QueueChange queues the change for the export
+func (r *inmem) QueueChange(e *entity.AuditLog) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.changes = append(r.changes, e)
+	return nil
+}
+
+// InTx runs fn; the queued changes are dropped when it fails
+func (r *inmem) InTx(fn func(repo Repository) error) error {
+	r.mut.RLock()
+	changes := len(r.changes)
+	r.mut.RUnlock()
+
+	err := fn(r)
+	if err != nil {
+		r.mut.Lock()
+		r.changes = r.changes[:changes]
+		r.mut.Unlock()
+	}
+	return err
+}
@@ -14,20 +14,37 @@ import (
 // Reader interface
 type Reader interface {
 	GetByName(tenantID id.ID, username string) (*entity.Account, error)
-	Get(accountID id.ID) (*entity.Account, error)
+	Get(tenantID id.ID, accountID id.ID) (*entity.Account, error)
 	List(tenantID id.ID, page, limit int, at entity.AccountType) ([]*entity.Account, error)
 	Search(tenantID id.ID, query string, page, limit int, at entity.AccountType) ([]*entity.Account, error)
 	GetCount(tenantID id.ID) (int, error)
 	GetByEmail(tenantID id.ID, email string) (*entity.Account, error)
+	GetByExtID(tenantID id.ID, extID string) (*entity.Account, error)
+	GetByCognitoID(tenantID id.ID, cognitoID string) (*entity.Account, error)
+	// GetByCalendarToken retrieves the account, of any tenant, subscribed
+	// with the calendar token hash
+	GetByCalendarToken(tokenHash string) (*entity.Account, error)
 }
 
 // Writer interface
 type Writer interface {
 	Create(e *entity.Account) error
 	Update(e *entity.Account) error
-	Delete(accountID id.ID) error
+	Delete(tenantID id.ID, accountID id.ID) error
 	DeleteByName(tenantID id.ID, username string) error
 	Upsert(e *entity.Account) (id.ID, error)
+	// BulkUpsert upserts accounts keyed by external id; returns ids by external id
+	BulkUpsert(tenantID id.ID, accounts []*entity.Account) (map[string]id.ID, error)
+	// SetStatus changes the account status
+	SetStatus(tenantID id.ID, accountID id.ID, status entity.AccountStatus) error
+	// SetCalendarToken sets the calendar token hash of the account; an empty
+	// hash revokes the token
+	SetCalendarToken(tenantID id.ID, accountID id.ID, tokenHash string) error
+	// QueueChange queues the change for the export (see audit.Outbox)
+	QueueChange(e *entity.AuditLog) error
+	// InTx runs fn with a repository bound to a transaction; the writes made
+	// by fn are committed together
+	InTx(fn func(repo Repository) error) error
 }
 
 // Repository interface
@@ -39,6 +56,7 @@ type Repository interface {
 // UseCase interface
 type UseCase interface {
 	CreateAccount(
+		actor entity.AuditActor,
 		tenantID id.ID,
 		cognitoID string,
 		username string,
@@ -52,11 +70,23 @@ type UseCase interface {
 	GetAccount(tenantID id.ID, accountID id.ID) (*entity.Account, error)
 	GetAccountByName(tenantID id.ID, username string) (*entity.Account, error)
 	ListAccounts(tenantID id.ID, page, limit int, at entity.AccountType) ([]*entity.Account, error)
-	UpdateAccount(e *entity.Account) error
-	DeleteAccount(tenantID id.ID, accountID id.ID) error
-	DeleteAccountByName(tenantID id.ID, username string) error
+	UpdateAccount(actor entity.AuditActor, e *entity.Account) error
+	DeleteAccount(actor entity.AuditActor, tenantID id.ID, accountID id.ID) error
+	DeleteAccountByName(actor entity.AuditActor, tenantID id.ID, username string) error
 	GetCount(tenantId id.ID) int
 	SearchAccounts(tenantID id.ID, query string, page, limit int, at entity.AccountType) ([]*entity.Account, error)
 	GetAccountByEmail(tenantID id.ID, email string) (*entity.Account, error)
-	UpsertAccount(e *entity.Account) (id.ID, error)
+	GetAccountByExtID(tenantID id.ID, extID string) (*entity.Account, error)
+	GetAccountByCognitoID(tenantID id.ID, cognitoID string) (*entity.Account, error)
+	UpsertAccount(actor entity.AuditActor, e *entity.Account) (id.ID, error)
+	UpsertAccounts(actor entity.AuditActor, tenantID id.ID, accounts []*entity.Account) ([]id.ID, error)
+	ArchiveAccountByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error)
+	RestoreAccountByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error)
+	// ResetCalendarToken issues a new calendar subscription token for the
+	// account, replacing the previous one
+	ResetCalendarToken(actor entity.AuditActor, tenantID id.ID, accountID id.ID) (string, error)
+	RevokeCalendarToken(actor entity.AuditActor, tenantID id.ID, accountID id.ID) error
+	// GetAccountByCalendarToken retrieves the active account subscribed with
+	// the calendar token
+	GetAccountByCalendarToken(token string) (*entity.Account, error)
 }
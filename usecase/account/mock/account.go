@@ -7,6 +7,7 @@ package mock_account
 import (
 	entity "ac9/glad/entity"
 	id "ac9/glad/pkg/id"
+	account "ac9/glad/usecase/account"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -36,18 +37,48 @@ func (m *MockReader) EXPECT() *MockReaderMockRecorder {
 }
 
 // Get mocks base method.
-func (m *MockReader) Get(accountID id.ID) (*entity.Account, error) {
+func (m *MockReader) Get(tenantID, accountID id.ID) (*entity.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", accountID)
+	ret := m.ctrl.Call(m, "Get", tenantID, accountID)
 	ret0, _ := ret[0].(*entity.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockReaderMockRecorder) Get(accountID interface{}) *gomock.Call {
+func (mr *MockReaderMockRecorder) Get(tenantID, accountID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, accountID)
+}
+
+// GetByCalendarToken mocks base method.
+func (m *MockReader) GetByCalendarToken(tokenHash string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCalendarToken", tokenHash)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCalendarToken indicates an expected call of GetByCalendarToken.
+func (mr *MockReaderMockRecorder) GetByCalendarToken(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCalendarToken", reflect.TypeOf((*MockReader)(nil).GetByCalendarToken), tokenHash)
+}
+
+// GetByCognitoID mocks base method.
+func (m *MockReader) GetByCognitoID(tenantID id.ID, cognitoID string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCognitoID", tenantID, cognitoID)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCognitoID indicates an expected call of GetByCognitoID.
+func (mr *MockReaderMockRecorder) GetByCognitoID(tenantID, cognitoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCognitoID", reflect.TypeOf((*MockReader)(nil).GetByCognitoID), tenantID, cognitoID)
 }
 
 // GetByEmail mocks base method.
@@ -65,6 +96,21 @@ func (mr *MockReaderMockRecorder) GetByEmail(tenantID, email interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockReader)(nil).GetByEmail), tenantID, email)
 }
 
+// GetByExtID mocks base method.
+func (m *MockReader) GetByExtID(tenantID id.ID, extID string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", tenantID, extID)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockReaderMockRecorder) GetByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockReader)(nil).GetByExtID), tenantID, extID)
+}
+
 // GetByName mocks base method.
 func (m *MockReader) GetByName(tenantID id.ID, username string) (*entity.Account, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +194,21 @@ func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
 	return m.recorder
 }
 
+// BulkUpsert mocks base method.
+func (m *MockWriter) BulkUpsert(tenantID id.ID, accounts []*entity.Account) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsert", tenantID, accounts)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpsert indicates an expected call of BulkUpsert.
+func (mr *MockWriterMockRecorder) BulkUpsert(tenantID, accounts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockWriter)(nil).BulkUpsert), tenantID, accounts)
+}
+
 // Create mocks base method.
 func (m *MockWriter) Create(e *entity.Account) error {
 	m.ctrl.T.Helper()
@@ -163,17 +224,17 @@ func (mr *MockWriterMockRecorder) Create(e interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockWriter) Delete(accountID id.ID) error {
+func (m *MockWriter) Delete(tenantID, accountID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", accountID)
+	ret := m.ctrl.Call(m, "Delete", tenantID, accountID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockWriterMockRecorder) Delete(accountID interface{}) *gomock.Call {
+func (mr *MockWriterMockRecorder) Delete(tenantID, accountID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), tenantID, accountID)
 }
 
 // DeleteByName mocks base method.
@@ -190,6 +251,62 @@ func (mr *MockWriterMockRecorder) DeleteByName(tenantID, username interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByName", reflect.TypeOf((*MockWriter)(nil).DeleteByName), tenantID, username)
 }
 
+// InTx mocks base method.
+func (m *MockWriter) InTx(fn func(account.Repository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockWriterMockRecorder) InTx(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockWriter)(nil).InTx), fn)
+}
+
+// QueueChange mocks base method.
+func (m *MockWriter) QueueChange(e *entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueChange", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueChange indicates an expected call of QueueChange.
+func (mr *MockWriterMockRecorder) QueueChange(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueChange", reflect.TypeOf((*MockWriter)(nil).QueueChange), e)
+}
+
+// SetCalendarToken mocks base method.
+func (m *MockWriter) SetCalendarToken(tenantID, accountID id.ID, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCalendarToken", tenantID, accountID, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCalendarToken indicates an expected call of SetCalendarToken.
+func (mr *MockWriterMockRecorder) SetCalendarToken(tenantID, accountID, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCalendarToken", reflect.TypeOf((*MockWriter)(nil).SetCalendarToken), tenantID, accountID, tokenHash)
+}
+
+// SetStatus mocks base method.
+func (m *MockWriter) SetStatus(tenantID, accountID id.ID, status entity.AccountStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", tenantID, accountID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockWriterMockRecorder) SetStatus(tenantID, accountID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockWriter)(nil).SetStatus), tenantID, accountID, status)
+}
+
 // Update mocks base method.
 func (m *MockWriter) Update(e *entity.Account) error {
 	m.ctrl.T.Helper()
@@ -242,6 +359,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BulkUpsert mocks base method.
+func (m *MockRepository) BulkUpsert(tenantID id.ID, accounts []*entity.Account) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsert", tenantID, accounts)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpsert indicates an expected call of BulkUpsert.
+func (mr *MockRepositoryMockRecorder) BulkUpsert(tenantID, accounts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockRepository)(nil).BulkUpsert), tenantID, accounts)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(e *entity.Account) error {
 	m.ctrl.T.Helper()
@@ -257,17 +389,17 @@ func (mr *MockRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockRepository) Delete(accountID id.ID) error {
+func (m *MockRepository) Delete(tenantID, accountID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", accountID)
+	ret := m.ctrl.Call(m, "Delete", tenantID, accountID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(accountID interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(tenantID, accountID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), tenantID, accountID)
 }
 
 // DeleteByName mocks base method.
@@ -285,18 +417,48 @@ func (mr *MockRepositoryMockRecorder) DeleteByName(tenantID, username interface{
 }
 
 // Get mocks base method.
-func (m *MockRepository) Get(accountID id.ID) (*entity.Account, error) {
+func (m *MockRepository) Get(tenantID, accountID id.ID) (*entity.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", accountID)
+	ret := m.ctrl.Call(m, "Get", tenantID, accountID)
 	ret0, _ := ret[0].(*entity.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockRepositoryMockRecorder) Get(accountID interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Get(tenantID, accountID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, accountID)
+}
+
+// GetByCalendarToken mocks base method.
+func (m *MockRepository) GetByCalendarToken(tokenHash string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCalendarToken", tokenHash)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCalendarToken indicates an expected call of GetByCalendarToken.
+func (mr *MockRepositoryMockRecorder) GetByCalendarToken(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCalendarToken", reflect.TypeOf((*MockRepository)(nil).GetByCalendarToken), tokenHash)
+}
+
+// GetByCognitoID mocks base method.
+func (m *MockRepository) GetByCognitoID(tenantID id.ID, cognitoID string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCognitoID", tenantID, cognitoID)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCognitoID indicates an expected call of GetByCognitoID.
+func (mr *MockRepositoryMockRecorder) GetByCognitoID(tenantID, cognitoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCognitoID", reflect.TypeOf((*MockRepository)(nil).GetByCognitoID), tenantID, cognitoID)
 }
 
 // GetByEmail mocks base method.
@@ -314,6 +476,21 @@ func (mr *MockRepositoryMockRecorder) GetByEmail(tenantID, email interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockRepository)(nil).GetByEmail), tenantID, email)
 }
 
+// GetByExtID mocks base method.
+func (m *MockRepository) GetByExtID(tenantID id.ID, extID string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", tenantID, extID)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockRepositoryMockRecorder) GetByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockRepository)(nil).GetByExtID), tenantID, extID)
+}
+
 // GetByName mocks base method.
 func (m *MockRepository) GetByName(tenantID id.ID, username string) (*entity.Account, error) {
 	m.ctrl.T.Helper()
@@ -344,6 +521,20 @@ func (mr *MockRepositoryMockRecorder) GetCount(tenantID interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRepository)(nil).GetCount), tenantID)
 }
 
+// InTx mocks base method.
+func (m *MockRepository) InTx(fn func(account.Repository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockRepositoryMockRecorder) InTx(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockRepository)(nil).InTx), fn)
+}
+
 // List mocks base method.
 func (m *MockRepository) List(tenantID id.ID, page, limit int, at entity.AccountType) ([]*entity.Account, error) {
 	m.ctrl.T.Helper()
@@ -359,6 +550,20 @@ func (mr *MockRepositoryMockRecorder) List(tenantID, page, limit, at interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), tenantID, page, limit, at)
 }
 
+// QueueChange mocks base method.
+func (m *MockRepository) QueueChange(e *entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueChange", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueChange indicates an expected call of QueueChange.
+func (mr *MockRepositoryMockRecorder) QueueChange(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueChange", reflect.TypeOf((*MockRepository)(nil).QueueChange), e)
+}
+
 // Search mocks base method.
 func (m *MockRepository) Search(tenantID id.ID, query string, page, limit int, at entity.AccountType) ([]*entity.Account, error) {
 	m.ctrl.T.Helper()
@@ -374,6 +579,34 @@ func (mr *MockRepositoryMockRecorder) Search(tenantID, query, page, limit, at in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRepository)(nil).Search), tenantID, query, page, limit, at)
 }
 
+// SetCalendarToken mocks base method.
+func (m *MockRepository) SetCalendarToken(tenantID, accountID id.ID, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCalendarToken", tenantID, accountID, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCalendarToken indicates an expected call of SetCalendarToken.
+func (mr *MockRepositoryMockRecorder) SetCalendarToken(tenantID, accountID, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCalendarToken", reflect.TypeOf((*MockRepository)(nil).SetCalendarToken), tenantID, accountID, tokenHash)
+}
+
+// SetStatus mocks base method.
+func (m *MockRepository) SetStatus(tenantID, accountID id.ID, status entity.AccountStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", tenantID, accountID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockRepositoryMockRecorder) SetStatus(tenantID, accountID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockRepository)(nil).SetStatus), tenantID, accountID, status)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(e *entity.Account) error {
 	m.ctrl.T.Helper()
@@ -426,46 +659,61 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ArchiveAccountByExtID mocks base method.
+func (m *MockUseCase) ArchiveAccountByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveAccountByExtID", actor, tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveAccountByExtID indicates an expected call of ArchiveAccountByExtID.
+func (mr *MockUseCaseMockRecorder) ArchiveAccountByExtID(actor, tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveAccountByExtID", reflect.TypeOf((*MockUseCase)(nil).ArchiveAccountByExtID), actor, tenantID, extID)
+}
+
 // CreateAccount mocks base method.
-func (m *MockUseCase) CreateAccount(tenantID id.ID, cognitoID, username, first_name, last_name, phone, email string, at entity.AccountType, as entity.AccountStatus) error {
+func (m *MockUseCase) CreateAccount(actor entity.AuditActor, tenantID id.ID, cognitoID, username, first_name, last_name, phone, email string, at entity.AccountType, as entity.AccountStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccount", tenantID, cognitoID, username, first_name, last_name, phone, email, at, as)
+	ret := m.ctrl.Call(m, "CreateAccount", actor, tenantID, cognitoID, username, first_name, last_name, phone, email, at, as)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateAccount indicates an expected call of CreateAccount.
-func (mr *MockUseCaseMockRecorder) CreateAccount(tenantID, cognitoID, username, first_name, last_name, phone, email, at, as interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) CreateAccount(actor, tenantID, cognitoID, username, first_name, last_name, phone, email, at, as interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockUseCase)(nil).CreateAccount), tenantID, cognitoID, username, first_name, last_name, phone, email, at, as)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockUseCase)(nil).CreateAccount), actor, tenantID, cognitoID, username, first_name, last_name, phone, email, at, as)
 }
 
 // DeleteAccount mocks base method.
-func (m *MockUseCase) DeleteAccount(tenantID, accountID id.ID) error {
+func (m *MockUseCase) DeleteAccount(actor entity.AuditActor, tenantID, accountID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", tenantID, accountID)
+	ret := m.ctrl.Call(m, "DeleteAccount", actor, tenantID, accountID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockUseCaseMockRecorder) DeleteAccount(tenantID, accountID interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DeleteAccount(actor, tenantID, accountID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockUseCase)(nil).DeleteAccount), tenantID, accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockUseCase)(nil).DeleteAccount), actor, tenantID, accountID)
 }
 
 // DeleteAccountByName mocks base method.
-func (m *MockUseCase) DeleteAccountByName(tenantID id.ID, username string) error {
+func (m *MockUseCase) DeleteAccountByName(actor entity.AuditActor, tenantID id.ID, username string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccountByName", tenantID, username)
+	ret := m.ctrl.Call(m, "DeleteAccountByName", actor, tenantID, username)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAccountByName indicates an expected call of DeleteAccountByName.
-func (mr *MockUseCaseMockRecorder) DeleteAccountByName(tenantID, username interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DeleteAccountByName(actor, tenantID, username interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountByName", reflect.TypeOf((*MockUseCase)(nil).DeleteAccountByName), tenantID, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountByName", reflect.TypeOf((*MockUseCase)(nil).DeleteAccountByName), actor, tenantID, username)
 }
 
 // GetAccount mocks base method.
@@ -483,6 +731,36 @@ func (mr *MockUseCaseMockRecorder) GetAccount(tenantID, accountID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockUseCase)(nil).GetAccount), tenantID, accountID)
 }
 
+// GetAccountByCalendarToken mocks base method.
+func (m *MockUseCase) GetAccountByCalendarToken(token string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByCalendarToken", token)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByCalendarToken indicates an expected call of GetAccountByCalendarToken.
+func (mr *MockUseCaseMockRecorder) GetAccountByCalendarToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByCalendarToken", reflect.TypeOf((*MockUseCase)(nil).GetAccountByCalendarToken), token)
+}
+
+// GetAccountByCognitoID mocks base method.
+func (m *MockUseCase) GetAccountByCognitoID(tenantID id.ID, cognitoID string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByCognitoID", tenantID, cognitoID)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByCognitoID indicates an expected call of GetAccountByCognitoID.
+func (mr *MockUseCaseMockRecorder) GetAccountByCognitoID(tenantID, cognitoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByCognitoID", reflect.TypeOf((*MockUseCase)(nil).GetAccountByCognitoID), tenantID, cognitoID)
+}
+
 // GetAccountByEmail mocks base method.
 func (m *MockUseCase) GetAccountByEmail(tenantID id.ID, email string) (*entity.Account, error) {
 	m.ctrl.T.Helper()
@@ -498,6 +776,21 @@ func (mr *MockUseCaseMockRecorder) GetAccountByEmail(tenantID, email interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByEmail", reflect.TypeOf((*MockUseCase)(nil).GetAccountByEmail), tenantID, email)
 }
 
+// GetAccountByExtID mocks base method.
+func (m *MockUseCase) GetAccountByExtID(tenantID id.ID, extID string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByExtID", tenantID, extID)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByExtID indicates an expected call of GetAccountByExtID.
+func (mr *MockUseCaseMockRecorder) GetAccountByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByExtID", reflect.TypeOf((*MockUseCase)(nil).GetAccountByExtID), tenantID, extID)
+}
+
 // GetAccountByName mocks base method.
 func (m *MockUseCase) GetAccountByName(tenantID id.ID, username string) (*entity.Account, error) {
 	m.ctrl.T.Helper()
@@ -542,6 +835,50 @@ func (mr *MockUseCaseMockRecorder) ListAccounts(tenantID, page, limit, at interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockUseCase)(nil).ListAccounts), tenantID, page, limit, at)
 }
 
+// ResetCalendarToken mocks base method.
+func (m *MockUseCase) ResetCalendarToken(actor entity.AuditActor, tenantID, accountID id.ID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetCalendarToken", actor, tenantID, accountID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetCalendarToken indicates an expected call of ResetCalendarToken.
+func (mr *MockUseCaseMockRecorder) ResetCalendarToken(actor, tenantID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetCalendarToken", reflect.TypeOf((*MockUseCase)(nil).ResetCalendarToken), actor, tenantID, accountID)
+}
+
+// RestoreAccountByExtID mocks base method.
+func (m *MockUseCase) RestoreAccountByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAccountByExtID", actor, tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreAccountByExtID indicates an expected call of RestoreAccountByExtID.
+func (mr *MockUseCaseMockRecorder) RestoreAccountByExtID(actor, tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAccountByExtID", reflect.TypeOf((*MockUseCase)(nil).RestoreAccountByExtID), actor, tenantID, extID)
+}
+
+// RevokeCalendarToken mocks base method.
+func (m *MockUseCase) RevokeCalendarToken(actor entity.AuditActor, tenantID, accountID id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCalendarToken", actor, tenantID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeCalendarToken indicates an expected call of RevokeCalendarToken.
+func (mr *MockUseCaseMockRecorder) RevokeCalendarToken(actor, tenantID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCalendarToken", reflect.TypeOf((*MockUseCase)(nil).RevokeCalendarToken), actor, tenantID, accountID)
+}
+
 // SearchAccounts mocks base method.
 func (m *MockUseCase) SearchAccounts(tenantID id.ID, query string, page, limit int, at entity.AccountType) ([]*entity.Account, error) {
 	m.ctrl.T.Helper()
@@ -558,30 +895,45 @@ func (mr *MockUseCaseMockRecorder) SearchAccounts(tenantID, query, page, limit,
 }
 
 // UpdateAccount mocks base method.
-func (m *MockUseCase) UpdateAccount(e *entity.Account) error {
+func (m *MockUseCase) UpdateAccount(actor entity.AuditActor, e *entity.Account) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAccount", e)
+	ret := m.ctrl.Call(m, "UpdateAccount", actor, e)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateAccount indicates an expected call of UpdateAccount.
-func (mr *MockUseCaseMockRecorder) UpdateAccount(e interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) UpdateAccount(actor, e interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockUseCase)(nil).UpdateAccount), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockUseCase)(nil).UpdateAccount), actor, e)
 }
 
 // UpsertAccount mocks base method.
-func (m *MockUseCase) UpsertAccount(e *entity.Account) (id.ID, error) {
+func (m *MockUseCase) UpsertAccount(actor entity.AuditActor, e *entity.Account) (id.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertAccount", e)
+	ret := m.ctrl.Call(m, "UpsertAccount", actor, e)
 	ret0, _ := ret[0].(id.ID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpsertAccount indicates an expected call of UpsertAccount.
-func (mr *MockUseCaseMockRecorder) UpsertAccount(e interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) UpsertAccount(actor, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAccount", reflect.TypeOf((*MockUseCase)(nil).UpsertAccount), actor, e)
+}
+
+// UpsertAccounts mocks base method.
+func (m *MockUseCase) UpsertAccounts(actor entity.AuditActor, tenantID id.ID, accounts []*entity.Account) ([]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertAccounts", actor, tenantID, accounts)
+	ret0, _ := ret[0].([]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertAccounts indicates an expected call of UpsertAccounts.
+func (mr *MockUseCaseMockRecorder) UpsertAccounts(actor, tenantID, accounts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAccount", reflect.TypeOf((*MockUseCase)(nil).UpsertAccount), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAccounts", reflect.TypeOf((*MockUseCase)(nil).UpsertAccounts), actor, tenantID, accounts)
 }
@@ -13,22 +13,26 @@ import (
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
+	"ac9/glad/usecase/audit"
 )
 
 // Service account usecase
 type Service struct {
-	repo Repository
+	repo  Repository
+	audit audit.Recorder
 }
 
 // NewService create new service
-func NewService(r Repository) *Service {
+func NewService(r Repository, a audit.Recorder) *Service {
 	return &Service{
-		repo: r,
+		repo:  r,
+		audit: a,
 	}
 }
 
 // CreateAccount creates an account
 func (s *Service) CreateAccount(
+	actor entity.AuditActor,
 	tenantID id.ID,
 	cognitoID string,
 	username string,
@@ -52,12 +56,24 @@ func (s *Service) CreateAccount(
 	if err != nil {
 		return err
 	}
-	return s.repo.Create(account)
+	err = s.repo.InTx(func(repo Repository) error {
+		err := repo.Create(account)
+		if err != nil {
+			return err
+		}
+		return audit.QueueChange(repo, actor, tenantID, entity.AuditAccount, account.ID, entity.AuditCreate, account)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditAccount, account.ID, entity.AuditCreate, nil, account)
+	return nil
 }
 
 // GetAccount retrieves an account
 func (s *Service) GetAccount(tenantID id.ID, accountID id.ID) (*entity.Account, error) {
-	account, err := s.repo.Get(accountID)
+	account, err := s.repo.Get(tenantID, accountID)
 	if account == nil {
 		return nil, glad.ErrNotFound
 	}
@@ -94,17 +110,31 @@ func (s *Service) ListAccounts(tenantID id.ID, page, limit int, at entity.Accoun
 }
 
 // UpdateAccount Update a account
-func (s *Service) UpdateAccount(t *entity.Account) error {
+func (s *Service) UpdateAccount(actor entity.AuditActor, t *entity.Account) error {
 	err := t.Validate()
 	if err != nil {
 		return err
 	}
+
+	before, _ := s.repo.Get(t.TenantID, t.ID)
 	t.UpdatedAt = time.Now()
-	return s.repo.Update(t)
+	err = s.repo.InTx(func(repo Repository) error {
+		err := repo.Update(t)
+		if err != nil {
+			return err
+		}
+		return audit.QueueChange(repo, actor, t.TenantID, entity.AuditAccount, t.ID, entity.AuditUpdate, t)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, t.TenantID, entity.AuditAccount, t.ID, entity.AuditUpdate, before, t)
+	return nil
 }
 
 // DeleteAccount Deletes an account
-func (s *Service) DeleteAccount(tenantID id.ID, accountID id.ID) error {
+func (s *Service) DeleteAccount(actor entity.AuditActor, tenantID id.ID, accountID id.ID) error {
 	account, err := s.GetAccount(tenantID, accountID)
 	if account == nil {
 		return glad.ErrNotFound
@@ -113,11 +143,23 @@ func (s *Service) DeleteAccount(tenantID id.ID, accountID id.ID) error {
 		return err
 	}
 
-	return s.repo.Delete(accountID)
+	err = s.repo.InTx(func(repo Repository) error {
+		err := repo.Delete(tenantID, accountID)
+		if err != nil {
+			return err
+		}
+		return audit.QueueChange(repo, actor, tenantID, entity.AuditAccount, accountID, entity.AuditDelete, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditAccount, accountID, entity.AuditDelete, account, nil)
+	return nil
 }
 
 // DeleteAccount Deletes an account using username
-func (s *Service) DeleteAccountByName(tenantID id.ID, username string) error {
+func (s *Service) DeleteAccountByName(actor entity.AuditActor, tenantID id.ID, username string) error {
 	account, err := s.GetAccountByName(tenantID, username)
 	if account == nil {
 		return glad.ErrNotFound
@@ -126,7 +168,19 @@ func (s *Service) DeleteAccountByName(tenantID id.ID, username string) error {
 		return err
 	}
 
-	return s.repo.DeleteByName(tenantID, username)
+	err = s.repo.InTx(func(repo Repository) error {
+		err := repo.DeleteByName(tenantID, username)
+		if err != nil {
+			return err
+		}
+		return audit.QueueChange(repo, actor, tenantID, entity.AuditAccount, account.ID, entity.AuditDelete, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditAccount, account.ID, entity.AuditDelete, account, nil)
+	return nil
 }
 
 // GetCount gets total account count
@@ -164,8 +218,34 @@ func (s *Service) GetAccountByEmail(tenantID id.ID, email string) (*entity.Accou
 	return account, nil
 }
 
+// GetAccountByExtID retrieves an account using external id
+func (s *Service) GetAccountByExtID(tenantID id.ID, extID string) (*entity.Account, error) {
+	account, err := s.repo.GetByExtID(tenantID, extID)
+	if account == nil {
+		return nil, glad.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// GetAccountByCognitoID retrieves an account using cognito id
+func (s *Service) GetAccountByCognitoID(tenantID id.ID, cognitoID string) (*entity.Account, error) {
+	account, err := s.repo.GetByCognitoID(tenantID, cognitoID)
+	if account == nil {
+		return nil, glad.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
 // UpsertAccount upserts an account
-func (s *Service) UpsertAccount(a *entity.Account) (id.ID, error) {
+func (s *Service) UpsertAccount(actor entity.AuditActor, a *entity.Account) (id.ID, error) {
 	if a.ID == id.IDInvalid {
 		// assign id and during update id should not be overwritten
 		a.ID = id.New()
@@ -180,5 +260,192 @@ func (s *Service) UpsertAccount(a *entity.Account) (id.ID, error) {
 		l.Log.Warnf("err=%v", err)
 		return id.IDInvalid, err
 	}
-	return s.repo.Upsert(a)
+
+	var before *entity.Account
+	if a.ExtID != "" {
+		before, _ = s.repo.GetByExtID(a.TenantID, a.ExtID)
+	}
+	accountID, err := s.repo.Upsert(a)
+	if err != nil {
+		return accountID, err
+	}
+
+	s.audit.Record(actor, a.TenantID, entity.AuditAccount, accountID, entity.AuditUpsert, before, a)
+	return accountID, nil
+}
+
+// UpsertAccounts upserts the tenant's accounts imported in bulk
+// The returned ids follow the order of accounts; a account that could not be
+// upserted gets id.IDInvalid. When the batch is rejected as a whole, e.g. on a
+// constraint violation, the accounts are upserted one by one so that a single
+// bad record does not fail the others.
+func (s *Service) UpsertAccounts(actor entity.AuditActor,
+	tenantID id.ID,
+	accounts []*entity.Account,
+) ([]id.ID, error) {
+	ids := make([]id.ID, len(accounts))
+	valid := make([]bool, len(accounts))
+
+	// the last record wins when an external id repeats within the batch
+	last := make(map[string]int, len(accounts))
+	for i, a := range accounts {
+		a.TenantID = tenantID
+		if a.ID == id.IDInvalid {
+			a.ID = id.New()
+		}
+
+		// Note: Salesforce data is not cleaner. Transform the data as a workaround
+		a.Transform()
+
+		err := a.Validate()
+		if err == nil && a.ExtID == "" {
+			err = glad.ErrInvalidValue
+		}
+		if err != nil {
+			l.Log.Warnf("index=%v, err=%v", i, err)
+			continue
+		}
+		valid[i] = true
+		last[a.ExtID] = i
+	}
+
+	batch := make([]*entity.Account, 0, len(last))
+	for i, a := range accounts {
+		if j, ok := last[a.ExtID]; ok && j == i {
+			batch = append(batch, a)
+		}
+	}
+	if len(batch) == 0 {
+		return ids, nil
+	}
+
+	byExtID, err := s.repo.BulkUpsert(tenantID, batch)
+	if err != nil {
+		l.Log.Warnf("Bulk upsert failed, upserting one by one. err=%v", err)
+		byExtID = make(map[string]id.ID, len(batch))
+		for _, a := range batch {
+			accountID, err := s.UpsertAccount(actor, a)
+			if err != nil {
+				l.Log.Warnf("extID=%v, err=%v", a.ExtID, err)
+				continue
+			}
+			byExtID[a.ExtID] = accountID
+		}
+	} else {
+		// Note: the state before the upsert is not looked up for bulk imports
+		for _, a := range batch {
+			s.audit.Record(actor, tenantID, entity.AuditAccount, byExtID[a.ExtID],
+				entity.AuditUpsert, nil, a)
+		}
+	}
+
+	for i, a := range accounts {
+		if valid[i] {
+			ids[i] = byExtID[a.ExtID]
+		}
+	}
+	return ids, nil
+}
+
+// ArchiveAccountByExtID disables the account deleted in Salesforce
+func (s *Service) ArchiveAccountByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+) (id.ID, error) {
+	return s.setStatusByExtID(actor, tenantID, extID, entity.AccountDisabled)
+}
+
+// RestoreAccountByExtID activates the account undeleted in Salesforce
+// Note: The status before the delete is not kept; it is corrected by the
+// next update from Salesforce.
+func (s *Service) RestoreAccountByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+) (id.ID, error) {
+	return s.setStatusByExtID(actor, tenantID, extID, entity.AccountActive)
+}
+
+// setStatusByExtID sets the account status; no-op when unchanged
+func (s *Service) setStatusByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+	status entity.AccountStatus,
+) (id.ID, error) {
+	if extID == "" {
+		return id.IDInvalid, glad.ErrInvalidValue
+	}
+
+	a, err := s.repo.GetByExtID(tenantID, extID)
+	if a == nil {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	if a.Status == status {
+		return a.ID, nil
+	}
+
+	err = s.repo.SetStatus(tenantID, a.ID, status)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	updated := *a
+	updated.Status = status
+	s.audit.Record(actor, tenantID, entity.AuditAccount, a.ID, entity.AuditUpdate, a, &updated)
+	return a.ID, nil
+}
+
+// ResetCalendarToken issues a new calendar subscription token for the account,
+// replacing the previous one. The token is returned only here.
+func (s *Service) ResetCalendarToken(actor entity.AuditActor,
+	tenantID id.ID,
+	accountID id.ID,
+) (string, error) {
+	token, hash, err := entity.NewCalendarToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.repo.SetCalendarToken(tenantID, accountID, hash)
+	if err != nil {
+		return "", err
+	}
+
+	// the token is a secret, only the change is recorded
+	s.audit.Record(actor, tenantID, entity.AuditAccount, accountID, entity.AuditUpdate, nil, nil)
+	return token, nil
+}
+
+// RevokeCalendarToken revokes the calendar subscription token of the account
+func (s *Service) RevokeCalendarToken(actor entity.AuditActor, tenantID id.ID, accountID id.ID) error {
+	err := s.repo.SetCalendarToken(tenantID, accountID, "")
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditAccount, accountID, entity.AuditUpdate, nil, nil)
+	return nil
+}
+
+// GetAccountByCalendarToken retrieves the account subscribed with the calendar
+// token; tokens of inactive or disabled accounts are not honored
+func (s *Service) GetAccountByCalendarToken(token string) (*entity.Account, error) {
+	if token == "" {
+		return nil, glad.ErrNotFound
+	}
+
+	account, err := s.repo.GetByCalendarToken(entity.HashCalendarToken(token))
+	if account == nil {
+		return nil, glad.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account.Status != "" && account.Status != entity.AccountActive {
+		return nil, glad.ErrNotFound
+	}
+
+	return account, nil
 }
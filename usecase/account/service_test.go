@@ -13,6 +13,7 @@ import (
 	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
+	"ac9/glad/usecase/audit"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,10 +31,12 @@ const (
 	aliceCognitoID  = "aws:cognito:alice"
 	alice2CognitoID = "aws:cognito:alice2"
 
-	// todo: add multi-tenant support
-	// tenantBob id.ID = 13790492210917015555
+	tenantBob id.ID = 13790492210917015555
 )
 
+// testActor caller making the changes
+var testActor = entity.AuditActor{Source: entity.AuditSourceAPI}
+
 func newFixtureAccount() *entity.Account {
 	return &entity.Account{
 		ID: accountIDAlice,
@@ -53,9 +56,9 @@ func newFixtureAccount() *entity.Account {
 
 func Test_Create(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	account := newFixtureAccount()
-	err := m.CreateAccount(tenantAlice,
+	err := m.CreateAccount(testActor, tenantAlice,
 		account.CognitoID,
 		account.Username,
 		account.FirstName,
@@ -71,7 +74,7 @@ func Test_Create(t *testing.T) {
 
 func Test_SearchAndFind(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	account1 := newFixtureAccount()
 	account2 := newFixtureAccount()
 	account2.ID = accountID2Alice
@@ -79,7 +82,7 @@ func Test_SearchAndFind(t *testing.T) {
 	account2.ExtID = alice2ExtID
 	account2.CognitoID = alice2CognitoID
 
-	_ = m.CreateAccount(tenantAlice,
+	_ = m.CreateAccount(testActor, tenantAlice,
 		account1.CognitoID,
 		account1.Username,
 		account1.FirstName,
@@ -89,7 +92,7 @@ func Test_SearchAndFind(t *testing.T) {
 		account1.Type,
 		account1.Status,
 	)
-	_ = m.CreateAccount(tenantAlice,
+	_ = m.CreateAccount(testActor, tenantAlice,
 		account2.CognitoID,
 		account2.Username,
 		account2.FirstName,
@@ -118,9 +121,9 @@ func Test_SearchAndFind(t *testing.T) {
 // Perhaps a human readable name can be given for customer to reference.
 func Test_Update(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	account := newFixtureAccount()
-	err := m.CreateAccount(tenantAlice,
+	err := m.CreateAccount(testActor, tenantAlice,
 		account.CognitoID,
 		account.Username,
 		account.FirstName,
@@ -134,7 +137,7 @@ func Test_Update(t *testing.T) {
 
 	saved, _ := m.GetAccountByName(tenantAlice, account.Username)
 	saved.Username = "starred"
-	assert.Nil(t, m.UpdateAccount(saved))
+	assert.Nil(t, m.UpdateAccount(testActor, saved))
 
 	_, err = m.GetAccountByName(tenantAlice, account.Username)
 	assert.Equal(t, glad.ErrNotFound, err)
@@ -146,7 +149,7 @@ func Test_Update(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 
 	account1 := newFixtureAccount()
 
@@ -154,7 +157,7 @@ func TestDelete(t *testing.T) {
 	account2.ID = accountID2Alice
 	account2.Username = accountUsername2Alice
 	account2.ExtID = alice2ExtID
-	_ = m.CreateAccount(tenantAlice,
+	_ = m.CreateAccount(testActor, tenantAlice,
 		account2.CognitoID,
 		account2.Username,
 		account2.FirstName,
@@ -165,12 +168,110 @@ func TestDelete(t *testing.T) {
 		account2.Status,
 	)
 
-	err := m.DeleteAccountByName(tenantAlice, account1.Username)
+	err := m.DeleteAccountByName(testActor, tenantAlice, account1.Username)
 	assert.Equal(t, glad.ErrNotFound, err)
 
-	err = m.DeleteAccountByName(tenantAlice, account2.Username)
+	err = m.DeleteAccountByName(testActor, tenantAlice, account2.Username)
 	assert.Nil(t, err)
 
 	_, err = m.GetAccountByName(tenantAlice, account2.Username)
 	assert.Equal(t, glad.ErrNotFound, err)
 }
+
+func TestTenantIsolation(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo, audit.Discard)
+	account := newFixtureAccount()
+	err := m.CreateAccount(testActor, tenantAlice,
+		account.CognitoID,
+		account.Username,
+		account.FirstName,
+		account.LastName,
+		account.Phone,
+		account.Email,
+		account.Type,
+		account.Status,
+	)
+	assert.Nil(t, err)
+	saved, _ := m.GetAccountByName(tenantAlice, account.Username)
+
+	_, err = m.GetAccount(tenantBob, saved.ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	other := *saved
+	other.TenantID = tenantBob
+	other.Type = entity.AccountCoOrdinator
+	assert.Equal(t, glad.ErrNotFound, m.UpdateAccount(testActor, &other))
+
+	err = m.DeleteAccount(testActor, tenantBob, saved.ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	found, err := m.GetAccount(tenantAlice, saved.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, account.Type, found.Type)
+}
+
+func TestArchiveByExtID(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo, audit.Discard)
+	account := newFixtureAccount()
+	account.TenantID = tenantAlice
+	assert.Nil(t, repo.Create(account))
+	aID := account.ID
+
+	archivedID, err := m.ArchiveAccountByExtID(testActor, tenantAlice, aliceExtID)
+	assert.Nil(t, err)
+	assert.Equal(t, aID, archivedID)
+	saved, _ := m.GetAccount(tenantAlice, aID)
+	assert.Equal(t, entity.AccountDisabled, saved.Status)
+
+	_, err = m.RestoreAccountByExtID(testActor, tenantAlice, aliceExtID)
+	assert.Nil(t, err)
+	saved, _ = m.GetAccount(tenantAlice, aID)
+	assert.Equal(t, entity.AccountActive, saved.Status)
+
+	_, err = m.RestoreAccountByExtID(testActor, tenantBob, aliceExtID)
+	assert.Equal(t, glad.ErrNotFound, err)
+	_, err = m.ArchiveAccountByExtID(testActor, tenantAlice, alice2ExtID)
+	assert.Equal(t, glad.ErrNotFound, err)
+}
+
+func TestCalendarToken(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo, audit.Discard)
+	account := newFixtureAccount()
+	account.TenantID = tenantAlice
+	assert.Nil(t, repo.Create(account))
+
+	token, err := m.ResetCalendarToken(testActor, tenantAlice, account.ID)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, token)
+	subscribed, err := m.GetAccountByCalendarToken(token)
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, subscribed.ID)
+	assert.Equal(t, tenantAlice, subscribed.TenantID)
+
+	// a new token replaces the previous one
+	renewed, err := m.ResetCalendarToken(testActor, tenantAlice, account.ID)
+	assert.Nil(t, err)
+	assert.NotEqual(t, token, renewed)
+	_, err = m.GetAccountByCalendarToken(token)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	// disabled accounts are not served
+	_, err = m.ArchiveAccountByExtID(testActor, tenantAlice, aliceExtID)
+	assert.Nil(t, err)
+	_, err = m.GetAccountByCalendarToken(renewed)
+	assert.Equal(t, glad.ErrNotFound, err)
+	_, err = m.RestoreAccountByExtID(testActor, tenantAlice, aliceExtID)
+	assert.Nil(t, err)
+
+	assert.Nil(t, m.RevokeCalendarToken(testActor, tenantAlice, account.ID))
+	_, err = m.GetAccountByCalendarToken(renewed)
+	assert.Equal(t, glad.ErrNotFound, err)
+	_, err = m.GetAccountByCalendarToken("")
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	_, err = m.ResetCalendarToken(testActor, tenantBob, account.ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package attendance
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// inmemCourse course details the attendance is recorded against
+type inmemCourse struct {
+	tenantID     id.ID
+	productID    id.ID
+	timezone     string
+	timings      []*entity.CourseTiming
+	participants []*entity.Participant
+}
+
+// inmemAttemptKey check-in attempts of an account in a session
+type inmemAttemptKey struct {
+	timingID  id.ID
+	accountID id.ID
+}
+
+// inmemAttempts check-in attempts with the code created at codeCreatedAt
+type inmemAttempts struct {
+	codeCreatedAt time.Time
+	attempts      int
+}
+
+// inmem in memory repo
+type inmem struct {
+	m           map[id.ID]*entity.Attendance
+	codes       map[id.ID]*entity.AttendanceCode
+	attempts    map[inmemAttemptKey]*inmemAttempts
+	courses     map[id.ID]*inmemCourse
+	minPercents map[id.ID]int
+	mut         *sync.RWMutex
+}
+
+// newInmem create new repository
+func newInmem() *inmem {
+	return &inmem{
+		m:           map[id.ID]*entity.Attendance{},
+		codes:       map[id.ID]*entity.AttendanceCode{},
+		attempts:    map[inmemAttemptKey]*inmemAttempts{},
+		courses:     map[id.ID]*inmemCourse{},
+		minPercents: map[id.ID]int{},
+		mut:         &sync.RWMutex{},
+	}
+}
+
+// addCourse adds a course of the product; attendance can only be recorded for
+// known courses
+func (r *inmem) addCourse(tenantID id.ID, courseID id.ID, productID id.ID, timezone string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.courses[courseID] = &inmemCourse{
+		tenantID:  tenantID,
+		productID: productID,
+		timezone:  timezone,
+	}
+}
+
+// addTiming adds a session to a known course
+func (r *inmem) addTiming(ct *entity.CourseTiming) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	c := r.courses[ct.CourseID]
+	c.timings = append(c.timings, ct)
+}
+
+// addParticipant adds a participant to a known course
+func (r *inmem) addParticipant(p *entity.Participant) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	c := r.courses[p.CourseID]
+	c.participants = append(c.participants, p)
+}
+
+// course gets the tenant's course
+func (r *inmem) course(tenantID id.ID, courseID id.ID) (*inmemCourse, error) {
+	c, ok := r.courses[courseID]
+	if !ok || c.tenantID != tenantID {
+		return nil, glad.ErrNotFound
+	}
+	return c, nil
+}
+
+// GetCourseTimezone gets the timezone of the course
+func (r *inmem) GetCourseTimezone(tenantID id.ID, courseID id.ID) (string, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	c, err := r.course(tenantID, courseID)
+	if err != nil {
+		return "", err
+	}
+	return c.timezone, nil
+}
+
+// ListTimings lists the sessions of the course
+func (r *inmem) ListTimings(tenantID id.ID, courseID id.ID) ([]*entity.CourseTiming, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	c, err := r.course(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	return c.timings, nil
+}
+
+// ListParticipants lists the participants of the course
+func (r *inmem) ListParticipants(tenantID id.ID, courseID id.ID) ([]*entity.Participant, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	c, err := r.course(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	return c.participants, nil
+}
+
+// GetCourseMinAttendance gets the attendance required by the product of the course
+func (r *inmem) GetCourseMinAttendance(tenantID id.ID, courseID id.ID) (int, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	c, err := r.course(tenantID, courseID)
+	if err != nil {
+		return 0, err
+	}
+	return r.minPercents[c.productID], nil
+}
+
+// list lists the tenant's attendance matching the filter, by check-in
+func (r *inmem) list(tenantID id.ID, match func(*entity.Attendance) bool) []*entity.Attendance {
+	var d []*entity.Attendance
+	for _, a := range r.m {
+		c, ok := r.courses[a.CourseID]
+		if ok && c.tenantID == tenantID && match(a) {
+			d = append(d, a)
+		}
+	}
+	sort.Slice(d, func(i, j int) bool {
+		return d[i].CheckedInAt.Before(d[j].CheckedInAt)
+	})
+	return d
+}
+
+// ListByCourse lists the attendance of the course
+func (r *inmem) ListByCourse(tenantID id.ID, courseID id.ID) ([]*entity.Attendance, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	return r.list(tenantID, func(a *entity.Attendance) bool {
+		return a.CourseID == courseID
+	}), nil
+}
+
+// ListByTiming lists the attendance of the session
+func (r *inmem) ListByTiming(tenantID id.ID, timingID id.ID) ([]*entity.Attendance, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	return r.list(tenantID, func(a *entity.Attendance) bool {
+		return a.TimingID == timingID
+	}), nil
+}
+
+// GetCode gets the check-in code of the session
+func (r *inmem) GetCode(tenantID id.ID, timingID id.ID) (*entity.AttendanceCode, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	c, ok := r.codes[timingID]
+	if !ok {
+		return nil, glad.ErrNotFound
+	}
+	if _, err := r.course(tenantID, c.CourseID); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetMinAttendance gets the attendance required for completion of the product
+func (r *inmem) GetMinAttendance(tenantID id.ID, productID id.ID) (int, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	return r.minPercents[productID], nil
+}
+
+// Upsert sets the attendance of the participant in the session
+func (r *inmem) Upsert(e *entity.Attendance) (id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	for _, a := range r.m {
+		if a.TimingID == e.TimingID && a.ParticipantID == e.ParticipantID {
+			e.ID, e.CreatedAt = a.ID, a.CreatedAt
+		}
+	}
+	r.m[e.ID] = e
+	return e.ID, nil
+}
+
+// SetCode replaces the check-in code of the session
+func (r *inmem) SetCode(e *entity.AttendanceCode) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.codes[e.TimingID] = e
+	return nil
+}
+
+// AddCheckInAttempt counts a check-in attempt of the account with the code
+func (r *inmem) AddCheckInAttempt(accountID id.ID, c *entity.AttendanceCode) (int, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	key := inmemAttemptKey{timingID: c.TimingID, accountID: accountID}
+	a, ok := r.attempts[key]
+	if !ok || !a.codeCreatedAt.Equal(c.CreatedAt) {
+		a = &inmemAttempts{codeCreatedAt: c.CreatedAt}
+		r.attempts[key] = a
+	}
+	a.attempts++
+	return a.attempts, nil
+}
+
+// SetMinAttendance sets the attendance required for completion of the product
+func (r *inmem) SetMinAttendance(tenantID id.ID, productID id.ID, percent int) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.minPercents[productID] = percent
+	return nil
+}
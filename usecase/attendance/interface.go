@@ -0,0 +1,97 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package attendance
+
+import (
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// CourseReader course data the attendance is recorded against
+type CourseReader interface {
+	// GetCourseTimezone gets the timezone of the tenant's course; returns
+	// glad.ErrNotFound when there is no such course
+	GetCourseTimezone(tenantID id.ID, courseID id.ID) (string, error)
+	// ListTimings lists the sessions of the course by date
+	ListTimings(tenantID id.ID, courseID id.ID) ([]*entity.CourseTiming, error)
+	// ListParticipants lists the participants of the course
+	ListParticipants(tenantID id.ID, courseID id.ID) ([]*entity.Participant, error)
+	// GetCourseMinAttendance gets the attendance required for completion by
+	// the product of the course; 0 when not required
+	GetCourseMinAttendance(tenantID id.ID, courseID id.ID) (int, error)
+}
+
+// Reader attendance reader
+type Reader interface {
+	ListByCourse(tenantID id.ID, courseID id.ID) ([]*entity.Attendance, error)
+	ListByTiming(tenantID id.ID, timingID id.ID) ([]*entity.Attendance, error)
+	// GetCode gets the latest check-in code of the session
+	GetCode(tenantID id.ID, timingID id.ID) (*entity.AttendanceCode, error)
+	// GetMinAttendance gets the attendance required for completion of the
+	// product; 0 when not required
+	GetMinAttendance(tenantID id.ID, productID id.ID) (int, error)
+}
+
+// Writer attendance writer
+type Writer interface {
+	// Upsert sets the attendance of the participant in the session
+	Upsert(e *entity.Attendance) (id.ID, error)
+	// SetCode replaces the check-in code of the session
+	SetCode(e *entity.AttendanceCode) error
+	// AddCheckInAttempt counts a check-in attempt of the account with the
+	// code and returns the attempts made with it, this one included
+	AddCheckInAttempt(accountID id.ID, c *entity.AttendanceCode) (int, error)
+	// SetMinAttendance sets the attendance required for completion of the
+	// product; returns glad.ErrNotFound unless the product belongs to the tenant
+	SetMinAttendance(tenantID id.ID, productID id.ID, percent int) error
+}
+
+// Repository interface
+type Repository interface {
+	CourseReader
+	Reader
+	Writer
+}
+
+// UseCase interface
+type UseCase interface {
+	// ListSessionAttendance lists the attendance marked for the session
+	ListSessionAttendance(tenantID id.ID, courseID id.ID, timingID id.ID) ([]*entity.Attendance, error)
+	// MarkAttendance marks the attendance of the participants in the session
+	MarkAttendance(recordedBy id.ID,
+		tenantID id.ID,
+		courseID id.ID,
+		timingID id.ID,
+		records []*entity.Attendance,
+	) error
+	// CreateCheckInCode creates a short-lived code participants check in to
+	// the session with; the previous code of the session stops working
+	CreateCheckInCode(createdBy id.ID,
+		tenantID id.ID,
+		courseID id.ID,
+		timingID id.ID,
+	) (*entity.AttendanceCode, error)
+	// CheckIn marks the account present (or late) in the session using its
+	// check-in code
+	CheckIn(tenantID id.ID,
+		courseID id.ID,
+		timingID id.ID,
+		accountID id.ID,
+		code string,
+		now time.Time,
+	) (*entity.Attendance, error)
+	GetCourseAttendance(tenantID id.ID, courseID id.ID) (*entity.CourseAttendance, error)
+	// GetParticipantAttendance gets the attendance of the account in the course
+	GetParticipantAttendance(tenantID id.ID,
+		courseID id.ID,
+		accountID id.ID,
+	) (*entity.ParticipantAttendance, error)
+	GetMinAttendance(tenantID id.ID, productID id.ID) (int, error)
+	SetMinAttendance(tenantID id.ID, productID id.ID, percent int) error
+}
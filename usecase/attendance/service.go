@@ -0,0 +1,328 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package attendance
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+const (
+	// DefaultCodeTTL time a check-in code can be used for
+	DefaultCodeTTL = 15 * time.Minute
+	// LateAfter check-ins this long after the session started are late
+	LateAfter = 10 * time.Minute
+	// MaxCheckInAttempts check-in attempts of an account with a code
+	MaxCheckInAttempts = 5
+)
+
+// Service attendance usecase
+type Service struct {
+	repo    Repository
+	codeTTL time.Duration
+}
+
+// NewService create new service
+// Note: The default code TTL is used when codeTTL is not positive.
+func NewService(r Repository, codeTTL time.Duration) *Service {
+	if codeTTL <= 0 {
+		codeTTL = DefaultCodeTTL
+	}
+	return &Service{
+		repo:    r,
+		codeTTL: codeTTL,
+	}
+}
+
+// timing gets the session of the course
+func (s *Service) timing(tenantID id.ID, courseID id.ID, timingID id.ID) (*entity.CourseTiming, error) {
+	timings, err := s.repo.ListTimings(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ct := range timings {
+		if ct.ID == timingID {
+			return ct, nil
+		}
+	}
+	return nil, glad.ErrNotFound
+}
+
+// ListSessionAttendance lists the attendance marked for the session
+func (s *Service) ListSessionAttendance(tenantID id.ID,
+	courseID id.ID,
+	timingID id.ID,
+) ([]*entity.Attendance, error) {
+	_, err := s.timing(tenantID, courseID, timingID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.repo.ListByTiming(tenantID, timingID)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, glad.ErrNotFound
+	}
+	return records, nil
+}
+
+// MarkAttendance marks the attendance of the participants in the session. Only
+// the participant, status and check-in time of the records are used. The
+// records are checked before any of them is saved.
+func (s *Service) MarkAttendance(recordedBy id.ID,
+	tenantID id.ID,
+	courseID id.ID,
+	timingID id.ID,
+	records []*entity.Attendance,
+) error {
+	_, err := s.timing(tenantID, courseID, timingID)
+	if err != nil {
+		return err
+	}
+
+	participants, err := s.repo.ListParticipants(tenantID, courseID)
+	if err != nil {
+		return err
+	}
+	known := make(map[id.ID]bool, len(participants))
+	for _, p := range participants {
+		known[p.ID] = true
+	}
+
+	marked := make([]*entity.Attendance, 0, len(records))
+	for _, r := range records {
+		if !known[r.ParticipantID] {
+			l.Log.Warnf("Not a participant course id=%v, participant id=%v", courseID, r.ParticipantID)
+			return glad.ErrInvalidEntity
+		}
+		a, err := entity.NewAttendance(courseID, timingID, r.ParticipantID, r.Status,
+			r.CheckedInAt, recordedBy)
+		if err != nil {
+			return err
+		}
+		marked = append(marked, a)
+	}
+
+	for _, a := range marked {
+		_, err = s.repo.Upsert(a)
+		if err != nil {
+			l.Log.Warnf("Unable to mark attendance timing id=%v, participant id=%v, err=%v",
+				timingID, a.ParticipantID, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateCheckInCode creates a short-lived check-in code for the session
+func (s *Service) CreateCheckInCode(createdBy id.ID,
+	tenantID id.ID,
+	courseID id.ID,
+	timingID id.ID,
+) (*entity.AttendanceCode, error) {
+	_, err := s.timing(tenantID, courseID, timingID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := entity.NewAttendanceCode(courseID, timingID, createdBy, s.codeTTL)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.SetCode(c)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CheckIn marks the account present in the session, or late when it checks in
+// LateAfter the session started. Checking in again keeps the first check-in.
+// An account has MaxCheckInAttempts with a code, so that the code cannot be
+// guessed; glad.ErrTooManyAttempts is returned until a new code is created.
+func (s *Service) CheckIn(tenantID id.ID,
+	courseID id.ID,
+	timingID id.ID,
+	accountID id.ID,
+	code string,
+	now time.Time,
+) (*entity.Attendance, error) {
+	ct, err := s.timing(tenantID, courseID, timingID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.repo.GetCode(tenantID, timingID)
+	if err != nil && err != glad.ErrNotFound {
+		return nil, err
+	}
+	if c == nil || c.IsExpired(now) {
+		return nil, glad.ErrInvalidCode
+	}
+
+	// Note: The attempt is counted before the code is compared, so that
+	// concurrent attempts cannot exceed the limit
+	attempts, err := s.repo.AddCheckInAttempt(accountID, c)
+	if err != nil {
+		return nil, err
+	}
+	if attempts > MaxCheckInAttempts {
+		l.Log.Warnf("Check-in attempts exhausted timing id=%v, account id=%v", timingID, accountID)
+		return nil, glad.ErrTooManyAttempts
+	}
+	if subtle.ConstantTimeCompare([]byte(c.Code), []byte(code)) != 1 {
+		return nil, glad.ErrInvalidCode
+	}
+
+	participants, err := s.repo.ListParticipants(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	var p *entity.Participant
+	for _, participant := range participants {
+		if participant.AccountID == accountID {
+			p = participant
+		}
+	}
+	if p == nil {
+		return nil, glad.ErrNotFound
+	}
+
+	records, err := s.repo.ListByTiming(tenantID, timingID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range records {
+		if a.ParticipantID == p.ID && a.Status.IsAttended() {
+			return a, nil
+		}
+	}
+
+	timezone, err := s.repo.GetCourseTimezone(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := entity.TimezoneLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+	start, _, err := ct.DateTime.Interval(loc)
+	if err != nil {
+		return nil, err
+	}
+	status := entity.AttendancePresent
+	if now.After(start.Add(LateAfter)) {
+		status = entity.AttendanceLate
+	}
+
+	a, err := entity.NewAttendance(courseID, timingID, p.ID, status, now, accountID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.repo.Upsert(a)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// GetCourseAttendance summarizes the attendance of the course by session and
+// by participant
+func (s *Service) GetCourseAttendance(tenantID id.ID, courseID id.ID) (*entity.CourseAttendance, error) {
+	timings, err := s.repo.ListTimings(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.repo.ListParticipants(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.repo.ListByCourse(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	minPercent, err := s.repo.GetCourseMinAttendance(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := &entity.CourseAttendance{
+		CourseID:   courseID,
+		MinPercent: minPercent,
+	}
+
+	byTiming := map[id.ID][]*entity.Attendance{}
+	byParticipant := map[id.ID][]*entity.Attendance{}
+	for _, a := range records {
+		byTiming[a.TimingID] = append(byTiming[a.TimingID], a)
+		byParticipant[a.ParticipantID] = append(byParticipant[a.ParticipantID], a)
+	}
+
+	for _, ct := range timings {
+		sa := &entity.SessionAttendance{
+			TimingID: ct.ID,
+			DateTime: ct.DateTime,
+			Unmarked: len(participants),
+		}
+		for _, a := range byTiming[ct.ID] {
+			switch a.Status {
+			case entity.AttendancePresent:
+				sa.Present++
+			case entity.AttendanceLate:
+				sa.Late++
+			case entity.AttendanceAbsent:
+				sa.Absent++
+			}
+			sa.Unmarked--
+		}
+		ca.Sessions = append(ca.Sessions, sa)
+	}
+
+	for _, p := range participants {
+		ca.Participants = append(ca.Participants,
+			entity.NewParticipantAttendance(p, len(timings), byParticipant[p.ID], minPercent))
+	}
+	return ca, nil
+}
+
+// GetParticipantAttendance gets the attendance of the account in the course
+func (s *Service) GetParticipantAttendance(tenantID id.ID,
+	courseID id.ID,
+	accountID id.ID,
+) (*entity.ParticipantAttendance, error) {
+	ca, err := s.GetCourseAttendance(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pa := range ca.Participants {
+		if pa.AccountID == accountID {
+			return pa, nil
+		}
+	}
+	return nil, glad.ErrNotFound
+}
+
+// GetMinAttendance gets the attendance required for completion of the product
+func (s *Service) GetMinAttendance(tenantID id.ID, productID id.ID) (int, error) {
+	return s.repo.GetMinAttendance(tenantID, productID)
+}
+
+// SetMinAttendance sets the attendance (percent) required for completion of
+// the product; 0 removes the requirement
+func (s *Service) SetMinAttendance(tenantID id.ID, productID id.ID, percent int) error {
+	if percent < 0 || percent > 100 {
+		return glad.ErrInvalidValue
+	}
+	return s.repo.SetMinAttendance(tenantID, productID, percent)
+}
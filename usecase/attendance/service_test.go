@@ -0,0 +1,274 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package attendance
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice    id.ID = 13790492210917015554
+	tenantBob      id.ID = 13790492210917015555
+	aliceCourseID  id.ID = 13790493495087071234
+	aliceProductID id.ID = 13790493495087075501
+
+	aliceTeacherID  id.ID = 100000010
+	aliceAccount1ID id.ID = 100000001
+	aliceAccount2ID id.ID = 100000002
+	aliceAccount3ID id.ID = 100000003
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+type fixture struct {
+	timings      []*entity.CourseTiming
+	participants []*entity.Participant
+}
+
+// newFixtureService creates a course with two sessions (10:00-12:00 in Los
+// Angeles) and two participants
+func newFixtureService(t *testing.T) (*Service, *inmem, *fixture) {
+	repo := newInmem()
+	repo.addCourse(tenantAlice, aliceCourseID, aliceProductID, "America/Los_Angeles")
+
+	f := &fixture{}
+	for _, date := range []string{"2024-05-14", "2024-05-15"} {
+		ct, err := entity.NewCourseTiming(aliceCourseID, nil, entity.CourseDateTime{
+			Date:      date,
+			StartTime: "10:00:00",
+			EndTime:   "12:00:00",
+		})
+		assert.Nil(t, err)
+		repo.addTiming(ct)
+		f.timings = append(f.timings, ct)
+	}
+	for _, accountID := range []id.ID{aliceAccount1ID, aliceAccount2ID} {
+		p, err := entity.NewParticipant(aliceCourseID, accountID, "")
+		assert.Nil(t, err)
+		repo.addParticipant(p)
+		f.participants = append(f.participants, p)
+	}
+	return NewService(repo, 0), repo, f
+}
+
+func Test_MarkAttendance(t *testing.T) {
+	m, _, f := newFixtureService(t)
+	timingID := f.timings[0].ID
+
+	_, err := m.ListSessionAttendance(tenantAlice, aliceCourseID, timingID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	err = m.MarkAttendance(aliceTeacherID, tenantAlice, aliceCourseID, timingID, []*entity.Attendance{
+		{ParticipantID: f.participants[0].ID, Status: entity.AttendancePresent},
+		{ParticipantID: f.participants[1].ID, Status: entity.AttendanceAbsent},
+	})
+	assert.Nil(t, err)
+
+	records, err := m.ListSessionAttendance(tenantAlice, aliceCourseID, timingID)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(records))
+	for _, a := range records {
+		assert.Equal(t, aliceTeacherID, a.RecordedBy)
+		assert.Equal(t, a.Status.IsAttended(), !a.CheckedInAt.IsZero())
+	}
+
+	t.Run("correction", func(t *testing.T) {
+		err := m.MarkAttendance(aliceTeacherID, tenantAlice, aliceCourseID, timingID, []*entity.Attendance{
+			{ParticipantID: f.participants[1].ID, Status: entity.AttendanceLate},
+		})
+		assert.Nil(t, err)
+		records, _ := m.ListSessionAttendance(tenantAlice, aliceCourseID, timingID)
+		assert.Equal(t, 2, len(records))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := m.MarkAttendance(aliceTeacherID, tenantAlice, aliceCourseID, timingID, []*entity.Attendance{
+			{ParticipantID: f.participants[0].ID, Status: "excused"},
+		})
+		assert.Equal(t, glad.ErrInvalidEntity, err)
+
+		// not a participant of the course
+		err = m.MarkAttendance(aliceTeacherID, tenantAlice, aliceCourseID, timingID, []*entity.Attendance{
+			{ParticipantID: id.New(), Status: entity.AttendancePresent},
+		})
+		assert.Equal(t, glad.ErrInvalidEntity, err)
+
+		// not a session of the course
+		err = m.MarkAttendance(aliceTeacherID, tenantAlice, aliceCourseID, id.New(), nil)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		err := m.MarkAttendance(aliceTeacherID, tenantBob, aliceCourseID, timingID, nil)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+}
+
+func Test_CheckIn(t *testing.T) {
+	m, _, f := newFixtureService(t)
+	timingID := f.timings[0].ID
+	// session starts at 17:00 UTC
+	start := time.Date(2024, 5, 14, 17, 0, 0, 0, time.UTC)
+
+	c, err := m.CreateCheckInCode(aliceTeacherID, tenantAlice, aliceCourseID, timingID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.AttendanceCodeDigits, len(c.Code))
+	assert.Equal(t, DefaultCodeTTL, c.ExpiresAt.Sub(c.CreatedAt))
+
+	t.Run("wrong code", func(t *testing.T) {
+		_, err := m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount1ID, "x"+c.Code, start)
+		assert.Equal(t, glad.ErrInvalidCode, err)
+	})
+
+	t.Run("present", func(t *testing.T) {
+		a, err := m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount1ID, c.Code, start.Add(5*time.Minute))
+		assert.Nil(t, err)
+		assert.Equal(t, entity.AttendancePresent, a.Status)
+		assert.Equal(t, aliceAccount1ID, a.RecordedBy)
+
+		// checking in again keeps the first check-in
+		again, err := m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount1ID, c.Code, start.Add(time.Hour))
+		assert.Nil(t, err)
+		assert.Equal(t, a.CheckedInAt, again.CheckedInAt)
+		assert.Equal(t, entity.AttendancePresent, again.Status)
+	})
+
+	t.Run("late", func(t *testing.T) {
+		a, err := m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount2ID, c.Code, start.Add(LateAfter+time.Minute))
+		assert.Nil(t, err)
+		assert.Equal(t, entity.AttendanceLate, a.Status)
+	})
+
+	t.Run("not a participant", func(t *testing.T) {
+		_, err := m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount3ID, c.Code, start)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, err := m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount1ID, c.Code, c.ExpiresAt)
+		assert.Equal(t, glad.ErrInvalidCode, err)
+	})
+
+	t.Run("replaced", func(t *testing.T) {
+		next, err := m.CreateCheckInCode(aliceTeacherID, tenantAlice, aliceCourseID, timingID)
+		assert.Nil(t, err)
+		if next.Code != c.Code {
+			_, err = m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount1ID, c.Code, start)
+			assert.Equal(t, glad.ErrInvalidCode, err)
+		}
+	})
+
+	t.Run("too many attempts", func(t *testing.T) {
+		next, err := m.CreateCheckInCode(aliceTeacherID, tenantAlice, aliceCourseID, timingID)
+		assert.Nil(t, err)
+		for i := 0; i < MaxCheckInAttempts; i++ {
+			_, err = m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount2ID, "x"+next.Code, start)
+			assert.Equal(t, glad.ErrInvalidCode, err)
+		}
+		_, err = m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount2ID, next.Code, start)
+		assert.Equal(t, glad.ErrTooManyAttempts, err)
+
+		// other accounts keep their attempts
+		a, err := m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount1ID, next.Code, start)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.AttendancePresent, a.Status)
+
+		// the attempts restart with a new code
+		next, err = m.CreateCheckInCode(aliceTeacherID, tenantAlice, aliceCourseID, timingID)
+		assert.Nil(t, err)
+		_, err = m.CheckIn(tenantAlice, aliceCourseID, timingID, aliceAccount2ID, next.Code, start)
+		assert.Nil(t, err)
+	})
+
+	t.Run("no code", func(t *testing.T) {
+		_, err := m.CheckIn(tenantAlice, aliceCourseID, f.timings[1].ID, aliceAccount1ID, c.Code, start)
+		assert.Equal(t, glad.ErrInvalidCode, err)
+	})
+}
+
+func Test_CourseAttendance(t *testing.T) {
+	m, _, f := newFixtureService(t)
+	p1, p2 := f.participants[0], f.participants[1]
+
+	err := m.MarkAttendance(aliceTeacherID, tenantAlice, aliceCourseID, f.timings[0].ID, []*entity.Attendance{
+		{ParticipantID: p1.ID, Status: entity.AttendancePresent},
+		{ParticipantID: p2.ID, Status: entity.AttendanceAbsent},
+	})
+	assert.Nil(t, err)
+	err = m.MarkAttendance(aliceTeacherID, tenantAlice, aliceCourseID, f.timings[1].ID, []*entity.Attendance{
+		{ParticipantID: p1.ID, Status: entity.AttendanceLate},
+	})
+	assert.Nil(t, err)
+
+	ca, err := m.GetCourseAttendance(tenantAlice, aliceCourseID)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, ca.MinPercent)
+	assert.Equal(t, 2, len(ca.Sessions))
+	assert.Equal(t, 1, ca.Sessions[0].Present)
+	assert.Equal(t, 1, ca.Sessions[0].Absent)
+	assert.Equal(t, 0, ca.Sessions[0].Unmarked)
+	assert.Equal(t, 1, ca.Sessions[1].Late)
+	assert.Equal(t, 1, ca.Sessions[1].Unmarked)
+
+	pa, err := m.GetParticipantAttendance(tenantAlice, aliceCourseID, aliceAccount1ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pa.Attended)
+	assert.Equal(t, 1, pa.Late)
+	assert.Equal(t, float64(100), pa.Percent)
+	assert.True(t, pa.IsEligible)
+
+	pa, err = m.GetParticipantAttendance(tenantAlice, aliceCourseID, aliceAccount2ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, pa.Attended)
+	assert.Equal(t, 1, pa.Absent)
+	// no requirement set
+	assert.True(t, pa.IsEligible)
+
+	t.Run("min attendance", func(t *testing.T) {
+		assert.Equal(t, glad.ErrInvalidValue, m.SetMinAttendance(tenantAlice, aliceProductID, 101))
+		assert.Nil(t, m.SetMinAttendance(tenantAlice, aliceProductID, 50))
+
+		percent, err := m.GetMinAttendance(tenantAlice, aliceProductID)
+		assert.Nil(t, err)
+		assert.Equal(t, 50, percent)
+
+		ca, err := m.GetCourseAttendance(tenantAlice, aliceCourseID)
+		assert.Nil(t, err)
+		assert.Equal(t, 50, ca.MinPercent)
+		for _, pa := range ca.Participants {
+			assert.Equal(t, pa.ParticipantID == p1.ID, pa.IsEligible)
+		}
+	})
+
+	t.Run("not a participant", func(t *testing.T) {
+		_, err := m.GetParticipantAttendance(tenantAlice, aliceCourseID, aliceAccount3ID)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		_, err := m.GetCourseAttendance(tenantBob, aliceCourseID)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package audit
+
+import (
+	"sync"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// inmem in memory repo
+type inmem struct {
+	logs    []*entity.AuditLog
+	changes []*entity.AuditLog
+	mut     *sync.RWMutex
+}
+
+// newInmem create new repository
+func newInmem() *inmem {
+	return &inmem{
+		mut: &sync.RWMutex{},
+	}
+}
+
+// Create an audit log
+func (r *inmem) Create(e *entity.AuditLog) (id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.logs = append(r.logs, e)
+	return e.ID, nil
+}
+
+// List audit logs, newest first
+func (r *inmem) List(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	entityID id.ID,
+	page, limit int,
+) (int, []*entity.AuditLog, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	var logs []*entity.AuditLog
+	for i := len(r.logs) - 1; i >= 0; i-- {
+		e := r.logs[i]
+		if e.TenantID == tenantID && e.EntityType == entityType &&
+			(entityID == id.IDInvalid || e.EntityID == entityID) {
+			logs = append(logs, e)
+		}
+	}
+
+	count := len(logs)
+	if page > 0 && limit > 0 {
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(logs) {
+			return count, []*entity.AuditLog{}, nil
+		}
+		if end > len(logs) {
+			end = len(logs)
+		}
+		return count, logs[start:end], nil
+	}
+
+	return count, logs, nil
+}
+
+// QueueChange queues a change in the outbox
+func (r *inmem) QueueChange(e *entity.AuditLog) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	e.Seq = int64(len(r.changes) + 1)
+	r.changes = append(r.changes, e)
+	return nil
+}
+
+// ListChanges lists the changes in the outbox after the seq, oldest first
+func (r *inmem) ListChanges(tenantID id.ID, after int64, limit int) ([]*entity.AuditLog, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	var logs []*entity.AuditLog
+	for _, e := range r.changes {
+		if e.TenantID != tenantID || e.Seq <= after {
+			continue
+		}
+		logs = append(logs, e)
+		if limit > 0 && len(logs) == limit {
+			break
+		}
+	}
+	return logs, nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package audit
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// Reader audit log reader
+type Reader interface {
+	// List lists the audit logs of an entity type, newest first. All the
+	// entities of the type are listed when entityID is IDInvalid.
+	List(tenantID id.ID,
+		entityType entity.AuditEntityType,
+		entityID id.ID,
+		page, limit int,
+	) (int, []*entity.AuditLog, error)
+	// ListChanges lists the changes in the outbox after the seq, oldest first
+	ListChanges(tenantID id.ID, after int64, limit int) ([]*entity.AuditLog, error)
+}
+
+// Writer audit log writer
+type Writer interface {
+	Create(e *entity.AuditLog) (id.ID, error)
+}
+
+// Outbox queues the changes made in GLAD that are exported to Salesforce.
+// Unlike the audit log, the change is queued in the transaction of the change.
+type Outbox interface {
+	QueueChange(e *entity.AuditLog) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// Recorder records the changes made by the usecase services. Recording is
+// best effort; failures are logged and do not fail the change itself.
+type Recorder interface {
+	Record(actor entity.AuditActor,
+		tenantID id.ID,
+		entityType entity.AuditEntityType,
+		entityID id.ID,
+		op entity.AuditOperation,
+		before interface{},
+		after interface{},
+	)
+}
+
+// UseCase interface
+type UseCase interface {
+	Recorder
+	ListAuditLogs(tenantID id.ID,
+		entityType entity.AuditEntityType,
+		entityID id.ID,
+		page, limit int,
+	) (int, []*entity.AuditLog, error)
+	ListChanges(tenantID id.ID, after int64, limit int) ([]*entity.AuditLog, error)
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package audit
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// Service audit usecase
+type Service struct {
+	repo Repository
+}
+
+// NewService create new service
+func NewService(r Repository) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// Record records a change made to an entity
+func (s *Service) Record(actor entity.AuditActor,
+	tenantID id.ID,
+	entityType entity.AuditEntityType,
+	entityID id.ID,
+	op entity.AuditOperation,
+	before interface{},
+	after interface{},
+) {
+	e, err := entity.NewAuditLog(actor, tenantID, entityType, entityID, op, before, after)
+	if err == nil {
+		_, err = s.repo.Create(e)
+	}
+	if err != nil {
+		l.Log.Errorf("Unable to record audit log entity=%v, id=%v, op=%v, err=%v",
+			entityType, entityID, op, err)
+	}
+}
+
+// ListAuditLogs lists the audit logs of an entity, newest first
+func (s *Service) ListAuditLogs(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	entityID id.ID,
+	page, limit int,
+) (int, []*entity.AuditLog, error) {
+	switch entityType {
+	case entity.AuditCourse, entity.AuditCenter, entity.AuditProduct,
+		entity.AuditAccount, entity.AuditTenant, entity.AuditTeacherEligibility:
+	default:
+		return 0, nil, glad.ErrInvalidValue
+	}
+
+	count, logs, err := s.repo.List(tenantID, entityType, entityID, page, limit)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(logs) == 0 {
+		return count, nil, glad.ErrNotFound
+	}
+	return count, logs, nil
+}
+
+// ListChanges lists the changes made in GLAD after the seq, oldest first. It
+// is the change feed used to push the changes to Salesforce.
+func (s *Service) ListChanges(tenantID id.ID, after int64, limit int) ([]*entity.AuditLog, error) {
+	logs, err := s.repo.ListChanges(tenantID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) == 0 {
+		return nil, glad.ErrNotFound
+	}
+	return logs, nil
+}
+
+// QueueChange queues the change made to an exported entity in o, the outbox
+// bound to the transaction of the change. Changes imported from Salesforce are
+// not queued, so that they are not exported back.
+func QueueChange(o Outbox,
+	actor entity.AuditActor,
+	tenantID id.ID,
+	entityType entity.AuditEntityType,
+	entityID id.ID,
+	op entity.AuditOperation,
+	after interface{},
+) error {
+	if actor.Source == entity.AuditSourceSalesforce {
+		return nil
+	}
+
+	e, err := entity.NewAuditLog(actor, tenantID, entityType, entityID, op, nil, after)
+	if err != nil {
+		return err
+	}
+	return o.QueueChange(e)
+}
+
+// discard drops all the changes
+type discard struct{}
+
+func (discard) Record(entity.AuditActor, id.ID, entity.AuditEntityType, id.ID,
+	entity.AuditOperation, interface{}, interface{}) {
+}
+
+// Discard is a Recorder that records nothing
+var Discard Recorder = discard{}
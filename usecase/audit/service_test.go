@@ -0,0 +1,161 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice  id.ID = 13790492210917015554
+	tenantBob    id.ID = 13790492210917015555
+	accountAlice id.ID = 13790492210917015556
+)
+
+func newFixtureTenant() *entity.Tenant {
+	return &entity.Tenant{
+		ID:        tenantAlice,
+		Name:      "alice@wonder.land",
+		Country:   "US",
+		AuthToken: "token123",
+		CreatedAt: time.Now(),
+	}
+}
+
+func Test_Record(t *testing.T) {
+	m := NewService(newInmem())
+	actor := entity.AuditActor{AccountID: accountAlice, Source: entity.AuditSourceAPI}
+
+	before := newFixtureTenant()
+	after := newFixtureTenant()
+	after.Country = "IN"
+	after.AuthToken = "token456"
+	after.UpdatedAt = time.Now()
+
+	m.Record(actor, tenantAlice, entity.AuditTenant, tenantAlice, entity.AuditCreate, nil, before)
+	m.Record(actor, tenantAlice, entity.AuditTenant, tenantAlice, entity.AuditUpdate, before, after)
+	m.Record(entity.AuditActor{Source: entity.AuditSourceSalesforce},
+		tenantAlice, entity.AuditTenant, tenantAlice, entity.AuditDelete, after, nil)
+
+	count, logs, err := m.ListAuditLogs(tenantAlice, entity.AuditTenant, tenantAlice, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 3, len(logs))
+
+	t.Run("newest first", func(t *testing.T) {
+		assert.Equal(t, entity.AuditDelete, logs[0].Operation)
+		assert.Equal(t, entity.AuditUpdate, logs[1].Operation)
+		assert.Equal(t, entity.AuditCreate, logs[2].Operation)
+	})
+
+	t.Run("actor and source", func(t *testing.T) {
+		assert.Equal(t, accountAlice, logs[1].ActorID)
+		assert.Equal(t, entity.AuditSourceAPI, logs[1].Source)
+		assert.Equal(t, id.ID(id.IDInvalid), logs[0].ActorID)
+		assert.Equal(t, entity.AuditSourceSalesforce, logs[0].Source)
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		update := logs[1]
+		assert.Equal(t, 1, len(update.Changes))
+		assert.Equal(t, "Country", update.Changes[0].Field)
+		assert.JSONEq(t, `"US"`, string(update.Changes[0].Before))
+		assert.JSONEq(t, `"IN"`, string(update.Changes[0].After))
+
+		assert.Nil(t, logs[2].Before)
+		assert.NotNil(t, logs[2].After)
+		assert.NotNil(t, logs[0].Before)
+		assert.Nil(t, logs[0].After)
+	})
+
+	t.Run("redacted", func(t *testing.T) {
+		var fields map[string]interface{}
+		assert.Nil(t, json.Unmarshal(logs[1].After, &fields))
+		assert.NotContains(t, fields, "AuthToken")
+		assert.Equal(t, "IN", fields["Country"])
+	})
+}
+
+func Test_ListAuditLogs(t *testing.T) {
+	m := NewService(newInmem())
+	actor := entity.AuditActor{Source: entity.AuditSourceAPI}
+	c1 := &entity.Center{ID: id.New(), TenantID: tenantAlice, Name: "one"}
+	c2 := &entity.Center{ID: id.New(), TenantID: tenantAlice, Name: "two"}
+
+	m.Record(actor, tenantAlice, entity.AuditCenter, c1.ID, entity.AuditCreate, nil, c1)
+	m.Record(actor, tenantAlice, entity.AuditCenter, c2.ID, entity.AuditCreate, nil, c2)
+	m.Record(actor, tenantAlice, entity.AuditCenter, c2.ID, entity.AuditDelete, c2, nil)
+
+	t.Run("by entity", func(t *testing.T) {
+		count, logs, err := m.ListAuditLogs(tenantAlice, entity.AuditCenter, c2.ID, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, c2.ID, logs[0].EntityID)
+	})
+
+	t.Run("by type with pagination", func(t *testing.T) {
+		count, logs, err := m.ListAuditLogs(tenantAlice, entity.AuditCenter, id.IDInvalid, 2, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, count)
+		assert.Equal(t, 1, len(logs))
+		assert.Equal(t, c1.ID, logs[0].EntityID)
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		_, _, err := m.ListAuditLogs(tenantBob, entity.AuditCenter, id.IDInvalid, 0, 0)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+
+	t.Run("invalid entity type", func(t *testing.T) {
+		_, _, err := m.ListAuditLogs(tenantAlice, "participant", id.IDInvalid, 0, 0)
+		assert.Equal(t, glad.ErrInvalidValue, err)
+	})
+}
+
+func Test_ListChanges(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo)
+	api := entity.AuditActor{Source: entity.AuditSourceAPI}
+	sf := entity.AuditActor{Source: entity.AuditSourceSalesforce}
+	c := &entity.Center{ID: id.New(), TenantID: tenantAlice, Name: "one"}
+
+	assert.Nil(t, QueueChange(repo, api, tenantAlice, entity.AuditCenter, c.ID, entity.AuditCreate, c))
+	assert.Nil(t, QueueChange(repo, sf, tenantAlice, entity.AuditCenter, c.ID, entity.AuditUpdate, c))
+	assert.Nil(t, QueueChange(repo, api, tenantAlice, entity.AuditCenter, c.ID, entity.AuditDelete, nil))
+	assert.Nil(t, QueueChange(repo, api, tenantBob, entity.AuditCenter, c.ID, entity.AuditCreate, c))
+
+	t.Run("oldest first, salesforce excluded", func(t *testing.T) {
+		logs, err := m.ListChanges(tenantAlice, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(logs))
+		assert.Equal(t, entity.AuditCreate, logs[0].Operation)
+		assert.Equal(t, entity.AuditDelete, logs[1].Operation)
+		assert.True(t, logs[0].Seq < logs[1].Seq)
+	})
+
+	t.Run("after and limit", func(t *testing.T) {
+		logs, err := m.ListChanges(tenantAlice, 0, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(logs))
+
+		logs, err = m.ListChanges(tenantAlice, logs[0].Seq, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(logs))
+		assert.Equal(t, entity.AuditDelete, logs[0].Operation)
+
+		_, err = m.ListChanges(tenantAlice, logs[0].Seq, 0)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+}
@@ -7,6 +7,7 @@
 package center
 
 import (
+	"sort"
 	"strings"
 	"sync"
 
@@ -17,8 +18,10 @@ import (
 
 // inmem in memory repo
 type inmem struct {
-	m   map[id.ID]*entity.Center
-	mut *sync.RWMutex
+	m map[id.ID]*entity.Center
+	// changes outbox of the export
+	changes []*entity.AuditLog
+	mut     *sync.RWMutex
 }
 
 // newInmem create new repository
@@ -40,14 +43,15 @@ func (r *inmem) Create(e *entity.Center) (id.ID, error) {
 }
 
 // Get a center
-func (r *inmem) Get(id id.ID) (*entity.Center, error) {
+func (r *inmem) Get(tenantID id.ID, centerID id.ID) (*entity.Center, error) {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
-	if r.m[id] == nil {
+	c := r.m[centerID]
+	if c == nil || c.TenantID != tenantID {
 		return nil, glad.ErrNotFound
 	}
-	return r.m[id], nil
+	return c, nil
 }
 
 // Update a center
@@ -55,8 +59,8 @@ func (r *inmem) Update(e *entity.Center) error {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
-	_, ok := r.m[e.ID]
-	if !ok {
+	c, ok := r.m[e.ID]
+	if !ok || c.TenantID != e.TenantID {
 		return glad.ErrNotFound
 	}
 
@@ -123,16 +127,56 @@ func (r *inmem) List(tenantID id.ID, page, limit int) ([]*entity.Center, error)
 	return centers, nil
 }
 
+// Nearby lists enabled centers within the query radius ordered by distance
+func (r *inmem) Nearby(tenantID id.ID,
+	q *entity.GeoQuery,
+	page, limit int,
+) ([]*entity.CenterNearby, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	box := q.BoundingBox()
+	var centers []*entity.CenterNearby
+	for _, j := range r.m {
+		if j.TenantID != tenantID || !j.IsEnabled || !box.Contains(j.GeoLocation) {
+			continue
+		}
+		distance := q.DistanceKm(j.GeoLocation)
+		if distance <= q.RadiusKm {
+			centers = append(centers, &entity.CenterNearby{Center: j, DistanceKm: distance})
+		}
+	}
+
+	sort.Slice(centers, func(i, j int) bool {
+		return centers[i].DistanceKm < centers[j].DistanceKm
+	})
+
+	if page > 0 && limit > 0 {
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(centers) {
+			return []*entity.CenterNearby{}, nil
+		}
+
+		if end > len(centers) {
+			end = len(centers)
+		}
+		return centers[start:end], nil
+	}
+	return centers, nil
+}
+
 // Delete a center
-func (r *inmem) Delete(id id.ID) error {
+func (r *inmem) Delete(tenantID id.ID, centerID id.ID) error {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
-	if r.m[id] == nil {
+	c := r.m[centerID]
+	if c == nil || c.TenantID != tenantID {
 		return glad.ErrNotFound
 	}
-	r.m[id] = nil
-	delete(r.m, id)
+	r.m[centerID] = nil
+	delete(r.m, centerID)
 	return nil
 }
 
@@ -161,6 +205,51 @@ func (r *inmem) Upsert(e *entity.Center) (id.ID, error) {
 	return e.ID, nil
 }
 
+// BulkUpsert upserts centers in memory
+func (r *inmem) BulkUpsert(tenantID id.ID, centers []*entity.Center) (map[string]id.ID, error) {
+	ids := make(map[string]id.ID, len(centers))
+	for _, c := range centers {
+		c.TenantID = tenantID
+		centerID, err := r.Upsert(c)
+		if err != nil {
+			return nil, err
+		}
+		ids[c.ExtID] = centerID
+	}
+	return ids, nil
+}
+
+// GetIDsByExtIDs gets ids using external ids
+func (r *inmem) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	ids := make(map[string]id.ID, len(extIDs))
+	for _, extID := range extIDs {
+		for _, e := range r.m {
+			if e.TenantID == tenantID && e.ExtID == extID {
+				ids[extID] = e.ID
+			}
+		}
+	}
+	return ids, nil
+}
+
+// SetEnabled enables or disables a center
+func (r *inmem) SetEnabled(tenantID id.ID, centerID id.ID, isEnabled bool) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	c, ok := r.m[centerID]
+	if !ok || c.TenantID != tenantID {
+		return glad.ErrNotFound
+	}
+	updated := *c
+	updated.IsEnabled = isEnabled
+	r.m[centerID] = &updated
+	return nil
+}
+
 // GetByExtID retrieves id using external id
 func (r *inmem) GetByExtID(tenantID id.ID, extID string) (*entity.Center, error) {
 	r.mut.Lock()
@@ -173,3 +262,26 @@ func (r *inmem) GetByExtID(tenantID id.ID, extID string) (*entity.Center, error)
 	}
 	return nil, glad.ErrNotFound
 }
+
+// QueueChange queues the change for the export
+func (r *inmem) QueueChange(e *entity.AuditLog) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.changes = append(r.changes, e)
+	return nil
+}
+
+// InTx runs fn; the queued changes are dropped when it fails
+func (r *inmem) InTx(fn func(repo Repository) error) error {
+	r.mut.RLock()
+	changes := len(r.changes)
+	r.mut.RUnlock()
+
+	err := fn(r)
+	if err != nil {
+		r.mut.Lock()
+		r.changes = r.changes[:changes]
+		r.mut.Unlock()
+	}
+	return err
+}
@@ -13,19 +13,31 @@ import (
 
 // Reader interface
 type Reader interface {
-	Get(id id.ID) (*entity.Center, error)
+	Get(tenantID id.ID, centerID id.ID) (*entity.Center, error)
 	Search(tenantID id.ID, query string, page, limit int) ([]*entity.Center, error)
 	List(tenantID id.ID, page, limit int) ([]*entity.Center, error)
+	Nearby(tenantID id.ID, q *entity.GeoQuery, page, limit int) ([]*entity.CenterNearby, error)
 	GetCount(id id.ID) (int, error)
 	GetByExtID(tenantID id.ID, extID string) (*entity.Center, error)
+	// GetIDsByExtIDs gets ids by external id; unknown ones are left out
+	GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error)
 }
 
 // Writer center writer
 type Writer interface {
 	Create(e *entity.Center) (id.ID, error)
 	Update(e *entity.Center) error
-	Delete(id id.ID) error
+	Delete(tenantID id.ID, centerID id.ID) error
 	Upsert(e *entity.Center) (id.ID, error)
+	// BulkUpsert upserts centers keyed by external id; returns ids by external id
+	BulkUpsert(tenantID id.ID, centers []*entity.Center) (map[string]id.ID, error)
+	// SetEnabled enables or disables the center
+	SetEnabled(tenantID id.ID, centerID id.ID, isEnabled bool) error
+	// QueueChange queues the change for the export (see audit.Outbox)
+	QueueChange(e *entity.AuditLog) error
+	// InTx runs fn with a repository bound to a transaction; the writes made
+	// by fn are committed together
+	InTx(fn func(repo Repository) error) error
 }
 
 // Repository interface
@@ -36,13 +48,23 @@ type Repository interface {
 
 // UseCase interface
 type UseCase interface {
-	GetCenter(id id.ID) (*entity.Center, error)
+	GetCenter(tenantID id.ID, centerID id.ID) (*entity.Center, error)
 	SearchCenters(tenantID id.ID, query string, page, limit int) ([]*entity.Center, error)
 	ListCenters(tenantID id.ID, page, limit int) ([]*entity.Center, error)
-	CreateCenter(tenantID id.ID, name string, mode entity.CenterMode, isEnabled bool) (id.ID, error)
-	UpdateCenter(e *entity.Center) error
-	DeleteCenter(id id.ID) error
+	GetNearbyCenters(tenantID id.ID, q *entity.GeoQuery, page, limit int) ([]*entity.CenterNearby, error)
+	CreateCenter(actor entity.AuditActor,
+		tenantID id.ID,
+		name string,
+		mode entity.CenterMode,
+		isEnabled bool,
+	) (id.ID, error)
+	UpdateCenter(actor entity.AuditActor, e *entity.Center) error
+	DeleteCenter(actor entity.AuditActor, tenantID id.ID, centerID id.ID) error
 	GetCount(id id.ID) int
-	UpsertCenter(e *entity.Center) (id.ID, error)
+	UpsertCenter(actor entity.AuditActor, e *entity.Center) (id.ID, error)
+	UpsertCenters(actor entity.AuditActor, tenantID id.ID, centers []*entity.Center) ([]id.ID, error)
 	GetIDByExtID(tenantID id.ID, extID string) (id.ID, error)
+	GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error)
+	ArchiveCenterByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error)
+	RestoreCenterByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error)
 }
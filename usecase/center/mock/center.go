@@ -7,6 +7,7 @@ package mock_center
 import (
 	entity "ac9/glad/entity"
 	id "ac9/glad/pkg/id"
+	center "ac9/glad/usecase/center"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -36,18 +37,18 @@ func (m *MockReader) EXPECT() *MockReaderMockRecorder {
 }
 
 // Get mocks base method.
-func (m *MockReader) Get(id id.ID) (*entity.Center, error) {
+func (m *MockReader) Get(tenantID, centerID id.ID) (*entity.Center, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, centerID)
 	ret0, _ := ret[0].(*entity.Center)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockReaderMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockReaderMockRecorder) Get(tenantID, centerID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, centerID)
 }
 
 // GetByExtID mocks base method.
@@ -80,6 +81,21 @@ func (mr *MockReaderMockRecorder) GetCount(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockReader)(nil).GetCount), id)
 }
 
+// GetIDsByExtIDs mocks base method.
+func (m *MockReader) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByExtIDs", tenantID, extIDs)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByExtIDs indicates an expected call of GetIDsByExtIDs.
+func (mr *MockReaderMockRecorder) GetIDsByExtIDs(tenantID, extIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByExtIDs", reflect.TypeOf((*MockReader)(nil).GetIDsByExtIDs), tenantID, extIDs)
+}
+
 // List mocks base method.
 func (m *MockReader) List(tenantID id.ID, page, limit int) ([]*entity.Center, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +111,21 @@ func (mr *MockReaderMockRecorder) List(tenantID, page, limit interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List), tenantID, page, limit)
 }
 
+// Nearby mocks base method.
+func (m *MockReader) Nearby(tenantID id.ID, q *entity.GeoQuery, page, limit int) ([]*entity.CenterNearby, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Nearby", tenantID, q, page, limit)
+	ret0, _ := ret[0].([]*entity.CenterNearby)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Nearby indicates an expected call of Nearby.
+func (mr *MockReaderMockRecorder) Nearby(tenantID, q, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nearby", reflect.TypeOf((*MockReader)(nil).Nearby), tenantID, q, page, limit)
+}
+
 // Search mocks base method.
 func (m *MockReader) Search(tenantID id.ID, query string, page, limit int) ([]*entity.Center, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +164,21 @@ func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
 	return m.recorder
 }
 
+// BulkUpsert mocks base method.
+func (m *MockWriter) BulkUpsert(tenantID id.ID, centers []*entity.Center) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsert", tenantID, centers)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpsert indicates an expected call of BulkUpsert.
+func (mr *MockWriterMockRecorder) BulkUpsert(tenantID, centers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockWriter)(nil).BulkUpsert), tenantID, centers)
+}
+
 // Create mocks base method.
 func (m *MockWriter) Create(e *entity.Center) (id.ID, error) {
 	m.ctrl.T.Helper()
@@ -149,17 +195,59 @@ func (mr *MockWriterMockRecorder) Create(e interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockWriter) Delete(id id.ID) error {
+func (m *MockWriter) Delete(tenantID, centerID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, centerID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockWriterMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockWriterMockRecorder) Delete(tenantID, centerID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), tenantID, centerID)
+}
+
+// InTx mocks base method.
+func (m *MockWriter) InTx(fn func(center.Repository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockWriterMockRecorder) InTx(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockWriter)(nil).InTx), fn)
+}
+
+// QueueChange mocks base method.
+func (m *MockWriter) QueueChange(e *entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueChange", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueChange indicates an expected call of QueueChange.
+func (mr *MockWriterMockRecorder) QueueChange(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueChange", reflect.TypeOf((*MockWriter)(nil).QueueChange), e)
+}
+
+// SetEnabled mocks base method.
+func (m *MockWriter) SetEnabled(tenantID, centerID id.ID, isEnabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnabled", tenantID, centerID, isEnabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEnabled indicates an expected call of SetEnabled.
+func (mr *MockWriterMockRecorder) SetEnabled(tenantID, centerID, isEnabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockWriter)(nil).SetEnabled), tenantID, centerID, isEnabled)
 }
 
 // Update mocks base method.
@@ -214,6 +302,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BulkUpsert mocks base method.
+func (m *MockRepository) BulkUpsert(tenantID id.ID, centers []*entity.Center) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsert", tenantID, centers)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpsert indicates an expected call of BulkUpsert.
+func (mr *MockRepositoryMockRecorder) BulkUpsert(tenantID, centers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockRepository)(nil).BulkUpsert), tenantID, centers)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(e *entity.Center) (id.ID, error) {
 	m.ctrl.T.Helper()
@@ -230,32 +333,32 @@ func (mr *MockRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockRepository) Delete(id id.ID) error {
+func (m *MockRepository) Delete(tenantID, centerID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, centerID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(tenantID, centerID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), tenantID, centerID)
 }
 
 // Get mocks base method.
-func (m *MockRepository) Get(id id.ID) (*entity.Center, error) {
+func (m *MockRepository) Get(tenantID, centerID id.ID) (*entity.Center, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, centerID)
 	ret0, _ := ret[0].(*entity.Center)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Get(tenantID, centerID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, centerID)
 }
 
 // GetByExtID mocks base method.
@@ -288,6 +391,35 @@ func (mr *MockRepositoryMockRecorder) GetCount(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRepository)(nil).GetCount), id)
 }
 
+// GetIDsByExtIDs mocks base method.
+func (m *MockRepository) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByExtIDs", tenantID, extIDs)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByExtIDs indicates an expected call of GetIDsByExtIDs.
+func (mr *MockRepositoryMockRecorder) GetIDsByExtIDs(tenantID, extIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByExtIDs", reflect.TypeOf((*MockRepository)(nil).GetIDsByExtIDs), tenantID, extIDs)
+}
+
+// InTx mocks base method.
+func (m *MockRepository) InTx(fn func(center.Repository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockRepositoryMockRecorder) InTx(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockRepository)(nil).InTx), fn)
+}
+
 // List mocks base method.
 func (m *MockRepository) List(tenantID id.ID, page, limit int) ([]*entity.Center, error) {
 	m.ctrl.T.Helper()
@@ -303,6 +435,35 @@ func (mr *MockRepositoryMockRecorder) List(tenantID, page, limit interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), tenantID, page, limit)
 }
 
+// Nearby mocks base method.
+func (m *MockRepository) Nearby(tenantID id.ID, q *entity.GeoQuery, page, limit int) ([]*entity.CenterNearby, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Nearby", tenantID, q, page, limit)
+	ret0, _ := ret[0].([]*entity.CenterNearby)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Nearby indicates an expected call of Nearby.
+func (mr *MockRepositoryMockRecorder) Nearby(tenantID, q, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nearby", reflect.TypeOf((*MockRepository)(nil).Nearby), tenantID, q, page, limit)
+}
+
+// QueueChange mocks base method.
+func (m *MockRepository) QueueChange(e *entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueChange", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueChange indicates an expected call of QueueChange.
+func (mr *MockRepositoryMockRecorder) QueueChange(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueChange", reflect.TypeOf((*MockRepository)(nil).QueueChange), e)
+}
+
 // Search mocks base method.
 func (m *MockRepository) Search(tenantID id.ID, query string, page, limit int) ([]*entity.Center, error) {
 	m.ctrl.T.Helper()
@@ -318,6 +479,20 @@ func (mr *MockRepositoryMockRecorder) Search(tenantID, query, page, limit interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRepository)(nil).Search), tenantID, query, page, limit)
 }
 
+// SetEnabled mocks base method.
+func (m *MockRepository) SetEnabled(tenantID, centerID id.ID, isEnabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnabled", tenantID, centerID, isEnabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEnabled indicates an expected call of SetEnabled.
+func (mr *MockRepositoryMockRecorder) SetEnabled(tenantID, centerID, isEnabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockRepository)(nil).SetEnabled), tenantID, centerID, isEnabled)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(e *entity.Center) error {
 	m.ctrl.T.Helper()
@@ -370,48 +545,63 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ArchiveCenterByExtID mocks base method.
+func (m *MockUseCase) ArchiveCenterByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveCenterByExtID", actor, tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveCenterByExtID indicates an expected call of ArchiveCenterByExtID.
+func (mr *MockUseCaseMockRecorder) ArchiveCenterByExtID(actor, tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveCenterByExtID", reflect.TypeOf((*MockUseCase)(nil).ArchiveCenterByExtID), actor, tenantID, extID)
+}
+
 // CreateCenter mocks base method.
-func (m *MockUseCase) CreateCenter(tenantID id.ID, name string, mode entity.CenterMode, isEnabled bool) (id.ID, error) {
+func (m *MockUseCase) CreateCenter(actor entity.AuditActor, tenantID id.ID, name string, mode entity.CenterMode, isEnabled bool) (id.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCenter", tenantID, name, mode, isEnabled)
+	ret := m.ctrl.Call(m, "CreateCenter", actor, tenantID, name, mode, isEnabled)
 	ret0, _ := ret[0].(id.ID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateCenter indicates an expected call of CreateCenter.
-func (mr *MockUseCaseMockRecorder) CreateCenter(tenantID, name, mode, isEnabled interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) CreateCenter(actor, tenantID, name, mode, isEnabled interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCenter", reflect.TypeOf((*MockUseCase)(nil).CreateCenter), tenantID, name, mode, isEnabled)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCenter", reflect.TypeOf((*MockUseCase)(nil).CreateCenter), actor, tenantID, name, mode, isEnabled)
 }
 
 // DeleteCenter mocks base method.
-func (m *MockUseCase) DeleteCenter(id id.ID) error {
+func (m *MockUseCase) DeleteCenter(actor entity.AuditActor, tenantID, centerID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCenter", id)
+	ret := m.ctrl.Call(m, "DeleteCenter", actor, tenantID, centerID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCenter indicates an expected call of DeleteCenter.
-func (mr *MockUseCaseMockRecorder) DeleteCenter(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DeleteCenter(actor, tenantID, centerID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCenter", reflect.TypeOf((*MockUseCase)(nil).DeleteCenter), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCenter", reflect.TypeOf((*MockUseCase)(nil).DeleteCenter), actor, tenantID, centerID)
 }
 
 // GetCenter mocks base method.
-func (m *MockUseCase) GetCenter(id id.ID) (*entity.Center, error) {
+func (m *MockUseCase) GetCenter(tenantID, centerID id.ID) (*entity.Center, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCenter", id)
+	ret := m.ctrl.Call(m, "GetCenter", tenantID, centerID)
 	ret0, _ := ret[0].(*entity.Center)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCenter indicates an expected call of GetCenter.
-func (mr *MockUseCaseMockRecorder) GetCenter(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) GetCenter(tenantID, centerID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCenter", reflect.TypeOf((*MockUseCase)(nil).GetCenter), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCenter", reflect.TypeOf((*MockUseCase)(nil).GetCenter), tenantID, centerID)
 }
 
 // GetCount mocks base method.
@@ -443,6 +633,36 @@ func (mr *MockUseCaseMockRecorder) GetIDByExtID(tenantID, extID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDByExtID", reflect.TypeOf((*MockUseCase)(nil).GetIDByExtID), tenantID, extID)
 }
 
+// GetIDsByExtIDs mocks base method.
+func (m *MockUseCase) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByExtIDs", tenantID, extIDs)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByExtIDs indicates an expected call of GetIDsByExtIDs.
+func (mr *MockUseCaseMockRecorder) GetIDsByExtIDs(tenantID, extIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByExtIDs", reflect.TypeOf((*MockUseCase)(nil).GetIDsByExtIDs), tenantID, extIDs)
+}
+
+// GetNearbyCenters mocks base method.
+func (m *MockUseCase) GetNearbyCenters(tenantID id.ID, q *entity.GeoQuery, page, limit int) ([]*entity.CenterNearby, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearbyCenters", tenantID, q, page, limit)
+	ret0, _ := ret[0].([]*entity.CenterNearby)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearbyCenters indicates an expected call of GetNearbyCenters.
+func (mr *MockUseCaseMockRecorder) GetNearbyCenters(tenantID, q, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyCenters", reflect.TypeOf((*MockUseCase)(nil).GetNearbyCenters), tenantID, q, page, limit)
+}
+
 // ListCenters mocks base method.
 func (m *MockUseCase) ListCenters(tenantID id.ID, page, limit int) ([]*entity.Center, error) {
 	m.ctrl.T.Helper()
@@ -458,6 +678,21 @@ func (mr *MockUseCaseMockRecorder) ListCenters(tenantID, page, limit interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCenters", reflect.TypeOf((*MockUseCase)(nil).ListCenters), tenantID, page, limit)
 }
 
+// RestoreCenterByExtID mocks base method.
+func (m *MockUseCase) RestoreCenterByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCenterByExtID", actor, tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCenterByExtID indicates an expected call of RestoreCenterByExtID.
+func (mr *MockUseCaseMockRecorder) RestoreCenterByExtID(actor, tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCenterByExtID", reflect.TypeOf((*MockUseCase)(nil).RestoreCenterByExtID), actor, tenantID, extID)
+}
+
 // SearchCenters mocks base method.
 func (m *MockUseCase) SearchCenters(tenantID id.ID, query string, page, limit int) ([]*entity.Center, error) {
 	m.ctrl.T.Helper()
@@ -474,30 +709,45 @@ func (mr *MockUseCaseMockRecorder) SearchCenters(tenantID, query, page, limit in
 }
 
 // UpdateCenter mocks base method.
-func (m *MockUseCase) UpdateCenter(e *entity.Center) error {
+func (m *MockUseCase) UpdateCenter(actor entity.AuditActor, e *entity.Center) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCenter", e)
+	ret := m.ctrl.Call(m, "UpdateCenter", actor, e)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCenter indicates an expected call of UpdateCenter.
-func (mr *MockUseCaseMockRecorder) UpdateCenter(e interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) UpdateCenter(actor, e interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCenter", reflect.TypeOf((*MockUseCase)(nil).UpdateCenter), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCenter", reflect.TypeOf((*MockUseCase)(nil).UpdateCenter), actor, e)
 }
 
 // UpsertCenter mocks base method.
-func (m *MockUseCase) UpsertCenter(e *entity.Center) (id.ID, error) {
+func (m *MockUseCase) UpsertCenter(actor entity.AuditActor, e *entity.Center) (id.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertCenter", e)
+	ret := m.ctrl.Call(m, "UpsertCenter", actor, e)
 	ret0, _ := ret[0].(id.ID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpsertCenter indicates an expected call of UpsertCenter.
-func (mr *MockUseCaseMockRecorder) UpsertCenter(e interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) UpsertCenter(actor, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCenter", reflect.TypeOf((*MockUseCase)(nil).UpsertCenter), actor, e)
+}
+
+// UpsertCenters mocks base method.
+func (m *MockUseCase) UpsertCenters(actor entity.AuditActor, tenantID id.ID, centers []*entity.Center) ([]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCenters", actor, tenantID, centers)
+	ret0, _ := ret[0].([]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCenters indicates an expected call of UpsertCenters.
+func (mr *MockUseCaseMockRecorder) UpsertCenters(actor, tenantID, centers interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCenter", reflect.TypeOf((*MockUseCase)(nil).UpsertCenter), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCenters", reflect.TypeOf((*MockUseCase)(nil).UpsertCenters), actor, tenantID, centers)
 }
@@ -14,22 +14,26 @@ import (
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
+	"ac9/glad/usecase/audit"
 )
 
 // Service center usecase
 type Service struct {
-	repo Repository
+	repo  Repository
+	audit audit.Recorder
 }
 
 // NewService create new service
-func NewService(r Repository) *Service {
+func NewService(r Repository, a audit.Recorder) *Service {
 	return &Service{
-		repo: r,
+		repo:  r,
+		audit: a,
 	}
 }
 
 // CreateCenter creates a center
-func (s *Service) CreateCenter(tenantID id.ID,
+func (s *Service) CreateCenter(actor entity.AuditActor,
+	tenantID id.ID,
 	name string,
 	mode entity.CenterMode,
 	isEnabled bool,
@@ -39,12 +43,26 @@ func (s *Service) CreateCenter(tenantID id.ID,
 	if err != nil {
 		return id.IDInvalid, err
 	}
-	return s.repo.Create(c)
+	var centerID id.ID
+	err = s.repo.InTx(func(repo Repository) error {
+		var err error
+		centerID, err = repo.Create(c)
+		if err != nil {
+			return err
+		}
+		return audit.QueueChange(repo, actor, tenantID, entity.AuditCenter, centerID, entity.AuditCreate, c)
+	})
+	if err != nil {
+		return centerID, err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditCenter, centerID, entity.AuditCreate, nil, c)
+	return centerID, nil
 }
 
 // GetCenter retrieves a center
-func (s *Service) GetCenter(id id.ID) (*entity.Center, error) {
-	t, err := s.repo.Get(id)
+func (s *Service) GetCenter(tenantID id.ID, centerID id.ID) (*entity.Center, error) {
+	t, err := s.repo.Get(tenantID, centerID)
 	if t == nil {
 		return nil, glad.ErrNotFound
 	}
@@ -81,9 +99,29 @@ func (s *Service) ListCenters(tenantID id.ID, page, limit int) ([]*entity.Center
 	return centers, nil
 }
 
+// GetNearbyCenters lists centers around a location, closest first
+func (s *Service) GetNearbyCenters(tenantID id.ID,
+	q *entity.GeoQuery,
+	page, limit int,
+) ([]*entity.CenterNearby, error) {
+	err := q.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	centers, err := s.repo.Nearby(tenantID, q, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(centers) == 0 {
+		return nil, glad.ErrNotFound
+	}
+	return centers, nil
+}
+
 // DeleteCenter Delete a center
-func (s *Service) DeleteCenter(id id.ID) error {
-	t, err := s.GetCenter(id)
+func (s *Service) DeleteCenter(actor entity.AuditActor, tenantID id.ID, centerID id.ID) error {
+	t, err := s.GetCenter(tenantID, centerID)
 	if t == nil {
 		return glad.ErrNotFound
 	}
@@ -91,17 +129,45 @@ func (s *Service) DeleteCenter(id id.ID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	err = s.repo.InTx(func(repo Repository) error {
+		err := repo.Delete(tenantID, centerID)
+		if err != nil {
+			return err
+		}
+		return audit.QueueChange(repo, actor, tenantID, entity.AuditCenter, centerID, entity.AuditDelete, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditCenter, centerID, entity.AuditDelete, t, nil)
+	return nil
 }
 
 // UpdateCenter Update a center
-func (s *Service) UpdateCenter(c *entity.Center) error {
+func (s *Service) UpdateCenter(actor entity.AuditActor, c *entity.Center) error {
 	err := c.Validate()
 	if err != nil {
 		return err
 	}
+	// the legacy abbreviations are kept as their IANA zone
+	c.Timezone, _ = entity.NormalizeTimezone(c.Timezone)
+
+	before, _ := s.repo.Get(c.TenantID, c.ID)
 	c.UpdatedAt = time.Now()
-	return s.repo.Update(c)
+	err = s.repo.InTx(func(repo Repository) error {
+		err := repo.Update(c)
+		if err != nil {
+			return err
+		}
+		return audit.QueueChange(repo, actor, c.TenantID, entity.AuditCenter, c.ID, entity.AuditUpdate, c)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, c.TenantID, entity.AuditCenter, c.ID, entity.AuditUpdate, before, c)
+	return nil
 }
 
 // GetCount gets total center count
@@ -115,7 +181,7 @@ func (s *Service) GetCount(tenantID id.ID) int {
 }
 
 // UpsertCenter upserts a center
-func (s *Service) UpsertCenter(c *entity.Center) (id.ID, error) {
+func (s *Service) UpsertCenter(actor entity.AuditActor, c *entity.Center) (id.ID, error) {
 	if c.ID == id.IDInvalid {
 		// assign id and during update id should not be overwritten
 		c.ID = id.New()
@@ -130,7 +196,91 @@ func (s *Service) UpsertCenter(c *entity.Center) (id.ID, error) {
 		l.Log.Warnf("err=%v", err)
 		return id.IDInvalid, err
 	}
-	return s.repo.Upsert(c)
+
+	var before *entity.Center
+	if c.ExtID != "" {
+		before, _ = s.repo.GetByExtID(c.TenantID, c.ExtID)
+	}
+	centerID, err := s.repo.Upsert(c)
+	if err != nil {
+		return centerID, err
+	}
+
+	s.audit.Record(actor, c.TenantID, entity.AuditCenter, centerID, entity.AuditUpsert, before, c)
+	return centerID, nil
+}
+
+// UpsertCenters upserts the tenant's centers imported in bulk
+// The returned ids follow the order of centers; a center that could not be
+// upserted gets id.IDInvalid. When the batch is rejected as a whole, e.g. on a
+// constraint violation, the centers are upserted one by one so that a single
+// bad record does not fail the others.
+func (s *Service) UpsertCenters(actor entity.AuditActor,
+	tenantID id.ID,
+	centers []*entity.Center,
+) ([]id.ID, error) {
+	ids := make([]id.ID, len(centers))
+	valid := make([]bool, len(centers))
+
+	// the last record wins when an external id repeats within the batch
+	last := make(map[string]int, len(centers))
+	for i, c := range centers {
+		c.TenantID = tenantID
+		if c.ID == id.IDInvalid {
+			c.ID = id.New()
+		}
+
+		// Note: Salesforce data is not cleaner. Transform the data as a workaround
+		c.Transform()
+
+		err := c.Validate()
+		if err == nil && c.ExtID == "" {
+			err = glad.ErrInvalidValue
+		}
+		if err != nil {
+			l.Log.Warnf("index=%v, err=%v", i, err)
+			continue
+		}
+		valid[i] = true
+		last[c.ExtID] = i
+	}
+
+	batch := make([]*entity.Center, 0, len(last))
+	for i, c := range centers {
+		if j, ok := last[c.ExtID]; ok && j == i {
+			batch = append(batch, c)
+		}
+	}
+	if len(batch) == 0 {
+		return ids, nil
+	}
+
+	byExtID, err := s.repo.BulkUpsert(tenantID, batch)
+	if err != nil {
+		l.Log.Warnf("Bulk upsert failed, upserting one by one. err=%v", err)
+		byExtID = make(map[string]id.ID, len(batch))
+		for _, c := range batch {
+			centerID, err := s.UpsertCenter(actor, c)
+			if err != nil {
+				l.Log.Warnf("extID=%v, err=%v", c.ExtID, err)
+				continue
+			}
+			byExtID[c.ExtID] = centerID
+		}
+	} else {
+		// Note: the state before the upsert is not looked up for bulk imports
+		for _, c := range batch {
+			s.audit.Record(actor, tenantID, entity.AuditCenter, byExtID[c.ExtID],
+				entity.AuditUpsert, nil, c)
+		}
+	}
+
+	for i, c := range centers {
+		if valid[i] {
+			ids[i] = byExtID[c.ExtID]
+		}
+	}
+	return ids, nil
 }
 
 // GetIDByExtID gets product id using external id
@@ -147,3 +297,66 @@ func (s *Service) GetIDByExtID(tenantID id.ID, extID string) (id.ID, error) {
 
 	return c.ID, nil
 }
+
+// GetIDsByExtIDs gets center ids using external ids in one lookup
+// Unknown external ids are absent from the result.
+func (s *Service) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	if len(extIDs) == 0 {
+		return map[string]id.ID{}, nil
+	}
+
+	ids, err := s.repo.GetIDsByExtIDs(tenantID, extIDs)
+	if err != nil {
+		l.Log.Warnf("tenantID=%v, err=%v", tenantID, err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ArchiveCenterByExtID disables the center deleted in Salesforce
+func (s *Service) ArchiveCenterByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+) (id.ID, error) {
+	return s.setEnabledByExtID(actor, tenantID, extID, false)
+}
+
+// RestoreCenterByExtID enables the center undeleted in Salesforce
+func (s *Service) RestoreCenterByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+) (id.ID, error) {
+	return s.setEnabledByExtID(actor, tenantID, extID, true)
+}
+
+// setEnabledByExtID enables or disables the center; no-op when unchanged
+func (s *Service) setEnabledByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+	isEnabled bool,
+) (id.ID, error) {
+	if extID == "" {
+		return id.IDInvalid, glad.ErrInvalidValue
+	}
+
+	c, err := s.repo.GetByExtID(tenantID, extID)
+	if c == nil {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	if c.IsEnabled == isEnabled {
+		return c.ID, nil
+	}
+
+	err = s.repo.SetEnabled(tenantID, c.ID, isEnabled)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	updated := *c
+	updated.IsEnabled = isEnabled
+	s.audit.Record(actor, tenantID, entity.AuditCenter, c.ID, entity.AuditUpdate, c, &updated)
+	return c.ID, nil
+}
@@ -13,6 +13,7 @@ import (
 	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
+	"ac9/glad/usecase/audit"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -22,11 +23,30 @@ const (
 	tenantAlice   id.ID = 13790492210917015554
 	aliceExtID          = "000aliceExtID"
 
-	// todo: add multi-tenant support
-	// tenantBob    id.ID = 13790492210917015555
-	bobExtID = "000bobExtID"
+	tenantBob id.ID = 13790492210917015555
+	bobExtID        = "000bobExtID"
 )
 
+// testActor caller making the changes
+var testActor = entity.AuditActor{Source: entity.AuditSourceAPI}
+
+// testRecorder keeps the changes recorded by the service
+type testRecorder struct {
+	logs []*entity.AuditLog
+}
+
+func (r *testRecorder) Record(actor entity.AuditActor,
+	tenantID id.ID,
+	entityType entity.AuditEntityType,
+	entityID id.ID,
+	op entity.AuditOperation,
+	before interface{},
+	after interface{},
+) {
+	e, _ := entity.NewAuditLog(actor, tenantID, entityType, entityID, op, before, after)
+	r.logs = append(r.logs, e)
+}
+
 func newFixtureCenter() *entity.Center {
 	return &entity.Center{
 		ID:        centerDefault,
@@ -42,23 +62,23 @@ func newFixtureCenter() *entity.Center {
 
 func Test_Create(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	tmpl := newFixtureCenter()
-	_, err := m.CreateCenter(tmpl.TenantID, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
+	_, err := m.CreateCenter(testActor, tmpl.TenantID, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
 	assert.Nil(t, err)
 	assert.False(t, tmpl.CreatedAt.IsZero())
 }
 
 func Test_SearchAndFind(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	tmpl1 := newFixtureCenter()
 	tmpl2 := newFixtureCenter()
 	tmpl2.Name = "default2"
 	tmpl2.ExtID = bobExtID
 
-	tID, _ := m.CreateCenter(tmpl1.TenantID, tmpl1.Name, tmpl1.Mode, tmpl1.IsEnabled)
-	_, _ = m.CreateCenter(tmpl2.TenantID, tmpl2.Name, tmpl2.Mode, tmpl2.IsEnabled)
+	tID, _ := m.CreateCenter(testActor, tmpl1.TenantID, tmpl1.Name, tmpl1.Mode, tmpl1.IsEnabled)
+	_, _ = m.CreateCenter(testActor, tmpl2.TenantID, tmpl2.Name, tmpl2.Mode, tmpl2.IsEnabled)
 
 	t.Run("search", func(t *testing.T) {
 		res, err := m.SearchCenters(tmpl1.TenantID, "default1", 0, 0)
@@ -83,7 +103,7 @@ func Test_SearchAndFind(t *testing.T) {
 	})
 
 	t.Run("get", func(t *testing.T) {
-		saved, err := m.GetCenter(tID)
+		saved, err := m.GetCenter(tenantAlice, tID)
 		assert.Nil(t, err)
 		assert.Equal(t, tmpl1.TenantID, saved.TenantID)
 		assert.Equal(t, tmpl1.IsEnabled, saved.IsEnabled)
@@ -94,34 +114,180 @@ func Test_SearchAndFind(t *testing.T) {
 
 func Test_Update(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	tmpl := newFixtureCenter()
-	id, err := m.CreateCenter(tmpl.TenantID, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
+	id, err := m.CreateCenter(testActor, tmpl.TenantID, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
 	assert.Nil(t, err)
 
-	saved, _ := m.GetCenter(id)
+	saved, _ := m.GetCenter(tenantAlice, id)
 	saved.Mode = entity.CenterOnline
-	assert.Nil(t, m.UpdateCenter(saved))
+	assert.Nil(t, m.UpdateCenter(testActor, saved))
 
-	updated, err := m.GetCenter(id)
+	updated, err := m.GetCenter(tenantAlice, id)
 	assert.Nil(t, err)
 	assert.Equal(t, entity.CenterOnline, updated.Mode)
 }
 
 func TestDelete(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 
 	tmpl1 := newFixtureCenter()
 	tmpl2 := newFixtureCenter()
 	tmpl2.ExtID = bobExtID
-	t2ID, _ := m.CreateCenter(tmpl2.TenantID, tmpl2.Name, tmpl2.Mode, tmpl2.IsEnabled)
+	t2ID, _ := m.CreateCenter(testActor, tmpl2.TenantID, tmpl2.Name, tmpl2.Mode, tmpl2.IsEnabled)
+
+	err := m.DeleteCenter(testActor, tenantAlice, tmpl1.ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	err = m.DeleteCenter(testActor, tenantAlice, t2ID)
+	assert.Nil(t, err)
+	_, err = m.GetCenter(tenantAlice, t2ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+}
+
+func TestAudit(t *testing.T) {
+	repo := newInmem()
+	rec := &testRecorder{}
+	m := NewService(repo, rec)
+	actor := entity.AuditActor{AccountID: id.New(), Source: entity.AuditSourceAPI}
+
+	tmpl := newFixtureCenter()
+	cID, err := m.CreateCenter(actor, tmpl.TenantID, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
+	assert.Nil(t, err)
+
+	// a copy, so that the stored center is the state before the update
+	saved, _ := m.GetCenter(tenantAlice, cID)
+	updated := *saved
+	updated.Name = "renamed"
+	assert.Nil(t, m.UpdateCenter(actor, &updated))
+	assert.Nil(t, m.DeleteCenter(actor, tenantAlice, cID))
+
+	// failed changes are not recorded
+	assert.Equal(t, glad.ErrNotFound, m.DeleteCenter(actor, tenantAlice, cID))
+
+	assert.Equal(t, 3, len(rec.logs))
+	for i, op := range []entity.AuditOperation{
+		entity.AuditCreate, entity.AuditUpdate, entity.AuditDelete,
+	} {
+		assert.Equal(t, op, rec.logs[i].Operation)
+		assert.Equal(t, entity.AuditCenter, rec.logs[i].EntityType)
+		assert.Equal(t, cID, rec.logs[i].EntityID)
+		assert.Equal(t, tenantAlice, rec.logs[i].TenantID)
+		assert.Equal(t, actor.AccountID, rec.logs[i].ActorID)
+	}
+
+	changes := rec.logs[1].Changes
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, "Name", changes[0].Field)
+	assert.JSONEq(t, `"renamed"`, string(changes[0].After))
+}
+
+func TestTenantIsolation(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo, audit.Discard)
+	tmpl := newFixtureCenter()
+	cID, err := m.CreateCenter(testActor, tmpl.TenantID, tmpl.Name, tmpl.Mode, tmpl.IsEnabled)
+	assert.Nil(t, err)
+
+	_, err = m.GetCenter(tenantBob, cID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	saved, _ := m.GetCenter(tenantAlice, cID)
+	other := *saved
+	other.TenantID = tenantBob
+	other.Name = "hijacked"
+	assert.Equal(t, glad.ErrNotFound, m.UpdateCenter(testActor, &other))
 
-	err := m.DeleteCenter(tmpl1.ID)
+	err = m.DeleteCenter(testActor, tenantBob, cID)
 	assert.Equal(t, glad.ErrNotFound, err)
 
-	err = m.DeleteCenter(t2ID)
+	saved, err = m.GetCenter(tenantAlice, cID)
 	assert.Nil(t, err)
-	_, err = m.GetCenter(t2ID)
+	assert.Equal(t, tmpl.Name, saved.Name)
+}
+
+func TestNearby(t *testing.T) {
+	repo := newInmem()
+	m := NewService(repo, audit.Discard)
+
+	// San Jose; Santa Clara is ~6 km away, San Francisco ~68 km
+	q := &entity.GeoQuery{Lat: 37.3382, Long: -121.8863}
+	for _, c := range []struct {
+		name      string
+		tenantID  id.ID
+		geo       entity.CenterGeoLocation
+		isEnabled bool
+	}{
+		{"santa-clara", tenantAlice, entity.CenterGeoLocation{Lat: 37.3541, Long: -121.9552}, true},
+		{"san-jose", tenantAlice, entity.CenterGeoLocation{Lat: 37.3300, Long: -121.8900}, true},
+		{"san-francisco", tenantAlice, entity.CenterGeoLocation{Lat: 37.7749, Long: -122.4194}, true},
+		{"disabled", tenantAlice, entity.CenterGeoLocation{Lat: 37.3382, Long: -121.8863}, false},
+		{"bob", tenantBob, entity.CenterGeoLocation{Lat: 37.3382, Long: -121.8863}, true},
+	} {
+		e, err := entity.NewCenter(c.tenantID, c.name, entity.CenterAddress{}, c.geo,
+			0, entity.CenterInPerson, "", false, c.isEnabled)
+		assert.Nil(t, err)
+		_, err = repo.Create(e)
+		assert.Nil(t, err)
+	}
+
+	t.Run("default radius, nearest first", func(t *testing.T) {
+		res, err := m.GetNearbyCenters(tenantAlice, q, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(res))
+		assert.Equal(t, "san-jose", res[0].Name)
+		assert.Equal(t, "santa-clara", res[1].Name)
+		assert.InDelta(t, 6.4, res[1].DistanceKm, 0.5)
+		assert.Equal(t, entity.GeoDefaultRadiusKm, q.RadiusKm)
+	})
+	t.Run("wider radius and pagination", func(t *testing.T) {
+		wide := &entity.GeoQuery{Lat: q.Lat, Long: q.Long, RadiusKm: 100}
+		res, err := m.GetNearbyCenters(tenantAlice, wide, 2, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(res))
+		assert.Equal(t, "san-francisco", res[0].Name)
+	})
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := m.GetNearbyCenters(tenantAlice, &entity.GeoQuery{Lat: 91}, 0, 0)
+		assert.Equal(t, glad.ErrInvalidValue, err)
+		_, err = m.GetNearbyCenters(tenantAlice,
+			&entity.GeoQuery{RadiusKm: entity.GeoMaxRadiusKm + 1}, 0, 0)
+		assert.Equal(t, glad.ErrInvalidValue, err)
+	})
+	t.Run("nothing nearby", func(t *testing.T) {
+		_, err := m.GetNearbyCenters(tenantAlice, &entity.GeoQuery{Lat: 0, Long: 0}, 0, 0)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+}
+
+func TestArchiveByExtID(t *testing.T) {
+	repo := newInmem()
+	rec := &testRecorder{}
+	m := NewService(repo, rec)
+	cID, err := m.UpsertCenter(testActor, newFixtureCenter())
+	assert.Nil(t, err)
+
+	archivedID, err := m.ArchiveCenterByExtID(testActor, tenantAlice, aliceExtID)
+	assert.Nil(t, err)
+	assert.Equal(t, cID, archivedID)
+	saved, _ := m.GetCenter(tenantAlice, cID)
+	assert.False(t, saved.IsEnabled)
+
+	// archiving again is a no-op and is not recorded
+	_, err = m.ArchiveCenterByExtID(testActor, tenantAlice, aliceExtID)
+	assert.Nil(t, err)
+
+	restoredID, err := m.RestoreCenterByExtID(testActor, tenantAlice, aliceExtID)
+	assert.Nil(t, err)
+	assert.Equal(t, cID, restoredID)
+	saved, _ = m.GetCenter(tenantAlice, cID)
+	assert.True(t, saved.IsEnabled)
+
+	assert.Equal(t, 3, len(rec.logs))
+	assert.Equal(t, "IsEnabled", rec.logs[1].Changes[0].Field)
+	assert.JSONEq(t, `false`, string(rec.logs[1].Changes[0].After))
+
+	_, err = m.ArchiveCenterByExtID(testActor, tenantBob, aliceExtID)
 	assert.Equal(t, glad.ErrNotFound, err)
 }
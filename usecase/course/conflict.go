@@ -0,0 +1,255 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package course
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// ListConflicts lists the sessions overlapping for a teacher, an organizer or
+// a center; those of the course, or among the courses of the account or the
+// center between the dates
+func (s *Service) ListConflicts(tenantID id.ID,
+	q *entity.CourseConflictQuery,
+) ([]*entity.CourseConflict, error) {
+	err := q.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if q.CourseID != id.IDInvalid {
+		cs, err := s.getCourseSchedule(tenantID, q.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		return scheduleConflicts(s.cRepo, cs)
+	}
+
+	var accountIDs []id.ID
+	if q.AccountID != id.IDInvalid {
+		accountIDs = append(accountIDs, q.AccountID)
+	}
+	schedules, err := s.cRepo.ListCourseSchedules(tenantID, id.IDInvalid, accountIDs, q.CenterID,
+		q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []*entity.CourseConflict
+	for i, cs := range schedules {
+		conflicts = append(conflicts, findConflicts(cs, schedules[i+1:])...)
+	}
+	return conflicts, nil
+}
+
+// getCourseSchedule gets the course with its timings and roster
+func (s *Service) getCourseSchedule(tenantID id.ID, courseID id.ID) (*entity.CourseSchedule, error) {
+	course, err := s.cRepo.Get(tenantID, courseID)
+	if err != nil && err != glad.ErrNotFound {
+		return nil, err
+	}
+	if course == nil {
+		return nil, glad.ErrNotFound
+	}
+
+	timings, err := s.ctRepo.GetByCourse(courseID)
+	if err != nil {
+		return nil, err
+	}
+	cts, err := s.cRepo.GetCourseTeacher(courseID)
+	if err != nil {
+		return nil, err
+	}
+	cos, err := s.cRepo.GetCourseOrganizer(courseID)
+	if err != nil {
+		return nil, err
+	}
+	return newCourseSchedule(course, timings, cts, cos), nil
+}
+
+// newCourseSchedule builds the schedule of the course
+func newCourseSchedule(course *entity.Course,
+	timings []*entity.CourseTiming,
+	cts []*entity.CourseTeacher,
+	cos []*entity.CourseOrganizer,
+) *entity.CourseSchedule {
+	cs := &entity.CourseSchedule{
+		Course:  course,
+		Timings: timings,
+	}
+	for _, ct := range cts {
+		cs.TeacherIDs = append(cs.TeacherIDs, ct.ID)
+	}
+	for _, co := range cos {
+		cs.OrganizerIDs = append(cs.OrganizerIDs, co.ID)
+	}
+	return cs
+}
+
+// checkConflicts fails with entity.ConflictError when the schedule overlaps
+// the schedule of other courses
+// Note: cRepo is bound to the transaction saving the schedule; the schedules
+// of its teachers, organizers and center are locked until it ends, so that
+// concurrent changes cannot book them twice.
+func checkConflicts(cRepo CourseRepository, cs *entity.CourseSchedule) error {
+	if !cs.Course.Status.IsScheduled() {
+		return nil
+	}
+
+	accountIDs, centerID := scheduleResources(cs)
+	err := cRepo.LockSchedules(accountIDs, centerID)
+	if err != nil {
+		return err
+	}
+
+	conflicts, err := scheduleConflicts(cRepo, cs)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &entity.ConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// scheduleResources gets the accounts and the center booked by the schedule;
+// id.IDInvalid center unless the course is held in person
+func scheduleResources(cs *entity.CourseSchedule) ([]id.ID, id.ID) {
+	accountIDs := append(append([]id.ID{}, cs.TeacherIDs...), cs.OrganizerIDs...)
+	centerID := id.ID(id.IDInvalid)
+	if cs.Course.Mode == entity.CourseInPerson {
+		centerID = cs.Course.CenterID
+	}
+	return accountIDs, centerID
+}
+
+// scheduleConflicts finds the sessions of the other courses overlapping the
+// schedule
+func scheduleConflicts(r CourseScheduleReader, cs *entity.CourseSchedule) ([]*entity.CourseConflict, error) {
+	sessions := cs.Sessions()
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+
+	accountIDs, centerID := scheduleResources(cs)
+	if len(accountIDs) == 0 && centerID == id.IDInvalid {
+		return nil, nil
+	}
+
+	// the dates of the other courses are in their own timezones; a day on
+	// each side covers any offset
+	first, last := sessions[0].Start, sessions[0].End
+	for _, session := range sessions[1:] {
+		if session.Start.Before(first) {
+			first = session.Start
+		}
+		if session.End.After(last) {
+			last = session.End
+		}
+	}
+	from := first.UTC().AddDate(0, 0, -1).Format(entity.CourseDateFormat)
+	to := last.UTC().AddDate(0, 0, 1).Format(entity.CourseDateFormat)
+
+	others, err := r.ListCourseSchedules(cs.Course.TenantID, cs.Course.ID, accountIDs, centerID,
+		from, to)
+	if err != nil {
+		return nil, err
+	}
+	return findConflicts(cs, others), nil
+}
+
+// findConflicts finds the sessions of the schedule overlapping the sessions
+// of the other schedules, for the teachers and organizers booked by both and
+// for the center when both courses are held there in person and jointly
+// exceed its capacity
+func findConflicts(cs *entity.CourseSchedule, others []*entity.CourseSchedule) []*entity.CourseConflict {
+	roles := map[id.ID]entity.CourseConflictKind{}
+	for _, organizerID := range cs.OrganizerIDs {
+		roles[organizerID] = entity.ConflictOrganizer
+	}
+	for _, teacherID := range cs.TeacherIDs {
+		roles[teacherID] = entity.ConflictTeacher
+	}
+	sessions := cs.Sessions()
+
+	var conflicts []*entity.CourseConflict
+	for _, other := range others {
+		if other.Course.ID == cs.Course.ID {
+			continue
+		}
+
+		var shared []id.ID
+		seen := map[id.ID]bool{}
+		for _, accountID := range append(append([]id.ID{}, other.TeacherIDs...), other.OrganizerIDs...) {
+			if _, ok := roles[accountID]; ok && !seen[accountID] {
+				seen[accountID] = true
+				shared = append(shared, accountID)
+			}
+		}
+
+		attendees := cs.Course.MaxAttendees + other.Course.MaxAttendees
+		overCapacity := cs.Course.Mode == entity.CourseInPerson &&
+			other.Course.Mode == entity.CourseInPerson &&
+			cs.Course.CenterID == other.Course.CenterID &&
+			other.CenterCapacity > 0 && attendees > other.CenterCapacity
+		if len(shared) == 0 && !overCapacity {
+			continue
+		}
+
+		for _, session := range sessions {
+			for _, with := range other.Sessions() {
+				if !session.Overlaps(with) {
+					continue
+				}
+				for _, accountID := range shared {
+					conflicts = append(conflicts, &entity.CourseConflict{
+						Kind:      roles[accountID],
+						AccountID: accountID,
+						Session:   *session,
+						With:      *with,
+					})
+				}
+				if overCapacity {
+					conflicts = append(conflicts, &entity.CourseConflict{
+						Kind:      entity.ConflictCenter,
+						CenterID:  cs.Course.CenterID,
+						Session:   *session,
+						With:      *with,
+						Attendees: attendees,
+						Capacity:  other.CenterCapacity,
+					})
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// mergeTimings overlays the updated timings on the timings of the course
+func mergeTimings(timings []*entity.CourseTiming, updated []*entity.CourseTiming) []*entity.CourseTiming {
+	byID := map[id.ID]*entity.CourseTiming{}
+	for _, ct := range updated {
+		byID[ct.ID] = ct
+	}
+
+	merged := make([]*entity.CourseTiming, 0, len(timings))
+	for _, ct := range timings {
+		if u, ok := byID[ct.ID]; ok {
+			ct = u
+			delete(byID, ct.ID)
+		}
+		merged = append(merged, ct)
+	}
+	for _, ct := range updated {
+		if _, ok := byID[ct.ID]; ok {
+			merged = append(merged, ct)
+		}
+	}
+	return merged
+}
@@ -7,6 +7,7 @@
 package course
 
 import (
+	"sort"
 	"strings"
 	"sync"
 
@@ -17,19 +18,94 @@ import (
 
 // inmemCourse in memory repo
 type inmemCourse struct {
-	m   map[id.ID]*entity.Course
-	mut *sync.RWMutex
+	m       map[id.ID]*entity.Course
+	centers map[id.ID]entity.CenterGeoLocation
+	history []*entity.CourseStatusChange
+	rosters map[string]*inmemRoster
+	// timezones timezone by center
+	timezones map[id.ID]string
+	// recurrences recurrence rule by course
+	recurrences map[id.ID]*entity.CourseRecurrence
+	// eligibility teaching eligibility by product and teacher
+	eligibility map[id.ID]map[id.ID]entity.EligibilityType
+	// products tenant by product, of the products with an eligibility
+	products map[id.ID]id.ID
+	// schedules courses with their timings and rosters, for the conflicts
+	schedules []*entity.CourseSchedule
+	// changes outbox of the export
+	changes []*entity.AuditLog
+	mut     *sync.RWMutex
+}
+
+// inmemRoster teacher or organizer entry imported from Salesforce
+type inmemRoster struct {
+	courseID  id.ID
+	accountID id.ID
+	isTeacher bool
+	isPrimary bool
 }
 
 // newinmemCourse create new repository
 func newInmemCourse() *inmemCourse {
 	var m = map[id.ID]*entity.Course{}
 	return &inmemCourse{
-		m:   m,
-		mut: &sync.RWMutex{},
+		m:       m,
+		centers: map[id.ID]entity.CenterGeoLocation{},
+		rosters: map[string]*inmemRoster{},
+
+		timezones:   map[id.ID]string{},
+		recurrences: map[id.ID]*entity.CourseRecurrence{},
+		eligibility: map[id.ID]map[id.ID]entity.EligibilityType{},
+		products:    map[id.ID]id.ID{},
+		mut:         &sync.RWMutex{},
 	}
 }
 
+// addEligibility makes the teacher eligible for the product of the tenant
+func (r *inmemCourse) addEligibility(tenantID id.ID,
+	productID id.ID,
+	teacherID id.ID,
+	et entity.EligibilityType,
+) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.products[productID] = tenantID
+	if r.eligibility[productID] == nil {
+		r.eligibility[productID] = map[id.ID]entity.EligibilityType{}
+	}
+	r.eligibility[productID][teacherID] = et
+}
+
+// addSchedule adds a course with its timings and roster; only these courses
+// are returned by ListCourseSchedules
+func (r *inmemCourse) addSchedule(cs *entity.CourseSchedule) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.schedules = append(r.schedules, cs)
+}
+
+// addCenter adds the location of an enabled center; only courses at known
+// centers are returned by Nearby
+func (r *inmemCourse) addCenter(centerID id.ID, geo entity.CenterGeoLocation) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.centers[centerID] = geo
+}
+
+// addCenterTimezone sets the timezone of the center
+func (r *inmemCourse) addCenterTimezone(centerID id.ID, timezone string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.timezones[centerID] = timezone
+}
+
+// GetCenterTimezone gets the timezone of the center; empty when not set
+func (r *inmemCourse) GetCenterTimezone(tenantID id.ID, centerID id.ID) (string, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	return r.timezones[centerID], nil
+}
+
 // Create a course
 func (r *inmemCourse) Create(e *entity.Course) (id.ID, error) {
 	r.mut.Lock()
@@ -39,54 +115,162 @@ func (r *inmemCourse) Create(e *entity.Course) (id.ID, error) {
 }
 
 // Get a course
-func (r *inmemCourse) Get(courseID id.ID) (*entity.Course, error) {
+func (r *inmemCourse) Get(tenantID id.ID, courseID id.ID) (*entity.Course, error) {
 	r.mut.RLock()
 	defer r.mut.RUnlock()
-	if course, ok := r.m[courseID]; ok {
+	if course, ok := r.m[courseID]; ok && course.TenantID == tenantID {
 		return course, nil
 	}
 	return nil, glad.ErrNotFound
 }
 
+// GetByExtID gets a course by its Salesforce id
+func (r *inmemCourse) GetByExtID(tenantID id.ID, extID string) (*entity.Course, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	for _, course := range r.m {
+		if course.TenantID == tenantID && course.ExtID != nil && *course.ExtID == extID {
+			return course, nil
+		}
+	}
+	return nil, glad.ErrNotFound
+}
+
 // Update a course
 func (r *inmemCourse) Update(e *entity.Course) error {
 	r.mut.Lock()
 	defer r.mut.Unlock()
-	_, ok := r.m[e.ID]
-	if !ok {
+	course, ok := r.m[e.ID]
+	if !ok || course.TenantID != e.TenantID {
 		return glad.ErrNotFound
 	}
-	r.m[e.ID] = e
+	e.Status = course.Status
+
+	// as the pgsql repo, the status, the seats, the Salesforce id and links
+	// are left as is
+	updated := *course
+	updated.CenterID = e.CenterID
+	updated.Name = e.Name
+	updated.Notes = e.Notes
+	updated.Timezone = e.Timezone
+	updated.Address = e.Address
+	updated.Mode = e.Mode
+	updated.MaxAttendees = e.MaxAttendees
+	updated.UpdatedAt = e.UpdatedAt
+	updated.ProductID = e.ProductID
+	updated.Recurrence = e.Recurrence
+	r.m[e.ID] = &updated
+	return nil
+}
+
+// SetExtID links the course to its Salesforce record
+func (r *inmemCourse) SetExtID(tenantID id.ID, courseID id.ID, extID string) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	course, ok := r.m[courseID]
+	if !ok || course.TenantID != tenantID {
+		return glad.ErrNotFound
+	}
+	updated := *course
+	updated.ExtID = &extID
+	r.m[courseID] = &updated
 	return nil
 }
 
+// UpdateStatus changes the course status if it is still in the from status
+func (r *inmemCourse) UpdateStatus(tenantID id.ID,
+	courseID id.ID,
+	from entity.CourseStatus,
+	to entity.CourseStatus,
+) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	course, ok := r.m[courseID]
+	if !ok || course.TenantID != tenantID || course.Status != from {
+		return glad.ErrNotFound
+	}
+	// copy so that the unit of work snapshot keeps the old status
+	updated := *course
+	updated.Status = to
+	r.m[courseID] = &updated
+	return nil
+}
+
+// InsertStatusChange records a lifecycle transition
+func (r *inmemCourse) InsertStatusChange(e *entity.CourseStatusChange) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.history = append(r.history, e)
+	return nil
+}
+
+// ListStatusChanges lists the lifecycle transitions of a course
+func (r *inmemCourse) ListStatusChanges(tenantID id.ID,
+	courseID id.ID,
+) ([]*entity.CourseStatusChange, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	var changes []*entity.CourseStatusChange
+	for _, e := range r.history {
+		if e.TenantID == tenantID && e.CourseID == courseID {
+			changes = append(changes, e)
+		}
+	}
+	return changes, nil
+}
+
 // Search courses
+// Note: roster and timings are not kept in memory, hence the teacher and date
+// filters are not applied and startDate sorts by creation time.
 func (r *inmemCourse) Search(tenantID id.ID,
-	query string, page, limit int,
-) ([]*entity.Course, error) {
+	f *entity.CourseFilter, page, limit int,
+) (int, []*entity.Course, error) {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 	var courses []*entity.Course
 	for _, course := range r.m {
-		if course.TenantID == tenantID &&
-			strings.Contains(strings.ToLower(course.Name), query) {
-			courses = append(courses, course)
+		if course.TenantID != tenantID ||
+			!strings.Contains(strings.ToLower(course.Name), strings.ToLower(f.Query)) ||
+			(f.Status != "" && course.Status != f.Status) ||
+			(f.Mode != "" && course.Mode != f.Mode) ||
+			(f.ProductID != id.IDInvalid && course.ProductID != f.ProductID) ||
+			(f.CenterID != id.IDInvalid && course.CenterID != f.CenterID) ||
+			(f.City != "" && !strings.EqualFold(course.Address.City, f.City)) ||
+			(f.State != "" && !strings.EqualFold(course.Address.State, f.State)) {
+			continue
 		}
+		courses = append(courses, course)
 	}
 
+	sort.Slice(courses, func(i, j int) bool {
+		a, b := courses[i], courses[j]
+		if f.SortDesc {
+			a, b = b, a
+		}
+		switch f.SortBy {
+		case entity.CourseSortName:
+			return a.Name < b.Name
+		case entity.CourseSortUpdatedAt:
+			return a.UpdatedAt.Before(b.UpdatedAt)
+		default:
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	})
+
+	count := len(courses)
 	if page > 0 && limit > 0 {
 		start := (page - 1) * limit
 		end := start + limit
 		if start > len(courses) {
-			return []*entity.Course{}, nil
+			return count, []*entity.Course{}, nil
 		}
 		if end > len(courses) {
 			end = len(courses)
 		}
-		return courses[start:end], nil
+		return count, courses[start:end], nil
 	}
 
-	return courses, nil
+	return count, courses, nil
 }
 
 // List courses
@@ -102,11 +286,63 @@ func (r *inmemCourse) List(tenantID id.ID, page, limit int) ([]*entity.Course, e
 	return courses, nil
 }
 
+// Nearby lists in-person courses at known centers within the query radius
+// Note: timings are not kept in memory, hence the from date is not applied.
+func (r *inmemCourse) Nearby(tenantID id.ID,
+	q *entity.GeoQuery,
+	from string,
+	page, limit int,
+) ([]*entity.CourseNearby, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	box := q.BoundingBox()
+	var courses []*entity.CourseNearby
+	for _, course := range r.m {
+		geo, ok := r.centers[course.CenterID]
+		if !ok || course.TenantID != tenantID || course.Mode != entity.CourseInPerson ||
+			course.Status == entity.CourseDraft ||
+			course.Status == entity.CourseCanceled ||
+			course.Status == entity.CourseArchived ||
+			!box.Contains(geo) {
+			continue
+		}
+		distance := q.DistanceKm(geo)
+		if distance > q.RadiusKm {
+			continue
+		}
+		courses = append(courses, &entity.CourseNearby{
+			CourseFull: &entity.CourseFull{Course: course},
+			DistanceKm: distance,
+		})
+	}
+
+	sort.Slice(courses, func(i, j int) bool {
+		if courses[i].DistanceKm != courses[j].DistanceKm {
+			return courses[i].DistanceKm < courses[j].DistanceKm
+		}
+		return courses[i].Course.ID < courses[j].Course.ID
+	})
+
+	if page > 0 && limit > 0 {
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(courses) {
+			return []*entity.CourseNearby{}, nil
+		}
+		if end > len(courses) {
+			end = len(courses)
+		}
+		return courses[start:end], nil
+	}
+
+	return courses, nil
+}
+
 // Delete a course
-func (r *inmemCourse) Delete(courseID id.ID) error {
+func (r *inmemCourse) Delete(tenantID id.ID, courseID id.ID) error {
 	r.mut.Lock()
 	defer r.mut.Unlock()
-	if _, ok := r.m[courseID]; ok {
+	if course, ok := r.m[courseID]; ok && course.TenantID == tenantID {
 
 		r.m[courseID] = nil
 		delete(r.m, courseID)
@@ -140,7 +376,7 @@ func (r *inmemCourse) Upsert(e *entity.Course) (id.ID, error) {
 	defer r.mut.Unlock()
 
 	for _, course := range r.m {
-		if course.ExtID == e.ExtID {
+		if course.ExtID != nil && e.ExtID != nil && *course.ExtID == *e.ExtID {
 			e.ID = course.ID
 		}
 	}
@@ -148,6 +384,20 @@ func (r *inmemCourse) Upsert(e *entity.Course) (id.ID, error) {
 	return e.ID, nil
 }
 
+// BulkUpsert upserts courses in memory
+func (r *inmemCourse) BulkUpsert(tenantID id.ID, courses []*entity.Course) (map[string]id.ID, error) {
+	ids := make(map[string]id.ID, len(courses))
+	for _, c := range courses {
+		c.TenantID = tenantID
+		courseID, err := r.Upsert(c)
+		if err != nil {
+			return nil, err
+		}
+		ids[*c.ExtID] = courseID
+	}
+	return ids, nil
+}
+
 // --------------------------------------------------------------------------------
 // Course Organizer
 // --------------------------------------------------------------------------------
@@ -228,6 +478,74 @@ func (r *inmemCourse) MultiGetCourseTeacher(courseIDList []id.ID,
 	return nil, nil
 }
 
+// --------------------------------------------------------------------------------
+// Course Roster imported from Salesforce
+// --------------------------------------------------------------------------------
+// UpsertCourseTeacherByExtID links the teacher to the course
+func (r *inmemCourse) UpsertCourseTeacherByExtID(tenantID id.ID,
+	courseID id.ID,
+	extID string,
+	ct *entity.CourseTeacher,
+) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	if !r.rosterOfTenant(tenantID, extID) {
+		return glad.ErrAlreadyExists
+	}
+	r.rosters[extID] = &inmemRoster{
+		courseID:  courseID,
+		accountID: ct.ID,
+		isTeacher: true,
+		isPrimary: ct.IsPrimary,
+	}
+	return nil
+}
+
+// UpsertCourseOrganizerByExtID links the organizer to the course
+func (r *inmemCourse) UpsertCourseOrganizerByExtID(tenantID id.ID,
+	courseID id.ID,
+	extID string,
+	co *entity.CourseOrganizer,
+) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	if !r.rosterOfTenant(tenantID, extID) {
+		return glad.ErrAlreadyExists
+	}
+	r.rosters[extID] = &inmemRoster{
+		courseID:  courseID,
+		accountID: co.ID,
+	}
+	return nil
+}
+
+// rosterOfTenant tells whether the entry linked to the external id, if any,
+// is on a course of the tenant. The external ids are unique across tenants.
+func (r *inmemCourse) rosterOfTenant(tenantID id.ID, extID string) bool {
+	roster, ok := r.rosters[extID]
+	if !ok {
+		return true
+	}
+	course, ok := r.m[roster.courseID]
+	return ok && course.TenantID == tenantID
+}
+
+// DeleteCourseRosterByExtID removes the teacher or organizer entry
+func (r *inmemCourse) DeleteCourseRosterByExtID(tenantID id.ID, extID string) (id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	roster, ok := r.rosters[extID]
+	if !ok {
+		return id.IDInvalid, nil
+	}
+	course, ok := r.m[roster.courseID]
+	if !ok || course.TenantID != tenantID {
+		return id.IDInvalid, nil
+	}
+	delete(r.rosters, extID)
+	return roster.courseID, nil
+}
+
 // --------------------------------------------------------------------------------
 // Course Contact
 // --------------------------------------------------------------------------------
@@ -307,3 +625,103 @@ func (r *inmemCourse) MultiGetCourseNotify(courseIDList []id.ID,
 	// TODO
 	return nil, nil
 }
+
+// GetTeacherEligibility gets the eligibility of the teachers for the product of
+// the tenant
+func (r *inmemCourse) GetTeacherEligibility(tenantID id.ID,
+	productID id.ID,
+	teacherIDs []id.ID,
+) (map[id.ID]entity.EligibilityType, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	eligibility := map[id.ID]entity.EligibilityType{}
+	if r.products[productID] != tenantID {
+		return eligibility, nil
+	}
+	for _, teacherID := range teacherIDs {
+		if et, ok := r.eligibility[productID][teacherID]; ok {
+			eligibility[teacherID] = et
+		}
+	}
+	return eligibility, nil
+}
+
+// --------------------------------------------------------------------------------
+// Course Schedule
+// --------------------------------------------------------------------------------
+// LockSchedules locks the schedules of the accounts and the center
+// Note: No-op; the in memory repo is not shared by concurrent units of work.
+func (r *inmemCourse) LockSchedules(accountIDs []id.ID, centerID id.ID) error {
+	return nil
+}
+
+// ListCourseSchedules lists the scheduled courses booking the accounts or the
+// center, with their timings between the dates
+func (r *inmemCourse) ListCourseSchedules(tenantID id.ID,
+	courseID id.ID,
+	accountIDs []id.ID,
+	centerID id.ID,
+	from, to string,
+) ([]*entity.CourseSchedule, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	books := func(cs *entity.CourseSchedule) bool {
+		if centerID != id.IDInvalid && cs.Course.CenterID == centerID {
+			return true
+		}
+		for _, accountID := range accountIDs {
+			for _, bookedID := range append(append([]id.ID{}, cs.TeacherIDs...), cs.OrganizerIDs...) {
+				if bookedID == accountID {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	var schedules []*entity.CourseSchedule
+	for _, cs := range r.schedules {
+		if cs.Course.TenantID != tenantID || cs.Course.ID == courseID ||
+			!cs.Course.Status.IsScheduled() || !books(cs) {
+			continue
+		}
+
+		var timings []*entity.CourseTiming
+		for _, ct := range cs.Timings {
+			if ct.DateTime.Date >= from && ct.DateTime.Date <= to {
+				timings = append(timings, ct)
+			}
+		}
+		if len(timings) == 0 {
+			continue
+		}
+		schedule := *cs
+		schedule.Timings = timings
+		schedules = append(schedules, &schedule)
+	}
+	return schedules, nil
+}
+
+// GetCourseRecurrence gets the rule of the course; nil when there is none
+func (r *inmemCourse) GetCourseRecurrence(courseID id.ID) (*entity.CourseRecurrence, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	return r.recurrences[courseID], nil
+}
+
+// SetCourseRecurrence sets or replaces the rule of the course
+func (r *inmemCourse) SetCourseRecurrence(courseID id.ID, rec *entity.CourseRecurrence) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.recurrences[courseID] = rec
+	return nil
+}
+
+// QueueChange queues the change for the export
+func (r *inmemCourse) QueueChange(e *entity.AuditLog) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.changes = append(r.changes, e)
+	return nil
+}
@@ -42,6 +42,17 @@ func (r *inmemCourseTiming) Get(id id.ID) (*entity.CourseTiming, error) {
 	return r.m[id], nil
 }
 
+// GetByExtID gets a course timing by its Salesforce id
+// Note: Timings do not keep the tenant; it is not checked here.
+func (r *inmemCourseTiming) GetByExtID(tenantID id.ID, extID string) (*entity.CourseTiming, error) {
+	for _, ct := range r.m {
+		if ct.ExtID != nil && *ct.ExtID == extID {
+			return ct, nil
+		}
+	}
+	return nil, glad.ErrNotFound
+}
+
 // Update a course
 func (r *inmemCourseTiming) Update(e *entity.CourseTiming) error {
 	// log.Printf("Update course timing; id=%v, details=%#v", e.ID, e)
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package course
+
+import (
+	"slices"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// inmemUnitOfWork in memory unit of work
+// Snapshots both repositories before fn runs and restores them on error.
+type inmemUnitOfWork struct {
+	cRepo  *inmemCourse
+	ctRepo *inmemCourseTiming
+}
+
+// inmemSnapshot deep copy of the stores written in a unit of work; the
+// stored entities may be changed in place, the pointers are not shared
+type inmemSnapshot struct {
+	courses     map[id.ID]*entity.Course
+	history     []*entity.CourseStatusChange
+	rosters     map[string]*inmemRoster
+	recurrences map[id.ID]*entity.CourseRecurrence
+	changes     []*entity.AuditLog
+	timings     map[id.ID]*entity.CourseTiming
+}
+
+// newInmemUnitOfWork create new unit of work over the given repositories
+func newInmemUnitOfWork(cr *inmemCourse, ctr *inmemCourseTiming) *inmemUnitOfWork {
+	return &inmemUnitOfWork{
+		cRepo:  cr,
+		ctRepo: ctr,
+	}
+}
+
+// Do runs fn and rolls back the in memory state if it fails
+func (u *inmemUnitOfWork) Do(fn func(cRepo CourseRepository, ctRepo CourseTimingRepository) error) error {
+	snapshot := u.snapshot()
+	err := fn(u.cRepo, u.ctRepo)
+	if err != nil {
+		u.restore(snapshot)
+	}
+	return err
+}
+
+func (u *inmemUnitOfWork) snapshot() *inmemSnapshot {
+	u.cRepo.mut.RLock()
+	defer u.cRepo.mut.RUnlock()
+
+	s := &inmemSnapshot{
+		courses:     make(map[id.ID]*entity.Course, len(u.cRepo.m)),
+		history:     make([]*entity.CourseStatusChange, 0, len(u.cRepo.history)),
+		rosters:     make(map[string]*inmemRoster, len(u.cRepo.rosters)),
+		recurrences: make(map[id.ID]*entity.CourseRecurrence, len(u.cRepo.recurrences)),
+		changes:     make([]*entity.AuditLog, 0, len(u.cRepo.changes)),
+		timings:     make(map[id.ID]*entity.CourseTiming, len(u.ctRepo.m)),
+	}
+	for k, v := range u.cRepo.m {
+		s.courses[k] = copyCourse(v)
+	}
+	for _, v := range u.cRepo.history {
+		change := *v
+		s.history = append(s.history, &change)
+	}
+	for k, v := range u.cRepo.rosters {
+		roster := *v
+		s.rosters[k] = &roster
+	}
+	for k, v := range u.cRepo.recurrences {
+		s.recurrences[k] = copyRecurrence(v)
+	}
+	for _, v := range u.cRepo.changes {
+		change := *v
+		change.Before = slices.Clone(v.Before)
+		change.After = slices.Clone(v.After)
+		change.Changes = slices.Clone(v.Changes)
+		s.changes = append(s.changes, &change)
+	}
+	for k, v := range u.ctRepo.m {
+		s.timings[k] = copyCourseTiming(v)
+	}
+	return s
+}
+
+func (u *inmemUnitOfWork) restore(s *inmemSnapshot) {
+	u.cRepo.mut.Lock()
+	defer u.cRepo.mut.Unlock()
+
+	u.cRepo.m = s.courses
+	u.cRepo.history = s.history
+	u.cRepo.rosters = s.rosters
+	u.cRepo.recurrences = s.recurrences
+	u.cRepo.changes = s.changes
+	u.ctRepo.m = s.timings
+}
+
+func copyCourse(c *entity.Course) *entity.Course {
+	if c == nil {
+		// deleted
+		return nil
+	}
+	course := *c
+	if c.ExtID != nil {
+		extID := *c.ExtID
+		course.ExtID = &extID
+	}
+	course.Recurrence = copyRecurrence(c.Recurrence)
+	return &course
+}
+
+func copyRecurrence(r *entity.CourseRecurrence) *entity.CourseRecurrence {
+	if r == nil {
+		return nil
+	}
+	recurrence := *r
+	recurrence.ExDates = slices.Clone(r.ExDates)
+	return &recurrence
+}
+
+func copyCourseTiming(t *entity.CourseTiming) *entity.CourseTiming {
+	if t == nil {
+		return nil
+	}
+	timing := *t
+	if t.ExtID != nil {
+		extID := *t.ExtID
+		timing.ExtID = &extID
+	}
+	return &timing
+}
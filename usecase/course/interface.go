@@ -13,22 +13,34 @@ import (
 
 // CourseReader course reader
 type CourseReader interface {
-	Get(id id.ID) (*entity.Course, error)
-	Search(tenantID id.ID, query string, page, limit int) ([]*entity.Course, error)
+	Get(tenantID id.ID, courseID id.ID) (*entity.Course, error)
+	GetByExtID(tenantID id.ID, extID string) (*entity.Course, error)
+	Search(tenantID id.ID, f *entity.CourseFilter, page, limit int) (int, []*entity.Course, error)
 	List(tenantID id.ID, page, limit int) ([]*entity.Course, error)
 	GetCount(id id.ID) (int, error)
 	GetByAccount(tenantID id.ID,
 		accountID id.ID,
 		page, limit int,
 	) (int, []*entity.Course, error)
+	Nearby(tenantID id.ID,
+		q *entity.GeoQuery,
+		from string,
+		page, limit int,
+	) ([]*entity.CourseNearby, error)
+	// GetCenterTimezone gets the timezone of the center; empty when not set
+	GetCenterTimezone(tenantID id.ID, centerID id.ID) (string, error)
 }
 
 // CourseWriter course writer
 type CourseWriter interface {
 	Create(e *entity.Course) (id.ID, error)
 	Update(e *entity.Course) error
-	Delete(id id.ID) error
+	Delete(tenantID id.ID, courseID id.ID) error
 	Upsert(course *entity.Course) (id.ID, error)
+	// BulkUpsert upserts courses keyed by external id; returns ids by external id
+	BulkUpsert(tenantID id.ID, courses []*entity.Course) (map[string]id.ID, error)
+	// SetExtID links the course to its Salesforce record
+	SetExtID(tenantID id.ID, courseID id.ID, extID string) error
 }
 
 // CourseOrganizerWriter course organizer writer
@@ -87,6 +99,87 @@ type CourseNotifyReader interface {
 	MultiGetCourseNotify([]id.ID) ([][]*entity.CourseNotify, error)
 }
 
+// CourseStatusWriter course lifecycle writer
+type CourseStatusWriter interface {
+	// UpdateStatus changes the status only when the course is still in the from
+	// status. Returns glad.ErrNotFound otherwise.
+	UpdateStatus(tenantID id.ID, courseID id.ID, from entity.CourseStatus, to entity.CourseStatus) error
+	InsertStatusChange(e *entity.CourseStatusChange) error
+}
+
+// CourseOutboxWriter queues the course changes for the export (see audit.Outbox)
+type CourseOutboxWriter interface {
+	QueueChange(e *entity.AuditLog) error
+}
+
+// CourseRosterWriter teachers and organizers imported from Salesforce, keyed
+// by the Salesforce id of the junction record
+type CourseRosterWriter interface {
+	// UpsertCourseTeacherByExtID links the teacher to the tenant's course. The
+	// entry of the teacher set in GLAD is taken over; an entry of the tenant
+	// linked to the external id for another course or teacher is replaced.
+	UpsertCourseTeacherByExtID(tenantID id.ID, courseID id.ID, extID string,
+		ct *entity.CourseTeacher) error
+	// UpsertCourseOrganizerByExtID links the organizer to the course, as
+	// UpsertCourseTeacherByExtID
+	UpsertCourseOrganizerByExtID(tenantID id.ID, courseID id.ID, extID string,
+		co *entity.CourseOrganizer) error
+	// DeleteCourseRosterByExtID removes the teacher or organizer entry of the
+	// tenant and returns its course id; id.IDInvalid when there is none
+	DeleteCourseRosterByExtID(tenantID id.ID, extID string) (id.ID, error)
+}
+
+// CourseEligibilityReader teaching eligibility of the course teachers
+type CourseEligibilityReader interface {
+	// GetTeacherEligibility gets the eligibility of the teachers for the
+	// product of the tenant by teacher id; teachers who are not eligible are
+	// left out, as are all of them when the product is not the tenant's
+	GetTeacherEligibility(tenantID id.ID,
+		productID id.ID,
+		teacherIDs []id.ID,
+	) (map[id.ID]entity.EligibilityType, error)
+}
+
+// CourseScheduleReader schedules of the courses competing for the same
+// teachers, organizers or center
+type CourseScheduleReader interface {
+	// ListCourseSchedules lists the scheduled courses of the tenant, other than
+	// the course, taught or organized by any of the accounts or held at the
+	// center, with their timings between the dates (YYYY-MM-DD, inclusive).
+	// Courses without a timing in the range are left out.
+	ListCourseSchedules(tenantID id.ID,
+		courseID id.ID,
+		accountIDs []id.ID,
+		centerID id.ID,
+		from, to string,
+	) ([]*entity.CourseSchedule, error)
+}
+
+// CourseScheduleLocker serializes the scheduling of the courses competing for
+// the same teachers, organizers or center
+type CourseScheduleLocker interface {
+	// LockSchedules locks the schedules of the accounts and the center until
+	// the end of the transaction; id.IDInvalid center is not locked
+	LockSchedules(accountIDs []id.ID, centerID id.ID) error
+}
+
+// CourseRecurrenceReader recurrence rule of the course sessions
+type CourseRecurrenceReader interface {
+	// GetCourseRecurrence gets the rule of the course; nil when there is none
+	GetCourseRecurrence(courseID id.ID) (*entity.CourseRecurrence, error)
+}
+
+// CourseRecurrenceWriter recurrence rule of the course sessions
+type CourseRecurrenceWriter interface {
+	// SetCourseRecurrence sets or replaces the rule of the course
+	SetCourseRecurrence(courseID id.ID, r *entity.CourseRecurrence) error
+}
+
+// CourseStatusReader course lifecycle reader
+type CourseStatusReader interface {
+	ListStatusChanges(tenantID id.ID, courseID id.ID) ([]*entity.CourseStatusChange, error)
+}
+
 // Course repository interface
 type CourseRepository interface {
 	CourseReader
@@ -99,11 +192,22 @@ type CourseRepository interface {
 	CourseContactReader
 	CourseNotifyWriter
 	CourseNotifyReader
+	CourseStatusWriter
+	CourseStatusReader
+	CourseRosterWriter
+	CourseEligibilityReader
+	CourseScheduleReader
+	CourseScheduleLocker
+	CourseRecurrenceReader
+	CourseRecurrenceWriter
+	CourseOutboxWriter
 }
 
 // CourseTimingReader course timing reader
 type CourseTimingReader interface {
 	Get(id id.ID) (*entity.CourseTiming, error)
+	// GetByExtID gets the timing of a course of the tenant by its Salesforce id
+	GetByExtID(tenantID id.ID, extID string) (*entity.CourseTiming, error)
 	GetByCourse(courseID id.ID) ([]*entity.CourseTiming, error)
 	GetCount() (int, error)
 	MultiGetCourseTiming(courseIDList []id.ID) ([][]*entity.CourseTiming, error)
@@ -122,12 +226,26 @@ type CourseTimingRepository interface {
 	CourseTimingWriter
 }
 
+// UnitOfWork runs a set of course and course timing writes atomically. fn
+// must use the repositories it is given, not the ones held by the caller.
+type UnitOfWork interface {
+	Do(fn func(cRepo CourseRepository, ctRepo CourseTimingRepository) error) error
+}
+
 // UseCase interface
 type UseCase interface {
-	GetCourse(id id.ID) (*entity.CourseFull, error)
-	SearchCourses(tenantID id.ID, query string, page, limit int) ([]*entity.Course, error)
+	GetCourse(tenantID id.ID, courseID id.ID) (*entity.CourseFull, error)
+	SearchCourses(tenantID id.ID,
+		f *entity.CourseFilter,
+		page, limit int,
+	) (int, []*entity.CourseFull, error)
 	ListCourses(tenantID id.ID, page, limit int) ([]*entity.Course, error)
+	GetNearbyCourses(tenantID id.ID,
+		q *entity.GeoQuery,
+		page, limit int,
+	) ([]*entity.CourseNearby, error)
 	CreateCourse(
+		actor entity.AuditActor,
 		course entity.Course,
 		cos []*entity.CourseOrganizer,
 		cts []*entity.CourseTeacher,
@@ -136,6 +254,7 @@ type UseCase interface {
 		courseTimings []*entity.CourseTiming,
 	) (id.ID, []id.ID, error)
 	UpdateCourse(
+		actor entity.AuditActor,
 		course entity.Course,
 		cos []*entity.CourseOrganizer,
 		cts []*entity.CourseTeacher,
@@ -148,7 +267,32 @@ type UseCase interface {
 		accountID id.ID,
 		page, limit int,
 	) (int, []*entity.CourseFull, error)
-	DeleteCourse(id id.ID) error
+	DeleteCourse(actor entity.AuditActor, tenantID id.ID, courseID id.ID) error
+	TransitionCourse(actor entity.AuditActor,
+		tenantID id.ID,
+		courseID id.ID,
+		action entity.CourseAction,
+	) (*entity.CourseStatusChange, error)
+	ListCourseTransitions(tenantID id.ID, courseID id.ID) ([]*entity.CourseStatusChange, error)
 	GetCount(id id.ID) int
-	UpsertCourse(course *entity.Course) (id.ID, error)
+	UpsertCourse(actor entity.AuditActor, course *entity.Course) (id.ID, error)
+	UpsertCourses(actor entity.AuditActor, tenantID id.ID, courses []*entity.Course) ([]id.ID, error)
+	SetCourseExtID(actor entity.AuditActor, tenantID id.ID, courseID id.ID, extID string) error
+	ArchiveCourseByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error)
+	RestoreCourseByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error)
+	GetCourseByExtID(tenantID id.ID, extID string) (*entity.Course, error)
+	// UpsertCourseTiming upserts the timing imported from Salesforce by its
+	// external id; returns the timing id
+	UpsertCourseTiming(tenantID id.ID, ct *entity.CourseTiming) (id.ID, error)
+	// RemoveCourseTimingByExtID removes the timing deleted in Salesforce and
+	// returns its id
+	RemoveCourseTimingByExtID(tenantID id.ID, extID string) (id.ID, error)
+	UpsertCourseTeacherByExtID(tenantID id.ID, courseID id.ID, extID string, ct *entity.CourseTeacher) error
+	UpsertCourseOrganizerByExtID(tenantID id.ID, courseID id.ID, extID string, co *entity.CourseOrganizer) error
+	// RemoveCourseRosterByExtID removes the teacher or organizer deleted in
+	// Salesforce and returns the course id
+	RemoveCourseRosterByExtID(tenantID id.ID, extID string) (id.ID, error)
+	// ListConflicts lists the sessions overlapping for a teacher, an organizer
+	// or a center
+	ListConflicts(tenantID id.ID, q *entity.CourseConflictQuery) ([]*entity.CourseConflict, error)
 }
@@ -7,6 +7,7 @@ package mock_course
 import (
 	entity "ac9/glad/entity"
 	id "ac9/glad/pkg/id"
+	course "ac9/glad/usecase/course"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -36,18 +37,18 @@ func (m *MockCourseReader) EXPECT() *MockCourseReaderMockRecorder {
 }
 
 // Get mocks base method.
-func (m *MockCourseReader) Get(id id.ID) (*entity.Course, error) {
+func (m *MockCourseReader) Get(tenantID, courseID id.ID) (*entity.Course, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, courseID)
 	ret0, _ := ret[0].(*entity.Course)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockCourseReaderMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockCourseReaderMockRecorder) Get(tenantID, courseID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCourseReader)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCourseReader)(nil).Get), tenantID, courseID)
 }
 
 // GetByAccount mocks base method.
@@ -66,6 +67,36 @@ func (mr *MockCourseReaderMockRecorder) GetByAccount(tenantID, accountID, page,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAccount", reflect.TypeOf((*MockCourseReader)(nil).GetByAccount), tenantID, accountID, page, limit)
 }
 
+// GetByExtID mocks base method.
+func (m *MockCourseReader) GetByExtID(tenantID id.ID, extID string) (*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", tenantID, extID)
+	ret0, _ := ret[0].(*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockCourseReaderMockRecorder) GetByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockCourseReader)(nil).GetByExtID), tenantID, extID)
+}
+
+// GetCenterTimezone mocks base method.
+func (m *MockCourseReader) GetCenterTimezone(tenantID, centerID id.ID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCenterTimezone", tenantID, centerID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCenterTimezone indicates an expected call of GetCenterTimezone.
+func (mr *MockCourseReaderMockRecorder) GetCenterTimezone(tenantID, centerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCenterTimezone", reflect.TypeOf((*MockCourseReader)(nil).GetCenterTimezone), tenantID, centerID)
+}
+
 // GetCount mocks base method.
 func (m *MockCourseReader) GetCount(id id.ID) (int, error) {
 	m.ctrl.T.Helper()
@@ -96,19 +127,35 @@ func (mr *MockCourseReaderMockRecorder) List(tenantID, page, limit interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCourseReader)(nil).List), tenantID, page, limit)
 }
 
-// Search mocks base method.
-func (m *MockCourseReader) Search(tenantID id.ID, query string, page, limit int) ([]*entity.Course, error) {
+// Nearby mocks base method.
+func (m *MockCourseReader) Nearby(tenantID id.ID, q *entity.GeoQuery, from string, page, limit int) ([]*entity.CourseNearby, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", tenantID, query, page, limit)
-	ret0, _ := ret[0].([]*entity.Course)
+	ret := m.ctrl.Call(m, "Nearby", tenantID, q, from, page, limit)
+	ret0, _ := ret[0].([]*entity.CourseNearby)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// Nearby indicates an expected call of Nearby.
+func (mr *MockCourseReaderMockRecorder) Nearby(tenantID, q, from, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nearby", reflect.TypeOf((*MockCourseReader)(nil).Nearby), tenantID, q, from, page, limit)
+}
+
+// Search mocks base method.
+func (m *MockCourseReader) Search(tenantID id.ID, f *entity.CourseFilter, page, limit int) (int, []*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", tenantID, f, page, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]*entity.Course)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // Search indicates an expected call of Search.
-func (mr *MockCourseReaderMockRecorder) Search(tenantID, query, page, limit interface{}) *gomock.Call {
+func (mr *MockCourseReaderMockRecorder) Search(tenantID, f, page, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockCourseReader)(nil).Search), tenantID, query, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockCourseReader)(nil).Search), tenantID, f, page, limit)
 }
 
 // MockCourseWriter is a mock of CourseWriter interface.
@@ -134,6 +181,21 @@ func (m *MockCourseWriter) EXPECT() *MockCourseWriterMockRecorder {
 	return m.recorder
 }
 
+// BulkUpsert mocks base method.
+func (m *MockCourseWriter) BulkUpsert(tenantID id.ID, courses []*entity.Course) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsert", tenantID, courses)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpsert indicates an expected call of BulkUpsert.
+func (mr *MockCourseWriterMockRecorder) BulkUpsert(tenantID, courses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockCourseWriter)(nil).BulkUpsert), tenantID, courses)
+}
+
 // Create mocks base method.
 func (m *MockCourseWriter) Create(e *entity.Course) (id.ID, error) {
 	m.ctrl.T.Helper()
@@ -150,17 +212,31 @@ func (mr *MockCourseWriterMockRecorder) Create(e interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockCourseWriter) Delete(id id.ID) error {
+func (m *MockCourseWriter) Delete(tenantID, courseID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, courseID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCourseWriterMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockCourseWriterMockRecorder) Delete(tenantID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCourseWriter)(nil).Delete), tenantID, courseID)
+}
+
+// SetExtID mocks base method.
+func (m *MockCourseWriter) SetExtID(tenantID, courseID id.ID, extID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExtID", tenantID, courseID, extID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExtID indicates an expected call of SetExtID.
+func (mr *MockCourseWriterMockRecorder) SetExtID(tenantID, courseID, extID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCourseWriter)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExtID", reflect.TypeOf((*MockCourseWriter)(nil).SetExtID), tenantID, courseID, extID)
 }
 
 // Update mocks base method.
@@ -720,316 +796,786 @@ func (mr *MockCourseNotifyReaderMockRecorder) MultiGetCourseNotify(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultiGetCourseNotify", reflect.TypeOf((*MockCourseNotifyReader)(nil).MultiGetCourseNotify), arg0)
 }
 
-// MockCourseRepository is a mock of CourseRepository interface.
-type MockCourseRepository struct {
+// MockCourseStatusWriter is a mock of CourseStatusWriter interface.
+type MockCourseStatusWriter struct {
 	ctrl     *gomock.Controller
-	recorder *MockCourseRepositoryMockRecorder
+	recorder *MockCourseStatusWriterMockRecorder
 }
 
-// MockCourseRepositoryMockRecorder is the mock recorder for MockCourseRepository.
-type MockCourseRepositoryMockRecorder struct {
-	mock *MockCourseRepository
+// MockCourseStatusWriterMockRecorder is the mock recorder for MockCourseStatusWriter.
+type MockCourseStatusWriterMockRecorder struct {
+	mock *MockCourseStatusWriter
 }
 
-// NewMockCourseRepository creates a new mock instance.
-func NewMockCourseRepository(ctrl *gomock.Controller) *MockCourseRepository {
-	mock := &MockCourseRepository{ctrl: ctrl}
-	mock.recorder = &MockCourseRepositoryMockRecorder{mock}
+// NewMockCourseStatusWriter creates a new mock instance.
+func NewMockCourseStatusWriter(ctrl *gomock.Controller) *MockCourseStatusWriter {
+	mock := &MockCourseStatusWriter{ctrl: ctrl}
+	mock.recorder = &MockCourseStatusWriterMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockCourseRepository) EXPECT() *MockCourseRepositoryMockRecorder {
+func (m *MockCourseStatusWriter) EXPECT() *MockCourseStatusWriterMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockCourseRepository) Create(e *entity.Course) (id.ID, error) {
+// InsertStatusChange mocks base method.
+func (m *MockCourseStatusWriter) InsertStatusChange(e *entity.CourseStatusChange) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", e)
-	ret0, _ := ret[0].(id.ID)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "InsertStatusChange", e)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockCourseRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
+// InsertStatusChange indicates an expected call of InsertStatusChange.
+func (mr *MockCourseStatusWriterMockRecorder) InsertStatusChange(e interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCourseRepository)(nil).Create), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertStatusChange", reflect.TypeOf((*MockCourseStatusWriter)(nil).InsertStatusChange), e)
 }
 
-// Delete mocks base method.
-func (m *MockCourseRepository) Delete(id id.ID) error {
+// UpdateStatus mocks base method.
+func (m *MockCourseStatusWriter) UpdateStatus(tenantID, courseID id.ID, from, to entity.CourseStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "UpdateStatus", tenantID, courseID, from, to)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockCourseRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockCourseStatusWriterMockRecorder) UpdateStatus(tenantID, courseID, from, to interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCourseRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockCourseStatusWriter)(nil).UpdateStatus), tenantID, courseID, from, to)
 }
 
-// DeleteCourseContact mocks base method.
-func (m *MockCourseRepository) DeleteCourseContact(arg0 id.ID, arg1 []*entity.CourseContact) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourseContact", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+// MockCourseOutboxWriter is a mock of CourseOutboxWriter interface.
+type MockCourseOutboxWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseOutboxWriterMockRecorder
 }
 
-// DeleteCourseContact indicates an expected call of DeleteCourseContact.
-func (mr *MockCourseRepositoryMockRecorder) DeleteCourseContact(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseContact", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseContact), arg0, arg1)
+// MockCourseOutboxWriterMockRecorder is the mock recorder for MockCourseOutboxWriter.
+type MockCourseOutboxWriterMockRecorder struct {
+	mock *MockCourseOutboxWriter
 }
 
-// DeleteCourseContactByCourse mocks base method.
-func (m *MockCourseRepository) DeleteCourseContactByCourse(arg0 id.ID) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourseContactByCourse", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+// NewMockCourseOutboxWriter creates a new mock instance.
+func NewMockCourseOutboxWriter(ctrl *gomock.Controller) *MockCourseOutboxWriter {
+	mock := &MockCourseOutboxWriter{ctrl: ctrl}
+	mock.recorder = &MockCourseOutboxWriterMockRecorder{mock}
+	return mock
 }
 
-// DeleteCourseContactByCourse indicates an expected call of DeleteCourseContactByCourse.
-func (mr *MockCourseRepositoryMockRecorder) DeleteCourseContactByCourse(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseContactByCourse", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseContactByCourse), arg0)
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseOutboxWriter) EXPECT() *MockCourseOutboxWriterMockRecorder {
+	return m.recorder
 }
 
-// DeleteCourseNotify mocks base method.
-func (m *MockCourseRepository) DeleteCourseNotify(arg0 id.ID, arg1 []*entity.CourseNotify) error {
+// QueueChange mocks base method.
+func (m *MockCourseOutboxWriter) QueueChange(e *entity.AuditLog) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourseNotify", arg0, arg1)
+	ret := m.ctrl.Call(m, "QueueChange", e)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteCourseNotify indicates an expected call of DeleteCourseNotify.
-func (mr *MockCourseRepositoryMockRecorder) DeleteCourseNotify(arg0, arg1 interface{}) *gomock.Call {
+// QueueChange indicates an expected call of QueueChange.
+func (mr *MockCourseOutboxWriterMockRecorder) QueueChange(e interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseNotify", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseNotify), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueChange", reflect.TypeOf((*MockCourseOutboxWriter)(nil).QueueChange), e)
 }
 
-// DeleteCourseNotifyByCourse mocks base method.
-func (m *MockCourseRepository) DeleteCourseNotifyByCourse(arg0 id.ID) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourseNotifyByCourse", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+// MockCourseRosterWriter is a mock of CourseRosterWriter interface.
+type MockCourseRosterWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseRosterWriterMockRecorder
 }
 
-// DeleteCourseNotifyByCourse indicates an expected call of DeleteCourseNotifyByCourse.
-func (mr *MockCourseRepositoryMockRecorder) DeleteCourseNotifyByCourse(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseNotifyByCourse", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseNotifyByCourse), arg0)
+// MockCourseRosterWriterMockRecorder is the mock recorder for MockCourseRosterWriter.
+type MockCourseRosterWriterMockRecorder struct {
+	mock *MockCourseRosterWriter
 }
 
-// DeleteCourseOrganizer mocks base method.
-func (m *MockCourseRepository) DeleteCourseOrganizer(arg0 id.ID, arg1 []*entity.CourseOrganizer) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourseOrganizer", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+// NewMockCourseRosterWriter creates a new mock instance.
+func NewMockCourseRosterWriter(ctrl *gomock.Controller) *MockCourseRosterWriter {
+	mock := &MockCourseRosterWriter{ctrl: ctrl}
+	mock.recorder = &MockCourseRosterWriterMockRecorder{mock}
+	return mock
 }
 
-// DeleteCourseOrganizer indicates an expected call of DeleteCourseOrganizer.
-func (mr *MockCourseRepositoryMockRecorder) DeleteCourseOrganizer(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseOrganizer", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseOrganizer), arg0, arg1)
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseRosterWriter) EXPECT() *MockCourseRosterWriterMockRecorder {
+	return m.recorder
 }
 
-// DeleteCourseOrganizerByCourse mocks base method.
-func (m *MockCourseRepository) DeleteCourseOrganizerByCourse(arg0 id.ID) error {
+// DeleteCourseRosterByExtID mocks base method.
+func (m *MockCourseRosterWriter) DeleteCourseRosterByExtID(tenantID id.ID, extID string) (id.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourseOrganizerByCourse", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteCourseRosterByExtID", tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteCourseOrganizerByCourse indicates an expected call of DeleteCourseOrganizerByCourse.
-func (mr *MockCourseRepositoryMockRecorder) DeleteCourseOrganizerByCourse(arg0 interface{}) *gomock.Call {
+// DeleteCourseRosterByExtID indicates an expected call of DeleteCourseRosterByExtID.
+func (mr *MockCourseRosterWriterMockRecorder) DeleteCourseRosterByExtID(tenantID, extID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseOrganizerByCourse", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseOrganizerByCourse), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseRosterByExtID", reflect.TypeOf((*MockCourseRosterWriter)(nil).DeleteCourseRosterByExtID), tenantID, extID)
 }
 
-// DeleteCourseTeacher mocks base method.
-func (m *MockCourseRepository) DeleteCourseTeacher(arg0 id.ID, arg1 []*entity.CourseTeacher) error {
+// UpsertCourseOrganizerByExtID mocks base method.
+func (m *MockCourseRosterWriter) UpsertCourseOrganizerByExtID(tenantID, courseID id.ID, extID string, co *entity.CourseOrganizer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourseTeacher", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpsertCourseOrganizerByExtID", tenantID, courseID, extID, co)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteCourseTeacher indicates an expected call of DeleteCourseTeacher.
-func (mr *MockCourseRepositoryMockRecorder) DeleteCourseTeacher(arg0, arg1 interface{}) *gomock.Call {
+// UpsertCourseOrganizerByExtID indicates an expected call of UpsertCourseOrganizerByExtID.
+func (mr *MockCourseRosterWriterMockRecorder) UpsertCourseOrganizerByExtID(tenantID, courseID, extID, co interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseTeacher", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseTeacher), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCourseOrganizerByExtID", reflect.TypeOf((*MockCourseRosterWriter)(nil).UpsertCourseOrganizerByExtID), tenantID, courseID, extID, co)
 }
 
-// DeleteCourseTeacherByCourse mocks base method.
-func (m *MockCourseRepository) DeleteCourseTeacherByCourse(arg0 id.ID) error {
+// UpsertCourseTeacherByExtID mocks base method.
+func (m *MockCourseRosterWriter) UpsertCourseTeacherByExtID(tenantID, courseID id.ID, extID string, ct *entity.CourseTeacher) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourseTeacherByCourse", arg0)
+	ret := m.ctrl.Call(m, "UpsertCourseTeacherByExtID", tenantID, courseID, extID, ct)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteCourseTeacherByCourse indicates an expected call of DeleteCourseTeacherByCourse.
-func (mr *MockCourseRepositoryMockRecorder) DeleteCourseTeacherByCourse(arg0 interface{}) *gomock.Call {
+// UpsertCourseTeacherByExtID indicates an expected call of UpsertCourseTeacherByExtID.
+func (mr *MockCourseRosterWriterMockRecorder) UpsertCourseTeacherByExtID(tenantID, courseID, extID, ct interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseTeacherByCourse", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseTeacherByCourse), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCourseTeacherByExtID", reflect.TypeOf((*MockCourseRosterWriter)(nil).UpsertCourseTeacherByExtID), tenantID, courseID, extID, ct)
 }
 
-// Get mocks base method.
-func (m *MockCourseRepository) Get(id id.ID) (*entity.Course, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
-	ret0, _ := ret[0].(*entity.Course)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// MockCourseEligibilityReader is a mock of CourseEligibilityReader interface.
+type MockCourseEligibilityReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseEligibilityReaderMockRecorder
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockCourseRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCourseRepository)(nil).Get), id)
+// MockCourseEligibilityReaderMockRecorder is the mock recorder for MockCourseEligibilityReader.
+type MockCourseEligibilityReaderMockRecorder struct {
+	mock *MockCourseEligibilityReader
 }
 
-// GetByAccount mocks base method.
-func (m *MockCourseRepository) GetByAccount(tenantID, accountID id.ID, page, limit int) (int, []*entity.Course, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByAccount", tenantID, accountID, page, limit)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].([]*entity.Course)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+// NewMockCourseEligibilityReader creates a new mock instance.
+func NewMockCourseEligibilityReader(ctrl *gomock.Controller) *MockCourseEligibilityReader {
+	mock := &MockCourseEligibilityReader{ctrl: ctrl}
+	mock.recorder = &MockCourseEligibilityReaderMockRecorder{mock}
+	return mock
 }
 
-// GetByAccount indicates an expected call of GetByAccount.
-func (mr *MockCourseRepositoryMockRecorder) GetByAccount(tenantID, accountID, page, limit interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAccount", reflect.TypeOf((*MockCourseRepository)(nil).GetByAccount), tenantID, accountID, page, limit)
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseEligibilityReader) EXPECT() *MockCourseEligibilityReaderMockRecorder {
+	return m.recorder
 }
 
-// GetCount mocks base method.
-func (m *MockCourseRepository) GetCount(id id.ID) (int, error) {
+// GetTeacherEligibility mocks base method.
+func (m *MockCourseEligibilityReader) GetTeacherEligibility(tenantID, productID id.ID, teacherIDs []id.ID) (map[id.ID]entity.EligibilityType, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", id)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetTeacherEligibility", tenantID, productID, teacherIDs)
+	ret0, _ := ret[0].(map[id.ID]entity.EligibilityType)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCount indicates an expected call of GetCount.
-func (mr *MockCourseRepositoryMockRecorder) GetCount(id interface{}) *gomock.Call {
+// GetTeacherEligibility indicates an expected call of GetTeacherEligibility.
+func (mr *MockCourseEligibilityReaderMockRecorder) GetTeacherEligibility(tenantID, productID, teacherIDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockCourseRepository)(nil).GetCount), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeacherEligibility", reflect.TypeOf((*MockCourseEligibilityReader)(nil).GetTeacherEligibility), tenantID, productID, teacherIDs)
 }
 
-// GetCourseContact mocks base method.
-func (m *MockCourseRepository) GetCourseContact(arg0 id.ID) ([]*entity.CourseContact, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourseContact", arg0)
-	ret0, _ := ret[0].([]*entity.CourseContact)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// MockCourseScheduleReader is a mock of CourseScheduleReader interface.
+type MockCourseScheduleReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseScheduleReaderMockRecorder
 }
 
-// GetCourseContact indicates an expected call of GetCourseContact.
-func (mr *MockCourseRepositoryMockRecorder) GetCourseContact(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseContact", reflect.TypeOf((*MockCourseRepository)(nil).GetCourseContact), arg0)
+// MockCourseScheduleReaderMockRecorder is the mock recorder for MockCourseScheduleReader.
+type MockCourseScheduleReaderMockRecorder struct {
+	mock *MockCourseScheduleReader
 }
 
-// GetCourseNotify mocks base method.
-func (m *MockCourseRepository) GetCourseNotify(arg0 id.ID) ([]*entity.CourseNotify, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourseNotify", arg0)
-	ret0, _ := ret[0].([]*entity.CourseNotify)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// NewMockCourseScheduleReader creates a new mock instance.
+func NewMockCourseScheduleReader(ctrl *gomock.Controller) *MockCourseScheduleReader {
+	mock := &MockCourseScheduleReader{ctrl: ctrl}
+	mock.recorder = &MockCourseScheduleReaderMockRecorder{mock}
+	return mock
 }
 
-// GetCourseNotify indicates an expected call of GetCourseNotify.
-func (mr *MockCourseRepositoryMockRecorder) GetCourseNotify(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseNotify", reflect.TypeOf((*MockCourseRepository)(nil).GetCourseNotify), arg0)
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseScheduleReader) EXPECT() *MockCourseScheduleReaderMockRecorder {
+	return m.recorder
 }
 
-// GetCourseOrganizer mocks base method.
-func (m *MockCourseRepository) GetCourseOrganizer(arg0 id.ID) ([]*entity.CourseOrganizer, error) {
+// ListCourseSchedules mocks base method.
+func (m *MockCourseScheduleReader) ListCourseSchedules(tenantID, courseID id.ID, accountIDs []id.ID, centerID id.ID, from, to string) ([]*entity.CourseSchedule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourseOrganizer", arg0)
-	ret0, _ := ret[0].([]*entity.CourseOrganizer)
+	ret := m.ctrl.Call(m, "ListCourseSchedules", tenantID, courseID, accountIDs, centerID, from, to)
+	ret0, _ := ret[0].([]*entity.CourseSchedule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCourseOrganizer indicates an expected call of GetCourseOrganizer.
-func (mr *MockCourseRepositoryMockRecorder) GetCourseOrganizer(arg0 interface{}) *gomock.Call {
+// ListCourseSchedules indicates an expected call of ListCourseSchedules.
+func (mr *MockCourseScheduleReaderMockRecorder) ListCourseSchedules(tenantID, courseID, accountIDs, centerID, from, to interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseOrganizer", reflect.TypeOf((*MockCourseRepository)(nil).GetCourseOrganizer), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCourseSchedules", reflect.TypeOf((*MockCourseScheduleReader)(nil).ListCourseSchedules), tenantID, courseID, accountIDs, centerID, from, to)
 }
 
-// GetCourseTeacher mocks base method.
-func (m *MockCourseRepository) GetCourseTeacher(arg0 id.ID) ([]*entity.CourseTeacher, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourseTeacher", arg0)
-	ret0, _ := ret[0].([]*entity.CourseTeacher)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// MockCourseScheduleLocker is a mock of CourseScheduleLocker interface.
+type MockCourseScheduleLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseScheduleLockerMockRecorder
 }
 
-// GetCourseTeacher indicates an expected call of GetCourseTeacher.
-func (mr *MockCourseRepositoryMockRecorder) GetCourseTeacher(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseTeacher", reflect.TypeOf((*MockCourseRepository)(nil).GetCourseTeacher), arg0)
+// MockCourseScheduleLockerMockRecorder is the mock recorder for MockCourseScheduleLocker.
+type MockCourseScheduleLockerMockRecorder struct {
+	mock *MockCourseScheduleLocker
 }
 
-// InsertCourseContact mocks base method.
-func (m *MockCourseRepository) InsertCourseContact(arg0 id.ID, arg1 []*entity.CourseContact) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertCourseContact", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+// NewMockCourseScheduleLocker creates a new mock instance.
+func NewMockCourseScheduleLocker(ctrl *gomock.Controller) *MockCourseScheduleLocker {
+	mock := &MockCourseScheduleLocker{ctrl: ctrl}
+	mock.recorder = &MockCourseScheduleLockerMockRecorder{mock}
+	return mock
 }
 
-// InsertCourseContact indicates an expected call of InsertCourseContact.
-func (mr *MockCourseRepositoryMockRecorder) InsertCourseContact(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCourseContact", reflect.TypeOf((*MockCourseRepository)(nil).InsertCourseContact), arg0, arg1)
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseScheduleLocker) EXPECT() *MockCourseScheduleLockerMockRecorder {
+	return m.recorder
 }
 
-// InsertCourseNotify mocks base method.
-func (m *MockCourseRepository) InsertCourseNotify(arg0 id.ID, arg1 []*entity.CourseNotify) error {
+// LockSchedules mocks base method.
+func (m *MockCourseScheduleLocker) LockSchedules(accountIDs []id.ID, centerID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertCourseNotify", arg0, arg1)
+	ret := m.ctrl.Call(m, "LockSchedules", accountIDs, centerID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertCourseNotify indicates an expected call of InsertCourseNotify.
-func (mr *MockCourseRepositoryMockRecorder) InsertCourseNotify(arg0, arg1 interface{}) *gomock.Call {
+// LockSchedules indicates an expected call of LockSchedules.
+func (mr *MockCourseScheduleLockerMockRecorder) LockSchedules(accountIDs, centerID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCourseNotify", reflect.TypeOf((*MockCourseRepository)(nil).InsertCourseNotify), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSchedules", reflect.TypeOf((*MockCourseScheduleLocker)(nil).LockSchedules), accountIDs, centerID)
 }
 
-// InsertCourseOrganizer mocks base method.
-func (m *MockCourseRepository) InsertCourseOrganizer(arg0 id.ID, arg1 []*entity.CourseOrganizer) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertCourseOrganizer", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+// MockCourseRecurrenceReader is a mock of CourseRecurrenceReader interface.
+type MockCourseRecurrenceReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseRecurrenceReaderMockRecorder
 }
 
-// InsertCourseOrganizer indicates an expected call of InsertCourseOrganizer.
-func (mr *MockCourseRepositoryMockRecorder) InsertCourseOrganizer(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCourseOrganizer", reflect.TypeOf((*MockCourseRepository)(nil).InsertCourseOrganizer), arg0, arg1)
+// MockCourseRecurrenceReaderMockRecorder is the mock recorder for MockCourseRecurrenceReader.
+type MockCourseRecurrenceReaderMockRecorder struct {
+	mock *MockCourseRecurrenceReader
+}
+
+// NewMockCourseRecurrenceReader creates a new mock instance.
+func NewMockCourseRecurrenceReader(ctrl *gomock.Controller) *MockCourseRecurrenceReader {
+	mock := &MockCourseRecurrenceReader{ctrl: ctrl}
+	mock.recorder = &MockCourseRecurrenceReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseRecurrenceReader) EXPECT() *MockCourseRecurrenceReaderMockRecorder {
+	return m.recorder
+}
+
+// GetCourseRecurrence mocks base method.
+func (m *MockCourseRecurrenceReader) GetCourseRecurrence(courseID id.ID) (*entity.CourseRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseRecurrence", courseID)
+	ret0, _ := ret[0].(*entity.CourseRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseRecurrence indicates an expected call of GetCourseRecurrence.
+func (mr *MockCourseRecurrenceReaderMockRecorder) GetCourseRecurrence(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseRecurrence", reflect.TypeOf((*MockCourseRecurrenceReader)(nil).GetCourseRecurrence), courseID)
+}
+
+// MockCourseRecurrenceWriter is a mock of CourseRecurrenceWriter interface.
+type MockCourseRecurrenceWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseRecurrenceWriterMockRecorder
+}
+
+// MockCourseRecurrenceWriterMockRecorder is the mock recorder for MockCourseRecurrenceWriter.
+type MockCourseRecurrenceWriterMockRecorder struct {
+	mock *MockCourseRecurrenceWriter
+}
+
+// NewMockCourseRecurrenceWriter creates a new mock instance.
+func NewMockCourseRecurrenceWriter(ctrl *gomock.Controller) *MockCourseRecurrenceWriter {
+	mock := &MockCourseRecurrenceWriter{ctrl: ctrl}
+	mock.recorder = &MockCourseRecurrenceWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseRecurrenceWriter) EXPECT() *MockCourseRecurrenceWriterMockRecorder {
+	return m.recorder
+}
+
+// SetCourseRecurrence mocks base method.
+func (m *MockCourseRecurrenceWriter) SetCourseRecurrence(courseID id.ID, r *entity.CourseRecurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCourseRecurrence", courseID, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCourseRecurrence indicates an expected call of SetCourseRecurrence.
+func (mr *MockCourseRecurrenceWriterMockRecorder) SetCourseRecurrence(courseID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCourseRecurrence", reflect.TypeOf((*MockCourseRecurrenceWriter)(nil).SetCourseRecurrence), courseID, r)
+}
+
+// MockCourseStatusReader is a mock of CourseStatusReader interface.
+type MockCourseStatusReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseStatusReaderMockRecorder
+}
+
+// MockCourseStatusReaderMockRecorder is the mock recorder for MockCourseStatusReader.
+type MockCourseStatusReaderMockRecorder struct {
+	mock *MockCourseStatusReader
+}
+
+// NewMockCourseStatusReader creates a new mock instance.
+func NewMockCourseStatusReader(ctrl *gomock.Controller) *MockCourseStatusReader {
+	mock := &MockCourseStatusReader{ctrl: ctrl}
+	mock.recorder = &MockCourseStatusReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseStatusReader) EXPECT() *MockCourseStatusReaderMockRecorder {
+	return m.recorder
+}
+
+// ListStatusChanges mocks base method.
+func (m *MockCourseStatusReader) ListStatusChanges(tenantID, courseID id.ID) ([]*entity.CourseStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusChanges", tenantID, courseID)
+	ret0, _ := ret[0].([]*entity.CourseStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusChanges indicates an expected call of ListStatusChanges.
+func (mr *MockCourseStatusReaderMockRecorder) ListStatusChanges(tenantID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusChanges", reflect.TypeOf((*MockCourseStatusReader)(nil).ListStatusChanges), tenantID, courseID)
+}
+
+// MockCourseRepository is a mock of CourseRepository interface.
+type MockCourseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourseRepositoryMockRecorder
+}
+
+// MockCourseRepositoryMockRecorder is the mock recorder for MockCourseRepository.
+type MockCourseRepositoryMockRecorder struct {
+	mock *MockCourseRepository
+}
+
+// NewMockCourseRepository creates a new mock instance.
+func NewMockCourseRepository(ctrl *gomock.Controller) *MockCourseRepository {
+	mock := &MockCourseRepository{ctrl: ctrl}
+	mock.recorder = &MockCourseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourseRepository) EXPECT() *MockCourseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BulkUpsert mocks base method.
+func (m *MockCourseRepository) BulkUpsert(tenantID id.ID, courses []*entity.Course) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsert", tenantID, courses)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpsert indicates an expected call of BulkUpsert.
+func (mr *MockCourseRepositoryMockRecorder) BulkUpsert(tenantID, courses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockCourseRepository)(nil).BulkUpsert), tenantID, courses)
+}
+
+// Create mocks base method.
+func (m *MockCourseRepository) Create(e *entity.Course) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", e)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCourseRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCourseRepository)(nil).Create), e)
+}
+
+// Delete mocks base method.
+func (m *MockCourseRepository) Delete(tenantID, courseID id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", tenantID, courseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCourseRepositoryMockRecorder) Delete(tenantID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCourseRepository)(nil).Delete), tenantID, courseID)
+}
+
+// DeleteCourseContact mocks base method.
+func (m *MockCourseRepository) DeleteCourseContact(arg0 id.ID, arg1 []*entity.CourseContact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourseContact", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourseContact indicates an expected call of DeleteCourseContact.
+func (mr *MockCourseRepositoryMockRecorder) DeleteCourseContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseContact", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseContact), arg0, arg1)
+}
+
+// DeleteCourseContactByCourse mocks base method.
+func (m *MockCourseRepository) DeleteCourseContactByCourse(arg0 id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourseContactByCourse", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourseContactByCourse indicates an expected call of DeleteCourseContactByCourse.
+func (mr *MockCourseRepositoryMockRecorder) DeleteCourseContactByCourse(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseContactByCourse", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseContactByCourse), arg0)
+}
+
+// DeleteCourseNotify mocks base method.
+func (m *MockCourseRepository) DeleteCourseNotify(arg0 id.ID, arg1 []*entity.CourseNotify) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourseNotify", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourseNotify indicates an expected call of DeleteCourseNotify.
+func (mr *MockCourseRepositoryMockRecorder) DeleteCourseNotify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseNotify", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseNotify), arg0, arg1)
+}
+
+// DeleteCourseNotifyByCourse mocks base method.
+func (m *MockCourseRepository) DeleteCourseNotifyByCourse(arg0 id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourseNotifyByCourse", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourseNotifyByCourse indicates an expected call of DeleteCourseNotifyByCourse.
+func (mr *MockCourseRepositoryMockRecorder) DeleteCourseNotifyByCourse(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseNotifyByCourse", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseNotifyByCourse), arg0)
+}
+
+// DeleteCourseOrganizer mocks base method.
+func (m *MockCourseRepository) DeleteCourseOrganizer(arg0 id.ID, arg1 []*entity.CourseOrganizer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourseOrganizer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourseOrganizer indicates an expected call of DeleteCourseOrganizer.
+func (mr *MockCourseRepositoryMockRecorder) DeleteCourseOrganizer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseOrganizer", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseOrganizer), arg0, arg1)
+}
+
+// DeleteCourseOrganizerByCourse mocks base method.
+func (m *MockCourseRepository) DeleteCourseOrganizerByCourse(arg0 id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourseOrganizerByCourse", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourseOrganizerByCourse indicates an expected call of DeleteCourseOrganizerByCourse.
+func (mr *MockCourseRepositoryMockRecorder) DeleteCourseOrganizerByCourse(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseOrganizerByCourse", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseOrganizerByCourse), arg0)
+}
+
+// DeleteCourseRosterByExtID mocks base method.
+func (m *MockCourseRepository) DeleteCourseRosterByExtID(tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourseRosterByExtID", tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCourseRosterByExtID indicates an expected call of DeleteCourseRosterByExtID.
+func (mr *MockCourseRepositoryMockRecorder) DeleteCourseRosterByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseRosterByExtID", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseRosterByExtID), tenantID, extID)
+}
+
+// DeleteCourseTeacher mocks base method.
+func (m *MockCourseRepository) DeleteCourseTeacher(arg0 id.ID, arg1 []*entity.CourseTeacher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourseTeacher", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourseTeacher indicates an expected call of DeleteCourseTeacher.
+func (mr *MockCourseRepositoryMockRecorder) DeleteCourseTeacher(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseTeacher", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseTeacher), arg0, arg1)
+}
+
+// DeleteCourseTeacherByCourse mocks base method.
+func (m *MockCourseRepository) DeleteCourseTeacherByCourse(arg0 id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourseTeacherByCourse", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourseTeacherByCourse indicates an expected call of DeleteCourseTeacherByCourse.
+func (mr *MockCourseRepositoryMockRecorder) DeleteCourseTeacherByCourse(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseTeacherByCourse", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseTeacherByCourse), arg0)
+}
+
+// Get mocks base method.
+func (m *MockCourseRepository) Get(tenantID, courseID id.ID) (*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", tenantID, courseID)
+	ret0, _ := ret[0].(*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCourseRepositoryMockRecorder) Get(tenantID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCourseRepository)(nil).Get), tenantID, courseID)
+}
+
+// GetByAccount mocks base method.
+func (m *MockCourseRepository) GetByAccount(tenantID, accountID id.ID, page, limit int) (int, []*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAccount", tenantID, accountID, page, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]*entity.Course)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByAccount indicates an expected call of GetByAccount.
+func (mr *MockCourseRepositoryMockRecorder) GetByAccount(tenantID, accountID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAccount", reflect.TypeOf((*MockCourseRepository)(nil).GetByAccount), tenantID, accountID, page, limit)
+}
+
+// GetByExtID mocks base method.
+func (m *MockCourseRepository) GetByExtID(tenantID id.ID, extID string) (*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", tenantID, extID)
+	ret0, _ := ret[0].(*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockCourseRepositoryMockRecorder) GetByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockCourseRepository)(nil).GetByExtID), tenantID, extID)
+}
+
+// GetCenterTimezone mocks base method.
+func (m *MockCourseRepository) GetCenterTimezone(tenantID, centerID id.ID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCenterTimezone", tenantID, centerID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCenterTimezone indicates an expected call of GetCenterTimezone.
+func (mr *MockCourseRepositoryMockRecorder) GetCenterTimezone(tenantID, centerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCenterTimezone", reflect.TypeOf((*MockCourseRepository)(nil).GetCenterTimezone), tenantID, centerID)
+}
+
+// GetCount mocks base method.
+func (m *MockCourseRepository) GetCount(id id.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockCourseRepositoryMockRecorder) GetCount(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockCourseRepository)(nil).GetCount), id)
+}
+
+// GetCourseContact mocks base method.
+func (m *MockCourseRepository) GetCourseContact(arg0 id.ID) ([]*entity.CourseContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseContact", arg0)
+	ret0, _ := ret[0].([]*entity.CourseContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseContact indicates an expected call of GetCourseContact.
+func (mr *MockCourseRepositoryMockRecorder) GetCourseContact(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseContact", reflect.TypeOf((*MockCourseRepository)(nil).GetCourseContact), arg0)
+}
+
+// GetCourseNotify mocks base method.
+func (m *MockCourseRepository) GetCourseNotify(arg0 id.ID) ([]*entity.CourseNotify, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseNotify", arg0)
+	ret0, _ := ret[0].([]*entity.CourseNotify)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseNotify indicates an expected call of GetCourseNotify.
+func (mr *MockCourseRepositoryMockRecorder) GetCourseNotify(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseNotify", reflect.TypeOf((*MockCourseRepository)(nil).GetCourseNotify), arg0)
+}
+
+// GetCourseOrganizer mocks base method.
+func (m *MockCourseRepository) GetCourseOrganizer(arg0 id.ID) ([]*entity.CourseOrganizer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseOrganizer", arg0)
+	ret0, _ := ret[0].([]*entity.CourseOrganizer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseOrganizer indicates an expected call of GetCourseOrganizer.
+func (mr *MockCourseRepositoryMockRecorder) GetCourseOrganizer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseOrganizer", reflect.TypeOf((*MockCourseRepository)(nil).GetCourseOrganizer), arg0)
+}
+
+// GetCourseRecurrence mocks base method.
+func (m *MockCourseRepository) GetCourseRecurrence(courseID id.ID) (*entity.CourseRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseRecurrence", courseID)
+	ret0, _ := ret[0].(*entity.CourseRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseRecurrence indicates an expected call of GetCourseRecurrence.
+func (mr *MockCourseRepositoryMockRecorder) GetCourseRecurrence(courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseRecurrence", reflect.TypeOf((*MockCourseRepository)(nil).GetCourseRecurrence), courseID)
+}
+
+// GetCourseTeacher mocks base method.
+func (m *MockCourseRepository) GetCourseTeacher(arg0 id.ID) ([]*entity.CourseTeacher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseTeacher", arg0)
+	ret0, _ := ret[0].([]*entity.CourseTeacher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseTeacher indicates an expected call of GetCourseTeacher.
+func (mr *MockCourseRepositoryMockRecorder) GetCourseTeacher(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseTeacher", reflect.TypeOf((*MockCourseRepository)(nil).GetCourseTeacher), arg0)
+}
+
+// GetTeacherEligibility mocks base method.
+func (m *MockCourseRepository) GetTeacherEligibility(tenantID, productID id.ID, teacherIDs []id.ID) (map[id.ID]entity.EligibilityType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeacherEligibility", tenantID, productID, teacherIDs)
+	ret0, _ := ret[0].(map[id.ID]entity.EligibilityType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeacherEligibility indicates an expected call of GetTeacherEligibility.
+func (mr *MockCourseRepositoryMockRecorder) GetTeacherEligibility(tenantID, productID, teacherIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeacherEligibility", reflect.TypeOf((*MockCourseRepository)(nil).GetTeacherEligibility), tenantID, productID, teacherIDs)
+}
+
+// InsertCourseContact mocks base method.
+func (m *MockCourseRepository) InsertCourseContact(arg0 id.ID, arg1 []*entity.CourseContact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCourseContact", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCourseContact indicates an expected call of InsertCourseContact.
+func (mr *MockCourseRepositoryMockRecorder) InsertCourseContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCourseContact", reflect.TypeOf((*MockCourseRepository)(nil).InsertCourseContact), arg0, arg1)
+}
+
+// InsertCourseNotify mocks base method.
+func (m *MockCourseRepository) InsertCourseNotify(arg0 id.ID, arg1 []*entity.CourseNotify) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCourseNotify", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCourseNotify indicates an expected call of InsertCourseNotify.
+func (mr *MockCourseRepositoryMockRecorder) InsertCourseNotify(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCourseNotify", reflect.TypeOf((*MockCourseRepository)(nil).InsertCourseNotify), arg0, arg1)
+}
+
+// InsertCourseOrganizer mocks base method.
+func (m *MockCourseRepository) InsertCourseOrganizer(arg0 id.ID, arg1 []*entity.CourseOrganizer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCourseOrganizer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCourseOrganizer indicates an expected call of InsertCourseOrganizer.
+func (mr *MockCourseRepositoryMockRecorder) InsertCourseOrganizer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCourseOrganizer", reflect.TypeOf((*MockCourseRepository)(nil).InsertCourseOrganizer), arg0, arg1)
 }
 
 // InsertCourseTeacher mocks base method.
@@ -1046,6 +1592,20 @@ func (mr *MockCourseRepositoryMockRecorder) InsertCourseTeacher(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCourseTeacher", reflect.TypeOf((*MockCourseRepository)(nil).InsertCourseTeacher), arg0, arg1)
 }
 
+// InsertStatusChange mocks base method.
+func (m *MockCourseRepository) InsertStatusChange(e *entity.CourseStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertStatusChange", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertStatusChange indicates an expected call of InsertStatusChange.
+func (mr *MockCourseRepositoryMockRecorder) InsertStatusChange(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertStatusChange", reflect.TypeOf((*MockCourseRepository)(nil).InsertStatusChange), e)
+}
+
 // List mocks base method.
 func (m *MockCourseRepository) List(tenantID id.ID, page, limit int) ([]*entity.Course, error) {
 	m.ctrl.T.Helper()
@@ -1061,6 +1621,50 @@ func (mr *MockCourseRepositoryMockRecorder) List(tenantID, page, limit interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCourseRepository)(nil).List), tenantID, page, limit)
 }
 
+// ListCourseSchedules mocks base method.
+func (m *MockCourseRepository) ListCourseSchedules(tenantID, courseID id.ID, accountIDs []id.ID, centerID id.ID, from, to string) ([]*entity.CourseSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCourseSchedules", tenantID, courseID, accountIDs, centerID, from, to)
+	ret0, _ := ret[0].([]*entity.CourseSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCourseSchedules indicates an expected call of ListCourseSchedules.
+func (mr *MockCourseRepositoryMockRecorder) ListCourseSchedules(tenantID, courseID, accountIDs, centerID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCourseSchedules", reflect.TypeOf((*MockCourseRepository)(nil).ListCourseSchedules), tenantID, courseID, accountIDs, centerID, from, to)
+}
+
+// ListStatusChanges mocks base method.
+func (m *MockCourseRepository) ListStatusChanges(tenantID, courseID id.ID) ([]*entity.CourseStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusChanges", tenantID, courseID)
+	ret0, _ := ret[0].([]*entity.CourseStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusChanges indicates an expected call of ListStatusChanges.
+func (mr *MockCourseRepositoryMockRecorder) ListStatusChanges(tenantID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusChanges", reflect.TypeOf((*MockCourseRepository)(nil).ListStatusChanges), tenantID, courseID)
+}
+
+// LockSchedules mocks base method.
+func (m *MockCourseRepository) LockSchedules(accountIDs []id.ID, centerID id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockSchedules", accountIDs, centerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockSchedules indicates an expected call of LockSchedules.
+func (mr *MockCourseRepositoryMockRecorder) LockSchedules(accountIDs, centerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSchedules", reflect.TypeOf((*MockCourseRepository)(nil).LockSchedules), accountIDs, centerID)
+}
+
 // MultiGetCourseContact mocks base method.
 func (m *MockCourseRepository) MultiGetCourseContact(arg0 []id.ID) ([][]*entity.CourseContact, error) {
 	m.ctrl.T.Helper()
@@ -1121,19 +1725,77 @@ func (mr *MockCourseRepositoryMockRecorder) MultiGetCourseTeacher(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultiGetCourseTeacher", reflect.TypeOf((*MockCourseRepository)(nil).MultiGetCourseTeacher), arg0)
 }
 
-// Search mocks base method.
-func (m *MockCourseRepository) Search(tenantID id.ID, query string, page, limit int) ([]*entity.Course, error) {
+// Nearby mocks base method.
+func (m *MockCourseRepository) Nearby(tenantID id.ID, q *entity.GeoQuery, from string, page, limit int) ([]*entity.CourseNearby, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", tenantID, query, page, limit)
-	ret0, _ := ret[0].([]*entity.Course)
+	ret := m.ctrl.Call(m, "Nearby", tenantID, q, from, page, limit)
+	ret0, _ := ret[0].([]*entity.CourseNearby)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// Nearby indicates an expected call of Nearby.
+func (mr *MockCourseRepositoryMockRecorder) Nearby(tenantID, q, from, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nearby", reflect.TypeOf((*MockCourseRepository)(nil).Nearby), tenantID, q, from, page, limit)
+}
+
+// QueueChange mocks base method.
+func (m *MockCourseRepository) QueueChange(e *entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueChange", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueChange indicates an expected call of QueueChange.
+func (mr *MockCourseRepositoryMockRecorder) QueueChange(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueChange", reflect.TypeOf((*MockCourseRepository)(nil).QueueChange), e)
+}
+
+// Search mocks base method.
+func (m *MockCourseRepository) Search(tenantID id.ID, f *entity.CourseFilter, page, limit int) (int, []*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", tenantID, f, page, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]*entity.Course)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // Search indicates an expected call of Search.
-func (mr *MockCourseRepositoryMockRecorder) Search(tenantID, query, page, limit interface{}) *gomock.Call {
+func (mr *MockCourseRepositoryMockRecorder) Search(tenantID, f, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockCourseRepository)(nil).Search), tenantID, f, page, limit)
+}
+
+// SetCourseRecurrence mocks base method.
+func (m *MockCourseRepository) SetCourseRecurrence(courseID id.ID, r *entity.CourseRecurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCourseRecurrence", courseID, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCourseRecurrence indicates an expected call of SetCourseRecurrence.
+func (mr *MockCourseRepositoryMockRecorder) SetCourseRecurrence(courseID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCourseRecurrence", reflect.TypeOf((*MockCourseRepository)(nil).SetCourseRecurrence), courseID, r)
+}
+
+// SetExtID mocks base method.
+func (m *MockCourseRepository) SetExtID(tenantID, courseID id.ID, extID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExtID", tenantID, courseID, extID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExtID indicates an expected call of SetExtID.
+func (mr *MockCourseRepositoryMockRecorder) SetExtID(tenantID, courseID, extID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockCourseRepository)(nil).Search), tenantID, query, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExtID", reflect.TypeOf((*MockCourseRepository)(nil).SetExtID), tenantID, courseID, extID)
 }
 
 // Update mocks base method.
@@ -1206,6 +1868,20 @@ func (mr *MockCourseRepositoryMockRecorder) UpdateCourseTeacher(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourseTeacher", reflect.TypeOf((*MockCourseRepository)(nil).UpdateCourseTeacher), arg0, arg1)
 }
 
+// UpdateStatus mocks base method.
+func (m *MockCourseRepository) UpdateStatus(tenantID, courseID id.ID, from, to entity.CourseStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", tenantID, courseID, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockCourseRepositoryMockRecorder) UpdateStatus(tenantID, courseID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockCourseRepository)(nil).UpdateStatus), tenantID, courseID, from, to)
+}
+
 // Upsert mocks base method.
 func (m *MockCourseRepository) Upsert(course *entity.Course) (id.ID, error) {
 	m.ctrl.T.Helper()
@@ -1221,6 +1897,34 @@ func (mr *MockCourseRepositoryMockRecorder) Upsert(course interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockCourseRepository)(nil).Upsert), course)
 }
 
+// UpsertCourseOrganizerByExtID mocks base method.
+func (m *MockCourseRepository) UpsertCourseOrganizerByExtID(tenantID, courseID id.ID, extID string, co *entity.CourseOrganizer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCourseOrganizerByExtID", tenantID, courseID, extID, co)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCourseOrganizerByExtID indicates an expected call of UpsertCourseOrganizerByExtID.
+func (mr *MockCourseRepositoryMockRecorder) UpsertCourseOrganizerByExtID(tenantID, courseID, extID, co interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCourseOrganizerByExtID", reflect.TypeOf((*MockCourseRepository)(nil).UpsertCourseOrganizerByExtID), tenantID, courseID, extID, co)
+}
+
+// UpsertCourseTeacherByExtID mocks base method.
+func (m *MockCourseRepository) UpsertCourseTeacherByExtID(tenantID, courseID id.ID, extID string, ct *entity.CourseTeacher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCourseTeacherByExtID", tenantID, courseID, extID, ct)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCourseTeacherByExtID indicates an expected call of UpsertCourseTeacherByExtID.
+func (mr *MockCourseRepositoryMockRecorder) UpsertCourseTeacherByExtID(tenantID, courseID, extID, ct interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCourseTeacherByExtID", reflect.TypeOf((*MockCourseRepository)(nil).UpsertCourseTeacherByExtID), tenantID, courseID, extID, ct)
+}
+
 // MockCourseTimingReader is a mock of CourseTimingReader interface.
 type MockCourseTimingReader struct {
 	ctrl     *gomock.Controller
@@ -1274,6 +1978,21 @@ func (mr *MockCourseTimingReaderMockRecorder) GetByCourse(courseID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCourse", reflect.TypeOf((*MockCourseTimingReader)(nil).GetByCourse), courseID)
 }
 
+// GetByExtID mocks base method.
+func (m *MockCourseTimingReader) GetByExtID(tenantID id.ID, extID string) (*entity.CourseTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", tenantID, extID)
+	ret0, _ := ret[0].(*entity.CourseTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockCourseTimingReaderMockRecorder) GetByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockCourseTimingReader)(nil).GetByExtID), tenantID, extID)
+}
+
 // GetCount mocks base method.
 func (m *MockCourseTimingReader) GetCount() (int, error) {
 	m.ctrl.T.Helper()
@@ -1452,6 +2171,21 @@ func (mr *MockCourseTimingRepositoryMockRecorder) GetByCourse(courseID interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCourse", reflect.TypeOf((*MockCourseTimingRepository)(nil).GetByCourse), courseID)
 }
 
+// GetByExtID mocks base method.
+func (m *MockCourseTimingRepository) GetByExtID(tenantID id.ID, extID string) (*entity.CourseTiming, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", tenantID, extID)
+	ret0, _ := ret[0].(*entity.CourseTiming)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockCourseTimingRepositoryMockRecorder) GetByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockCourseTimingRepository)(nil).GetByExtID), tenantID, extID)
+}
+
 // GetCount mocks base method.
 func (m *MockCourseTimingRepository) GetCount() (int, error) {
 	m.ctrl.T.Helper()
@@ -1496,6 +2230,43 @@ func (mr *MockCourseTimingRepositoryMockRecorder) Update(e interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCourseTimingRepository)(nil).Update), e)
 }
 
+// MockUnitOfWork is a mock of UnitOfWork interface.
+type MockUnitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnitOfWorkMockRecorder
+}
+
+// MockUnitOfWorkMockRecorder is the mock recorder for MockUnitOfWork.
+type MockUnitOfWorkMockRecorder struct {
+	mock *MockUnitOfWork
+}
+
+// NewMockUnitOfWork creates a new mock instance.
+func NewMockUnitOfWork(ctrl *gomock.Controller) *MockUnitOfWork {
+	mock := &MockUnitOfWork{ctrl: ctrl}
+	mock.recorder = &MockUnitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnitOfWork) EXPECT() *MockUnitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockUnitOfWork) Do(fn func(course.CourseRepository, course.CourseTimingRepository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockUnitOfWorkMockRecorder) Do(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockUnitOfWork)(nil).Do), fn)
+}
+
 // MockUseCase is a mock of UseCase interface.
 type MockUseCase struct {
 	ctrl     *gomock.Controller
@@ -1519,10 +2290,25 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ArchiveCourseByExtID mocks base method.
+func (m *MockUseCase) ArchiveCourseByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveCourseByExtID", actor, tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveCourseByExtID indicates an expected call of ArchiveCourseByExtID.
+func (mr *MockUseCaseMockRecorder) ArchiveCourseByExtID(actor, tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveCourseByExtID", reflect.TypeOf((*MockUseCase)(nil).ArchiveCourseByExtID), actor, tenantID, extID)
+}
+
 // CreateCourse mocks base method.
-func (m *MockUseCase) CreateCourse(course entity.Course, cos []*entity.CourseOrganizer, cts []*entity.CourseTeacher, ccs []*entity.CourseContact, cns []*entity.CourseNotify, courseTimings []*entity.CourseTiming) (id.ID, []id.ID, error) {
+func (m *MockUseCase) CreateCourse(actor entity.AuditActor, course entity.Course, cos []*entity.CourseOrganizer, cts []*entity.CourseTeacher, ccs []*entity.CourseContact, cns []*entity.CourseNotify, courseTimings []*entity.CourseTiming) (id.ID, []id.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCourse", course, cos, cts, ccs, cns, courseTimings)
+	ret := m.ctrl.Call(m, "CreateCourse", actor, course, cos, cts, ccs, cns, courseTimings)
 	ret0, _ := ret[0].(id.ID)
 	ret1, _ := ret[1].([]id.ID)
 	ret2, _ := ret[2].(error)
@@ -1530,23 +2316,23 @@ func (m *MockUseCase) CreateCourse(course entity.Course, cos []*entity.CourseOrg
 }
 
 // CreateCourse indicates an expected call of CreateCourse.
-func (mr *MockUseCaseMockRecorder) CreateCourse(course, cos, cts, ccs, cns, courseTimings interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) CreateCourse(actor, course, cos, cts, ccs, cns, courseTimings interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourse", reflect.TypeOf((*MockUseCase)(nil).CreateCourse), course, cos, cts, ccs, cns, courseTimings)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourse", reflect.TypeOf((*MockUseCase)(nil).CreateCourse), actor, course, cos, cts, ccs, cns, courseTimings)
 }
 
 // DeleteCourse mocks base method.
-func (m *MockUseCase) DeleteCourse(id id.ID) error {
+func (m *MockUseCase) DeleteCourse(actor entity.AuditActor, tenantID, courseID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCourse", id)
+	ret := m.ctrl.Call(m, "DeleteCourse", actor, tenantID, courseID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCourse indicates an expected call of DeleteCourse.
-func (mr *MockUseCaseMockRecorder) DeleteCourse(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DeleteCourse(actor, tenantID, courseID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourse", reflect.TypeOf((*MockUseCase)(nil).DeleteCourse), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourse", reflect.TypeOf((*MockUseCase)(nil).DeleteCourse), actor, tenantID, courseID)
 }
 
 // GetCount mocks base method.
@@ -1564,18 +2350,18 @@ func (mr *MockUseCaseMockRecorder) GetCount(id interface{}) *gomock.Call {
 }
 
 // GetCourse mocks base method.
-func (m *MockUseCase) GetCourse(id id.ID) (*entity.CourseFull, error) {
+func (m *MockUseCase) GetCourse(tenantID, courseID id.ID) (*entity.CourseFull, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourse", id)
+	ret := m.ctrl.Call(m, "GetCourse", tenantID, courseID)
 	ret0, _ := ret[0].(*entity.CourseFull)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCourse indicates an expected call of GetCourse.
-func (mr *MockUseCaseMockRecorder) GetCourse(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) GetCourse(tenantID, courseID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourse", reflect.TypeOf((*MockUseCase)(nil).GetCourse), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourse", reflect.TypeOf((*MockUseCase)(nil).GetCourse), tenantID, courseID)
 }
 
 // GetCourseByAccount mocks base method.
@@ -1594,6 +2380,66 @@ func (mr *MockUseCaseMockRecorder) GetCourseByAccount(tenantID, accountID, page,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseByAccount", reflect.TypeOf((*MockUseCase)(nil).GetCourseByAccount), tenantID, accountID, page, limit)
 }
 
+// GetCourseByExtID mocks base method.
+func (m *MockUseCase) GetCourseByExtID(tenantID id.ID, extID string) (*entity.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourseByExtID", tenantID, extID)
+	ret0, _ := ret[0].(*entity.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourseByExtID indicates an expected call of GetCourseByExtID.
+func (mr *MockUseCaseMockRecorder) GetCourseByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourseByExtID", reflect.TypeOf((*MockUseCase)(nil).GetCourseByExtID), tenantID, extID)
+}
+
+// GetNearbyCourses mocks base method.
+func (m *MockUseCase) GetNearbyCourses(tenantID id.ID, q *entity.GeoQuery, page, limit int) ([]*entity.CourseNearby, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearbyCourses", tenantID, q, page, limit)
+	ret0, _ := ret[0].([]*entity.CourseNearby)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearbyCourses indicates an expected call of GetNearbyCourses.
+func (mr *MockUseCaseMockRecorder) GetNearbyCourses(tenantID, q, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyCourses", reflect.TypeOf((*MockUseCase)(nil).GetNearbyCourses), tenantID, q, page, limit)
+}
+
+// ListConflicts mocks base method.
+func (m *MockUseCase) ListConflicts(tenantID id.ID, q *entity.CourseConflictQuery) ([]*entity.CourseConflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConflicts", tenantID, q)
+	ret0, _ := ret[0].([]*entity.CourseConflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConflicts indicates an expected call of ListConflicts.
+func (mr *MockUseCaseMockRecorder) ListConflicts(tenantID, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConflicts", reflect.TypeOf((*MockUseCase)(nil).ListConflicts), tenantID, q)
+}
+
+// ListCourseTransitions mocks base method.
+func (m *MockUseCase) ListCourseTransitions(tenantID, courseID id.ID) ([]*entity.CourseStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCourseTransitions", tenantID, courseID)
+	ret0, _ := ret[0].([]*entity.CourseStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCourseTransitions indicates an expected call of ListCourseTransitions.
+func (mr *MockUseCaseMockRecorder) ListCourseTransitions(tenantID, courseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCourseTransitions", reflect.TypeOf((*MockUseCase)(nil).ListCourseTransitions), tenantID, courseID)
+}
+
 // ListCourses mocks base method.
 func (m *MockUseCase) ListCourses(tenantID id.ID, page, limit int) ([]*entity.Course, error) {
 	m.ctrl.T.Helper()
@@ -1609,46 +2455,179 @@ func (mr *MockUseCaseMockRecorder) ListCourses(tenantID, page, limit interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCourses", reflect.TypeOf((*MockUseCase)(nil).ListCourses), tenantID, page, limit)
 }
 
-// SearchCourses mocks base method.
-func (m *MockUseCase) SearchCourses(tenantID id.ID, query string, page, limit int) ([]*entity.Course, error) {
+// RemoveCourseRosterByExtID mocks base method.
+func (m *MockUseCase) RemoveCourseRosterByExtID(tenantID id.ID, extID string) (id.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchCourses", tenantID, query, page, limit)
-	ret0, _ := ret[0].([]*entity.Course)
+	ret := m.ctrl.Call(m, "RemoveCourseRosterByExtID", tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveCourseRosterByExtID indicates an expected call of RemoveCourseRosterByExtID.
+func (mr *MockUseCaseMockRecorder) RemoveCourseRosterByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCourseRosterByExtID", reflect.TypeOf((*MockUseCase)(nil).RemoveCourseRosterByExtID), tenantID, extID)
+}
+
+// RemoveCourseTimingByExtID mocks base method.
+func (m *MockUseCase) RemoveCourseTimingByExtID(tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCourseTimingByExtID", tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveCourseTimingByExtID indicates an expected call of RemoveCourseTimingByExtID.
+func (mr *MockUseCaseMockRecorder) RemoveCourseTimingByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCourseTimingByExtID", reflect.TypeOf((*MockUseCase)(nil).RemoveCourseTimingByExtID), tenantID, extID)
+}
+
+// RestoreCourseByExtID mocks base method.
+func (m *MockUseCase) RestoreCourseByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCourseByExtID", actor, tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// RestoreCourseByExtID indicates an expected call of RestoreCourseByExtID.
+func (mr *MockUseCaseMockRecorder) RestoreCourseByExtID(actor, tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCourseByExtID", reflect.TypeOf((*MockUseCase)(nil).RestoreCourseByExtID), actor, tenantID, extID)
+}
+
+// SearchCourses mocks base method.
+func (m *MockUseCase) SearchCourses(tenantID id.ID, f *entity.CourseFilter, page, limit int) (int, []*entity.CourseFull, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCourses", tenantID, f, page, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]*entity.CourseFull)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // SearchCourses indicates an expected call of SearchCourses.
-func (mr *MockUseCaseMockRecorder) SearchCourses(tenantID, query, page, limit interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) SearchCourses(tenantID, f, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCourses", reflect.TypeOf((*MockUseCase)(nil).SearchCourses), tenantID, f, page, limit)
+}
+
+// SetCourseExtID mocks base method.
+func (m *MockUseCase) SetCourseExtID(actor entity.AuditActor, tenantID, courseID id.ID, extID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCourseExtID", actor, tenantID, courseID, extID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCourseExtID indicates an expected call of SetCourseExtID.
+func (mr *MockUseCaseMockRecorder) SetCourseExtID(actor, tenantID, courseID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCourseExtID", reflect.TypeOf((*MockUseCase)(nil).SetCourseExtID), actor, tenantID, courseID, extID)
+}
+
+// TransitionCourse mocks base method.
+func (m *MockUseCase) TransitionCourse(actor entity.AuditActor, tenantID, courseID id.ID, action entity.CourseAction) (*entity.CourseStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionCourse", actor, tenantID, courseID, action)
+	ret0, _ := ret[0].(*entity.CourseStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionCourse indicates an expected call of TransitionCourse.
+func (mr *MockUseCaseMockRecorder) TransitionCourse(actor, tenantID, courseID, action interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCourses", reflect.TypeOf((*MockUseCase)(nil).SearchCourses), tenantID, query, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionCourse", reflect.TypeOf((*MockUseCase)(nil).TransitionCourse), actor, tenantID, courseID, action)
 }
 
 // UpdateCourse mocks base method.
-func (m *MockUseCase) UpdateCourse(course entity.Course, cos []*entity.CourseOrganizer, cts []*entity.CourseTeacher, ccs []*entity.CourseContact, cns []*entity.CourseNotify, courseTimings []*entity.CourseTiming) error {
+func (m *MockUseCase) UpdateCourse(actor entity.AuditActor, course entity.Course, cos []*entity.CourseOrganizer, cts []*entity.CourseTeacher, ccs []*entity.CourseContact, cns []*entity.CourseNotify, courseTimings []*entity.CourseTiming) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCourse", course, cos, cts, ccs, cns, courseTimings)
+	ret := m.ctrl.Call(m, "UpdateCourse", actor, course, cos, cts, ccs, cns, courseTimings)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCourse indicates an expected call of UpdateCourse.
-func (mr *MockUseCaseMockRecorder) UpdateCourse(course, cos, cts, ccs, cns, courseTimings interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) UpdateCourse(actor, course, cos, cts, ccs, cns, courseTimings interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourse", reflect.TypeOf((*MockUseCase)(nil).UpdateCourse), course, cos, cts, ccs, cns, courseTimings)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourse", reflect.TypeOf((*MockUseCase)(nil).UpdateCourse), actor, course, cos, cts, ccs, cns, courseTimings)
 }
 
 // UpsertCourse mocks base method.
-func (m *MockUseCase) UpsertCourse(course *entity.Course) (id.ID, error) {
+func (m *MockUseCase) UpsertCourse(actor entity.AuditActor, course *entity.Course) (id.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertCourse", course)
+	ret := m.ctrl.Call(m, "UpsertCourse", actor, course)
 	ret0, _ := ret[0].(id.ID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpsertCourse indicates an expected call of UpsertCourse.
-func (mr *MockUseCaseMockRecorder) UpsertCourse(course interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) UpsertCourse(actor, course interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCourse", reflect.TypeOf((*MockUseCase)(nil).UpsertCourse), actor, course)
+}
+
+// UpsertCourseOrganizerByExtID mocks base method.
+func (m *MockUseCase) UpsertCourseOrganizerByExtID(tenantID, courseID id.ID, extID string, co *entity.CourseOrganizer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCourseOrganizerByExtID", tenantID, courseID, extID, co)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCourseOrganizerByExtID indicates an expected call of UpsertCourseOrganizerByExtID.
+func (mr *MockUseCaseMockRecorder) UpsertCourseOrganizerByExtID(tenantID, courseID, extID, co interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCourseOrganizerByExtID", reflect.TypeOf((*MockUseCase)(nil).UpsertCourseOrganizerByExtID), tenantID, courseID, extID, co)
+}
+
+// UpsertCourseTeacherByExtID mocks base method.
+func (m *MockUseCase) UpsertCourseTeacherByExtID(tenantID, courseID id.ID, extID string, ct *entity.CourseTeacher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCourseTeacherByExtID", tenantID, courseID, extID, ct)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCourseTeacherByExtID indicates an expected call of UpsertCourseTeacherByExtID.
+func (mr *MockUseCaseMockRecorder) UpsertCourseTeacherByExtID(tenantID, courseID, extID, ct interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCourseTeacherByExtID", reflect.TypeOf((*MockUseCase)(nil).UpsertCourseTeacherByExtID), tenantID, courseID, extID, ct)
+}
+
+// UpsertCourseTiming mocks base method.
+func (m *MockUseCase) UpsertCourseTiming(tenantID id.ID, ct *entity.CourseTiming) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCourseTiming", tenantID, ct)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCourseTiming indicates an expected call of UpsertCourseTiming.
+func (mr *MockUseCaseMockRecorder) UpsertCourseTiming(tenantID, ct interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCourseTiming", reflect.TypeOf((*MockUseCase)(nil).UpsertCourseTiming), tenantID, ct)
+}
+
+// UpsertCourses mocks base method.
+func (m *MockUseCase) UpsertCourses(actor entity.AuditActor, tenantID id.ID, courses []*entity.Course) ([]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCourses", actor, tenantID, courses)
+	ret0, _ := ret[0].([]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCourses indicates an expected call of UpsertCourses.
+func (mr *MockUseCaseMockRecorder) UpsertCourses(actor, tenantID, courses interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCourse", reflect.TypeOf((*MockUseCase)(nil).UpsertCourse), course)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCourses", reflect.TypeOf((*MockUseCase)(nil).UpsertCourses), actor, tenantID, courses)
 }
@@ -8,31 +8,44 @@ package course
 
 import (
 	"database/sql"
-	"strings"
 	"time"
 
 	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
+	"ac9/glad/usecase/audit"
 )
 
 // Service course usecase
 type Service struct {
 	cRepo  CourseRepository
 	ctRepo CourseTimingRepository
+	uow    UnitOfWork
+	audit  audit.Recorder
 }
 
 // NewService creates new service
-func NewService(cr CourseRepository, ctr CourseTimingRepository) *Service {
+func NewService(cr CourseRepository,
+	ctr CourseTimingRepository,
+	uow UnitOfWork,
+	a audit.Recorder,
+) *Service {
 	return &Service{
 		cRepo:  cr,
 		ctRepo: ctr,
+		uow:    uow,
+		audit:  a,
 	}
 }
 
 // CreateCourse creates a course
+// The course, its roster and timings are written in a single unit of work;
+// nothing is persisted if any of them fails. Returns entity.ConflictError
+// when the sessions overlap other courses of the teachers, the organizers or
+// the center.
 func (s *Service) CreateCourse(
+	actor entity.AuditActor,
 	course entity.Course,
 	cos []*entity.CourseOrganizer,
 	cts []*entity.CourseTeacher,
@@ -40,60 +53,190 @@ func (s *Service) CreateCourse(
 	cns []*entity.CourseNotify,
 	courseTiming []*entity.CourseTiming,
 ) (id.ID, []id.ID, error) {
+	if course.Status == "" {
+		course.Status = entity.CourseDraft
+	}
+	err := s.normalizeTimings(&course, courseTiming)
+	if err != nil {
+		return id.IDInvalid, nil, err
+	}
+	generated, err := recurrenceTimings(&course, "")
+	if err != nil {
+		return id.IDInvalid, nil, err
+	}
+	courseTiming = append(courseTiming, generated...)
+
 	c, err := course.New()
 	if err != nil {
 		return id.IDInvalid, nil, err
 	}
 
-	courseID, err := s.cRepo.Create(c)
+	err = s.checkEligibility(c.TenantID, c.ProductID, cts)
 	if err != nil {
-		return courseID, nil, err
+		return id.IDInvalid, nil, err
 	}
 
 	for i, ct := range courseTiming {
-		ct.CourseID = courseID
+		ct.CourseID = c.ID
 		courseTiming[i], err = ct.Clone()
 		if err != nil {
 			return id.IDInvalid, nil, err
 		}
 	}
 
-	// Note: These can be executed in parallel using go-routines
-	err = s.cRepo.InsertCourseOrganizer(courseID, cos)
+	var courseID id.ID
+	var courseTimingID []id.ID
+	err = s.uow.Do(func(cRepo CourseRepository, ctRepo CourseTimingRepository) error {
+		err := checkConflicts(cRepo, newCourseSchedule(c, courseTiming, cts, cos))
+		if err != nil {
+			return err
+		}
+
+		courseID, err = cRepo.Create(c)
+		if err != nil {
+			return err
+		}
+
+		err = cRepo.InsertCourseOrganizer(courseID, cos)
+		if err != nil {
+			return err
+		}
+
+		err = cRepo.InsertCourseTeacher(courseID, cts)
+		if err != nil {
+			return err
+		}
+
+		err = cRepo.InsertCourseContact(courseID, ccs)
+		if err != nil {
+			return err
+		}
+
+		err = cRepo.InsertCourseNotify(courseID, cns)
+		if err != nil {
+			return err
+		}
+
+		if c.Recurrence != nil {
+			err = cRepo.SetCourseRecurrence(courseID, c.Recurrence)
+			if err != nil {
+				return err
+			}
+		}
+
+		courseTimingID = nil
+		for _, ct := range courseTiming {
+			ctID, err := ctRepo.Create(ct)
+			if err != nil {
+				return err
+			}
+			courseTimingID = append(courseTimingID, ctID)
+		}
+		return audit.QueueChange(cRepo, actor, c.TenantID, entity.AuditCourse, courseID, entity.AuditCreate, c)
+	})
+	if err != nil {
+		return id.IDInvalid, nil, err
+	}
+
+	s.audit.Record(actor, c.TenantID, entity.AuditCourse, courseID, entity.AuditCreate, nil, c)
+	return courseID, courseTimingID, nil
+}
+
+// normalizeTimings sets the IANA zone of the course, the zone of the center
+// when not set, and validates the timings in that zone
+func (s *Service) normalizeTimings(course *entity.Course, timings []*entity.CourseTiming) error {
+	timezone, err := entity.NormalizeTimezone(course.Timezone)
+	if err != nil {
+		return glad.ErrInvalidEntity
+	}
+	if timezone == "" && course.CenterID != id.IDInvalid {
+		centerTimezone, err := s.cRepo.GetCenterTimezone(course.TenantID, course.CenterID)
+		if err != nil {
+			return err
+		}
+		timezone, err = entity.NormalizeTimezone(centerTimezone)
+		if err != nil {
+			l.Log.Warnf("Center id=%v timezone=%v is not an IANA zone", course.CenterID, centerTimezone)
+			timezone = ""
+		}
+	}
+	course.Timezone = timezone
+
+	for _, ct := range timings {
+		err := ct.DateTime.Normalize()
+		if err != nil {
+			return glad.ErrInvalidEntity
+		}
+	}
+	return entity.ValidateCourseTimings(course.Timezone, timings)
+}
+
+// recurrenceTimings generates the timings of the recurrence rule of the
+// course on or after the date (YYYY-MM-DD, empty for all); none when the
+// course has no rule
+func recurrenceTimings(course *entity.Course, from string) ([]*entity.CourseTiming, error) {
+	if course.Recurrence == nil {
+		return nil, nil
+	}
+	err := course.Recurrence.Normalize()
+	if err != nil {
+		return nil, glad.ErrInvalidEntity
+	}
+	sessions, err := course.Recurrence.Sessions(from)
 	if err != nil {
-		return courseID, nil, err
+		return nil, glad.ErrInvalidEntity
 	}
 
-	err = s.cRepo.InsertCourseTeacher(courseID, cts)
+	timings := make([]*entity.CourseTiming, 0, len(sessions))
+	for _, dt := range sessions {
+		timings = append(timings, &entity.CourseTiming{CourseID: course.ID, DateTime: dt})
+	}
+	err = entity.ValidateCourseTimings(course.Timezone, timings)
 	if err != nil {
-		return courseID, nil, err
+		return nil, err
 	}
+	return timings, nil
+}
 
-	err = s.cRepo.InsertCourseContact(courseID, ccs)
+// courseToday today's date (YYYY-MM-DD) in the course timezone
+func courseToday(timezone string) string {
+	loc, err := entity.TimezoneLocation(timezone)
 	if err != nil {
-		return courseID, nil, err
+		loc = time.UTC
 	}
+	return time.Now().In(loc).Format(entity.CourseDateFormat)
+}
 
-	err = s.cRepo.InsertCourseNotify(courseID, cns)
+// checkEligibility checks the teachers are eligible to teach the product of the
+// tenant; a primary teacher needs the primary eligibility
+func (s *Service) checkEligibility(tenantID id.ID, productID id.ID, cts []*entity.CourseTeacher) error {
+	if len(cts) == 0 {
+		return nil
+	}
+
+	teacherIDs := make([]id.ID, len(cts))
+	for i, ct := range cts {
+		teacherIDs[i] = ct.ID
+	}
+	eligibility, err := s.cRepo.GetTeacherEligibility(tenantID, productID, teacherIDs)
 	if err != nil {
-		return courseID, nil, err
+		return err
 	}
 
-	var courseTimingID []id.ID
-	for _, ct := range courseTiming {
-		ctID, err := s.ctRepo.Create(ct)
-		if err != nil {
-			return courseID, nil, err
+	for _, ct := range cts {
+		et, ok := eligibility[ct.ID]
+		if !ok || !et.CanTeach(ct) {
+			l.Log.Warnf("Teacher id=%v, isPrimary=%v is not eligible for product id=%v",
+				ct.ID, ct.IsPrimary, productID)
+			return glad.ErrNotEligible
 		}
-		courseTimingID = append(courseTimingID, ctID)
 	}
-
-	return courseID, courseTimingID, err
+	return nil
 }
 
 // GetCourse retrieves a course and related information
-func (s *Service) GetCourse(courseID id.ID) (*entity.CourseFull, error) {
-	course, err := s.cRepo.Get(courseID)
+func (s *Service) GetCourse(tenantID id.ID, courseID id.ID) (*entity.CourseFull, error) {
+	course, err := s.cRepo.Get(tenantID, courseID)
 	if course == nil {
 		err = glad.ErrNotFound
 		return nil, err
@@ -132,22 +275,74 @@ func (s *Service) GetCourse(courseID id.ID) (*entity.CourseFull, error) {
 		return nil, err
 	}
 
+	course.Recurrence, err = s.cRepo.GetCourseRecurrence(courseID)
+	if err != nil {
+		l.Log.Warnf("Unable to fetch recurrence for course id=%v", courseID)
+		return nil, err
+	}
+
 	return entity.NewCourseFull(*course, cos, cts, ccs, cns, courseTiming), err
 }
 
-// SearchCourses searches course
-// TODO: Return full information
+// SearchCourses searches courses using the filter
+// Returns the total number of matches and the requested page with organizers,
+// teachers, contacts, notify lists and timings populated.
 func (s *Service) SearchCourses(tenantID id.ID,
-	query string, page, limit int,
-) ([]*entity.Course, error) {
-	courses, err := s.cRepo.Search(tenantID, strings.ToLower(query), page, limit)
+	f *entity.CourseFilter,
+	page, limit int,
+) (int, []*entity.CourseFull, error) {
+	err := f.Validate()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	count, courseList, err := s.cRepo.Search(tenantID, f, page, limit)
+	if err != nil {
+		l.Log.Warnf("%v", err)
+		return count, nil, err
+	}
+	if count == 0 || len(courseList) == 0 {
+		return count, nil, glad.ErrNotFound
+	}
+
+	cfList, err := s.getCourseFullList(courseList)
+	return count, cfList, err
+}
+
+// GetNearbyCourses lists upcoming in-person courses at centers within the
+// query radius, nearest first
+func (s *Service) GetNearbyCourses(tenantID id.ID,
+	q *entity.GeoQuery,
+	page, limit int,
+) ([]*entity.CourseNearby, error) {
+	err := q.Validate()
 	if err != nil {
 		return nil, err
 	}
-	if len(courses) == 0 {
+
+	from := time.Now().Format(entity.CourseFilterDateLayout)
+	nearby, err := s.cRepo.Nearby(tenantID, q, from, page, limit)
+	if err != nil {
+		l.Log.Warnf("%v", err)
+		return nil, err
+	}
+	if len(nearby) == 0 {
 		return nil, glad.ErrNotFound
 	}
-	return courses, nil
+
+	courseList := make([]*entity.Course, len(nearby))
+	for i, cn := range nearby {
+		courseList[i] = cn.Course
+	}
+	cfList, err := s.getCourseFullList(courseList)
+	if err != nil {
+		return nil, err
+	}
+	for i := range nearby {
+		nearby[i].CourseFull = cfList[i]
+	}
+
+	return nearby, nil
 }
 
 // ListCourses lists course
@@ -165,13 +360,185 @@ func (s *Service) ListCourses(tenantID id.ID, page, limit int) ([]*entity.Course
 
 // DeleteCourse deletes a course
 // Note: Since delete is cascaded to dependent tables, no need to call those functions explicitly
-func (s *Service) DeleteCourse(id id.ID) error {
-	err := s.cRepo.Delete(id)
+func (s *Service) DeleteCourse(actor entity.AuditActor, tenantID id.ID, courseID id.ID) error {
+	before, _ := s.cRepo.Get(tenantID, courseID)
+	err := s.uow.Do(func(cRepo CourseRepository, _ CourseTimingRepository) error {
+		err := cRepo.Delete(tenantID, courseID)
+		if err != nil {
+			return err
+		}
+		return audit.QueueChange(cRepo, actor, tenantID, entity.AuditCourse, courseID, entity.AuditDelete, nil)
+	})
 	if err == sql.ErrNoRows {
 		return glad.ErrNotFound
 	}
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.audit.Record(actor, tenantID, entity.AuditCourse, courseID, entity.AuditDelete, before, nil)
+	return nil
+}
+
+// TransitionCourse performs a lifecycle action on the course and records it
+// in the course history along with the account that triggered it, as well as
+// in the audit log. Returns glad.ErrInvalidTransition when the action is not
+// permitted in the current status. Registrations close as a side effect of
+// moving to a status that does not accept them (see
+// entity.CourseStatus.IsRegistrationOpen).
+func (s *Service) TransitionCourse(actor entity.AuditActor,
+	tenantID id.ID,
+	courseID id.ID,
+	action entity.CourseAction,
+) (*entity.CourseStatusChange, error) {
+	var change *entity.CourseStatusChange
+	var before, after *entity.Course
+	err := s.uow.Do(func(cRepo CourseRepository, _ CourseTimingRepository) error {
+		course, err := cRepo.Get(tenantID, courseID)
+		if err != nil {
+			return err
+		}
+		if course == nil {
+			return glad.ErrNotFound
+		}
+
+		to, err := course.Status.Next(action)
+		if err != nil {
+			return err
+		}
+
+		err = cRepo.UpdateStatus(tenantID, courseID, course.Status, to)
+		if err == glad.ErrNotFound {
+			// status was changed by someone else in the meantime
+			return glad.ErrInvalidTransition
+		}
+		if err != nil {
+			return err
+		}
+
+		change = entity.NewCourseStatusChange(tenantID, courseID, action, course.Status, to, actor.AccountID)
+		err = cRepo.InsertStatusChange(change)
+		if err != nil {
+			return err
+		}
+
+		before = course
+		updated := *course
+		updated.Status = to
+		after = &updated
+		return audit.QueueChange(cRepo, actor, tenantID, entity.AuditCourse, courseID, entity.AuditUpdate, after)
+	})
+	if err != nil {
+		l.Log.Warnf("course id=%v, action=%v, err=%v", courseID, action, err)
+		return nil, err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditCourse, courseID, entity.AuditUpdate, before, after)
+	return change, nil
+}
+
+// ArchiveCourseByExtID archives the course deleted in Salesforce
+// Salesforce is the source of truth, hence the lifecycle is bypassed; the
+// change is kept in the course history so that it can be restored. Archiving
+// an archived course is a no-op.
+func (s *Service) ArchiveCourseByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+) (id.ID, error) {
+	return s.setStatusByExtID(actor, tenantID, extID, entity.CourseActionArchive,
+		func(course *entity.Course, _ []*entity.CourseStatusChange) entity.CourseStatus {
+			return entity.CourseArchived
+		})
+}
+
+// RestoreCourseByExtID restores the course undeleted in Salesforce to the
+// status it had before being archived. Restoring a course that is not
+// archived is a no-op.
+func (s *Service) RestoreCourseByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+) (id.ID, error) {
+	return s.setStatusByExtID(actor, tenantID, extID, entity.CourseActionRestore,
+		func(course *entity.Course, history []*entity.CourseStatusChange) entity.CourseStatus {
+			if course.Status != entity.CourseArchived {
+				return course.Status
+			}
+			for i := len(history) - 1; i >= 0; i-- {
+				if history[i].To == entity.CourseArchived {
+					return history[i].From
+				}
+			}
+			return entity.CourseDraft
+		})
+}
+
+// setStatusByExtID moves the course to the status returned by next and
+// records the change in the course history and the audit log
+func (s *Service) setStatusByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+	action entity.CourseAction,
+	next func(*entity.Course, []*entity.CourseStatusChange) entity.CourseStatus,
+) (id.ID, error) {
+	if extID == "" {
+		return id.IDInvalid, glad.ErrInvalidValue
+	}
+
+	var before, after *entity.Course
+	err := s.uow.Do(func(cRepo CourseRepository, _ CourseTimingRepository) error {
+		course, err := cRepo.GetByExtID(tenantID, extID)
+		if course == nil {
+			return glad.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		before = course
+
+		history, err := cRepo.ListStatusChanges(tenantID, course.ID)
+		if err != nil {
+			return err
+		}
+
+		to := next(course, history)
+		if to == course.Status {
+			return nil
+		}
+
+		err = cRepo.UpdateStatus(tenantID, course.ID, course.Status, to)
+		if err != nil {
+			return err
+		}
+
+		updated := *course
+		updated.Status = to
+		after = &updated
+		change := entity.NewCourseStatusChange(tenantID, course.ID, action, course.Status, to, actor.AccountID)
+		return cRepo.InsertStatusChange(change)
+	})
+	if err != nil {
+		l.Log.Warnf("course extID=%v, action=%v, err=%v", extID, action, err)
+		return id.IDInvalid, err
+	}
+
+	if after != nil {
+		s.audit.Record(actor, tenantID, entity.AuditCourse, before.ID, entity.AuditUpdate, before, after)
+	}
+	return before.ID, nil
+}
+
+// ListCourseTransitions lists the lifecycle history of the course, oldest first
+func (s *Service) ListCourseTransitions(tenantID id.ID,
+	courseID id.ID,
+) ([]*entity.CourseStatusChange, error) {
+	changes, err := s.cRepo.ListStatusChanges(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, glad.ErrNotFound
+	}
+	return changes, nil
 }
 
 // GetCount gets total course count
@@ -185,7 +552,10 @@ func (s *Service) GetCount(tenantID id.ID) int {
 }
 
 // UpdateCourse updates course
+// As with CreateCourse, all writes are committed or rolled back together and
+// the sessions are checked for conflicts.
 func (s *Service) UpdateCourse(
+	actor entity.AuditActor,
 	course entity.Course,
 	cos []*entity.CourseOrganizer,
 	cts []*entity.CourseTeacher,
@@ -199,48 +569,142 @@ func (s *Service) UpdateCourse(
 		return err
 	}
 
-	courseID := course.ID
+	err = s.normalizeTimings(&course, courseTiming)
+	if err != nil {
+		return err
+	}
 
-	// This may not be needed
-	course.UpdatedAt = time.Now()
-	err = s.cRepo.Update(&course)
+	err = s.checkEligibility(course.TenantID, course.ProductID, cts)
 	if err != nil {
 		return err
 	}
 
+	courseID := course.ID
+
+	// This may not be needed
+	course.UpdatedAt = time.Now()
+
+	// Note: Status is not updated here; it changes only through TransitionCourse
+
 	for _, ct := range courseTiming {
 		ct.CourseID = courseID
 	}
 
-	// Note: These can be executed in parallel using go-routines
-	err = s.cRepo.UpdateCourseOrganizer(courseID, cos)
-	if err != nil {
-		return err
-	}
+	before, _ := s.cRepo.Get(course.TenantID, courseID)
 
-	err = s.cRepo.UpdateCourseTeacher(courseID, cts)
+	timings, err := s.ctRepo.GetByCourse(courseID)
 	if err != nil {
 		return err
 	}
 
-	err = s.cRepo.UpdateCourseContact(courseID, ccs)
-	if err != nil {
-		return err
+	// the sessions from today on are replaced by the ones of the rule; the
+	// past ones are kept
+	var stale, generated []*entity.CourseTiming
+	if course.Recurrence != nil {
+		today := courseToday(course.Timezone)
+		generated, err = recurrenceTimings(&course, today)
+		if err != nil {
+			return err
+		}
+		for i, ct := range generated {
+			generated[i], err = ct.Clone()
+			if err != nil {
+				return err
+			}
+		}
+
+		var past []*entity.CourseTiming
+		for _, ct := range timings {
+			if ct.DateTime.Date >= today {
+				stale = append(stale, ct)
+			} else {
+				past = append(past, ct)
+			}
+		}
+		timings = past
 	}
 
-	err = s.cRepo.UpdateCourseNotify(courseID, cns)
-	if err != nil {
-		return err
+	var after *entity.Course
+	scheduled := course
+	if before != nil {
+		scheduled.Status = before.Status
 	}
+	err = s.uow.Do(func(cRepo CourseRepository, ctRepo CourseTimingRepository) error {
+		err := checkConflicts(cRepo, newCourseSchedule(&scheduled,
+			append(mergeTimings(timings, courseTiming), generated...), cts, cos))
+		if err != nil {
+			return err
+		}
 
-	for _, ct := range courseTiming {
-		err := s.ctRepo.Update(ct)
+		err = cRepo.Update(&course)
 		if err != nil {
 			return err
 		}
+
+		err = cRepo.UpdateCourseOrganizer(courseID, cos)
+		if err != nil {
+			return err
+		}
+
+		err = cRepo.UpdateCourseTeacher(courseID, cts)
+		if err != nil {
+			return err
+		}
+
+		err = cRepo.UpdateCourseContact(courseID, ccs)
+		if err != nil {
+			return err
+		}
+
+		err = cRepo.UpdateCourseNotify(courseID, cns)
+		if err != nil {
+			return err
+		}
+
+		for _, ct := range courseTiming {
+			err := ctRepo.Update(ct)
+			if err != nil {
+				return err
+			}
+		}
+
+		if course.Recurrence != nil {
+			for _, ct := range stale {
+				err := ctRepo.Delete(ct.ID)
+				if err != nil {
+					return err
+				}
+			}
+			for _, ct := range generated {
+				_, err := ctRepo.Create(ct)
+				if err != nil {
+					return err
+				}
+			}
+			err = cRepo.SetCourseRecurrence(courseID, course.Recurrence)
+			if err != nil {
+				return err
+			}
+		}
+
+		// the stored course is exported and audited, not the request: status,
+		// seats, Salesforce id and links are not updated here
+		after, err = cRepo.Get(course.TenantID, courseID)
+		if err != nil {
+			return err
+		}
+		if after == nil {
+			return glad.ErrNotFound
+		}
+		return audit.QueueChange(cRepo, actor, course.TenantID, entity.AuditCourse, courseID,
+			entity.AuditUpdate, after)
+	})
+	if err != nil {
+		return err
 	}
 
-	return err
+	s.audit.Record(actor, course.TenantID, entity.AuditCourse, courseID, entity.AuditUpdate, before, after)
+	return nil
 }
 
 // GetCourseByAccount retrieves course and related information using account id
@@ -259,56 +723,77 @@ func (s *Service) GetCourseByAccount(courseID id.ID,
 		return count, nil, err
 	}
 
+	cfList, err := s.getCourseFullList(courseList)
+	return count, cfList, err
+}
+
+// getCourseFullList fetches organizers, teachers, contact, notify and timings
+// for the given courses in one query each and merges them into CourseFull
+func (s *Service) getCourseFullList(courseList []*entity.Course) ([]*entity.CourseFull, error) {
 	// Generate course ids
 	var courseIDList []id.ID
 	for _, course := range courseList {
 		courseIDList = append(courseIDList, course.ID)
 	}
 
-	// Get all organizers, teachers, contact, notify and timings for N courses
 	cosList, err := s.cRepo.MultiGetCourseOrganizer(courseIDList)
 	if err != nil {
 		l.Log.Warnf("%v", err)
-		return count, nil, err
+		return nil, err
 	}
 
 	ctsList, err := s.cRepo.MultiGetCourseTeacher(courseIDList)
 	if err != nil {
 		l.Log.Warnf("%v", err)
-		return count, nil, err
+		return nil, err
 	}
 
 	ccsList, err := s.cRepo.MultiGetCourseContact(courseIDList)
 	if err != nil {
 		l.Log.Warnf("%v", err)
-		return count, nil, err
+		return nil, err
 	}
 
 	cnsList, err := s.cRepo.MultiGetCourseNotify(courseIDList)
 	if err != nil {
 		l.Log.Warnf("%v", err)
-		return count, nil, err
+		return nil, err
 	}
 
 	courseTimingList, err := s.ctRepo.MultiGetCourseTiming(courseIDList)
 	if err != nil {
 		l.Log.Warnf("%v", err)
-		return count, nil, err
+		return nil, err
 	}
 
-	// Merge all these in CourseFull entity and return a list of CourseFull entity & total count
+	// Merge all these in CourseFull entity; a repo may return fewer lists
+	// than courses when there is nothing to report
 	var cfList []*entity.CourseFull
 	for i := range courseList {
 		l.Log.Debugf("Course id=%v", courseList[i].ID)
 		cfList = append(cfList,
-			entity.NewCourseFull(*courseList[i], cosList[i], ctsList[i], ccsList[i], cnsList[i], courseTimingList[i]))
+			entity.NewCourseFull(*courseList[i],
+				itemAt(cosList, i),
+				itemAt(ctsList, i),
+				itemAt(ccsList, i),
+				itemAt(cnsList, i),
+				itemAt(courseTimingList, i),
+			))
 	}
 
-	return count, cfList, err
+	return cfList, nil
+}
+
+// itemAt returns list[i] or nil when the list is shorter
+func itemAt[T any](list [][]T, i int) []T {
+	if i < len(list) {
+		return list[i]
+	}
+	return nil
 }
 
 // UpsertCourse upserts a course
-func (s *Service) UpsertCourse(c *entity.Course) (id.ID, error) {
+func (s *Service) UpsertCourse(actor entity.AuditActor, c *entity.Course) (id.ID, error) {
 	if c.ID == id.IDInvalid {
 		// assign id and during update id should not be overwritten
 		c.ID = id.New()
@@ -323,5 +808,283 @@ func (s *Service) UpsertCourse(c *entity.Course) (id.ID, error) {
 		l.Log.Warnf("err=%v", err)
 		return id.IDInvalid, err
 	}
-	return s.cRepo.Upsert(c)
+
+	courseID, err := s.cRepo.Upsert(c)
+	if err != nil {
+		return courseID, err
+	}
+
+	// Note: Course repository has no lookup by external id; the state before
+	// the upsert is not captured.
+	s.audit.Record(actor, c.TenantID, entity.AuditCourse, courseID, entity.AuditUpsert, nil, c)
+	return courseID, nil
+}
+
+// UpsertCourses upserts the tenant's courses imported in bulk
+// The returned ids follow the order of courses; a course that could not be
+// upserted gets id.IDInvalid. When the batch is rejected as a whole, e.g. on a
+// constraint violation, the courses are upserted one by one so that a single
+// bad record does not fail the others.
+func (s *Service) UpsertCourses(actor entity.AuditActor,
+	tenantID id.ID,
+	courses []*entity.Course,
+) ([]id.ID, error) {
+	ids := make([]id.ID, len(courses))
+	valid := make([]bool, len(courses))
+
+	// the last record wins when an external id repeats within the batch
+	last := make(map[string]int, len(courses))
+	for i, c := range courses {
+		c.TenantID = tenantID
+		if c.ID == id.IDInvalid {
+			c.ID = id.New()
+		}
+
+		// Note: Salesforce data is not cleaner. Transform the data as a workaround
+		c.Transform()
+
+		err := c.Validate()
+		if err == nil && (c.ExtID == nil || *c.ExtID == "") {
+			err = glad.ErrInvalidValue
+		}
+		if err != nil {
+			l.Log.Warnf("index=%v, err=%v", i, err)
+			continue
+		}
+		valid[i] = true
+		last[*c.ExtID] = i
+	}
+
+	batch := make([]*entity.Course, 0, len(last))
+	for i, c := range courses {
+		if valid[i] && last[*c.ExtID] == i {
+			batch = append(batch, c)
+		}
+	}
+	if len(batch) == 0 {
+		return ids, nil
+	}
+
+	byExtID, err := s.cRepo.BulkUpsert(tenantID, batch)
+	if err != nil {
+		l.Log.Warnf("Bulk upsert failed, upserting one by one. err=%v", err)
+		byExtID = make(map[string]id.ID, len(batch))
+		for _, c := range batch {
+			courseID, err := s.UpsertCourse(actor, c)
+			if err != nil {
+				l.Log.Warnf("extID=%v, err=%v", *c.ExtID, err)
+				continue
+			}
+			byExtID[*c.ExtID] = courseID
+		}
+	} else {
+		// Note: the state before the upsert is not looked up for bulk imports
+		for _, c := range batch {
+			s.audit.Record(actor, tenantID, entity.AuditCourse, byExtID[*c.ExtID],
+				entity.AuditUpsert, nil, c)
+		}
+	}
+
+	for i, c := range courses {
+		if valid[i] {
+			ids[i] = byExtID[*c.ExtID]
+		}
+	}
+	return ids, nil
+}
+
+// SetCourseExtID links a course created in GLAD to the Salesforce record it
+// was exported to. Returns glad.ErrAlreadyExists when the course is already
+// linked to another record.
+func (s *Service) SetCourseExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	courseID id.ID,
+	extID string,
+) error {
+	if extID == "" {
+		return glad.ErrInvalidValue
+	}
+
+	course, err := s.cRepo.Get(tenantID, courseID)
+	if course == nil {
+		return glad.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if course.ExtID != nil && *course.ExtID != "" {
+		if *course.ExtID == extID {
+			return nil
+		}
+		return glad.ErrAlreadyExists
+	}
+
+	err = s.cRepo.SetExtID(tenantID, courseID, extID)
+	if err != nil {
+		return err
+	}
+
+	updated := *course
+	updated.ExtID = &extID
+	s.audit.Record(actor, tenantID, entity.AuditCourse, courseID, entity.AuditUpdate, course, &updated)
+	return nil
+}
+
+// GetCourseByExtID retrieves a course by its Salesforce id
+func (s *Service) GetCourseByExtID(tenantID id.ID, extID string) (*entity.Course, error) {
+	course, err := s.cRepo.GetByExtID(tenantID, extID)
+	if course == nil {
+		return nil, glad.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return course, nil
+}
+
+// UpsertCourseTiming upserts the course timing imported from Salesforce
+// When the external id is new, a timing of the course on the same date that
+// has no external id, e.g. created in GLAD, is taken over instead of adding
+// another one.
+func (s *Service) UpsertCourseTiming(tenantID id.ID, ct *entity.CourseTiming) (id.ID, error) {
+	if ct.ExtID == nil || *ct.ExtID == "" {
+		return id.IDInvalid, glad.ErrInvalidValue
+	}
+	err := ct.DateTime.Normalize()
+	if err != nil {
+		return id.IDInvalid, glad.ErrInvalidEntity
+	}
+
+	course, _ := s.cRepo.Get(tenantID, ct.CourseID)
+	if course == nil {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+
+	existing, err := s.ctRepo.GetByExtID(tenantID, *ct.ExtID)
+	if err != nil && err != glad.ErrNotFound {
+		return id.IDInvalid, err
+	}
+	if existing == nil {
+		timings, err := s.ctRepo.GetByCourse(ct.CourseID)
+		if err != nil {
+			return id.IDInvalid, err
+		}
+		for _, t := range timings {
+			if (t.ExtID == nil || *t.ExtID == "") && t.DateTime.Date == ct.DateTime.Date {
+				existing = t
+				break
+			}
+		}
+	}
+
+	if ct.UpdatedAt.IsZero() {
+		ct.UpdatedAt = time.Now()
+	}
+	err = s.uow.Do(func(cRepo CourseRepository, ctRepo CourseTimingRepository) error {
+		switch {
+		case existing == nil:
+			ct.ID = id.New()
+		case existing.CourseID == ct.CourseID:
+			ct.ID = existing.ID
+			ct.CreatedAt = existing.CreatedAt
+			return ctRepo.Update(ct)
+		default:
+			// moved to another course
+			ct.ID = existing.ID
+			err := ctRepo.Delete(existing.ID)
+			if err != nil {
+				return err
+			}
+		}
+		if ct.CreatedAt.IsZero() {
+			ct.CreatedAt = time.Now()
+		}
+		_, err := ctRepo.Create(ct)
+		return err
+	})
+	if err != nil {
+		l.Log.Warnf("extID=%v, err=%v", *ct.ExtID, err)
+		return id.IDInvalid, err
+	}
+	return ct.ID, nil
+}
+
+// RemoveCourseTimingByExtID removes the course timing deleted in Salesforce
+func (s *Service) RemoveCourseTimingByExtID(tenantID id.ID, extID string) (id.ID, error) {
+	ct, err := s.ctRepo.GetByExtID(tenantID, extID)
+	if ct == nil {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	err = s.ctRepo.Delete(ct.ID)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	return ct.ID, nil
+}
+
+// UpsertCourseTeacherByExtID links the teacher imported from Salesforce to
+// the course
+func (s *Service) UpsertCourseTeacherByExtID(tenantID id.ID,
+	courseID id.ID,
+	extID string,
+	ct *entity.CourseTeacher,
+) error {
+	return s.upsertRoster(tenantID, courseID, extID, func(cRepo CourseRepository) error {
+		return cRepo.UpsertCourseTeacherByExtID(tenantID, courseID, extID, ct)
+	})
+}
+
+// UpsertCourseOrganizerByExtID links the organizer imported from Salesforce
+// to the course
+func (s *Service) UpsertCourseOrganizerByExtID(tenantID id.ID,
+	courseID id.ID,
+	extID string,
+	co *entity.CourseOrganizer,
+) error {
+	return s.upsertRoster(tenantID, courseID, extID, func(cRepo CourseRepository) error {
+		return cRepo.UpsertCourseOrganizerByExtID(tenantID, courseID, extID, co)
+	})
+}
+
+// upsertRoster checks the course belongs to the tenant and runs upsert in a
+// unit of work
+func (s *Service) upsertRoster(tenantID id.ID,
+	courseID id.ID,
+	extID string,
+	upsert func(cRepo CourseRepository) error,
+) error {
+	if extID == "" {
+		return glad.ErrInvalidValue
+	}
+
+	course, _ := s.cRepo.Get(tenantID, courseID)
+	if course == nil {
+		return glad.ErrNotFound
+	}
+
+	err := s.uow.Do(func(cRepo CourseRepository, _ CourseTimingRepository) error {
+		return upsert(cRepo)
+	})
+	if err != nil {
+		l.Log.Warnf("extID=%v, err=%v", extID, err)
+	}
+	return err
+}
+
+// RemoveCourseRosterByExtID removes the teacher or organizer deleted in
+// Salesforce from the course
+func (s *Service) RemoveCourseRosterByExtID(tenantID id.ID, extID string) (id.ID, error) {
+	courseID, err := s.cRepo.DeleteCourseRosterByExtID(tenantID, extID)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	if courseID == id.IDInvalid {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+	return courseID, nil
 }
@@ -7,13 +7,21 @@
 package course
 
 import (
+	"encoding/json"
+	"log"
+	"os"
+	"sort"
 	"testing"
 	"time"
 
 	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+	"ac9/glad/usecase/audit"
+	amock "ac9/glad/usecase/audit/mock"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,10 +32,9 @@ const (
 	aliceCenterID        = 13790493495087075501
 	aliceProductID       = 13790493495087076601
 
-	// todo: add multi-tenant support
-	// tenantBob    id.ID = 13790492210917015555
-	bobExtID    = "000bobExtID"
-	bobCenterID = 13790493495087075502
+	tenantBob   id.ID = 13790492210917015555
+	bobExtID          = "000bobExtID"
+	bobCenterID       = 13790493495087075502
 
 	aliceOrganizer1ID = 100000001
 	aliceOrganizer2ID = 100000002
@@ -40,6 +47,19 @@ const (
 	aliceTiming3ID = 300000003
 )
 
+// testActor caller making the changes
+var testActor = entity.AuditActor{Source: entity.AuditSourceAPI}
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
 func newFixtureCourse() *entity.Course {
 	extID := aliceExtID
 
@@ -114,11 +134,13 @@ func newCourseNotify() []*entity.CourseNotify {
 	return cns
 }
 
-// TODO: Need to set proper dates, time and verify the same
 func newCourseTiming() []*entity.CourseTiming {
-	ct1 := entity.CourseTiming{ID: aliceTiming1ID}
-	ct2 := entity.CourseTiming{ID: aliceTiming2ID}
-	ct3 := entity.CourseTiming{ID: aliceTiming3ID}
+	dateTime := func(date string) entity.CourseDateTime {
+		return entity.CourseDateTime{Date: date, StartTime: "09:00", EndTime: "12:00"}
+	}
+	ct1 := entity.CourseTiming{ID: aliceTiming1ID, DateTime: dateTime("2024-06-01")}
+	ct2 := entity.CourseTiming{ID: aliceTiming2ID, DateTime: dateTime("2024-06-02")}
+	ct3 := entity.CourseTiming{ID: aliceTiming3ID, DateTime: dateTime("2024-06-03")}
 
 	var cts []*entity.CourseTiming
 	cts = append(cts, &ct1)
@@ -128,12 +150,20 @@ func newCourseTiming() []*entity.CourseTiming {
 	return cts
 }
 
+// addFixtureEligibility makes the fixture teachers eligible for the product
+func addFixtureEligibility(repo *inmemCourse) {
+	repo.addEligibility(tenantAlice, aliceProductID, aliceTeacher1ID, entity.EligibilityPrimary)
+	repo.addEligibility(tenantAlice, aliceProductID, aliceTeacher2ID, entity.EligibilityAssistant)
+}
+
 func Test_Create(t *testing.T) {
 	repo := newInmemCourse()
+	addFixtureEligibility(repo)
 	ctRepo := newInmemCourseTiming()
-	m := NewService(repo, ctRepo)
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
 	tmpl := newFixtureCourse()
 	_, _, err := m.CreateCourse(
+		testActor,
 		*tmpl,
 		newCourseOrganizer(),
 		newCourseTeacher(),
@@ -148,8 +178,9 @@ func Test_Create(t *testing.T) {
 
 func Test_SearchAndFind(t *testing.T) {
 	repo := newInmemCourse()
+	addFixtureEligibility(repo)
 	ctRepo := newInmemCourseTiming()
-	m := NewService(repo, ctRepo)
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
 	tmpl1 := newFixtureCourse()
 	tmpl2 := newFixtureCourse()
 	tmpl2.Name = "Course Sahaj Meditation"
@@ -157,6 +188,7 @@ func Test_SearchAndFind(t *testing.T) {
 	tmpl2.ExtID = &extID
 
 	tID, _, _ := m.CreateCourse(
+		testActor,
 		*tmpl1,
 		newCourseOrganizer(),
 		newCourseTeacher(),
@@ -165,6 +197,7 @@ func Test_SearchAndFind(t *testing.T) {
 		newCourseTiming(),
 	)
 	_, _, _ = m.CreateCourse(
+		testActor,
 		*tmpl2,
 		newCourseOrganizer(),
 		newCourseTeacher(),
@@ -174,23 +207,60 @@ func Test_SearchAndFind(t *testing.T) {
 	)
 
 	t.Run("search", func(t *testing.T) {
-		res, err := m.SearchCourses(tmpl1.TenantID, "Part", 0, 0)
+		count, res, err := m.SearchCourses(tmpl1.TenantID, &entity.CourseFilter{Query: "Part"}, 0, 0)
 		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
 		assert.Equal(t, 1, len(res))
-		assert.Equal(t, tmpl1.ExtID, res[0].ExtID)
-		assert.Equal(t, tmpl1.CenterID, res[0].CenterID)
-		assert.Equal(t, tmpl1.Status, res[0].Status)
+		assert.Equal(t, tmpl1.ExtID, res[0].Course.ExtID)
+		assert.Equal(t, tmpl1.CenterID, res[0].Course.CenterID)
+		assert.Equal(t, tmpl1.Status, res[0].Course.Status)
 		// TODO: checks for other fields to be added
 
-		// 'default' query value matches both the course names
-		res, err = m.SearchCourses(tmpl1.TenantID, "Sahaj", 0, 0)
+		count, res, err = m.SearchCourses(tmpl1.TenantID, &entity.CourseFilter{Query: "Sahaj"}, 0, 0)
 		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
 		assert.Equal(t, 1, len(res))
 
-		res, err = m.SearchCourses(tmpl1.TenantID, "non-existent", 0, 0)
+		count, res, err = m.SearchCourses(tmpl1.TenantID, &entity.CourseFilter{Query: "non-existent"}, 0, 0)
 		assert.Equal(t, glad.ErrNotFound, err)
+		assert.Equal(t, 0, count)
 		assert.Nil(t, res)
 	})
+	t.Run("search filters", func(t *testing.T) {
+		// both courses match; only one is returned per page but count covers both
+		count, res, err := m.SearchCourses(tmpl1.TenantID, &entity.CourseFilter{
+			Status: entity.CourseActive,
+			Mode:   entity.CourseInPerson,
+			City:   "cityname",
+			SortBy: entity.CourseSortName,
+		}, 1, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, 1, len(res))
+		assert.Equal(t, tmpl1.Name, res[0].Course.Name)
+
+		_, res, err = m.SearchCourses(tmpl1.TenantID, &entity.CourseFilter{
+			SortBy:   entity.CourseSortName,
+			SortDesc: true,
+		}, 1, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, tmpl2.Name, res[0].Course.Name)
+
+		_, _, err = m.SearchCourses(tmpl1.TenantID, &entity.CourseFilter{Status: entity.CourseDraft}, 0, 0)
+		assert.Equal(t, glad.ErrNotFound, err)
+
+		_, _, err = m.SearchCourses(tenantBob, &entity.CourseFilter{}, 0, 0)
+		assert.Equal(t, glad.ErrNotFound, err)
+
+		_, _, err = m.SearchCourses(tmpl1.TenantID, &entity.CourseFilter{SortBy: "price"}, 0, 0)
+		assert.Equal(t, glad.ErrInvalidValue, err)
+
+		_, _, err = m.SearchCourses(tmpl1.TenantID, &entity.CourseFilter{
+			DateFrom: "2024-06-02",
+			DateTo:   "2024-06-01",
+		}, 0, 0)
+		assert.Equal(t, glad.ErrInvalidValue, err)
+	})
 	t.Run("list all", func(t *testing.T) {
 		all, err := m.ListCourses(tmpl1.TenantID, 0, 0)
 		assert.Nil(t, err)
@@ -198,7 +268,7 @@ func Test_SearchAndFind(t *testing.T) {
 	})
 
 	t.Run("get", func(t *testing.T) {
-		savedFull, err := m.GetCourse(tID)
+		savedFull, err := m.GetCourse(tenantAlice, tID)
 		assert.Nil(t, err)
 		saved := savedFull.Course
 		assert.Equal(t, tmpl1.TenantID, saved.TenantID)
@@ -210,13 +280,20 @@ func Test_SearchAndFind(t *testing.T) {
 }
 
 func Test_Update(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	recorder := amock.NewMockRecorder(controller)
 	repo := newInmemCourse()
+	addFixtureEligibility(repo)
 	ctRepo := newInmemCourseTiming()
-	m := NewService(repo, ctRepo)
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), recorder)
+	recorder.EXPECT().Record(testActor, tenantAlice, entity.AuditCourse, gomock.Any(),
+		entity.AuditCreate, nil, gomock.Any())
 	tmpl := newFixtureCourse()
 	courseTiming := newCourseTiming()
 
-	id, courseTimingIDs, err := m.CreateCourse(
+	courseID, courseTimingIDs, err := m.CreateCourse(
+		testActor,
 		*tmpl,
 		newCourseOrganizer(),
 		newCourseTeacher(),
@@ -226,15 +303,38 @@ func Test_Update(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	savedFull, _ := m.GetCourse(id)
+	// seats reserved by the registrations meanwhile, linked to Salesforce
+	extID := "a0B5g00000CourseX"
+	repo.m[courseID].NumAttendees = 7
+	repo.m[courseID].ExtID = &extID
+	repo.m[courseID].URL = "https://example.org/course"
+
+	savedFull, _ := m.GetCourse(tenantAlice, courseID)
 	saved := savedFull.Course
 	saved.Mode = entity.CourseOnline
+	// as sent by the clients, which do not know the seats, the status, the
+	// Salesforce id or the links
+	saved.NumAttendees = 0
+	saved.Status = ""
+	saved.ExtID = nil
+	saved.URL = ""
 	// Update the course timing ids as it is generated afresh
 	for i := range courseTiming {
 		courseTiming[i].ID = courseTimingIDs[i]
 	}
 
+	// the stored course is audited, not the request
+	recorder.EXPECT().Record(testActor, tenantAlice, entity.AuditCourse, courseID, entity.AuditUpdate,
+		gomock.Any(), gomock.Any()).
+		Do(func(_ entity.AuditActor, _ id.ID, _ entity.AuditEntityType, _ id.ID,
+			_ entity.AuditOperation, _, after interface{}) {
+			stored := after.(*entity.Course)
+			assert.Equal(t, extID, *stored.ExtID)
+			assert.Equal(t, "https://example.org/course", stored.URL)
+			assert.Equal(t, entity.CourseOnline, stored.Mode)
+		})
 	assert.Nil(t, m.UpdateCourse(
+		testActor,
 		*saved,
 		newCourseOrganizer(),
 		newCourseTeacher(),
@@ -243,22 +343,34 @@ func Test_Update(t *testing.T) {
 		courseTiming,
 	))
 
-	updatedFull, err := m.GetCourse(id)
+	updatedFull, err := m.GetCourse(tenantAlice, courseID)
 	updated := updatedFull.Course
 	assert.Nil(t, err)
 	assert.Equal(t, entity.CourseOnline, updated.Mode)
+	assert.Equal(t, int32(7), updated.NumAttendees)
+
+	// the stored course is queued for the export
+	var queued entity.Course
+	assert.Nil(t, json.Unmarshal(repo.changes[len(repo.changes)-1].After, &queued))
+	assert.Equal(t, updated.Status, queued.Status)
+	assert.Equal(t, extID, *queued.ExtID)
+	assert.Equal(t, updated.URL, queued.URL)
+	assert.Equal(t, updated.NumAttendees, queued.NumAttendees)
+	assert.Equal(t, entity.CourseOnline, queued.Mode)
 }
 
 func TestDelete(t *testing.T) {
 	repo := newInmemCourse()
+	addFixtureEligibility(repo)
 	ctRepo := newInmemCourseTiming()
-	m := NewService(repo, ctRepo)
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
 
 	tmpl1 := newFixtureCourse()
 	tmpl2 := newFixtureCourse()
 	extID := bobExtID
 	tmpl2.ExtID = &extID
 	t2ID, _, _ := m.CreateCourse(
+		testActor,
 		*tmpl2,
 		newCourseOrganizer(),
 		newCourseTeacher(),
@@ -267,11 +379,794 @@ func TestDelete(t *testing.T) {
 		newCourseTiming(),
 	)
 
-	err := m.DeleteCourse(tmpl1.ID)
+	err := m.DeleteCourse(testActor, tenantAlice, tmpl1.ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	err = m.DeleteCourse(testActor, tenantAlice, t2ID)
+	assert.Nil(t, err)
+	_, err = m.GetCourse(tenantAlice, t2ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+}
+
+func TestTenantIsolation(t *testing.T) {
+	repo := newInmemCourse()
+	addFixtureEligibility(repo)
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	tmpl := newFixtureCourse()
+	cID, _, err := m.CreateCourse(
+		testActor,
+		*tmpl,
+		newCourseOrganizer(),
+		newCourseTeacher(),
+		newCourseContact(),
+		newCourseNotify(),
+		newCourseTiming(),
+	)
+	assert.Nil(t, err)
+
+	_, err = m.GetCourse(tenantBob, cID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	savedFull, _ := m.GetCourse(tenantAlice, cID)
+	other := *savedFull.Course
+	other.TenantID = tenantBob
+	other.Name = "hijacked"
+	assert.Equal(t, glad.ErrNotFound, m.UpdateCourse(testActor, other, nil, nil, nil, nil, nil))
+
+	err = m.DeleteCourse(testActor, tenantBob, cID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	savedFull, err = m.GetCourse(tenantAlice, cID)
+	assert.Nil(t, err)
+	assert.Equal(t, tmpl.Name, savedFull.Course.Name)
+}
+
+func TestUpdateRollback(t *testing.T) {
+	repo := newInmemCourse()
+	addFixtureEligibility(repo)
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	tmpl := newFixtureCourse()
+
+	cID, _, err := m.CreateCourse(
+		testActor,
+		*tmpl,
+		newCourseOrganizer(),
+		newCourseTeacher(),
+		newCourseContact(),
+		newCourseNotify(),
+		newCourseTiming(),
+	)
+	assert.Nil(t, err)
+
+	savedFull, _ := m.GetCourse(tenantAlice, cID)
+	saved := savedFull.Course
+	saved.Mode = entity.CourseOnline
+
+	// course timing ids are not known to the repo; the last write fails
+	// and the course update must not be visible afterwards
+	err = m.UpdateCourse(
+		testActor,
+		*saved,
+		newCourseOrganizer(),
+		newCourseTeacher(),
+		newCourseContact(),
+		newCourseNotify(),
+		newCourseTiming(),
+	)
 	assert.Equal(t, glad.ErrNotFound, err)
 
-	err = m.DeleteCourse(t2ID)
+	updatedFull, err := m.GetCourse(tenantAlice, cID)
+	assert.Nil(t, err)
+	assert.Equal(t, tmpl.Mode, updatedFull.Course.Mode)
+}
+
+func TestUnitOfWorkRollback(t *testing.T) {
+	repo := newInmemCourse()
+	addFixtureEligibility(repo)
+	ctRepo := newInmemCourseTiming()
+	uow := newInmemUnitOfWork(repo, ctRepo)
+	m := NewService(repo, ctRepo, uow, audit.Discard)
+	tmpl := newFixtureCourse()
+
+	cID, _, err := m.CreateCourse(testActor, *tmpl, nil, nil, nil, nil, newCourseTiming())
+	assert.Nil(t, err)
+	assert.Nil(t, repo.SetCourseRecurrence(cID, &entity.CourseRecurrence{
+		Rule: "FREQ=WEEKLY;COUNT=4", ExDates: []string{"2024-06-11"}}))
+	assert.Nil(t, repo.UpsertCourseTeacherByExtID(tenantAlice, cID, "000teacherExtID",
+		&entity.CourseTeacher{ID: aliceTeacher1ID}))
+	timings, _ := ctRepo.GetByCourse(cID)
+	assert.NotEmpty(t, timings)
+	timingID := timings[0].ID
+	timingDate := timings[0].DateTime.Date
+	changes := len(repo.changes)
+
+	// the stored entities are changed in place as well as replaced
+	err = uow.Do(func(cRepo CourseRepository, ctRepo CourseTimingRepository) error {
+		course, _ := cRepo.Get(tenantAlice, cID)
+		course.Name = "Renamed"
+		assert.Nil(t, cRepo.UpdateStatus(tenantAlice, cID, course.Status, entity.CourseActive))
+		assert.Nil(t, cRepo.InsertStatusChange(&entity.CourseStatusChange{
+			TenantID: tenantAlice, CourseID: cID, To: entity.CourseActive}))
+		assert.Nil(t, cRepo.UpsertCourseTeacherByExtID(tenantAlice, cID, "000teacherExtID",
+			&entity.CourseTeacher{ID: aliceTeacher2ID}))
+		rec, _ := cRepo.GetCourseRecurrence(cID)
+		rec.ExDates[0] = "2024-06-18"
+		assert.Nil(t, cRepo.QueueChange(&entity.AuditLog{TenantID: tenantAlice, EntityID: cID}))
+		timing, _ := ctRepo.Get(timingID)
+		timing.DateTime.Date = "2024-07-01"
+		return glad.ErrInvalidValue
+	})
+	assert.Equal(t, glad.ErrInvalidValue, err)
+
+	course, _ := repo.Get(tenantAlice, cID)
+	assert.Equal(t, tmpl.Name, course.Name)
+	assert.Equal(t, tmpl.Status, course.Status)
+	history, _ := repo.ListStatusChanges(tenantAlice, cID)
+	assert.Empty(t, history)
+	assert.Equal(t, id.ID(aliceTeacher1ID), repo.rosters["000teacherExtID"].accountID)
+	rec, _ := repo.GetCourseRecurrence(cID)
+	assert.Equal(t, []string{"2024-06-11"}, rec.ExDates)
+	assert.Equal(t, changes, len(repo.changes))
+	timing, _ := ctRepo.Get(timingID)
+	assert.Equal(t, timingDate, timing.DateTime.Date)
+}
+
+func TestNearby(t *testing.T) {
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+
+	const farCenterID = 13790493495087075503
+	repo.addCenter(aliceCenterID, entity.CenterGeoLocation{Lat: 37.3541, Long: -121.9552})
+	repo.addCenter(farCenterID, entity.CenterGeoLocation{Lat: 37.7749, Long: -122.4194})
+	repo.addCenter(bobCenterID, entity.CenterGeoLocation{Lat: 37.3382, Long: -121.8863})
+
+	for i, c := range []struct {
+		tenantID id.ID
+		centerID id.ID
+		mode     entity.CourseMode
+		status   entity.CourseStatus
+	}{
+		{tenantAlice, aliceCenterID, entity.CourseInPerson, entity.CourseOpen},
+		{tenantAlice, farCenterID, entity.CourseInPerson, entity.CourseActive},
+		{tenantAlice, aliceCenterID, entity.CourseOnline, entity.CourseOpen},
+		{tenantAlice, aliceCenterID, entity.CourseInPerson, entity.CourseCanceled},
+		{tenantBob, bobCenterID, entity.CourseInPerson, entity.CourseOpen},
+	} {
+		course := newFixtureCourse()
+		course.ID = courseDefault + id.ID(i)
+		course.TenantID = c.tenantID
+		course.CenterID = c.centerID
+		course.Mode = c.mode
+		course.Status = c.status
+		_, err := repo.Create(course)
+		assert.Nil(t, err)
+	}
+
+	q := &entity.GeoQuery{Lat: 37.3382, Long: -121.8863, RadiusKm: 100}
+	res, err := m.GetNearbyCourses(tenantAlice, q, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, courseDefault, res[0].Course.ID)
+	assert.Equal(t, courseDefault+1, res[1].Course.ID)
+	assert.True(t, res[0].DistanceKm < res[1].DistanceKm)
+
+	q.RadiusKm = 10
+	res, err = m.GetNearbyCourses(tenantAlice, q, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(res))
+
+	_, err = m.GetNearbyCourses(tenantAlice, &entity.GeoQuery{Long: 181}, 0, 0)
+	assert.Equal(t, glad.ErrInvalidValue, err)
+}
+
+func TestTransition(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	recorder := amock.NewMockRecorder(controller)
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), recorder)
+	tmpl := newFixtureCourse()
+	tmpl.Status = ""
+	const actorID id.ID = aliceOrganizer1ID
+	actor := entity.AuditActor{Source: entity.AuditSourceAPI, AccountID: actorID}
+
+	recorder.EXPECT().Record(testActor, tenantAlice, entity.AuditCourse, gomock.Any(),
+		entity.AuditCreate, nil, gomock.Any())
+
+	cID, _, err := m.CreateCourse(testActor, *tmpl, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+	saved, _ := m.GetCourse(tenantAlice, cID)
+	assert.Equal(t, entity.CourseDraft, saved.Course.Status)
+
+	for _, step := range []struct {
+		action entity.CourseAction
+		to     entity.CourseStatus
+	}{
+		{entity.CourseActionSubmit, entity.CourseSubmitted},
+		{entity.CourseActionApprove, entity.CourseActive},
+		{entity.CourseActionOpen, entity.CourseOpen},
+		{entity.CourseActionCancel, entity.CourseCanceled},
+	} {
+		recorder.EXPECT().Record(actor, tenantAlice, entity.AuditCourse, cID, entity.AuditUpdate,
+			gomock.Any(), gomock.Any()).
+			Do(func(_ entity.AuditActor, _ id.ID, _ entity.AuditEntityType, _ id.ID,
+				_ entity.AuditOperation, before, after interface{}) {
+				assert.Equal(t, step.to, after.(*entity.Course).Status)
+				assert.NotEqual(t, step.to, before.(*entity.Course).Status)
+			})
+		change, err := m.TransitionCourse(actor, tenantAlice, cID, step.action)
+		assert.Nil(t, err, step.action)
+		assert.Equal(t, step.to, change.To)
+		assert.Equal(t, actorID, change.ActorID)
+
+		// queued for the export in the same transaction
+		queued := repo.changes[len(repo.changes)-1]
+		assert.Equal(t, cID, queued.EntityID)
+		assert.Equal(t, entity.AuditUpdate, queued.Operation)
+	}
+
+	t.Run("not permitted", func(t *testing.T) {
+		_, err := m.TransitionCourse(actor, tenantAlice, cID, entity.CourseActionOpen)
+		assert.Equal(t, glad.ErrInvalidTransition, err)
+		_, err = m.TransitionCourse(actor, tenantAlice, cID, entity.CourseAction("publish"))
+		assert.Equal(t, glad.ErrInvalidValue, err)
+		_, err = m.TransitionCourse(actor, tenantBob, cID, entity.CourseActionArchive)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+	t.Run("update keeps status", func(t *testing.T) {
+		saved, _ := m.GetCourse(tenantAlice, cID)
+		course := *saved.Course
+		course.Status = entity.CourseOpen
+		recorder.EXPECT().Record(testActor, tenantAlice, entity.AuditCourse, cID, entity.AuditUpdate,
+			gomock.Any(), gomock.Any())
+		assert.Nil(t, m.UpdateCourse(testActor, course, nil, nil, nil, nil, nil))
+		saved, _ = m.GetCourse(tenantAlice, cID)
+		assert.Equal(t, entity.CourseCanceled, saved.Course.Status)
+	})
+	t.Run("history", func(t *testing.T) {
+		changes, err := m.ListCourseTransitions(tenantAlice, cID)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, len(changes))
+		assert.Equal(t, entity.CourseDraft, changes[0].From)
+		assert.Equal(t, entity.CourseActionCancel, changes[3].Action)
+
+		_, err = m.ListCourseTransitions(tenantBob, cID)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+}
+
+func TestSetCourseExtID(t *testing.T) {
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	tmpl := newFixtureCourse()
+	tmpl.ExtID = nil
+
+	cID, _, err := m.CreateCourse(testActor, *tmpl, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, glad.ErrInvalidValue, m.SetCourseExtID(testActor, tenantAlice, cID, ""))
+	assert.Nil(t, m.SetCourseExtID(testActor, tenantAlice, cID, aliceExtID))
+	saved, _ := m.GetCourse(tenantAlice, cID)
+	assert.Equal(t, aliceExtID, *saved.Course.ExtID)
+
+	t.Run("already linked", func(t *testing.T) {
+		assert.Nil(t, m.SetCourseExtID(testActor, tenantAlice, cID, aliceExtID))
+		assert.Equal(t, glad.ErrAlreadyExists,
+			m.SetCourseExtID(testActor, tenantAlice, cID, "000otherExtID"))
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Equal(t, glad.ErrNotFound,
+			m.SetCourseExtID(testActor, tenantBob, cID, aliceExtID))
+		assert.Equal(t, glad.ErrNotFound,
+			m.SetCourseExtID(testActor, tenantAlice, id.New(), aliceExtID))
+	})
+}
+
+func TestArchiveByExtID(t *testing.T) {
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	tmpl := newFixtureCourse()
+	tmpl.Status = entity.CourseOpen
+	actor := entity.AuditActor{AccountID: aliceOrganizer1ID, Source: entity.AuditSourceSalesforce}
+
+	cID, _, err := m.CreateCourse(testActor, *tmpl, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	// open courses cannot be archived through the lifecycle
+	archivedID, err := m.ArchiveCourseByExtID(actor, tenantAlice, aliceExtID)
+	assert.Nil(t, err)
+	assert.Equal(t, cID, archivedID)
+	saved, _ := m.GetCourse(tenantAlice, cID)
+	assert.Equal(t, entity.CourseArchived, saved.Course.Status)
+
+	_, err = m.ArchiveCourseByExtID(actor, tenantAlice, aliceExtID)
 	assert.Nil(t, err)
-	_, err = m.GetCourse(t2ID)
+
+	restoredID, err := m.RestoreCourseByExtID(actor, tenantAlice, aliceExtID)
+	assert.Nil(t, err)
+	assert.Equal(t, cID, restoredID)
+	saved, _ = m.GetCourse(tenantAlice, cID)
+	assert.Equal(t, entity.CourseOpen, saved.Course.Status)
+
+	t.Run("history", func(t *testing.T) {
+		changes, err := m.ListCourseTransitions(tenantAlice, cID)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(changes))
+		assert.Equal(t, entity.CourseActionArchive, changes[0].Action)
+		assert.Equal(t, entity.CourseOpen, changes[0].From)
+		assert.Equal(t, entity.CourseActionRestore, changes[1].Action)
+		assert.Equal(t, entity.CourseOpen, changes[1].To)
+		assert.Equal(t, actor.AccountID, changes[1].ActorID)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := m.ArchiveCourseByExtID(actor, tenantBob, aliceExtID)
+		assert.Equal(t, glad.ErrNotFound, err)
+		_, err = m.RestoreCourseByExtID(actor, tenantAlice, "")
+		assert.Equal(t, glad.ErrInvalidValue, err)
+	})
+}
+
+func TestUpsertCourses(t *testing.T) {
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+
+	newCourse := func(extID *string, name string) *entity.Course {
+		c := newFixtureCourse()
+		c.ID = id.IDInvalid
+		c.TenantID = id.IDInvalid
+		c.ExtID = extID
+		c.Name = name
+		return c
+	}
+	extID := aliceExtID
+	otherExtID := "000otherExtID"
+	emptyExtID := ""
+	courses := []*entity.Course{
+		newCourse(&extID, "first"),
+		newCourse(&otherExtID, "other"),
+		newCourse(&extID, ""),
+		newCourse(nil, "no ext id"),
+		newCourse(&emptyExtID, "empty ext id"),
+		newCourse(&extID, "last"),
+	}
+
+	ids, err := m.UpsertCourses(testActor, tenantAlice, courses)
+	assert.Nil(t, err)
+	assert.Equal(t, len(courses), len(ids))
+
+	t.Run("last record wins", func(t *testing.T) {
+		assert.NotEqual(t, id.ID(id.IDInvalid), ids[0])
+		assert.Equal(t, ids[0], ids[5])
+		saved, err := m.GetCourse(tenantAlice, ids[5])
+		assert.Nil(t, err)
+		assert.Equal(t, "last", saved.Course.Name)
+		assert.Equal(t, tenantAlice, saved.Course.TenantID)
+	})
+	t.Run("invalid records", func(t *testing.T) {
+		assert.NotEqual(t, id.ID(id.IDInvalid), ids[1])
+		assert.Equal(t, id.ID(id.IDInvalid), ids[2])
+		assert.Equal(t, id.ID(id.IDInvalid), ids[3])
+		assert.Equal(t, id.ID(id.IDInvalid), ids[4])
+	})
+	t.Run("update keeps id", func(t *testing.T) {
+		again, err := m.UpsertCourses(testActor, tenantAlice,
+			[]*entity.Course{newCourse(&otherExtID, "renamed")})
+		assert.Nil(t, err)
+		assert.Equal(t, ids[1], again[0])
+		saved, _ := m.GetCourse(tenantAlice, ids[1])
+		assert.Equal(t, "renamed", saved.Course.Name)
+	})
+	t.Run("empty batch", func(t *testing.T) {
+		ids, err := m.UpsertCourses(testActor, tenantAlice, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(ids))
+	})
+}
+
+func TestUpsertCourseTiming(t *testing.T) {
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	tmpl := newFixtureCourse()
+
+	cID, _, err := m.CreateCourse(testActor, *tmpl, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	timingExtID := "000timingExtID"
+	newTiming := func(courseID id.ID, extID *string, date, startTime string) *entity.CourseTiming {
+		return &entity.CourseTiming{
+			CourseID: courseID,
+			ExtID:    extID,
+			DateTime: entity.CourseDateTime{Date: date, StartTime: startTime, EndTime: "12:00:00"},
+		}
+	}
+
+	ctID, err := m.UpsertCourseTiming(tenantAlice, newTiming(cID, &timingExtID, "2024-11-02", "09:00:00"))
+	assert.Nil(t, err)
+	uID, err := m.UpsertCourseTiming(tenantAlice, newTiming(cID, &timingExtID, "2024-11-02", "10:00:00"))
+	assert.Nil(t, err)
+	assert.Equal(t, ctID, uID)
+	saved, _ := ctRepo.Get(ctID)
+	assert.Equal(t, "10:00:00", saved.DateTime.StartTime)
+
+	t.Run("takes over timing without ext id", func(t *testing.T) {
+		existingID, err := ctRepo.Create(&entity.CourseTiming{
+			ID:       id.New(),
+			CourseID: cID,
+			DateTime: entity.CourseDateTime{Date: "2024-11-03", StartTime: "09:00:00", EndTime: "12:00:00"},
+		})
+		assert.Nil(t, err)
+		extID := "000otherTimingExtID"
+		ctID, err := m.UpsertCourseTiming(tenantAlice, newTiming(cID, &extID, "2024-11-03", "09:30:00"))
+		assert.Nil(t, err)
+		assert.Equal(t, existingID, ctID)
+		timings, _ := ctRepo.GetByCourse(cID)
+		assert.Equal(t, 2, len(timings))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := m.UpsertCourseTiming(tenantAlice, newTiming(cID, nil, "2024-11-02", "09:00:00"))
+		assert.Equal(t, glad.ErrInvalidValue, err)
+		_, err = m.UpsertCourseTiming(tenantAlice, newTiming(cID, &timingExtID, "2024-11-02", ""))
+		assert.Equal(t, glad.ErrInvalidEntity, err)
+		_, err = m.UpsertCourseTiming(tenantBob, newTiming(cID, &timingExtID, "2024-11-02", "09:00:00"))
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+	t.Run("remove", func(t *testing.T) {
+		removedID, err := m.RemoveCourseTimingByExtID(tenantAlice, timingExtID)
+		assert.Nil(t, err)
+		assert.Equal(t, ctID, removedID)
+		_, err = m.RemoveCourseTimingByExtID(tenantAlice, timingExtID)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+}
+
+func TestCourseRosterByExtID(t *testing.T) {
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	tmpl := newFixtureCourse()
+
+	cID, _, err := m.CreateCourse(testActor, *tmpl, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	teacherExtID := "000teacherExtID"
+	organizerExtID := "000organizerExtID"
+	assert.Nil(t, m.UpsertCourseTeacherByExtID(tenantAlice, cID, teacherExtID,
+		&entity.CourseTeacher{ID: aliceTeacher1ID, IsPrimary: true}))
+	assert.Nil(t, m.UpsertCourseOrganizerByExtID(tenantAlice, cID, organizerExtID,
+		&entity.CourseOrganizer{ID: aliceOrganizer1ID}))
+	assert.True(t, repo.rosters[teacherExtID].isTeacher)
+	assert.Equal(t, id.ID(aliceOrganizer1ID), repo.rosters[organizerExtID].accountID)
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, glad.ErrInvalidValue, m.UpsertCourseTeacherByExtID(tenantAlice, cID, "",
+			&entity.CourseTeacher{ID: aliceTeacher1ID}))
+		assert.Equal(t, glad.ErrNotFound, m.UpsertCourseOrganizerByExtID(tenantBob, cID, organizerExtID,
+			&entity.CourseOrganizer{ID: aliceOrganizer1ID}))
+	})
+	t.Run("other tenant", func(t *testing.T) {
+		bobCourse := newFixtureCourse()
+		bobCourse.TenantID = tenantBob
+		bobID, _, err := m.CreateCourse(testActor, *bobCourse, nil, nil, nil, nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, glad.ErrAlreadyExists, m.UpsertCourseTeacherByExtID(tenantBob, bobID,
+			teacherExtID, &entity.CourseTeacher{ID: aliceTeacher1ID}))
+		assert.Equal(t, cID, repo.rosters[teacherExtID].courseID)
+	})
+	t.Run("remove", func(t *testing.T) {
+		_, err := m.RemoveCourseRosterByExtID(tenantBob, teacherExtID)
+		assert.Equal(t, glad.ErrNotFound, err)
+		courseID, err := m.RemoveCourseRosterByExtID(tenantAlice, teacherExtID)
+		assert.Nil(t, err)
+		assert.Equal(t, cID, courseID)
+		_, err = m.RemoveCourseRosterByExtID(tenantAlice, teacherExtID)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+}
+
+func TestTeacherEligibility(t *testing.T) {
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	tmpl := newFixtureCourse()
+
+	_, _, err := m.CreateCourse(testActor, *tmpl, nil, newCourseTeacher(), nil, nil, nil)
+	assert.Equal(t, glad.ErrNotEligible, err)
+
+	addFixtureEligibility(repo)
+	cID, _, err := m.CreateCourse(testActor, *tmpl, nil, newCourseTeacher(), nil, nil, nil)
+	assert.Nil(t, err)
+	saved, _ := m.GetCourse(tenantAlice, cID)
+
+	t.Run("assistant cannot be primary", func(t *testing.T) {
+		cts := []*entity.CourseTeacher{{ID: aliceTeacher2ID, IsPrimary: true}}
+		assert.Equal(t, glad.ErrNotEligible,
+			m.UpdateCourse(testActor, *saved.Course, nil, cts, nil, nil, nil))
+
+		repo.addEligibility(tenantAlice, aliceProductID, aliceTeacher2ID, entity.EligibilityPrimary)
+		assert.Nil(t, m.UpdateCourse(testActor, *saved.Course, nil, cts, nil, nil, nil))
+	})
+	t.Run("other product", func(t *testing.T) {
+		other := *saved.Course
+		other.ProductID = id.New()
+		assert.Equal(t, glad.ErrNotEligible,
+			m.UpdateCourse(testActor, other, nil, newCourseTeacher(), nil, nil, nil))
+	})
+	t.Run("product of another tenant", func(t *testing.T) {
+		bobProductID := id.New()
+		repo.addEligibility(tenantBob, bobProductID, aliceTeacher1ID, entity.EligibilityPrimary)
+		other := *saved.Course
+		other.ProductID = bobProductID
+		assert.Equal(t, glad.ErrNotEligible,
+			m.UpdateCourse(testActor, other, nil, newCourseTeacher(), nil, nil, nil))
+	})
+	t.Run("no teachers", func(t *testing.T) {
+		assert.Nil(t, m.UpdateCourse(testActor, *saved.Course, nil, nil, nil, nil, nil))
+	})
+}
+
+func TestTimezones(t *testing.T) {
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	create := func(c entity.Course, timings ...entity.CourseDateTime) (id.ID, error) {
+		var cts []*entity.CourseTiming
+		for _, dt := range timings {
+			cts = append(cts, &entity.CourseTiming{DateTime: dt})
+		}
+		cID, _, err := m.CreateCourse(testActor, c, nil, nil, nil, nil, cts)
+		return cID, err
+	}
+
+	t.Run("legacy abbreviation", func(t *testing.T) {
+		cID, err := create(*newFixtureCourse(),
+			entity.CourseDateTime{Date: "2024-11-03", StartTime: "09:00", EndTime: "12:00"})
+		assert.Nil(t, err)
+		saved, _ := m.GetCourse(tenantAlice, cID)
+		assert.Equal(t, "America/Los_Angeles", saved.Course.Timezone)
+		assert.Equal(t, "09:00:00", saved.CourseTiming[0].DateTime.StartTime)
+	})
+	t.Run("invalid timezone", func(t *testing.T) {
+		c := *newFixtureCourse()
+		c.Timezone = "Mars/Olympus_Mons"
+		_, err := create(c)
+		assert.Equal(t, glad.ErrInvalidEntity, err)
+	})
+	t.Run("invalid timings", func(t *testing.T) {
+		for _, dt := range []entity.CourseDateTime{
+			{Date: "2024-06-31", StartTime: "09:00", EndTime: "12:00"},
+			{Date: "2024-06-01", StartTime: "9am", EndTime: "12:00"},
+			{Date: "2024-06-01", StartTime: "12:00", EndTime: "09:00"},
+			// skipped when the clocks go forward in Los Angeles
+			{Date: "2024-03-10", StartTime: "02:30", EndTime: "04:00"},
+		} {
+			_, err := create(*newFixtureCourse(), dt)
+			assert.Equal(t, glad.ErrInvalidEntity, err, dt)
+		}
+	})
+	t.Run("center timezone", func(t *testing.T) {
+		repo.addCenterTimezone(aliceCenterID, "Asia/Kolkata")
+		c := *newFixtureCourse()
+		c.Timezone = ""
+		cID, err := create(c)
+		assert.Nil(t, err)
+		saved, _ := m.GetCourse(tenantAlice, cID)
+		assert.Equal(t, "Asia/Kolkata", saved.Course.Timezone)
+	})
+}
+
+func TestRecurrence(t *testing.T) {
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	dates := func(cf *entity.CourseFull) []string {
+		var dates []string
+		for _, ct := range cf.CourseTiming {
+			dates = append(dates, ct.DateTime.Date+" "+ct.DateTime.StartTime)
+		}
+		sort.Strings(dates)
+		return dates
+	}
+
+	t.Run("weekly", func(t *testing.T) {
+		c := *newFixtureCourse()
+		c.Recurrence = &entity.CourseRecurrence{
+			// Tuesdays and Thursdays, from Tuesday 2024-06-04
+			Rule:      "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=5",
+			StartDate: "2024-06-04",
+			StartTime: "18:00",
+			EndTime:   "20:00",
+			ExDates:   []string{"2024-06-13"},
+		}
+		cID, ctIDs, err := m.CreateCourse(testActor, c, nil, nil, nil, nil, nil)
+		assert.Nil(t, err)
+		assert.Len(t, ctIDs, 4)
+
+		saved, _ := m.GetCourse(tenantAlice, cID)
+		assert.Equal(t, []string{
+			"2024-06-04 18:00:00",
+			"2024-06-06 18:00:00",
+			"2024-06-11 18:00:00",
+			"2024-06-18 18:00:00",
+		}, dates(saved))
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=5", saved.Course.Recurrence.Rule)
+	})
+	t.Run("invalid rule", func(t *testing.T) {
+		for _, rule := range []string{
+			"FREQ=MONTHLY;COUNT=3",
+			"FREQ=DAILY",
+			"FREQ=DAILY;COUNT=3;UNTIL=20240630",
+			"FREQ=WEEKLY;BYDAY=XX;COUNT=3",
+			"FREQ=DAILY;COUNT=1000",
+		} {
+			c := *newFixtureCourse()
+			c.Recurrence = &entity.CourseRecurrence{
+				Rule:      rule,
+				StartDate: "2024-06-04",
+				StartTime: "18:00",
+				EndTime:   "20:00",
+			}
+			_, _, err := m.CreateCourse(testActor, c, nil, nil, nil, nil, nil)
+			assert.Equal(t, glad.ErrInvalidEntity, err, rule)
+		}
+	})
+	t.Run("edit keeps past sessions", func(t *testing.T) {
+		loc, _ := entity.TimezoneLocation(newFixtureCourse().Timezone)
+		today := time.Now().In(loc)
+		day := func(days int) string {
+			return today.AddDate(0, 0, days).Format(entity.CourseDateFormat)
+		}
+
+		c := *newFixtureCourse()
+		c.Recurrence = &entity.CourseRecurrence{
+			Rule:      "FREQ=DAILY;COUNT=4",
+			StartDate: day(-2),
+			StartTime: "09:00",
+			EndTime:   "11:00",
+		}
+		cID, _, err := m.CreateCourse(testActor, c, nil, nil, nil, nil, nil)
+		assert.Nil(t, err)
+
+		saved, _ := m.GetCourse(tenantAlice, cID)
+		saved.Course.Recurrence.StartTime = "10:00"
+		saved.Course.Recurrence.ExDates = []string{day(1)}
+		assert.Nil(t, m.UpdateCourse(testActor, *saved.Course, nil, nil, nil, nil, nil))
+
+		saved, _ = m.GetCourse(tenantAlice, cID)
+		assert.Equal(t, []string{
+			day(-2) + " 09:00:00",
+			day(-1) + " 09:00:00",
+			day(0) + " 10:00:00",
+		}, dates(saved))
+	})
+}
+
+// newOtherSchedule course of alice at the center taught by the first teacher,
+// 10:00-12:00 PST on 2024-06-01
+func newOtherSchedule(status entity.CourseStatus) *entity.CourseSchedule {
+	return &entity.CourseSchedule{
+		Course: &entity.Course{
+			ID:           id.New(),
+			TenantID:     tenantAlice,
+			CenterID:     aliceCenterID,
+			Timezone:     "EST",
+			Status:       status,
+			Mode:         entity.CourseInPerson,
+			MaxAttendees: 30,
+		},
+		Timings: []*entity.CourseTiming{{
+			ID:       id.New(),
+			DateTime: entity.CourseDateTime{Date: "2024-06-01", StartTime: "13:00:00", EndTime: "15:00:00"},
+		}},
+		TeacherIDs:     []id.ID{aliceTeacher1ID},
+		CenterCapacity: 60,
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	repo := newInmemCourse()
+	addFixtureEligibility(repo)
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	other := newOtherSchedule(entity.CourseActive)
+	repo.addSchedule(other)
+	repo.addSchedule(newOtherSchedule(entity.CourseCanceled))
+
+	timing := func(start, end string) []*entity.CourseTiming {
+		return []*entity.CourseTiming{{
+			DateTime: entity.CourseDateTime{Date: "2024-06-01", StartTime: start, EndTime: end},
+		}}
+	}
+
+	t.Run("teacher and center", func(t *testing.T) {
+		_, _, err := m.CreateCourse(testActor, *newFixtureCourse(), nil, newCourseTeacher(), nil, nil,
+			timing("11:00", "12:30"))
+		assert.ErrorIs(t, err, glad.ErrScheduleConflict)
+		var ce *entity.ConflictError
+		assert.ErrorAs(t, err, &ce)
+		assert.Equal(t, 2, len(ce.Conflicts))
+		assert.Equal(t, entity.ConflictTeacher, ce.Conflicts[0].Kind)
+		assert.Equal(t, id.ID(aliceTeacher1ID), ce.Conflicts[0].AccountID)
+		assert.Equal(t, other.Course.ID, ce.Conflicts[0].With.CourseID)
+		assert.Equal(t, entity.ConflictCenter, ce.Conflicts[1].Kind)
+		assert.Equal(t, int32(80), ce.Conflicts[1].Attendees)
+		assert.Equal(t, time.Date(2024, 6, 1, 17, 0, 0, 0, time.UTC), ce.Conflicts[1].With.Start.UTC())
+	})
+	t.Run("center only", func(t *testing.T) {
+		cts := []*entity.CourseTeacher{{ID: aliceTeacher2ID}}
+		_, _, err := m.CreateCourse(testActor, *newFixtureCourse(), nil, cts, nil, nil,
+			timing("11:00", "12:30"))
+		var ce *entity.ConflictError
+		assert.ErrorAs(t, err, &ce)
+		assert.Equal(t, 1, len(ce.Conflicts))
+		assert.Equal(t, entity.ConflictCenter, ce.Conflicts[0].Kind)
+
+		// online courses do not use the center
+		online := *newFixtureCourse()
+		online.Mode = entity.CourseOnline
+		_, _, err = m.CreateCourse(testActor, online, nil, cts, nil, nil, timing("11:00", "12:30"))
+		assert.Nil(t, err)
+	})
+	t.Run("timezone", func(t *testing.T) {
+		// 13:30 PST is after the other course, though 13:30 EST is not
+		cID, _, err := m.CreateCourse(testActor, *newFixtureCourse(), nil, newCourseTeacher(), nil, nil,
+			timing("13:30", "14:30"))
+		assert.Nil(t, err)
+
+		// moving the session onto the other course is rejected
+		saved, _ := m.GetCourse(tenantAlice, cID)
+		moved, _ := ctRepo.GetByCourse(cID)
+		moved[0].DateTime.StartTime = "09:00"
+		err = m.UpdateCourse(testActor, *saved.Course, nil, newCourseTeacher(), nil, nil, moved)
+		assert.ErrorIs(t, err, glad.ErrScheduleConflict)
+	})
+}
+
+func TestListConflicts(t *testing.T) {
+	repo := newInmemCourse()
+	ctRepo := newInmemCourseTiming()
+	m := NewService(repo, ctRepo, newInmemUnitOfWork(repo, ctRepo), audit.Discard)
+	first := newOtherSchedule(entity.CourseActive)
+	second := newOtherSchedule(entity.CourseDraft)
+	second.Course.MaxAttendees = 10
+	second.Timings[0].DateTime.StartTime = "14:00:00"
+	repo.addSchedule(first)
+	repo.addSchedule(second)
+
+	conflicts, err := m.ListConflicts(tenantAlice, &entity.CourseConflictQuery{
+		AccountID: aliceTeacher1ID,
+		From:      "2024-06-01",
+		To:        "2024-06-30",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(conflicts))
+	assert.Equal(t, entity.ConflictTeacher, conflicts[0].Kind)
+	assert.Equal(t, first.Course.ID, conflicts[0].Session.CourseID)
+	assert.Equal(t, second.Course.ID, conflicts[0].With.CourseID)
+
+	conflicts, err = m.ListConflicts(tenantAlice, &entity.CourseConflictQuery{
+		CenterID: aliceCenterID,
+		From:     "2024-06-02",
+		To:       "2024-06-30",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(conflicts))
+
+	_, err = m.ListConflicts(tenantAlice, &entity.CourseConflictQuery{From: "2024-06-01", To: "2024-06-30"})
+	assert.Equal(t, glad.ErrMissingParam, err)
+	_, err = m.ListConflicts(tenantAlice, &entity.CourseConflictQuery{
+		CenterID: aliceCenterID,
+		From:     "2024-06-01",
+		To:       "2026-06-30",
+	})
+	assert.Equal(t, glad.ErrInvalidValue, err)
+	_, err = m.ListConflicts(tenantAlice, &entity.CourseConflictQuery{CourseID: id.New()})
 	assert.Equal(t, glad.ErrNotFound, err)
 }
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package dead_letter
+
+import (
+	"sort"
+	"sync"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// inmem in memory repo
+type inmem struct {
+	m   map[id.ID]*entity.DeadLetter
+	mut *sync.RWMutex
+}
+
+// newInmem create new repository
+func newInmem() *inmem {
+	return &inmem{
+		m:   map[id.ID]*entity.DeadLetter{},
+		mut: &sync.RWMutex{},
+	}
+}
+
+// Create a dead letter
+func (r *inmem) Create(e *entity.DeadLetter) (id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	if _, ok := r.m[e.ID]; ok {
+		return id.IDInvalid, glad.ErrAlreadyExists
+	}
+	c := *e
+	r.m[e.ID] = &c
+	return e.ID, nil
+}
+
+// Get a dead letter
+func (r *inmem) Get(tenantID id.ID, deadLetterID id.ID) (*entity.DeadLetter, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	e, ok := r.m[deadLetterID]
+	if !ok || e.TenantID != tenantID {
+		return nil, glad.ErrNotFound
+	}
+	c := *e
+	return &c, nil
+}
+
+// GetByExtID gets the dead letter of the record
+func (r *inmem) GetByExtID(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	extID string,
+) (*entity.DeadLetter, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	for _, e := range r.m {
+		if e.TenantID == tenantID && e.EntityType == entityType && e.ExtID == extID {
+			c := *e
+			return &c, nil
+		}
+	}
+	return nil, glad.ErrNotFound
+}
+
+// List dead letters, oldest first
+func (r *inmem) List(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	page, limit int,
+) (int, []*entity.DeadLetter, error) {
+	letters := r.filter(func(e *entity.DeadLetter) bool {
+		return e.TenantID == tenantID && (entityType == "" || e.EntityType == entityType)
+	})
+
+	count := len(letters)
+	if page > 0 && limit > 0 {
+		start := min((page-1)*limit, count)
+		end := min(start+limit, count)
+		letters = letters[start:end]
+	}
+	return count, letters, nil
+}
+
+// ListByDependency lists the dead letters waiting for the entities
+func (r *inmem) ListByDependency(tenantID id.ID,
+	dependencyType entity.AuditEntityType,
+	dependencyExtIDs []string,
+) ([]*entity.DeadLetter, error) {
+	extIDs := make(map[string]bool, len(dependencyExtIDs))
+	for _, extID := range dependencyExtIDs {
+		extIDs[extID] = true
+	}
+	return r.filter(func(e *entity.DeadLetter) bool {
+		return e.TenantID == tenantID && e.DependencyType == dependencyType &&
+			extIDs[e.DependencyExtID]
+	}), nil
+}
+
+// Update a dead letter
+func (r *inmem) Update(e *entity.DeadLetter) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	if _, ok := r.m[e.ID]; !ok {
+		return glad.ErrNotFound
+	}
+	c := *e
+	r.m[e.ID] = &c
+	return nil
+}
+
+// Delete a dead letter
+func (r *inmem) Delete(tenantID id.ID, deadLetterID id.ID) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	e, ok := r.m[deadLetterID]
+	if !ok || e.TenantID != tenantID {
+		return glad.ErrNotFound
+	}
+	delete(r.m, deadLetterID)
+	return nil
+}
+
+// DeleteByExtIDs deletes the dead letters of the records
+func (r *inmem) DeleteByExtIDs(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	extIDs []string,
+) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	for _, extID := range extIDs {
+		for k, e := range r.m {
+			if e.TenantID == tenantID && e.EntityType == entityType && e.ExtID == extID {
+				delete(r.m, k)
+			}
+		}
+	}
+	return nil
+}
+
+// filter lists copies of the matching dead letters, oldest first
+func (r *inmem) filter(match func(e *entity.DeadLetter) bool) []*entity.DeadLetter {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	var letters []*entity.DeadLetter
+	for _, e := range r.m {
+		if match(e) {
+			c := *e
+			letters = append(letters, &c)
+		}
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].CreatedAt.Before(letters[j].CreatedAt)
+	})
+	return letters
+}
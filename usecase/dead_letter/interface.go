@@ -0,0 +1,66 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package dead_letter
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// Reader dead letter reader
+type Reader interface {
+	Get(tenantID id.ID, deadLetterID id.ID) (*entity.DeadLetter, error)
+	GetByExtID(tenantID id.ID,
+		entityType entity.AuditEntityType,
+		extID string,
+	) (*entity.DeadLetter, error)
+	// List lists the dead letters of an entity type, oldest first. All the
+	// types are listed when entityType is empty.
+	List(tenantID id.ID,
+		entityType entity.AuditEntityType,
+		page, limit int,
+	) (int, []*entity.DeadLetter, error)
+	// ListByDependency lists the dead letters waiting for any of the entities
+	ListByDependency(tenantID id.ID,
+		dependencyType entity.AuditEntityType,
+		dependencyExtIDs []string,
+	) ([]*entity.DeadLetter, error)
+}
+
+// Writer dead letter writer
+type Writer interface {
+	Create(e *entity.DeadLetter) (id.ID, error)
+	Update(e *entity.DeadLetter) error
+	Delete(tenantID id.ID, deadLetterID id.ID) error
+	DeleteByExtIDs(tenantID id.ID, entityType entity.AuditEntityType, extIDs []string) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// UseCase interface
+type UseCase interface {
+	// AddDeadLetter keeps the failed record. A record that failed before is
+	// replaced by the new attempt and its attempts are counted.
+	AddDeadLetter(e *entity.DeadLetter) error
+	GetDeadLetter(tenantID id.ID, deadLetterID id.ID) (*entity.DeadLetter, error)
+	ListDeadLetters(tenantID id.ID,
+		entityType entity.AuditEntityType,
+		page, limit int,
+	) (int, []*entity.DeadLetter, error)
+	ListDependentDeadLetters(tenantID id.ID,
+		dependencyType entity.AuditEntityType,
+		dependencyExtIDs []string,
+	) ([]*entity.DeadLetter, error)
+	// ResolveDeadLetters removes the dead letters of the records that are
+	// now imported
+	ResolveDeadLetters(tenantID id.ID, entityType entity.AuditEntityType, extIDs []string) error
+	DiscardDeadLetter(tenantID id.ID, deadLetterID id.ID) error
+}
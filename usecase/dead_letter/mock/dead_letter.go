@@ -0,0 +1,429 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/dead_letter/interface.go
+
+// Package mock_dead_letter is a generated GoMock package.
+package mock_dead_letter
+
+import (
+	entity "ac9/glad/entity"
+	id "ac9/glad/pkg/id"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReader) Get(tenantID, deadLetterID id.ID) (*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", tenantID, deadLetterID)
+	ret0, _ := ret[0].(*entity.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReaderMockRecorder) Get(tenantID, deadLetterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, deadLetterID)
+}
+
+// GetByExtID mocks base method.
+func (m *MockReader) GetByExtID(tenantID id.ID, entityType entity.AuditEntityType, extID string) (*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", tenantID, entityType, extID)
+	ret0, _ := ret[0].(*entity.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockReaderMockRecorder) GetByExtID(tenantID, entityType, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockReader)(nil).GetByExtID), tenantID, entityType, extID)
+}
+
+// List mocks base method.
+func (m *MockReader) List(tenantID id.ID, entityType entity.AuditEntityType, page, limit int) (int, []*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", tenantID, entityType, page, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]*entity.DeadLetter)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockReaderMockRecorder) List(tenantID, entityType, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List), tenantID, entityType, page, limit)
+}
+
+// ListByDependency mocks base method.
+func (m *MockReader) ListByDependency(tenantID id.ID, dependencyType entity.AuditEntityType, dependencyExtIDs []string) ([]*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByDependency", tenantID, dependencyType, dependencyExtIDs)
+	ret0, _ := ret[0].([]*entity.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByDependency indicates an expected call of ListByDependency.
+func (mr *MockReaderMockRecorder) ListByDependency(tenantID, dependencyType, dependencyExtIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByDependency", reflect.TypeOf((*MockReader)(nil).ListByDependency), tenantID, dependencyType, dependencyExtIDs)
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWriter) Create(e *entity.DeadLetter) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", e)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWriterMockRecorder) Create(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWriter)(nil).Create), e)
+}
+
+// Delete mocks base method.
+func (m *MockWriter) Delete(tenantID, deadLetterID id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", tenantID, deadLetterID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWriterMockRecorder) Delete(tenantID, deadLetterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), tenantID, deadLetterID)
+}
+
+// DeleteByExtIDs mocks base method.
+func (m *MockWriter) DeleteByExtIDs(tenantID id.ID, entityType entity.AuditEntityType, extIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByExtIDs", tenantID, entityType, extIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByExtIDs indicates an expected call of DeleteByExtIDs.
+func (mr *MockWriterMockRecorder) DeleteByExtIDs(tenantID, entityType, extIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByExtIDs", reflect.TypeOf((*MockWriter)(nil).DeleteByExtIDs), tenantID, entityType, extIDs)
+}
+
+// Update mocks base method.
+func (m *MockWriter) Update(e *entity.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWriterMockRecorder) Update(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWriter)(nil).Update), e)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(e *entity.DeadLetter) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", e)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), e)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(tenantID, deadLetterID id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", tenantID, deadLetterID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(tenantID, deadLetterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), tenantID, deadLetterID)
+}
+
+// DeleteByExtIDs mocks base method.
+func (m *MockRepository) DeleteByExtIDs(tenantID id.ID, entityType entity.AuditEntityType, extIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByExtIDs", tenantID, entityType, extIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByExtIDs indicates an expected call of DeleteByExtIDs.
+func (mr *MockRepositoryMockRecorder) DeleteByExtIDs(tenantID, entityType, extIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByExtIDs", reflect.TypeOf((*MockRepository)(nil).DeleteByExtIDs), tenantID, entityType, extIDs)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(tenantID, deadLetterID id.ID) (*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", tenantID, deadLetterID)
+	ret0, _ := ret[0].(*entity.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(tenantID, deadLetterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, deadLetterID)
+}
+
+// GetByExtID mocks base method.
+func (m *MockRepository) GetByExtID(tenantID id.ID, entityType entity.AuditEntityType, extID string) (*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", tenantID, entityType, extID)
+	ret0, _ := ret[0].(*entity.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockRepositoryMockRecorder) GetByExtID(tenantID, entityType, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockRepository)(nil).GetByExtID), tenantID, entityType, extID)
+}
+
+// List mocks base method.
+func (m *MockRepository) List(tenantID id.ID, entityType entity.AuditEntityType, page, limit int) (int, []*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", tenantID, entityType, page, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]*entity.DeadLetter)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(tenantID, entityType, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), tenantID, entityType, page, limit)
+}
+
+// ListByDependency mocks base method.
+func (m *MockRepository) ListByDependency(tenantID id.ID, dependencyType entity.AuditEntityType, dependencyExtIDs []string) ([]*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByDependency", tenantID, dependencyType, dependencyExtIDs)
+	ret0, _ := ret[0].([]*entity.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByDependency indicates an expected call of ListByDependency.
+func (mr *MockRepositoryMockRecorder) ListByDependency(tenantID, dependencyType, dependencyExtIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByDependency", reflect.TypeOf((*MockRepository)(nil).ListByDependency), tenantID, dependencyType, dependencyExtIDs)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(e *entity.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), e)
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// AddDeadLetter mocks base method.
+func (m *MockUseCase) AddDeadLetter(e *entity.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeadLetter", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeadLetter indicates an expected call of AddDeadLetter.
+func (mr *MockUseCaseMockRecorder) AddDeadLetter(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeadLetter", reflect.TypeOf((*MockUseCase)(nil).AddDeadLetter), e)
+}
+
+// DiscardDeadLetter mocks base method.
+func (m *MockUseCase) DiscardDeadLetter(tenantID, deadLetterID id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardDeadLetter", tenantID, deadLetterID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardDeadLetter indicates an expected call of DiscardDeadLetter.
+func (mr *MockUseCaseMockRecorder) DiscardDeadLetter(tenantID, deadLetterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardDeadLetter", reflect.TypeOf((*MockUseCase)(nil).DiscardDeadLetter), tenantID, deadLetterID)
+}
+
+// GetDeadLetter mocks base method.
+func (m *MockUseCase) GetDeadLetter(tenantID, deadLetterID id.ID) (*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetter", tenantID, deadLetterID)
+	ret0, _ := ret[0].(*entity.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetter indicates an expected call of GetDeadLetter.
+func (mr *MockUseCaseMockRecorder) GetDeadLetter(tenantID, deadLetterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetter", reflect.TypeOf((*MockUseCase)(nil).GetDeadLetter), tenantID, deadLetterID)
+}
+
+// ListDeadLetters mocks base method.
+func (m *MockUseCase) ListDeadLetters(tenantID id.ID, entityType entity.AuditEntityType, page, limit int) (int, []*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadLetters", tenantID, entityType, page, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]*entity.DeadLetter)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeadLetters indicates an expected call of ListDeadLetters.
+func (mr *MockUseCaseMockRecorder) ListDeadLetters(tenantID, entityType, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadLetters", reflect.TypeOf((*MockUseCase)(nil).ListDeadLetters), tenantID, entityType, page, limit)
+}
+
+// ListDependentDeadLetters mocks base method.
+func (m *MockUseCase) ListDependentDeadLetters(tenantID id.ID, dependencyType entity.AuditEntityType, dependencyExtIDs []string) ([]*entity.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDependentDeadLetters", tenantID, dependencyType, dependencyExtIDs)
+	ret0, _ := ret[0].([]*entity.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDependentDeadLetters indicates an expected call of ListDependentDeadLetters.
+func (mr *MockUseCaseMockRecorder) ListDependentDeadLetters(tenantID, dependencyType, dependencyExtIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDependentDeadLetters", reflect.TypeOf((*MockUseCase)(nil).ListDependentDeadLetters), tenantID, dependencyType, dependencyExtIDs)
+}
+
+// ResolveDeadLetters mocks base method.
+func (m *MockUseCase) ResolveDeadLetters(tenantID id.ID, entityType entity.AuditEntityType, extIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDeadLetters", tenantID, entityType, extIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveDeadLetters indicates an expected call of ResolveDeadLetters.
+func (mr *MockUseCaseMockRecorder) ResolveDeadLetters(tenantID, entityType, extIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDeadLetters", reflect.TypeOf((*MockUseCase)(nil).ResolveDeadLetters), tenantID, entityType, extIDs)
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package dead_letter
+
+import (
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// Service dead letter usecase
+type Service struct {
+	repo Repository
+}
+
+// NewService create new service
+func NewService(r Repository) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// AddDeadLetter keeps the failed record, counting the attempts of a record
+// that failed before
+func (s *Service) AddDeadLetter(e *entity.DeadLetter) error {
+	err := e.Validate()
+	if err != nil {
+		return err
+	}
+
+	prev, err := s.repo.GetByExtID(e.TenantID, e.EntityType, e.ExtID)
+	if err != nil && err != glad.ErrNotFound {
+		return err
+	}
+	if prev == nil {
+		_, err = s.repo.Create(e)
+		if err != nil {
+			l.Log.Warnf("Unable to create dead letter extID=%v, err=%v", e.ExtID, err)
+		}
+		return err
+	}
+
+	e.ID = prev.ID
+	e.CreatedAt = prev.CreatedAt
+	e.Attempts = prev.Attempts + 1
+	e.UpdatedAt = time.Now()
+	err = s.repo.Update(e)
+	if err != nil {
+		l.Log.Warnf("Unable to update dead letter extID=%v, err=%v", e.ExtID, err)
+	}
+	return err
+}
+
+// GetDeadLetter gets the dead letter of the tenant
+func (s *Service) GetDeadLetter(tenantID id.ID, deadLetterID id.ID) (*entity.DeadLetter, error) {
+	e, err := s.repo.Get(tenantID, deadLetterID)
+	if err != nil && err != glad.ErrNotFound {
+		return nil, err
+	}
+	if e == nil {
+		return nil, glad.ErrNotFound
+	}
+	return e, nil
+}
+
+// ListDeadLetters lists the dead letters, oldest first
+func (s *Service) ListDeadLetters(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	page, limit int,
+) (int, []*entity.DeadLetter, error) {
+	count, letters, err := s.repo.List(tenantID, entityType, page, limit)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(letters) == 0 {
+		return count, nil, glad.ErrNotFound
+	}
+	return count, letters, nil
+}
+
+// ListDependentDeadLetters lists the dead letters waiting for the entities
+func (s *Service) ListDependentDeadLetters(tenantID id.ID,
+	dependencyType entity.AuditEntityType,
+	dependencyExtIDs []string,
+) ([]*entity.DeadLetter, error) {
+	if len(dependencyExtIDs) == 0 {
+		return nil, nil
+	}
+	return s.repo.ListByDependency(tenantID, dependencyType, dependencyExtIDs)
+}
+
+// ResolveDeadLetters removes the dead letters of the imported records
+func (s *Service) ResolveDeadLetters(tenantID id.ID,
+	entityType entity.AuditEntityType,
+	extIDs []string,
+) error {
+	if len(extIDs) == 0 {
+		return nil
+	}
+	return s.repo.DeleteByExtIDs(tenantID, entityType, extIDs)
+}
+
+// DiscardDeadLetter drops the dead letter without importing it
+func (s *Service) DiscardDeadLetter(tenantID id.ID, deadLetterID id.ID) error {
+	_, err := s.GetDeadLetter(tenantID, deadLetterID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(tenantID, deadLetterID)
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package dead_letter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice id.ID = 13790492210917015554
+	tenantBob   id.ID = 13790492210917015555
+)
+
+func newFixtureDeadLetter(extID string, centerExtID string) *entity.DeadLetter {
+	payload, _ := json.Marshal(glad.Course{ExtID: extID, CenterExtID: centerExtID})
+	e, _ := entity.NewDeadLetter(tenantAlice, entity.AuditCourse, extID, payload,
+		entity.DeadLetterRejected, "Unknown center")
+	e.SetDependency(entity.AuditCenter, centerExtID)
+	return e
+}
+
+func Test_AddDeadLetter(t *testing.T) {
+	s := NewService(newInmem())
+
+	first := newFixtureDeadLetter("a0W000000000001", "c1")
+	assert.Nil(t, s.AddDeadLetter(first))
+
+	// the record fails again, now waiting for its product
+	again := newFixtureDeadLetter("a0W000000000001", "c1")
+	again.SetDependency(entity.AuditProduct, "p1")
+	assert.Nil(t, s.AddDeadLetter(again))
+
+	saved, err := s.GetDeadLetter(tenantAlice, first.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, saved.Attempts)
+	assert.Equal(t, entity.AuditProduct, saved.DependencyType)
+	assert.Equal(t, "p1", saved.DependencyExtID)
+	assert.Equal(t, first.CreatedAt.Unix(), saved.CreatedAt.Unix())
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := entity.NewDeadLetter(tenantAlice, entity.AuditCourse, "", []byte("{}"),
+			entity.DeadLetterRejected, "")
+		assert.Equal(t, glad.ErrInvalidEntity, err)
+		e := newFixtureDeadLetter("a0W000000000002", "c1")
+		e.Payload = nil
+		assert.Equal(t, glad.ErrInvalidEntity, s.AddDeadLetter(e))
+	})
+}
+
+func Test_ListDeadLetters(t *testing.T) {
+	s := NewService(newInmem())
+	_, _, err := s.ListDeadLetters(tenantAlice, "", 0, 0)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	for _, e := range []*entity.DeadLetter{
+		newFixtureDeadLetter("a0W000000000001", "c1"),
+		newFixtureDeadLetter("a0W000000000002", "c2"),
+		newFixtureDeadLetter("a0W000000000003", "c1"),
+	} {
+		assert.Nil(t, s.AddDeadLetter(e))
+	}
+
+	count, letters, err := s.ListDeadLetters(tenantAlice, entity.AuditCourse, 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 2, len(letters))
+
+	_, _, err = s.ListDeadLetters(tenantBob, "", 0, 0)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	dependents, err := s.ListDependentDeadLetters(tenantAlice, entity.AuditCenter, []string{"c1", "c9"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(dependents))
+	dependents, err = s.ListDependentDeadLetters(tenantAlice, entity.AuditProduct, []string{"c1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(dependents))
+}
+
+func Test_ResolveDeadLetters(t *testing.T) {
+	s := NewService(newInmem())
+	e1 := newFixtureDeadLetter("a0W000000000001", "c1")
+	e2 := newFixtureDeadLetter("a0W000000000002", "c1")
+	assert.Nil(t, s.AddDeadLetter(e1))
+	assert.Nil(t, s.AddDeadLetter(e2))
+
+	assert.Nil(t, s.ResolveDeadLetters(tenantAlice, entity.AuditCourse, []string{"a0W000000000001"}))
+	_, err := s.GetDeadLetter(tenantAlice, e1.ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	assert.Equal(t, glad.ErrNotFound, s.DiscardDeadLetter(tenantBob, e2.ID))
+	assert.Nil(t, s.DiscardDeadLetter(tenantAlice, e2.ID))
+	_, err = s.GetDeadLetter(tenantAlice, e2.ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+}
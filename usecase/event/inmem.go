@@ -0,0 +1,69 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package event
+
+import (
+	"sync"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// inmem in memory repo
+type inmem struct {
+	events []*entity.Event
+	mut    *sync.RWMutex
+}
+
+// newInmem create new repository
+func newInmem() *inmem {
+	return &inmem{
+		mut: &sync.RWMutex{},
+	}
+}
+
+// Create an event
+func (r *inmem) Create(e *entity.Event) (id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.events = append(r.events, e)
+	return e.ID, nil
+}
+
+// ListPending lists the events not delivered yet, oldest first
+func (r *inmem) ListPending(limit int) ([]*entity.Event, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	var events []*entity.Event
+	for _, e := range r.events {
+		if !e.DeliveredAt.IsZero() {
+			continue
+		}
+		events = append(events, e)
+		if limit > 0 && len(events) == limit {
+			break
+		}
+	}
+	return events, nil
+}
+
+// MarkDelivered marks the events delivered
+func (r *inmem) MarkDelivered(eventIDs []id.ID) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	delivered := map[id.ID]bool{}
+	for _, eventID := range eventIDs {
+		delivered[eventID] = true
+	}
+	for _, e := range r.events {
+		if delivered[e.ID] {
+			e.DeliveredAt = time.Now()
+		}
+	}
+	return nil
+}
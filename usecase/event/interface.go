@@ -0,0 +1,49 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package event
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// Reader event reader
+type Reader interface {
+	// ListPending lists the events not delivered yet, oldest first
+	ListPending(limit int) ([]*entity.Event, error)
+}
+
+// Writer event writer
+type Writer interface {
+	Create(e *entity.Event) (id.ID, error)
+	// MarkDelivered marks the events delivered; they are not listed again
+	MarkDelivered(eventIDs []id.ID) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// Publisher publishes the events raised by the usecase services. Publishing
+// is best effort; failures are logged and do not fail the change itself.
+type Publisher interface {
+	Publish(tenantID id.ID,
+		eventType entity.EventType,
+		accountID id.ID,
+		courseID id.ID,
+		payload interface{},
+	)
+}
+
+// UseCase interface
+type UseCase interface {
+	Publisher
+	ListPending(limit int) ([]*entity.Event, error)
+	MarkDelivered(eventIDs []id.ID) error
+}
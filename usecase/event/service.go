@@ -0,0 +1,74 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package event
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+)
+
+// Service event usecase
+// Note: Events are stored in the outbox (event table) and delivered by the
+// services consuming them, e.g. pushd sends the notifications.
+type Service struct {
+	repo Repository
+}
+
+// NewService create new service
+func NewService(r Repository) *Service {
+	return &Service{
+		repo: r,
+	}
+}
+
+// Publish adds the event to the outbox
+func (s *Service) Publish(tenantID id.ID,
+	eventType entity.EventType,
+	accountID id.ID,
+	courseID id.ID,
+	payload interface{},
+) {
+	e, err := entity.NewEvent(tenantID, eventType, accountID, courseID, payload)
+	if err == nil {
+		_, err = s.repo.Create(e)
+	}
+	if err != nil {
+		l.Log.Errorf("Unable to publish event type=%v, account id=%v, course id=%v, err=%v",
+			eventType, accountID, courseID, err)
+	}
+}
+
+// ListPending lists the events to be delivered, oldest first
+func (s *Service) ListPending(limit int) ([]*entity.Event, error) {
+	events, err := s.repo.ListPending(limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, glad.ErrNotFound
+	}
+	return events, nil
+}
+
+// MarkDelivered marks the events delivered
+func (s *Service) MarkDelivered(eventIDs []id.ID) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	return s.repo.MarkDelivered(eventIDs)
+}
+
+// discard drops all the events
+type discard struct{}
+
+func (discard) Publish(id.ID, entity.EventType, id.ID, id.ID, interface{}) {
+}
+
+// Discard is a Publisher that publishes nothing
+var Discard Publisher = discard{}
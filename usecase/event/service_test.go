@@ -0,0 +1,88 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package event
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice  id.ID = 13790492210917015554
+	accountAlice id.ID = 13790492210917015556
+	courseAlice  id.ID = 13790493495087071234
+	entryAlice   id.ID = 13790493495087075555
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+func Test_Publish(t *testing.T) {
+	m := NewService(newInmem())
+	holdUntil := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	_, err := m.ListPending(0)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	m.Publish(tenantAlice, entity.EventWaitlistPromoted, accountAlice, courseAlice,
+		entity.EventWaitlistPromotion{EntryID: entryAlice, HoldUntil: holdUntil})
+	m.Publish(tenantAlice, entity.EventWaitlistPromoted, accountAlice, courseAlice, nil)
+	// dropped; the account to notify is unknown
+	m.Publish(tenantAlice, entity.EventWaitlistPromoted, id.IDInvalid, courseAlice, nil)
+
+	events, err := m.ListPending(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(events))
+
+	t.Run("payload", func(t *testing.T) {
+		e := events[0]
+		assert.Equal(t, tenantAlice, e.TenantID)
+		assert.Equal(t, accountAlice, e.AccountID)
+		assert.Equal(t, courseAlice, e.CourseID)
+
+		var p entity.EventWaitlistPromotion
+		assert.Nil(t, json.Unmarshal(e.Payload, &p))
+		assert.Equal(t, entryAlice, p.EntryID)
+		assert.True(t, holdUntil.Equal(p.HoldUntil))
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		limited, err := m.ListPending(1)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(limited))
+		assert.Equal(t, events[0].ID, limited[0].ID)
+	})
+
+	t.Run("delivered", func(t *testing.T) {
+		assert.Nil(t, m.MarkDelivered([]id.ID{events[0].ID}))
+		pending, err := m.ListPending(0)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(pending))
+		assert.Equal(t, events[1].ID, pending[0].ID)
+
+		assert.Nil(t, m.MarkDelivered([]id.ID{events[1].ID}))
+		_, err = m.ListPending(0)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+}
@@ -0,0 +1,413 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package participant
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// inmemSeat attendee details of a course
+type inmemSeat struct {
+	tenantID     id.ID
+	status       entity.CourseStatus
+	maxAttendees int32
+	numAttendees int32
+}
+
+// inmem in memory repo
+type inmem struct {
+	m        map[id.ID]*entity.Participant
+	courses  map[id.ID]*inmemSeat
+	waitlist map[id.ID]*entity.WaitlistEntry
+	mut      *sync.RWMutex
+}
+
+// newInmem create new repository
+func newInmem() *inmem {
+	return &inmem{
+		m:        map[id.ID]*entity.Participant{},
+		courses:  map[id.ID]*inmemSeat{},
+		waitlist: map[id.ID]*entity.WaitlistEntry{},
+		mut:      &sync.RWMutex{},
+	}
+}
+
+// addCourse adds a course; participants can only be added to known courses
+func (r *inmem) addCourse(tenantID id.ID, courseID id.ID, maxAttendees int32) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.courses[courseID] = &inmemSeat{
+		tenantID:     tenantID,
+		status:       entity.CourseOpen,
+		maxAttendees: maxAttendees,
+	}
+}
+
+// setCourseStatus changes the status of a known course
+func (r *inmem) setCourseStatus(courseID id.ID, status entity.CourseStatus) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.courses[courseID].status = status
+}
+
+// isTenantCourse checks whether the course belongs to the tenant
+func (r *inmem) isTenantCourse(tenantID id.ID, courseID id.ID) bool {
+	seat, ok := r.courses[courseID]
+	return ok && seat.tenantID == tenantID
+}
+
+// Create a participant
+func (r *inmem) Create(e *entity.Participant) (id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.m[e.ID] = e
+	return e.ID, nil
+}
+
+// Get a participant
+func (r *inmem) Get(tenantID id.ID, participantID id.ID) (*entity.Participant, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	p, ok := r.m[participantID]
+	if !ok || !r.isTenantCourse(tenantID, p.CourseID) {
+		return nil, glad.ErrNotFound
+	}
+	return p, nil
+}
+
+// GetByAccount gets a participant of a course using account id
+func (r *inmem) GetByAccount(tenantID id.ID,
+	courseID id.ID,
+	accountID id.ID,
+) (*entity.Participant, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	if !r.isTenantCourse(tenantID, courseID) {
+		return nil, glad.ErrNotFound
+	}
+
+	for _, p := range r.m {
+		if p.CourseID == courseID && p.AccountID == accountID {
+			return p, nil
+		}
+	}
+	return nil, glad.ErrNotFound
+}
+
+// GetByExtID gets a participant by its Salesforce id
+func (r *inmem) GetByExtID(tenantID id.ID, extID string) (*entity.Participant, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	for _, p := range r.m {
+		if p.ExtID != nil && *p.ExtID == extID && r.isTenantCourse(tenantID, p.CourseID) {
+			return p, nil
+		}
+	}
+	return nil, glad.ErrNotFound
+}
+
+// ListByCourse lists participants of a course
+func (r *inmem) ListByCourse(tenantID id.ID,
+	courseID id.ID,
+	page, limit int,
+) ([]*entity.Participant, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	var participants []*entity.Participant
+	if !r.isTenantCourse(tenantID, courseID) {
+		return participants, nil
+	}
+
+	for _, p := range r.m {
+		if p.CourseID == courseID {
+			participants = append(participants, p)
+		}
+	}
+
+	if page > 0 && limit > 0 {
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(participants) {
+			return []*entity.Participant{}, nil
+		}
+		if end > len(participants) {
+			end = len(participants)
+		}
+		return participants[start:end], nil
+	}
+
+	return participants, nil
+}
+
+// GetCountByCourse gets total participants of a course
+func (r *inmem) GetCountByCourse(tenantID id.ID, courseID id.ID) (int, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	count := 0
+	if !r.isTenantCourse(tenantID, courseID) {
+		return count, nil
+	}
+
+	for _, p := range r.m {
+		if p.CourseID == courseID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Delete a participant
+func (r *inmem) Delete(participantID id.ID) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if _, ok := r.m[participantID]; !ok {
+		return glad.ErrNotFound
+	}
+
+	r.m[participantID] = nil
+	delete(r.m, participantID)
+	return nil
+}
+
+// Upsert upserts a participant
+func (r *inmem) Upsert(tenantID id.ID, e *entity.Participant) (id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if !r.isTenantCourse(tenantID, e.CourseID) {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+
+	for _, p := range r.m {
+		if p.ExtID != nil && e.ExtID != nil && *p.ExtID == *e.ExtID {
+			e.ID = p.ID
+		}
+	}
+	r.m[e.ID] = e
+	return e.ID, nil
+}
+
+// SetExtID links the participant to its Salesforce record
+func (r *inmem) SetExtID(participantID id.ID, extID string) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	p, ok := r.m[participantID]
+	if !ok {
+		return glad.ErrNotFound
+	}
+	p.ExtID = &extID
+	return nil
+}
+
+// ReserveSeat increments the attendee count
+func (r *inmem) ReserveSeat(tenantID id.ID, courseID id.ID) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if !r.isTenantCourse(tenantID, courseID) {
+		return glad.ErrNotFound
+	}
+
+	seat := r.courses[courseID]
+	if !seat.status.IsRegistrationOpen() {
+		return glad.ErrRegistrationClosed
+	}
+	if seat.maxAttendees > 0 && seat.numAttendees >= seat.maxAttendees {
+		return glad.ErrCourseFull
+	}
+	seat.numAttendees++
+	return nil
+}
+
+// ReleaseSeat decrements the attendee count
+func (r *inmem) ReleaseSeat(tenantID id.ID, courseID id.ID) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if !r.isTenantCourse(tenantID, courseID) {
+		return glad.ErrNotFound
+	}
+
+	seat := r.courses[courseID]
+	if seat.numAttendees > 0 {
+		seat.numAttendees--
+	}
+	return nil
+}
+
+// ReleaseSeatToWaitlist releases a seat and offers it to the first waiting entry
+func (r *inmem) ReleaseSeatToWaitlist(tenantID id.ID,
+	courseID id.ID,
+	now time.Time,
+	hold time.Duration,
+) (*entity.WaitlistEntry, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if !r.isTenantCourse(tenantID, courseID) {
+		return nil, glad.ErrNotFound
+	}
+
+	seat := r.courses[courseID]
+	if seat.numAttendees > 0 {
+		seat.numAttendees--
+	}
+	if !seat.status.IsRegistrationOpen() ||
+		(seat.maxAttendees > 0 && seat.numAttendees >= seat.maxAttendees) {
+		return nil, nil
+	}
+
+	for _, e := range r.courseWaitlist(courseID) {
+		if e.Status != entity.WaitlistWaiting {
+			continue
+		}
+		seat.numAttendees++
+		e.Offer(now, hold)
+		offered := *e
+		return &offered, nil
+	}
+	return nil, nil
+}
+
+// courseWaitlist entries of the course by position
+func (r *inmem) courseWaitlist(courseID id.ID) []*entity.WaitlistEntry {
+	var entries []*entity.WaitlistEntry
+	for _, e := range r.waitlist {
+		if e.CourseID == courseID {
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Position < entries[j].Position
+	})
+	return entries
+}
+
+// GetWaitlistEntry gets a waitlist entry
+func (r *inmem) GetWaitlistEntry(tenantID id.ID, entryID id.ID) (*entity.WaitlistEntry, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	e, ok := r.waitlist[entryID]
+	if !ok || !r.isTenantCourse(tenantID, e.CourseID) {
+		return nil, glad.ErrNotFound
+	}
+	return e, nil
+}
+
+// GetWaitlistEntryByAccount gets a waitlist entry of a course using account id
+func (r *inmem) GetWaitlistEntryByAccount(tenantID id.ID,
+	courseID id.ID,
+	accountID id.ID,
+) (*entity.WaitlistEntry, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	if !r.isTenantCourse(tenantID, courseID) {
+		return nil, glad.ErrNotFound
+	}
+
+	for _, e := range r.waitlist {
+		if e.CourseID == courseID && e.AccountID == accountID {
+			return e, nil
+		}
+	}
+	return nil, glad.ErrNotFound
+}
+
+// ListWaitlist lists the entries of the course by position
+func (r *inmem) ListWaitlist(tenantID id.ID, courseID id.ID) ([]*entity.WaitlistEntry, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	if !r.isTenantCourse(tenantID, courseID) {
+		return nil, nil
+	}
+	return r.courseWaitlist(courseID), nil
+}
+
+// ListExpiredHolds lists the offered entries whose hold ended before now
+func (r *inmem) ListExpiredHolds(now time.Time) ([]*entity.WaitlistEntry, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	var entries []*entity.WaitlistEntry
+	for _, e := range r.waitlist {
+		if e.IsHoldExpired(now) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// CreateWaitlistEntry adds the entry at the end of the waitlist of the course
+func (r *inmem) CreateWaitlistEntry(e *entity.WaitlistEntry) (id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if !r.isTenantCourse(e.TenantID, e.CourseID) {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+
+	e.Position = len(r.courseWaitlist(e.CourseID)) + 1
+	r.waitlist[e.ID] = e
+	return e.ID, nil
+}
+
+// DeleteWaitlistEntry removes the entry; the entries after it move up
+func (r *inmem) DeleteWaitlistEntry(entryID id.ID) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	e, ok := r.waitlist[entryID]
+	if !ok {
+		return glad.ErrNotFound
+	}
+
+	delete(r.waitlist, entryID)
+	for _, other := range r.waitlist {
+		if other.CourseID == e.CourseID && other.Position > e.Position {
+			other.Position--
+		}
+	}
+	return nil
+}
+
+// ReorderWaitlist sets the positions of the entries in the order given
+func (r *inmem) ReorderWaitlist(tenantID id.ID, courseID id.ID, entryIDs []id.ID) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if !r.isTenantCourse(tenantID, courseID) {
+		return glad.ErrNotFound
+	}
+
+	for i, entryID := range entryIDs {
+		e, ok := r.waitlist[entryID]
+		if !ok || e.CourseID != courseID {
+			return glad.ErrNotFound
+		}
+		e.Position = i + 1
+	}
+	return nil
+}
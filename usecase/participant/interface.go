@@ -0,0 +1,113 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package participant
+
+import (
+	"context"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// Reader participant reader
+type Reader interface {
+	Get(tenantID id.ID, participantID id.ID) (*entity.Participant, error)
+	GetByAccount(tenantID id.ID, courseID id.ID, accountID id.ID) (*entity.Participant, error)
+	// GetByExtID gets a participant of a course of the tenant by its Salesforce id
+	GetByExtID(tenantID id.ID, extID string) (*entity.Participant, error)
+	ListByCourse(tenantID id.ID, courseID id.ID, page, limit int) ([]*entity.Participant, error)
+	GetCountByCourse(tenantID id.ID, courseID id.ID) (int, error)
+}
+
+// Writer participant writer
+type Writer interface {
+	Create(e *entity.Participant) (id.ID, error)
+	Delete(participantID id.ID) error
+	Upsert(tenantID id.ID, e *entity.Participant) (id.ID, error)
+	// SetExtID links the participant to its Salesforce record
+	SetExtID(participantID id.ID, extID string) error
+}
+
+// SeatWriter updates the attendee count of the course
+type SeatWriter interface {
+	// ReserveSeat increments the attendee count. Returns glad.ErrCourseFull when
+	// the course has reached maximum attendees and glad.ErrRegistrationClosed
+	// when the course status does not accept registrations.
+	ReserveSeat(tenantID id.ID, courseID id.ID) error
+	// ReleaseSeat decrements the attendee count
+	ReleaseSeat(tenantID id.ID, courseID id.ID) error
+	// ReleaseSeatToWaitlist releases a seat and, in the same transaction,
+	// reserves it for the first waiting entry, held for hold from now. Returns
+	// the offered entry; nil when the seat is left free, e.g. nobody waits.
+	ReleaseSeatToWaitlist(tenantID id.ID,
+		courseID id.ID,
+		now time.Time,
+		hold time.Duration,
+	) (*entity.WaitlistEntry, error)
+}
+
+// WaitlistReader waitlist reader
+type WaitlistReader interface {
+	GetWaitlistEntry(tenantID id.ID, entryID id.ID) (*entity.WaitlistEntry, error)
+	GetWaitlistEntryByAccount(tenantID id.ID,
+		courseID id.ID,
+		accountID id.ID,
+	) (*entity.WaitlistEntry, error)
+	// ListWaitlist lists the entries of the course by position
+	ListWaitlist(tenantID id.ID, courseID id.ID) ([]*entity.WaitlistEntry, error)
+	// ListExpiredHolds lists the offered entries of all the tenants whose hold
+	// ended before now
+	ListExpiredHolds(now time.Time) ([]*entity.WaitlistEntry, error)
+}
+
+// WaitlistWriter waitlist writer
+type WaitlistWriter interface {
+	// CreateWaitlistEntry adds the entry at the end of the waitlist of the
+	// course and sets its position
+	CreateWaitlistEntry(e *entity.WaitlistEntry) (id.ID, error)
+	// DeleteWaitlistEntry removes the entry; the entries after it move up.
+	// Returns glad.ErrNotFound when the entry is already removed.
+	DeleteWaitlistEntry(entryID id.ID) error
+	// ReorderWaitlist sets the positions of the entries of the course, in the
+	// order of entryIDs
+	ReorderWaitlist(tenantID id.ID, courseID id.ID, entryIDs []id.ID) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+	SeatWriter
+	WaitlistReader
+	WaitlistWriter
+}
+
+// UseCase interface
+type UseCase interface {
+	GetParticipant(tenantID id.ID, participantID id.ID) (*entity.Participant, error)
+	ListParticipants(tenantID id.ID, courseID id.ID, page, limit int) ([]*entity.Participant, error)
+	GetCount(tenantID id.ID, courseID id.ID) int
+	AddParticipant(tenantID id.ID, courseID id.ID, accountID id.ID, email string) (id.ID, error)
+	RemoveParticipant(tenantID id.ID, courseID id.ID, participantID id.ID) error
+	UpsertParticipant(tenantID id.ID, e *entity.Participant) (id.ID, error)
+	// RemoveParticipantByExtID removes the participant deleted in Salesforce
+	// and returns its id
+	RemoveParticipantByExtID(tenantID id.ID, extID string) (id.ID, error)
+
+	GetWaitlistEntry(tenantID id.ID, entryID id.ID) (*entity.WaitlistEntry, error)
+	ListWaitlist(tenantID id.ID, courseID id.ID) ([]*entity.WaitlistEntry, error)
+	ReorderWaitlist(tenantID id.ID, courseID id.ID, entryIDs []id.ID) error
+	// ConfirmWaitlistEntry registers the account for the seat held for it and
+	// returns the participant id
+	ConfirmWaitlistEntry(tenantID id.ID, courseID id.ID, accountID id.ID) (id.ID, error)
+	// ExpireHolds releases the seats held beyond the hold window to the next
+	// entries and returns the number of holds released
+	ExpireHolds(now time.Time) int
+	// Start runs ExpireHolds at the interval until ctx is done
+	Start(ctx context.Context, interval time.Duration) error
+}
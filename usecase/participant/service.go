@@ -0,0 +1,392 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package participant
+
+import (
+	"context"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/usecase/event"
+)
+
+// DefaultHoldWindow time a promoted waitlist entry has to confirm its seat
+const DefaultHoldWindow = 24 * time.Hour
+
+// Service participant usecase
+type Service struct {
+	repo   Repository
+	events event.Publisher
+	hold   time.Duration
+}
+
+// NewService creates new service
+// Note: The default hold window is used when hold is not positive.
+func NewService(r Repository, events event.Publisher, hold time.Duration) *Service {
+	if hold <= 0 {
+		hold = DefaultHoldWindow
+	}
+	return &Service{
+		repo:   r,
+		events: events,
+		hold:   hold,
+	}
+}
+
+// GetParticipant retrieves a participant
+func (s *Service) GetParticipant(tenantID id.ID, participantID id.ID) (*entity.Participant, error) {
+	p, err := s.repo.Get(tenantID, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, glad.ErrNotFound
+	}
+
+	return p, nil
+}
+
+// ListParticipants lists participants of a course
+func (s *Service) ListParticipants(tenantID id.ID,
+	courseID id.ID,
+	page, limit int,
+) ([]*entity.Participant, error) {
+	participants, err := s.repo.ListByCourse(tenantID, courseID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(participants) == 0 {
+		return nil, glad.ErrNotFound
+	}
+	return participants, nil
+}
+
+// GetCount gets total participant count of a course
+func (s *Service) GetCount(tenantID id.ID, courseID id.ID) int {
+	count, err := s.repo.GetCountByCourse(tenantID, courseID)
+	if err != nil {
+		return 0
+	}
+
+	return count
+}
+
+// AddParticipant registers an account to the course
+// Note: The seat is reserved first so that the course never goes beyond maximum attendees.
+// If the registration fails afterwards, the seat is released.
+// When the course is full, the account is added to the waitlist and the id of
+// the waitlist entry is returned along with glad.ErrWaitlisted. An account
+// holding a seat from the waitlist is registered for that seat.
+func (s *Service) AddParticipant(tenantID id.ID,
+	courseID id.ID,
+	accountID id.ID,
+	email string,
+) (id.ID, error) {
+	p, err := entity.NewParticipant(courseID, accountID, email)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	existing, err := s.repo.GetByAccount(tenantID, courseID, accountID)
+	if err != nil && err != glad.ErrNotFound {
+		return id.IDInvalid, err
+	}
+	if existing != nil {
+		return existing.ID, glad.ErrAlreadyExists
+	}
+
+	entry, err := s.repo.GetWaitlistEntryByAccount(tenantID, courseID, accountID)
+	if err != nil && err != glad.ErrNotFound {
+		return id.IDInvalid, err
+	}
+	if entry != nil && entry.Status == entity.WaitlistOffered {
+		return s.ConfirmWaitlistEntry(tenantID, courseID, accountID)
+	}
+
+	err = s.repo.ReserveSeat(tenantID, courseID)
+	if err == glad.ErrCourseFull {
+		if entry != nil {
+			return entry.ID, glad.ErrWaitlisted
+		}
+		return s.joinWaitlist(tenantID, courseID, accountID, email)
+	}
+	if err != nil {
+		l.Log.Warnf("Unable to reserve seat course id=%v, err=%v", courseID, err)
+		return id.IDInvalid, err
+	}
+
+	participantID, err := s.repo.Create(p)
+	if err != nil {
+		l.Log.Warnf("Unable to add participant course id=%v, account id=%v, err=%v",
+			courseID, accountID, err)
+		if rerr := s.repo.ReleaseSeat(tenantID, courseID); rerr != nil {
+			l.Log.Errorf("Unable to release seat course id=%v, err=%v", courseID, rerr)
+		}
+		return id.IDInvalid, err
+	}
+
+	// registered without waiting for the turn, e.g. the maximum attendees went up
+	if entry != nil {
+		if err := s.repo.DeleteWaitlistEntry(entry.ID); err != nil {
+			l.Log.Warnf("Unable to remove waitlist entry id=%v, err=%v", entry.ID, err)
+		}
+	}
+	return participantID, nil
+}
+
+// RemoveParticipant removes the participant from the course and releases the
+// seat, which is offered to the next account on the waitlist
+func (s *Service) RemoveParticipant(tenantID id.ID, courseID id.ID, participantID id.ID) error {
+	p, err := s.GetParticipant(tenantID, participantID)
+	if err != nil {
+		return err
+	}
+	if p.CourseID != courseID {
+		return glad.ErrNotFound
+	}
+
+	err = s.repo.Delete(participantID)
+	if err != nil {
+		return err
+	}
+
+	return s.releaseSeat(tenantID, courseID)
+}
+
+// UpsertParticipant upserts a participant
+// Note: Attendee count is not updated here. Imported courses carry the attendee count.
+// A registration of the account made in GLAD is linked to the Salesforce
+// record instead of adding another one.
+func (s *Service) UpsertParticipant(tenantID id.ID, p *entity.Participant) (id.ID, error) {
+	if p.ID == id.IDInvalid {
+		// assign id and during update id should not be overwritten
+		p.ID = id.New()
+	}
+
+	err := p.Validate()
+	if err != nil {
+		l.Log.Warnf("err=%v", err)
+		return id.IDInvalid, err
+	}
+
+	if p.ExtID != nil && *p.ExtID != "" {
+		existing, _ := s.repo.GetByAccount(tenantID, p.CourseID, p.AccountID)
+		if existing != nil && (existing.ExtID == nil || *existing.ExtID == "") {
+			err = s.repo.SetExtID(existing.ID, *p.ExtID)
+			if err != nil {
+				l.Log.Warnf("err=%v", err)
+				return id.IDInvalid, err
+			}
+		}
+	}
+	return s.repo.Upsert(tenantID, p)
+}
+
+// RemoveParticipantByExtID removes the participant deleted in Salesforce
+// Note: As with the upsert, the attendee count is not updated.
+func (s *Service) RemoveParticipantByExtID(tenantID id.ID, extID string) (id.ID, error) {
+	p, err := s.repo.GetByExtID(tenantID, extID)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	if p == nil {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+
+	err = s.repo.Delete(p.ID)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	return p.ID, nil
+}
+
+// joinWaitlist adds the account at the end of the waitlist of the course
+func (s *Service) joinWaitlist(tenantID id.ID,
+	courseID id.ID,
+	accountID id.ID,
+	email string,
+) (id.ID, error) {
+	e, err := entity.NewWaitlistEntry(tenantID, courseID, accountID, email)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	entryID, err := s.repo.CreateWaitlistEntry(e)
+	if err != nil {
+		l.Log.Warnf("Unable to add to waitlist course id=%v, account id=%v, err=%v",
+			courseID, accountID, err)
+		return id.IDInvalid, err
+	}
+	return entryID, glad.ErrWaitlisted
+}
+
+// releaseSeat releases a seat of the course and offers it to the first waiting
+// entry; the seat is held for the entry until it is confirmed or the hold
+// expires
+// Note: The seat is offered along with the release so that a registration
+// cannot take it ahead of the waitlist.
+func (s *Service) releaseSeat(tenantID id.ID, courseID id.ID) error {
+	e, err := s.repo.ReleaseSeatToWaitlist(tenantID, courseID, time.Now(), s.hold)
+	if err != nil {
+		return err
+	}
+
+	if e != nil {
+		s.events.Publish(tenantID, entity.EventWaitlistPromoted, e.AccountID, courseID,
+			entity.EventWaitlistPromotion{EntryID: e.ID, HoldUntil: e.HoldUntil})
+	}
+	return nil
+}
+
+// GetWaitlistEntry retrieves a waitlist entry
+func (s *Service) GetWaitlistEntry(tenantID id.ID, entryID id.ID) (*entity.WaitlistEntry, error) {
+	e, err := s.repo.GetWaitlistEntry(tenantID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, glad.ErrNotFound
+	}
+
+	return e, nil
+}
+
+// ListWaitlist lists the waitlist of a course by position
+func (s *Service) ListWaitlist(tenantID id.ID, courseID id.ID) ([]*entity.WaitlistEntry, error) {
+	entries, err := s.repo.ListWaitlist(tenantID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, glad.ErrNotFound
+	}
+	return entries, nil
+}
+
+// ReorderWaitlist reorders the waitlist of a course. All the entries of the
+// waitlist must be given, each once.
+// Note: Entries already offered a seat keep their hold.
+func (s *Service) ReorderWaitlist(tenantID id.ID, courseID id.ID, entryIDs []id.ID) error {
+	entries, err := s.ListWaitlist(tenantID, courseID)
+	if err != nil {
+		return err
+	}
+	if len(entryIDs) != len(entries) {
+		return glad.ErrInvalidValue
+	}
+
+	pending := make(map[id.ID]bool, len(entries))
+	for _, e := range entries {
+		pending[e.ID] = true
+	}
+	for _, entryID := range entryIDs {
+		if !pending[entryID] {
+			return glad.ErrInvalidValue
+		}
+		delete(pending, entryID)
+	}
+
+	return s.repo.ReorderWaitlist(tenantID, courseID, entryIDs)
+}
+
+// ConfirmWaitlistEntry registers the account for the seat held for it
+// Note: The entry is removed first so that the seat is not released by the
+// hold expiry at the same time.
+func (s *Service) ConfirmWaitlistEntry(tenantID id.ID,
+	courseID id.ID,
+	accountID id.ID,
+) (id.ID, error) {
+	e, err := s.repo.GetWaitlistEntryByAccount(tenantID, courseID, accountID)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	if e == nil {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+	if e.Status != entity.WaitlistOffered || e.IsHoldExpired(time.Now()) {
+		return id.IDInvalid, glad.ErrInvalidTransition
+	}
+
+	p, err := entity.NewParticipant(courseID, accountID, e.Email)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	err = s.repo.DeleteWaitlistEntry(e.ID)
+	if err == glad.ErrNotFound {
+		// the hold expired meanwhile
+		return id.IDInvalid, glad.ErrInvalidTransition
+	}
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	participantID, err := s.repo.Create(p)
+	if err != nil {
+		l.Log.Warnf("Unable to add participant course id=%v, account id=%v, err=%v",
+			courseID, accountID, err)
+		if rerr := s.releaseSeat(tenantID, courseID); rerr != nil {
+			l.Log.Errorf("Unable to release seat course id=%v, err=%v", courseID, rerr)
+		}
+		return id.IDInvalid, err
+	}
+
+	return participantID, nil
+}
+
+// ExpireHolds removes the entries whose hold ended before now and offers their
+// seats to the next entries
+func (s *Service) ExpireHolds(now time.Time) int {
+	entries, err := s.repo.ListExpiredHolds(now)
+	if err != nil {
+		l.Log.Warnf("Unable to list expired holds. err=%v", err)
+		return 0
+	}
+
+	released := 0
+	for _, e := range entries {
+		err = s.repo.DeleteWaitlistEntry(e.ID)
+		if err != nil {
+			// confirmed meanwhile
+			continue
+		}
+
+		err = s.releaseSeat(e.TenantID, e.CourseID)
+		if err != nil {
+			l.Log.Errorf("Unable to release seat course id=%v, err=%v", e.CourseID, err)
+			continue
+		}
+		released++
+	}
+	return released
+}
+
+// Start runs ExpireHolds at the interval until ctx is done
+func (s *Service) Start(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return glad.ErrInvalidValue
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if released := s.ExpireHolds(now); released > 0 {
+					l.Log.Infof("Released %v expired waitlist holds", released)
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,323 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package participant
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+	"ac9/glad/usecase/event"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice   id.ID = 13790492210917015554
+	tenantBob     id.ID = 13790492210917015555
+	aliceCourseID id.ID = 13790493495087071234
+	bobCourseID   id.ID = 13790493495087071235
+
+	aliceAccount1ID id.ID = 100000001
+	aliceAccount2ID id.ID = 100000002
+	aliceAccount3ID id.ID = 100000003
+	aliceAccount4ID id.ID = 100000004
+
+	aliceExtID = "000aliceExtID"
+)
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+func newFixtureService(maxAttendees int32) (*Service, *inmem) {
+	repo := newInmem()
+	repo.addCourse(tenantAlice, aliceCourseID, maxAttendees)
+	repo.addCourse(tenantBob, bobCourseID, maxAttendees)
+	return NewService(repo, event.Discard, 0), repo
+}
+
+// promotions records the waitlist promotions published
+type promotions []entity.EventWaitlistPromotion
+
+func (p *promotions) Publish(tenantID id.ID,
+	eventType entity.EventType,
+	accountID id.ID,
+	courseID id.ID,
+	payload interface{},
+) {
+	*p = append(*p, payload.(entity.EventWaitlistPromotion))
+}
+
+func Test_AddParticipant(t *testing.T) {
+	m, repo := newFixtureService(2)
+
+	pID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount1ID, "alice@wonderland.ai")
+	assert.Nil(t, err)
+	assert.NotEqual(t, id.ID(id.IDInvalid), pID)
+	assert.Equal(t, int32(1), repo.courses[aliceCourseID].numAttendees)
+
+	t.Run("duplicate", func(t *testing.T) {
+		dupID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount1ID, "")
+		assert.Equal(t, glad.ErrAlreadyExists, err)
+		assert.Equal(t, pID, dupID)
+		assert.Equal(t, int32(1), repo.courses[aliceCourseID].numAttendees)
+	})
+
+	t.Run("course full", func(t *testing.T) {
+		_, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount2ID, "")
+		assert.Nil(t, err)
+		entryID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount3ID, "")
+		assert.Equal(t, glad.ErrWaitlisted, err)
+		assert.Equal(t, 2, m.GetCount(tenantAlice, aliceCourseID))
+
+		e, err := m.GetWaitlistEntry(tenantAlice, entryID)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, e.Position)
+		assert.Equal(t, entity.WaitlistWaiting, e.Status)
+
+		// registering again keeps the place on the waitlist
+		againID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount3ID, "")
+		assert.Equal(t, glad.ErrWaitlisted, err)
+		assert.Equal(t, entryID, againID)
+	})
+
+	t.Run("registration closed", func(t *testing.T) {
+		repo.setCourseStatus(aliceCourseID, entity.CourseCanceled)
+		defer repo.setCourseStatus(aliceCourseID, entity.CourseOpen)
+		_, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount3ID, "")
+		assert.Equal(t, glad.ErrRegistrationClosed, err)
+	})
+
+	t.Run("not published", func(t *testing.T) {
+		defer repo.setCourseStatus(aliceCourseID, entity.CourseOpen)
+		for _, status := range []entity.CourseStatus{entity.CourseDraft, entity.CourseSubmitted} {
+			repo.setCourseStatus(aliceCourseID, status)
+			_, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount3ID, "")
+			assert.Equal(t, glad.ErrRegistrationClosed, err, status)
+		}
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		_, err := m.AddParticipant(tenantBob, aliceCourseID, aliceAccount3ID, "")
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := m.AddParticipant(tenantAlice, aliceCourseID, id.IDInvalid, "")
+		assert.Equal(t, glad.ErrInvalidEntity, err)
+	})
+}
+
+func Test_ListParticipants(t *testing.T) {
+	m, _ := newFixtureService(0)
+
+	_, _ = m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount1ID, "")
+	_, _ = m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount2ID, "")
+
+	all, err := m.ListParticipants(tenantAlice, aliceCourseID, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(all))
+
+	paged, err := m.ListParticipants(tenantAlice, aliceCourseID, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(paged))
+
+	_, err = m.ListParticipants(tenantBob, aliceCourseID, 0, 0)
+	assert.Equal(t, glad.ErrNotFound, err)
+	assert.Equal(t, 0, m.GetCount(tenantBob, aliceCourseID))
+}
+
+func Test_RemoveParticipant(t *testing.T) {
+	m, repo := newFixtureService(1)
+
+	pID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount1ID, "")
+	assert.Nil(t, err)
+
+	err = m.RemoveParticipant(tenantBob, aliceCourseID, pID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	err = m.RemoveParticipant(tenantAlice, aliceCourseID, pID)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), repo.courses[aliceCourseID].numAttendees)
+
+	_, err = m.GetParticipant(tenantAlice, pID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	// seat is available again
+	_, err = m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount2ID, "")
+	assert.Nil(t, err)
+}
+
+func Test_UpsertParticipant(t *testing.T) {
+	m, repo := newFixtureService(0)
+	extID := aliceExtID
+
+	p := &entity.Participant{
+		CourseID:  aliceCourseID,
+		AccountID: aliceAccount1ID,
+		ExtID:     &extID,
+	}
+	pID, err := m.UpsertParticipant(tenantAlice, p)
+	assert.Nil(t, err)
+
+	update := &entity.Participant{
+		CourseID:  aliceCourseID,
+		AccountID: aliceAccount1ID,
+		ExtID:     &extID,
+		Email:     "alice@wonderland.ai",
+	}
+	uID, err := m.UpsertParticipant(tenantAlice, update)
+	assert.Nil(t, err)
+	assert.Equal(t, pID, uID)
+	assert.Equal(t, 1, m.GetCount(tenantAlice, aliceCourseID))
+
+	// attendee count is carried by the imported course
+	assert.Equal(t, int32(0), repo.courses[aliceCourseID].numAttendees)
+
+	_, err = m.UpsertParticipant(tenantBob, &entity.Participant{
+		CourseID:  aliceCourseID,
+		AccountID: aliceAccount2ID,
+	})
+	assert.Equal(t, glad.ErrNotFound, err)
+}
+
+func Test_ParticipantByExtID(t *testing.T) {
+	m, _ := newFixtureService(0)
+
+	// registered in GLAD first, then imported from Salesforce
+	pID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount1ID, "")
+	assert.Nil(t, err)
+
+	extID := aliceExtID
+	uID, err := m.UpsertParticipant(tenantAlice, &entity.Participant{
+		CourseID:  aliceCourseID,
+		AccountID: aliceAccount1ID,
+		ExtID:     &extID,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, pID, uID)
+	assert.Equal(t, 1, m.GetCount(tenantAlice, aliceCourseID))
+
+	_, err = m.RemoveParticipantByExtID(tenantBob, extID)
+	assert.Equal(t, glad.ErrNotFound, err)
+	removedID, err := m.RemoveParticipantByExtID(tenantAlice, extID)
+	assert.Nil(t, err)
+	assert.Equal(t, pID, removedID)
+	assert.Equal(t, 0, m.GetCount(tenantAlice, aliceCourseID))
+}
+
+func Test_Waitlist(t *testing.T) {
+	repo := newInmem()
+	repo.addCourse(tenantAlice, aliceCourseID, 1)
+	published := &promotions{}
+	m := NewService(repo, published, time.Hour)
+
+	pID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount1ID, "")
+	assert.Nil(t, err)
+	entry2ID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount2ID, "")
+	assert.Equal(t, glad.ErrWaitlisted, err)
+	entry3ID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount3ID, "")
+	assert.Equal(t, glad.ErrWaitlisted, err)
+	entry4ID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount4ID, "")
+	assert.Equal(t, glad.ErrWaitlisted, err)
+
+	positions := func() []id.ID {
+		entries, _ := m.ListWaitlist(tenantAlice, aliceCourseID)
+		var ids []id.ID
+		for i, e := range entries {
+			assert.Equal(t, i+1, e.Position)
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []id.ID{entry2ID, entry3ID, entry4ID}, positions())
+
+	t.Run("reorder", func(t *testing.T) {
+		err := m.ReorderWaitlist(tenantAlice, aliceCourseID, []id.ID{entry3ID, entry2ID})
+		assert.Equal(t, glad.ErrInvalidValue, err)
+		err = m.ReorderWaitlist(tenantAlice, aliceCourseID, []id.ID{entry3ID, entry3ID, entry4ID})
+		assert.Equal(t, glad.ErrInvalidValue, err)
+		err = m.ReorderWaitlist(tenantBob, aliceCourseID, []id.ID{entry3ID, entry2ID, entry4ID})
+		assert.Equal(t, glad.ErrNotFound, err)
+
+		err = m.ReorderWaitlist(tenantAlice, aliceCourseID, []id.ID{entry3ID, entry2ID, entry4ID})
+		assert.Nil(t, err)
+		assert.Equal(t, []id.ID{entry3ID, entry2ID, entry4ID}, positions())
+	})
+
+	t.Run("promote on cancellation", func(t *testing.T) {
+		err := m.RemoveParticipant(tenantAlice, aliceCourseID, pID)
+		assert.Nil(t, err)
+
+		// the seat is held for the first entry
+		assert.Equal(t, int32(1), repo.courses[aliceCourseID].numAttendees)
+		e, err := m.GetWaitlistEntry(tenantAlice, entry3ID)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.WaitlistOffered, e.Status)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), e.HoldUntil, time.Minute)
+
+		assert.Equal(t, 1, len(*published))
+		assert.Equal(t, entry3ID, (*published)[0].EntryID)
+
+		// others keep waiting for the held seat
+		_, err = m.ConfirmWaitlistEntry(tenantAlice, aliceCourseID, aliceAccount2ID)
+		assert.Equal(t, glad.ErrInvalidTransition, err)
+	})
+
+	t.Run("hold expires", func(t *testing.T) {
+		assert.Equal(t, 0, m.ExpireHolds(time.Now()))
+		assert.Equal(t, 1, m.ExpireHolds(time.Now().Add(2*time.Hour)))
+
+		_, err := m.GetWaitlistEntry(tenantAlice, entry3ID)
+		assert.Equal(t, glad.ErrNotFound, err)
+		assert.Equal(t, []id.ID{entry2ID, entry4ID}, positions())
+
+		// the seat moved on to the next entry
+		assert.Equal(t, int32(1), repo.courses[aliceCourseID].numAttendees)
+		assert.Equal(t, 2, len(*published))
+		assert.Equal(t, entry2ID, (*published)[1].EntryID)
+	})
+
+	t.Run("confirm", func(t *testing.T) {
+		_, err := m.ConfirmWaitlistEntry(tenantAlice, aliceCourseID, aliceAccount3ID)
+		assert.Equal(t, glad.ErrNotFound, err)
+
+		// registering again confirms the held seat
+		participantID, err := m.AddParticipant(tenantAlice, aliceCourseID, aliceAccount2ID, "")
+		assert.Nil(t, err)
+		p, err := m.GetParticipant(tenantAlice, participantID)
+		assert.Nil(t, err)
+		assert.Equal(t, aliceAccount2ID, p.AccountID)
+
+		assert.Equal(t, int32(1), repo.courses[aliceCourseID].numAttendees)
+		assert.Equal(t, []id.ID{entry4ID}, positions())
+		assert.Equal(t, 0, m.ExpireHolds(time.Now().Add(2*time.Hour)))
+	})
+
+	t.Run("course closed", func(t *testing.T) {
+		repo.setCourseStatus(aliceCourseID, entity.CourseClosed)
+		participants, _ := m.ListParticipants(tenantAlice, aliceCourseID, 0, 0)
+		err := m.RemoveParticipant(tenantAlice, aliceCourseID, participants[0].ID)
+		assert.Nil(t, err)
+
+		// no seat is offered
+		assert.Equal(t, int32(0), repo.courses[aliceCourseID].numAttendees)
+		assert.Equal(t, 2, len(*published))
+	})
+}
@@ -39,11 +39,11 @@ func (r *inmem) Create(e *entity.Product) (id.ID, error) {
 }
 
 // Get retrieves a product from memory
-func (r *inmem) Get(id id.ID) (*entity.Product, error) {
+func (r *inmem) Get(tenantID id.ID, productID id.ID) (*entity.Product, error) {
 	r.mut.RLock()
 	defer r.mut.RUnlock()
 
-	if product, ok := r.m[id]; ok {
+	if product, ok := r.m[productID]; ok && product.TenantID == tenantID {
 		return product, nil
 	}
 	return nil, glad.ErrNotFound
@@ -54,8 +54,8 @@ func (r *inmem) Update(e *entity.Product) error {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
-	_, ok := r.m[e.ID]
-	if !ok {
+	p, ok := r.m[e.ID]
+	if !ok || p.TenantID != e.TenantID {
 		return glad.ErrNotFound
 	}
 
@@ -70,7 +70,7 @@ func (r *inmem) List(tenantID id.ID, page, limit int) ([]*entity.Product, error)
 
 	var products []*entity.Product
 	for _, product := range r.m {
-		if product.TenantID == tenantID {
+		if product.TenantID == tenantID && !product.IsDeleted {
 			products = append(products, product)
 		}
 	}
@@ -92,18 +92,33 @@ func (r *inmem) List(tenantID id.ID, page, limit int) ([]*entity.Product, error)
 }
 
 // Delete marks a product as deleted in memory
-func (r *inmem) Delete(id id.ID) error {
+func (r *inmem) Delete(tenantID id.ID, productID id.ID) error {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
-	if _, ok := r.m[id]; ok {
-		r.m[id] = nil
-		delete(r.m, id)
+	if product, ok := r.m[productID]; ok && product.TenantID == tenantID {
+		r.m[productID] = nil
+		delete(r.m, productID)
 		return nil
 	}
 	return glad.ErrNotFound
 }
 
+// SetDeleted marks a product as deleted or restores it
+func (r *inmem) SetDeleted(tenantID id.ID, productID id.ID, isDeleted bool) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	product, ok := r.m[productID]
+	if !ok || product.TenantID != tenantID {
+		return glad.ErrNotFound
+	}
+	updated := *product
+	updated.IsDeleted = isDeleted
+	r.m[productID] = &updated
+	return nil
+}
+
 // Search searches for products in memory
 func (r *inmem) Search(tenantID id.ID, query string, page, limit int) ([]*entity.Product, error) {
 	r.mut.RLock()
@@ -111,7 +126,7 @@ func (r *inmem) Search(tenantID id.ID, query string, page, limit int) ([]*entity
 
 	var products []*entity.Product
 	for _, product := range r.m {
-		if product.TenantID == tenantID &&
+		if product.TenantID == tenantID && !product.IsDeleted &&
 			(strings.Contains(strings.ToLower(product.ExtName), strings.ToLower(query)) ||
 				strings.Contains(strings.ToLower(product.Title), strings.ToLower(query))) {
 			products = append(products, product)
@@ -141,7 +156,7 @@ func (r *inmem) GetCount(tenantID id.ID) (int, error) {
 
 	count := 0
 	for _, product := range r.m {
-		if product.TenantID == tenantID {
+		if product.TenantID == tenantID && !product.IsDeleted {
 			count++
 		}
 	}
@@ -156,6 +171,8 @@ func (r *inmem) Upsert(e *entity.Product) (id.ID, error) {
 	for _, product := range r.m {
 		if product.ExtID == e.ExtID {
 			e.ID = product.ID
+			// deletion is only changed by SetDeleted
+			e.IsDeleted = product.IsDeleted
 		}
 	}
 	r.m[e.ID] = e
@@ -175,6 +192,36 @@ func (r *inmem) GetByExtID(tenantID id.ID, extID string) (*entity.Product, error
 	return nil, glad.ErrNotFound
 }
 
+// BulkUpsert upserts products in memory
+func (r *inmem) BulkUpsert(tenantID id.ID, products []*entity.Product) (map[string]id.ID, error) {
+	ids := make(map[string]id.ID, len(products))
+	for _, p := range products {
+		p.TenantID = tenantID
+		productID, err := r.Upsert(p)
+		if err != nil {
+			return nil, err
+		}
+		ids[p.ExtID] = productID
+	}
+	return ids, nil
+}
+
+// GetIDsByExtIDs gets ids using external ids
+func (r *inmem) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	ids := make(map[string]id.ID, len(extIDs))
+	for _, extID := range extIDs {
+		for _, e := range r.m {
+			if e.TenantID == tenantID && e.ExtID == extID {
+				ids[extID] = e.ID
+			}
+		}
+	}
+	return ids, nil
+}
+
 // Additional helper methods for testing
 func (r *inmem) Clean() {
 	r.mut.Lock()
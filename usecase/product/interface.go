@@ -13,19 +13,25 @@ import (
 
 // Reader defines read-only operations for products
 type Reader interface {
-	Get(id id.ID) (*entity.Product, error)
+	Get(tenantID id.ID, productID id.ID) (*entity.Product, error)
 	List(tenantID id.ID, page, limit int) ([]*entity.Product, error)
 	Search(tenantID id.ID, q string, page, limit int) ([]*entity.Product, error)
 	GetCount(tenantID id.ID) (int, error)
 	GetByExtID(tenantID id.ID, extID string) (*entity.Product, error)
+	// GetIDsByExtIDs gets ids by external id; unknown ones are left out
+	GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error)
 }
 
 // Writer defines write-only operations for products
 type Writer interface {
 	Create(product *entity.Product) (id.ID, error)
 	Update(product *entity.Product) error
-	Delete(id id.ID) error
+	Delete(tenantID id.ID, productID id.ID) error
 	Upsert(product *entity.Product) (id.ID, error)
+	// BulkUpsert upserts products keyed by external id; returns ids by external id
+	BulkUpsert(tenantID id.ID, products []*entity.Product) (map[string]id.ID, error)
+	// SetDeleted marks the product as deleted in Salesforce or restores it
+	SetDeleted(tenantID id.ID, productID id.ID, isDeleted bool) error
 }
 
 // Repository interface
@@ -36,10 +42,11 @@ type Repository interface {
 
 // UseCase defines the interface for product business logic
 type UseCase interface {
-	GetProduct(id id.ID) (*entity.Product, error)
+	GetProduct(tenantID id.ID, productID id.ID) (*entity.Product, error)
 	SearchProducts(tenantID id.ID, q string, page, limit int) ([]*entity.Product, error)
 	ListProducts(tenantID id.ID, page, limit int) ([]*entity.Product, error)
-	CreateProduct(tenantID id.ID,
+	CreateProduct(actor entity.AuditActor,
+		tenantID id.ID,
 		extName string,
 		title string,
 		ctype string,
@@ -50,9 +57,13 @@ type UseCase interface {
 		format entity.ProductFormat,
 		isAutoApprove bool,
 	) (id.ID, error)
-	UpdateProduct(e *entity.Product) error
-	DeleteProduct(id id.ID) error
+	UpdateProduct(actor entity.AuditActor, e *entity.Product) error
+	DeleteProduct(actor entity.AuditActor, tenantID id.ID, productID id.ID) error
 	GetCount(id id.ID) int
-	UpsertProduct(e *entity.Product) (id.ID, error)
+	UpsertProduct(actor entity.AuditActor, e *entity.Product) (id.ID, error)
+	UpsertProducts(actor entity.AuditActor, tenantID id.ID, products []*entity.Product) ([]id.ID, error)
 	GetIDByExtID(tenantID id.ID, extID string) (id.ID, error)
+	GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error)
+	ArchiveProductByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error)
+	RestoreProductByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error)
 }
@@ -36,18 +36,18 @@ func (m *MockReader) EXPECT() *MockReaderMockRecorder {
 }
 
 // Get mocks base method.
-func (m *MockReader) Get(id id.ID) (*entity.Product, error) {
+func (m *MockReader) Get(tenantID, productID id.ID) (*entity.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, productID)
 	ret0, _ := ret[0].(*entity.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockReaderMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockReaderMockRecorder) Get(tenantID, productID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, productID)
 }
 
 // GetByExtID mocks base method.
@@ -80,6 +80,21 @@ func (mr *MockReaderMockRecorder) GetCount(tenantID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockReader)(nil).GetCount), tenantID)
 }
 
+// GetIDsByExtIDs mocks base method.
+func (m *MockReader) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByExtIDs", tenantID, extIDs)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByExtIDs indicates an expected call of GetIDsByExtIDs.
+func (mr *MockReaderMockRecorder) GetIDsByExtIDs(tenantID, extIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByExtIDs", reflect.TypeOf((*MockReader)(nil).GetIDsByExtIDs), tenantID, extIDs)
+}
+
 // List mocks base method.
 func (m *MockReader) List(tenantID id.ID, page, limit int) ([]*entity.Product, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +148,21 @@ func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
 	return m.recorder
 }
 
+// BulkUpsert mocks base method.
+func (m *MockWriter) BulkUpsert(tenantID id.ID, products []*entity.Product) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsert", tenantID, products)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpsert indicates an expected call of BulkUpsert.
+func (mr *MockWriterMockRecorder) BulkUpsert(tenantID, products interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockWriter)(nil).BulkUpsert), tenantID, products)
+}
+
 // Create mocks base method.
 func (m *MockWriter) Create(product *entity.Product) (id.ID, error) {
 	m.ctrl.T.Helper()
@@ -149,17 +179,31 @@ func (mr *MockWriterMockRecorder) Create(product interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockWriter) Delete(id id.ID) error {
+func (m *MockWriter) Delete(tenantID, productID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, productID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockWriterMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockWriterMockRecorder) Delete(tenantID, productID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), tenantID, productID)
+}
+
+// SetDeleted mocks base method.
+func (m *MockWriter) SetDeleted(tenantID, productID id.ID, isDeleted bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeleted", tenantID, productID, isDeleted)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeleted indicates an expected call of SetDeleted.
+func (mr *MockWriterMockRecorder) SetDeleted(tenantID, productID, isDeleted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeleted", reflect.TypeOf((*MockWriter)(nil).SetDeleted), tenantID, productID, isDeleted)
 }
 
 // Update mocks base method.
@@ -214,6 +258,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BulkUpsert mocks base method.
+func (m *MockRepository) BulkUpsert(tenantID id.ID, products []*entity.Product) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsert", tenantID, products)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpsert indicates an expected call of BulkUpsert.
+func (mr *MockRepositoryMockRecorder) BulkUpsert(tenantID, products interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockRepository)(nil).BulkUpsert), tenantID, products)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(product *entity.Product) (id.ID, error) {
 	m.ctrl.T.Helper()
@@ -230,32 +289,32 @@ func (mr *MockRepositoryMockRecorder) Create(product interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockRepository) Delete(id id.ID) error {
+func (m *MockRepository) Delete(tenantID, productID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", tenantID, productID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(tenantID, productID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), tenantID, productID)
 }
 
 // Get mocks base method.
-func (m *MockRepository) Get(id id.ID) (*entity.Product, error) {
+func (m *MockRepository) Get(tenantID, productID id.ID) (*entity.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", id)
+	ret := m.ctrl.Call(m, "Get", tenantID, productID)
 	ret0, _ := ret[0].(*entity.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Get(tenantID, productID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, productID)
 }
 
 // GetByExtID mocks base method.
@@ -288,6 +347,21 @@ func (mr *MockRepositoryMockRecorder) GetCount(tenantID interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRepository)(nil).GetCount), tenantID)
 }
 
+// GetIDsByExtIDs mocks base method.
+func (m *MockRepository) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByExtIDs", tenantID, extIDs)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByExtIDs indicates an expected call of GetIDsByExtIDs.
+func (mr *MockRepositoryMockRecorder) GetIDsByExtIDs(tenantID, extIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByExtIDs", reflect.TypeOf((*MockRepository)(nil).GetIDsByExtIDs), tenantID, extIDs)
+}
+
 // List mocks base method.
 func (m *MockRepository) List(tenantID id.ID, page, limit int) ([]*entity.Product, error) {
 	m.ctrl.T.Helper()
@@ -318,6 +392,20 @@ func (mr *MockRepositoryMockRecorder) Search(tenantID, q, page, limit interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRepository)(nil).Search), tenantID, q, page, limit)
 }
 
+// SetDeleted mocks base method.
+func (m *MockRepository) SetDeleted(tenantID, productID id.ID, isDeleted bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeleted", tenantID, productID, isDeleted)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeleted indicates an expected call of SetDeleted.
+func (mr *MockRepositoryMockRecorder) SetDeleted(tenantID, productID, isDeleted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeleted", reflect.TypeOf((*MockRepository)(nil).SetDeleted), tenantID, productID, isDeleted)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(product *entity.Product) error {
 	m.ctrl.T.Helper()
@@ -370,33 +458,48 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ArchiveProductByExtID mocks base method.
+func (m *MockUseCase) ArchiveProductByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveProductByExtID", actor, tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveProductByExtID indicates an expected call of ArchiveProductByExtID.
+func (mr *MockUseCaseMockRecorder) ArchiveProductByExtID(actor, tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveProductByExtID", reflect.TypeOf((*MockUseCase)(nil).ArchiveProductByExtID), actor, tenantID, extID)
+}
+
 // CreateProduct mocks base method.
-func (m *MockUseCase) CreateProduct(tenantID id.ID, extName, title, ctype, baseProductExtID string, durationDays int32, visibility entity.ProductVisibility, maxAttendees int32, format entity.ProductFormat, isAutoApprove bool) (id.ID, error) {
+func (m *MockUseCase) CreateProduct(actor entity.AuditActor, tenantID id.ID, extName, title, ctype, baseProductExtID string, durationDays int32, visibility entity.ProductVisibility, maxAttendees int32, format entity.ProductFormat, isAutoApprove bool) (id.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProduct", tenantID, extName, title, ctype, baseProductExtID, durationDays, visibility, maxAttendees, format, isAutoApprove)
+	ret := m.ctrl.Call(m, "CreateProduct", actor, tenantID, extName, title, ctype, baseProductExtID, durationDays, visibility, maxAttendees, format, isAutoApprove)
 	ret0, _ := ret[0].(id.ID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateProduct indicates an expected call of CreateProduct.
-func (mr *MockUseCaseMockRecorder) CreateProduct(tenantID, extName, title, ctype, baseProductExtID, durationDays, visibility, maxAttendees, format, isAutoApprove interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) CreateProduct(actor, tenantID, extName, title, ctype, baseProductExtID, durationDays, visibility, maxAttendees, format, isAutoApprove interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockUseCase)(nil).CreateProduct), tenantID, extName, title, ctype, baseProductExtID, durationDays, visibility, maxAttendees, format, isAutoApprove)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockUseCase)(nil).CreateProduct), actor, tenantID, extName, title, ctype, baseProductExtID, durationDays, visibility, maxAttendees, format, isAutoApprove)
 }
 
 // DeleteProduct mocks base method.
-func (m *MockUseCase) DeleteProduct(id id.ID) error {
+func (m *MockUseCase) DeleteProduct(actor entity.AuditActor, tenantID, productID id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", id)
+	ret := m.ctrl.Call(m, "DeleteProduct", actor, tenantID, productID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockUseCaseMockRecorder) DeleteProduct(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DeleteProduct(actor, tenantID, productID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockUseCase)(nil).DeleteProduct), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockUseCase)(nil).DeleteProduct), actor, tenantID, productID)
 }
 
 // GetCount mocks base method.
@@ -428,19 +531,34 @@ func (mr *MockUseCaseMockRecorder) GetIDByExtID(tenantID, extID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDByExtID", reflect.TypeOf((*MockUseCase)(nil).GetIDByExtID), tenantID, extID)
 }
 
+// GetIDsByExtIDs mocks base method.
+func (m *MockUseCase) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByExtIDs", tenantID, extIDs)
+	ret0, _ := ret[0].(map[string]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByExtIDs indicates an expected call of GetIDsByExtIDs.
+func (mr *MockUseCaseMockRecorder) GetIDsByExtIDs(tenantID, extIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByExtIDs", reflect.TypeOf((*MockUseCase)(nil).GetIDsByExtIDs), tenantID, extIDs)
+}
+
 // GetProduct mocks base method.
-func (m *MockUseCase) GetProduct(id id.ID) (*entity.Product, error) {
+func (m *MockUseCase) GetProduct(tenantID, productID id.ID) (*entity.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProduct", id)
+	ret := m.ctrl.Call(m, "GetProduct", tenantID, productID)
 	ret0, _ := ret[0].(*entity.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProduct indicates an expected call of GetProduct.
-func (mr *MockUseCaseMockRecorder) GetProduct(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) GetProduct(tenantID, productID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockUseCase)(nil).GetProduct), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockUseCase)(nil).GetProduct), tenantID, productID)
 }
 
 // ListProducts mocks base method.
@@ -458,6 +576,21 @@ func (mr *MockUseCaseMockRecorder) ListProducts(tenantID, page, limit interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockUseCase)(nil).ListProducts), tenantID, page, limit)
 }
 
+// RestoreProductByExtID mocks base method.
+func (m *MockUseCase) RestoreProductByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProductByExtID", actor, tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreProductByExtID indicates an expected call of RestoreProductByExtID.
+func (mr *MockUseCaseMockRecorder) RestoreProductByExtID(actor, tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProductByExtID", reflect.TypeOf((*MockUseCase)(nil).RestoreProductByExtID), actor, tenantID, extID)
+}
+
 // SearchProducts mocks base method.
 func (m *MockUseCase) SearchProducts(tenantID id.ID, q string, page, limit int) ([]*entity.Product, error) {
 	m.ctrl.T.Helper()
@@ -474,30 +607,45 @@ func (mr *MockUseCaseMockRecorder) SearchProducts(tenantID, q, page, limit inter
 }
 
 // UpdateProduct mocks base method.
-func (m *MockUseCase) UpdateProduct(e *entity.Product) error {
+func (m *MockUseCase) UpdateProduct(actor entity.AuditActor, e *entity.Product) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProduct", e)
+	ret := m.ctrl.Call(m, "UpdateProduct", actor, e)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateProduct indicates an expected call of UpdateProduct.
-func (mr *MockUseCaseMockRecorder) UpdateProduct(e interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) UpdateProduct(actor, e interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockUseCase)(nil).UpdateProduct), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockUseCase)(nil).UpdateProduct), actor, e)
 }
 
 // UpsertProduct mocks base method.
-func (m *MockUseCase) UpsertProduct(e *entity.Product) (id.ID, error) {
+func (m *MockUseCase) UpsertProduct(actor entity.AuditActor, e *entity.Product) (id.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertProduct", e)
+	ret := m.ctrl.Call(m, "UpsertProduct", actor, e)
 	ret0, _ := ret[0].(id.ID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpsertProduct indicates an expected call of UpsertProduct.
-func (mr *MockUseCaseMockRecorder) UpsertProduct(e interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) UpsertProduct(actor, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProduct", reflect.TypeOf((*MockUseCase)(nil).UpsertProduct), actor, e)
+}
+
+// UpsertProducts mocks base method.
+func (m *MockUseCase) UpsertProducts(actor entity.AuditActor, tenantID id.ID, products []*entity.Product) ([]id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProducts", actor, tenantID, products)
+	ret0, _ := ret[0].([]id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertProducts indicates an expected call of UpsertProducts.
+func (mr *MockUseCaseMockRecorder) UpsertProducts(actor, tenantID, products interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProduct", reflect.TypeOf((*MockUseCase)(nil).UpsertProduct), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProducts", reflect.TypeOf((*MockUseCase)(nil).UpsertProducts), actor, tenantID, products)
 }
@@ -8,22 +8,26 @@ import (
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
 	l "ac9/glad/pkg/logger"
+	"ac9/glad/usecase/audit"
 )
 
 // Service product usecase
 type Service struct {
-	repo Repository
+	repo  Repository
+	audit audit.Recorder
 }
 
 // NewService create new service
-func NewService(r Repository) *Service {
+func NewService(r Repository, a audit.Recorder) *Service {
 	return &Service{
-		repo: r,
+		repo:  r,
+		audit: a,
 	}
 }
 
 // CreateProduct creates a product
-func (s *Service) CreateProduct(tenantID id.ID,
+func (s *Service) CreateProduct(actor entity.AuditActor,
+	tenantID id.ID,
 	extName string,
 	title string,
 	ctype string,
@@ -49,12 +53,18 @@ func (s *Service) CreateProduct(tenantID id.ID,
 		return id.IDInvalid, err
 	}
 
-	return s.repo.Create(p)
+	productID, err := s.repo.Create(p)
+	if err != nil {
+		return productID, err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditProduct, productID, entity.AuditCreate, nil, p)
+	return productID, nil
 }
 
 // GetProduct retrieves a product
-func (s *Service) GetProduct(id id.ID) (*entity.Product, error) {
-	p, err := s.repo.Get(id)
+func (s *Service) GetProduct(tenantID id.ID, productID id.ID) (*entity.Product, error) {
+	p, err := s.repo.Get(tenantID, productID)
 	if p == nil {
 		return nil, glad.ErrNotFound
 	}
@@ -90,18 +100,26 @@ func (s *Service) ListProducts(tenantID id.ID, page, limit int) ([]*entity.Produ
 }
 
 // UpdateProduct Update a product
-func (s *Service) UpdateProduct(p *entity.Product) error {
+func (s *Service) UpdateProduct(actor entity.AuditActor, p *entity.Product) error {
 	err := p.Validate()
 	if err != nil {
 		return err
 	}
+
+	before, _ := s.repo.Get(p.TenantID, p.ID)
 	p.UpdatedAt = time.Now()
-	return s.repo.Update(p)
+	err = s.repo.Update(p)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, p.TenantID, entity.AuditProduct, p.ID, entity.AuditUpdate, before, p)
+	return nil
 }
 
 // DeleteProduct Delete a product
-func (s *Service) DeleteProduct(id id.ID) error {
-	p, err := s.GetProduct(id)
+func (s *Service) DeleteProduct(actor entity.AuditActor, tenantID id.ID, productID id.ID) error {
+	p, err := s.GetProduct(tenantID, productID)
 	if p == nil {
 		return glad.ErrNotFound
 	}
@@ -109,7 +127,13 @@ func (s *Service) DeleteProduct(id id.ID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	err = s.repo.Delete(tenantID, productID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditProduct, productID, entity.AuditDelete, p, nil)
+	return nil
 }
 
 // GetCount gets total product count
@@ -123,7 +147,7 @@ func (s *Service) GetCount(tenantID id.ID) int {
 }
 
 // UpsertProduct upserts a product
-func (s *Service) UpsertProduct(p *entity.Product) (id.ID, error) {
+func (s *Service) UpsertProduct(actor entity.AuditActor, p *entity.Product) (id.ID, error) {
 	if p.ID == id.IDInvalid {
 		// assign id and during update id should not be overwritten
 		p.ID = id.New()
@@ -135,7 +159,88 @@ func (s *Service) UpsertProduct(p *entity.Product) (id.ID, error) {
 		l.Log.Warnf("err=%v", err)
 		return id.IDInvalid, err
 	}
-	return s.repo.Upsert(p)
+
+	var before *entity.Product
+	if p.ExtID != "" {
+		before, _ = s.repo.GetByExtID(p.TenantID, p.ExtID)
+	}
+	productID, err := s.repo.Upsert(p)
+	if err != nil {
+		return productID, err
+	}
+
+	s.audit.Record(actor, p.TenantID, entity.AuditProduct, productID, entity.AuditUpsert, before, p)
+	return productID, nil
+}
+
+// UpsertProducts upserts the tenant's products imported in bulk
+// The returned ids follow the order of products; a product that could not be
+// upserted gets id.IDInvalid. When the batch is rejected as a whole, e.g. on a
+// constraint violation, the products are upserted one by one so that a single
+// bad record does not fail the others.
+func (s *Service) UpsertProducts(actor entity.AuditActor,
+	tenantID id.ID,
+	products []*entity.Product,
+) ([]id.ID, error) {
+	ids := make([]id.ID, len(products))
+	valid := make([]bool, len(products))
+
+	// the last record wins when an external id repeats within the batch
+	last := make(map[string]int, len(products))
+	for i, p := range products {
+		p.TenantID = tenantID
+		if p.ID == id.IDInvalid {
+			p.ID = id.New()
+		}
+
+		err := p.Validate()
+		if err == nil && p.ExtID == "" {
+			err = glad.ErrInvalidValue
+		}
+		if err != nil {
+			l.Log.Warnf("index=%v, err=%v", i, err)
+			continue
+		}
+		valid[i] = true
+		last[p.ExtID] = i
+	}
+
+	batch := make([]*entity.Product, 0, len(last))
+	for i, p := range products {
+		if j, ok := last[p.ExtID]; ok && j == i {
+			batch = append(batch, p)
+		}
+	}
+	if len(batch) == 0 {
+		return ids, nil
+	}
+
+	byExtID, err := s.repo.BulkUpsert(tenantID, batch)
+	if err != nil {
+		l.Log.Warnf("Bulk upsert failed, upserting one by one. err=%v", err)
+		byExtID = make(map[string]id.ID, len(batch))
+		for _, p := range batch {
+			productID, err := s.UpsertProduct(actor, p)
+			if err != nil {
+				l.Log.Warnf("extID=%v, err=%v", p.ExtID, err)
+				continue
+			}
+			byExtID[p.ExtID] = productID
+		}
+	} else {
+		// Note: the state before the upsert is not looked up for bulk imports
+		for _, p := range batch {
+			s.audit.Record(actor, tenantID, entity.AuditProduct, byExtID[p.ExtID],
+				entity.AuditUpsert, nil, p)
+		}
+	}
+
+	for i, p := range products {
+		if valid[i] {
+			ids[i] = byExtID[p.ExtID]
+		}
+	}
+	return ids, nil
 }
 
 // GetIDByExtID gets product id using external id
@@ -152,3 +257,67 @@ func (s *Service) GetIDByExtID(tenantID id.ID, extID string) (id.ID, error) {
 
 	return p.ID, nil
 }
+
+// GetIDsByExtIDs gets product ids using external ids in one lookup
+// Unknown external ids are absent from the result.
+func (s *Service) GetIDsByExtIDs(tenantID id.ID, extIDs []string) (map[string]id.ID, error) {
+	if len(extIDs) == 0 {
+		return map[string]id.ID{}, nil
+	}
+
+	ids, err := s.repo.GetIDsByExtIDs(tenantID, extIDs)
+	if err != nil {
+		l.Log.Warnf("tenantID=%v, err=%v", tenantID, err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ArchiveProductByExtID marks the product deleted in Salesforce as deleted
+// Deleted products are no longer listed; courses keep referring to them.
+func (s *Service) ArchiveProductByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+) (id.ID, error) {
+	return s.setDeletedByExtID(actor, tenantID, extID, true)
+}
+
+// RestoreProductByExtID restores the product undeleted in Salesforce
+func (s *Service) RestoreProductByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+) (id.ID, error) {
+	return s.setDeletedByExtID(actor, tenantID, extID, false)
+}
+
+// setDeletedByExtID sets the deletion mark of the product; no-op when unchanged
+func (s *Service) setDeletedByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+	isDeleted bool,
+) (id.ID, error) {
+	if extID == "" {
+		return id.IDInvalid, glad.ErrInvalidValue
+	}
+
+	p, err := s.repo.GetByExtID(tenantID, extID)
+	if p == nil {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+	if err != nil {
+		return id.IDInvalid, err
+	}
+	if p.IsDeleted == isDeleted {
+		return p.ID, nil
+	}
+
+	err = s.repo.SetDeleted(tenantID, p.ID, isDeleted)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	updated := *p
+	updated.IsDeleted = isDeleted
+	s.audit.Record(actor, tenantID, entity.AuditProduct, p.ID, entity.AuditUpdate, p, &updated)
+	return p.ID, nil
+}
@@ -7,6 +7,7 @@ import (
 	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
+	"ac9/glad/usecase/audit"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -14,10 +15,14 @@ import (
 const (
 	productDefault id.ID = 13790493495087071234
 	tenantAlice    id.ID = 13790492210917015554
+	tenantBob      id.ID = 13790492210917015555
 	aliceExtID           = "000aliceExtID"
 	bobExtID             = "000bobExtID"
 )
 
+// testActor caller making the changes
+var testActor = entity.AuditActor{Source: entity.AuditSourceAPI}
+
 func newFixtureProduct() *entity.Product {
 	return &entity.Product{
 		ID:               productDefault,
@@ -36,9 +41,10 @@ func newFixtureProduct() *entity.Product {
 
 func Test_CreateProduct(t *testing.T) {
 	repo := NewInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	tmpl := newFixtureProduct()
 	_, err := m.CreateProduct(
+		testActor,
 		tmpl.TenantID,
 		tmpl.ExtName,
 		tmpl.Title,
@@ -57,13 +63,14 @@ func Test_CreateProduct(t *testing.T) {
 // TODO: Add test cases for page and limit
 func Test_SearchAndFind(t *testing.T) {
 	repo := NewInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	tmpl1 := newFixtureProduct()
 	tmpl2 := newFixtureProduct()
 	tmpl2.ExtName = "default2"
 	tmpl2.Title = "Default Product 2"
 
 	_, _ = m.CreateProduct(
+		testActor,
 		tmpl1.TenantID,
 		tmpl1.ExtName,
 		tmpl1.Title,
@@ -77,6 +84,7 @@ func Test_SearchAndFind(t *testing.T) {
 	)
 
 	tID, _ := m.CreateProduct(
+		testActor,
 		tmpl2.TenantID,
 		tmpl2.ExtName,
 		tmpl2.Title,
@@ -112,7 +120,7 @@ func Test_SearchAndFind(t *testing.T) {
 	})
 
 	t.Run("get", func(t *testing.T) {
-		saved, err := m.GetProduct(tID)
+		saved, err := m.GetProduct(tenantAlice, tID)
 		assert.Nil(t, err)
 		assert.Equal(t, tmpl2.TenantID, saved.TenantID)
 		assert.Equal(t, tmpl2.Format, saved.Format)
@@ -123,9 +131,10 @@ func Test_SearchAndFind(t *testing.T) {
 
 func Test_UpdateProduct(t *testing.T) {
 	repo := NewInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	tmpl := newFixtureProduct()
 	id, err := m.CreateProduct(
+		testActor,
 		tmpl.TenantID,
 		tmpl.ExtName,
 		tmpl.Title,
@@ -139,23 +148,24 @@ func Test_UpdateProduct(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	saved, _ := m.GetProduct(id)
+	saved, _ := m.GetProduct(tenantAlice, id)
 	saved.Format = entity.ProductFormatOnline
-	assert.Nil(t, m.UpdateProduct(saved))
+	assert.Nil(t, m.UpdateProduct(testActor, saved))
 
-	updated, err := m.GetProduct(id)
+	updated, err := m.GetProduct(tenantAlice, id)
 	assert.Nil(t, err)
 	assert.Equal(t, entity.ProductFormatOnline, updated.Format)
 }
 
 func TestDeleteProduct(t *testing.T) {
 	repo := NewInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 
 	tmpl1 := newFixtureProduct()
 	tmpl2 := newFixtureProduct()
 
 	id2, _ := m.CreateProduct(
+		testActor,
 		tmpl2.TenantID,
 		tmpl2.ExtName,
 		tmpl2.Title,
@@ -168,11 +178,97 @@ func TestDeleteProduct(t *testing.T) {
 		tmpl2.IsAutoApprove,
 	)
 
-	err := m.DeleteProduct(tmpl1.ID)
+	err := m.DeleteProduct(testActor, tenantAlice, tmpl1.ID)
 	assert.Equal(t, glad.ErrNotFound, err)
 
-	err = m.DeleteProduct(id2)
+	err = m.DeleteProduct(testActor, tenantAlice, id2)
 	assert.Nil(t, err)
-	_, err = m.GetProduct(id2)
+	_, err = m.GetProduct(tenantAlice, id2)
 	assert.Equal(t, glad.ErrNotFound, err)
 }
+
+func TestTenantIsolation(t *testing.T) {
+	repo := NewInmem()
+	m := NewService(repo, audit.Discard)
+	tmpl := newFixtureProduct()
+	pID, err := m.CreateProduct(
+		testActor,
+		tmpl.TenantID,
+		tmpl.ExtName,
+		tmpl.Title,
+		tmpl.CType,
+		tmpl.BaseProductExtID,
+		tmpl.DurationDays,
+		tmpl.Visibility,
+		tmpl.MaxAttendees,
+		tmpl.Format,
+		tmpl.IsAutoApprove,
+	)
+	assert.Nil(t, err)
+
+	_, err = m.GetProduct(tenantBob, pID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	saved, _ := m.GetProduct(tenantAlice, pID)
+	other := *saved
+	other.TenantID = tenantBob
+	other.Title = "hijacked"
+	assert.Equal(t, glad.ErrNotFound, m.UpdateProduct(testActor, &other))
+
+	err = m.DeleteProduct(testActor, tenantBob, pID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	saved, err = m.GetProduct(tenantAlice, pID)
+	assert.Nil(t, err)
+	assert.Equal(t, tmpl.Title, saved.Title)
+}
+
+func TestArchiveByExtID(t *testing.T) {
+	repo := NewInmem()
+	m := NewService(repo, audit.Discard)
+	tmpl := newFixtureProduct()
+	tmpl.ExtID = aliceExtID
+	pID, err := m.UpsertProduct(testActor, tmpl)
+	assert.Nil(t, err)
+
+	archivedID, err := m.ArchiveProductByExtID(testActor, tenantAlice, aliceExtID)
+	assert.Nil(t, err)
+	assert.Equal(t, pID, archivedID)
+
+	t.Run("not listed", func(t *testing.T) {
+		_, err := m.ListProducts(tenantAlice, 0, 0)
+		assert.Equal(t, glad.ErrNotFound, err)
+		assert.Equal(t, 0, m.GetCount(tenantAlice))
+
+		saved, err := m.GetProduct(tenantAlice, pID)
+		assert.Nil(t, err)
+		assert.True(t, saved.IsDeleted)
+	})
+	t.Run("upsert keeps deletion", func(t *testing.T) {
+		updated := *newFixtureProduct()
+		updated.ExtID = aliceExtID
+		updated.Title = "renamed"
+		_, err := m.UpsertProduct(testActor, &updated)
+		assert.Nil(t, err)
+		saved, _ := m.GetProduct(tenantAlice, pID)
+		assert.True(t, saved.IsDeleted)
+	})
+	t.Run("restore", func(t *testing.T) {
+		restoredID, err := m.RestoreProductByExtID(testActor, tenantAlice, aliceExtID)
+		assert.Nil(t, err)
+		assert.Equal(t, pID, restoredID)
+		all, err := m.ListProducts(tenantAlice, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(all))
+
+		// no-op
+		_, err = m.RestoreProductByExtID(testActor, tenantAlice, aliceExtID)
+		assert.Nil(t, err)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := m.ArchiveProductByExtID(testActor, tenantBob, aliceExtID)
+		assert.Equal(t, glad.ErrNotFound, err)
+		_, err = m.ArchiveProductByExtID(testActor, tenantAlice, "")
+		assert.Equal(t, glad.ErrInvalidValue, err)
+	})
+}
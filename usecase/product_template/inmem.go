@@ -0,0 +1,64 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package product_template
+
+import (
+	"sync"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// inmem in memory repo
+type inmem struct {
+	m        map[id.ID]*entity.ProductTemplate
+	products map[id.ID]id.ID
+	mut      *sync.RWMutex
+}
+
+// newInmem create new repository
+func newInmem() *inmem {
+	return &inmem{
+		m:        map[id.ID]*entity.ProductTemplate{},
+		products: map[id.ID]id.ID{},
+		mut:      &sync.RWMutex{},
+	}
+}
+
+// addProduct adds a product of the tenant; templates can only be set for
+// known products
+func (r *inmem) addProduct(tenantID id.ID, productID id.ID) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.products[productID] = tenantID
+}
+
+// Get gets the template of the product
+func (r *inmem) Get(tenantID id.ID, productID id.ID) (*entity.ProductTemplate, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	t, ok := r.m[productID]
+	if !ok || t.TenantID != tenantID {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// Set sets the template of the product
+func (r *inmem) Set(e *entity.ProductTemplate) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if tenantID, ok := r.products[e.ProductID]; !ok || tenantID != e.TenantID {
+		return glad.ErrNotFound
+	}
+	r.m[e.ProductID] = e
+	return nil
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package product_template
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// Reader product template reader
+type Reader interface {
+	// Get gets the template of the tenant's product; nil when there is none
+	Get(tenantID id.ID, productID id.ID) (*entity.ProductTemplate, error)
+}
+
+// Writer product template writer
+type Writer interface {
+	// Set sets or replaces the template; returns glad.ErrNotFound unless the
+	// product belongs to the tenant
+	Set(e *entity.ProductTemplate) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// UseCase interface
+type UseCase interface {
+	GetProductTemplate(tenantID id.ID, productID id.ID) (*entity.ProductTemplate, error)
+	SetProductTemplate(e *entity.ProductTemplate) error
+	// CreateCourse creates a course of the product at the center, with the
+	// defaults derived from the product, its template and the center; returns
+	// the course and timing ids
+	CreateCourse(actor entity.AuditActor, req *entity.CourseFromProduct) (id.ID, []id.ID, error)
+}
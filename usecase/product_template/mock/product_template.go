@@ -0,0 +1,208 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/product_template/interface.go
+
+// Package mock_product_template is a generated GoMock package.
+package mock_product_template
+
+import (
+	entity "ac9/glad/entity"
+	id "ac9/glad/pkg/id"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReader) Get(tenantID, productID id.ID) (*entity.ProductTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", tenantID, productID)
+	ret0, _ := ret[0].(*entity.ProductTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReaderMockRecorder) Get(tenantID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, productID)
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Set mocks base method.
+func (m *MockWriter) Set(e *entity.ProductTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockWriterMockRecorder) Set(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockWriter)(nil).Set), e)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(tenantID, productID id.ID) (*entity.ProductTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", tenantID, productID)
+	ret0, _ := ret[0].(*entity.ProductTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(tenantID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, productID)
+}
+
+// Set mocks base method.
+func (m *MockRepository) Set(e *entity.ProductTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRepositoryMockRecorder) Set(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRepository)(nil).Set), e)
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateCourse mocks base method.
+func (m *MockUseCase) CreateCourse(actor entity.AuditActor, req *entity.CourseFromProduct) (id.ID, []id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCourse", actor, req)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].([]id.ID)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateCourse indicates an expected call of CreateCourse.
+func (mr *MockUseCaseMockRecorder) CreateCourse(actor, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourse", reflect.TypeOf((*MockUseCase)(nil).CreateCourse), actor, req)
+}
+
+// GetProductTemplate mocks base method.
+func (m *MockUseCase) GetProductTemplate(tenantID, productID id.ID) (*entity.ProductTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductTemplate", tenantID, productID)
+	ret0, _ := ret[0].(*entity.ProductTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductTemplate indicates an expected call of GetProductTemplate.
+func (mr *MockUseCaseMockRecorder) GetProductTemplate(tenantID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductTemplate", reflect.TypeOf((*MockUseCase)(nil).GetProductTemplate), tenantID, productID)
+}
+
+// SetProductTemplate mocks base method.
+func (m *MockUseCase) SetProductTemplate(e *entity.ProductTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductTemplate", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProductTemplate indicates an expected call of SetProductTemplate.
+func (mr *MockUseCaseMockRecorder) SetProductTemplate(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductTemplate", reflect.TypeOf((*MockUseCase)(nil).SetProductTemplate), e)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package product_template
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/usecase/center"
+	"ac9/glad/usecase/course"
+	"ac9/glad/usecase/product"
+)
+
+// Service product template usecase
+type Service struct {
+	repo     Repository
+	courses  course.UseCase
+	products product.UseCase
+	centers  center.UseCase
+}
+
+// NewService create new service
+func NewService(r Repository,
+	courses course.UseCase,
+	products product.UseCase,
+	centers center.UseCase,
+) *Service {
+	return &Service{
+		repo:     r,
+		courses:  courses,
+		products: products,
+		centers:  centers,
+	}
+}
+
+// GetProductTemplate gets the template of the product
+func (s *Service) GetProductTemplate(tenantID id.ID, productID id.ID) (*entity.ProductTemplate, error) {
+	t, err := s.repo.Get(tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, glad.ErrNotFound
+	}
+	return t, nil
+}
+
+// SetProductTemplate sets the template of the product
+func (s *Service) SetProductTemplate(e *entity.ProductTemplate) error {
+	err := e.Normalize()
+	if err != nil {
+		return glad.ErrInvalidEntity
+	}
+	return s.repo.Set(e)
+}
+
+// CreateCourse creates a course of the product at the center
+func (s *Service) CreateCourse(actor entity.AuditActor,
+	req *entity.CourseFromProduct,
+) (id.ID, []id.ID, error) {
+	p, err := s.products.GetProduct(req.TenantID, req.ProductID)
+	if err != nil {
+		return id.IDInvalid, nil, err
+	}
+	if p.IsDeleted {
+		return id.IDInvalid, nil, glad.ErrNotFound
+	}
+
+	c, err := s.centers.GetCenter(req.TenantID, req.CenterID)
+	if err == glad.ErrNotFound {
+		// Note: The product exists; a missing center is a bad request
+		return id.IDInvalid, nil, glad.ErrInvalidEntity
+	}
+	if err != nil {
+		return id.IDInvalid, nil, err
+	}
+
+	t, err := s.repo.Get(req.TenantID, req.ProductID)
+	if err != nil {
+		return id.IDInvalid, nil, err
+	}
+
+	newCourse, timings, err := entity.NewCourseFromProduct(req, p, t, c)
+	if err != nil {
+		l.Log.Warnf("Unable to derive course product id=%v, center id=%v, err=%v",
+			req.ProductID, req.CenterID, err)
+		return id.IDInvalid, nil, err
+	}
+
+	return s.courses.CreateCourse(actor, *newCourse, req.Organizers, req.Teachers, nil, nil, timings)
+}
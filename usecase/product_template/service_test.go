@@ -0,0 +1,239 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package product_template
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+	centermock "ac9/glad/usecase/center/mock"
+	coursemock "ac9/glad/usecase/course/mock"
+	productmock "ac9/glad/usecase/product/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice    id.ID = 13790492210917015554
+	tenantBob      id.ID = 13790492210917015555
+	aliceProductID id.ID = 13790493495087075501
+	aliceCenterID  id.ID = 13790493495087075601
+	aliceCourseID  id.ID = 13790493495087071234
+
+	aliceTeacherID id.ID = 100000010
+)
+
+// testActor caller making the changes
+var testActor = entity.AuditActor{Source: entity.AuditSourceAPI}
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+type fixture struct {
+	courses  *coursemock.MockUseCase
+	products *productmock.MockUseCase
+	centers  *centermock.MockUseCase
+}
+
+func newFixtureService(t *testing.T) (*Service, *inmem, *fixture) {
+	controller := gomock.NewController(t)
+	t.Cleanup(controller.Finish)
+
+	repo := newInmem()
+	repo.addProduct(tenantAlice, aliceProductID)
+	f := &fixture{
+		courses:  coursemock.NewMockUseCase(controller),
+		products: productmock.NewMockUseCase(controller),
+		centers:  centermock.NewMockUseCase(controller),
+	}
+	return NewService(repo, f.courses, f.products, f.centers), repo, f
+}
+
+func newProduct() *entity.Product {
+	return &entity.Product{
+		ID:            aliceProductID,
+		TenantID:      tenantAlice,
+		Title:         "Happiness Program",
+		DurationDays:  3,
+		MaxAttendees:  40,
+		Format:        entity.ProductFormatInPerson,
+		IsAutoApprove: true,
+	}
+}
+
+func newCenter(capacity int32) *entity.Center {
+	return &entity.Center{
+		ID:       aliceCenterID,
+		TenantID: tenantAlice,
+		Name:     "Wonderland",
+		Address: entity.CenterAddress{
+			Street1: "1 Rabbit Hole",
+			City:    "Oxford",
+			State:   "OX",
+			Zip:     "OX1",
+			Country: "UK",
+		},
+		Capacity: capacity,
+		Timezone: "Europe/London",
+	}
+}
+
+func Test_ProductTemplate(t *testing.T) {
+	m, _, _ := newFixtureService(t)
+
+	_, err := m.GetProductTemplate(tenantAlice, aliceProductID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	tmpl, err := entity.NewProductTemplate(tenantAlice, aliceProductID, "9:30", "12:00", "Bring a mat")
+	assert.Nil(t, err)
+	assert.Equal(t, "09:30:00", tmpl.StartTime)
+	assert.Nil(t, m.SetProductTemplate(tmpl))
+
+	saved, err := m.GetProductTemplate(tenantAlice, aliceProductID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bring a mat", saved.Notes)
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := entity.NewProductTemplate(tenantAlice, aliceProductID, "12:00", "09:30", "")
+		assert.Equal(t, glad.ErrInvalidEntity, err)
+
+		err = m.SetProductTemplate(&entity.ProductTemplate{
+			TenantID:  tenantAlice,
+			ProductID: aliceProductID,
+			StartTime: "25:00",
+			EndTime:   "26:00",
+		})
+		assert.Equal(t, glad.ErrInvalidEntity, err)
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		_, err := m.GetProductTemplate(tenantBob, aliceProductID)
+		assert.Equal(t, glad.ErrNotFound, err)
+
+		other := *tmpl
+		other.TenantID = tenantBob
+		assert.Equal(t, glad.ErrNotFound, m.SetProductTemplate(&other))
+	})
+}
+
+func Test_CreateCourse(t *testing.T) {
+	m, _, f := newFixtureService(t)
+
+	tmpl, _ := entity.NewProductTemplate(tenantAlice, aliceProductID, "09:30", "12:00", "Bring a mat")
+	assert.Nil(t, m.SetProductTemplate(tmpl))
+
+	req := &entity.CourseFromProduct{
+		TenantID:  tenantAlice,
+		ProductID: aliceProductID,
+		CenterID:  aliceCenterID,
+		StartDate: "2024-05-14",
+		Teachers:  []*entity.CourseTeacher{{ID: aliceTeacherID, IsPrimary: true}},
+	}
+
+	f.products.EXPECT().GetProduct(tenantAlice, aliceProductID).Return(newProduct(), nil)
+	f.centers.EXPECT().GetCenter(tenantAlice, aliceCenterID).Return(newCenter(30), nil)
+	f.courses.EXPECT().
+		CreateCourse(testActor, gomock.Any(), gomock.Nil(), req.Teachers, gomock.Nil(), gomock.Nil(), gomock.Any()).
+		DoAndReturn(func(_ entity.AuditActor,
+			c entity.Course,
+			_ []*entity.CourseOrganizer,
+			_ []*entity.CourseTeacher,
+			_ []*entity.CourseContact,
+			_ []*entity.CourseNotify,
+			timings []*entity.CourseTiming,
+		) (id.ID, []id.ID, error) {
+			assert.Equal(t, "Happiness Program", c.Name)
+			assert.Equal(t, "Bring a mat", c.Notes)
+			assert.Equal(t, "Europe/London", c.Timezone)
+			assert.Equal(t, "Oxford", c.Address.City)
+			assert.Equal(t, entity.CourseInPerson, c.Mode)
+			assert.Equal(t, entity.CourseActive, c.Status)
+			// capped by the center capacity
+			assert.Equal(t, int32(30), c.MaxAttendees)
+
+			assert.Equal(t, 3, len(timings))
+			assert.Equal(t, "2024-05-16", timings[2].DateTime.Date)
+			assert.Equal(t, "09:30:00", timings[2].DateTime.StartTime)
+			assert.Equal(t, "12:00:00", timings[2].DateTime.EndTime)
+			return aliceCourseID, []id.ID{id.New(), id.New(), id.New()}, nil
+		})
+
+	courseID, timingIDs, err := m.CreateCourse(testActor, req)
+	assert.Nil(t, err)
+	assert.Equal(t, aliceCourseID, courseID)
+	assert.Equal(t, 3, len(timingIDs))
+
+	t.Run("overrides", func(t *testing.T) {
+		p := newProduct()
+		p.Format = entity.ProductFormatOnline
+		p.IsAutoApprove = false
+		p.DurationDays = 0
+		f.products.EXPECT().GetProduct(tenantAlice, aliceProductID).Return(p, nil)
+		f.centers.EXPECT().GetCenter(tenantAlice, aliceCenterID).Return(newCenter(0), nil)
+		f.courses.EXPECT().
+			CreateCourse(testActor, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ entity.AuditActor,
+				c entity.Course,
+				_ []*entity.CourseOrganizer,
+				_ []*entity.CourseTeacher,
+				_ []*entity.CourseContact,
+				_ []*entity.CourseNotify,
+				timings []*entity.CourseTiming,
+			) (id.ID, []id.ID, error) {
+				assert.Equal(t, "Evening batch", c.Name)
+				assert.Equal(t, entity.CourseOnline, c.Mode)
+				assert.Equal(t, entity.CourseSubmitted, c.Status)
+				assert.Equal(t, int32(40), c.MaxAttendees)
+				assert.Equal(t, 1, len(timings))
+				assert.Equal(t, "18:00:00", timings[0].DateTime.StartTime)
+				return aliceCourseID, []id.ID{id.New()}, nil
+			})
+
+		override := *req
+		override.Name = "Evening batch"
+		override.StartTime = "18:00"
+		override.EndTime = "20:00"
+		_, _, err := m.CreateCourse(testActor, &override)
+		assert.Nil(t, err)
+	})
+
+	t.Run("no session times", func(t *testing.T) {
+		f.products.EXPECT().GetProduct(tenantBob, aliceProductID).Return(newProduct(), nil)
+		f.centers.EXPECT().GetCenter(tenantBob, aliceCenterID).Return(newCenter(0), nil)
+
+		other := *req
+		other.TenantID = tenantBob
+		_, _, err := m.CreateCourse(testActor, &other)
+		assert.Equal(t, glad.ErrMissingParam, err)
+	})
+
+	t.Run("unknown product", func(t *testing.T) {
+		f.products.EXPECT().GetProduct(tenantAlice, aliceProductID).Return(nil, glad.ErrNotFound)
+		_, _, err := m.CreateCourse(testActor, req)
+		assert.Equal(t, glad.ErrNotFound, err)
+	})
+
+	t.Run("unknown center", func(t *testing.T) {
+		f.products.EXPECT().GetProduct(tenantAlice, aliceProductID).Return(newProduct(), nil)
+		f.centers.EXPECT().GetCenter(tenantAlice, aliceCenterID).Return(nil, glad.ErrNotFound)
+		_, _, err := m.CreateCourse(testActor, req)
+		assert.Equal(t, glad.ErrInvalidEntity, err)
+	})
+}
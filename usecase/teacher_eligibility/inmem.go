@@ -0,0 +1,219 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package teacher_eligibility
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+)
+
+// inmemKey eligibility key
+type inmemKey struct {
+	productID id.ID
+	teacherID id.ID
+}
+
+// inmem in memory repo
+type inmem struct {
+	m map[inmemKey]*entity.TeacherEligibility
+	// tenants tenant of the known products and teachers
+	tenants map[id.ID]id.ID
+	// taught locations of the centers each teacher taught at
+	taught map[id.ID][]entity.CenterGeoLocation
+	mut    *sync.RWMutex
+}
+
+// newInmem create new repository
+func newInmem() *inmem {
+	return &inmem{
+		m:       map[inmemKey]*entity.TeacherEligibility{},
+		tenants: map[id.ID]id.ID{},
+		taught:  map[id.ID][]entity.CenterGeoLocation{},
+		mut:     &sync.RWMutex{},
+	}
+}
+
+// addTenantRecord adds a product or teacher of the tenant
+func (r *inmem) addTenantRecord(tenantID id.ID, recordID id.ID) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.tenants[recordID] = tenantID
+}
+
+// addTaught records that the teacher taught at a center at the location
+func (r *inmem) addTaught(teacherID id.ID, g entity.CenterGeoLocation) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.taught[teacherID] = append(r.taught[teacherID], g)
+}
+
+// isTenantEligibility checks whether the product and the teacher belong to the tenant
+func (r *inmem) isTenantEligibility(tenantID id.ID, productID id.ID, teacherID id.ID) bool {
+	productTenant, ok := r.tenants[productID]
+	if !ok || productTenant != tenantID {
+		return false
+	}
+	teacherTenant, ok := r.tenants[teacherID]
+	return ok && teacherTenant == tenantID
+}
+
+// Get gets the eligibility of the teacher for the product
+func (r *inmem) Get(tenantID id.ID, productID id.ID, teacherID id.ID) (*entity.TeacherEligibility, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	e, ok := r.m[inmemKey{productID, teacherID}]
+	if !ok || !r.isTenantEligibility(tenantID, productID, teacherID) {
+		return nil, glad.ErrNotFound
+	}
+	return e, nil
+}
+
+// GetByExtID gets the eligibility by its Salesforce id
+func (r *inmem) GetByExtID(tenantID id.ID, extID string) (*entity.TeacherEligibility, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	for k, e := range r.m {
+		if e.ExtID != nil && *e.ExtID == extID && r.isTenantEligibility(tenantID, k.productID, k.teacherID) {
+			return e, nil
+		}
+	}
+	return nil, glad.ErrNotFound
+}
+
+// ListByProduct lists the eligibility of the teachers for the product
+func (r *inmem) ListByProduct(tenantID id.ID,
+	productID id.ID,
+	page, limit int,
+) ([]*entity.TeacherEligibility, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	var list []*entity.TeacherEligibility
+	for k, e := range r.m {
+		if k.productID == productID && r.isTenantEligibility(tenantID, k.productID, k.teacherID) {
+			list = append(list, e)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].TeacherID < list[j].TeacherID
+	})
+
+	if page > 0 && limit > 0 {
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(list) {
+			return []*entity.TeacherEligibility{}, nil
+		}
+		if end > len(list) {
+			end = len(list)
+		}
+		return list[start:end], nil
+	}
+	return list, nil
+}
+
+// ListByTeacher lists the eligibility of the teacher for the products
+func (r *inmem) ListByTeacher(tenantID id.ID, teacherID id.ID) ([]*entity.TeacherEligibility, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	var list []*entity.TeacherEligibility
+	for k, e := range r.m {
+		if k.teacherID == teacherID && r.isTenantEligibility(tenantID, k.productID, k.teacherID) {
+			list = append(list, e)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ProductID < list[j].ProductID
+	})
+	return list, nil
+}
+
+// Nearby lists the teachers eligible for the product who taught at centers
+// within the query radius, nearest first
+func (r *inmem) Nearby(tenantID id.ID,
+	productID id.ID,
+	q *entity.GeoQuery,
+	page, limit int,
+) ([]*entity.EligibleTeacher, error) {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	var teachers []*entity.EligibleTeacher
+	for k, e := range r.m {
+		if k.productID != productID || !r.isTenantEligibility(tenantID, k.productID, k.teacherID) {
+			continue
+		}
+		nearest := math.Inf(1)
+		for _, g := range r.taught[k.teacherID] {
+			nearest = math.Min(nearest, q.DistanceKm(g))
+		}
+		if nearest <= q.RadiusKm {
+			teachers = append(teachers, &entity.EligibleTeacher{TeacherEligibility: e, DistanceKm: nearest})
+		}
+	}
+
+	sort.Slice(teachers, func(i, j int) bool {
+		return teachers[i].DistanceKm < teachers[j].DistanceKm
+	})
+
+	if page > 0 && limit > 0 {
+		start := (page - 1) * limit
+		end := start + limit
+		if start > len(teachers) {
+			return []*entity.EligibleTeacher{}, nil
+		}
+		if end > len(teachers) {
+			end = len(teachers)
+		}
+		return teachers[start:end], nil
+	}
+	return teachers, nil
+}
+
+// Upsert sets the eligibility of the teacher for the product
+func (r *inmem) Upsert(tenantID id.ID, e *entity.TeacherEligibility) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if !r.isTenantEligibility(tenantID, e.ProductID, e.TeacherID) {
+		return glad.ErrNotFound
+	}
+
+	k := inmemKey{e.ProductID, e.TeacherID}
+	if e.ExtID != nil {
+		for other, o := range r.m {
+			if other != k && o.ExtID != nil && *o.ExtID == *e.ExtID {
+				delete(r.m, other)
+			}
+		}
+	}
+	r.m[k] = e
+	return nil
+}
+
+// Delete removes the eligibility of the teacher for the product
+func (r *inmem) Delete(tenantID id.ID, productID id.ID, teacherID id.ID) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	k := inmemKey{productID, teacherID}
+	if _, ok := r.m[k]; !ok || !r.isTenantEligibility(tenantID, productID, teacherID) {
+		return glad.ErrNotFound
+	}
+	delete(r.m, k)
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package teacher_eligibility
+
+import (
+	"ac9/glad/entity"
+	"ac9/glad/pkg/id"
+)
+
+// Reader teacher eligibility reader
+type Reader interface {
+	Get(tenantID id.ID, productID id.ID, teacherID id.ID) (*entity.TeacherEligibility, error)
+	GetByExtID(tenantID id.ID, extID string) (*entity.TeacherEligibility, error)
+	ListByProduct(tenantID id.ID, productID id.ID, page, limit int) ([]*entity.TeacherEligibility, error)
+	ListByTeacher(tenantID id.ID, teacherID id.ID) ([]*entity.TeacherEligibility, error)
+	// Nearby lists the teachers eligible for the product who taught at
+	// centers within the query radius, nearest first
+	Nearby(tenantID id.ID,
+		productID id.ID,
+		q *entity.GeoQuery,
+		page, limit int,
+	) ([]*entity.EligibleTeacher, error)
+}
+
+// Writer teacher eligibility writer
+type Writer interface {
+	// Upsert sets the eligibility of the teacher for the product; returns
+	// glad.ErrNotFound unless both belong to the tenant. An eligibility linked
+	// to the external id for another product or teacher is replaced.
+	Upsert(tenantID id.ID, e *entity.TeacherEligibility) error
+	Delete(tenantID id.ID, productID id.ID, teacherID id.ID) error
+}
+
+// Repository interface
+type Repository interface {
+	Reader
+	Writer
+}
+
+// UseCase interface
+type UseCase interface {
+	GetEligibility(tenantID id.ID, productID id.ID, teacherID id.ID) (*entity.TeacherEligibility, error)
+	ListEligibleTeachers(tenantID id.ID, productID id.ID, page, limit int) ([]*entity.TeacherEligibility, error)
+	ListTeacherEligibility(tenantID id.ID, teacherID id.ID) ([]*entity.TeacherEligibility, error)
+	// GetNearbyTeachers lists the teachers eligible for the product who taught
+	// at centers near the queried point
+	GetNearbyTeachers(tenantID id.ID,
+		productID id.ID,
+		q *entity.GeoQuery,
+		page, limit int,
+	) ([]*entity.EligibleTeacher, error)
+	SetEligibility(actor entity.AuditActor,
+		tenantID id.ID,
+		productID id.ID,
+		teacherID id.ID,
+		et entity.EligibilityType,
+	) error
+	RemoveEligibility(actor entity.AuditActor, tenantID id.ID, productID id.ID, teacherID id.ID) error
+	// UpsertEligibility upserts the eligibility imported from Salesforce
+	UpsertEligibility(actor entity.AuditActor, tenantID id.ID, e *entity.TeacherEligibility) error
+	// RemoveEligibilityByExtID removes the eligibility deleted in Salesforce
+	// and returns the teacher id
+	RemoveEligibilityByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error)
+}
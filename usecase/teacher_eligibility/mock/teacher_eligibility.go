@@ -0,0 +1,428 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/teacher_eligibility/interface.go
+
+// Package mock_teacher_eligibility is a generated GoMock package.
+package mock_teacher_eligibility
+
+import (
+	entity "ac9/glad/entity"
+	id "ac9/glad/pkg/id"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReader) Get(tenantID, productID, teacherID id.ID) (*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", tenantID, productID, teacherID)
+	ret0, _ := ret[0].(*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReaderMockRecorder) Get(tenantID, productID, teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), tenantID, productID, teacherID)
+}
+
+// GetByExtID mocks base method.
+func (m *MockReader) GetByExtID(tenantID id.ID, extID string) (*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", tenantID, extID)
+	ret0, _ := ret[0].(*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockReaderMockRecorder) GetByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockReader)(nil).GetByExtID), tenantID, extID)
+}
+
+// ListByProduct mocks base method.
+func (m *MockReader) ListByProduct(tenantID, productID id.ID, page, limit int) ([]*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByProduct", tenantID, productID, page, limit)
+	ret0, _ := ret[0].([]*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByProduct indicates an expected call of ListByProduct.
+func (mr *MockReaderMockRecorder) ListByProduct(tenantID, productID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByProduct", reflect.TypeOf((*MockReader)(nil).ListByProduct), tenantID, productID, page, limit)
+}
+
+// ListByTeacher mocks base method.
+func (m *MockReader) ListByTeacher(tenantID, teacherID id.ID) ([]*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTeacher", tenantID, teacherID)
+	ret0, _ := ret[0].([]*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTeacher indicates an expected call of ListByTeacher.
+func (mr *MockReaderMockRecorder) ListByTeacher(tenantID, teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTeacher", reflect.TypeOf((*MockReader)(nil).ListByTeacher), tenantID, teacherID)
+}
+
+// Nearby mocks base method.
+func (m *MockReader) Nearby(tenantID, productID id.ID, q *entity.GeoQuery, page, limit int) ([]*entity.EligibleTeacher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Nearby", tenantID, productID, q, page, limit)
+	ret0, _ := ret[0].([]*entity.EligibleTeacher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Nearby indicates an expected call of Nearby.
+func (mr *MockReaderMockRecorder) Nearby(tenantID, productID, q, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nearby", reflect.TypeOf((*MockReader)(nil).Nearby), tenantID, productID, q, page, limit)
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWriter) Delete(tenantID, productID, teacherID id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", tenantID, productID, teacherID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWriterMockRecorder) Delete(tenantID, productID, teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), tenantID, productID, teacherID)
+}
+
+// Upsert mocks base method.
+func (m *MockWriter) Upsert(tenantID id.ID, e *entity.TeacherEligibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", tenantID, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockWriterMockRecorder) Upsert(tenantID, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockWriter)(nil).Upsert), tenantID, e)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(tenantID, productID, teacherID id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", tenantID, productID, teacherID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(tenantID, productID, teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), tenantID, productID, teacherID)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(tenantID, productID, teacherID id.ID) (*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", tenantID, productID, teacherID)
+	ret0, _ := ret[0].(*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(tenantID, productID, teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), tenantID, productID, teacherID)
+}
+
+// GetByExtID mocks base method.
+func (m *MockRepository) GetByExtID(tenantID id.ID, extID string) (*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExtID", tenantID, extID)
+	ret0, _ := ret[0].(*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExtID indicates an expected call of GetByExtID.
+func (mr *MockRepositoryMockRecorder) GetByExtID(tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExtID", reflect.TypeOf((*MockRepository)(nil).GetByExtID), tenantID, extID)
+}
+
+// ListByProduct mocks base method.
+func (m *MockRepository) ListByProduct(tenantID, productID id.ID, page, limit int) ([]*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByProduct", tenantID, productID, page, limit)
+	ret0, _ := ret[0].([]*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByProduct indicates an expected call of ListByProduct.
+func (mr *MockRepositoryMockRecorder) ListByProduct(tenantID, productID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByProduct", reflect.TypeOf((*MockRepository)(nil).ListByProduct), tenantID, productID, page, limit)
+}
+
+// ListByTeacher mocks base method.
+func (m *MockRepository) ListByTeacher(tenantID, teacherID id.ID) ([]*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTeacher", tenantID, teacherID)
+	ret0, _ := ret[0].([]*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTeacher indicates an expected call of ListByTeacher.
+func (mr *MockRepositoryMockRecorder) ListByTeacher(tenantID, teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTeacher", reflect.TypeOf((*MockRepository)(nil).ListByTeacher), tenantID, teacherID)
+}
+
+// Nearby mocks base method.
+func (m *MockRepository) Nearby(tenantID, productID id.ID, q *entity.GeoQuery, page, limit int) ([]*entity.EligibleTeacher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Nearby", tenantID, productID, q, page, limit)
+	ret0, _ := ret[0].([]*entity.EligibleTeacher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Nearby indicates an expected call of Nearby.
+func (mr *MockRepositoryMockRecorder) Nearby(tenantID, productID, q, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nearby", reflect.TypeOf((*MockRepository)(nil).Nearby), tenantID, productID, q, page, limit)
+}
+
+// Upsert mocks base method.
+func (m *MockRepository) Upsert(tenantID id.ID, e *entity.TeacherEligibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", tenantID, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockRepositoryMockRecorder) Upsert(tenantID, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockRepository)(nil).Upsert), tenantID, e)
+}
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetEligibility mocks base method.
+func (m *MockUseCase) GetEligibility(tenantID, productID, teacherID id.ID) (*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEligibility", tenantID, productID, teacherID)
+	ret0, _ := ret[0].(*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEligibility indicates an expected call of GetEligibility.
+func (mr *MockUseCaseMockRecorder) GetEligibility(tenantID, productID, teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEligibility", reflect.TypeOf((*MockUseCase)(nil).GetEligibility), tenantID, productID, teacherID)
+}
+
+// GetNearbyTeachers mocks base method.
+func (m *MockUseCase) GetNearbyTeachers(tenantID, productID id.ID, q *entity.GeoQuery, page, limit int) ([]*entity.EligibleTeacher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearbyTeachers", tenantID, productID, q, page, limit)
+	ret0, _ := ret[0].([]*entity.EligibleTeacher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearbyTeachers indicates an expected call of GetNearbyTeachers.
+func (mr *MockUseCaseMockRecorder) GetNearbyTeachers(tenantID, productID, q, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyTeachers", reflect.TypeOf((*MockUseCase)(nil).GetNearbyTeachers), tenantID, productID, q, page, limit)
+}
+
+// ListEligibleTeachers mocks base method.
+func (m *MockUseCase) ListEligibleTeachers(tenantID, productID id.ID, page, limit int) ([]*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEligibleTeachers", tenantID, productID, page, limit)
+	ret0, _ := ret[0].([]*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEligibleTeachers indicates an expected call of ListEligibleTeachers.
+func (mr *MockUseCaseMockRecorder) ListEligibleTeachers(tenantID, productID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEligibleTeachers", reflect.TypeOf((*MockUseCase)(nil).ListEligibleTeachers), tenantID, productID, page, limit)
+}
+
+// ListTeacherEligibility mocks base method.
+func (m *MockUseCase) ListTeacherEligibility(tenantID, teacherID id.ID) ([]*entity.TeacherEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeacherEligibility", tenantID, teacherID)
+	ret0, _ := ret[0].([]*entity.TeacherEligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeacherEligibility indicates an expected call of ListTeacherEligibility.
+func (mr *MockUseCaseMockRecorder) ListTeacherEligibility(tenantID, teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeacherEligibility", reflect.TypeOf((*MockUseCase)(nil).ListTeacherEligibility), tenantID, teacherID)
+}
+
+// RemoveEligibility mocks base method.
+func (m *MockUseCase) RemoveEligibility(actor entity.AuditActor, tenantID, productID, teacherID id.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveEligibility", actor, tenantID, productID, teacherID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveEligibility indicates an expected call of RemoveEligibility.
+func (mr *MockUseCaseMockRecorder) RemoveEligibility(actor, tenantID, productID, teacherID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEligibility", reflect.TypeOf((*MockUseCase)(nil).RemoveEligibility), actor, tenantID, productID, teacherID)
+}
+
+// RemoveEligibilityByExtID mocks base method.
+func (m *MockUseCase) RemoveEligibilityByExtID(actor entity.AuditActor, tenantID id.ID, extID string) (id.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveEligibilityByExtID", actor, tenantID, extID)
+	ret0, _ := ret[0].(id.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveEligibilityByExtID indicates an expected call of RemoveEligibilityByExtID.
+func (mr *MockUseCaseMockRecorder) RemoveEligibilityByExtID(actor, tenantID, extID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEligibilityByExtID", reflect.TypeOf((*MockUseCase)(nil).RemoveEligibilityByExtID), actor, tenantID, extID)
+}
+
+// SetEligibility mocks base method.
+func (m *MockUseCase) SetEligibility(actor entity.AuditActor, tenantID, productID, teacherID id.ID, et entity.EligibilityType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEligibility", actor, tenantID, productID, teacherID, et)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEligibility indicates an expected call of SetEligibility.
+func (mr *MockUseCaseMockRecorder) SetEligibility(actor, tenantID, productID, teacherID, et interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEligibility", reflect.TypeOf((*MockUseCase)(nil).SetEligibility), actor, tenantID, productID, teacherID, et)
+}
+
+// UpsertEligibility mocks base method.
+func (m *MockUseCase) UpsertEligibility(actor entity.AuditActor, tenantID id.ID, e *entity.TeacherEligibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEligibility", actor, tenantID, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertEligibility indicates an expected call of UpsertEligibility.
+func (mr *MockUseCaseMockRecorder) UpsertEligibility(actor, tenantID, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEligibility", reflect.TypeOf((*MockUseCase)(nil).UpsertEligibility), actor, tenantID, e)
+}
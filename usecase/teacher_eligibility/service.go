@@ -0,0 +1,175 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package teacher_eligibility
+
+import (
+	"time"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	l "ac9/glad/pkg/logger"
+	"ac9/glad/usecase/audit"
+)
+
+// Service teacher eligibility usecase
+type Service struct {
+	repo  Repository
+	audit audit.Recorder
+}
+
+// NewService create new service
+func NewService(r Repository, a audit.Recorder) *Service {
+	return &Service{
+		repo:  r,
+		audit: a,
+	}
+}
+
+// GetEligibility gets the eligibility of the teacher for the product
+func (s *Service) GetEligibility(tenantID id.ID,
+	productID id.ID,
+	teacherID id.ID,
+) (*entity.TeacherEligibility, error) {
+	e, err := s.repo.Get(tenantID, productID, teacherID)
+	if err != nil && err != glad.ErrNotFound {
+		return nil, err
+	}
+	if e == nil {
+		return nil, glad.ErrNotFound
+	}
+	return e, nil
+}
+
+// ListEligibleTeachers lists the teachers eligible for the product
+func (s *Service) ListEligibleTeachers(tenantID id.ID,
+	productID id.ID,
+	page, limit int,
+) ([]*entity.TeacherEligibility, error) {
+	return s.repo.ListByProduct(tenantID, productID, page, limit)
+}
+
+// ListTeacherEligibility lists the products the teacher is eligible for
+func (s *Service) ListTeacherEligibility(tenantID id.ID, teacherID id.ID) ([]*entity.TeacherEligibility, error) {
+	return s.repo.ListByTeacher(tenantID, teacherID)
+}
+
+// GetNearbyTeachers lists the teachers eligible for the product who taught at
+// centers within the query radius, nearest first
+func (s *Service) GetNearbyTeachers(tenantID id.ID,
+	productID id.ID,
+	q *entity.GeoQuery,
+	page, limit int,
+) ([]*entity.EligibleTeacher, error) {
+	err := q.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Nearby(tenantID, productID, q, page, limit)
+}
+
+// SetEligibility sets the eligibility of the teacher for the product
+func (s *Service) SetEligibility(actor entity.AuditActor,
+	tenantID id.ID,
+	productID id.ID,
+	teacherID id.ID,
+	et entity.EligibilityType,
+) error {
+	e, err := entity.NewTeacherEligibility(productID, teacherID, et)
+	if err != nil {
+		return err
+	}
+
+	before, _ := s.repo.Get(tenantID, productID, teacherID)
+	if before != nil {
+		// keep the link to Salesforce
+		e.ExtID = before.ExtID
+		e.CreatedAt = before.CreatedAt
+	}
+	err = s.repo.Upsert(tenantID, e)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditTeacherEligibility, teacherID, entity.AuditUpsert, before, e)
+	return nil
+}
+
+// RemoveEligibility removes the eligibility of the teacher for the product
+func (s *Service) RemoveEligibility(actor entity.AuditActor,
+	tenantID id.ID,
+	productID id.ID,
+	teacherID id.ID,
+) error {
+	before, err := s.GetEligibility(tenantID, productID, teacherID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Delete(tenantID, productID, teacherID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditTeacherEligibility, teacherID, entity.AuditDelete, before, nil)
+	return nil
+}
+
+// UpsertEligibility upserts the eligibility imported from Salesforce
+func (s *Service) UpsertEligibility(actor entity.AuditActor,
+	tenantID id.ID,
+	e *entity.TeacherEligibility,
+) error {
+	if e.ExtID == nil || *e.ExtID == "" {
+		return glad.ErrInvalidValue
+	}
+	err := e.Validate()
+	if err != nil {
+		return err
+	}
+
+	if e.UpdatedAt.IsZero() {
+		e.UpdatedAt = time.Now()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = e.UpdatedAt
+	}
+	err = s.repo.Upsert(tenantID, e)
+	if err != nil {
+		l.Log.Warnf("extID=%v, err=%v", *e.ExtID, err)
+		return err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditTeacherEligibility, e.TeacherID, entity.AuditUpsert, nil, e)
+	return nil
+}
+
+// RemoveEligibilityByExtID removes the eligibility deleted in Salesforce
+func (s *Service) RemoveEligibilityByExtID(actor entity.AuditActor,
+	tenantID id.ID,
+	extID string,
+) (id.ID, error) {
+	if extID == "" {
+		return id.IDInvalid, glad.ErrInvalidValue
+	}
+
+	e, err := s.repo.GetByExtID(tenantID, extID)
+	if err != nil && err != glad.ErrNotFound {
+		return id.IDInvalid, err
+	}
+	if e == nil {
+		return id.IDInvalid, glad.ErrNotFound
+	}
+
+	err = s.repo.Delete(tenantID, e.ProductID, e.TeacherID)
+	if err != nil {
+		return id.IDInvalid, err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditTeacherEligibility, e.TeacherID, entity.AuditDelete, e, nil)
+	return e.TeacherID, nil
+}
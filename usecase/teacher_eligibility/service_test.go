@@ -0,0 +1,191 @@
+/*
+ * Copyright 2024 AboveCloud9.AI Products and Services Private Limited
+ * All rights reserved.
+ * This code may not be used, copied, modified, or distributed without explicit permission.
+ */
+
+package teacher_eligibility
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"ac9/glad/entity"
+	"ac9/glad/pkg/glad"
+	"ac9/glad/pkg/id"
+	"ac9/glad/pkg/logger"
+	"ac9/glad/usecase/audit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	tenantAlice     id.ID = 13790492210917015554
+	aliceProductID  id.ID = 13790492210917015601
+	aliceTeacher1ID id.ID = 13790492210917015701
+	aliceTeacher2ID id.ID = 13790492210917015702
+
+	tenantBob     id.ID = 13790492210917015555
+	bobProductID  id.ID = 13790492210917015611
+	bobTeacher1ID id.ID = 13790492210917015711
+)
+
+// testActor caller making the changes
+var testActor = entity.AuditActor{Source: entity.AuditSourceAPI}
+
+func TestMain(m *testing.M) {
+	// Initialize logger
+	Log := logger.NewLoggerZap()
+	if Log == nil {
+		log.Fatalf("Failed to initialize logger")
+	}
+
+	os.Exit(m.Run())
+}
+
+func newFixtureRepo() *inmem {
+	repo := newInmem()
+	for _, recordID := range []id.ID{aliceProductID, aliceTeacher1ID, aliceTeacher2ID} {
+		repo.addTenantRecord(tenantAlice, recordID)
+	}
+	for _, recordID := range []id.ID{bobProductID, bobTeacher1ID} {
+		repo.addTenantRecord(tenantBob, recordID)
+	}
+	return repo
+}
+
+func TestSetAndRemove(t *testing.T) {
+	m := NewService(newFixtureRepo(), audit.Discard)
+
+	err := m.SetEligibility(testActor, tenantAlice, aliceProductID, aliceTeacher1ID, entity.EligibilityPrimary)
+	assert.Nil(t, err)
+	err = m.SetEligibility(testActor, tenantAlice, aliceProductID, aliceTeacher2ID, entity.EligibilityAssistant)
+	assert.Nil(t, err)
+
+	e, err := m.GetEligibility(tenantAlice, aliceProductID, aliceTeacher2ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.EligibilityAssistant, e.Type)
+
+	// promote the assistant
+	err = m.SetEligibility(testActor, tenantAlice, aliceProductID, aliceTeacher2ID, entity.EligibilityPrimary)
+	assert.Nil(t, err)
+	e, _ = m.GetEligibility(tenantAlice, aliceProductID, aliceTeacher2ID)
+	assert.Equal(t, entity.EligibilityPrimary, e.Type)
+
+	err = m.SetEligibility(testActor, tenantAlice, aliceProductID, aliceTeacher1ID, "instructor")
+	assert.Equal(t, glad.ErrInvalidEntity, err)
+
+	list, err := m.ListEligibleTeachers(tenantAlice, aliceProductID, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(list))
+	list, err = m.ListEligibleTeachers(tenantAlice, aliceProductID, 2, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(list))
+	assert.Equal(t, aliceTeacher2ID, list[0].TeacherID)
+
+	list, err = m.ListTeacherEligibility(tenantAlice, aliceTeacher1ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(list))
+
+	err = m.RemoveEligibility(testActor, tenantAlice, aliceProductID, aliceTeacher1ID)
+	assert.Nil(t, err)
+	_, err = m.GetEligibility(tenantAlice, aliceProductID, aliceTeacher1ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+	err = m.RemoveEligibility(testActor, tenantAlice, aliceProductID, aliceTeacher1ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+}
+
+func TestTenantIsolation(t *testing.T) {
+	m := NewService(newFixtureRepo(), audit.Discard)
+
+	err := m.SetEligibility(testActor, tenantAlice, aliceProductID, aliceTeacher1ID, entity.EligibilityPrimary)
+	assert.Nil(t, err)
+
+	// the product and the teacher must belong to the tenant
+	err = m.SetEligibility(testActor, tenantBob, aliceProductID, bobTeacher1ID, entity.EligibilityPrimary)
+	assert.Equal(t, glad.ErrNotFound, err)
+	err = m.SetEligibility(testActor, tenantAlice, aliceProductID, bobTeacher1ID, entity.EligibilityPrimary)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	_, err = m.GetEligibility(tenantBob, aliceProductID, aliceTeacher1ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+	list, _ := m.ListEligibleTeachers(tenantBob, aliceProductID, 0, 0)
+	assert.Equal(t, 0, len(list))
+	err = m.RemoveEligibility(testActor, tenantBob, aliceProductID, aliceTeacher1ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+}
+
+func TestUpsertByExtID(t *testing.T) {
+	m := NewService(newFixtureRepo(), audit.Discard)
+	extID := "a0E000000000001"
+
+	e := &entity.TeacherEligibility{
+		ProductID: aliceProductID,
+		TeacherID: aliceTeacher1ID,
+		Type:      entity.EligibilityPrimary,
+	}
+	err := m.UpsertEligibility(testActor, tenantAlice, e)
+	assert.Equal(t, glad.ErrInvalidValue, err)
+
+	e.ExtID = &extID
+	err = m.UpsertEligibility(testActor, tenantAlice, e)
+	assert.Nil(t, err)
+	assert.False(t, e.CreatedAt.IsZero())
+
+	// the Salesforce record now refers to another teacher
+	err = m.UpsertEligibility(testActor, tenantAlice, &entity.TeacherEligibility{
+		ProductID: aliceProductID,
+		TeacherID: aliceTeacher2ID,
+		ExtID:     &extID,
+		Type:      entity.EligibilityAssistant,
+	})
+	assert.Nil(t, err)
+	_, err = m.GetEligibility(tenantAlice, aliceProductID, aliceTeacher1ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+
+	// a manual change keeps the link to Salesforce
+	err = m.SetEligibility(testActor, tenantAlice, aliceProductID, aliceTeacher2ID, entity.EligibilityPrimary)
+	assert.Nil(t, err)
+
+	_, err = m.RemoveEligibilityByExtID(testActor, tenantBob, extID)
+	assert.Equal(t, glad.ErrNotFound, err)
+	teacherID, err := m.RemoveEligibilityByExtID(testActor, tenantAlice, extID)
+	assert.Nil(t, err)
+	assert.Equal(t, aliceTeacher2ID, teacherID)
+	_, err = m.GetEligibility(tenantAlice, aliceProductID, aliceTeacher2ID)
+	assert.Equal(t, glad.ErrNotFound, err)
+}
+
+func TestGetNearbyTeachers(t *testing.T) {
+	repo := newFixtureRepo()
+	m := NewService(repo, audit.Discard)
+
+	_ = m.SetEligibility(testActor, tenantAlice, aliceProductID, aliceTeacher1ID, entity.EligibilityPrimary)
+	_ = m.SetEligibility(testActor, tenantAlice, aliceProductID, aliceTeacher2ID, entity.EligibilityAssistant)
+	// teacher 1 taught about 100 km away, teacher 2 at the queried point
+	repo.addTaught(aliceTeacher1ID, entity.CenterGeoLocation{Lat: 12.9, Long: 77.6})
+	repo.addTaught(aliceTeacher2ID, entity.CenterGeoLocation{Lat: 13.9, Long: 77.6})
+	repo.addTaught(aliceTeacher2ID, entity.CenterGeoLocation{Lat: 13.8, Long: 77.6})
+
+	q := &entity.GeoQuery{Lat: 13.8, Long: 77.6, RadiusKm: 150}
+	teachers, err := m.GetNearbyTeachers(tenantAlice, aliceProductID, q, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(teachers))
+	assert.Equal(t, aliceTeacher2ID, teachers[0].TeacherID)
+	assert.InDelta(t, 0, teachers[0].DistanceKm, 0.1)
+	assert.Equal(t, aliceTeacher1ID, teachers[1].TeacherID)
+
+	q.RadiusKm = 50
+	teachers, err = m.GetNearbyTeachers(tenantAlice, aliceProductID, q, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(teachers))
+
+	teachers, err = m.GetNearbyTeachers(tenantBob, aliceProductID, q, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(teachers))
+
+	_, err = m.GetNearbyTeachers(tenantAlice, aliceProductID,
+		&entity.GeoQuery{Lat: 100, Long: 77.6}, 0, 0)
+	assert.Equal(t, glad.ErrInvalidValue, err)
+}
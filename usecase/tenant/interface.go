@@ -36,9 +36,9 @@ type Repository interface {
 type UseCase interface {
 	GetTenant(id id.ID) (*entity.Tenant, error)
 	ListTenants(page, limit int) ([]*entity.Tenant, error)
-	CreateTenant(username, country string) (id.ID, error)
-	UpdateTenant(e *entity.Tenant) error
-	DeleteTenant(id id.ID) error
+	CreateTenant(actor entity.AuditActor, username, country string) (id.ID, error)
+	UpdateTenant(actor entity.AuditActor, e *entity.Tenant) error
+	DeleteTenant(actor entity.AuditActor, id id.ID) error
 	Login(username, password string) (*entity.Tenant, error)
 	GetCount() int
 	// Thoughts: Need to validate token; use tenant id and token to validate
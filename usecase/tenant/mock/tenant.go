@@ -311,32 +311,32 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 }
 
 // CreateTenant mocks base method.
-func (m *MockUseCase) CreateTenant(username, country string) (id.ID, error) {
+func (m *MockUseCase) CreateTenant(actor entity.AuditActor, username, country string) (id.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTenant", username, country)
+	ret := m.ctrl.Call(m, "CreateTenant", actor, username, country)
 	ret0, _ := ret[0].(id.ID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTenant indicates an expected call of CreateTenant.
-func (mr *MockUseCaseMockRecorder) CreateTenant(username, country interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) CreateTenant(actor, username, country interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTenant", reflect.TypeOf((*MockUseCase)(nil).CreateTenant), username, country)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTenant", reflect.TypeOf((*MockUseCase)(nil).CreateTenant), actor, username, country)
 }
 
 // DeleteTenant mocks base method.
-func (m *MockUseCase) DeleteTenant(id id.ID) error {
+func (m *MockUseCase) DeleteTenant(actor entity.AuditActor, id id.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTenant", id)
+	ret := m.ctrl.Call(m, "DeleteTenant", actor, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTenant indicates an expected call of DeleteTenant.
-func (mr *MockUseCaseMockRecorder) DeleteTenant(id interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DeleteTenant(actor, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTenant", reflect.TypeOf((*MockUseCase)(nil).DeleteTenant), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTenant", reflect.TypeOf((*MockUseCase)(nil).DeleteTenant), actor, id)
 }
 
 // GetCount mocks base method.
@@ -399,15 +399,15 @@ func (mr *MockUseCaseMockRecorder) Login(username, password interface{}) *gomock
 }
 
 // UpdateTenant mocks base method.
-func (m *MockUseCase) UpdateTenant(e *entity.Tenant) error {
+func (m *MockUseCase) UpdateTenant(actor entity.AuditActor, e *entity.Tenant) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTenant", e)
+	ret := m.ctrl.Call(m, "UpdateTenant", actor, e)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTenant indicates an expected call of UpdateTenant.
-func (mr *MockUseCaseMockRecorder) UpdateTenant(e interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) UpdateTenant(actor, e interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenant", reflect.TypeOf((*MockUseCase)(nil).UpdateTenant), e)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenant", reflect.TypeOf((*MockUseCase)(nil).UpdateTenant), actor, e)
 }
@@ -12,29 +12,38 @@ import (
 	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
+	"ac9/glad/usecase/audit"
 )
 
 // Service tenant usecase
 type Service struct {
-	repo Repository
+	repo  Repository
+	audit audit.Recorder
 }
 
 // NewService create new service
-func NewService(r Repository) *Service {
+func NewService(r Repository, a audit.Recorder) *Service {
 	return &Service{
-		repo: r,
+		repo:  r,
+		audit: a,
 	}
 }
 
 // CreateTenant create a tenant
-func (s *Service) CreateTenant(name, country string) (id.ID, error) {
+func (s *Service) CreateTenant(actor entity.AuditActor, name, country string) (id.ID, error) {
 	// TODO: Check whether tenant already exists with same name
 
 	t, err := entity.NewTenant(name, country)
 	if err != nil {
 		return t.ID, err
 	}
-	return s.repo.Create(t)
+	tenantID, err := s.repo.Create(t)
+	if err != nil {
+		return tenantID, err
+	}
+
+	s.audit.Record(actor, tenantID, entity.AuditTenant, tenantID, entity.AuditCreate, nil, t)
+	return tenantID, nil
 }
 
 // GetTenant get a tenant
@@ -76,7 +85,7 @@ func (s *Service) ListTenants(page, limit int) ([]*entity.Tenant, error) {
 }
 
 // DeleteTenant Delete a tenant
-func (s *Service) DeleteTenant(id id.ID) error {
+func (s *Service) DeleteTenant(actor entity.AuditActor, id id.ID) error {
 	t, err := s.GetTenant(id)
 	if t == nil {
 		return glad.ErrNotFound
@@ -85,11 +94,19 @@ func (s *Service) DeleteTenant(id id.ID) error {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	err = s.repo.Delete(id)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, id, entity.AuditTenant, id, entity.AuditDelete, t, nil)
+	return nil
 }
 
 // UpdateTenant Update a tenant
-func (s *Service) UpdateTenant(t *entity.Tenant) error {
+func (s *Service) UpdateTenant(actor entity.AuditActor, t *entity.Tenant) error {
+	before, _ := s.repo.Get(t.ID)
+
 	// retrieve and fill in empty values for mandatory fields
 	if t.Country == "" {
 		current, err := s.GetTenant(t.ID)
@@ -111,7 +128,13 @@ func (s *Service) UpdateTenant(t *entity.Tenant) error {
 		return err
 	}
 	t.UpdatedAt = time.Now()
-	return s.repo.Update(t)
+	err = s.repo.Update(t)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(actor, t.ID, entity.AuditTenant, t.ID, entity.AuditUpdate, before, t)
+	return nil
 }
 
 // UNUSED: Login Validates credentials, generates token and update the DB
@@ -133,7 +156,7 @@ func (s *Service) Login(name, password string) (*entity.Tenant, error) {
 	// }
 
 	// Update tenant: store token to database
-	if err = s.UpdateTenant(t); err != nil {
+	if err = s.UpdateTenant(entity.AuditActor{Source: entity.AuditSourceSystem}, t); err != nil {
 		return nil, err
 	}
 
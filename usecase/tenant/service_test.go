@@ -13,6 +13,7 @@ import (
 	"ac9/glad/entity"
 	"ac9/glad/pkg/glad"
 	"ac9/glad/pkg/id"
+	"ac9/glad/usecase/audit"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -21,6 +22,9 @@ const (
 	tenantDefault id.ID = 13790492210917015554
 )
 
+// testActor caller making the changes
+var testActor = entity.AuditActor{Source: entity.AuditSourceAPI}
+
 func newFixtureTenant() *entity.Tenant {
 	return &entity.Tenant{
 		ID:        tenantDefault,
@@ -33,22 +37,22 @@ func newFixtureTenant() *entity.Tenant {
 
 func Test_Create(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	tenant := newFixtureTenant()
-	_, err := m.CreateTenant(tenant.Name, tenant.Country)
+	_, err := m.CreateTenant(testActor, tenant.Name, tenant.Country)
 	assert.Nil(t, err)
 	assert.False(t, tenant.CreatedAt.IsZero())
 }
 
 func Test_SearchAndFind(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	t1 := newFixtureTenant()
 	t2 := newFixtureTenant()
 	t2.Name = "bob@wunder.land"
 
-	tID, _ := m.CreateTenant(t1.Name, t1.Country)
-	_, _ = m.CreateTenant(t2.Name, t2.Country)
+	tID, _ := m.CreateTenant(testActor, t1.Name, t1.Country)
+	_, _ = m.CreateTenant(testActor, t2.Name, t2.Country)
 
 	t.Run("list all", func(t *testing.T) {
 		all, err := m.ListTenants(0, 0)
@@ -68,14 +72,14 @@ func Test_SearchAndFind(t *testing.T) {
 
 func Test_Update(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 	tenant := newFixtureTenant()
-	id, err := m.CreateTenant(tenant.Name, tenant.Country)
+	id, err := m.CreateTenant(testActor, tenant.Name, tenant.Country)
 	assert.Nil(t, err)
 
 	saved, _ := m.GetTenant(id)
 	saved.Country = "testing456"
-	assert.Nil(t, m.UpdateTenant(saved))
+	assert.Nil(t, m.UpdateTenant(testActor, saved))
 
 	updated, err := m.GetTenant(id)
 	assert.Nil(t, err)
@@ -84,16 +88,16 @@ func Test_Update(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 
 	t1 := newFixtureTenant()
 	t2 := newFixtureTenant()
-	t2ID, _ := m.CreateTenant(t2.Name, t2.Country)
+	t2ID, _ := m.CreateTenant(testActor, t2.Name, t2.Country)
 
-	err := m.DeleteTenant(t1.ID)
+	err := m.DeleteTenant(testActor, t1.ID)
 	assert.Equal(t, glad.ErrNotFound, err)
 
-	err = m.DeleteTenant(t2ID)
+	err = m.DeleteTenant(testActor, t2ID)
 	assert.Nil(t, err)
 	_, err = m.GetTenant(t2ID)
 	assert.Equal(t, glad.ErrNotFound, err)
@@ -101,15 +105,15 @@ func TestDelete(t *testing.T) {
 
 func TestLogin(t *testing.T) {
 	repo := newInmem()
-	m := NewService(repo)
+	m := NewService(repo, audit.Discard)
 
 	// pre-requisities
 	t1 := newFixtureTenant()
 	t2 := newFixtureTenant()
 	t2.Name = "bob@wunder.land"
 
-	t1ID, _ := m.CreateTenant(t1.Name, t1.Country)
-	_, _ = m.CreateTenant(t2.Name, t2.Country)
+	t1ID, _ := m.CreateTenant(testActor, t1.Name, t1.Country)
+	_, _ = m.CreateTenant(testActor, t2.Name, t2.Country)
 
 	// test
 	t.Run("valid credentials", func(t *testing.T) {